@@ -0,0 +1,16 @@
+/*
+ * Connector Service Fleet Manager Admin APIs
+ *
+ * Connector Service Fleet Manager Admin is a Rest API to manage connector clusters.
+ *
+ * API version: 0.0.3
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package private
+
+// ConnectorReconcileSettings The settings of the connector reconcile, shared by all the fleet manager instances
+type ConnectorReconcileSettings struct {
+	// The connectors are not reconciled while paused, e.g. during a data plane incident
+	Paused bool `json:"paused,omitempty"`
+}
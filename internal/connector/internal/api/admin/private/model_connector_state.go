@@ -14,14 +14,16 @@ type ConnectorState string
 
 // List of ConnectorState
 const (
-	CONNECTORSTATE_ASSIGNING      ConnectorState = "assigning"
-	CONNECTORSTATE_ASSIGNED       ConnectorState = "assigned"
-	CONNECTORSTATE_UPDATING       ConnectorState = "updating"
-	CONNECTORSTATE_READY          ConnectorState = "ready"
-	CONNECTORSTATE_STOPPED        ConnectorState = "stopped"
-	CONNECTORSTATE_FAILED         ConnectorState = "failed"
-	CONNECTORSTATE_DELETING       ConnectorState = "deleting"
-	CONNECTORSTATE_DELETED        ConnectorState = "deleted"
-	CONNECTORSTATE_PROVISIONING   ConnectorState = "provisioning"
-	CONNECTORSTATE_DEPROVISIONING ConnectorState = "deprovisioning"
+	CONNECTORSTATE_ASSIGNING           ConnectorState = "assigning"
+	CONNECTORSTATE_ASSIGNED            ConnectorState = "assigned"
+	CONNECTORSTATE_UPDATING            ConnectorState = "updating"
+	CONNECTORSTATE_READY               ConnectorState = "ready"
+	CONNECTORSTATE_STOPPED             ConnectorState = "stopped"
+	CONNECTORSTATE_FAILED              ConnectorState = "failed"
+	CONNECTORSTATE_DELETING            ConnectorState = "deleting"
+	CONNECTORSTATE_DELETED             ConnectorState = "deleted"
+	CONNECTORSTATE_PROVISIONING        ConnectorState = "provisioning"
+	CONNECTORSTATE_DEPROVISIONING      ConnectorState = "deprovisioning"
+	CONNECTORSTATE_UPGRADE_FAILED      ConnectorState = "upgrade_failed"
+	CONNECTORSTATE_CLUSTER_UNREACHABLE ConnectorState = "cluster_unreachable"
 )
@@ -0,0 +1,18 @@
+/*
+ * Connector Service Fleet Manager Admin APIs
+ *
+ * Connector Service Fleet Manager Admin is a Rest API to manage connector clusters.
+ *
+ * API version: 0.0.3
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package private
+
+// ConnectorTypeRedeployment The deployments of a connector type and channel forced to be redeployed
+type ConnectorTypeRedeployment struct {
+	ConnectorTypeId string `json:"connector_type_id,omitempty"`
+	Channel         string `json:"channel,omitempty"`
+	// The number of deployments to be redeployed
+	Deployments int64 `json:"deployments,omitempty"`
+}
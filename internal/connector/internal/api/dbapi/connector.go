@@ -1,6 +1,8 @@
 package dbapi
 
 import (
+	"time"
+
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
 )
@@ -14,16 +16,18 @@ const (
 	ConnectorStopped    ConnectorDesiredState = "stopped"
 	ConnectorDeleted    ConnectorDesiredState = "deleted"
 
-	ConnectorStatusPhaseAssigning      ConnectorStatusPhase = "assigning"      // set by kas-fleet-manager - user request
-	ConnectorStatusPhaseAssigned       ConnectorStatusPhase = "assigned"       // set by kas-fleet-manager - worker
-	ConnectorStatusPhaseUpdating       ConnectorStatusPhase = "updating"       // set by kas-fleet-manager - user request
-	ConnectorStatusPhaseStopped        ConnectorStatusPhase = "stopped"        // set by kas-fleet-manager - user request
-	ConnectorStatusPhaseProvisioning   ConnectorStatusPhase = "provisioning"   // set by kas-agent
-	ConnectorStatusPhaseReady          ConnectorStatusPhase = "ready"          // set by the agent
-	ConnectorStatusPhaseFailed         ConnectorStatusPhase = "failed"         // set by the agent
-	ConnectorStatusPhaseDeprovisioning ConnectorStatusPhase = "deprovisioning" // set by kas-agent
-	ConnectorStatusPhaseDeleting       ConnectorStatusPhase = "deleting"       // set by the kas-fleet-manager - user request
-	ConnectorStatusPhaseDeleted        ConnectorStatusPhase = "deleted"        // set by the agent
+	ConnectorStatusPhaseAssigning          ConnectorStatusPhase = "assigning"           // set by kas-fleet-manager - user request
+	ConnectorStatusPhaseAssigned           ConnectorStatusPhase = "assigned"            // set by kas-fleet-manager - worker
+	ConnectorStatusPhaseUpdating           ConnectorStatusPhase = "updating"            // set by kas-fleet-manager - user request
+	ConnectorStatusPhaseUpgradeFailed      ConnectorStatusPhase = "upgrade_failed"      // set by kas-fleet-manager - worker
+	ConnectorStatusPhaseStopped            ConnectorStatusPhase = "stopped"             // set by kas-fleet-manager - user request
+	ConnectorStatusPhaseProvisioning       ConnectorStatusPhase = "provisioning"        // set by kas-agent
+	ConnectorStatusPhaseReady              ConnectorStatusPhase = "ready"               // set by the agent
+	ConnectorStatusPhaseFailed             ConnectorStatusPhase = "failed"              // set by the agent
+	ConnectorStatusPhaseDeprovisioning     ConnectorStatusPhase = "deprovisioning"      // set by kas-agent
+	ConnectorStatusPhaseDeleting           ConnectorStatusPhase = "deleting"            // set by the kas-fleet-manager - user request
+	ConnectorStatusPhaseDeleted            ConnectorStatusPhase = "deleted"             // set by the agent
+	ConnectorStatusPhaseClusterUnreachable ConnectorStatusPhase = "cluster_unreachable" // set by kas-fleet-manager - worker
 )
 
 var ValidDesiredStates = []string{
@@ -59,9 +63,15 @@ type Connector struct {
 	ConnectorSpec   api.JSON `gorm:"type:jsonb"`
 	DesiredState    ConnectorDesiredState
 	Channel         string
-	Kafka           KafkaConnectionSettings          `gorm:"embedded;embeddedPrefix:kafka_"`
-	SchemaRegistry  SchemaRegistryConnectionSettings `gorm:"embedded;embeddedPrefix:schema_registry_"`
-	ServiceAccount  ServiceAccount                   `gorm:"embedded;embeddedPrefix:service_account_"`
+	// PinnedShardRevision is the shard metadata revision the connector is deployed with instead of the latest one,
+	// connectors with a pinned revision are not upgraded when the channel gets a new revision
+	PinnedShardRevision *int64
+	// TargetNamespaceId is the namespace the connector is moved to once removed from its namespace,
+	// when it is evicted from an over capacity cluster
+	TargetNamespaceId *string
+	Kafka             KafkaConnectionSettings          `gorm:"embedded;embeddedPrefix:kafka_"`
+	SchemaRegistry    SchemaRegistryConnectionSettings `gorm:"embedded;embeddedPrefix:schema_registry_"`
+	ServiceAccount    ServiceAccount                   `gorm:"embedded;embeddedPrefix:service_account_"`
 
 	Status ConnectorStatus `gorm:"foreignKey:ID"`
 }
@@ -70,6 +80,8 @@ type ConnectorStatus struct {
 	db.Model
 	NamespaceID *string
 	Phase       ConnectorStatusPhase
+	// Reason explains the phase set by kas-fleet-manager, e.g. why an upgrade failed
+	Reason string
 }
 
 type ConnectorList []*Connector
@@ -94,7 +106,13 @@ type ConnectorDeployment struct {
 	ClusterID                string
 	NamespaceID              string
 	AllowUpgrade             bool
-	Status                   ConnectorDeploymentStatus `gorm:"foreignKey:ID;references:ID"`
+	// ResourceCPU and ResourceMemory are the resource limits hinted by the shard metadata, as kubernetes quantities
+	ResourceCPU    string
+	ResourceMemory string
+	// RedeployCount is how many times the deployment has been forced to be redeployed without a connector change,
+	// it is sent to the agent in the deployment spec
+	RedeployCount int64
+	Status        ConnectorDeploymentStatus `gorm:"foreignKey:ID;references:ID"`
 }
 
 type ConnectorDeploymentList []ConnectorDeployment
@@ -108,6 +126,28 @@ type ConnectorDeploymentStatus struct {
 	UpgradeAvailable bool
 }
 
+// ConnectorDeploymentStatusHistory records a change of a deployment status phase
+type ConnectorDeploymentStatusHistory struct {
+	ID           int64  `gorm:"primaryKey:autoIncrement"`
+	DeploymentID string `gorm:"index"`
+	Phase        ConnectorStatusPhase
+	Version      int64
+	CreatedAt    time.Time
+}
+
+type ConnectorDeploymentStatusHistoryList []ConnectorDeploymentStatusHistory
+
+// ConnectorReconcileSettingsID is the id of the single row of the connector reconcile settings
+const ConnectorReconcileSettingsID = "connector"
+
+// ConnectorReconcileSettings holds the settings of the connector reconcile shared by all the fleet manager instances
+type ConnectorReconcileSettings struct {
+	ID string `gorm:"primaryKey"`
+	// Paused makes the connector manager skip the connectors, e.g. during a data plane incident, until it is resumed
+	Paused    bool
+	UpdatedAt time.Time
+}
+
 type KafkaConnectionSettings struct {
 	KafkaID         string `gorm:"column:id"`
 	BootstrapServer string
@@ -122,6 +162,9 @@ type ServiceAccount struct {
 	ClientId        string
 	ClientSecret    string `gorm:"-"`
 	ClientSecretRef string `gorm:"column:client_secret"`
+	// Managed is set when the service account has been created by the fleet manager, only those are deleted from the
+	// SSO along with their connectors. The service accounts supplied by the users are never deleted.
+	Managed bool
 }
 
 type ConnectorDeploymentOperatorUpgrade struct {
@@ -25,6 +25,20 @@ var AllConnectorNamespaceStatus = []ConnectorNamespacePhaseEnum{
 	ConnectorNamespacePhaseDeleted,
 }
 
+const (
+	// ConnectorNamespaceEvictionPriorityAnnotation sets the priority of the connectors of a namespace, between
+	// MinConnectorEvictionPriority and MaxConnectorEvictionPriority, when connectors are evicted from an over capacity
+	// cluster. The connectors with the lowest priority are evicted first. It can only be set by an admin.
+	ConnectorNamespaceEvictionPriorityAnnotation = "connector_mgmt.bf2.org/eviction-priority"
+	MinConnectorEvictionPriority                 = 0
+	MaxConnectorEvictionPriority                 = 100
+	// DefaultConnectorEvictionPriority is the priority of the connectors of namespaces without an eviction priority
+	DefaultConnectorEvictionPriority = 50
+)
+
+// AdminConnectorNamespaceAnnotations are the namespace annotations that can only be set by an admin
+var AdminConnectorNamespaceAnnotations = []string{ConnectorNamespaceEvictionPriorityAnnotation}
+
 type ConnectorTenantUser struct {
 	db.Model // user id in Id, required for references and data consistency
 }
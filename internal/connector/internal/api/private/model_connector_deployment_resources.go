@@ -0,0 +1,18 @@
+/*
+ * Connector Service Fleet Manager Private APIs
+ *
+ * Connector Service Fleet Manager apis that are used by internal services.
+ *
+ * API version: 0.0.3
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package private
+
+// ConnectorDeploymentResources The resource limits the connector should be deployed with, as hinted by its shard metadata
+type ConnectorDeploymentResources struct {
+	// the cpu limit as a kubernetes quantity, e.g. 500m
+	Cpu string `json:"cpu,omitempty"`
+	// the memory limit as a kubernetes quantity, e.g. 512Mi
+	Memory string `json:"memory,omitempty"`
+}
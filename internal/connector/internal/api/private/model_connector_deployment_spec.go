@@ -20,7 +20,10 @@ type ConnectorDeploymentSpec struct {
 	NamespaceId              string                           `json:"namespace_id,omitempty"`
 	ConnectorSpec            map[string]interface{}           `json:"connector_spec,omitempty"`
 	// an optional operator id that the connector should be run under.
-	OperatorId    string                 `json:"operator_id,omitempty"`
-	DesiredState  ConnectorDesiredState  `json:"desired_state,omitempty"`
-	ShardMetadata map[string]interface{} `json:"shard_metadata,omitempty"`
+	OperatorId    string                       `json:"operator_id,omitempty"`
+	DesiredState  ConnectorDesiredState        `json:"desired_state,omitempty"`
+	ShardMetadata map[string]interface{}       `json:"shard_metadata,omitempty"`
+	Resources     ConnectorDeploymentResources `json:"resources,omitempty"`
+	// how many times the deployment has been forced to be redeployed, the connector must be redeployed when it changes
+	RedeployCount int64 `json:"redeploy_count,omitempty"`
 }
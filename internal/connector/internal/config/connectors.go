@@ -20,6 +20,7 @@ import (
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared"
 	"github.com/golang/glog"
 	"github.com/spf13/pflag"
+	"github.com/xeipuuv/gojsonschema"
 )
 
 type ConnectorsConfig struct {
@@ -30,9 +31,20 @@ type ConnectorsConfig struct {
 	ConnectorCatalogDirs                []string                `json:"connector_types"`
 	CatalogEntries                      []ConnectorCatalogEntry `json:"connector_type_urls"`
 	CatalogChecksums                    map[string]string       `json:"connector_catalog_checksums"`
+	// LifecycleEventsSinkURL is the URL the connector lifecycle events are posted to in the CloudEvents format
+	// (e.g. the topic endpoint of a Kafka HTTP bridge). The events are discarded when empty
+	LifecycleEventsSinkURL     string        `json:"connector_lifecycle_events_sink_url"`
+	LifecycleEventsSinkTimeout time.Duration `json:"connector_lifecycle_events_sink_timeout"`
+	// ClusterUnreachableThreshold is how long the agent of a ready cluster can go without reporting its status before
+	// the connectors of the cluster are moved to the cluster_unreachable phase. The detection is disabled when zero
+	ClusterUnreachableThreshold time.Duration `json:"connector_cluster_unreachable_threshold"`
+	// MaxConnectorsPerCluster is the number of connectors a cluster can host, the connectors over it are moved to
+	// other clusters by lowest eviction priority first. The eviction is disabled when zero
+	MaxConnectorsPerCluster int `json:"connector_cluster_max_connectors"`
 }
 
 var _ environments.ConfigModule = &ConnectorsConfig{}
+var _ environments.ServiceValidator = &ConnectorsConfig{}
 
 type ConnectorChannelConfig struct {
 	ShardMetadata map[string]interface{} `json:"shard_metadata,omitempty"`
@@ -45,7 +57,8 @@ type ConnectorCatalogEntry struct {
 
 func NewConnectorsConfig() *ConnectorsConfig {
 	return &ConnectorsConfig{
-		CatalogChecksums: make(map[string]string),
+		CatalogChecksums:           make(map[string]string),
+		LifecycleEventsSinkTimeout: 5 * time.Second,
 	}
 }
 
@@ -55,10 +68,28 @@ func (c *ConnectorsConfig) AddFlags(fs *pflag.FlagSet) {
 	fs.StringArrayVar(&c.ConnectorEvalOrganizations, "connector-eval-organizations", c.ConnectorEvalOrganizations, "Connector eval organization IDs")
 	fs.BoolVar(&c.ConnectorNamespaceLifecycleAPI, "connector-namespace-lifecycle-api", c.ConnectorNamespaceLifecycleAPI, "Enable APIs to create, update, delete non-eval Namespaces")
 	fs.BoolVar(&c.ConnectorEnableUnassignedConnectors, "connector-enable-unassigned-connectors", c.ConnectorEnableUnassignedConnectors, "Enable support for 'unassigned' state for Connectors")
+	fs.StringVar(&c.LifecycleEventsSinkURL, "connector-lifecycle-events-sink-url", c.LifecycleEventsSinkURL, "URL the connector lifecycle events are posted to in the CloudEvents format, e.g. the topic endpoint of a Kafka HTTP bridge. The events are not published when empty")
+	fs.DurationVar(&c.LifecycleEventsSinkTimeout, "connector-lifecycle-events-sink-timeout", c.LifecycleEventsSinkTimeout, "Timeout for posting a connector lifecycle event")
+	fs.DurationVar(&c.ClusterUnreachableThreshold, "connector-cluster-unreachable-threshold", c.ClusterUnreachableThreshold, "How long the agent of a ready connector cluster can go without reporting its status before its connectors are marked as cluster_unreachable. The detection is disabled when 0")
+	fs.IntVar(&c.MaxConnectorsPerCluster, "connector-cluster-max-connectors", c.MaxConnectorsPerCluster, "Maximum number of connectors a connector cluster can host, the connectors with the lowest eviction priority are moved from the clusters over it to other namespaces of their tenant first. The eviction is disabled when 0")
+}
+
+func (c *ConnectorsConfig) Validate(env *environments.Env) error {
+	return c.validateLifecycleEventsSinkTimeout()
+}
+
+// validateLifecycleEventsSinkTimeout makes sure the posting of a lifecycle event is bounded, the events are posted by
+// the connector reconciler so a sink that never answers would otherwise stall the reconcile of all the connectors
+func (c *ConnectorsConfig) validateLifecycleEventsSinkTimeout() error {
+	if c.LifecycleEventsSinkURL != "" && c.LifecycleEventsSinkTimeout <= 0 {
+		return fmt.Errorf("connector-lifecycle-events-sink-timeout must be greater than 0, got %s", c.LifecycleEventsSinkTimeout)
+	}
+	return nil
 }
 
 func (c *ConnectorsConfig) ReadFiles() error {
 	typesLoaded := map[string]string{}
+	invalidEntries := map[string]string{}
 	var values []ConnectorCatalogEntry
 
 	for _, dir := range c.ConnectorCatalogDirs {
@@ -97,6 +128,15 @@ func (c *ConnectorsConfig) ReadFiles() error {
 				return err
 			}
 
+			// keep walking the catalog so that the problems of all the invalid entries are reported at once,
+			// the same entry may be walked more than once through symlinks so it is only reported once
+			if problems := validateCatalogEntry(entry); len(problems) > 0 {
+				if _, found := invalidEntries[sum]; !found {
+					invalidEntries[sum] = fmt.Sprintf("invalid catalog file %s: %s", path, strings.Join(problems, ", "))
+				}
+				return nil
+			}
+
 			// when walking directories with symlink such as what kubernetes does when mounting
 			// a volume from a configmap where the actual files are double-symlinked from some
 			// random named path so this method is invoked twice or more, but it is not actually
@@ -145,6 +185,15 @@ func (c *ConnectorsConfig) ReadFiles() error {
 		}
 	}
 
+	if len(invalidEntries) > 0 {
+		var problems []string
+		for _, problem := range invalidEntries {
+			problems = append(problems, problem)
+		}
+		sort.Strings(problems)
+		return gherrors.Errorf("error validating connector catalogs: %s", strings.Join(problems, "; "))
+	}
+
 	sort.Slice(values, func(i, j int) bool {
 		return values[i].ConnectorType.Id < values[j].ConnectorType.Id
 	})
@@ -155,6 +204,46 @@ func (c *ConnectorsConfig) ReadFiles() error {
 	return nil
 }
 
+// validateCatalogEntry returns the problems of a catalog entry that would prevent its connector type from being used
+func validateCatalogEntry(entry ConnectorCatalogEntry) []string {
+	var problems []string
+
+	ct := entry.ConnectorType
+	if ct.Id == "" {
+		problems = append(problems, "connector type id is missing")
+	}
+	if ct.Name == "" {
+		problems = append(problems, "connector type name is missing")
+	}
+	if ct.Version == "" {
+		problems = append(problems, "connector type version is missing")
+	}
+	if len(ct.Schema) == 0 {
+		problems = append(problems, "connector type schema is missing")
+	} else if _, err := gojsonschema.NewSchema(gojsonschema.NewGoLoader(ct.Schema)); err != nil {
+		problems = append(problems, fmt.Sprintf("connector type schema is not a valid json schema: %s", err))
+	}
+
+	if len(entry.Channels) == 0 {
+		problems = append(problems, "no channels defined")
+	}
+	channels := make([]string, 0, len(entry.Channels))
+	for channel := range entry.Channels {
+		channels = append(channels, channel)
+	}
+	sort.Strings(channels)
+	for _, channel := range channels {
+		revision, found := entry.Channels[channel].ShardMetadata["connector_revision"]
+		if !found {
+			problems = append(problems, fmt.Sprintf("connector_revision not found in shard metadata of channel '%s'", channel))
+		} else if _, ok := revision.(float64); !ok {
+			problems = append(problems, fmt.Sprintf("connector_revision in shard metadata of channel '%s' is not a number", channel))
+		}
+	}
+
+	return problems
+}
+
 func checksum(spec interface{}) (string, error) {
 	h := sha1.New()
 	err := json.NewEncoder(h).Encode(spec)
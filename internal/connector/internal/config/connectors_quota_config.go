@@ -15,6 +15,9 @@ type ConnectorsQuotaConfig struct {
 	ConnectorsQuotaConfigFile    string
 	EvalNamespaceQuotaProfile    string
 	DefaultNamespaceQuotaProfile string
+	// MaxConnectorsPerOwner and MaxConnectorsPerOrganisation limit the number of deployed connectors, 0 means no limit
+	MaxConnectorsPerOwner        int
+	MaxConnectorsPerOrganisation int
 }
 
 func NewConnectorsQuotaConfig() *ConnectorsQuotaConfig {
@@ -30,6 +33,8 @@ func (c *ConnectorsQuotaConfig) AddFlags(fs *pflag.FlagSet) {
 	fs.StringVar(&c.ConnectorsQuotaConfigFile, "connectors-quota-config-file", c.ConnectorsQuotaConfigFile, "Connectors quota configuration file")
 	fs.StringVar(&c.EvalNamespaceQuotaProfile, "connectors-eval-namespace-quota-profile", c.EvalNamespaceQuotaProfile, "Connectors quota profile name for evaluation namespaces")
 	fs.StringVar(&c.DefaultNamespaceQuotaProfile, "default-eval-namespace-quota-profile", c.DefaultNamespaceQuotaProfile, "Connectors quota profile name for default namespace")
+	fs.IntVar(&c.MaxConnectorsPerOwner, "connectors-max-per-owner", c.MaxConnectorsPerOwner, "Maximum number of deployed connectors per owner, 0 means no limit")
+	fs.IntVar(&c.MaxConnectorsPerOrganisation, "connectors-max-per-organisation", c.MaxConnectorsPerOrganisation, "Maximum number of deployed connectors per organisation, 0 means no limit")
 }
 
 func (c *ConnectorsQuotaConfig) ReadFiles() (err error) {
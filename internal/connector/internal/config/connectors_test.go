@@ -78,6 +78,17 @@ func TestConnectorsConfig_ReadFiles(t *testing.T) {
 			wantErr: true,
 			err:     ".*error unmarshaling catalog file .+/internal/connector/test/bad-connector-catalog/bad-connector-type.json: invalid character 'b' looking for beginning of value$",
 		},
+		{
+			name: "invalid catalog entries",
+			fields: fields{
+				CatalogChecksums:     make(map[string]string),
+				ConnectorCatalogDirs: []string{"./internal/connector/test/invalid-connector-catalog"}},
+			wantErr: true,
+			err: "^error validating connector catalogs: " +
+				"invalid catalog file .+/invalid-schema.json: connector type schema is not a valid json schema: .+, no channels defined; " +
+				"invalid catalog file .+/missing-fields.json: connector type name is missing, connector type version is missing, " +
+				"connector_revision not found in shard metadata of channel 'stable'$",
+		},
 	}
 	for _, testcase := range tests {
 		tt := testcase
@@ -178,3 +189,44 @@ func createSymLinkedCatalogDir() (string, error) {
 
 	return dir, nil
 }
+
+func TestConnectorsConfig_ValidateLifecycleEventsSinkTimeout(t *testing.T) {
+	tests := []struct {
+		name    string
+		url     string
+		timeout time.Duration
+		wantErr bool
+	}{
+		{
+			name:    "should return no error when no sink is configured",
+			wantErr: false,
+		},
+		{
+			name:    "should return no error when the sink has a timeout",
+			url:     "http://localhost:8080/topics/connector-lifecycle",
+			timeout: 5 * time.Second,
+			wantErr: false,
+		},
+		{
+			name:    "should return an error when the sink has no timeout",
+			url:     "http://localhost:8080/topics/connector-lifecycle",
+			wantErr: true,
+		},
+		{
+			name:    "should return an error when the sink has a negative timeout",
+			url:     "http://localhost:8080/topics/connector-lifecycle",
+			timeout: -time.Second,
+			wantErr: true,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			config := &ConnectorsConfig{LifecycleEventsSinkURL: tt.url, LifecycleEventsSinkTimeout: tt.timeout}
+			g.Expect(config.Validate(nil) != nil).To(gomega.Equal(tt.wantErr))
+		})
+	}
+}
@@ -33,6 +33,7 @@ type ConnectorAdminHandler struct {
 	QuotaConfig           *config.ConnectorsQuotaConfig
 	ConnectorCluster      *ConnectorClusterHandler //TODO: eventually move deployment handling into a deployment service
 	ConnectorTypesService services.ConnectorTypesService
+	ConnectorManager      *workers.ConnectorManager
 }
 
 func NewConnectorAdminHandler(handler ConnectorAdminHandler) *ConnectorAdminHandler {
@@ -140,6 +141,49 @@ func (h *ConnectorAdminHandler) UpgradeConnectorsByOperator(writer http.Response
 	handlers.Handle(writer, request, &cfg, http.StatusNoContent)
 }
 
+func (h *ConnectorAdminHandler) GetConnectorReconcileSettings(writer http.ResponseWriter, request *http.Request) {
+	cfg := handlers.HandlerConfig{
+		Action: func() (i interface{}, serviceError *errors.ServiceError) {
+			settings, serviceError := h.ConnectorsService.GetReconcileSettings(request.Context())
+			if serviceError != nil {
+				return nil, serviceError
+			}
+			return presenters.PresentConnectorReconcileSettings(settings), nil
+		},
+	}
+
+	handlers.HandleGet(writer, request, &cfg)
+}
+
+func (h *ConnectorAdminHandler) UpdateConnectorReconcileSettings(writer http.ResponseWriter, request *http.Request) {
+	var resource private.ConnectorReconcileSettings
+	cfg := handlers.HandlerConfig{
+		MarshalInto: &resource,
+		Action: func() (i interface{}, serviceError *errors.ServiceError) {
+			settings, serviceError := h.ConnectorsService.SetReconcilePaused(request.Context(), resource.Paused)
+			if serviceError != nil {
+				return nil, serviceError
+			}
+			return presenters.PresentConnectorReconcileSettings(settings), nil
+		},
+	}
+
+	handlers.Handle(writer, request, &cfg, http.StatusOK)
+}
+
+func (h *ConnectorAdminHandler) ReloadConnectorCatalog(writer http.ResponseWriter, request *http.Request) {
+	cfg := handlers.HandlerConfig{
+		Action: func() (i interface{}, serviceError *errors.ServiceError) {
+			if err := h.ConnectorManager.ReconcileCatalogNow(); err != nil {
+				return nil, errors.ToServiceError(err)
+			}
+			return nil, nil
+		},
+	}
+
+	handlers.Handle(writer, request, &cfg, http.StatusNoContent)
+}
+
 func (h *ConnectorAdminHandler) GetClusterNamespaces(writer http.ResponseWriter, request *http.Request) {
 	id := mux.Vars(request)["connector_cluster_id"]
 	listArgs := coreservices.NewListArguments(request.URL.Query())
@@ -594,6 +638,31 @@ func (h *ConnectorAdminHandler) GetConnectorType(writer http.ResponseWriter, req
 	handlers.HandleGet(writer, request, &cfg)
 }
 
+func (h *ConnectorAdminHandler) RedeployConnectorType(writer http.ResponseWriter, request *http.Request) {
+	id := mux.Vars(request)["connector_type_id"]
+	channel := mux.Vars(request)["channel"]
+
+	cfg := handlers.HandlerConfig{
+		Validate: []handlers.Validate{
+			handlers.Validation("connector_type_id", &id, handlers.MinLen(1), handlers.MaxLen(maxConnectorTypeIdLength)),
+			handlers.Validation("channel", &channel, handlers.MinLen(1)),
+		},
+		Action: func() (interface{}, *errors.ServiceError) {
+			count, err := h.Service.ForceRedeployConnectorType(request.Context(), id, channel)
+			if err != nil {
+				return nil, err
+			}
+			return private.ConnectorTypeRedeployment{
+				ConnectorTypeId: id,
+				Channel:         channel,
+				Deployments:     count,
+			}, nil
+		},
+	}
+
+	handlers.Handle(writer, request, &cfg, http.StatusOK)
+}
+
 func (h *ConnectorAdminHandler) PatchConnectorDeployment(writer http.ResponseWriter, request *http.Request) {
 	clusterId := mux.Vars(request)["connector_cluster_id"]
 	deploymentId := mux.Vars(request)["deployment_id"]
@@ -6,6 +6,7 @@ import (
 
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared/utils/arrays"
 
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/api/dbapi"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/api/public"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/services"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
@@ -22,6 +23,18 @@ func validateConnectorClusterId(ctx context.Context, clusterService services.Con
 	}
 }
 
+// validateNamespaceAnnotations rejects the namespace annotations that can only be set by an admin
+func validateNamespaceAnnotations(annotations *map[string]string) handlers.Validate {
+	return func() *errors.ServiceError {
+		for key := range *annotations {
+			if arrays.Contains(dbapi.AdminConnectorNamespaceAnnotations, key) {
+				return errors.BadRequest("annotation %s can only be set by an admin", key)
+			}
+		}
+		return nil
+	}
+}
+
 func validateConnectorRequest(connectorTypesService services.ConnectorTypesService, resource *public.ConnectorRequest) handlers.Validate {
 	return connectorValidationFunction(connectorTypesService, &resource.ConnectorTypeId, &resource.Channel, &resource.Connector)
 }
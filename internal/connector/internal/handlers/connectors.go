@@ -182,7 +182,10 @@ func (h ConnectorsHandler) Patch(w http.ResponseWriter, r *http.Request) {
 
 			// get and validate patch operation type
 			var operation phase.ConnectorOperation
-			if operation, serr = h.getOperation(resource, patch); err != nil {
+			if operation, serr = h.getOperation(resource, patch); serr != nil {
+				return nil, serr
+			}
+			if serr = phase.ValidateDesiredStateTransition(&dbresource.Connector, dbapi.ConnectorDesiredState(patch.DesiredState)); serr != nil {
 				return nil, serr
 			}
 			if operation == phase.UnassignConnector && !h.connectorsConfig.ConnectorEnableUnassignedConnectors {
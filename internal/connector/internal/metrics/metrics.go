@@ -10,17 +10,34 @@ const (
 
 	// label for operation name
 	labelOperation = "operation"
+	// label for connector cluster id
+	labelClusterId = "cluster_id"
+	// label for connector type id
+	labelConnectorTypeId = "connector_type_id"
 
 	VaultServiceTotalCount   = "vault_service_total_count"
 	VaultServiceSuccessCount = "vault_service_success_count"
 	VaultServiceFailureCount = "vault_service_failure_count"
 	VaultServiceErrorsCount  = "vault_service_errors_count"
+
+	ConnectorDeploymentCreationFailureCount = "connector_deployment_creation_failure_count"
+
+	ConnectorClusterUnreachable = "connector_cluster_unreachable"
 )
 
 var VaultServiceMetricsLabels = []string{
 	labelOperation,
 }
 
+var ConnectorClusterMetricsLabels = []string{
+	labelClusterId,
+}
+
+var ConnectorDeploymentMetricsLabels = []string{
+	labelClusterId,
+	labelConnectorTypeId,
+}
+
 // #### Metrics for Vault Service ####
 
 var vaultServiceTotalCountMetric = prometheus.NewCounterVec(
@@ -81,6 +98,47 @@ func IncreaseVaultServiceErrorsCount(operationType string) {
 
 // #### Metrics for Vault Service - End ####
 
+// #### Metrics for Connector Manager ####
+
+var connectorDeploymentCreationFailureCountMetric = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Subsystem: CosFleetManager,
+		Name:      ConnectorDeploymentCreationFailureCount,
+		Help:      "count of failures to save the deployment of an assigned connector",
+	}, ConnectorDeploymentMetricsLabels)
+
+func IncreaseConnectorDeploymentCreationFailureCount(clusterId string, connectorTypeId string) {
+	labels := prometheus.Labels{
+		labelClusterId:       clusterId,
+		labelConnectorTypeId: connectorTypeId,
+	}
+	connectorDeploymentCreationFailureCountMetric.With(labels).Inc()
+}
+
+// #### Metrics for Connector Manager - End ####
+
+// #### Metrics for Cluster Manager ####
+
+var connectorClusterUnreachableMetric = prometheus.NewGaugeVec(
+	prometheus.GaugeOpts{
+		Subsystem: CosFleetManager,
+		Name:      ConnectorClusterUnreachable,
+		Help:      "set to 1 for each ready connector cluster whose agent stopped reporting its status",
+	}, ConnectorClusterMetricsLabels)
+
+// UpdateConnectorClustersUnreachable replaces the clusters reported as unreachable with the given ones
+func UpdateConnectorClustersUnreachable(clusterIds []string) {
+	connectorClusterUnreachableMetric.Reset()
+	for _, clusterId := range clusterIds {
+		labels := prometheus.Labels{
+			labelClusterId: clusterId,
+		}
+		connectorClusterUnreachableMetric.With(labels).Set(1)
+	}
+}
+
+// #### Metrics for Cluster Manager - End ####
+
 // register the metric(s)
 func init() {
 	// metrics for vault service
@@ -88,6 +146,12 @@ func init() {
 	prometheus.MustRegister(vaultServiceSuccessCountMetric)
 	prometheus.MustRegister(vaultServiceFailureCountMetric)
 	prometheus.MustRegister(vaultServiceErrorsCountMetric)
+
+	// metrics for connector manager
+	prometheus.MustRegister(connectorDeploymentCreationFailureCountMetric)
+
+	// metrics for cluster manager
+	prometheus.MustRegister(connectorClusterUnreachableMetric)
 }
 
 // ResetMetricsForVaultService will reset the metrics related to Vault Service requests
@@ -99,7 +163,21 @@ func ResetMetricsForVaultService() {
 	vaultServiceErrorsCountMetric.Reset()
 }
 
+// ResetMetricsForConnectorManager will reset the metrics related to the Connector Manager
+// This is needed because if current process is not the leader anymore, the metrics need to be reset otherwise staled data will be scraped
+func ResetMetricsForConnectorManager() {
+	connectorDeploymentCreationFailureCountMetric.Reset()
+}
+
+// ResetMetricsForClusterManager will reset the metrics related to the Cluster Manager
+// This is needed because if current process is not the leader anymore, the metrics need to be reset otherwise staled data will be scraped
+func ResetMetricsForClusterManager() {
+	connectorClusterUnreachableMetric.Reset()
+}
+
 // Reset the metrics we have defined. It is mainly used for testing.
 func Reset() {
 	ResetMetricsForVaultService()
+	ResetMetricsForConnectorManager()
+	ResetMetricsForClusterManager()
 }
@@ -0,0 +1,27 @@
+package migrations
+
+// Migrations should NEVER use types from other packages. Types can change
+// and then migrations run on a _new_ database will fail or behave unexpectedly.
+// Instead of importing types, always re-create the type in the migration, as
+// is done here, even though the same type is defined in pkg/api
+
+import (
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/go-gormigrate/gormigrate/v2"
+)
+
+func addConnectorDeploymentStatusHistory(migrationId string) *gormigrate.Migration {
+	type ConnectorDeploymentStatusHistory struct {
+		ID           int64  `gorm:"primaryKey:autoIncrement"`
+		DeploymentID string `gorm:"index"`
+		Phase        string
+		Version      int64
+		CreatedAt    time.Time
+	}
+
+	return db.CreateMigrationFromActions(migrationId,
+		db.CreateTableAction(&ConnectorDeploymentStatusHistory{}),
+	)
+}
@@ -0,0 +1,23 @@
+package migrations
+
+// Migrations should NEVER use types from other packages. Types can change
+// and then migrations run on a _new_ database will fail or behave unexpectedly.
+// Instead of importing types, always re-create the type in the migration, as
+// is done here, even though the same type is defined in pkg/api
+
+import (
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/go-gormigrate/gormigrate/v2"
+)
+
+func addConnectorServiceAccountManaged(migrationId string) *gormigrate.Migration {
+	type Connector struct {
+		ServiceAccountManaged bool `gorm:"not null;default:false"`
+	}
+
+	return db.CreateMigrationFromActions(migrationId,
+		// add whether the service account of the connector has been created by the fleet manager, the existing
+		// connectors use service accounts supplied by their users
+		db.AddTableColumnsAction(&Connector{}),
+	)
+}
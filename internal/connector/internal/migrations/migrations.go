@@ -46,6 +46,15 @@ var migrations = []*gormigrate.Migration{
 	addConnectorTypeFeaturedRank("202208250000"),
 	addConnectorTypeLease("202208220000"),
 	addConnectorClusterPlatform("202209270000"),
+	addConnectorPinnedShardRevision("202210130000"),
+	addConnectorDeploymentStatusHistory("202210140000"),
+	addConnectorStatusReason("202210150000"),
+	addConnectorDeploymentResources("202210160000"),
+	addConnectorClusterLastHeartbeat("202210170000"),
+	addConnectorDeploymentRedeployCount("202210180000"),
+	addConnectorTargetNamespaceId("202210190000"),
+	addConnectorReconcileSettings("202210200000"),
+	addConnectorServiceAccountManaged("202210210000"),
 }
 
 func New(dbConfig *db.DatabaseConfig) (*db.Migration, func(), error) {
@@ -65,7 +65,10 @@ func PresentConnectorWithError(from *dbapi.ConnectorWithConditions) (public.Conn
 		if err != nil {
 			return public.Connector{}, errors.GeneralError("invalid conditions: %v", err)
 		}
-		connector.Status.Error = getStatusError(conditions)
+		// keep the reason set by kas-fleet-manager when the conditions don't report an error
+		if statusError := getStatusError(conditions); statusError != "" {
+			connector.Status.Error = statusError
+		}
 	}
 
 	return connector, nil
@@ -89,6 +92,7 @@ func PresentConnectorAdminView(from *dbapi.ConnectorWithConditions) (admin.Conne
 		ConnectorTypeId: from.ConnectorTypeId,
 		Status: admin.ConnectorStatusStatus{
 			State: admin.ConnectorState(from.Status.Phase),
+			Error: from.Status.Reason,
 		},
 		DesiredState: admin.ConnectorDesiredState(from.DesiredState),
 		Channel:      admin.Channel(from.Channel),
@@ -105,7 +109,9 @@ func PresentConnectorAdminView(from *dbapi.ConnectorWithConditions) (admin.Conne
 			if err != nil {
 				return admin.ConnectorAdminView{}, errors.GeneralError("invalid conditions: %v", err)
 			}
-			connector.Status.Error = getStatusError(conditions)
+			if statusError := getStatusError(conditions); statusError != "" {
+				connector.Status.Error = statusError
+			}
 		}
 	}
 
@@ -159,6 +165,7 @@ func PresentConnector(from *dbapi.Connector) (public.Connector, *errors.ServiceE
 		Connector:       spec,
 		Status: public.ConnectorStatusStatus{
 			State: public.ConnectorState(from.Status.Phase),
+			Error: from.Status.Reason,
 		},
 		DesiredState: public.ConnectorDesiredState(from.DesiredState),
 		Channel:      public.Channel(from.Channel),
@@ -77,6 +77,11 @@ func PresentConnectorDeployment(from dbapi.ConnectorDeployment, resolvedSecrets
 				ClientSecret: presentedConnector.ServiceAccount.ClientSecret,
 			},
 			ConnectorTypeId: presentedConnector.ConnectorTypeId,
+			Resources: private.ConnectorDeploymentResources{
+				Cpu:    from.ResourceCPU,
+				Memory: from.ResourceMemory,
+			},
+			RedeployCount: from.RedeployCount,
 		},
 		Status: private.ConnectorDeploymentStatus{
 			Phase:           private.ConnectorState(from.Status.Phase),
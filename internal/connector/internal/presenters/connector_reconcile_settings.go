@@ -0,0 +1,12 @@
+package presenters
+
+import (
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/api/admin/private"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/api/dbapi"
+)
+
+func PresentConnectorReconcileSettings(settings *dbapi.ConnectorReconcileSettings) *private.ConnectorReconcileSettings {
+	return &private.ConnectorReconcileSettings{
+		Paused: settings.Paused,
+	}
+}
@@ -176,8 +176,12 @@ func (s *options) AddRoutes(mainRouter *mux.Router) error {
 	//adminRouter.HandleFunc("/kafka_connector_namespaces/{namespace_id}/deployments/{deployment_id}", s.ConnectorAdminHandler.PatchConnectorDeployment).Methods(http.MethodGet)
 	adminRouter.HandleFunc("/kafka_connectors/{connector_id}", s.ConnectorAdminHandler.GetConnector).Methods(http.MethodGet)
 	adminRouter.HandleFunc("/kafka_connectors/{connector_id}", s.ConnectorAdminHandler.DeleteConnector).Methods(http.MethodDelete)
+	adminRouter.HandleFunc("/kafka_connector_reconcile", s.ConnectorAdminHandler.GetConnectorReconcileSettings).Methods(http.MethodGet)
+	adminRouter.HandleFunc("/kafka_connector_reconcile", s.ConnectorAdminHandler.UpdateConnectorReconcileSettings).Methods(http.MethodPut)
 	adminRouter.HandleFunc("/kafka_connector_types", s.ConnectorAdminHandler.ListConnectorTypes).Methods(http.MethodGet)
 	adminRouter.HandleFunc("/kafka_connector_types/{connector_type_id}", s.ConnectorAdminHandler.GetConnectorType).Methods(http.MethodGet)
+	adminRouter.HandleFunc("/kafka_connector_types/{connector_type_id}/channels/{channel}/redeploy", s.ConnectorAdminHandler.RedeployConnectorType).Methods(http.MethodPost)
+	adminRouter.HandleFunc("/kafka_connector_catalog/reload", s.ConnectorAdminHandler.ReloadConnectorCatalog).Methods(http.MethodPost)
 
 	v1Metadata := api.VersionMetadata{
 		ID:          "v1",
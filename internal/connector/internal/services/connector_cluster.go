@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"reflect"
 	"strings"
+	"time"
 
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/api/dbapi"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/api/private"
@@ -36,14 +37,19 @@ type ConnectorClusterService interface {
 	UpdateDeployment(resource *dbapi.ConnectorDeployment) *errors.ServiceError
 	ListConnectorDeployments(ctx context.Context, clusterId string, filterChannelUpdates bool, includeDanglingDeploymentsOnly bool, listArgs *services.ListArguments, gtVersion int64) (dbapi.ConnectorDeploymentList, *api.PagingMeta, *errors.ServiceError)
 	UpdateConnectorDeploymentStatus(ctx context.Context, status dbapi.ConnectorDeploymentStatus) *errors.ServiceError
+	GetDeploymentStatusHistory(id string) (dbapi.ConnectorDeploymentStatusHistoryList, *errors.ServiceError)
 	FindAvailableNamespace(owner string, orgId string, namespaceId *string) (*dbapi.ConnectorNamespace, *errors.ServiceError)
+	GetNamespaceUtilization(namespaceID string) (*NamespaceUtilization, *errors.ServiceError)
 	GetDeploymentByConnectorId(ctx context.Context, connectorID string) (dbapi.ConnectorDeployment, *errors.ServiceError)
 	GetDeployment(ctx context.Context, id string) (dbapi.ConnectorDeployment, *errors.ServiceError)
 	GetAvailableDeploymentOperatorUpgrades(listArgs *services.ListArguments) (dbapi.ConnectorDeploymentOperatorUpgradeList, *api.PagingMeta, *errors.ServiceError)
 	UpgradeConnectorsByOperator(ctx context.Context, clusterId string, upgrades dbapi.ConnectorDeploymentOperatorUpgradeList) *errors.ServiceError
+	ForceRedeployConnectorType(ctx context.Context, typeId string, channel string) (int64, *errors.ServiceError)
 	CleanupDeployments() *errors.ServiceError
 	ReconcileEmptyDeletingClusters(ctx context.Context, clusterIds []string) (int, []*errors.ServiceError)
 	ReconcileNonEmptyDeletingClusters(ctx context.Context, clusterIds []string) (int, []*errors.ServiceError)
+	ReconcileUnreachableClusters(ctx context.Context, clusterIds []string) (int, []*errors.ServiceError)
+	ReconcileRecoveredClusters(ctx context.Context, clusterIds []string) (int, []*errors.ServiceError)
 	GetClusterIds(query string, args ...interface{}) ([]string, error)
 	GetClusterOrg(id string) (string, *errors.ServiceError)
 	ResetServiceAccount(ctx context.Context, cluster *dbapi.ConnectorCluster) *errors.ServiceError
@@ -315,6 +321,12 @@ func (k *connectorClusterService) UpdateConnectorClusterStatus(ctx context.Conte
 	// agent doesn't directly modify cluster phase, that's done in PerformClusterOperation()
 	status.Phase = resource.Status.Phase
 
+	// every status report is a heartbeat of the agent, even when the status is unchanged
+	if err := dbConn.Model(&dbapi.ConnectorCluster{Model: db.Model{ID: id}}).
+		UpdateColumn("last_heartbeat_at", time.Now()).Error; err != nil {
+		return errors.NewWithCause(errors.ErrorGeneral, err, "failed to update last heartbeat")
+	}
+
 	if updated || !reflect.DeepEqual(resource.Status, status) {
 
 		if updated {
@@ -371,7 +383,26 @@ func (k *connectorClusterService) GetClientId(clusterID string) (string, error)
 func (k *connectorClusterService) SaveDeployment(ctx context.Context, resource *dbapi.ConnectorDeployment) *errors.ServiceError {
 	dbConn := k.connectionFactory.New()
 
-	if err := dbConn.Save(resource).Error; err != nil {
+	// read the current phase to find out if the save changes the deployment status
+	var previous dbapi.ConnectorDeploymentStatus
+	if resource.ID != "" {
+		if err := dbConn.Select("phase").Where("id = ?", resource.ID).
+			Limit(1).Find(&previous).Error; err != nil {
+			return services.HandleGetError(`Connector deployment status`, "id", resource.ID, err)
+		}
+	}
+
+	// the status history is saved along with the deployment so that a status change is never missing from it
+	if err := dbConn.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(resource).Error; err != nil {
+			return services.HandleCreateError(`Connector deployment`, err)
+		}
+
+		return saveDeploymentStatusHistory(tx, resource.ID, previous.Phase, resource.Status)
+	}); err != nil {
+		if serr, ok := err.(*errors.ServiceError); ok {
+			return serr
+		}
 		return services.HandleCreateError(`Connector deployment`, err)
 	}
 
@@ -388,6 +419,39 @@ func (k *connectorClusterService) SaveDeployment(ctx context.Context, resource *
 	return nil
 }
 
+// saveDeploymentStatusHistory records the phase of the given deployment status when it's different from the previous one
+func saveDeploymentStatusHistory(tx *gorm.DB, deploymentID string, previous dbapi.ConnectorStatusPhase, status dbapi.ConnectorDeploymentStatus) error {
+	if status.Phase == "" || status.Phase == previous {
+		return nil
+	}
+	history := dbapi.ConnectorDeploymentStatusHistory{
+		DeploymentID: deploymentID,
+		Phase:        status.Phase,
+		Version:      status.Version,
+	}
+	if err := tx.Create(&history).Error; err != nil {
+		return services.HandleCreateError(`Connector deployment status history`, err)
+	}
+	return nil
+}
+
+// GetDeploymentStatusHistory returns the status phase changes of a deployment, oldest first
+func (k *connectorClusterService) GetDeploymentStatusHistory(id string) (dbapi.ConnectorDeploymentStatusHistoryList, *errors.ServiceError) {
+	if id == "" {
+		return nil, errors.Validation("deployment id is undefined")
+	}
+
+	var history dbapi.ConnectorDeploymentStatusHistoryList
+	dbConn := k.connectionFactory.New()
+	if err := dbConn.Where("deployment_id = ?", id).
+		Order("created_at, id").
+		Find(&history).Error; err != nil {
+		return nil, services.HandleGetError(`Connector deployment status history`, "deployment_id", id, err)
+	}
+
+	return history, nil
+}
+
 func (k *connectorClusterService) UpdateDeployment(resource *dbapi.ConnectorDeployment) *errors.ServiceError {
 	dbConn := k.connectionFactory.New()
 	updates := dbConn.Where("id = ?", resource.ID).
@@ -475,8 +539,24 @@ func (k *connectorClusterService) UpdateConnectorDeploymentStatus(ctx context.Co
 		return services.HandleGoneError("Connector deployment", "id", deploymentStatus.ID)
 	}
 
-	if err := dbConn.Model(&deploymentStatus).Where("id = ? and version <= ?", deploymentStatus.ID, deploymentStatus.Version).Save(&deploymentStatus).Error; err != nil {
-		return errors.Conflict("failed to update deployment status: %s, probably a stale deployment status version was used: %d", err.Error(), deploymentStatus.Version)
+	// read the current phase to find out if the reported status changes it
+	var previous dbapi.ConnectorDeploymentStatus
+	if err := dbConn.Select("phase").Where("id = ?", deploymentStatus.ID).
+		Limit(1).Find(&previous).Error; err != nil {
+		return services.HandleGetError(`Connector deployment status`, "id", deploymentStatus.ID, err)
+	}
+
+	// the status history is saved along with the status reported by the agent so that a status change is never missing from it
+	if err := dbConn.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&deploymentStatus).Where("id = ? and version <= ?", deploymentStatus.ID, deploymentStatus.Version).Save(&deploymentStatus).Error; err != nil {
+			return errors.Conflict("failed to update deployment status: %s, probably a stale deployment status version was used: %d", err.Error(), deploymentStatus.Version)
+		}
+		return saveDeploymentStatusHistory(tx, deploymentStatus.ID, previous.Phase, deploymentStatus)
+	}); err != nil {
+		if serr, ok := err.(*errors.ServiceError); ok {
+			return serr
+		}
+		return services.HandleUpdateError(`Connector deployment status`, err)
 	}
 
 	connector := dbapi.Connector{}
@@ -493,6 +573,12 @@ func (k *connectorClusterService) UpdateConnectorDeploymentStatus(ctx context.Co
 		return services.HandleGetError("Connector", "id", deployment.ConnectorID, err)
 	}
 
+	// the reason of an unreachable connector is stale once its deployment reports status again
+	statusUpdate := dbConn.Where("id = ?", deployment.ConnectorID)
+	if connectorStatus.Phase == dbapi.ConnectorStatusPhaseClusterUnreachable {
+		statusUpdate = statusUpdate.Select("phase", "reason")
+	}
+
 	connectorStatus.Phase = deploymentStatus.Phase
 	if deploymentStatus.Phase == dbapi.ConnectorStatusPhaseDeleted {
 		// we don't need the deployment anymore...
@@ -502,7 +588,7 @@ func (k *connectorClusterService) UpdateConnectorDeploymentStatus(ctx context.Co
 	}
 
 	// update the connector status
-	if err := dbConn.Where("id = ?", deployment.ConnectorID).Updates(&connectorStatus).Error; err != nil {
+	if err := statusUpdate.Updates(&connectorStatus).Error; err != nil {
 		return services.HandleUpdateError("Connector status", err)
 	}
 
@@ -532,6 +618,53 @@ func (k *connectorClusterService) FindAvailableNamespace(owner string, orgID str
 	return nil, nil
 }
 
+// NamespaceUtilization is the number of active connector deployments of a namespace, and the number of connectors
+// using it compared to its connectors quota, as enforced by ConnectorNamespaceService.CheckConnectorQuota.
+// A Limit of 0 means that the namespace has no connectors limit, in which case Remaining is -1.
+type NamespaceUtilization struct {
+	NamespaceID string
+	Deployments int64
+	Connectors  int64
+	Limit       int64
+	Remaining   int64
+}
+
+func (k *connectorClusterService) GetNamespaceUtilization(namespaceID string) (*NamespaceUtilization, *errors.ServiceError) {
+	quota, serr := k.connectorNamespaceService.GetNamespaceQuota(namespaceID)
+	if serr != nil {
+		return nil, serr
+	}
+
+	var deployments int64
+	dbConn := k.connectionFactory.New()
+	if err := dbConn.Model(&dbapi.ConnectorDeployment{}).Where("namespace_id = ?", namespaceID).
+		Count(&deployments).Error; err != nil {
+		return nil, services.HandleGetError("Connector deployment", "namespace_id", namespaceID, err)
+	}
+
+	var connectors int64
+	if err := dbConn.Model(&dbapi.Connector{}).Where("namespace_id = ?", namespaceID).
+		Count(&connectors).Error; err != nil {
+		return nil, services.HandleGetError("Connector", "namespace_id", namespaceID, err)
+	}
+
+	utilization := &NamespaceUtilization{
+		NamespaceID: namespaceID,
+		Deployments: deployments,
+		Connectors:  connectors,
+		Limit:       int64(quota.Connectors),
+		Remaining:   -1,
+	}
+	if utilization.Limit > 0 {
+		utilization.Remaining = utilization.Limit - connectors
+		if utilization.Remaining < 0 {
+			utilization.Remaining = 0
+		}
+	}
+
+	return utilization, nil
+}
+
 func (k *connectorClusterService) GetDeploymentByConnectorId(ctx context.Context, connectorID string) (resource dbapi.ConnectorDeployment, serr *errors.ServiceError) {
 
 	dbConn := k.connectionFactory.New().Joins("Status").Joins("ConnectorShardMetadata").Joins("Connector").Where("connector_id = ?", connectorID)
@@ -625,6 +758,60 @@ func (k *connectorClusterService) GetAvailableDeploymentOperatorUpgrades(listArg
 	return
 }
 
+// ForceRedeployConnectorType increments the redeploy count of the deployments of the connectors of the given type and
+// channel, and bumps the version of those connectors so that the connector reconcile loop updates their deployments
+// even though neither the connectors nor their shard metadata changed, e.g. after a base image fix.
+// The redeploy count is part of the deployment spec sent to the agent, which redeploys the connector when it changes.
+// Both updates run in the transaction of the context, which is marked for rollback if any of them fails.
+// The number of deployments redeployed is returned.
+func (k *connectorClusterService) ForceRedeployConnectorType(ctx context.Context, typeId string, channel string) (int64, *errors.ServiceError) {
+	if typeId == "" || channel == "" {
+		return 0, errors.Validation("connector type id and channel are required")
+	}
+
+	dbConn, err := k.connectionFactory.NewFromContext(ctx)
+	if err != nil {
+		return 0, errors.GeneralError("failed to get the transaction of the context: %v", err)
+	}
+
+	connectorIds := dbConn.
+		Model(&dbapi.Connector{}).
+		Select("id").
+		Where("connector_type_id = ? AND channel = ?", typeId, channel)
+	result := dbConn.
+		Model(&dbapi.ConnectorDeployment{}).
+		Where("connector_id IN (?)", connectorIds).
+		Update("redeploy_count", gorm.Expr("redeploy_count + 1"))
+	if result.Error != nil {
+		db.MarkForRollback(ctx, result.Error)
+		return 0, services.HandleUpdateError("Connector deployment", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return 0, nil
+	}
+
+	// the connectors version trigger assigns a new version to the updated connectors
+	deployedConnectorIds := dbConn.
+		Model(&dbapi.ConnectorDeployment{}).
+		Select("connector_id")
+	if err := dbConn.
+		Model(&dbapi.Connector{}).
+		Where("connector_type_id = ? AND channel = ?", typeId, channel).
+		Where("id IN (?)", deployedConnectorIds).
+		Update("updated_at", time.Now()).Error; err != nil {
+		db.MarkForRollback(ctx, err)
+		return 0, services.HandleUpdateError("Connector", err)
+	}
+
+	_ = db.AddPostCommitAction(ctx, func() {
+		// Wake up the reconcile loop...
+		k.bus.Notify("reconcile:connector")
+	})
+
+	glog.Infof("forced the redeployment of %d deployment(s) of connector type %s and channel %s", result.RowsAffected, typeId, channel)
+	return result.RowsAffected, nil
+}
+
 func (k *connectorClusterService) UpgradeConnectorsByOperator(ctx context.Context, clusterId string, upgrades dbapi.ConnectorDeploymentOperatorUpgradeList) *errors.ServiceError {
 	// get deployment ids from available upgrades
 	available, _, serr := k.GetAvailableDeploymentOperatorUpgrades(&services.ListArguments{})
@@ -761,6 +948,76 @@ func (k *connectorClusterService) ReconcileNonEmptyDeletingClusters(ctx context.
 	return count, errs
 }
 
+// unreachableConnectorPhases are the phases reported by the agent of the connectors that are moved to the
+// cluster_unreachable phase when the agent stops reporting status
+var unreachableConnectorPhases = []string{
+	string(dbapi.ConnectorStatusPhaseProvisioning),
+	string(dbapi.ConnectorStatusPhaseDeprovisioning),
+	string(dbapi.ConnectorStatusPhaseStopped),
+	string(dbapi.ConnectorStatusPhaseReady),
+	string(dbapi.ConnectorStatusPhaseFailed),
+}
+
+// ReconcileUnreachableClusters moves the connectors deployed in clusters whose agent stopped reporting status to the
+// cluster_unreachable phase
+func (k *connectorClusterService) ReconcileUnreachableClusters(_ context.Context, clusterIds []string) (int, []*errors.ServiceError) {
+	count := 0
+	var errs []*errors.ServiceError
+	for _, clusterId := range clusterIds {
+		dbConn := k.connectionFactory.New()
+		deployedConnectors := k.connectionFactory.New().Model(&dbapi.ConnectorDeployment{}).
+			Select("connector_id").Where("cluster_id = ?", clusterId)
+		result := dbConn.Model(&dbapi.ConnectorStatus{}).
+			Where("phase IN ? AND id IN (?)", unreachableConnectorPhases, deployedConnectors).
+			Updates(map[string]interface{}{
+				"phase":  dbapi.ConnectorStatusPhaseClusterUnreachable,
+				"reason": fmt.Sprintf("connector cluster %s stopped reporting its status", clusterId),
+			})
+		if result.Error != nil {
+			errs = append(errs, services.HandleUpdateError("Connector status", result.Error))
+			continue
+		}
+		if result.RowsAffected > 0 {
+			glog.Warningf("Connector cluster %s stopped reporting its status, moved %d connectors to phase %s",
+				clusterId, result.RowsAffected, dbapi.ConnectorStatusPhaseClusterUnreachable)
+		}
+		count++
+	}
+	return count, errs
+}
+
+// ReconcileRecoveredClusters restores the phase of the cluster_unreachable connectors of clusters whose agent reports
+// status again to the phase last reported for their deployment
+func (k *connectorClusterService) ReconcileRecoveredClusters(_ context.Context, clusterIds []string) (int, []*errors.ServiceError) {
+	count := 0
+	var errs []*errors.ServiceError
+	for _, clusterId := range clusterIds {
+		dbConn := k.connectionFactory.New()
+		deployedConnectors := k.connectionFactory.New().Model(&dbapi.ConnectorDeployment{}).
+			Select("connector_id").Where("cluster_id = ?", clusterId)
+		result := dbConn.Model(&dbapi.ConnectorStatus{}).
+			Where("phase = ? AND id IN (?)", dbapi.ConnectorStatusPhaseClusterUnreachable, deployedConnectors).
+			Updates(map[string]interface{}{
+				// connectors whose deployment never reported status go back to assigned
+				"phase": gorm.Expr("COALESCE((SELECT NULLIF(connector_deployment_statuses.phase, '') "+
+					"FROM connector_deployment_statuses JOIN connector_deployments ON connector_deployments.id = connector_deployment_statuses.id "+
+					"WHERE connector_deployments.connector_id = connector_statuses.id AND connector_deployments.deleted_at IS NULL LIMIT 1), ?)",
+					dbapi.ConnectorStatusPhaseAssigned),
+				"reason": "",
+			})
+		if result.Error != nil {
+			errs = append(errs, services.HandleUpdateError("Connector status", result.Error))
+			continue
+		}
+		if result.RowsAffected > 0 {
+			glog.Infof("Connector cluster %s reports its status again, restored the phase of %d connectors",
+				clusterId, result.RowsAffected)
+		}
+		count++
+	}
+	return count, errs
+}
+
 // GetClusterIds gets ids of all clusters that match the query and args
 func (k *connectorClusterService) GetClusterIds(query string, args ...interface{}) ([]string, error) {
 	var clusterIds []string
@@ -0,0 +1,508 @@
+package services
+
+import (
+	"context"
+	"database/sql/driver"
+	"testing"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/config"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/onsi/gomega"
+	mocket "github.com/selvatico/go-mocket"
+)
+
+// connectorNamespaceServiceStub only implements GetNamespaceQuota, calling any other method panics
+type connectorNamespaceServiceStub struct {
+	ConnectorNamespaceService
+	quota    config.NamespaceQuota
+	quotaErr *errors.ServiceError
+}
+
+func (s *connectorNamespaceServiceStub) GetNamespaceQuota(namespaceId string) (config.NamespaceQuota, *errors.ServiceError) {
+	return s.quota, s.quotaErr
+}
+
+func Test_connectorClusterService_GetNamespaceUtilization(t *testing.T) {
+	const namespaceID = "namespace-id"
+
+	tests := []struct {
+		name        string
+		quota       config.NamespaceQuota
+		quotaErr    *errors.ServiceError
+		deployments int
+		connectors  int
+		want        *NamespaceUtilization
+		wantErr     bool
+	}{
+		{
+			name:        "should return no remaining connectors for a full namespace",
+			quota:       config.NamespaceQuota{Connectors: 2},
+			deployments: 1,
+			connectors:  2,
+			want: &NamespaceUtilization{
+				NamespaceID: namespaceID,
+				Deployments: 1,
+				Connectors:  2,
+				Limit:       2,
+				Remaining:   0,
+			},
+		},
+		{
+			name:        "should return the remaining connectors for an under-utilized namespace",
+			quota:       config.NamespaceQuota{Connectors: 4},
+			deployments: 1,
+			connectors:  1,
+			want: &NamespaceUtilization{
+				NamespaceID: namespaceID,
+				Deployments: 1,
+				Connectors:  1,
+				Limit:       4,
+				Remaining:   3,
+			},
+		},
+		{
+			name:        "should return -1 remaining connectors for a namespace without connectors limit",
+			quota:       config.NamespaceQuota{},
+			deployments: 5,
+			connectors:  5,
+			want: &NamespaceUtilization{
+				NamespaceID: namespaceID,
+				Deployments: 5,
+				Connectors:  5,
+				Limit:       0,
+				Remaining:   -1,
+			},
+		},
+		{
+			name:     "should return an error if the namespace quota cannot be read",
+			quotaErr: errors.FailedToCheckQuota("failed to read quota"),
+			wantErr:  true,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			mocket.Catcher.Reset().
+				NewMock().
+				WithQuery(`SELECT count(1) FROM "connector_deployments" WHERE namespace_id = $1`).
+				WithArgs(namespaceID).
+				WithReply([]map[string]interface{}{{"count": tt.deployments}})
+			mocket.Catcher.NewMock().
+				WithQuery(`SELECT count(1) FROM "connectors" WHERE namespace_id = $1`).
+				WithArgs(namespaceID).
+				WithReply([]map[string]interface{}{{"count": tt.connectors}})
+			mocket.Catcher.NewMock().WithExecException().WithQueryException()
+
+			k := &connectorClusterService{
+				connectionFactory: db.NewMockConnectionFactory(nil),
+				connectorNamespaceService: &connectorNamespaceServiceStub{
+					quota:    tt.quota,
+					quotaErr: tt.quotaErr,
+				},
+			}
+
+			got, err := k.GetNamespaceUtilization(namespaceID)
+			g.Expect(err != nil).To(gomega.Equal(tt.wantErr))
+			g.Expect(got).To(gomega.Equal(tt.want))
+		})
+	}
+}
+
+func Test_connectorClusterService_SaveDeployment_StatusHistory(t *testing.T) {
+	const deploymentID = "deployment-id"
+	g := gomega.NewWithT(t)
+
+	k := &connectorClusterService{
+		connectionFactory: db.NewMockConnectionFactory(nil),
+	}
+
+	// save the deployment through a sequence of phases, the stored phase is the last saved one
+	phases := []dbapi.ConnectorStatusPhase{
+		dbapi.ConnectorStatusPhaseAssigned,
+		dbapi.ConnectorStatusPhaseAssigned,
+		dbapi.ConnectorStatusPhaseReady,
+		dbapi.ConnectorStatusPhaseReady,
+		dbapi.ConnectorStatusPhaseDeleting,
+	}
+	var stored dbapi.ConnectorStatusPhase
+	var history []dbapi.ConnectorStatusPhase
+	for i, phase := range phases {
+		var previous []map[string]interface{}
+		if stored != "" {
+			previous = []map[string]interface{}{{"phase": stored}}
+		}
+		mocket.Catcher.Reset().
+			NewMock().
+			WithQuery(`SELECT "phase" FROM "connector_deployment_statuses"`).
+			WithArgs(deploymentID).
+			WithReply(previous)
+		mocket.Catcher.NewMock().
+			WithQuery(`INSERT INTO "connector_deployment_status_histories"`).
+			WithCallback(func(_ string, args []driver.NamedValue) {
+				g.Expect(args[0].Value).To(gomega.Equal(deploymentID))
+				history = append(history, dbapi.ConnectorStatusPhase(args[1].Value.(string)))
+			}).
+			WithReply([]map[string]interface{}{{"id": len(history) + 1}})
+		mocket.Catcher.NewMock().
+			WithQuery(`SELECT "version" FROM "connector_deployments"`).
+			WithReply([]map[string]interface{}{{"version": i + 1}})
+
+		deployment := &dbapi.ConnectorDeployment{
+			Status: dbapi.ConnectorDeploymentStatus{Phase: phase},
+		}
+		deployment.ID = deploymentID
+		deployment.Status.ID = deploymentID
+		g.Expect(k.SaveDeployment(context.TODO(), deployment)).To(gomega.BeNil())
+		stored = phase
+	}
+
+	g.Expect(history).To(gomega.Equal([]dbapi.ConnectorStatusPhase{
+		dbapi.ConnectorStatusPhaseAssigned,
+		dbapi.ConnectorStatusPhaseReady,
+		dbapi.ConnectorStatusPhaseDeleting,
+	}))
+}
+
+func Test_connectorClusterService_SaveDeployment_StatusHistoryError(t *testing.T) {
+	const deploymentID = "deployment-id"
+	g := gomega.NewWithT(t)
+
+	k := &connectorClusterService{
+		connectionFactory: db.NewMockConnectionFactory(nil),
+	}
+
+	var versionRead bool
+	mocket.Catcher.Reset().
+		NewMock().
+		WithQuery(`SELECT "phase" FROM "connector_deployment_statuses"`).
+		WithArgs(deploymentID).
+		WithReply([]map[string]interface{}{{"phase": dbapi.ConnectorStatusPhaseAssigned}})
+	// the deployment is not saved without its status history
+	mocket.Catcher.NewMock().
+		WithQuery(`INSERT INTO "connector_deployment_status_histories"`).
+		WithQueryException()
+	mocket.Catcher.NewMock().
+		WithQuery(`SELECT "version" FROM "connector_deployments"`).
+		WithCallback(func(_ string, _ []driver.NamedValue) {
+			versionRead = true
+		})
+
+	deployment := &dbapi.ConnectorDeployment{
+		Status: dbapi.ConnectorDeploymentStatus{Phase: dbapi.ConnectorStatusPhaseReady},
+	}
+	deployment.ID = deploymentID
+	deployment.Status.ID = deploymentID
+	g.Expect(k.SaveDeployment(context.TODO(), deployment)).ToNot(gomega.BeNil())
+	g.Expect(versionRead).To(gomega.BeFalse())
+}
+
+func Test_connectorClusterService_UpdateConnectorDeploymentStatus_StatusHistory(t *testing.T) {
+	const deploymentID = "deployment-id"
+	g := gomega.NewWithT(t)
+
+	k := &connectorClusterService{
+		connectionFactory: db.NewMockConnectionFactory(nil),
+	}
+
+	// the agent reports the status of the assigned deployment through a sequence of phases
+	phases := []dbapi.ConnectorStatusPhase{
+		dbapi.ConnectorStatusPhaseReady,
+		dbapi.ConnectorStatusPhaseReady,
+		dbapi.ConnectorStatusPhaseFailed,
+		dbapi.ConnectorStatusPhaseReady,
+	}
+	stored := dbapi.ConnectorStatusPhaseAssigned
+	var history []dbapi.ConnectorStatusPhase
+	for i, phase := range phases {
+		mocket.Catcher.Reset().
+			NewMock().
+			WithQuery(`SELECT "connector_id","deleted_at" FROM "connector_deployments"`).
+			WithReply([]map[string]interface{}{{"connector_id": "connector-id"}})
+		mocket.Catcher.NewMock().
+			WithQuery(`SELECT "phase" FROM "connector_deployment_statuses"`).
+			WithArgs(deploymentID).
+			WithReply([]map[string]interface{}{{"phase": stored}})
+		mocket.Catcher.NewMock().
+			WithQuery(`UPDATE "connector_deployment_statuses"`).
+			WithRowsNum(1)
+		mocket.Catcher.NewMock().
+			WithQuery(`INSERT INTO "connector_deployment_status_histories"`).
+			WithCallback(func(_ string, args []driver.NamedValue) {
+				g.Expect(args[0].Value).To(gomega.Equal(deploymentID))
+				history = append(history, dbapi.ConnectorStatusPhase(args[1].Value.(string)))
+			}).
+			WithReply([]map[string]interface{}{{"id": len(history) + 1}})
+		mocket.Catcher.NewMock().
+			WithQuery(`SELECT "desired_state" FROM "connectors"`).
+			WithReply([]map[string]interface{}{{"desired_state": dbapi.ConnectorReady}})
+		mocket.Catcher.NewMock().
+			WithQuery(`SELECT "phase" FROM "connector_statuses"`).
+			WithReply([]map[string]interface{}{{"phase": stored}})
+		mocket.Catcher.NewMock().WithQuery(`UPDATE "connector_statuses"`).WithRowsNum(1)
+		mocket.Catcher.NewMock().WithExecException().WithQueryException()
+
+		status := dbapi.ConnectorDeploymentStatus{Phase: phase, Version: int64(i + 1)}
+		status.ID = deploymentID
+		g.Expect(k.UpdateConnectorDeploymentStatus(context.TODO(), status)).To(gomega.BeNil())
+		stored = phase
+	}
+
+	g.Expect(history).To(gomega.Equal([]dbapi.ConnectorStatusPhase{
+		dbapi.ConnectorStatusPhaseReady,
+		dbapi.ConnectorStatusPhaseFailed,
+		dbapi.ConnectorStatusPhaseReady,
+	}))
+}
+
+func Test_connectorClusterService_GetDeploymentStatusHistory(t *testing.T) {
+	const deploymentID = "deployment-id"
+
+	tests := []struct {
+		name    string
+		id      string
+		reply   []map[string]interface{}
+		want    []dbapi.ConnectorStatusPhase
+		wantErr bool
+	}{
+		{
+			name: "should return the history oldest first",
+			id:   deploymentID,
+			reply: []map[string]interface{}{
+				{"id": 1, "deployment_id": deploymentID, "phase": "assigned", "version": 1},
+				{"id": 2, "deployment_id": deploymentID, "phase": "ready", "version": 2},
+				{"id": 3, "deployment_id": deploymentID, "phase": "deleting", "version": 3},
+			},
+			want: []dbapi.ConnectorStatusPhase{
+				dbapi.ConnectorStatusPhaseAssigned,
+				dbapi.ConnectorStatusPhaseReady,
+				dbapi.ConnectorStatusPhaseDeleting,
+			},
+		},
+		{
+			name:  "should return an empty history for a deployment without status changes",
+			id:    deploymentID,
+			reply: []map[string]interface{}{},
+			want:  []dbapi.ConnectorStatusPhase{},
+		},
+		{
+			name:    "should return an error for an empty deployment id",
+			wantErr: true,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			mocket.Catcher.Reset().
+				NewMock().
+				WithQuery(`SELECT * FROM "connector_deployment_status_histories" WHERE deployment_id = $1 ORDER BY created_at, id`).
+				WithArgs(tt.id).
+				WithReply(tt.reply)
+			mocket.Catcher.NewMock().WithExecException().WithQueryException()
+
+			k := &connectorClusterService{
+				connectionFactory: db.NewMockConnectionFactory(nil),
+			}
+
+			got, err := k.GetDeploymentStatusHistory(tt.id)
+			g.Expect(err != nil).To(gomega.Equal(tt.wantErr))
+			if tt.wantErr {
+				return
+			}
+			phases := make([]dbapi.ConnectorStatusPhase, 0, len(got))
+			for _, h := range got {
+				g.Expect(h.DeploymentID).To(gomega.Equal(deploymentID))
+				phases = append(phases, h.Phase)
+			}
+			g.Expect(phases).To(gomega.Equal(tt.want))
+		})
+	}
+}
+
+func Test_connectorClusterService_ReconcileUnreachableClusters(t *testing.T) {
+	const clusterID = "cluster-id"
+
+	tests := []struct {
+		name      string
+		updateErr bool
+		wantCount int
+		wantErrs  int
+	}{
+		{
+			name:      "should move the connectors of an unreachable cluster to cluster_unreachable",
+			wantCount: 1,
+		},
+		{
+			name:      "should return an error if the connectors cannot be updated",
+			updateErr: true,
+			wantCount: 0,
+			wantErrs:  1,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+
+			var updated []interface{}
+			mocket.Catcher.Reset()
+			if !tt.updateErr {
+				mocket.Catcher.NewMock().
+					WithQuery(`UPDATE "connector_statuses" SET "phase"=$1,"reason"=$2`).
+					WithCallback(func(_ string, args []driver.NamedValue) {
+						for _, arg := range args {
+							updated = append(updated, arg.Value)
+						}
+					}).
+					WithRowsNum(2)
+			}
+			mocket.Catcher.NewMock().WithExecException().WithQueryException()
+
+			k := &connectorClusterService{
+				connectionFactory: db.NewMockConnectionFactory(nil),
+			}
+			count, errs := k.ReconcileUnreachableClusters(context.TODO(), []string{clusterID})
+			g.Expect(count).To(gomega.Equal(tt.wantCount))
+			g.Expect(errs).To(gomega.HaveLen(tt.wantErrs))
+			if tt.updateErr {
+				return
+			}
+			g.Expect(updated).To(gomega.ContainElements(
+				string(dbapi.ConnectorStatusPhaseClusterUnreachable),
+				"connector cluster cluster-id stopped reporting its status",
+				string(dbapi.ConnectorStatusPhaseReady),
+				clusterID,
+			))
+		})
+	}
+}
+
+func Test_connectorClusterService_ReconcileRecoveredClusters(t *testing.T) {
+	const clusterID = "cluster-id"
+	g := gomega.NewWithT(t)
+
+	var updated []interface{}
+	mocket.Catcher.Reset().
+		NewMock().
+		WithQuery(`UPDATE "connector_statuses" SET "phase"=COALESCE((SELECT NULLIF(connector_deployment_statuses.phase, '')`).
+		WithCallback(func(_ string, args []driver.NamedValue) {
+			for _, arg := range args {
+				updated = append(updated, arg.Value)
+			}
+		}).
+		WithRowsNum(2)
+	mocket.Catcher.NewMock().WithExecException().WithQueryException()
+
+	k := &connectorClusterService{
+		connectionFactory: db.NewMockConnectionFactory(nil),
+	}
+	count, errs := k.ReconcileRecoveredClusters(context.TODO(), []string{clusterID})
+	g.Expect(count).To(gomega.Equal(1))
+	g.Expect(errs).To(gomega.BeEmpty())
+
+	// the connectors of the cluster are restored from cluster_unreachable, clearing their reason
+	g.Expect(updated).To(gomega.ContainElements(
+		string(dbapi.ConnectorStatusPhaseAssigned),
+		"",
+		string(dbapi.ConnectorStatusPhaseClusterUnreachable),
+		clusterID,
+	))
+}
+
+func Test_connectorClusterService_ForceRedeployConnectorType(t *testing.T) {
+	tests := []struct {
+		name                string
+		typeId              string
+		channel             string
+		deployments         int64
+		wantCount           int64
+		noTxCtx             bool
+		wantConnectorUpdate bool
+		wantErr             bool
+	}{
+		{
+			name:                "should redeploy all the deployments of the connector type and channel",
+			typeId:              "log_sink_0.1",
+			channel:             "stable",
+			deployments:         3,
+			wantCount:           3,
+			wantConnectorUpdate: true,
+		},
+		{
+			name:        "should not update the connectors when the connector type has no deployment",
+			typeId:      "log_sink_0.1",
+			channel:     "stable",
+			deployments: 0,
+			wantCount:   0,
+		},
+		{
+			name:    "should return an error when the channel is missing",
+			typeId:  "log_sink_0.1",
+			wantErr: true,
+		},
+		{
+			name:    "should return an error when the context has no transaction",
+			typeId:  "log_sink_0.1",
+			channel: "stable",
+			noTxCtx: true,
+			wantErr: true,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+
+			var deploymentArgs, connectorArgs []interface{}
+			mocket.Catcher.Reset().NewMock().WithQuery("select txid_current()").
+				WithReply([]map[string]interface{}{{"txid_current": 1}})
+			mocket.Catcher.NewMock().
+				WithQuery(`UPDATE "connector_deployments" SET "redeploy_count"=redeploy_count + 1`).
+				WithCallback(func(_ string, args []driver.NamedValue) {
+					for _, arg := range args {
+						deploymentArgs = append(deploymentArgs, arg.Value)
+					}
+				}).
+				WithRowsNum(tt.deployments)
+			mocket.Catcher.NewMock().
+				WithQuery(`UPDATE "connectors" SET "updated_at"`).
+				WithCallback(func(_ string, args []driver.NamedValue) {
+					for _, arg := range args {
+						connectorArgs = append(connectorArgs, arg.Value)
+					}
+				}).
+				WithRowsNum(tt.deployments)
+			mocket.Catcher.NewMock().WithExecException().WithQueryException()
+
+			k := &connectorClusterService{
+				connectionFactory: db.NewMockConnectionFactory(nil),
+			}
+			ctx := context.TODO()
+			if !tt.noTxCtx {
+				var err error
+				ctx, err = k.connectionFactory.NewContext(ctx)
+				g.Expect(err).ToNot(gomega.HaveOccurred())
+			}
+			count, err := k.ForceRedeployConnectorType(ctx, tt.typeId, tt.channel)
+			if tt.wantErr {
+				g.Expect(err).ToNot(gomega.BeNil())
+				return
+			}
+			g.Expect(err).To(gomega.BeNil())
+			g.Expect(count).To(gomega.Equal(tt.wantCount))
+			g.Expect(deploymentArgs).To(gomega.ContainElements(tt.typeId, tt.channel))
+			if tt.wantConnectorUpdate {
+				g.Expect(connectorArgs).To(gomega.ContainElements(tt.typeId, tt.channel))
+			} else {
+				g.Expect(connectorArgs).To(gomega.BeEmpty())
+			}
+		})
+	}
+}
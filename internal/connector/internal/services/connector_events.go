@@ -0,0 +1,122 @@
+package services
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/config"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+)
+
+type ConnectorLifecycleEventType string
+
+const (
+	ConnectorLifecycleEventAssigned ConnectorLifecycleEventType = "org.bf2.connector.assigned"
+	ConnectorLifecycleEventDeleted  ConnectorLifecycleEventType = "org.bf2.connector.deleted"
+	ConnectorLifecycleEventFailed   ConnectorLifecycleEventType = "org.bf2.connector.failed"
+
+	connectorLifecycleEventSource      = "cos-fleet-manager"
+	connectorLifecycleEventSpecVersion = "1.0"
+	connectorLifecycleEventContentType = "application/cloudevents+json"
+)
+
+// ConnectorLifecycleEvent is a phase transition of a connector in the CloudEvents structured JSON format
+type ConnectorLifecycleEvent struct {
+	SpecVersion     string                      `json:"specversion"`
+	ID              string                      `json:"id"`
+	Source          string                      `json:"source"`
+	Type            ConnectorLifecycleEventType `json:"type"`
+	Subject         string                      `json:"subject"`
+	Time            time.Time                   `json:"time"`
+	DataContentType string                      `json:"datacontenttype"`
+	Data            ConnectorLifecycleEventData `json:"data"`
+}
+
+type ConnectorLifecycleEventData struct {
+	ID              string `json:"id"`
+	Name            string `json:"name"`
+	Owner           string `json:"owner"`
+	OrganisationID  string `json:"organisation_id"`
+	ConnectorTypeID string `json:"connector_type_id"`
+	NamespaceID     string `json:"namespace_id,omitempty"`
+	Phase           string `json:"phase"`
+	// Error is why the reconcile of the connector failed, only set for failed events
+	Error string `json:"error,omitempty"`
+}
+
+func NewConnectorLifecycleEvent(eventType ConnectorLifecycleEventType, connector *dbapi.Connector) ConnectorLifecycleEvent {
+	data := ConnectorLifecycleEventData{
+		ID:              connector.ID,
+		Name:            connector.Name,
+		Owner:           connector.Owner,
+		OrganisationID:  connector.OrganisationId,
+		ConnectorTypeID: connector.ConnectorTypeId,
+		Phase:           string(connector.Status.Phase),
+	}
+	if connector.Status.NamespaceID != nil {
+		data.NamespaceID = *connector.Status.NamespaceID
+	}
+
+	return ConnectorLifecycleEvent{
+		SpecVersion:     connectorLifecycleEventSpecVersion,
+		ID:              api.NewID(),
+		Source:          connectorLifecycleEventSource,
+		Type:            eventType,
+		Subject:         connector.ID,
+		Time:            time.Now().UTC(),
+		DataContentType: "application/json",
+		Data:            data,
+	}
+}
+
+// ConnectorLifecycleEventSink publishes the lifecycle events of the connectors to downstream systems
+type ConnectorLifecycleEventSink interface {
+	Emit(event ConnectorLifecycleEvent) error
+}
+
+// NewConnectorLifecycleEventSink returns a sink posting the events to the configured URL, or a sink discarding them
+// when no URL is configured
+func NewConnectorLifecycleEventSink(connectorsConfig *config.ConnectorsConfig) ConnectorLifecycleEventSink {
+	if connectorsConfig.LifecycleEventsSinkURL == "" {
+		return &noopConnectorLifecycleEventSink{}
+	}
+	return &httpConnectorLifecycleEventSink{
+		url:    connectorsConfig.LifecycleEventsSinkURL,
+		client: &http.Client{Timeout: connectorsConfig.LifecycleEventsSinkTimeout},
+	}
+}
+
+type noopConnectorLifecycleEventSink struct{}
+
+func (s *noopConnectorLifecycleEventSink) Emit(event ConnectorLifecycleEvent) error {
+	return nil
+}
+
+// httpConnectorLifecycleEventSink posts each event in the CloudEvents structured mode, e.g. to the topic endpoint of a
+// Kafka HTTP bridge
+type httpConnectorLifecycleEventSink struct {
+	url    string
+	client *http.Client
+}
+
+func (s *httpConnectorLifecycleEventSink) Emit(event ConnectorLifecycleEvent) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	resp, err := s.client.Post(s.url, connectorLifecycleEventContentType, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("unexpected status code %d from the connector lifecycle event sink", resp.StatusCode)
+	}
+	return nil
+}
@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/profiles"
 	"math/rand"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -21,6 +23,7 @@ import (
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/queryparser"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/signalbus"
+	"github.com/golang/glog"
 	"gorm.io/gorm"
 )
 
@@ -38,8 +41,13 @@ type ConnectorNamespaceService interface {
 	ReconcileUnusedDeletingNamespaces(ctx context.Context) (int64, *errors.ServiceError)
 	ReconcileUsedDeletingNamespaces(ctx context.Context) (int64, *errors.ServiceError)
 	ReconcileDeletedNamespaces(ctx context.Context) (int64, *errors.ServiceError)
+	// ReconcileOverCapacityClusters moves the connectors hosted by a cluster over the configured maximum number of
+	// connectors per cluster to another namespace of their tenant, starting with the connectors of the namespaces with
+	// the lowest eviction priority
+	ReconcileOverCapacityClusters(ctx context.Context) (int64, *errors.ServiceError)
 	GetNamespaceTenant(namespaceId string) (*dbapi.ConnectorNamespace, *errors.ServiceError)
 	CheckConnectorQuota(namespaceId string) *errors.ServiceError
+	GetNamespaceQuota(namespaceId string) (config.NamespaceQuota, *errors.ServiceError)
 	CanCreateEvalNamespace(userId string) *errors.ServiceError
 	GetEmptyDeletingNamespaces(clusterId string) (dbapi.ConnectorNamespaceList, *errors.ServiceError)
 }
@@ -145,6 +153,11 @@ func (k *connectorNamespaceService) validateAnnotations(request *dbapi.Connector
 				return errors.BadRequest(`invalid profile %s`, a.Value)
 			}
 		}
+		if a.Key == dbapi.ConnectorNamespaceEvictionPriorityAnnotation {
+			if _, err := parseEvictionPriority(a.Value); err != nil {
+				return errors.BadRequest(`invalid eviction priority %s: %s`, a.Value, err)
+			}
+		}
 	}
 	return nil
 }
@@ -670,6 +683,217 @@ func (k *connectorNamespaceService) ReconcileDeletedNamespaces(_ context.Context
 	return count, nil
 }
 
+// connectorEvictionCandidate is a connector of an over capacity cluster with the eviction priority of its namespace
+type connectorEvictionCandidate struct {
+	ID                   string
+	CreatedAt            time.Time
+	Priority             *string
+	TenantUserId         *string
+	TenantOrganisationId *string
+}
+
+// connectorEvictionTarget is a ready namespace evicted connectors can be moved to
+type connectorEvictionTarget struct {
+	ID                   string
+	ClusterId            string
+	TenantUserId         *string
+	TenantOrganisationId *string
+	Profile              *string
+	Connectors           int
+}
+
+// sameTenant returns true if the namespace belongs to the tenant of the candidate's namespace
+func (t *connectorEvictionTarget) sameTenant(candidate connectorEvictionCandidate) bool {
+	if candidate.TenantUserId != nil {
+		return t.TenantUserId != nil && *t.TenantUserId == *candidate.TenantUserId
+	}
+	return candidate.TenantOrganisationId != nil && t.TenantOrganisationId != nil &&
+		*t.TenantOrganisationId == *candidate.TenantOrganisationId
+}
+
+func (k *connectorNamespaceService) ReconcileOverCapacityClusters(ctx context.Context) (int64, *errors.ServiceError) {
+	maxConnectors := k.connectorsConfig.MaxConnectorsPerCluster
+	if maxConnectors <= 0 {
+		return 0, nil
+	}
+
+	// connectors being deleted or unassigned are not hosted by their cluster anymore
+	excludedStates := []string{string(dbapi.ConnectorDeleted), string(dbapi.ConnectorUnassigned)}
+	var count int64
+	if err := k.connectionFactory.New().Transaction(func(dbConn *gorm.DB) error {
+
+		// get the number of connectors that are not being deleted or unassigned hosted by every cluster
+		var clusters []struct {
+			ClusterId  string
+			Connectors int
+		}
+		if err := dbConn.Table("connectors").Select("connector_namespaces.cluster_id, count(*) AS connectors").
+			Joins("JOIN connector_namespaces ON connector_namespaces.id = connectors.namespace_id AND "+
+				"connector_namespaces.deleted_at IS NULL").
+			Where("connectors.deleted_at IS NULL AND connectors.desired_state NOT IN ?", excludedStates).
+			Group("connector_namespaces.cluster_id").
+			Order("connector_namespaces.cluster_id").
+			Scan(&clusters).Error; err != nil {
+			return services.HandleGetError("Connector", "cluster_id", "over capacity", err)
+		}
+		clusterConnectors := make(map[string]int, len(clusters))
+		var overCapacityClusterIds []string
+		for _, cluster := range clusters {
+			clusterConnectors[cluster.ClusterId] = cluster.Connectors
+			if cluster.Connectors > maxConnectors {
+				overCapacityClusterIds = append(overCapacityClusterIds, cluster.ClusterId)
+			}
+		}
+		if len(overCapacityClusterIds) == 0 {
+			return nil
+		}
+
+		// get the ready namespaces the evicted connectors can be moved to, with their number of connectors
+		var targets []*connectorEvictionTarget
+		if err := dbConn.Table("connector_namespaces").
+			Select("connector_namespaces.id, connector_namespaces.cluster_id, connector_namespaces.tenant_user_id, "+
+				"connector_namespaces.tenant_organisation_id, connector_namespace_annotations.value AS profile, "+
+				"count(connectors.id) AS connectors").
+			Joins("LEFT JOIN connectors ON connectors.namespace_id = connector_namespaces.id AND "+
+				"connectors.deleted_at IS NULL AND connectors.desired_state NOT IN ?", excludedStates).
+			Joins("LEFT JOIN connector_namespace_annotations ON "+
+				"connector_namespace_annotations.namespace_id = connector_namespaces.id AND "+
+				"connector_namespace_annotations.key = ?", profiles.AnnotationProfileKey).
+			Where("connector_namespaces.deleted_at IS NULL AND connector_namespaces.status_phase = ?",
+				dbapi.ConnectorNamespacePhaseReady).
+			Group("connector_namespaces.id, connector_namespace_annotations.value").
+			Order("connector_namespaces.created_at").
+			Scan(&targets).Error; err != nil {
+			return services.HandleGetError("Connector namespace", "status_phase", dbapi.ConnectorNamespacePhaseReady, err)
+		}
+
+		var connectorIds, namespaceIds []string
+		for _, clusterId := range overCapacityClusterIds {
+			// only running connectors are moved, moving a stopped connector would start it
+			var candidates []connectorEvictionCandidate
+			if err := dbConn.Table("connectors").
+				Select("connectors.id, connectors.created_at, connector_namespace_annotations.value AS priority, "+
+					"connector_namespaces.tenant_user_id, connector_namespaces.tenant_organisation_id").
+				Joins("JOIN connector_namespaces ON connector_namespaces.id = connectors.namespace_id AND "+
+					"connector_namespaces.deleted_at IS NULL").
+				Joins("LEFT JOIN connector_namespace_annotations ON "+
+					"connector_namespace_annotations.namespace_id = connectors.namespace_id AND "+
+					"connector_namespace_annotations.key = ?", dbapi.ConnectorNamespaceEvictionPriorityAnnotation).
+				Where("connector_namespaces.cluster_id = ? AND connectors.deleted_at IS NULL AND "+
+					"connectors.desired_state = ?", clusterId, dbapi.ConnectorReady).
+				Scan(&candidates).Error; err != nil {
+				return services.HandleGetError("Connector", "cluster_id", clusterId, err)
+			}
+
+			sortByEvictionPriority(candidates)
+			for _, candidate := range candidates {
+				if clusterConnectors[clusterId] <= maxConnectors {
+					break
+				}
+				target := k.findEvictionTarget(targets, candidate, clusterId, clusterConnectors, maxConnectors)
+				if target == nil {
+					glog.V(5).Infof("no namespace to move connector %s of over capacity cluster %s to", candidate.ID, clusterId)
+					continue
+				}
+				connectorIds = append(connectorIds, candidate.ID)
+				namespaceIds = append(namespaceIds, target.ID)
+				target.Connectors++
+				clusterConnectors[target.ClusterId]++
+				clusterConnectors[clusterId]--
+			}
+			if clusterConnectors[clusterId] > maxConnectors {
+				glog.Warningf("cluster %s hosts %d connectors over the maximum of %d, no namespace to move them to",
+					clusterId, clusterConnectors[clusterId]-maxConnectors, maxConnectors)
+			}
+		}
+
+		// set moved connectors' target namespace, desired state to 'unassigned' and status to 'deleting' to remove them
+		// from their namespace, the connector manager assigns them to their target namespace once removed
+		for i := range connectorIds {
+			if err := dbConn.Where("deleted_at IS NULL AND id = ?", connectorIds[i]).
+				Updates(&dbapi.Connector{DesiredState: dbapi.ConnectorUnassigned, TargetNamespaceId: &namespaceIds[i]}).Error; err != nil {
+				return services.HandleUpdateError("Connector", err)
+			}
+			glog.Infof("moving connector %s to namespace %s", connectorIds[i], namespaceIds[i])
+		}
+		if len(connectorIds) > 0 {
+			if err := dbConn.Where("deleted_at IS NULL AND id IN ? AND phase NOT IN ?",
+				connectorIds, []string{string(dbapi.ConnectorStatusPhaseDeleting), string(dbapi.ConnectorStatusPhaseDeleted)}).
+				Updates(&dbapi.ConnectorStatus{Phase: dbapi.ConnectorStatusPhaseDeleting}).Error; err != nil {
+				return services.HandleUpdateError("Connector", err)
+			}
+		}
+		count = int64(len(connectorIds))
+
+		return nil
+
+	}); err != nil {
+		return 0, services.HandleUpdateError("Connector", err)
+	}
+
+	if count > 0 {
+		// notify connector status update
+		_ = db.AddPostCommitAction(ctx, func() {
+			k.bus.Notify("reconcile:connector")
+		})
+	}
+
+	return count, nil
+}
+
+// findEvictionTarget returns the first namespace of the tenant of the candidate, in another cluster under capacity,
+// that has quota left for one more connector. It returns nil if there is no such namespace.
+func (k *connectorNamespaceService) findEvictionTarget(targets []*connectorEvictionTarget, candidate connectorEvictionCandidate,
+	clusterId string, clusterConnectors map[string]int, maxConnectors int) *connectorEvictionTarget {
+	for _, target := range targets {
+		if target.ClusterId == clusterId || !target.sameTenant(candidate) ||
+			clusterConnectors[target.ClusterId] >= maxConnectors {
+			continue
+		}
+		if target.Profile != nil {
+			if quota, ok := k.quotaConfig.GetNamespaceQuota(*target.Profile); ok &&
+				quota.Connectors > 0 && target.Connectors >= int(quota.Connectors) {
+				continue
+			}
+		}
+		return target
+	}
+	return nil
+}
+
+// sortByEvictionPriority sorts the candidates in eviction order, i.e. by lowest eviction priority first and, for the
+// same priority, by most recently created first
+func sortByEvictionPriority(candidates []connectorEvictionCandidate) {
+	priority := func(candidate connectorEvictionCandidate) int {
+		if candidate.Priority == nil {
+			return dbapi.DefaultConnectorEvictionPriority
+		}
+		p, err := parseEvictionPriority(*candidate.Priority)
+		if err != nil {
+			return dbapi.DefaultConnectorEvictionPriority
+		}
+		return p
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		pi, pj := priority(candidates[i]), priority(candidates[j])
+		if pi != pj {
+			return pi < pj
+		}
+		return candidates[i].CreatedAt.After(candidates[j].CreatedAt)
+	})
+}
+
+func parseEvictionPriority(value string) (int, error) {
+	p, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("must be an integer")
+	}
+	if p < dbapi.MinConnectorEvictionPriority || p > dbapi.MaxConnectorEvictionPriority {
+		return 0, fmt.Errorf("must be between %d and %d", dbapi.MinConnectorEvictionPriority, dbapi.MaxConnectorEvictionPriority)
+	}
+	return p, nil
+}
+
 func (k *connectorNamespaceService) GetNamespaceTenant(namespaceId string) (*dbapi.ConnectorNamespace, *errors.ServiceError) {
 	dbConn := k.connectionFactory.New()
 	var namespace dbapi.ConnectorNamespace
@@ -683,18 +907,27 @@ func (k *connectorNamespaceService) GetNamespaceTenant(namespaceId string) (*dba
 	return &namespace, nil
 }
 
-func (k *connectorNamespaceService) CheckConnectorQuota(namespaceId string) *errors.ServiceError {
+// GetNamespaceQuota returns the quota of the profile set in the annotations of the given namespace
+func (k *connectorNamespaceService) GetNamespaceQuota(namespaceId string) (config.NamespaceQuota, *errors.ServiceError) {
 	dbConn := k.connectionFactory.New()
 	var profileName string
-	var quota config.NamespaceQuota
 	if err := dbConn.Model(&dbapi.ConnectorNamespaceAnnotation{}).
 		Where("namespace_id = ? AND key = ?", namespaceId, profiles.AnnotationProfileKey).
 		Select("value").First(&profileName).Error; err != nil {
-		return errors.FailedToCheckQuota("Error reading Connector namespace annotation with namespace id %s: %s", namespaceId, err)
+		return config.NamespaceQuota{}, errors.FailedToCheckQuota("Error reading Connector namespace annotation with namespace id %s: %s", namespaceId, err)
+	}
+	quota, _ := k.quotaConfig.GetNamespaceQuota(profileName)
+	return quota, nil
+}
+
+func (k *connectorNamespaceService) CheckConnectorQuota(namespaceId string) *errors.ServiceError {
+	quota, err := k.GetNamespaceQuota(namespaceId)
+	if err != nil {
+		return err
 	}
-	quota, _ = k.quotaConfig.GetNamespaceQuota(profileName)
 	if quota.Connectors > 0 {
 		// get number of connectors using this namespace
+		dbConn := k.connectionFactory.New()
 		var count int64
 		if err := dbConn.Model(&dbapi.Connector{}).Where("namespace_id = ?", namespaceId).
 			Count(&count).Error; err != nil {
@@ -0,0 +1,156 @@
+package services
+
+import (
+	"context"
+	"database/sql/driver"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/config"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/onsi/gomega"
+	mocket "github.com/selvatico/go-mocket"
+)
+
+func Test_sortByEvictionPriority(t *testing.T) {
+	g := gomega.NewWithT(t)
+	now := time.Now()
+	priority := func(p string) *string { return &p }
+
+	candidates := []connectorEvictionCandidate{
+		{ID: "high", CreatedAt: now, Priority: priority("90")},
+		{ID: "unset-old", CreatedAt: now.Add(-time.Hour)},
+		{ID: "invalid", CreatedAt: now.Add(-2 * time.Hour), Priority: priority("low")},
+		{ID: "low", CreatedAt: now.Add(-time.Hour), Priority: priority("10")},
+		{ID: "unset-new", CreatedAt: now},
+	}
+	sortByEvictionPriority(candidates)
+
+	var ids []string
+	for _, c := range candidates {
+		ids = append(ids, c.ID)
+	}
+	// unprioritized connectors and connectors with an invalid priority default to the middle priority
+	g.Expect(ids).To(gomega.Equal([]string{"low", "unset-new", "unset-old", "invalid", "high"}))
+}
+
+func Test_connectorNamespaceService_ReconcileOverCapacityClusters(t *testing.T) {
+	const clusterID = "cluster-id"
+	const otherClusterID = "other-cluster-id"
+	now := time.Now()
+	candidates := []map[string]interface{}{
+		{"id": "connector-high", "created_at": now, "priority": "90", "tenant_user_id": "user-id"},
+		{"id": "connector-unset-old", "created_at": now.Add(-time.Hour), "priority": nil, "tenant_user_id": "user-id"},
+		{"id": "connector-low", "created_at": now.Add(-time.Hour), "priority": "10", "tenant_user_id": "user-id"},
+		{"id": "connector-unset-new", "created_at": now, "priority": nil, "tenant_user_id": "user-id"},
+	}
+
+	tests := []struct {
+		name          string
+		maxConnectors int
+		clusters      []map[string]interface{}
+		targets       []map[string]interface{}
+		candidates    []map[string]interface{}
+		wantCount     int64
+		wantMoved     []string
+		wantTargets   []string
+	}{
+		{
+			name:          "should not move connectors when the eviction is disabled",
+			maxConnectors: 0,
+			wantCount:     0,
+		},
+		{
+			name:          "should not move connectors when no cluster is over capacity",
+			maxConnectors: 2,
+			clusters:      []map[string]interface{}{{"cluster_id": clusterID, "connectors": 2}},
+			wantCount:     0,
+		},
+		{
+			name:          "should move the connectors with the lowest priority first",
+			maxConnectors: 2,
+			clusters:      []map[string]interface{}{{"cluster_id": clusterID, "connectors": 4}},
+			targets: []map[string]interface{}{
+				{"id": "namespace-source", "cluster_id": clusterID, "tenant_user_id": "user-id", "connectors": 4},
+				{"id": "namespace-target", "cluster_id": otherClusterID, "tenant_user_id": "user-id", "connectors": 0},
+			},
+			candidates:  candidates,
+			wantCount:   2,
+			wantMoved:   []string{"connector-low", "connector-unset-new"},
+			wantTargets: []string{"namespace-target", "namespace-target"},
+		},
+		{
+			name:          "should not move connectors to a namespace of another tenant",
+			maxConnectors: 2,
+			clusters:      []map[string]interface{}{{"cluster_id": clusterID, "connectors": 4}},
+			targets: []map[string]interface{}{
+				{"id": "namespace-target", "cluster_id": otherClusterID, "tenant_user_id": "other-user-id", "connectors": 0},
+			},
+			candidates: candidates,
+			wantCount:  0,
+		},
+		{
+			name:          "should not move connectors to a cluster at capacity",
+			maxConnectors: 2,
+			clusters: []map[string]interface{}{
+				{"cluster_id": clusterID, "connectors": 4},
+				{"cluster_id": otherClusterID, "connectors": 1},
+			},
+			targets: []map[string]interface{}{
+				{"id": "namespace-target", "cluster_id": otherClusterID, "tenant_user_id": "user-id", "connectors": 1},
+			},
+			candidates:  candidates,
+			wantCount:   1,
+			wantMoved:   []string{"connector-low"},
+			wantTargets: []string{"namespace-target"},
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+
+			var moved, targets []string
+			mocket.Catcher.Reset()
+			mocket.Catcher.NewMock().
+				WithQuery(`count(*) AS connectors`).
+				WithReply(tt.clusters)
+			mocket.Catcher.NewMock().
+				WithQuery(`count(connectors.id) AS connectors`).
+				WithReply(tt.targets)
+			mocket.Catcher.NewMock().
+				WithQuery(`connector_namespace_annotations.value AS priority`).
+				WithReply(tt.candidates)
+			mocket.Catcher.NewMock().
+				WithQuery(`UPDATE "connectors" SET`).
+				WithCallback(func(_ string, args []driver.NamedValue) {
+					for _, arg := range args {
+						if id, ok := arg.Value.(string); ok && strings.HasPrefix(id, "connector-") {
+							moved = append(moved, id)
+						}
+						if id, ok := arg.Value.(string); ok && strings.HasPrefix(id, "namespace-") {
+							targets = append(targets, id)
+						}
+					}
+				}).
+				WithRowsNum(1)
+			mocket.Catcher.NewMock().
+				WithQuery(`UPDATE "connector_statuses" SET`).
+				WithRowsNum(int64(len(tt.wantMoved)))
+			mocket.Catcher.NewMock().WithExecException().WithQueryException()
+
+			k := &connectorNamespaceService{
+				connectionFactory: db.NewMockConnectionFactory(nil),
+				connectorsConfig:  &config.ConnectorsConfig{MaxConnectorsPerCluster: tt.maxConnectors},
+				quotaConfig:       config.NewConnectorsQuotaConfig(),
+			}
+			count, err := k.ReconcileOverCapacityClusters(context.TODO())
+			g.Expect(err).To(gomega.BeNil())
+			g.Expect(count).To(gomega.Equal(tt.wantCount))
+			g.Expect(moved).To(gomega.Equal(tt.wantMoved))
+			g.Expect(targets).To(gomega.Equal(tt.wantTargets))
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package services
+
+import (
+	"fmt"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/config"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+)
+
+// ConnectorQuotaService checks the connector quota of owners and organisations
+type ConnectorQuotaService interface {
+	// CheckConnectorQuota returns whether the owner and the organisation of the connector are within their quota of
+	// deployed connectors, i.e. whether the connector can be deployed, and the reason when they are not
+	CheckConnectorQuota(connector *dbapi.Connector) (bool, string, *errors.ServiceError)
+}
+
+var _ ConnectorQuotaService = &connectorQuotaService{}
+
+type connectorQuotaService struct {
+	connectionFactory *db.ConnectionFactory
+	quotaConfig       *config.ConnectorsQuotaConfig
+}
+
+func NewConnectorQuotaService(connectionFactory *db.ConnectionFactory, quotaConfig *config.ConnectorsQuotaConfig) *connectorQuotaService {
+	return &connectorQuotaService{
+		connectionFactory: connectionFactory,
+		quotaConfig:       quotaConfig,
+	}
+}
+
+func (q *connectorQuotaService) CheckConnectorQuota(connector *dbapi.Connector) (bool, string, *errors.ServiceError) {
+	if q.quotaConfig.MaxConnectorsPerOwner > 0 {
+		count, err := q.countDeployedConnectors("owner", connector.Owner)
+		if err != nil {
+			return false, "", err
+		}
+		if count >= int64(q.quotaConfig.MaxConnectorsPerOwner) {
+			return false, fmt.Sprintf("owner %s has reached the quota of %d deployed connectors",
+				connector.Owner, q.quotaConfig.MaxConnectorsPerOwner), nil
+		}
+	}
+
+	if q.quotaConfig.MaxConnectorsPerOrganisation > 0 && connector.OrganisationId != "" {
+		count, err := q.countDeployedConnectors("organisation_id", connector.OrganisationId)
+		if err != nil {
+			return false, "", err
+		}
+		if count >= int64(q.quotaConfig.MaxConnectorsPerOrganisation) {
+			return false, fmt.Sprintf("organisation %s has reached the quota of %d deployed connectors",
+				connector.OrganisationId, q.quotaConfig.MaxConnectorsPerOrganisation), nil
+		}
+	}
+
+	return true, "", nil
+}
+
+// countDeployedConnectors returns the number of connectors with a deployment whose column has the given value
+func (q *connectorQuotaService) countDeployedConnectors(column string, value string) (int64, *errors.ServiceError) {
+	var count int64
+	if err := q.connectionFactory.New().Model(&dbapi.ConnectorDeployment{}).
+		Joins("JOIN connectors ON connectors.id = connector_deployments.connector_id AND connectors.deleted_at IS NULL").
+		Where(fmt.Sprintf("connectors.%s = ?", column), value).
+		Count(&count).Error; err != nil {
+		return 0, errors.FailedToCheckQuota("failed to count deployed connectors with %s %s: %v", column, value, err)
+	}
+	return count, nil
+}
@@ -0,0 +1,83 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/config"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/onsi/gomega"
+	mocket "github.com/selvatico/go-mocket"
+)
+
+func Test_connectorQuotaService_CheckConnectorQuota(t *testing.T) {
+	tests := []struct {
+		name                    string
+		maxPerOwner             int
+		maxPerOrganisation      int
+		ownerDeployments        int
+		organisationDeployments int
+		want                    bool
+		wantReason              string
+	}{
+		{
+			name:                    "should be within quota when there are no limits",
+			ownerDeployments:        10,
+			organisationDeployments: 10,
+			want:                    true,
+		},
+		{
+			name:                    "should be within quota when the owner and organisation are under their limits",
+			maxPerOwner:             2,
+			maxPerOrganisation:      4,
+			ownerDeployments:        1,
+			organisationDeployments: 3,
+			want:                    true,
+		},
+		{
+			name:                    "should be over quota when the owner has reached the limit",
+			maxPerOwner:             2,
+			maxPerOrganisation:      4,
+			ownerDeployments:        2,
+			organisationDeployments: 2,
+			want:                    false,
+			wantReason:              "owner owner-id has reached the quota of 2 deployed connectors",
+		},
+		{
+			name:                    "should be over quota when the organisation has reached the limit",
+			maxPerOwner:             2,
+			maxPerOrganisation:      4,
+			ownerDeployments:        1,
+			organisationDeployments: 4,
+			want:                    false,
+			wantReason:              "organisation org-id has reached the quota of 4 deployed connectors",
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			mocket.Catcher.Reset().
+				NewMock().
+				WithQuery(`WHERE (connectors.owner = $1)`).
+				WithArgs("owner-id").
+				WithReply([]map[string]interface{}{{"count": tt.ownerDeployments}})
+			mocket.Catcher.NewMock().
+				WithQuery(`WHERE (connectors.organisation_id = $1)`).
+				WithArgs("org-id").
+				WithReply([]map[string]interface{}{{"count": tt.organisationDeployments}})
+			mocket.Catcher.NewMock().WithExecException().WithQueryException()
+
+			quotaConfig := config.NewConnectorsQuotaConfig()
+			quotaConfig.MaxConnectorsPerOwner = tt.maxPerOwner
+			quotaConfig.MaxConnectorsPerOrganisation = tt.maxPerOrganisation
+			q := NewConnectorQuotaService(db.NewMockConnectionFactory(nil), quotaConfig)
+
+			got, reason, err := q.CheckConnectorQuota(&dbapi.Connector{Owner: "owner-id", OrganisationId: "org-id"})
+			g.Expect(err).To(gomega.BeNil())
+			g.Expect(got).To(gomega.Equal(tt.want))
+			g.Expect(reason).To(gomega.Equal(tt.wantReason))
+		})
+	}
+}
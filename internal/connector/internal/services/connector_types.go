@@ -9,6 +9,7 @@ package services
 import (
 	"database/sql"
 	"strings"
+	"sync"
 
 	"gorm.io/gorm"
 
@@ -21,14 +22,17 @@ import (
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services"
 	coreService "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/queryparser"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared/utils/arrays"
 	"github.com/golang/glog"
 )
 
 type ConnectorTypesService interface {
 	Get(id string) (*dbapi.ConnectorType, *errors.ServiceError)
 	List(listArgs *services.ListArguments) (dbapi.ConnectorTypeList, *api.PagingMeta, *errors.ServiceError)
+	ListByLabel(labels []string, listArgs *services.ListArguments) (dbapi.ConnectorTypeList, *api.PagingMeta, *errors.ServiceError)
 	ListLabels(listArgs *services.ListArguments) (dbapi.ConnectorTypeLabelCountList, *errors.ServiceError)
 	ForEachConnectorCatalogEntry(f func(id string, channel string, ccc *config.ConnectorChannelConfig) *errors.ServiceError) *errors.ServiceError
+	ReloadCatalog() *errors.ServiceError
 
 	PutConnectorShardMetadata(ctc *dbapi.ConnectorShardMetadata) (int64, *errors.ServiceError)
 	GetConnectorShardMetadata(typeId, channel string, revision int64) (*dbapi.ConnectorShardMetadata, *errors.ServiceError)
@@ -37,6 +41,18 @@ type ConnectorTypesService interface {
 	DeleteUnusedAndNotInCatalog() *errors.ServiceError
 	ListCatalogEntries(*coreService.ListArguments) ([]dbapi.ConnectorCatalogEntry, *api.PagingMeta, *errors.ServiceError)
 	GetCatalogEntry(tyd string) (*dbapi.ConnectorCatalogEntry, *errors.ServiceError)
+	// GetDisplayMetadata returns the metadata of a connector type shown by the UI, as found in the loaded catalog
+	GetDisplayMetadata(typeId string) (*ConnectorTypeMetadata, *errors.ServiceError)
+}
+
+// ConnectorTypeMetadata is the display metadata of a connector type
+type ConnectorTypeMetadata struct {
+	Id          string
+	Name        string
+	Description string
+	Version     string
+	// IconHref is the URL to an icon of the connector type, it is empty when the catalog entry has none
+	IconHref string
 }
 
 var _ ConnectorTypesService = &connectorTypesService{}
@@ -44,6 +60,8 @@ var _ ConnectorTypesService = &connectorTypesService{}
 type connectorTypesService struct {
 	connectorsConfig  *config.ConnectorsConfig
 	connectionFactory *db.ConnectionFactory
+	// catalogMu guards the catalog entries and checksums of connectorsConfig, which ReloadCatalog replaces
+	catalogMu sync.RWMutex
 }
 
 func NewConnectorTypesService(connectorsConfig *config.ConnectorsConfig, connectionFactory *db.ConnectionFactory) *connectorTypesService {
@@ -130,13 +148,40 @@ func GetValidConnectorTypeColumns() []string {
 
 // List returns all connector types
 func (cts *connectorTypesService) List(listArgs *services.ListArguments) (dbapi.ConnectorTypeList, *api.PagingMeta, *errors.ServiceError) {
+	return cts.list(cts.connectionFactory.New(), listArgs)
+}
+
+// ListByLabel returns the connector types that have all the given catalog labels
+func (cts *connectorTypesService) ListByLabel(labels []string, listArgs *services.ListArguments) (dbapi.ConnectorTypeList, *api.PagingMeta, *errors.ServiceError) {
+	if len(labels) == 0 {
+		return nil, nil, errors.Validation("at least one connector type label is required")
+	}
+
+	// ignore duplicate labels, as they would never match the count of distinct labels of a connector type
+	var distinctLabels []string
+	for _, label := range labels {
+		if !arrays.Contains(distinctLabels, label) {
+			distinctLabels = append(distinctLabels, label)
+		}
+	}
+
+	dbConn := cts.connectionFactory.New()
+	typesWithLabels := dbConn.Table("connector_type_labels").
+		Select("connector_type_id").
+		Where("label IN ?", distinctLabels).
+		Group("connector_type_id").
+		Having("COUNT(DISTINCT label) = ?", len(distinctLabels))
+
+	return cts.list(cts.connectionFactory.New().Where("connector_types.id IN (?)", typesWithLabels), listArgs)
+}
+
+func (cts *connectorTypesService) list(dbConn *gorm.DB, listArgs *services.ListArguments) (dbapi.ConnectorTypeList, *api.PagingMeta, *errors.ServiceError) {
 	if err := listArgs.Validate(GetValidConnectorTypeColumns()); err != nil {
 		return nil, nil, errors.NewWithCause(errors.ErrorMalformedRequest, err, "Unable to list connector type requests: %s", err.Error())
 	}
 
 	//var resourceList dbapi.ConnectorTypeList
 	var resourceList dbapi.ConnectorTypeList
-	dbConn := cts.connectionFactory.New()
 	pagingMeta := &api.PagingMeta{
 		Page: listArgs.Page,
 		Size: listArgs.Size,
@@ -250,9 +295,17 @@ func (cts *connectorTypesService) ListLabels(listArgs *services.ListArguments) (
 	return resourceList, nil
 }
 
+// catalog returns the loaded catalog entries and checksums, they are replaced and never modified by ReloadCatalog
+func (cts *connectorTypesService) catalog() ([]config.ConnectorCatalogEntry, map[string]string) {
+	cts.catalogMu.RLock()
+	defer cts.catalogMu.RUnlock()
+	return cts.connectorsConfig.CatalogEntries, cts.connectorsConfig.CatalogChecksums
+}
+
 func (cts *connectorTypesService) ForEachConnectorCatalogEntry(f func(id string, channel string, ccc *config.ConnectorChannelConfig) *errors.ServiceError) *errors.ServiceError {
 
-	for _, entry := range cts.connectorsConfig.CatalogEntries {
+	catalogEntries, catalogChecksums := cts.catalog()
+	for _, entry := range catalogEntries {
 		// create/update connector type
 		connectorType, err := presenters.ConvertConnectorType(entry.ConnectorType)
 		if err != nil {
@@ -274,13 +327,50 @@ func (cts *connectorTypesService) ForEachConnectorCatalogEntry(f func(id string,
 		// update type checksum for latest catalog shard metadata
 		dbConn := cts.connectionFactory.New()
 		if err = dbConn.Model(connectorType).Where("id = ?", connectorType.ID).
-			UpdateColumn("checksum", cts.connectorsConfig.CatalogChecksums[connectorType.ID]).Error; err != nil {
+			UpdateColumn("checksum", catalogChecksums[connectorType.ID]).Error; err != nil {
 			return errors.GeneralError("failed to update connector type %s checksum: %v", entry.ConnectorType.Id, err.Error())
 		}
 	}
 	return nil
 }
 
+// ReloadCatalog reads the connector catalog directories again, the loaded catalog is only replaced if all the entries are valid
+func (cts *connectorTypesService) ReloadCatalog() *errors.ServiceError {
+	reloaded := &config.ConnectorsConfig{
+		ConnectorCatalogDirs: cts.connectorsConfig.ConnectorCatalogDirs,
+		CatalogChecksums:     make(map[string]string),
+	}
+	if err := reloaded.ReadFiles(); err != nil {
+		return errors.GeneralError("failed to reload connector catalogs: %v", err)
+	}
+
+	cts.catalogMu.Lock()
+	defer cts.catalogMu.Unlock()
+	cts.connectorsConfig.CatalogEntries = reloaded.CatalogEntries
+	cts.connectorsConfig.CatalogChecksums = reloaded.CatalogChecksums
+	return nil
+}
+
+func (cts *connectorTypesService) GetDisplayMetadata(typeId string) (*ConnectorTypeMetadata, *errors.ServiceError) {
+	if typeId == "" {
+		return nil, errors.Validation("TypeId is empty.")
+	}
+
+	catalogEntries, _ := cts.catalog()
+	for _, entry := range catalogEntries {
+		if entry.ConnectorType.Id == typeId {
+			return &ConnectorTypeMetadata{
+				Id:          entry.ConnectorType.Id,
+				Name:        entry.ConnectorType.Name,
+				Description: entry.ConnectorType.Description,
+				Version:     entry.ConnectorType.Version,
+				IconHref:    entry.ConnectorType.IconHref,
+			}, nil
+		}
+	}
+	return nil, errors.NotFound("Connector type with id='%s' not found in the catalog", typeId)
+}
+
 func (cts *connectorTypesService) PutConnectorShardMetadata(connectorShardMetadata *dbapi.ConnectorShardMetadata) (int64, *errors.ServiceError) {
 
 	var resource dbapi.ConnectorShardMetadata
@@ -382,7 +472,7 @@ func (cts *connectorTypesService) GetLatestConnectorShardMetadata(typeId, channe
 
 func (cts *connectorTypesService) CatalogEntriesReconciled() (bool, *errors.ServiceError) {
 	var typeIds []string
-	catalogChecksums := cts.connectorsConfig.CatalogChecksums
+	_, catalogChecksums := cts.catalog()
 	for id := range catalogChecksums {
 		typeIds = append(typeIds, id)
 	}
@@ -406,8 +496,9 @@ func (cts *connectorTypesService) CatalogEntriesReconciled() (bool, *errors.Serv
 }
 
 func (cts *connectorTypesService) DeleteUnusedAndNotInCatalog() *errors.ServiceError {
-	notToBeDeletedIDs := make([]string, len(cts.connectorsConfig.CatalogEntries))
-	for _, entry := range cts.connectorsConfig.CatalogEntries {
+	catalogEntries, _ := cts.catalog()
+	notToBeDeletedIDs := make([]string, len(catalogEntries))
+	for _, entry := range catalogEntries {
 		notToBeDeletedIDs = append(notToBeDeletedIDs, entry.ConnectorType.Id)
 	}
 	glog.V(5).Infof("Connector Type IDs in catalog not to be deleted: %v", notToBeDeletedIDs)
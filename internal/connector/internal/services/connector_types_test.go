@@ -0,0 +1,139 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/api/public"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/config"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services"
+	"github.com/onsi/gomega"
+	mocket "github.com/selvatico/go-mocket"
+)
+
+func Test_connectorTypesService_ListByLabel(t *testing.T) {
+	tests := []struct {
+		name      string
+		labels    []string
+		wantArgs  []interface{}
+		wantTypes []string
+		wantErr   bool
+	}{
+		{
+			name:      "should list the connector types with a single label",
+			labels:    []string{"database"},
+			wantArgs:  []interface{}{"database", int64(1)},
+			wantTypes: []string{"postgres-source", "mysql-source"},
+		},
+		{
+			name:      "should list the connector types with all of multiple labels",
+			labels:    []string{"database", "aws", "aws"},
+			wantArgs:  []interface{}{"database", "aws", int64(2)},
+			wantTypes: []string{"aws-rds-source"},
+		},
+		{
+			name:    "should return an error if no label is given",
+			labels:  []string{},
+			wantErr: true,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+
+			var reply []map[string]interface{}
+			for _, id := range tt.wantTypes {
+				reply = append(reply, map[string]interface{}{"id": id, "name": id})
+			}
+			mocket.Catcher.Reset()
+			mocket.Catcher.NewMock().
+				WithQuery(`SELECT count(1) FROM "connector_types" WHERE (connector_types.id IN (`).
+				WithArgs(tt.wantArgs...).
+				WithReply([]map[string]interface{}{{"count": len(tt.wantTypes)}})
+			mocket.Catcher.NewMock().
+				WithQuery(`SELECT * FROM "connector_types" WHERE (connector_types.id IN (`).
+				WithArgs(tt.wantArgs...).
+				WithReply(reply)
+			// channels, labels and capabilities are not needed by the test
+			mocket.Catcher.NewMock().WithQuery(`SELECT`).WithReply([]map[string]interface{}{})
+			mocket.Catcher.NewMock().WithExecException()
+
+			cts := &connectorTypesService{
+				connectionFactory: db.NewMockConnectionFactory(nil),
+			}
+			types, _, err := cts.ListByLabel(tt.labels, &services.ListArguments{Page: 1, Size: 100})
+			g.Expect(err != nil).To(gomega.Equal(tt.wantErr))
+
+			var ids []string
+			for _, ct := range types {
+				ids = append(ids, ct.ID)
+			}
+			g.Expect(ids).To(gomega.Equal(tt.wantTypes))
+		})
+	}
+}
+
+func Test_connectorTypesService_GetDisplayMetadata(t *testing.T) {
+	cts := &connectorTypesService{
+		connectorsConfig: &config.ConnectorsConfig{
+			CatalogEntries: []config.ConnectorCatalogEntry{
+				{
+					ConnectorType: public.ConnectorType{
+						Id:          "log_sink_0.1",
+						Name:        "Log Sink",
+						Description: "Log is a sink that logs the records it receives",
+						Version:     "0.1",
+						IconHref:    "http://example.com/images/log.png",
+					},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name        string
+		typeId      string
+		want        *ConnectorTypeMetadata
+		wantErrCode errors.ServiceErrorCode
+	}{
+		{
+			name:   "should return the display metadata of a connector type in the catalog",
+			typeId: "log_sink_0.1",
+			want: &ConnectorTypeMetadata{
+				Id:          "log_sink_0.1",
+				Name:        "Log Sink",
+				Description: "Log is a sink that logs the records it receives",
+				Version:     "0.1",
+				IconHref:    "http://example.com/images/log.png",
+			},
+		},
+		{
+			name:        "should return a not found error for a connector type that is not in the catalog",
+			typeId:      "unknown",
+			wantErrCode: errors.ErrorNotFound,
+		},
+		{
+			name:        "should return a validation error when the connector type is empty",
+			typeId:      "",
+			wantErrCode: errors.ErrorValidation,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			got, err := cts.GetDisplayMetadata(tt.typeId)
+			if tt.wantErrCode != 0 {
+				g.Expect(err).ToNot(gomega.BeNil())
+				g.Expect(err.Code).To(gomega.Equal(tt.wantErrCode))
+				return
+			}
+			g.Expect(err).To(gomega.BeNil())
+			g.Expect(got).To(gomega.Equal(tt.want))
+		})
+	}
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services"
 	coreServices "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/queryparser"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/signalbus"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/sso"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared/secrets"
 	goerrors "github.com/pkg/errors"
 	"github.com/spyzhov/ajson"
@@ -36,6 +37,16 @@ type ConnectorsService interface {
 	Delete(ctx context.Context, id string) *errors.ServiceError
 	ForEach(f func(*dbapi.Connector) *errors.ServiceError, query string, args ...interface{}) []error
 	ForceDelete(ctx context.Context, id string) *errors.ServiceError
+	// DeleteServiceAccountsOfDeletedConnectors deletes from the SSO the service accounts created by the fleet manager for
+	// the deleted connectors that are not used by any other connector. The service accounts supplied by the users are left
+	// alone. A deletion that fails is retried on the next call. The number of service
+	// accounts released by the deleted connectors is returned.
+	DeleteServiceAccountsOfDeletedConnectors() (int, []error)
+	// GetReconcileSettings returns the settings of the connector reconcile shared by all the fleet manager instances
+	GetReconcileSettings(ctx context.Context) (*dbapi.ConnectorReconcileSettings, *errors.ServiceError)
+	// SetReconcilePaused pauses or resumes the reconciliation of the connectors by the connector manager of every fleet
+	// manager instance, the setting is kept across restarts
+	SetReconcilePaused(ctx context.Context, paused bool) (*dbapi.ConnectorReconcileSettings, *errors.ServiceError)
 
 	ResolveConnectorRefsWithBase64Secrets(resource *dbapi.Connector) (bool, *errors.ServiceError)
 }
@@ -47,15 +58,18 @@ type connectorsService struct {
 	bus                   signalbus.SignalBus
 	vaultService          vault.VaultService
 	connectorTypesService ConnectorTypesService
+	keycloakService       sso.KafkaKeycloakService
 }
 
 func NewConnectorsService(connectionFactory *db.ConnectionFactory, bus signalbus.SignalBus,
-	vaultService vault.VaultService, connectorTypesService ConnectorTypesService) *connectorsService {
+	vaultService vault.VaultService, connectorTypesService ConnectorTypesService,
+	keycloakService sso.KafkaKeycloakService) *connectorsService {
 	return &connectorsService{
 		connectionFactory:     connectionFactory,
 		bus:                   bus,
 		vaultService:          vaultService,
 		connectorTypesService: connectorTypesService,
+		keycloakService:       keycloakService,
 	}
 }
 
@@ -388,6 +402,76 @@ func (k *connectorsService) ForEach(f func(*dbapi.Connector) *errors.ServiceErro
 	return errs
 }
 
+func (k *connectorsService) DeleteServiceAccountsOfDeletedConnectors() (int, []error) {
+	dbConn := k.connectionFactory.New()
+
+	// the service account client id is cleared from a deleted connector once its service account has been deleted.
+	// Only the service accounts created by the fleet manager are deleted, the users may use theirs for other applications
+	var deleted []dbapi.Connector
+	if err := dbConn.Unscoped().Select("id", "service_account_client_id").
+		Where("deleted_at IS NOT NULL AND service_account_managed AND service_account_client_id <> ''").
+		Find(&deleted).Error; err != nil {
+		return 0, []error{errors.GeneralError("unable to list the service accounts of deleted connectors: %s", err)}
+	}
+
+	count := 0
+	var errs []error
+	for _, resource := range deleted {
+		clientId := resource.ServiceAccount.ClientId
+
+		// the same service account can be used by several connectors, it's deleted along with the last one of them
+		var inUse int64
+		if err := dbConn.Model(&dbapi.Connector{}).
+			Where("service_account_client_id = ?", clientId).
+			Count(&inUse).Error; err != nil {
+			errs = append(errs, errors.GeneralError("unable to count the connectors using service account %s: %s", clientId, err))
+			continue
+		}
+		if inUse == 0 {
+			// deleting a service account that doesn't exist anymore succeeds, so a deletion can be retried safely
+			if err := k.keycloakService.DeleteServiceAccountInternal(clientId); err != nil {
+				errs = append(errs, errors.GeneralError("failed to delete service account %s of deleted connector %s: %s", clientId, resource.ID, err))
+				continue
+			}
+			logger.Logger.V(5).Infof("Deleted service account %s of deleted connector %s", clientId, resource.ID)
+		}
+
+		if err := dbConn.Unscoped().Model(&dbapi.Connector{}).
+			Where("id = ?", resource.ID).
+			Update("service_account_client_id", "").Error; err != nil {
+			errs = append(errs, errors.GeneralError("unable to release service account %s of deleted connector %s: %s", clientId, resource.ID, err))
+			continue
+		}
+		count++
+	}
+
+	return count, errs
+}
+
+func (k *connectorsService) GetReconcileSettings(ctx context.Context) (*dbapi.ConnectorReconcileSettings, *errors.ServiceError) {
+	// the connectors are reconciled until the settings are saved for the first time
+	settings := dbapi.ConnectorReconcileSettings{ID: dbapi.ConnectorReconcileSettingsID}
+	if err := k.connectionFactory.New().Where("id = ?", settings.ID).Limit(1).Find(&settings).Error; err != nil {
+		return nil, errors.GeneralError("failed to get connector reconcile settings: %v", err)
+	}
+	return &settings, nil
+}
+
+func (k *connectorsService) SetReconcilePaused(ctx context.Context, paused bool) (*dbapi.ConnectorReconcileSettings, *errors.ServiceError) {
+	settings := dbapi.ConnectorReconcileSettings{ID: dbapi.ConnectorReconcileSettingsID, Paused: paused}
+	if err := k.connectionFactory.New().Save(&settings).Error; err != nil {
+		return nil, errors.GeneralError("failed to update connector reconcile settings: %v", err)
+	}
+
+	if !paused {
+		_ = db.AddPostCommitAction(ctx, func() {
+			// Wake up the reconcile loop...
+			k.bus.Notify("reconcile:connector")
+		})
+	}
+	return &settings, nil
+}
+
 func (k *connectorsService) ForceDelete(ctx context.Context, id string) *errors.ServiceError {
 	if err := k.connectionFactory.New().Transaction(func(tx *gorm.DB) error {
 		// delete deployment status, deployment, connector status and connector
@@ -0,0 +1,117 @@
+package services
+
+import (
+	"database/sql/driver"
+	"testing"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/sso"
+	"github.com/onsi/gomega"
+	mocket "github.com/selvatico/go-mocket"
+)
+
+func Test_connectorsService_DeleteServiceAccountsOfDeletedConnectors(t *testing.T) {
+	tests := []struct {
+		name string
+		// userSupplied is set when the service account of the deleted connector has not been created by the fleet manager
+		userSupplied bool
+		// inUse is the number of connectors that are not deleted using the service account
+		inUse int
+		// failedDeletions is the number of deletions of the service account failing before it succeeds
+		failedDeletions int
+		// calls is the number of reconciles
+		calls        int
+		wantCounts   []int
+		wantErrs     []bool
+		wantDeleted  []string
+		wantReleased int
+	}{
+		{
+			name:         "should delete the service account of a deleted connector",
+			calls:        1,
+			wantCounts:   []int{1},
+			wantErrs:     []bool{false},
+			wantDeleted:  []string{"client-id"},
+			wantReleased: 1,
+		},
+		{
+			name:         "should not delete a service account supplied by the user",
+			userSupplied: true,
+			calls:        1,
+			wantCounts:   []int{0},
+			wantErrs:     []bool{false},
+			wantDeleted:  []string{},
+			wantReleased: 0,
+		},
+		{
+			name:         "should not delete a service account used by another connector",
+			inUse:        1,
+			calls:        1,
+			wantCounts:   []int{1},
+			wantErrs:     []bool{false},
+			wantDeleted:  []string{},
+			wantReleased: 1,
+		},
+		{
+			name:            "should retry the deletion of a service account that failed",
+			failedDeletions: 1,
+			calls:           2,
+			wantCounts:      []int{0, 1},
+			wantErrs:        []bool{true, false},
+			wantDeleted:     []string{"client-id", "client-id"},
+			wantReleased:    1,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			released := 0
+			// the database only returns the deleted connectors whose service account has been created by the fleet manager
+			deletedConnectors := []map[string]interface{}{{"id": "connector-id", "service_account_client_id": "client-id"}}
+			if tt.userSupplied {
+				deletedConnectors = []map[string]interface{}{}
+			}
+			mocket.Catcher.Reset().
+				NewMock().
+				WithQuery(`deleted_at IS NOT NULL AND service_account_managed AND service_account_client_id <> ''`).
+				WithReply(deletedConnectors)
+			mocket.Catcher.NewMock().
+				WithQuery(`service_account_client_id = $1`).
+				WithArgs("client-id").
+				WithReply([]map[string]interface{}{{"count": tt.inUse}})
+			mocket.Catcher.NewMock().
+				WithQuery(`UPDATE "connectors" SET "service_account_client_id"=$1`).
+				WithCallback(func(_ string, args []driver.NamedValue) {
+					g.Expect(args[0].Value).To(gomega.Equal(""))
+					g.Expect(args[len(args)-1].Value).To(gomega.Equal("connector-id"))
+					released++
+				})
+			mocket.Catcher.NewMock().WithExecException().WithQueryException()
+
+			deleted := []string{}
+			k := &connectorsService{
+				connectionFactory: db.NewMockConnectionFactory(nil),
+				keycloakService: &sso.KeycloakServiceMock{
+					DeleteServiceAccountInternalFunc: func(clientId string) *errors.ServiceError {
+						deleted = append(deleted, clientId)
+						if len(deleted) <= tt.failedDeletions {
+							return errors.GeneralError("failed to delete service account")
+						}
+						return nil
+					},
+				},
+			}
+
+			for i := 0; i < tt.calls; i++ {
+				count, errs := k.DeleteServiceAccountsOfDeletedConnectors()
+				g.Expect(count).To(gomega.Equal(tt.wantCounts[i]))
+				g.Expect(len(errs) > 0).To(gomega.Equal(tt.wantErrs[i]))
+			}
+			g.Expect(deleted).To(gomega.Equal(tt.wantDeleted))
+			g.Expect(released).To(gomega.Equal(tt.wantReleased))
+		})
+	}
+}
@@ -63,6 +63,33 @@ var ConnectorStartingPhase = map[ConnectorOperation]dbapi.ConnectorStatusPhase{
 	DeleteConnector:   dbapi.ConnectorStatusPhaseDeleting,
 }
 
+// connectorDesiredStateTransitions has the desired states a connector can be changed to in a given status phase,
+// status phases not in this table allow any desired state
+var connectorDesiredStateTransitions = map[dbapi.ConnectorStatusPhase][]dbapi.ConnectorDesiredState{
+	dbapi.ConnectorStatusPhaseDeleting: {dbapi.ConnectorDeleted},
+	dbapi.ConnectorStatusPhaseDeleted:  {dbapi.ConnectorDeleted},
+}
+
+// ValidateDesiredStateTransition returns an error if the connector's desired state cannot be changed to the given
+// desired state in the connector's present status phase, e.g. a connector that is being deleted cannot be made ready.
+// Requests that do not change the connector's desired state are always valid.
+func ValidateDesiredStateTransition(connector *dbapi.Connector, desiredState dbapi.ConnectorDesiredState) *errors.ServiceError {
+	if connector.DesiredState == desiredState {
+		return nil
+	}
+	allowed, ok := connectorDesiredStateTransitions[connector.Status.Phase]
+	if !ok {
+		return nil
+	}
+	for _, s := range allowed {
+		if s == desiredState {
+			return nil
+		}
+	}
+	return errors.BadRequest("Cannot change desired state of Connector with id %s from [%s] to [%s] while in phase [%s]",
+		connector.ID, connector.DesiredState, desiredState, connector.Status.Phase)
+}
+
 func NewConnectorFSM(namespace *dbapi.ConnectorNamespace, connector *dbapi.Connector) *ConnectorFSM {
 	return &ConnectorFSM{
 		Connector:      connector,
@@ -0,0 +1,78 @@
+package phase
+
+import (
+	"testing"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/api/dbapi"
+	"github.com/onsi/gomega"
+)
+
+func Test_ValidateDesiredStateTransition(t *testing.T) {
+
+	tests := []struct {
+		scenario     string
+		currentState dbapi.ConnectorDesiredState
+		phase        dbapi.ConnectorStatusPhase
+		desiredState dbapi.ConnectorDesiredState
+		expectError  bool
+	}{
+		{
+			scenario:     "ready while deleting",
+			currentState: dbapi.ConnectorDeleted,
+			phase:        dbapi.ConnectorStatusPhaseDeleting,
+			desiredState: dbapi.ConnectorReady,
+			expectError:  true,
+		},
+		{
+			scenario:     "stopped while deleted",
+			currentState: dbapi.ConnectorDeleted,
+			phase:        dbapi.ConnectorStatusPhaseDeleted,
+			desiredState: dbapi.ConnectorStopped,
+			expectError:  true,
+		},
+		{
+			scenario:     "deleted while deleting",
+			currentState: dbapi.ConnectorUnassigned,
+			phase:        dbapi.ConnectorStatusPhaseDeleting,
+			desiredState: dbapi.ConnectorDeleted,
+			expectError:  false,
+		},
+		{
+			scenario:     "unchanged desired state while deleting",
+			currentState: dbapi.ConnectorUnassigned,
+			phase:        dbapi.ConnectorStatusPhaseDeleting,
+			desiredState: dbapi.ConnectorUnassigned,
+			expectError:  false,
+		},
+		{
+			scenario:     "stop ready connector",
+			currentState: dbapi.ConnectorReady,
+			phase:        dbapi.ConnectorStatusPhaseReady,
+			desiredState: dbapi.ConnectorStopped,
+			expectError:  false,
+		},
+		{
+			scenario:     "restart stopped connector",
+			currentState: dbapi.ConnectorStopped,
+			phase:        dbapi.ConnectorStatusPhaseStopped,
+			desiredState: dbapi.ConnectorReady,
+			expectError:  false,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.scenario, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			connector := &dbapi.Connector{
+				DesiredState: tt.currentState,
+				Status: dbapi.ConnectorStatus{
+					Phase: tt.phase,
+				},
+			}
+
+			err := ValidateDesiredStateTransition(connector, tt.desiredState)
+			g.Expect(err != nil).Should(gomega.Equal(tt.expectError), "ValidateDesiredStateTransition error=%v, expect error=%v", err, tt.expectError)
+		})
+	}
+}
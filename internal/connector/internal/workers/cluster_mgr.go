@@ -2,7 +2,11 @@ package workers
 
 import (
 	"context"
+	"time"
+
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/config"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/metrics"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/services"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
@@ -15,9 +19,10 @@ var _ workers.Worker = &ClusterManager{}
 
 type ClusterManager struct {
 	workers.BaseWorker
-	clusterService services.ConnectorClusterService
-	db             *db.ConnectionFactory
-	ctx            context.Context
+	clusterService   services.ConnectorClusterService
+	connectorsConfig *config.ConnectorsConfig
+	db               *db.ConnectionFactory
+	ctx              context.Context
 }
 
 func (m *ClusterManager) Start() {
@@ -28,15 +33,16 @@ func (m *ClusterManager) Stop() {
 	m.StopWorker(m)
 }
 
-func NewClusterManager(clusterService services.ConnectorClusterService, db *db.ConnectionFactory, reconciler workers.Reconciler) *ClusterManager {
+func NewClusterManager(clusterService services.ConnectorClusterService, connectorsConfig *config.ConnectorsConfig, db *db.ConnectionFactory, reconciler workers.Reconciler) *ClusterManager {
 	return &ClusterManager{
 		BaseWorker: workers.BaseWorker{
 			Id:         uuid.New().String(),
 			WorkerType: "connector_cluster",
 			Reconciler: reconciler,
 		},
-		clusterService: clusterService,
-		db:             db,
+		clusterService:   clusterService,
+		connectorsConfig: connectorsConfig,
+		db:               db,
 	}
 }
 
@@ -61,23 +67,42 @@ func (m *ClusterManager) Reconcile() []error {
 		"connector_clusters.status_phase = ? AND "+
 			"connector_clusters.deleted_at IS NULL AND cluster_id IS NOT NULL", dbapi.ConnectorClusterPhaseDeleting)
 
+	if threshold := m.connectorsConfig.ClusterUnreachableThreshold; threshold > 0 {
+		lastHeartbeatLimit := time.Now().Add(-threshold)
+
+		// reconcile ready clusters whose agent stopped reporting status, marking their connectors as unreachable
+		unreachableClusterIds := m.doReconcile(&errs, "unreachable", m.clusterService.ReconcileUnreachableClusters,
+			"connector_clusters.status_phase = ? AND connector_clusters.deleted_at IS NULL AND "+
+				"connector_clusters.last_heartbeat_at < ?", dbapi.ConnectorClusterPhaseReady, lastHeartbeatLimit)
+		metrics.UpdateConnectorClustersUnreachable(unreachableClusterIds)
+
+		// reconcile clusters whose agent reports status again, restoring the phase of their unreachable connectors
+		m.doReconcile(&errs, "recovered", m.clusterService.ReconcileRecoveredClusters,
+			"connector_clusters.deleted_at IS NULL AND connector_clusters.last_heartbeat_at >= ? AND "+
+				"connector_clusters.id IN (SELECT connector_deployments.cluster_id FROM connector_deployments "+
+				"JOIN connector_statuses ON connector_statuses.id = connector_deployments.connector_id "+
+				"WHERE connector_statuses.phase = ? AND connector_deployments.deleted_at IS NULL)",
+			lastHeartbeatLimit, dbapi.ConnectorStatusPhaseClusterUnreachable)
+	}
+
 	return errs
 }
 
+// doReconcile reconciles the clusters matching the query and returns their ids
 func (m *ClusterManager) doReconcile(errs *[]error, kind string,
 	reconcileFunc func(context.Context, []string) (int, []*errors.ServiceError),
-	query string, args ...interface{}) {
+	query string, args ...interface{}) []string {
 
 	glog.V(5).Infof("Reconciling %s clusters...", kind)
 
-	clusterIds, err := m.clusterService.GetClusterIds(query, args)
+	clusterIds, err := m.clusterService.GetClusterIds(query, args...)
 	if err != nil {
 		glog.Errorf("Error retrieving %s clusters: %s", kind, err)
 		*errs = append(*errs, err)
 	}
 	if len(clusterIds) == 0 {
 		glog.V(5).Infof("No %s clusters", kind)
-		return
+		return clusterIds
 	}
 
 	if derr := InDBTransaction(m.ctx, func(ctx context.Context) error {
@@ -99,4 +124,5 @@ func (m *ClusterManager) doReconcile(errs *[]error, kind string,
 	}); derr != nil {
 		glog.Errorf("Error reconciling %s clusters: %v", kind, derr)
 	}
+	return clusterIds
 }
@@ -0,0 +1,111 @@
+package workers
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/config"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/metrics"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/services"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	serviceError "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	mocket "github.com/selvatico/go-mocket"
+)
+
+// clusterHeartbeatServiceStub simulates a single ready cluster and the phase of its connectors, it only implements the
+// methods used by the cluster manager, calling any other method panics
+type clusterHeartbeatServiceStub struct {
+	services.ConnectorClusterService
+	clusterId     string
+	lastHeartbeat time.Time
+	unreachable   bool
+}
+
+func (s *clusterHeartbeatServiceStub) GetClusterIds(query string, args ...interface{}) ([]string, error) {
+	switch {
+	case strings.Contains(query, "last_heartbeat_at < ?"):
+		if s.lastHeartbeat.Before(args[1].(time.Time)) {
+			return []string{s.clusterId}, nil
+		}
+	case strings.Contains(query, "last_heartbeat_at >= ?"):
+		if s.unreachable && !s.lastHeartbeat.Before(args[0].(time.Time)) {
+			return []string{s.clusterId}, nil
+		}
+	}
+	return nil, nil
+}
+
+func (s *clusterHeartbeatServiceStub) ReconcileUnreachableClusters(_ context.Context, clusterIds []string) (int, []*serviceError.ServiceError) {
+	s.unreachable = true
+	return len(clusterIds), nil
+}
+
+func (s *clusterHeartbeatServiceStub) ReconcileRecoveredClusters(_ context.Context, clusterIds []string) (int, []*serviceError.ServiceError) {
+	s.unreachable = false
+	return len(clusterIds), nil
+}
+
+func TestClusterManager_Reconcile_UnreachableClusters(t *testing.T) {
+	const metricName = "cos_fleet_manager_connector_cluster_unreachable"
+	const unreachableMetric = `
+# HELP cos_fleet_manager_connector_cluster_unreachable set to 1 for each ready connector cluster whose agent stopped reporting its status
+# TYPE cos_fleet_manager_connector_cluster_unreachable gauge
+cos_fleet_manager_connector_cluster_unreachable{cluster_id="cluster-id"} 1
+`
+	g := gomega.NewWithT(t)
+	metrics.Reset()
+	mocket.Catcher.Reset().NewMock().WithQuery("select txid_current()").
+		WithReply([]map[string]interface{}{{"txid_current": 1}})
+
+	ctx, err := db.NewMockConnectionFactory(nil).NewContext(context.Background())
+	g.Expect(err).To(gomega.BeNil())
+
+	clusterService := &clusterHeartbeatServiceStub{
+		clusterId:     "cluster-id",
+		lastHeartbeat: time.Now(),
+	}
+	m := &ClusterManager{
+		clusterService:   clusterService,
+		connectorsConfig: &config.ConnectorsConfig{ClusterUnreachableThreshold: time.Minute},
+		ctx:              ctx,
+	}
+
+	// the cluster is reachable while its agent reports status
+	g.Expect(m.Reconcile()).To(gomega.BeEmpty())
+	g.Expect(clusterService.unreachable).To(gomega.BeFalse())
+	g.Expect(testutil.GatherAndCompare(prometheus.DefaultGatherer, strings.NewReader(""), metricName)).To(gomega.Succeed())
+
+	// the connectors of the cluster are unreachable once its agent stops reporting status
+	clusterService.lastHeartbeat = time.Now().Add(-2 * time.Minute)
+	g.Expect(m.Reconcile()).To(gomega.BeEmpty())
+	g.Expect(clusterService.unreachable).To(gomega.BeTrue())
+	g.Expect(testutil.GatherAndCompare(prometheus.DefaultGatherer, strings.NewReader(unreachableMetric), metricName)).To(gomega.Succeed())
+
+	// the connectors of the cluster are restored once its agent reports status again
+	clusterService.lastHeartbeat = time.Now()
+	g.Expect(m.Reconcile()).To(gomega.BeEmpty())
+	g.Expect(clusterService.unreachable).To(gomega.BeFalse())
+	g.Expect(testutil.GatherAndCompare(prometheus.DefaultGatherer, strings.NewReader(""), metricName)).To(gomega.Succeed())
+}
+
+func TestClusterManager_Reconcile_UnreachableClustersDisabled(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	clusterService := &clusterHeartbeatServiceStub{
+		clusterId:     "cluster-id",
+		lastHeartbeat: time.Now().Add(-time.Hour),
+	}
+	m := &ClusterManager{
+		clusterService:   clusterService,
+		connectorsConfig: &config.ConnectorsConfig{},
+		ctx:              context.Background(),
+	}
+
+	g.Expect(m.Reconcile()).To(gomega.BeEmpty())
+	g.Expect(clusterService.unreachable).To(gomega.BeFalse())
+}
@@ -3,8 +3,10 @@ package workers
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/api/dbapi"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/config"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/metrics"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/services"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/services/vault"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
@@ -12,10 +14,13 @@ import (
 	serviceError "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/workers"
 	"reflect"
+	"sync"
+	"sync/atomic"
 
 	"github.com/golang/glog"
 	"github.com/google/uuid"
 	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 )
 
 // ConnectorManager represents a connector manager that periodically reconciles connector requests
@@ -25,9 +30,15 @@ type ConnectorManager struct {
 	connectorClusterService services.ConnectorClusterService
 	connectorTypesService   services.ConnectorTypesService
 	vaultService            vault.VaultService
+	lifecycleEventSink      services.ConnectorLifecycleEventSink
+	connectorQuotaService   services.ConnectorQuotaService
 	lastVersion             int64
 	db                      *db.ConnectionFactory
 	ctx                     context.Context
+	// reconcileMutex prevents a catalog reconcile from running concurrently with the reconcile loop
+	reconcileMutex sync.Mutex
+	// stopping is set when Stop is called so that an in-flight reconcile doesn't start any new connector transaction
+	stopping atomic.Bool
 }
 
 // NewConnectorManager creates a new connector manager
@@ -36,6 +47,8 @@ func NewConnectorManager(
 	connectorService services.ConnectorsService,
 	connectorClusterService services.ConnectorClusterService,
 	vaultService vault.VaultService,
+	lifecycleEventSink services.ConnectorLifecycleEventSink,
+	connectorQuotaService services.ConnectorQuotaService,
 	db *db.ConnectionFactory,
 	reconciler workers.Reconciler,
 ) *ConnectorManager {
@@ -49,6 +62,8 @@ func NewConnectorManager(
 		connectorClusterService: connectorClusterService,
 		connectorTypesService:   connectorTypesService,
 		vaultService:            vaultService,
+		lifecycleEventSink:      lifecycleEventSink,
+		connectorQuotaService:   connectorQuotaService,
 		db:                      db,
 	}
 
@@ -57,11 +72,16 @@ func NewConnectorManager(
 
 // Start initializes the connector manager to reconcile connector requests
 func (k *ConnectorManager) Start() {
+	k.stopping.Store(false)
 	k.StartWorker(k)
 }
 
 // Stop causes the process for reconciling connector requests to stop.
+// The reconciler waits for an in-flight reconcile to complete, which only completes the transaction of the connector it
+// is reconciling as no new connector transaction is started once stopping, so that Start can't be called before the
+// previous reconcile loop is done.
 func (k *ConnectorManager) Stop() {
+	k.stopping.Store(true)
 	k.StopWorker(k)
 }
 
@@ -69,6 +89,9 @@ func (k *ConnectorManager) Reconcile() []error {
 	glog.V(5).Infoln("Reconciling connectors...")
 	var errs []error
 
+	k.reconcileMutex.Lock()
+	defer k.reconcileMutex.Unlock()
+
 	if k.ctx == nil {
 		ctx, err := k.db.NewContext(context.Background())
 		if err != nil {
@@ -77,6 +100,17 @@ func (k *ConnectorManager) Reconcile() []error {
 		k.ctx = ctx
 	}
 
+	// the reconcile can be paused through the admin API, e.g. during a data plane incident, the catalog is still
+	// reconciled while the connectors are paused
+	settings, serr := k.connectorService.GetReconcileSettings(k.ctx)
+	if serr != nil {
+		return []error{serr}
+	}
+	if settings.Paused {
+		glog.Infoln("Connector reconcile is paused, skipping the connectors")
+		return nil
+	}
+
 	// reconcile assigning connectors in "ready" desired state with "assigning" phase and a valid namespace id
 	k.doReconcile(&errs, "assigning", k.reconcileAssigning,
 		"desired_state = ? AND phase = ? AND connectors.namespace_id IS NOT NULL", dbapi.ConnectorReady, dbapi.ConnectorStatusPhaseAssigning)
@@ -94,6 +128,11 @@ func (k *ConnectorManager) Reconcile() []error {
 		"desired_state = ? AND phase IN ?", dbapi.ConnectorDeleted,
 		[]string{string(dbapi.ConnectorStatusPhaseAssigning), string(dbapi.ConnectorStatusPhaseDeleted)})
 
+	// delete the service accounts of the deleted connectors, deletions that failed are retried on every reconcile
+	count, serviceErrs := k.connectorService.DeleteServiceAccountsOfDeletedConnectors()
+	errs = append(errs, serviceErrs...)
+	glog.V(5).Infof("Released %d service accounts of deleted connectors with %d errors", count, len(serviceErrs))
+
 	// reconcile connector updates for assigned connectors that aren't being deleted...
 	k.doReconcile(&errs, "updated", k.reconcileConnectorUpdate,
 		"version > ? AND phase NOT IN ?", k.lastVersion,
@@ -102,6 +141,33 @@ func (k *ConnectorManager) Reconcile() []error {
 	return errs
 }
 
+// ReconcileCatalogNow reloads the connector catalog and reconciles it with the stored connector types and shard metadata
+// without restarting the service. Connectors are redeployed by the reconcile loop when their shard metadata changes.
+// Only the catalog of this fleet manager instance is reloaded: the connector types and shard metadata it stores are
+// shared by all the instances, so the connectors are redeployed whichever instance runs the reconcile loop, but the
+// other instances keep the display metadata of their loaded catalog until they restart and load the catalog again.
+// The ConnectorTypeManager of a starting instance reconciles the catalog it loaded from its catalog directories, which
+// overrides the reloaded catalog unless those directories hold the reloaded catalog too, e.g. a shared config map.
+func (k *ConnectorManager) ReconcileCatalogNow() error {
+	k.reconcileMutex.Lock()
+	defer k.reconcileMutex.Unlock()
+
+	glog.V(5).Infoln("Reconciling connector catalog updates...")
+
+	if err := k.connectorTypesService.ReloadCatalog(); err != nil {
+		return err
+	}
+	if err := k.connectorTypesService.DeleteUnusedAndNotInCatalog(); err != nil {
+		return err
+	}
+	if err := k.connectorTypesService.ForEachConnectorCatalogEntry(k.ReconcileConnectorCatalogEntry); err != nil {
+		return err
+	}
+
+	glog.V(5).Infoln("Catalog updates processed")
+	return nil
+}
+
 func (k *ConnectorManager) ReconcileConnectorCatalogEntry(id string, channel string, connectorChannelConfig *config.ConnectorChannelConfig) *serviceError.ServiceError {
 
 	connectorShardMetadata := dbapi.ConnectorShardMetadata{
@@ -154,9 +220,38 @@ func (k *ConnectorManager) reconcileAssigning(ctx context.Context, connector *db
 		return nil
 	}
 
-	shardMetadata, err := k.connectorTypesService.GetLatestConnectorShardMetadata(connector.ConnectorTypeId, connector.Channel)
-	if err != nil {
-		return errors.Wrapf(err, "failed to get latest channel version for connector request %s", connector.ID)
+	withinQuota, reason, serr := k.connectorQuotaService.CheckConnectorQuota(connector)
+	if serr != nil {
+		return errors.Wrapf(serr, "failed to check connector quota for connector request %s", connector.ID)
+	}
+	if !withinQuota {
+		// the connector is held in assigning until its owner and organisation are back within their quota
+		if connector.Status.Reason != reason {
+			glog.Infof("connector request %s is over quota: %s", connector.ID, reason)
+			connector.Status.Reason = reason
+			if serr = k.connectorService.SaveStatus(ctx, connector.Status); serr != nil {
+				return errors.Wrapf(serr, "failed to update connector status %s with quota reason", connector.ID)
+			}
+		}
+		return nil
+	}
+
+	var shardMetadata *dbapi.ConnectorShardMetadata
+	if connector.PinnedShardRevision != nil {
+		shardMetadata, err = k.connectorTypesService.GetConnectorShardMetadata(connector.ConnectorTypeId, connector.Channel, *connector.PinnedShardRevision)
+		if err != nil {
+			return errors.Wrapf(err, "failed to get pinned channel version %d for connector request %s", *connector.PinnedShardRevision, connector.ID)
+		}
+	} else {
+		shardMetadata, err = k.connectorTypesService.GetLatestConnectorShardMetadata(connector.ConnectorTypeId, connector.Channel)
+		if err != nil {
+			return errors.Wrapf(err, "failed to get latest channel version for connector request %s", connector.ID)
+		}
+	}
+
+	resources, rerr := getShardMetadataResources(shardMetadata)
+	if rerr != nil {
+		return errors.Wrapf(rerr, "failed to get resource hints of channel version %d for connector request %s", shardMetadata.Revision, connector.ID)
 	}
 
 	var status = dbapi.ConnectorStatus{}
@@ -176,24 +271,77 @@ func (k *ConnectorManager) reconcileAssigning(ctx context.Context, connector *db
 		NamespaceID:              namespace.ID,
 		ConnectorVersion:         connector.Version,
 		ConnectorShardMetadataID: shardMetadata.ID,
+		ResourceCPU:              resources.CPU,
+		ResourceMemory:           resources.Memory,
 		Status:                   dbapi.ConnectorDeploymentStatus{},
 	}
 
 	if err = k.connectorClusterService.SaveDeployment(ctx, &deployment); err != nil {
+		metrics.IncreaseConnectorDeploymentCreationFailureCount(namespace.ClusterId, connector.ConnectorTypeId)
 		return errors.Wrapf(err, "failed to create connector deployment for connector %s", connector.ID)
 	}
 
+	connector.Status = status
+	k.emitLifecycleEventAfterCommit(ctx, services.ConnectorLifecycleEventAssigned, connector)
+
 	return nil
 }
 
+// shardMetadataResources are the resource hints of a shard metadata
+type shardMetadataResources struct {
+	CPU    string `json:"cpu"`
+	Memory string `json:"memory"`
+}
+
+// getShardMetadataResources returns the resource hints of the shard metadata, which are optional but must be valid
+// kubernetes quantities when set
+func getShardMetadataResources(shardMetadata *dbapi.ConnectorShardMetadata) (shardMetadataResources, error) {
+	var metadata struct {
+		Resources shardMetadataResources `json:"resources"`
+	}
+	if len(shardMetadata.ShardMetadata) == 0 {
+		return metadata.Resources, nil
+	}
+	if err := shardMetadata.ShardMetadata.Unmarshal(&metadata); err != nil {
+		return metadata.Resources, errors.Wrapf(err, "invalid resources in shard metadata")
+	}
+
+	for name, value := range map[string]string{"cpu": metadata.Resources.CPU, "memory": metadata.Resources.Memory} {
+		if value == "" {
+			continue
+		}
+		quantity, err := resource.ParseQuantity(value)
+		if err != nil {
+			return metadata.Resources, errors.Wrapf(err, "invalid %s resource %q in shard metadata", name, value)
+		}
+		if quantity.Sign() <= 0 {
+			return metadata.Resources, errors.Errorf("invalid %s resource %q in shard metadata: it must be positive", name, value)
+		}
+	}
+
+	return metadata.Resources, nil
+}
+
 func (k *ConnectorManager) reconcileUnassigned(ctx context.Context, connector *dbapi.Connector) error {
 	// set phase to "assigning" and namespace_id to nil
 	connector.Status.Phase = dbapi.ConnectorStatusPhaseAssigning
 	connector.Status.NamespaceID = nil
 	connector.NamespaceId = nil
 
+	updates := map[string]interface{}{"namespace_id": nil}
+	if connector.TargetNamespaceId != nil {
+		// a connector evicted from an over capacity cluster is assigned to its target namespace and started again
+		updates = map[string]interface{}{
+			"namespace_id":        *connector.TargetNamespaceId,
+			"target_namespace_id": nil,
+			"desired_state":       dbapi.ConnectorReady,
+		}
+		connector.NamespaceId = connector.TargetNamespaceId
+		connector.TargetNamespaceId = nil
+		connector.DesiredState = dbapi.ConnectorReady
+	}
 	if err := k.db.New().Model(&connector).Where("id = ?", connector.ID).
-		Update("namespace_id", nil).Error; err != nil {
+		Updates(updates).Error; err != nil {
 		return errors.Wrapf(err, "failed to update namespace_id for connector %s", connector.ID)
 	}
 	if err := k.connectorService.SaveStatus(ctx, connector.Status); err != nil {
@@ -228,6 +376,7 @@ func (k *ConnectorManager) reconcileDeleted(ctx context.Context, connector *dbap
 	if err := k.connectorService.Delete(ctx, connector.ID); err != nil {
 		return err
 	}
+	k.emitLifecycleEventAfterCommit(ctx, services.ConnectorLifecycleEventDeleted, connector)
 	return nil
 }
 
@@ -240,10 +389,7 @@ func (k *ConnectorManager) reconcileConnectorUpdate(ctx context.Context, connect
 	} else {
 		// we may need to update the deployment due to connector change.
 		if deployment.ConnectorVersion != connector.Version {
-			deployment.ConnectorVersion = connector.Version
-			if serr = k.connectorClusterService.SaveDeployment(ctx, &deployment); serr != nil {
-				err = errors.Wrapf(serr, "failed to update connector version in deployment for connector %s", connector.ID)
-			}
+			err = k.updateDeploymentVersion(ctx, connector, &deployment)
 		}
 	}
 
@@ -261,12 +407,131 @@ func (k *ConnectorManager) reconcileConnectorUpdate(ctx context.Context, connect
 	return err
 }
 
+// updateDeploymentVersion moves the deployment to the connector version, after validating that the target shard
+// metadata exists and is compatible with the deployed one. Otherwise the connector is moved to the upgrade_failed
+// phase with the reason, instead of pushing a broken deployment.
+func (k *ConnectorManager) updateDeploymentVersion(ctx context.Context, connector *dbapi.Connector, deployment *dbapi.ConnectorDeployment) error {
+	shardMetadata, reason, err := k.getTargetShardMetadata(connector, deployment)
+	if err != nil {
+		return err
+	}
+	if reason != "" {
+		glog.Warningf("connector %s cannot be updated to version %d: %s", connector.ID, connector.Version, reason)
+		connector.Status.Phase = dbapi.ConnectorStatusPhaseUpgradeFailed
+		connector.Status.Reason = reason
+		if serr := k.connectorService.SaveStatus(ctx, connector.Status); serr != nil {
+			return errors.Wrapf(serr, "failed to update phase to %s for connector %s", dbapi.ConnectorStatusPhaseUpgradeFailed, connector.ID)
+		}
+		return nil
+	}
+
+	deployment.ConnectorVersion = connector.Version
+	deployment.ConnectorShardMetadataID = shardMetadata.ID
+	resources, rerr := getShardMetadataResources(shardMetadata)
+	if rerr != nil {
+		return errors.Wrapf(rerr, "failed to get resource hints of channel version %d for connector %s", shardMetadata.Revision, connector.ID)
+	}
+	deployment.ResourceCPU = resources.CPU
+	deployment.ResourceMemory = resources.Memory
+	if serr := k.connectorClusterService.SaveDeployment(ctx, deployment); serr != nil {
+		return errors.Wrapf(serr, "failed to update connector version in deployment for connector %s", connector.ID)
+	}
+
+	// a valid update recovers the connector from a previously failed one
+	if connector.Status.Phase == dbapi.ConnectorStatusPhaseUpgradeFailed {
+		connector.Status.Phase = dbapi.ConnectorStatusPhaseUpdating
+		connector.Status.Reason = ""
+		if serr := k.connectorService.SaveStatus(ctx, connector.Status); serr != nil {
+			return errors.Wrapf(serr, "failed to update phase to %s for connector %s", dbapi.ConnectorStatusPhaseUpdating, connector.ID)
+		}
+	}
+
+	return nil
+}
+
+// getTargetShardMetadata returns the shard metadata the connector should be deployed with, i.e. the pinned revision
+// if any or the deployed one, or the reason why the connector cannot be deployed with it
+func (k *ConnectorManager) getTargetShardMetadata(connector *dbapi.Connector, deployment *dbapi.ConnectorDeployment) (*dbapi.ConnectorShardMetadata, string, error) {
+	current := &deployment.ConnectorShardMetadata
+	target := current
+	if connector.PinnedShardRevision != nil && *connector.PinnedShardRevision != current.Revision {
+		var serr *serviceError.ServiceError
+		target, serr = k.connectorTypesService.GetConnectorShardMetadata(connector.ConnectorTypeId, connector.Channel, *connector.PinnedShardRevision)
+		if serr != nil {
+			if serr.Is404() {
+				return nil, fmt.Sprintf("shard metadata revision %d not found for connector type %s and channel %s",
+					*connector.PinnedShardRevision, connector.ConnectorTypeId, connector.Channel), nil
+			}
+			return nil, "", errors.Wrapf(serr, "failed to get pinned channel version %d for connector %s", *connector.PinnedShardRevision, connector.ID)
+		}
+	}
+
+	if target.ConnectorTypeId != connector.ConnectorTypeId || target.Channel != connector.Channel {
+		return nil, fmt.Sprintf("shard metadata revision %d is for connector type %s and channel %s instead of connector type %s and channel %s",
+			target.Revision, target.ConnectorTypeId, target.Channel, connector.ConnectorTypeId, connector.Channel), nil
+	}
+	if target != current {
+		reason, err := checkShardMetadataCompatibility(current, target)
+		if err != nil {
+			return nil, "", errors.Wrapf(err, "failed to check shard metadata revision %d for connector %s", target.Revision, connector.ID)
+		}
+		if reason != "" {
+			return nil, reason, nil
+		}
+	}
+
+	return target, "", nil
+}
+
+// checkShardMetadataCompatibility returns why a deployment cannot be moved from the current to the target shard
+// metadata, i.e. the kind of connector or its operator would change
+func checkShardMetadataCompatibility(current, target *dbapi.ConnectorShardMetadata) (string, error) {
+	type operator struct {
+		Type string `json:"type"`
+	}
+	type shardMetadata struct {
+		ConnectorType string     `json:"connector_type"`
+		Operators     []operator `json:"operators"`
+	}
+
+	var currentMetadata, targetMetadata shardMetadata
+	if err := current.ShardMetadata.Unmarshal(&currentMetadata); err != nil {
+		return "", err
+	}
+	if err := target.ShardMetadata.Unmarshal(&targetMetadata); err != nil {
+		return fmt.Sprintf("shard metadata revision %d is invalid: %v", target.Revision, err), nil
+	}
+
+	if currentMetadata.ConnectorType != targetMetadata.ConnectorType {
+		return fmt.Sprintf("shard metadata revision %d has connector_type %q instead of %q",
+			target.Revision, targetMetadata.ConnectorType, currentMetadata.ConnectorType), nil
+	}
+	for _, currentOperator := range currentMetadata.Operators {
+		found := false
+		for _, targetOperator := range targetMetadata.Operators {
+			if targetOperator.Type == currentOperator.Type {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Sprintf("shard metadata revision %d does not support operator %q", target.Revision, currentOperator.Type), nil
+		}
+	}
+
+	return "", nil
+}
+
 func (k *ConnectorManager) doReconcile(errs *[]error, reconcilePhase string, reconcileFunc func(ctx context.Context, connector *dbapi.Connector) error, query string, args ...interface{}) {
 	var count int64
 	var serviceErrs []error
 	glog.V(5).Infof("Reconciling %s connectors...", reconcilePhase)
 	if serviceErrs = k.connectorService.ForEach(func(connector *dbapi.Connector) *serviceError.ServiceError {
-		return InDBTransaction(k.ctx, func(ctx context.Context) error {
+		// connectors left are reconciled when the manager is started again
+		if k.stopping.Load() {
+			return nil
+		}
+		serr := InDBTransaction(k.ctx, func(ctx context.Context) error {
 			if err := reconcileFunc(ctx, connector); err != nil {
 				glog.Errorf("failed to reconcile %s connector %s in phase %s: %v", reconcilePhase,
 					connector.ID, connector.Status.Phase, err)
@@ -275,6 +540,13 @@ func (k *ConnectorManager) doReconcile(errs *[]error, reconcilePhase string, rec
 			count++
 			return nil
 		})
+		if serr != nil {
+			// the transaction has been rolled back, so there is no commit to wait for
+			event := services.NewConnectorLifecycleEvent(services.ConnectorLifecycleEventFailed, connector)
+			event.Data.Error = serr.Error()
+			k.emitLifecycleEvent(event)
+		}
+		return serr
 	}, query, args...); len(serviceErrs) > 0 {
 		*errs = append(*errs, serviceErrs...)
 	}
@@ -285,6 +557,27 @@ func (k *ConnectorManager) doReconcile(errs *[]error, reconcilePhase string, rec
 	}
 }
 
+// emitLifecycleEventAfterCommit publishes a lifecycle event of the given connector once the transaction of the
+// context has been committed
+func (k *ConnectorManager) emitLifecycleEventAfterCommit(ctx context.Context, eventType services.ConnectorLifecycleEventType, connector *dbapi.Connector) {
+	event := services.NewConnectorLifecycleEvent(eventType, connector)
+	if err := db.AddPostCommitAction(ctx, func() {
+		k.emitLifecycleEvent(event)
+	}); err != nil {
+		glog.Errorf("failed to AddPostCommitAction to emit %s event for connector %s: %v", eventType, connector.ID, err)
+	}
+}
+
+// emitLifecycleEvent publishes the given event, a failure is only logged so that it never affects the reconcile
+func (k *ConnectorManager) emitLifecycleEvent(event services.ConnectorLifecycleEvent) {
+	if k.lifecycleEventSink == nil {
+		return
+	}
+	if err := k.lifecycleEventSink.Emit(event); err != nil {
+		glog.Warningf("failed to emit %s event for connector %s: %v", event.Type, event.Subject, err)
+	}
+}
+
 func InDBTransaction(ctx context.Context, f func(ctx context.Context) error) (rerr *serviceError.ServiceError) {
 	err := db.Begin(ctx)
 	if err != nil {
@@ -0,0 +1,958 @@
+package workers
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/api/public"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/config"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/metrics"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/services"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	serviceError "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/signalbus"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/workers"
+	"github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	mocket "github.com/selvatico/go-mocket"
+)
+
+// the stubs below only implement the methods used by the tests, calling any other method panics
+
+type connectorClusterServiceStub struct {
+	services.ConnectorClusterService
+	namespace         *dbapi.ConnectorNamespace
+	deployment        *dbapi.ConnectorDeployment
+	saveDeploymentErr *serviceError.ServiceError
+	savedDeployment   *dbapi.ConnectorDeployment
+}
+
+func (s *connectorClusterServiceStub) GetDeploymentByConnectorId(ctx context.Context, connectorID string) (dbapi.ConnectorDeployment, *serviceError.ServiceError) {
+	if s.deployment == nil {
+		return dbapi.ConnectorDeployment{}, serviceError.NotFound("connector deployment not found")
+	}
+	return *s.deployment, nil
+}
+
+func (s *connectorClusterServiceStub) FindAvailableNamespace(owner string, orgId string, namespaceId *string) (*dbapi.ConnectorNamespace, *serviceError.ServiceError) {
+	return s.namespace, nil
+}
+
+func (s *connectorClusterServiceStub) SaveDeployment(ctx context.Context, resource *dbapi.ConnectorDeployment) *serviceError.ServiceError {
+	s.savedDeployment = resource
+	return s.saveDeploymentErr
+}
+
+// redeployClusterServiceStub stores the deployments by connector id and bumps the version of the connectors whose
+// deployments are forced to be redeployed, like the connectors version trigger does
+type redeployClusterServiceStub struct {
+	services.ConnectorClusterService
+	connectors       []*dbapi.Connector
+	deployments      map[string]*dbapi.ConnectorDeployment
+	lastVersion      int64
+	savedDeployments []string
+}
+
+func (s *redeployClusterServiceStub) ForceRedeployConnectorType(ctx context.Context, typeId string, channel string) (int64, *serviceError.ServiceError) {
+	var count int64
+	for _, connector := range s.connectors {
+		deployment, ok := s.deployments[connector.ID]
+		if !ok || connector.ConnectorTypeId != typeId || connector.Channel != channel {
+			continue
+		}
+		deployment.RedeployCount++
+		s.lastVersion++
+		connector.Version = s.lastVersion
+		count++
+	}
+	return count, nil
+}
+
+func (s *redeployClusterServiceStub) GetDeploymentByConnectorId(ctx context.Context, connectorID string) (dbapi.ConnectorDeployment, *serviceError.ServiceError) {
+	deployment, ok := s.deployments[connectorID]
+	if !ok {
+		return dbapi.ConnectorDeployment{}, serviceError.NotFound("connector deployment not found")
+	}
+	return *deployment, nil
+}
+
+func (s *redeployClusterServiceStub) SaveDeployment(ctx context.Context, resource *dbapi.ConnectorDeployment) *serviceError.ServiceError {
+	s.deployments[resource.ConnectorID] = resource
+	s.savedDeployments = append(s.savedDeployments, resource.ConnectorID)
+	return nil
+}
+
+// connectorTypesServiceStub uses the revision of the shard metadata as its id
+type connectorTypesServiceStub struct {
+	services.ConnectorTypesService
+	latestRevision int64
+	// catalog is replaced by reloadedCatalog when the catalog is reloaded
+	catalog         []config.ConnectorCatalogEntry
+	reloadedCatalog []config.ConnectorCatalogEntry
+	reloadErr       *serviceError.ServiceError
+	shardMetadata   []dbapi.ConnectorShardMetadata
+	// shardMetadataJSON is the shard metadata returned for each revision
+	shardMetadataJSON map[int64]api.JSON
+}
+
+func (s *connectorTypesServiceStub) ReloadCatalog() *serviceError.ServiceError {
+	if s.reloadErr != nil {
+		return s.reloadErr
+	}
+	s.catalog = s.reloadedCatalog
+	return nil
+}
+
+func (s *connectorTypesServiceStub) DeleteUnusedAndNotInCatalog() *serviceError.ServiceError {
+	return nil
+}
+
+func (s *connectorTypesServiceStub) ForEachConnectorCatalogEntry(f func(id string, channel string, ccc *config.ConnectorChannelConfig) *serviceError.ServiceError) *serviceError.ServiceError {
+	for _, entry := range s.catalog {
+		for channel, ccc := range entry.Channels {
+			ccc := ccc
+			if err := f(entry.ConnectorType.Id, channel, &ccc); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (s *connectorTypesServiceStub) PutConnectorShardMetadata(ctc *dbapi.ConnectorShardMetadata) (int64, *serviceError.ServiceError) {
+	s.shardMetadata = append(s.shardMetadata, *ctc)
+	return int64(len(s.shardMetadata)), nil
+}
+
+func (s *connectorTypesServiceStub) GetLatestConnectorShardMetadata(typeId, channel string) (*dbapi.ConnectorShardMetadata, *serviceError.ServiceError) {
+	return &dbapi.ConnectorShardMetadata{ID: s.latestRevision, ConnectorTypeId: typeId, Channel: channel, Revision: s.latestRevision,
+		ShardMetadata: s.shardMetadataJSON[s.latestRevision]}, nil
+}
+
+func (s *connectorTypesServiceStub) GetConnectorShardMetadata(typeId, channel string, revision int64) (*dbapi.ConnectorShardMetadata, *serviceError.ServiceError) {
+	if revision > s.latestRevision {
+		return nil, serviceError.NotFound("connector type shard metadata not found")
+	}
+	return &dbapi.ConnectorShardMetadata{ID: revision, ConnectorTypeId: typeId, Channel: channel, Revision: revision,
+		ShardMetadata: s.shardMetadataJSON[revision]}, nil
+}
+
+type connectorsServiceStub struct {
+	services.ConnectorsService
+	forEach     func(f func(*dbapi.Connector) *serviceError.ServiceError, query string, args ...interface{}) []error
+	savedStatus *dbapi.ConnectorStatus
+	paused      bool
+}
+
+func (s *connectorsServiceStub) GetReconcileSettings(ctx context.Context) (*dbapi.ConnectorReconcileSettings, *serviceError.ServiceError) {
+	return &dbapi.ConnectorReconcileSettings{ID: dbapi.ConnectorReconcileSettingsID, Paused: s.paused}, nil
+}
+
+func (s *connectorsServiceStub) ForEach(f func(*dbapi.Connector) *serviceError.ServiceError, query string, args ...interface{}) []error {
+	return s.forEach(f, query, args...)
+}
+
+func (s *connectorsServiceStub) SaveStatus(ctx context.Context, resource dbapi.ConnectorStatus) *serviceError.ServiceError {
+	s.savedStatus = &resource
+	return nil
+}
+
+func (s *connectorsServiceStub) Delete(ctx context.Context, id string) *serviceError.ServiceError {
+	return nil
+}
+
+func (s *connectorsServiceStub) DeleteServiceAccountsOfDeletedConnectors() (int, []error) {
+	return 0, nil
+}
+
+// connectorQuotaServiceStub is over quota when overQuotaReason is set
+type connectorQuotaServiceStub struct {
+	overQuotaReason string
+}
+
+func (s *connectorQuotaServiceStub) CheckConnectorQuota(connector *dbapi.Connector) (bool, string, *serviceError.ServiceError) {
+	return s.overQuotaReason == "", s.overQuotaReason, nil
+}
+
+// connectorLifecycleEventSinkStub captures the emitted events
+type connectorLifecycleEventSinkStub struct {
+	events  []services.ConnectorLifecycleEvent
+	emitErr error
+}
+
+func (s *connectorLifecycleEventSinkStub) Emit(event services.ConnectorLifecycleEvent) error {
+	s.events = append(s.events, event)
+	return s.emitErr
+}
+
+func TestConnectorManager_reconcileAssigning_DeploymentCreationFailureMetric(t *testing.T) {
+	const metricName = metrics.CosFleetManager + "_" + metrics.ConnectorDeploymentCreationFailureCount
+
+	tests := []struct {
+		name              string
+		saveDeploymentErr *serviceError.ServiceError
+		wantMetric        string
+	}{
+		{
+			name:              "should increase the deployment creation failure count when the deployment cannot be saved",
+			saveDeploymentErr: serviceError.GeneralError("failed to save deployment"),
+			wantMetric: fmt.Sprintf(`# HELP %[1]s count of failures to save the deployment of an assigned connector
+# TYPE %[1]s counter
+%[1]s{cluster_id="cluster-id",connector_type_id="connector-type-id"} 1
+`, metricName),
+		},
+		{
+			name:              "should not increase the deployment creation failure count when the deployment is saved",
+			saveDeploymentErr: nil,
+			wantMetric:        "",
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			metrics.Reset()
+
+			namespace := &dbapi.ConnectorNamespace{ClusterId: "cluster-id"}
+			namespace.ID = "namespace-id"
+			k := &ConnectorManager{
+				connectorService: &connectorsServiceStub{},
+				connectorClusterService: &connectorClusterServiceStub{
+					namespace:         namespace,
+					saveDeploymentErr: tt.saveDeploymentErr,
+				},
+				connectorTypesService: &connectorTypesServiceStub{},
+				connectorQuotaService: &connectorQuotaServiceStub{},
+			}
+			connector := &dbapi.Connector{
+				Model:           db.Model{ID: "connector-id"},
+				ConnectorTypeId: "connector-type-id",
+				Channel:         "stable",
+			}
+
+			err := k.reconcileAssigning(context.Background(), connector)
+			g.Expect(err != nil).To(gomega.Equal(tt.saveDeploymentErr != nil))
+			g.Expect(testutil.GatherAndCompare(prometheus.DefaultGatherer, strings.NewReader(tt.wantMetric), metricName)).To(gomega.Succeed())
+		})
+	}
+}
+
+func TestConnectorManager_reconcileAssigning_PinnedShardRevision(t *testing.T) {
+	pinnedRevision := int64(1)
+
+	tests := []struct {
+		name                string
+		pinnedShardRevision *int64
+		latestRevision      int64
+		wantRevision        int64
+		wantErr             bool
+	}{
+		{
+			name:                "should deploy an unpinned connector with the latest revision",
+			pinnedShardRevision: nil,
+			latestRevision:      2,
+			wantRevision:        2,
+		},
+		{
+			name:                "should keep the pinned revision of a connector after a catalog update",
+			pinnedShardRevision: &pinnedRevision,
+			latestRevision:      2,
+			wantRevision:        pinnedRevision,
+		},
+		{
+			name:                "should return an error if the pinned revision does not exist",
+			pinnedShardRevision: &pinnedRevision,
+			latestRevision:      0,
+			wantErr:             true,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+
+			namespace := &dbapi.ConnectorNamespace{ClusterId: "cluster-id"}
+			namespace.ID = "namespace-id"
+			clusterService := &connectorClusterServiceStub{
+				namespace: namespace,
+			}
+			k := &ConnectorManager{
+				connectorQuotaService:   &connectorQuotaServiceStub{},
+				connectorService:        &connectorsServiceStub{},
+				connectorClusterService: clusterService,
+				connectorTypesService:   &connectorTypesServiceStub{latestRevision: tt.latestRevision},
+			}
+			connector := &dbapi.Connector{
+				Model:               db.Model{ID: "connector-id"},
+				ConnectorTypeId:     "connector-type-id",
+				Channel:             "stable",
+				PinnedShardRevision: tt.pinnedShardRevision,
+			}
+
+			err := k.reconcileAssigning(context.Background(), connector)
+			g.Expect(err != nil).To(gomega.Equal(tt.wantErr))
+			if !tt.wantErr {
+				g.Expect(clusterService.savedDeployment).ToNot(gomega.BeNil())
+				g.Expect(clusterService.savedDeployment.ConnectorShardMetadataID).To(gomega.Equal(tt.wantRevision))
+			}
+		})
+	}
+}
+
+func TestConnectorManager_reconcileAssigning_ShardMetadataResources(t *testing.T) {
+	tests := []struct {
+		name              string
+		shardMetadataJSON api.JSON
+		wantCPU           string
+		wantMemory        string
+		wantErr           bool
+	}{
+		{
+			name:              "should inherit the resource hints of the shard metadata",
+			shardMetadataJSON: api.JSON(`{"connector_revision": 1, "resources": {"cpu": "500m", "memory": "512Mi"}}`),
+			wantCPU:           "500m",
+			wantMemory:        "512Mi",
+		},
+		{
+			name:              "should not set resource limits when the shard metadata has no resource hints",
+			shardMetadataJSON: api.JSON(`{"connector_revision": 1}`),
+		},
+		{
+			name:              "should return an error if a resource hint is not a quantity",
+			shardMetadataJSON: api.JSON(`{"connector_revision": 1, "resources": {"cpu": "half a core"}}`),
+			wantErr:           true,
+		},
+		{
+			name:              "should return an error if a resource hint is not positive",
+			shardMetadataJSON: api.JSON(`{"connector_revision": 1, "resources": {"memory": "-1Gi"}}`),
+			wantErr:           true,
+		},
+		{
+			name:              "should return an error if the resource hints are malformed",
+			shardMetadataJSON: api.JSON(`{"connector_revision": 1, "resources": "500m"}`),
+			wantErr:           true,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+
+			namespace := &dbapi.ConnectorNamespace{ClusterId: "cluster-id"}
+			namespace.ID = "namespace-id"
+			clusterService := &connectorClusterServiceStub{
+				namespace: namespace,
+			}
+			connectorsService := &connectorsServiceStub{}
+			k := &ConnectorManager{
+				connectorQuotaService:   &connectorQuotaServiceStub{},
+				connectorService:        connectorsService,
+				connectorClusterService: clusterService,
+				connectorTypesService: &connectorTypesServiceStub{
+					latestRevision:    1,
+					shardMetadataJSON: map[int64]api.JSON{1: tt.shardMetadataJSON},
+				},
+			}
+			connector := &dbapi.Connector{
+				Model:           db.Model{ID: "connector-id"},
+				ConnectorTypeId: "connector-type-id",
+				Channel:         "stable",
+			}
+
+			err := k.reconcileAssigning(context.Background(), connector)
+			g.Expect(err != nil).To(gomega.Equal(tt.wantErr))
+			if tt.wantErr {
+				// the connector is left assigning until its shard metadata is fixed
+				g.Expect(connectorsService.savedStatus).To(gomega.BeNil())
+				g.Expect(clusterService.savedDeployment).To(gomega.BeNil())
+				return
+			}
+			g.Expect(clusterService.savedDeployment).ToNot(gomega.BeNil())
+			g.Expect(clusterService.savedDeployment.ResourceCPU).To(gomega.Equal(tt.wantCPU))
+			g.Expect(clusterService.savedDeployment.ResourceMemory).To(gomega.Equal(tt.wantMemory))
+		})
+	}
+}
+
+func TestConnectorManager_reconcileAssigning_ConnectorQuota(t *testing.T) {
+	const overQuotaReason = "owner owner-id has reached the quota of 2 deployed connectors"
+
+	tests := []struct {
+		name            string
+		overQuotaReason string
+		currentReason   string
+		wantDeployment  bool
+		wantStatus      *dbapi.ConnectorStatus
+	}{
+		{
+			name:           "should deploy a connector whose owner is within quota",
+			wantDeployment: true,
+			wantStatus:     &dbapi.ConnectorStatus{Phase: dbapi.ConnectorStatusPhaseAssigned},
+		},
+		{
+			name:            "should hold a connector whose owner is over quota in assigning",
+			overQuotaReason: overQuotaReason,
+			wantStatus:      &dbapi.ConnectorStatus{Phase: dbapi.ConnectorStatusPhaseAssigning, Reason: overQuotaReason},
+		},
+		{
+			name:            "should not save the status of a connector already held for the same reason",
+			overQuotaReason: overQuotaReason,
+			currentReason:   overQuotaReason,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+
+			namespace := &dbapi.ConnectorNamespace{ClusterId: "cluster-id"}
+			namespace.ID = "namespace-id"
+			clusterService := &connectorClusterServiceStub{
+				namespace: namespace,
+			}
+			connectorsService := &connectorsServiceStub{}
+			k := &ConnectorManager{
+				connectorService:        connectorsService,
+				connectorClusterService: clusterService,
+				connectorTypesService:   &connectorTypesServiceStub{latestRevision: 1},
+				connectorQuotaService:   &connectorQuotaServiceStub{overQuotaReason: tt.overQuotaReason},
+			}
+			connector := &dbapi.Connector{
+				Model:           db.Model{ID: "connector-id"},
+				Owner:           "owner-id",
+				ConnectorTypeId: "connector-type-id",
+				Channel:         "stable",
+			}
+			connector.Status.Phase = dbapi.ConnectorStatusPhaseAssigning
+			connector.Status.Reason = tt.currentReason
+
+			g.Expect(k.reconcileAssigning(context.Background(), connector)).To(gomega.Succeed())
+			g.Expect(clusterService.savedDeployment != nil).To(gomega.Equal(tt.wantDeployment))
+			if tt.wantStatus == nil {
+				g.Expect(connectorsService.savedStatus).To(gomega.BeNil())
+				return
+			}
+			g.Expect(connectorsService.savedStatus).ToNot(gomega.BeNil())
+			g.Expect(connectorsService.savedStatus.Phase).To(gomega.Equal(tt.wantStatus.Phase))
+			g.Expect(connectorsService.savedStatus.Reason).To(gomega.Equal(tt.wantStatus.Reason))
+		})
+	}
+}
+
+func TestConnectorManager_reconcileUnassigned(t *testing.T) {
+	targetNamespaceId := "target-namespace-id"
+
+	tests := []struct {
+		name              string
+		targetNamespaceId *string
+		wantUpdate        string
+		wantNamespaceId   *string
+		wantDesiredState  dbapi.ConnectorDesiredState
+	}{
+		{
+			name:             "should leave an unassigned connector without a namespace",
+			wantUpdate:       `UPDATE "connectors" SET "namespace_id"=$1`,
+			wantDesiredState: dbapi.ConnectorUnassigned,
+		},
+		{
+			name:              "should assign an evicted connector to its target namespace",
+			targetNamespaceId: &targetNamespaceId,
+			wantUpdate:        `UPDATE "connectors" SET "desired_state"=$1,"namespace_id"=$2,"target_namespace_id"=$3`,
+			wantNamespaceId:   &targetNamespaceId,
+			wantDesiredState:  dbapi.ConnectorReady,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+
+			var updated bool
+			mocket.Catcher.Reset().NewMock().WithQuery(tt.wantUpdate).
+				WithCallback(func(string, []driver.NamedValue) { updated = true }).
+				WithRowsNum(1)
+			// the status association of the connector is upserted too
+			mocket.Catcher.NewMock().WithQuery(`INSERT INTO "connector_statuses"`)
+			mocket.Catcher.NewMock().WithExecException().WithQueryException()
+
+			connectorsService := &connectorsServiceStub{}
+			k := &ConnectorManager{
+				connectorService: connectorsService,
+				db:               db.NewMockConnectionFactory(nil),
+			}
+			namespaceId := "namespace-id"
+			connector := &dbapi.Connector{
+				Model:             db.Model{ID: "connector-id"},
+				NamespaceId:       &namespaceId,
+				TargetNamespaceId: tt.targetNamespaceId,
+				DesiredState:      dbapi.ConnectorUnassigned,
+			}
+			connector.Status.Phase = dbapi.ConnectorStatusPhaseDeleted
+
+			g.Expect(k.reconcileUnassigned(context.Background(), connector)).To(gomega.Succeed())
+			g.Expect(updated).To(gomega.BeTrue())
+			g.Expect(connector.NamespaceId).To(gomega.Equal(tt.wantNamespaceId))
+			g.Expect(connector.TargetNamespaceId).To(gomega.BeNil())
+			g.Expect(connector.DesiredState).To(gomega.Equal(tt.wantDesiredState))
+			g.Expect(connectorsService.savedStatus).ToNot(gomega.BeNil())
+			g.Expect(connectorsService.savedStatus.Phase).To(gomega.Equal(dbapi.ConnectorStatusPhaseAssigning))
+		})
+	}
+}
+
+func TestConnectorManager_reconcileConnectorUpdate(t *testing.T) {
+	sinkMetadata := api.JSON(`{"connector_revision": 1, "connector_type": "sink", "operators": [{"type": "camel-connector-operator"}]}`)
+	compatibleRevision := int64(2)
+	incompatibleTypeRevision := int64(3)
+	incompatibleOperatorRevision := int64(4)
+	missingRevision := int64(5)
+	shardMetadataJSON := map[int64]api.JSON{
+		compatibleRevision:           api.JSON(`{"connector_revision": 2, "connector_type": "sink", "operators": [{"type": "camel-connector-operator"}]}`),
+		incompatibleTypeRevision:     api.JSON(`{"connector_revision": 3, "connector_type": "source", "operators": [{"type": "camel-connector-operator"}]}`),
+		incompatibleOperatorRevision: api.JSON(`{"connector_revision": 4, "connector_type": "sink", "operators": [{"type": "debezium-connector-operator"}]}`),
+	}
+
+	tests := []struct {
+		name                string
+		pinnedShardRevision *int64
+		phase               dbapi.ConnectorStatusPhase
+		wantShardMetadataID int64
+		wantPhase           dbapi.ConnectorStatusPhase
+		wantReason          string
+	}{
+		{
+			name:                "should update the connector version of the deployment",
+			phase:               dbapi.ConnectorStatusPhaseReady,
+			wantShardMetadataID: 1,
+			wantPhase:           dbapi.ConnectorStatusPhaseReady,
+		},
+		{
+			name:                "should move the deployment to a compatible pinned revision",
+			pinnedShardRevision: &compatibleRevision,
+			phase:               dbapi.ConnectorStatusPhaseReady,
+			wantShardMetadataID: compatibleRevision,
+			wantPhase:           dbapi.ConnectorStatusPhaseReady,
+		},
+		{
+			name:                "should recover a connector from a failed upgrade",
+			pinnedShardRevision: &compatibleRevision,
+			phase:               dbapi.ConnectorStatusPhaseUpgradeFailed,
+			wantShardMetadataID: compatibleRevision,
+			wantPhase:           dbapi.ConnectorStatusPhaseUpdating,
+		},
+		{
+			name:                "should fail the upgrade to a pinned revision that does not exist",
+			pinnedShardRevision: &missingRevision,
+			phase:               dbapi.ConnectorStatusPhaseReady,
+			wantPhase:           dbapi.ConnectorStatusPhaseUpgradeFailed,
+			wantReason:          "shard metadata revision 5 not found",
+		},
+		{
+			name:                "should fail the upgrade to a revision with a different connector type",
+			pinnedShardRevision: &incompatibleTypeRevision,
+			phase:               dbapi.ConnectorStatusPhaseReady,
+			wantPhase:           dbapi.ConnectorStatusPhaseUpgradeFailed,
+			wantReason:          `has connector_type "source" instead of "sink"`,
+		},
+		{
+			name:                "should fail the upgrade to a revision without the deployed operator",
+			pinnedShardRevision: &incompatibleOperatorRevision,
+			phase:               dbapi.ConnectorStatusPhaseReady,
+			wantPhase:           dbapi.ConnectorStatusPhaseUpgradeFailed,
+			wantReason:          `does not support operator "camel-connector-operator"`,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			mocket.Catcher.Reset().NewMock().WithQuery("select txid_current()").
+				WithReply([]map[string]interface{}{{"txid_current": 1}})
+			ctx, err := db.NewMockConnectionFactory(nil).NewContext(context.Background())
+			g.Expect(err).ToNot(gomega.HaveOccurred())
+
+			connectorService := &connectorsServiceStub{}
+			clusterService := &connectorClusterServiceStub{
+				deployment: &dbapi.ConnectorDeployment{
+					Model:                    db.Model{ID: "deployment-id"},
+					ConnectorID:              "connector-id",
+					ConnectorVersion:         1,
+					ConnectorShardMetadataID: 1,
+					ConnectorShardMetadata: dbapi.ConnectorShardMetadata{
+						ID:              1,
+						ConnectorTypeId: "connector-type-id",
+						Channel:         "stable",
+						Revision:        1,
+						ShardMetadata:   sinkMetadata,
+					},
+				},
+			}
+			k := &ConnectorManager{
+				connectorService:        connectorService,
+				connectorClusterService: clusterService,
+				connectorTypesService: &connectorTypesServiceStub{
+					latestRevision:    incompatibleOperatorRevision,
+					shardMetadataJSON: shardMetadataJSON,
+				},
+			}
+			connector := &dbapi.Connector{
+				Model:               db.Model{ID: "connector-id"},
+				ConnectorTypeId:     "connector-type-id",
+				Channel:             "stable",
+				Version:             2,
+				PinnedShardRevision: tt.pinnedShardRevision,
+			}
+			connector.Status.Phase = tt.phase
+
+			serr := InDBTransaction(ctx, func(ctx context.Context) error {
+				return k.reconcileConnectorUpdate(ctx, connector)
+			})
+			g.Expect(serr).To(gomega.BeNil())
+			g.Expect(k.lastVersion).To(gomega.Equal(connector.Version))
+			g.Expect(connector.Status.Phase).To(gomega.Equal(tt.wantPhase))
+
+			if tt.wantReason != "" {
+				g.Expect(clusterService.savedDeployment).To(gomega.BeNil())
+				g.Expect(connectorService.savedStatus).ToNot(gomega.BeNil())
+				g.Expect(connectorService.savedStatus.Phase).To(gomega.Equal(dbapi.ConnectorStatusPhaseUpgradeFailed))
+				g.Expect(connectorService.savedStatus.Reason).To(gomega.ContainSubstring(tt.wantReason))
+				return
+			}
+			g.Expect(clusterService.savedDeployment).ToNot(gomega.BeNil())
+			g.Expect(clusterService.savedDeployment.ConnectorVersion).To(gomega.Equal(connector.Version))
+			g.Expect(clusterService.savedDeployment.ConnectorShardMetadataID).To(gomega.Equal(tt.wantShardMetadataID))
+			g.Expect(connector.Status.Reason).To(gomega.BeEmpty())
+		})
+	}
+}
+
+func TestConnectorManager_ReconcileCatalogNow(t *testing.T) {
+	catalogEntry := func(id string, revision float64) config.ConnectorCatalogEntry {
+		return config.ConnectorCatalogEntry{
+			ConnectorType: public.ConnectorType{Id: id},
+			Channels: map[string]config.ConnectorChannelConfig{
+				"stable": {ShardMetadata: map[string]interface{}{"connector_revision": revision}},
+			},
+		}
+	}
+
+	tests := []struct {
+		name              string
+		reloadedCatalog   []config.ConnectorCatalogEntry
+		reloadErr         *serviceError.ServiceError
+		wantShardMetadata []string
+		wantErr           bool
+	}{
+		{
+			name:              "should pick up a new catalog entry",
+			reloadedCatalog:   []config.ConnectorCatalogEntry{catalogEntry("log_sink_0.1", 1), catalogEntry("aws-sqs-source-v1alpha1", 1)},
+			wantShardMetadata: []string{"log_sink_0.1/stable/1", "aws-sqs-source-v1alpha1/stable/1"},
+		},
+		{
+			name:              "should pick up a new revision of a catalog entry",
+			reloadedCatalog:   []config.ConnectorCatalogEntry{catalogEntry("log_sink_0.1", 2)},
+			wantShardMetadata: []string{"log_sink_0.1/stable/2"},
+		},
+		{
+			name:      "should not reconcile the catalog if it cannot be reloaded",
+			reloadErr: serviceError.GeneralError("failed to reload connector catalogs"),
+			wantErr:   true,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+
+			typesService := &connectorTypesServiceStub{
+				catalog:         []config.ConnectorCatalogEntry{catalogEntry("log_sink_0.1", 1)},
+				reloadedCatalog: tt.reloadedCatalog,
+				reloadErr:       tt.reloadErr,
+			}
+			k := &ConnectorManager{
+				connectorTypesService: typesService,
+			}
+
+			err := k.ReconcileCatalogNow()
+			g.Expect(err != nil).To(gomega.Equal(tt.wantErr))
+
+			var shardMetadata []string
+			for _, sm := range typesService.shardMetadata {
+				shardMetadata = append(shardMetadata, fmt.Sprintf("%s/%s/%d", sm.ConnectorTypeId, sm.Channel, sm.Revision))
+			}
+			g.Expect(shardMetadata).To(gomega.Equal(tt.wantShardMetadata))
+		})
+	}
+}
+
+func TestConnectorManager_reconcileConnectorUpdate_ForcedRedeploy(t *testing.T) {
+	g := gomega.NewWithT(t)
+	mocket.Catcher.Reset().NewMock().WithQuery("select txid_current()").
+		WithReply([]map[string]interface{}{{"txid_current": 1}})
+
+	shardMetadata := dbapi.ConnectorShardMetadata{
+		ID:              1,
+		ConnectorTypeId: "log_sink_0.1",
+		Channel:         "stable",
+		Revision:        1,
+		ShardMetadata:   api.JSON(`{"connector_revision": 1, "connector_type": "sink"}`),
+	}
+	clusterService := &redeployClusterServiceStub{
+		deployments: map[string]*dbapi.ConnectorDeployment{},
+	}
+	for _, c := range []struct{ id, typeId, channel string }{
+		{"log-sink-1", "log_sink_0.1", "stable"},
+		{"log-sink-2", "log_sink_0.1", "stable"},
+		{"log-sink-beta", "log_sink_0.1", "beta"},
+		{"sqs-source", "aws-sqs-source-v1alpha1", "stable"},
+	} {
+		clusterService.lastVersion++
+		connector := &dbapi.Connector{
+			Model:           db.Model{ID: c.id},
+			ConnectorTypeId: c.typeId,
+			Channel:         c.channel,
+			Version:         clusterService.lastVersion,
+		}
+		connector.Status.Phase = dbapi.ConnectorStatusPhaseReady
+		clusterService.connectors = append(clusterService.connectors, connector)
+		clusterService.deployments[c.id] = &dbapi.ConnectorDeployment{
+			Model:                    db.Model{ID: "deployment-" + c.id},
+			ConnectorID:              c.id,
+			ConnectorVersion:         connector.Version,
+			ConnectorShardMetadataID: shardMetadata.ID,
+			ConnectorShardMetadata:   shardMetadata,
+		}
+	}
+
+	k := &ConnectorManager{
+		connectorService:        &connectorsServiceStub{},
+		connectorClusterService: clusterService,
+		db:                      db.NewMockConnectionFactory(nil),
+		lastVersion:             clusterService.lastVersion,
+	}
+
+	ctx, err := k.db.NewContext(context.Background())
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	count, serr := clusterService.ForceRedeployConnectorType(ctx, "log_sink_0.1", "stable")
+	g.Expect(serr).To(gomega.BeNil())
+	g.Expect(count).To(gomega.Equal(int64(2)))
+
+	// the reconcile loop updates the deployments of the connectors with a newer version
+	lastVersion := k.lastVersion
+	for _, connector := range clusterService.connectors {
+		if connector.Version <= lastVersion {
+			continue
+		}
+		serr := InDBTransaction(ctx, func(ctx context.Context) error {
+			return k.reconcileConnectorUpdate(ctx, connector)
+		})
+		g.Expect(serr).To(gomega.BeNil())
+	}
+
+	g.Expect(clusterService.savedDeployments).To(gomega.ConsistOf("log-sink-1", "log-sink-2"))
+	for _, connector := range clusterService.connectors {
+		deployment := clusterService.deployments[connector.ID]
+		g.Expect(deployment.ConnectorVersion).To(gomega.Equal(connector.Version))
+		if connector.ConnectorTypeId == "log_sink_0.1" && connector.Channel == "stable" {
+			g.Expect(deployment.RedeployCount).To(gomega.Equal(int64(1)))
+		} else {
+			g.Expect(deployment.RedeployCount).To(gomega.BeZero())
+		}
+	}
+}
+
+func TestConnectorManager_Stop(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	var started sync.Once
+	startedChan := make(chan struct{})
+	var completed atomic.Bool
+	var reconciledPhases atomic.Int32
+	k := &ConnectorManager{
+		BaseWorker: workers.BaseWorker{
+			Id:         "test",
+			WorkerType: "connector",
+			Reconciler: workers.Reconciler{
+				SignalBus:        signalbus.NewSignalBus(),
+				ReconcilerConfig: workers.NewReconcilerConfig(),
+			},
+		},
+		connectorService: &connectorsServiceStub{
+			// the first reconcile phase takes a while, Stop is called meanwhile
+			forEach: func(f func(*dbapi.Connector) *serviceError.ServiceError, query string, args ...interface{}) []error {
+				reconciledPhases.Add(1)
+				started.Do(func() {
+					close(startedChan)
+					time.Sleep(200 * time.Millisecond)
+					completed.Store(true)
+				})
+				return nil
+			},
+		},
+		ctx: context.Background(),
+	}
+
+	k.Start()
+	<-startedChan
+	k.Stop()
+	// the reconcile in progress completes before Stop returns
+	g.Expect(completed.Load()).To(gomega.BeTrue())
+
+	// the worker can be started again once stopped
+	phases := reconciledPhases.Load()
+	k.Start()
+	g.Eventually(reconciledPhases.Load).Should(gomega.BeNumerically(">", phases))
+	k.Stop()
+}
+
+func TestConnectorManager_Reconcile_Paused(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	var reconciledPhases int
+	connectorService := &connectorsServiceStub{
+		forEach: func(f func(*dbapi.Connector) *serviceError.ServiceError, query string, args ...interface{}) []error {
+			reconciledPhases++
+			return nil
+		},
+	}
+	k := &ConnectorManager{
+		connectorService: connectorService,
+		ctx:              context.Background(),
+	}
+
+	// no connector is reconciled while paused
+	connectorService.paused = true
+	g.Expect(k.Reconcile()).To(gomega.BeEmpty())
+	g.Expect(reconciledPhases).To(gomega.Equal(0))
+
+	// every phase is reconciled again once resumed
+	connectorService.paused = false
+	g.Expect(k.Reconcile()).To(gomega.BeEmpty())
+	g.Expect(reconciledPhases).To(gomega.Equal(5))
+}
+
+func TestConnectorManager_doReconcile_LifecycleEvents(t *testing.T) {
+	namespace := &dbapi.ConnectorNamespace{ClusterId: "cluster-id"}
+	namespace.ID = "namespace-id"
+
+	tests := []struct {
+		name              string
+		reconcilePhase    string
+		phase             dbapi.ConnectorStatusPhase
+		saveDeploymentErr *serviceError.ServiceError
+		emitErr           error
+		reconcileFunc     func(k *ConnectorManager) func(ctx context.Context, connector *dbapi.Connector) error
+		wantEventType     services.ConnectorLifecycleEventType
+		wantPhase         dbapi.ConnectorStatusPhase
+		wantErrs          int
+	}{
+		{
+			name:           "should emit an assigned event once the connector is assigned",
+			reconcilePhase: "assigning",
+			phase:          dbapi.ConnectorStatusPhaseAssigning,
+			reconcileFunc: func(k *ConnectorManager) func(ctx context.Context, connector *dbapi.Connector) error {
+				return k.reconcileAssigning
+			},
+			wantEventType: services.ConnectorLifecycleEventAssigned,
+			wantPhase:     dbapi.ConnectorStatusPhaseAssigned,
+		},
+		{
+			name:           "should emit a deleted event once the connector is deleted",
+			reconcilePhase: "deleted",
+			phase:          dbapi.ConnectorStatusPhaseDeleted,
+			reconcileFunc: func(k *ConnectorManager) func(ctx context.Context, connector *dbapi.Connector) error {
+				return k.reconcileDeleted
+			},
+			wantEventType: services.ConnectorLifecycleEventDeleted,
+			wantPhase:     dbapi.ConnectorStatusPhaseDeleted,
+		},
+		{
+			name:              "should emit a failed event when the connector cannot be assigned",
+			reconcilePhase:    "assigning",
+			phase:             dbapi.ConnectorStatusPhaseAssigning,
+			saveDeploymentErr: serviceError.GeneralError("failed to save deployment"),
+			reconcileFunc: func(k *ConnectorManager) func(ctx context.Context, connector *dbapi.Connector) error {
+				return k.reconcileAssigning
+			},
+			wantEventType: services.ConnectorLifecycleEventFailed,
+			wantPhase:     dbapi.ConnectorStatusPhaseAssigning,
+			wantErrs:      1,
+		},
+		{
+			name:           "should not fail the reconcile when the event cannot be emitted",
+			reconcilePhase: "deleted",
+			phase:          dbapi.ConnectorStatusPhaseDeleted,
+			emitErr:        fmt.Errorf("sink unavailable"),
+			reconcileFunc: func(k *ConnectorManager) func(ctx context.Context, connector *dbapi.Connector) error {
+				return k.reconcileDeleted
+			},
+			wantEventType: services.ConnectorLifecycleEventDeleted,
+			wantPhase:     dbapi.ConnectorStatusPhaseDeleted,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			mocket.Catcher.Reset().NewMock().WithQuery("select txid_current()").
+				WithReply([]map[string]interface{}{{"txid_current": 1}})
+			ctx, err := db.NewMockConnectionFactory(nil).NewContext(context.Background())
+			g.Expect(err).ToNot(gomega.HaveOccurred())
+
+			connector := &dbapi.Connector{
+				Model:           db.Model{ID: "connector-id"},
+				ConnectorTypeId: "connector-type-id",
+				Channel:         "stable",
+			}
+			connector.Status.Phase = tt.phase
+			sink := &connectorLifecycleEventSinkStub{emitErr: tt.emitErr}
+			k := &ConnectorManager{
+				connectorService: &connectorsServiceStub{
+					forEach: func(f func(*dbapi.Connector) *serviceError.ServiceError, query string, args ...interface{}) []error {
+						if err := f(connector); err != nil {
+							return []error{err}
+						}
+						return nil
+					},
+				},
+				connectorClusterService: &connectorClusterServiceStub{
+					namespace:         namespace,
+					saveDeploymentErr: tt.saveDeploymentErr,
+				},
+				connectorTypesService: &connectorTypesServiceStub{},
+				connectorQuotaService: &connectorQuotaServiceStub{},
+				lifecycleEventSink:    sink,
+				ctx:                   ctx,
+			}
+
+			var errs []error
+			k.doReconcile(&errs, tt.reconcilePhase, tt.reconcileFunc(k), "")
+			g.Expect(errs).To(gomega.HaveLen(tt.wantErrs))
+			g.Expect(sink.events).To(gomega.HaveLen(1))
+			g.Expect(sink.events[0].Type).To(gomega.Equal(tt.wantEventType))
+			g.Expect(sink.events[0].Subject).To(gomega.Equal("connector-id"))
+			g.Expect(sink.events[0].Data.Phase).To(gomega.Equal(string(tt.wantPhase)))
+			if tt.wantEventType == services.ConnectorLifecycleEventFailed {
+				g.Expect(sink.events[0].Data.Error).ToNot(gomega.BeEmpty())
+			}
+		})
+	}
+}
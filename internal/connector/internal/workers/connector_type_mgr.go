@@ -29,6 +29,8 @@ type ConnectorTypeManager struct {
 	connectorTypesService   services.ConnectorTypesService
 	startupReconcileDone    bool
 	startupReconcileWG      sync.WaitGroup
+	startupCheckStop        chan struct{}
+	startupCheckStopOnce    sync.Once
 }
 
 // NewApiServerReadyCondition is used to inject a server.ApiServerReadyCondition into the server.ApiServer
@@ -56,6 +58,7 @@ func NewConnectorTypeManager(
 		connectorClusterService: connectorClusterService,
 		connectorTypesService:   connectorTypesService,
 		startupReconcileDone:    false,
+		startupCheckStop:        make(chan struct{}),
 	}
 
 	// The release of this waiting group signal the http service to start serving request
@@ -77,7 +80,11 @@ func (k *ConnectorTypeManager) Start() {
 }
 
 // Stop causes the process for reconciling connector requests to stop.
+// It also stops the startup reconcile check worker, if it is still waiting for the catalog updates.
 func (k *ConnectorTypeManager) Stop() {
+	k.startupCheckStopOnce.Do(func() {
+		close(k.startupCheckStop)
+	})
 	k.StopWorker(k)
 }
 
@@ -145,6 +152,8 @@ func (k *ConnectorTypeManager) ReconcileConnectorCatalogEntry(id string, channel
 
 func (k *ConnectorTypeManager) runStartupReconcileCheckWorker() {
 	go func() {
+		// the api server must not wait forever for a stopped manager
+		defer k.startupReconcileWG.Done()
 		for !k.startupReconcileDone {
 			glog.V(5).Infoln("Waiting for startup connector catalog updates...")
 			// this check that ConnectorTypes in the current configured catalog have the same checksum of the one
@@ -154,12 +163,16 @@ func (k *ConnectorTypeManager) runStartupReconcileCheckWorker() {
 				glog.Errorf("Error checking catalog entry checksums: %s", err)
 			} else if done {
 				k.startupReconcileDone = true
-			} else {
-				// wait another 5 seconds to check
-				time.Sleep(checkCatalogEntriesDuration)
+				continue
+			}
+			// wait another 5 seconds to check
+			select {
+			case <-time.After(checkCatalogEntriesDuration):
+			case <-k.startupCheckStop:
+				glog.V(5).Infoln("Stopped waiting for connector catalog updates")
+				return
 			}
 		}
 		glog.V(5).Infoln("Wait for connector catalog updates done!")
-		k.startupReconcileWG.Done()
 	}()
 }
@@ -63,6 +63,9 @@ func (m *NamespaceManager) Reconcile() []error {
 	// delete "deleted" namespaces with no connectors
 	m.doReconcile(&errs, "empty deleted", m.namespaceService.ReconcileDeletedNamespaces)
 
+	// evict the connectors of over capacity clusters, lowest eviction priority first
+	m.doReconcile(&errs, "over capacity cluster", m.namespaceService.ReconcileOverCapacityClusters)
+
 	return errs
 }
 
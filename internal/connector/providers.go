@@ -22,7 +22,7 @@ import (
 func ConfigProviders(kafkaEnabled bool) di.Option {
 
 	result := di.Options(
-		di.Provide(config.NewConnectorsConfig, di.As(new(environments2.ConfigModule))),
+		di.Provide(config.NewConnectorsConfig, di.As(new(environments2.ConfigModule)), di.As(new(environments2.ServiceValidator))),
 		di.Provide(config.NewConnectorsQuotaConfig, di.As(new(environments2.ConfigModule))),
 		di.Provide(environments2.Func(serviceProviders)),
 		di.Provide(migrations.New),
@@ -53,6 +53,8 @@ func serviceProviders() di.Option {
 		di.Provide(services.NewConnectorTypesService, di.As(new(services.ConnectorTypesService))),
 		di.Provide(services.NewConnectorClusterService, di.As(new(services.ConnectorClusterService)), di.As(new(auth.AuthAgentService))),
 		di.Provide(services.NewConnectorNamespaceService, di.As(new(services.ConnectorNamespaceService))),
+		di.Provide(services.NewConnectorLifecycleEventSink),
+		di.Provide(services.NewConnectorQuotaService, di.As(new(services.ConnectorQuotaService))),
 		di.Provide(authz.NewAuthZService, di.As(new(authz.AuthZService))),
 		di.Provide(handlers.NewConnectorNamespaceHandler),
 		di.Provide(handlers.NewConnectorAdminHandler),
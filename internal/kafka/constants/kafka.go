@@ -10,7 +10,12 @@ type KafkaStatus string
 // KafkaOperation type
 type KafkaOperation string
 
+// KafkaDeprovisionReason type
+type KafkaDeprovisionReason string
+
 const (
+	// KafkaRequestStatusPendingQuota - kafka request status when registered without reserving quota, waiting for the quota to be confirmed
+	KafkaRequestStatusPendingQuota KafkaStatus = "pending_quota"
 	// KafkaRequestStatusAccepted - kafka request status when accepted by kafka worker
 	KafkaRequestStatusAccepted KafkaStatus = "accepted"
 	// KafkaRequestStatusPreparing - kafka request status of a preparing kafka
@@ -21,6 +26,9 @@ const (
 	KafkaRequestStatusReady KafkaStatus = "ready"
 	// KafkaRequestStatusFailed - kafka request failed
 	KafkaRequestStatusFailed KafkaStatus = "failed"
+	// KafkaRequestStatusDeprovisionPending - kafka request status during the grace window before its deprovision,
+	// in which the deletion can still be cancelled
+	KafkaRequestStatusDeprovisionPending KafkaStatus = "deprovision_pending"
 	// KafkaRequestStatusDeprovision - kafka request status when to be deleted by kafka
 	KafkaRequestStatusDeprovision KafkaStatus = "deprovision"
 	// KafkaRequestStatusDeleting - external resources are being deleted for the kafka request
@@ -37,6 +45,17 @@ const (
 	KafkaOperationDelete KafkaOperation = "delete"
 	// KafkaOperationDeprovision = Kafka cluster deprovision operations
 	KafkaOperationDeprovision KafkaOperation = "deprovision"
+	// KafkaOperationForceDelete = Kafka cluster force delete operations
+	KafkaOperationForceDelete KafkaOperation = "force_delete"
+
+	// KafkaDeprovisionReasonUserRequest - kafka deprovisioned on the request of its owner or an organisation admin
+	KafkaDeprovisionReasonUserRequest KafkaDeprovisionReason = "user_request"
+	// KafkaDeprovisionReasonAdminRequest - kafka deprovisioned on the request of a fleet manager admin
+	KafkaDeprovisionReasonAdminRequest KafkaDeprovisionReason = "admin_request"
+	// KafkaDeprovisionReasonExpired - kafka deprovisioned at the end of the lifespan of its instance size
+	KafkaDeprovisionReasonExpired KafkaDeprovisionReason = "expired"
+	// KafkaDeprovisionReasonUserOffboarded - kafka deprovisioned because its owner is no longer allowed to use the service
+	KafkaDeprovisionReasonUserOffboarded KafkaDeprovisionReason = "user_offboarded"
 
 	// ObservabilityCanaryPodLabelKey that will be used by the observability operator to scrap metrics
 	ObservabilityCanaryPodLabelKey = "managed-kafka-canary"
@@ -55,26 +74,36 @@ const (
 	// AcceptedKafkaMaxRetryDurationWhileWaitingForClusterAssignment the maximum duration, in hours, where KAS Fleet Manager
 	// will retry reconciliation of a Kafka request in an 'accepted' state in order to assign it into a data plane cluster.
 	AcceptedKafkaMaxRetryDurationWhileWaitingForClusterAssignment = 1 * time.Hour
+
+	// PendingQuotaKafkaMaxDuration the maximum duration a Kafka request can stay in 'pending_quota' state waiting for its
+	// quota to be confirmed. Past this duration the Kafka request is deleted so that it does not hold capacity forever.
+	PendingQuotaKafkaMaxDuration = 15 * time.Minute
 )
 
 // ordinals - Used to decide if a status comes after or before a given state
 var ordinals = map[string]int{
-	KafkaRequestStatusAccepted.String():     0,
-	KafkaRequestStatusPreparing.String():    10,
-	KafkaRequestStatusProvisioning.String(): 20,
-	KafkaRequestStatusResuming.String():     20,
-	KafkaRequestStatusReady.String():        30,
-	KafkaRequestStatusDeprovision.String():  40,
-	KafkaRequestStatusDeleting.String():     50,
-	KafkaRequestStatusSuspending.String():   60,
-	KafkaRequestStatusSuspended.String():    70,
-	KafkaRequestStatusFailed.String():       500,
+	KafkaRequestStatusPendingQuota.String():       -10,
+	KafkaRequestStatusAccepted.String():           0,
+	KafkaRequestStatusPreparing.String():          10,
+	KafkaRequestStatusProvisioning.String():       20,
+	KafkaRequestStatusResuming.String():           20,
+	KafkaRequestStatusReady.String():              30,
+	KafkaRequestStatusDeprovisionPending.String(): 35,
+	KafkaRequestStatusDeprovision.String():        40,
+	KafkaRequestStatusDeleting.String():           50,
+	KafkaRequestStatusSuspending.String():         60,
+	KafkaRequestStatusSuspended.String():          70,
+	KafkaRequestStatusFailed.String():             500,
 }
 
 func (k KafkaOperation) String() string {
 	return string(k)
 }
 
+func (k KafkaDeprovisionReason) String() string {
+	return string(k)
+}
+
 // KafkaStatus Methods
 func (k KafkaStatus) String() string {
 	return string(k)
@@ -0,0 +1,29 @@
+package dbapi
+
+import "time"
+
+// StreamingUnitCount is the number of streaming units consumed by the kafkas of an instance type on a data plane
+// cluster, as last materialized by the capacity recomputation worker
+type StreamingUnitCount struct {
+	ClusterID     string `json:"cluster_id" gorm:"primaryKey"`
+	InstanceType  string `json:"instance_type" gorm:"primaryKey"`
+	ClusterRecord string `json:"cluster_record"` // the id of the row of the cluster in the clusters table
+	CloudProvider string `json:"cloud_provider"`
+	Region        string `json:"region"`
+	Status        string `json:"status"`
+	Count         int32  `json:"count"`
+	MaxUnits      int32  `json:"max_units"`
+	// ComputedAt is the time at which the counts of all the clusters have been computed
+	ComputedAt time.Time `json:"computed_at"`
+}
+
+// RegionCapacityUsage is the capacity consumed by the kafkas of an instance type in a cloud provider region, as last
+// materialized by the capacity recomputation worker
+type RegionCapacityUsage struct {
+	CloudProvider    string `json:"cloud_provider" gorm:"primaryKey"`
+	Region           string `json:"region" gorm:"primaryKey"`
+	InstanceType     string `json:"instance_type" gorm:"primaryKey"`
+	CapacityConsumed int    `json:"capacity_consumed"`
+	// ComputedAt is the time at which the usages of all the regions have been computed
+	ComputedAt time.Time `json:"computed_at"`
+}
@@ -25,6 +25,11 @@ type DataPlaneKafkaStatusCondition struct {
 type DataPlaneKafkaRoute struct {
 	Domain string
 	Router string
+	// Apex is true when Domain is the apex of its hosted zone. A CNAME record cannot be created at a zone apex,
+	// so an alias record targeting Router is created instead
+	Apex bool `json:",omitempty"`
+	// RouterHostedZoneId is the Route53 hosted zone id of Router. It is required by the alias record of apex routes
+	RouterHostedZoneId string `json:",omitempty"`
 }
 
 type DataPlaneKafkaRouteRequest struct {
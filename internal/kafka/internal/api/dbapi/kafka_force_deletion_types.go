@@ -0,0 +1,18 @@
+package dbapi
+
+import "time"
+
+// KafkaForceDeletion is the audit record of a kafka request hard deleted by an admin with a force delete, it holds
+// what is needed to track down the resources the kafka request may have left behind
+type KafkaForceDeletion struct {
+	ID             string    `json:"id" gorm:"primaryKey"`
+	KafkaID        string    `json:"kafka_id" gorm:"index"`
+	Name           string    `json:"name"`
+	Owner          string    `json:"owner"`
+	OrganisationId string    `json:"organisation_id"`
+	ClusterID      string    `json:"cluster_id"`
+	SubscriptionId string    `json:"subscription_id"`
+	Status         string    `json:"status"`
+	DeletedBy      string    `json:"deleted_by"`
+	CreatedAt      time.Time `json:"created_at"`
+}
@@ -2,10 +2,16 @@ package dbapi
 
 import (
 	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
 	"gorm.io/gorm"
+	apivalidation "k8s.io/apimachinery/pkg/api/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 )
 
 type KafkaRequest struct {
@@ -55,6 +61,43 @@ type KafkaRequest struct {
 	BillingCloudAccountId   string `json:"billing_cloud_account_id"`
 	Marketplace             string `json:"marketplace"`
 	BillingModel            string `json:"billing_model"`
+	// StatusUpdatedAt is the last time the status of the kafka request has been changed
+	StatusUpdatedAt *time.Time `json:"status_updated_at"`
+	// UpgradeStartedAt is the time at which the data plane started reporting an ongoing upgrade (strimzi, kafka or kafka ibp)
+	// of the kafka. It is nil when no upgrade is in progress.
+	UpgradeStartedAt *time.Time `json:"upgrade_started_at"`
+	// ProvisionedAt is the time at which the kafka became ready for the first time after being provisioned. It is nil
+	// until then and for the kafkas provisioned before it was recorded.
+	ProvisionedAt *time.Time `json:"provisioned_at"`
+	// MaintenanceWindowDay is the lowercase day of the week (e.g. "sunday") during which upgrades of the kafka are allowed.
+	// No maintenance window is defined when empty.
+	MaintenanceWindowDay string `json:"maintenance_window_day"`
+	// MaintenanceWindowStart is the UTC time, in HH:MM format, at which the maintenance window starts
+	MaintenanceWindowStart string `json:"maintenance_window_start"`
+	// MaintenanceWindowEnd is the UTC time, in HH:MM format, at which the maintenance window ends. 24:00 can be used to end
+	// the window at midnight.
+	MaintenanceWindowEnd string `json:"maintenance_window_end"`
+	// DeprovisionReason is why the kafka has been deprovisioned (e.g. "user_request" or "expired"). It is empty until the
+	// kafka is deprovisioned.
+	DeprovisionReason string `json:"deprovision_reason"`
+	// StatusBeforeDeprovision is the status the kafka is restored to when its deletion is cancelled during the
+	// deprovision grace period. It is empty unless the kafka is in deprovision_pending status.
+	StatusBeforeDeprovision string `json:"status_before_deprovision"`
+	// Annotations are custom annotations added to the ManagedKafka CR of the kafka, e.g. for the data plane operator to act on.
+	// Stored as a JSON object of string values.
+	Annotations api.JSON `json:"annotations"`
+	// MaxConnectionAttemptsPerSecOverride throttles the connection attempts of the kafka below the limit of its size.
+	// The limit of the size is used when nil.
+	MaxConnectionAttemptsPerSecOverride *int `json:"max_connection_attempts_per_sec_override"`
+	// CapacityConsumedOverride is the capacity consumed by the kafka when it differs from the capacity consumed by its size,
+	// e.g. for the kafkas of grandfathered plans. The capacity consumed by the size is used when nil.
+	CapacityConsumedOverride *int `json:"capacity_consumed_override"`
+	// ReconciliationPaused annotates the ManagedKafka CR of the kafka sent to the data plane so that the agent leaves it
+	// alone, e.g. while a broken kafka is investigated
+	ReconciliationPaused bool `json:"reconciliation_paused"`
+	// ReauthenticationUnspecified is set when the kafka is requested without a reauthentication setting, the default
+	// reauthentication of its instance type is then applied when it is registered. It is not persisted.
+	ReauthenticationUnspecified bool `json:"-" gorm:"-"`
 }
 
 type KafkaList []*KafkaRequest
@@ -98,6 +141,47 @@ func (k *KafkaRequest) SetRoutes(routes []DataPlaneKafkaRoute) error {
 	}
 }
 
+// ReservedAnnotationPrefix is the prefix of the ManagedKafka CR annotations set by kas-fleet-manager.
+// Custom annotations with this prefix are not allowed.
+const ReservedAnnotationPrefix = "bf2.org/"
+
+func (k *KafkaRequest) GetAnnotations() (map[string]string, error) {
+	annotations := map[string]string{}
+	if k.Annotations == nil {
+		return annotations, nil
+	}
+	if err := json.Unmarshal(k.Annotations, &annotations); err != nil {
+		return nil, err
+	}
+	return annotations, nil
+}
+
+func (k *KafkaRequest) SetAnnotations(annotations map[string]string) error {
+	if len(annotations) == 0 {
+		k.Annotations = nil
+		return nil
+	}
+	a, err := json.Marshal(annotations)
+	if err != nil {
+		return err
+	}
+	k.Annotations = a
+	return nil
+}
+
+// ValidateAnnotations validates the custom annotations of a kafka against the Kubernetes annotation rules
+// and rejects the keys using the ReservedAnnotationPrefix
+func ValidateAnnotations(annotations map[string]string) error {
+	fldPath := field.NewPath("annotations")
+	errs := apivalidation.ValidateAnnotations(annotations, fldPath)
+	for key := range annotations {
+		if strings.HasPrefix(key, ReservedAnnotationPrefix) {
+			errs = append(errs, field.Forbidden(fldPath.Key(key), fmt.Sprintf("the %s prefix is reserved", ReservedAnnotationPrefix)))
+		}
+	}
+	return errs.ToAggregate()
+}
+
 // GetExpirationTime returns when the Kafka request will expire based on the
 // provided lifespanSeconds value. lifespanSeconds is assumed to be greater
 // than 0
@@ -105,3 +189,98 @@ func (k *KafkaRequest) GetExpirationTime(lifespanSeconds int) *time.Time {
 	expireTime := k.CreatedAt.Add(time.Duration(lifespanSeconds) * time.Second)
 	return &expireTime
 }
+
+// GetStatusUpdatedAt returns when the Kafka request has been moved to its current status, or when it has been created
+// if its status has not been changed since the status_updated_at column exists
+func (k *KafkaRequest) GetStatusUpdatedAt() time.Time {
+	if k.StatusUpdatedAt == nil {
+		return k.CreatedAt
+	}
+	return *k.StatusUpdatedAt
+}
+
+// HasMaintenanceWindow returns true if a maintenance window has been defined for the kafka
+func (k *KafkaRequest) HasMaintenanceWindow() bool {
+	return k.MaintenanceWindowDay != ""
+}
+
+// IsInMaintenanceWindow returns true if the given time is within the maintenance window of the kafka.
+// It always returns true when the kafka has no maintenance window.
+func (k *KafkaRequest) IsInMaintenanceWindow(t time.Time) bool {
+	if !k.HasMaintenanceWindow() {
+		return true
+	}
+
+	day, start, end, err := parseMaintenanceWindow(k.MaintenanceWindowDay, k.MaintenanceWindowStart, k.MaintenanceWindowEnd)
+	if err != nil {
+		return false
+	}
+
+	t = t.UTC()
+	minuteOfDay := t.Hour()*60 + t.Minute()
+	return t.Weekday() == day && minuteOfDay >= start && minuteOfDay < end
+}
+
+// GetMaintenanceWindow returns the maintenance window of the kafka in the "<day> <start>-<end>" format (e.g. "sunday 02:00-04:00"),
+// or an empty string if the kafka has no maintenance window
+func (k *KafkaRequest) GetMaintenanceWindow() string {
+	if !k.HasMaintenanceWindow() {
+		return ""
+	}
+	return fmt.Sprintf("%s %s-%s", k.MaintenanceWindowDay, k.MaintenanceWindowStart, k.MaintenanceWindowEnd)
+}
+
+// ValidateMaintenanceWindow validates that day is a lowercase day of the week and that start and end are UTC times in
+// HH:MM format, start being before end. Windows spanning midnight are not supported.
+func ValidateMaintenanceWindow(day, start, end string) error {
+	_, _, _, err := parseMaintenanceWindow(day, start, end)
+	return err
+}
+
+var weekdays = map[string]time.Weekday{
+	"sunday":    time.Sunday,
+	"monday":    time.Monday,
+	"tuesday":   time.Tuesday,
+	"wednesday": time.Wednesday,
+	"thursday":  time.Thursday,
+	"friday":    time.Friday,
+	"saturday":  time.Saturday,
+}
+
+var maintenanceWindowTimeRegexp = regexp.MustCompile(`^([01][0-9]|2[0-3]):([0-5][0-9])$`)
+
+// parseMaintenanceWindow returns the day of the week and the start and end minutes of the day of the given maintenance window
+func parseMaintenanceWindow(day, start, end string) (time.Weekday, int, int, error) {
+	weekday, ok := weekdays[day]
+	if !ok {
+		return 0, 0, 0, fmt.Errorf("invalid maintenance window day '%s': must be a lowercase day of the week", day)
+	}
+
+	startMinute, err := parseMaintenanceWindowTime(start)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+
+	endMinute := 24 * 60
+	if end != "24:00" {
+		if endMinute, err = parseMaintenanceWindowTime(end); err != nil {
+			return 0, 0, 0, err
+		}
+	}
+
+	if startMinute >= endMinute {
+		return 0, 0, 0, fmt.Errorf("invalid maintenance window: start time '%s' must be before end time '%s'", start, end)
+	}
+
+	return weekday, startMinute, endMinute, nil
+}
+
+func parseMaintenanceWindowTime(value string) (int, error) {
+	matches := maintenanceWindowTimeRegexp.FindStringSubmatch(value)
+	if matches == nil {
+		return 0, fmt.Errorf("invalid maintenance window time '%s': must be in HH:MM format", value)
+	}
+	hours, _ := strconv.Atoi(matches[1])
+	minutes, _ := strconv.Atoi(matches[2])
+	return hours*60 + minutes, nil
+}
@@ -0,0 +1,195 @@
+package dbapi
+
+import (
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+)
+
+func TestValidateMaintenanceWindow(t *testing.T) {
+	tests := []struct {
+		name    string
+		day     string
+		start   string
+		end     string
+		wantErr bool
+	}{
+		{
+			name:    "should accept a valid maintenance window",
+			day:     "sunday",
+			start:   "02:00",
+			end:     "04:30",
+			wantErr: false,
+		},
+		{
+			name:    "should accept a maintenance window ending at midnight",
+			day:     "saturday",
+			start:   "22:00",
+			end:     "24:00",
+			wantErr: false,
+		},
+		{
+			name:    "should reject an unknown day",
+			day:     "Sunday",
+			start:   "02:00",
+			end:     "04:00",
+			wantErr: true,
+		},
+		{
+			name:    "should reject a time not in HH:MM format",
+			day:     "monday",
+			start:   "2:00",
+			end:     "04:00",
+			wantErr: true,
+		},
+		{
+			name:    "should reject an invalid hour",
+			day:     "monday",
+			start:   "02:00",
+			end:     "25:00",
+			wantErr: true,
+		},
+		{
+			name:    "should reject a start time after the end time",
+			day:     "monday",
+			start:   "04:00",
+			end:     "02:00",
+			wantErr: true,
+		},
+		{
+			name:    "should reject an empty window",
+			day:     "monday",
+			start:   "04:00",
+			end:     "04:00",
+			wantErr: true,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			g.Expect(ValidateMaintenanceWindow(tt.day, tt.start, tt.end) != nil).To(gomega.Equal(tt.wantErr))
+		})
+	}
+}
+
+func TestKafkaRequest_IsInMaintenanceWindow(t *testing.T) {
+	// 2022-09-04 is a sunday
+	sunday := func(hour, minute int) time.Time {
+		return time.Date(2022, time.September, 4, hour, minute, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name  string
+		kafka KafkaRequest
+		time  time.Time
+		want  bool
+	}{
+		{
+			name:  "should always be in the maintenance window when the kafka has none",
+			kafka: KafkaRequest{},
+			time:  sunday(12, 0),
+			want:  true,
+		},
+		{
+			name:  "should be in the maintenance window at its start",
+			kafka: KafkaRequest{MaintenanceWindowDay: "sunday", MaintenanceWindowStart: "02:00", MaintenanceWindowEnd: "04:00"},
+			time:  sunday(2, 0),
+			want:  true,
+		},
+		{
+			name:  "should not be in the maintenance window at its end",
+			kafka: KafkaRequest{MaintenanceWindowDay: "sunday", MaintenanceWindowStart: "02:00", MaintenanceWindowEnd: "04:00"},
+			time:  sunday(4, 0),
+			want:  false,
+		},
+		{
+			name:  "should not be in the maintenance window on another day",
+			kafka: KafkaRequest{MaintenanceWindowDay: "monday", MaintenanceWindowStart: "02:00", MaintenanceWindowEnd: "04:00"},
+			time:  sunday(3, 0),
+			want:  false,
+		},
+		{
+			name:  "should compare against the UTC time",
+			kafka: KafkaRequest{MaintenanceWindowDay: "sunday", MaintenanceWindowStart: "02:00", MaintenanceWindowEnd: "04:00"},
+			time:  sunday(3, 0).In(time.FixedZone("UTC+10", 10*60*60)),
+			want:  true,
+		},
+		{
+			name:  "should be in a maintenance window ending at midnight",
+			kafka: KafkaRequest{MaintenanceWindowDay: "sunday", MaintenanceWindowStart: "22:00", MaintenanceWindowEnd: "24:00"},
+			time:  sunday(23, 59),
+			want:  true,
+		},
+		{
+			name:  "should not be in an invalid maintenance window",
+			kafka: KafkaRequest{MaintenanceWindowDay: "sunday", MaintenanceWindowStart: "invalid", MaintenanceWindowEnd: "04:00"},
+			time:  sunday(3, 0),
+			want:  false,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			g.Expect(tt.kafka.IsInMaintenanceWindow(tt.time)).To(gomega.Equal(tt.want))
+		})
+	}
+}
+
+func TestValidateAnnotations(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		wantErr     bool
+	}{
+		{
+			name:        "should accept empty annotations",
+			annotations: map[string]string{},
+			wantErr:     false,
+		},
+		{
+			name:        "should accept valid annotations",
+			annotations: map[string]string{"example.com/team": "streaming", "owner": "someone"},
+			wantErr:     false,
+		},
+		{
+			name:        "should reject an invalid key",
+			annotations: map[string]string{"not a valid key!": "value"},
+			wantErr:     true,
+		},
+		{
+			name:        "should reject a key with the reserved prefix",
+			annotations: map[string]string{"bf2.org/id": "some-id"},
+			wantErr:     true,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			g.Expect(ValidateAnnotations(tt.annotations) != nil).To(gomega.Equal(tt.wantErr))
+		})
+	}
+}
+
+func TestKafkaRequest_Annotations(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	kafka := &KafkaRequest{}
+	annotations, err := kafka.GetAnnotations()
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(annotations).To(gomega.BeEmpty())
+
+	g.Expect(kafka.SetAnnotations(map[string]string{"example.com/team": "streaming"})).To(gomega.Succeed())
+	annotations, err = kafka.GetAnnotations()
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(annotations).To(gomega.Equal(map[string]string{"example.com/team": "streaming"}))
+
+	g.Expect(kafka.SetAnnotations(nil)).To(gomega.Succeed())
+	g.Expect(kafka.Annotations).To(gomega.BeNil())
+}
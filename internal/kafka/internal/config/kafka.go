@@ -2,13 +2,23 @@ package config
 
 import (
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/environments"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared/utils/arrays"
 	"github.com/spf13/pflag"
+	"k8s.io/apimachinery/pkg/util/validation"
 )
 
+// KafkaDomainConfig is the domain name and the Route53 hosted zone used for the kafkas of a cloud provider
+type KafkaDomainConfig struct {
+	DomainName   string `yaml:"domain_name"`
+	HostedZoneID string `yaml:"hosted_zone_id"`
+}
+
 type KafkaConfig struct {
 	KafkaTLSCert                   string
 	KafkaTLSCertFile               string
@@ -17,7 +27,10 @@ type KafkaConfig struct {
 	EnableKafkaExternalCertificate bool
 	EnableKafkaCNAMERegistration   bool
 	KafkaDomainName                string
-	BrowserUrl                     string
+	// CloudProviderDomains overrides the KafkaDomainName of the kafkas of the given cloud providers
+	CloudProviderDomains     map[string]KafkaDomainConfig
+	CloudProviderDomainsFile string
+	BrowserUrl               string
 
 	KafkaLifespan          *KafkaLifespanConfig
 	Quota                  *KafkaQuotaConfig
@@ -25,6 +38,39 @@ type KafkaConfig struct {
 	EnableKafkaOwnerConfig bool
 	KafkaOwnerList         []string
 	KafkaOwnerListFile     string
+	// StreamingUnitCountCacheTTL is how long the streaming unit counts used for the capacity metrics are cached.
+	// Caching is disabled when zero
+	StreamingUnitCountCacheTTL time.Duration
+	// ClusterDNSCacheTTL is how long the DNS of the data plane clusters used to prepare the kafkas is cached.
+	// Caching is disabled when zero
+	ClusterDNSCacheTTL time.Duration
+	// CapacityRecomputationMaxAge is how old the capacity materialized by the capacity recomputation worker can be to
+	// be used by the capacity metrics and the region capacity checks. The worker is disabled when zero
+	CapacityRecomputationMaxAge time.Duration
+	// LifecycleEventsSinkURL is the URL the kafka lifecycle events are posted to in the CloudEvents format
+	// (e.g. the topic endpoint of a Kafka HTTP bridge). The events are discarded when empty
+	LifecycleEventsSinkURL     string
+	LifecycleEventsSinkTimeout time.Duration
+	// KafkaRequestCacheTTL is how long the kafka requests read by admins are cached, up to KafkaRequestCacheSize
+	// of them. Caching is disabled when either is zero
+	KafkaRequestCacheTTL  time.Duration
+	KafkaRequestCacheSize int
+	// KafkaNamespacePool is the list of pre-allocated namespaces the kafkas are assigned to. The namespace of each
+	// kafka is derived from its id when empty
+	KafkaNamespacePool     []string
+	KafkaNamespacePoolFile string
+	// SkipExternalCleanupOnDelete skips the deletion of the canary service account and of the CNAME records of the
+	// kafkas when they are deleted, for test environments without keycloak or Route53. It is refused in production
+	SkipExternalCleanupOnDelete bool
+	// DeprovisionGracePeriod is how long the deprovisioned kafkas stay in the deprovision_pending status, in which
+	// their deletion can be cancelled, before being deleted. The kafkas are deleted immediately when zero
+	DeprovisionGracePeriod time.Duration
+	// AllowCrossCloudMarketplaceBilling allows the kafkas to be billed through the marketplace of a cloud provider
+	// other than the one they are created in
+	AllowCrossCloudMarketplaceBilling bool
+	// ReauthenticationDisabledInstanceTypes are the instance types of the kafkas whose reauthentication is disabled
+	// when it is not specified in their request, it is enabled by default for the other instance types
+	ReauthenticationDisabledInstanceTypes []string
 }
 
 func NewKafkaConfig() *KafkaConfig {
@@ -40,6 +86,10 @@ func NewKafkaConfig() *KafkaConfig {
 		SupportedInstanceTypes:         NewKafkaSupportedInstanceTypesConfig(),
 		KafkaOwnerListFile:             "config/kafka-owner-list.yaml",
 		BrowserUrl:                     "http://localhost:8080/",
+		StreamingUnitCountCacheTTL:     30 * time.Second,
+		ClusterDNSCacheTTL:             30 * time.Second,
+		LifecycleEventsSinkTimeout:     5 * time.Second,
+		KafkaRequestCacheSize:          100,
 	}
 }
 
@@ -49,13 +99,27 @@ func (c *KafkaConfig) AddFlags(fs *pflag.FlagSet) {
 	fs.BoolVar(&c.EnableKafkaExternalCertificate, "enable-kafka-external-certificate", c.EnableKafkaExternalCertificate, "Enable custom certificate for Kafka TLS")
 	fs.BoolVar(&c.EnableKafkaCNAMERegistration, "enable-kafka-cname-registration", c.EnableKafkaCNAMERegistration, "Enable custom CNAME registration for Kafka instances")
 	fs.BoolVar(&c.KafkaLifespan.EnableDeletionOfExpiredKafka, "enable-deletion-of-expired-kafka", c.KafkaLifespan.EnableDeletionOfExpiredKafka, "Enable the deletion of kafkas when its life span has expired")
+	fs.StringVar(&c.KafkaLifespan.OrganisationLifespanSecondsFile, "kafka-organisation-lifespan-file", c.KafkaLifespan.OrganisationLifespanSecondsFile, "File containing the lifespan in seconds overriding the lifespan of the kafka sizes having one (e.g. developer instances) for the kafkas of each organisation id")
 	fs.StringVar(&c.KafkaDomainName, "kafka-domain-name", c.KafkaDomainName, "The domain name to use for Kafka instances")
+	fs.StringVar(&c.CloudProviderDomainsFile, "kafka-cloud-provider-domains-file", c.CloudProviderDomainsFile, "File containing the domain name and Route53 hosted zone id to use for the Kafka instances of each cloud provider. The kafka domain name is used for cloud providers not in the file")
 	fs.StringVar(&c.Quota.Type, "quota-type", c.Quota.Type, "The type of the quota service to be used. The available options are: 'ams' for AMS backed implementation and 'quota-management-list' for quota list backed implementation (default).")
 	fs.BoolVar(&c.Quota.AllowDeveloperInstance, "allow-developer-instance", c.Quota.AllowDeveloperInstance, "Allow the creation of kafka developer instances")
 	fs.StringVar(&c.SupportedInstanceTypes.ConfigurationFile, "supported-kafka-instance-types-config-file", c.SupportedInstanceTypes.ConfigurationFile, "File containing the supported instance types configuration")
 	fs.StringVar(&c.BrowserUrl, "browser-url", c.BrowserUrl, "Browser url to kafka admin UI")
 	fs.BoolVar(&c.EnableKafkaOwnerConfig, "enable-kafka-owner-config", c.EnableKafkaOwnerConfig, "Enable configuration for setting kafka owners")
 	fs.StringVar(&c.KafkaOwnerListFile, "kafka-owner-list-file", c.KafkaOwnerListFile, "File containing list of kafka owners")
+	fs.DurationVar(&c.StreamingUnitCountCacheTTL, "streaming-unit-count-cache-ttl", c.StreamingUnitCountCacheTTL, "How long the streaming unit counts used for the capacity metrics are cached. Set to 0 to disable caching")
+	fs.DurationVar(&c.CapacityRecomputationMaxAge, "capacity-recomputation-max-age", c.CapacityRecomputationMaxAge, "How old the capacity periodically recomputed in the background can be to be used by the capacity metrics and the region capacity checks, which compute it on each call otherwise. Set to 0 to disable the background recomputation")
+	fs.DurationVar(&c.ClusterDNSCacheTTL, "cluster-dns-cache-ttl", c.ClusterDNSCacheTTL, "How long the DNS of the data plane clusters used to prepare the kafkas is cached. Set to 0 to disable caching")
+	fs.StringVar(&c.LifecycleEventsSinkURL, "kafka-lifecycle-events-sink-url", c.LifecycleEventsSinkURL, "URL the kafka lifecycle events are posted to in the CloudEvents format, e.g. the topic endpoint of a Kafka HTTP bridge. The events are not published when empty")
+	fs.DurationVar(&c.LifecycleEventsSinkTimeout, "kafka-lifecycle-events-sink-timeout", c.LifecycleEventsSinkTimeout, "Timeout for posting a kafka lifecycle event")
+	fs.DurationVar(&c.KafkaRequestCacheTTL, "kafka-request-cache-ttl", c.KafkaRequestCacheTTL, "How long the kafka requests read by admins are cached. Set to 0 to disable caching")
+	fs.IntVar(&c.KafkaRequestCacheSize, "kafka-request-cache-size", c.KafkaRequestCacheSize, "Maximum number of kafka requests cached, the least recently used ones are evicted first")
+	fs.StringVar(&c.KafkaNamespacePoolFile, "kafka-namespace-pool-file", c.KafkaNamespacePoolFile, "File containing the list of pre-allocated namespaces the kafkas are assigned to. The namespace of each kafka is derived from its id when not set")
+	fs.BoolVar(&c.SkipExternalCleanupOnDelete, "skip-kafka-external-cleanup-on-delete", c.SkipExternalCleanupOnDelete, "Skip the deletion of the canary service account and of the CNAME records of the deleted Kafka instances, for test environments without keycloak or Route53. Not allowed in production")
+	fs.DurationVar(&c.DeprovisionGracePeriod, "kafka-deprovision-grace-period", c.DeprovisionGracePeriod, "How long the deprovisioned Kafka instances can still be restored before being deleted. Set to 0 to delete them immediately")
+	fs.BoolVar(&c.AllowCrossCloudMarketplaceBilling, "allow-cross-cloud-marketplace-billing", c.AllowCrossCloudMarketplaceBilling, "Allow the Kafka instances to be billed through the marketplace of a cloud provider other than the one they are created in")
+	fs.StringSliceVar(&c.ReauthenticationDisabledInstanceTypes, "kafka-reauthentication-disabled-instance-types", c.ReauthenticationDisabledInstanceTypes, "Instance types of the Kafka instances whose reauthentication is disabled when it is not specified at creation time, it is enabled by default for the other instance types")
 	fs.IntVar(&c.Quota.MaxAllowedDeveloperInstances, "max-allowed-developer-instances", c.Quota.MaxAllowedDeveloperInstances, "As a user, one can create up to N defined max developer instances if they do not have quota to create standard instances")
 }
 
@@ -80,13 +144,108 @@ func (c *KafkaConfig) ReadFiles() error {
 		return err
 	}
 
+	if c.CloudProviderDomainsFile != "" {
+		err = shared.ReadYamlFile(c.CloudProviderDomainsFile, &c.CloudProviderDomains)
+		if err != nil {
+			return err
+		}
+	}
+
+	if c.KafkaNamespacePoolFile != "" {
+		err = shared.ReadYamlFile(c.KafkaNamespacePoolFile, &c.KafkaNamespacePool)
+		if err != nil {
+			return err
+		}
+	}
+
+	if c.KafkaLifespan.OrganisationLifespanSecondsFile != "" {
+		err = shared.ReadYamlFile(c.KafkaLifespan.OrganisationLifespanSecondsFile, &c.KafkaLifespan.OrganisationLifespanSeconds)
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
 func (c *KafkaConfig) Validate(env *environments.Env) error {
+	for provider, domain := range c.CloudProviderDomains {
+		if domain.DomainName == "" {
+			return fmt.Errorf("domain name of cloud provider '%s' cannot be empty", provider)
+		}
+	}
+	if err := c.validateKafkaNamespacePool(); err != nil {
+		return err
+	}
+	if err := c.validateSkipExternalCleanupOnDelete(env.Name); err != nil {
+		return err
+	}
+	if err := c.KafkaLifespan.validate(); err != nil {
+		return err
+	}
+	if err := c.validateReauthenticationDisabledInstanceTypes(); err != nil {
+		return err
+	}
+	if err := c.validateLifecycleEventsSinkTimeout(); err != nil {
+		return err
+	}
 	return c.SupportedInstanceTypes.Configuration.validate()
 }
 
+func (c *KafkaConfig) validateReauthenticationDisabledInstanceTypes() error {
+	for _, instanceType := range c.ReauthenticationDisabledInstanceTypes {
+		if _, err := c.SupportedInstanceTypes.Configuration.GetKafkaInstanceTypeByID(instanceType); err != nil {
+			return fmt.Errorf("instance type '%s' of kafka-reauthentication-disabled-instance-types is not supported", instanceType)
+		}
+	}
+	return nil
+}
+
+// validateLifecycleEventsSinkTimeout makes sure the posting of a lifecycle event is bounded, the events are posted one
+// at a time so a sink that never answers would hold back all the following events
+func (c *KafkaConfig) validateLifecycleEventsSinkTimeout() error {
+	if c.LifecycleEventsSinkURL != "" && c.LifecycleEventsSinkTimeout <= 0 {
+		return fmt.Errorf("kafka-lifecycle-events-sink-timeout must be greater than 0, got %s", c.LifecycleEventsSinkTimeout)
+	}
+	return nil
+}
+
+func (c *KafkaConfig) validateSkipExternalCleanupOnDelete(envName string) error {
+	if c.SkipExternalCleanupOnDelete && envName == environments.ProductionEnv {
+		return fmt.Errorf("skip-kafka-external-cleanup-on-delete cannot be enabled in the %s environment", envName)
+	}
+	return nil
+}
+
+func (c *KafkaConfig) validateKafkaNamespacePool() error {
+	namespaces := make(map[string]struct{}, len(c.KafkaNamespacePool))
+	for _, namespace := range c.KafkaNamespacePool {
+		if errs := validation.IsDNS1123Label(namespace); len(errs) > 0 {
+			return fmt.Errorf("invalid namespace '%s' in the kafka namespace pool: %s", namespace, strings.Join(errs, ", "))
+		}
+		if _, ok := namespaces[namespace]; ok {
+			return fmt.Errorf("namespace '%s' is duplicated in the kafka namespace pool", namespace)
+		}
+		namespaces[namespace] = struct{}{}
+	}
+	return nil
+}
+
+// GetKafkaDomain returns the domain name and the Route53 hosted zone id to use for the kafkas of the given cloud provider.
+// The KafkaDomainName and an empty hosted zone id are returned if the cloud provider does not have its own domain.
+func (c *KafkaConfig) GetKafkaDomain(cloudProvider string) (string, string) {
+	if domain, ok := c.CloudProviderDomains[cloudProvider]; ok {
+		return domain.DomainName, domain.HostedZoneID
+	}
+	return c.KafkaDomainName, ""
+}
+
+// IsReauthenticationEnabledByDefault returns whether the reauthentication of the kafkas of the given instance type is
+// enabled when it is not specified in their request
+func (c *KafkaConfig) IsReauthenticationEnabledByDefault(instanceType string) bool {
+	return !arrays.Contains(c.ReauthenticationDisabledInstanceTypes, instanceType)
+}
+
 func (c *KafkaConfig) GetFirstAvailableSize(instanceType string) (*KafkaInstanceSize, error) {
 	kafkaInstanceType, err := c.SupportedInstanceTypes.Configuration.GetKafkaInstanceTypeByID(instanceType)
 	if err != nil {
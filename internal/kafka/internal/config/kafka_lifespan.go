@@ -1,7 +1,13 @@
 package config
 
+import "fmt"
+
 type KafkaLifespanConfig struct {
 	EnableDeletionOfExpiredKafka bool
+	// OrganisationLifespanSeconds overrides the lifespan of the kafka sizes having one (e.g. developer instances)
+	// for the kafkas of the given organisations, e.g. to grant them an extended trial
+	OrganisationLifespanSeconds     map[string]int
+	OrganisationLifespanSecondsFile string
 }
 
 func NewKafkaLifespanConfig() *KafkaLifespanConfig {
@@ -9,3 +15,24 @@ func NewKafkaLifespanConfig() *KafkaLifespanConfig {
 		EnableDeletionOfExpiredKafka: true,
 	}
 }
+
+// GetLifespanSeconds returns the lifespan of a kafka of the given organisation whose size has the given lifespan.
+// The size lifespan is returned if the organisation doesn't have its own lifespan, and nil if the size has no lifespan
+func (c *KafkaLifespanConfig) GetLifespanSeconds(organisationId string, sizeLifespanSeconds *int) *int {
+	if sizeLifespanSeconds == nil || c == nil || organisationId == "" {
+		return sizeLifespanSeconds
+	}
+	if lifespanSeconds, ok := c.OrganisationLifespanSeconds[organisationId]; ok {
+		return &lifespanSeconds
+	}
+	return sizeLifespanSeconds
+}
+
+func (c *KafkaLifespanConfig) validate() error {
+	for organisationId, lifespanSeconds := range c.OrganisationLifespanSeconds {
+		if lifespanSeconds <= 0 {
+			return fmt.Errorf("lifespan of organisation '%s' must be greater than 0, got %d", organisationId, lifespanSeconds)
+		}
+	}
+	return nil
+}
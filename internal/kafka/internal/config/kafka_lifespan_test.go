@@ -28,3 +28,60 @@ func Test_NewKafkaLifespanConfig(t *testing.T) {
 		})
 	}
 }
+
+func Test_KafkaLifespanConfig_GetLifespanSeconds(t *testing.T) {
+	sizeLifespanSeconds := 172800
+	extendedLifespanSeconds := 2592000
+
+	tests := []struct {
+		name                string
+		config              *KafkaLifespanConfig
+		organisationId      string
+		sizeLifespanSeconds *int
+		want                *int
+	}{
+		{
+			name: "should return the lifespan of the organisation when it has its own lifespan",
+			config: &KafkaLifespanConfig{
+				OrganisationLifespanSeconds: map[string]int{"extended-org": extendedLifespanSeconds},
+			},
+			organisationId:      "extended-org",
+			sizeLifespanSeconds: &sizeLifespanSeconds,
+			want:                &extendedLifespanSeconds,
+		},
+		{
+			name: "should fall back to the size lifespan for other organisations",
+			config: &KafkaLifespanConfig{
+				OrganisationLifespanSeconds: map[string]int{"extended-org": extendedLifespanSeconds},
+			},
+			organisationId:      "other-org",
+			sizeLifespanSeconds: &sizeLifespanSeconds,
+			want:                &sizeLifespanSeconds,
+		},
+		{
+			name: "should not give a lifespan to the sizes without one",
+			config: &KafkaLifespanConfig{
+				OrganisationLifespanSeconds: map[string]int{"extended-org": extendedLifespanSeconds},
+			},
+			organisationId:      "extended-org",
+			sizeLifespanSeconds: nil,
+			want:                nil,
+		},
+		{
+			name:                "should fall back to the size lifespan when there is no lifespan config",
+			config:              nil,
+			organisationId:      "extended-org",
+			sizeLifespanSeconds: &sizeLifespanSeconds,
+			want:                &sizeLifespanSeconds,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			g.Expect(tt.config.GetLifespanSeconds(tt.organisationId, tt.sizeLifespanSeconds)).To(gomega.Equal(tt.want))
+		})
+	}
+}
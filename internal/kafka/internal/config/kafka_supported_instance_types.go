@@ -3,6 +3,7 @@ package config
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared/utils/arrays"
 
@@ -27,6 +28,9 @@ type KafkaInstanceType struct {
 	Id          string              `yaml:"id"`
 	DisplayName string              `yaml:"display_name"`
 	Sizes       []KafkaInstanceSize `yaml:"sizes"`
+	// ProvisioningTimeoutSeconds is how long the kafkas of the instance type can take to be provisioned before being
+	// failed. The kafkas of the instance type are never failed for taking too long to be provisioned when not set
+	ProvisioningTimeoutSeconds *int `yaml:"provisioning_timeout_seconds"`
 }
 
 func (kp *KafkaInstanceType) GetKafkaInstanceSizeByID(sizeId string) (*KafkaInstanceSize, error) {
@@ -67,6 +71,15 @@ func (kp *KafkaInstanceType) HasAnInstanceSizeWithLifespan() bool {
 	return false
 }
 
+// GetProvisioningTimeout returns how long the kafkas of the instance type can take to be provisioned before being
+// failed, the returned boolean is false when they never time out
+func (kp *KafkaInstanceType) GetProvisioningTimeout() (time.Duration, bool) {
+	if kp.ProvisioningTimeoutSeconds == nil {
+		return 0, false
+	}
+	return time.Duration(*kp.ProvisioningTimeoutSeconds) * time.Second, true
+}
+
 // validates kafka instance type config to ensure the following:
 // - id must be defined and included in the valid instance type id list
 // - display_name must be defined and included in the valid instance type list
@@ -80,6 +93,10 @@ func (kp *KafkaInstanceType) validate() error {
 		return fmt.Errorf("kafka instance type id '%s' is not valid. Valid kafka instance types are: '%v'", kp.Id, types.ValidKafkaInstanceTypes)
 	}
 
+	if kp.ProvisioningTimeoutSeconds != nil && *kp.ProvisioningTimeoutSeconds <= 0 {
+		return fmt.Errorf("Kafka instance type '%s' specifies a provisioning_timeout_seconds value less than or equals to Zero.", kp.Id)
+	}
+
 	existingSizes := make(map[string]int, len(kp.Sizes))
 
 	for _, kafkaInstanceSize := range kp.Sizes {
@@ -103,6 +120,7 @@ type KafkaInstanceSize struct {
 	EgressThroughputPerSec      Quantity       `yaml:"egressThroughputPerSec"`
 	TotalMaxConnections         int            `yaml:"totalMaxConnections"`
 	MaxDataRetentionSize        Quantity       `yaml:"maxDataRetentionSize"`
+	MinDataRetentionSize        Quantity       `yaml:"minDataRetentionSize"` // smallest storage size of the kafkas of this size, no minimum when empty
 	MaxPartitions               int            `yaml:"maxPartitions"`
 	MaxDataRetentionPeriod      string         `yaml:"maxDataRetentionPeriod"`
 	MaxConnectionAttemptsPerSec int            `yaml:"maxConnectionAttemptsPerSec"`
@@ -144,6 +162,16 @@ func (k *KafkaInstanceSize) validate(instanceTypeId string) error {
 		return fmt.Errorf("maxDataRetentionSize for Kafka instance type '%s', size '%s' is invalid: %s", k.Id, instanceTypeId, err.Error())
 	}
 
+	if k.MinDataRetentionSize != "" {
+		minDataRetentionSize, err := k.MinDataRetentionSize.ToK8Quantity()
+		if err != nil {
+			return fmt.Errorf("minDataRetentionSize for Kafka instance type '%s', size '%s' is invalid: %s", k.Id, instanceTypeId, err.Error())
+		}
+		if minDataRetentionSize.Cmp(*maxDataRetentionSize) > 0 {
+			return fmt.Errorf("minDataRetentionSize for Kafka instance type '%s', size '%s' is greater than its maxDataRetentionSize", k.Id, instanceTypeId)
+		}
+	}
+
 	maxDataRetentionPeriod, err := duration.ParseISO8601(k.MaxDataRetentionPeriod)
 	if err != nil {
 		return fmt.Errorf("maxDataRetentionPeriod for Kafka instance type '%s', size '%s' is invalid: %s", k.Id, instanceTypeId, err.Error())
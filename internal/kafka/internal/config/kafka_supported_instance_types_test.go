@@ -2,6 +2,7 @@ package config
 
 import (
 	"testing"
+	"time"
 
 	"github.com/onsi/gomega"
 )
@@ -175,6 +176,66 @@ func TestKafkaSupportedSizesConfig_Validate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "Should not return an error when property MinDataRetentionSize is not greater than MaxDataRetentionSize",
+			configFactoryFunc: func() SupportedKafkaInstanceTypesConfig {
+				testKafkaInstanceSizex1 := buildTestStandardKafkaInstanceSize()
+				testKafkaInstanceSizex1.MinDataRetentionSize = Quantity("100Gi")
+				res := SupportedKafkaInstanceTypesConfig{
+					SupportedKafkaInstanceTypes: []KafkaInstanceType{
+						{
+							Id:          "standard",
+							DisplayName: "Standard",
+							Sizes: []KafkaInstanceSize{
+								testKafkaInstanceSizex1,
+							},
+						},
+					},
+				}
+				return res
+			},
+			wantErr: false,
+		},
+		{
+			name: "Should return error when property MinDataRetentionSize is invalid",
+			configFactoryFunc: func() SupportedKafkaInstanceTypesConfig {
+				testKafkaInstanceSizex1 := buildTestStandardKafkaInstanceSize()
+				testKafkaInstanceSizex1.MinDataRetentionSize = Quantity("invalid")
+				res := SupportedKafkaInstanceTypesConfig{
+					SupportedKafkaInstanceTypes: []KafkaInstanceType{
+						{
+							Id:          "standard",
+							DisplayName: "Standard",
+							Sizes: []KafkaInstanceSize{
+								testKafkaInstanceSizex1,
+							},
+						},
+					},
+				}
+				return res
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should return error when property MinDataRetentionSize is greater than MaxDataRetentionSize",
+			configFactoryFunc: func() SupportedKafkaInstanceTypesConfig {
+				testKafkaInstanceSizex1 := buildTestStandardKafkaInstanceSize()
+				testKafkaInstanceSizex1.MinDataRetentionSize = Quantity("200Gi")
+				res := SupportedKafkaInstanceTypesConfig{
+					SupportedKafkaInstanceTypes: []KafkaInstanceType{
+						{
+							Id:          "standard",
+							DisplayName: "Standard",
+							Sizes: []KafkaInstanceSize{
+								testKafkaInstanceSizex1,
+							},
+						},
+					},
+				}
+				return res
+			},
+			wantErr: true,
+		},
 		{
 			name: "Should return error when property MaxDataRetentionPeriod is undefined",
 			configFactoryFunc: func() SupportedKafkaInstanceTypesConfig {
@@ -774,6 +835,25 @@ func TestKafkaSupportedSizesConfig_Validate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "Should return error when property ProvisioningTimeoutSeconds in a kafka instance type is set to 0",
+			configFactoryFunc: func() SupportedKafkaInstanceTypesConfig {
+				res := SupportedKafkaInstanceTypesConfig{
+					SupportedKafkaInstanceTypes: []KafkaInstanceType{
+						{
+							Id:                         "standard",
+							DisplayName:                "Standard",
+							ProvisioningTimeoutSeconds: &[]int{0}[0],
+							Sizes: []KafkaInstanceSize{
+								buildTestStandardKafkaInstanceSize(),
+							},
+						},
+					},
+				}
+				return res
+			},
+			wantErr: true,
+		},
 		{
 			name: "Should return an error if maturity status is invalid",
 			configFactoryFunc: func() SupportedKafkaInstanceTypesConfig {
@@ -868,6 +948,44 @@ func TestKafkaInstanceType_HasAnInstanceSizeWithLifespan(t *testing.T) {
 
 }
 
+func TestKafkaInstanceType_GetProvisioningTimeout(t *testing.T) {
+	tests := []struct {
+		name              string
+		kafkaInstanceType KafkaInstanceType
+		wantTimeout       time.Duration
+		wantOk            bool
+	}{
+		{
+			name: "returns the provisioning timeout of the kafka instance type when set",
+			kafkaInstanceType: KafkaInstanceType{
+				Id:                         "myinstancetype",
+				ProvisioningTimeoutSeconds: &[]int{1800}[0],
+			},
+			wantTimeout: 30 * time.Minute,
+			wantOk:      true,
+		},
+		{
+			name: "returns false when the kafka instance type has no provisioning timeout",
+			kafkaInstanceType: KafkaInstanceType{
+				Id: "myinstancetype",
+			},
+			wantTimeout: 0,
+			wantOk:      false,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			timeout, ok := tt.kafkaInstanceType.GetProvisioningTimeout()
+			g.Expect(timeout).To(gomega.Equal(tt.wantTimeout))
+			g.Expect(ok).To(gomega.Equal(tt.wantOk))
+		})
+	}
+}
+
 func TestKafkaInstanceType_GetBiggestCapacityConsumedSize(t *testing.T) {
 	tests := []struct {
 		name              string
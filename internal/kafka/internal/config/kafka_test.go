@@ -2,7 +2,9 @@ package config
 
 import (
 	"testing"
+	"time"
 
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/environments"
 	"github.com/onsi/gomega"
 )
 
@@ -24,6 +26,10 @@ func Test_NewKafkaConfig(t *testing.T) {
 				SupportedInstanceTypes:         NewKafkaSupportedInstanceTypesConfig(),
 				EnableKafkaOwnerConfig:         false,
 				KafkaOwnerListFile:             "config/kafka-owner-list.yaml",
+				StreamingUnitCountCacheTTL:     30 * time.Second,
+				ClusterDNSCacheTTL:             30 * time.Second,
+				LifecycleEventsSinkTimeout:     5 * time.Second,
+				KafkaRequestCacheSize:          100,
 			},
 		},
 	}
@@ -101,3 +107,185 @@ func Test_ReadFilesKafkaConfig(t *testing.T) {
 		})
 	}
 }
+
+func Test_GetKafkaDomain(t *testing.T) {
+	config := NewKafkaConfig()
+	config.CloudProviderDomains = map[string]KafkaDomainConfig{
+		"gcp": {
+			DomainName:   "gcp.kafka.bf2.dev",
+			HostedZoneID: "gcp-zone-id",
+		},
+	}
+
+	tests := []struct {
+		name             string
+		cloudProvider    string
+		wantDomainName   string
+		wantHostedZoneID string
+	}{
+		{
+			name:             "should return the domain of a cloud provider with its own domain",
+			cloudProvider:    "gcp",
+			wantDomainName:   "gcp.kafka.bf2.dev",
+			wantHostedZoneID: "gcp-zone-id",
+		},
+		{
+			name:             "should fall back to the kafka domain name for other cloud providers",
+			cloudProvider:    "aws",
+			wantDomainName:   "kafka.bf2.dev",
+			wantHostedZoneID: "",
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			domainName, hostedZoneID := config.GetKafkaDomain(tt.cloudProvider)
+			g.Expect(domainName).To(gomega.Equal(tt.wantDomainName))
+			g.Expect(hostedZoneID).To(gomega.Equal(tt.wantHostedZoneID))
+		})
+	}
+}
+
+func Test_IsReauthenticationEnabledByDefault(t *testing.T) {
+	config := NewKafkaConfig()
+	config.ReauthenticationDisabledInstanceTypes = []string{"developer"}
+
+	tests := []struct {
+		name         string
+		instanceType string
+		want         bool
+	}{
+		{
+			name:         "should disable the reauthentication of the instance types it is disabled for",
+			instanceType: "developer",
+			want:         false,
+		},
+		{
+			name:         "should enable the reauthentication of the other instance types",
+			instanceType: "standard",
+			want:         true,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			g.Expect(config.IsReauthenticationEnabledByDefault(tt.instanceType)).To(gomega.Equal(tt.want))
+		})
+	}
+}
+
+func Test_ValidateKafkaNamespacePool(t *testing.T) {
+	tests := []struct {
+		name    string
+		pool    []string
+		wantErr bool
+	}{
+		{
+			name:    "should return no error when the pool is empty",
+			wantErr: false,
+		},
+		{
+			name:    "should return no error when the namespaces are valid and unique",
+			pool:    []string{"kafka-pool-1", "kafka-pool-2"},
+			wantErr: false,
+		},
+		{
+			name:    "should return an error when a namespace is invalid",
+			pool:    []string{"kafka-pool-1", "Kafka_Pool_2"},
+			wantErr: true,
+		},
+		{
+			name:    "should return an error when a namespace is duplicated",
+			pool:    []string{"kafka-pool-1", "kafka-pool-1"},
+			wantErr: true,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			config := &KafkaConfig{KafkaNamespacePool: tt.pool}
+			g.Expect(config.validateKafkaNamespacePool() != nil).To(gomega.Equal(tt.wantErr))
+		})
+	}
+}
+
+func Test_ValidateSkipExternalCleanupOnDelete(t *testing.T) {
+	tests := []struct {
+		name    string
+		skip    bool
+		envName string
+		wantErr bool
+	}{
+		{
+			name:    "should return no error when the cleanup is not skipped in production",
+			envName: environments.ProductionEnv,
+			wantErr: false,
+		},
+		{
+			name:    "should return no error when the cleanup is skipped in a test environment",
+			skip:    true,
+			envName: environments.TestingEnv,
+			wantErr: false,
+		},
+		{
+			name:    "should return an error when the cleanup is skipped in production",
+			skip:    true,
+			envName: environments.ProductionEnv,
+			wantErr: true,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			config := &KafkaConfig{SkipExternalCleanupOnDelete: tt.skip}
+			g.Expect(config.validateSkipExternalCleanupOnDelete(tt.envName) != nil).To(gomega.Equal(tt.wantErr))
+		})
+	}
+}
+
+func Test_ValidateLifecycleEventsSinkTimeout(t *testing.T) {
+	tests := []struct {
+		name    string
+		url     string
+		timeout time.Duration
+		wantErr bool
+	}{
+		{
+			name:    "should return no error when no sink is configured",
+			wantErr: false,
+		},
+		{
+			name:    "should return no error when the sink has a timeout",
+			url:     "http://localhost:8080/topics/kafka-lifecycle",
+			timeout: 5 * time.Second,
+			wantErr: false,
+		},
+		{
+			name:    "should return an error when the sink has no timeout",
+			url:     "http://localhost:8080/topics/kafka-lifecycle",
+			wantErr: true,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			config := &KafkaConfig{LifecycleEventsSinkURL: tt.url, LifecycleEventsSinkTimeout: tt.timeout}
+			g.Expect(config.validateLifecycleEventsSinkTimeout() != nil).To(gomega.Equal(tt.wantErr))
+		})
+	}
+}
@@ -42,6 +42,16 @@ type Region struct {
 	Name                   string          `yaml:"name"`
 	Default                bool            `yaml:"default"`
 	SupportedInstanceTypes InstanceTypeMap `yaml:"supported_instance_type"`
+	// SupportsMultiAZ indicates whether the region is able to host multi AZ kafkas.
+	// If not set, the region is considered to support multi AZ.
+	SupportsMultiAZ *bool `yaml:"supports_multi_az"`
+	// MaintenanceMode indicates whether the region is in maintenance, in which case no kafka can be created in it
+	MaintenanceMode bool `yaml:"maintenance_mode"`
+}
+
+// IsMultiAZSupported returns true if multi AZ kafkas can be placed in the region
+func (r Region) IsMultiAZSupported() bool {
+	return r.SupportsMultiAZ == nil || *r.SupportsMultiAZ
 }
 
 func (r Region) IsInstanceTypeSupported(instanceType InstanceType) bool {
@@ -260,6 +270,19 @@ func (c *ProviderConfig) GetInstanceLimit(region string, providerName string, in
 	return reg.getLimitSetForInstanceTypeInRegion(instanceType)
 }
 
+// IsMultiAZSupported returns true if the given region of the given cloud provider is able to host multi AZ kafkas
+func (c *ProviderConfig) IsMultiAZSupported(region string, providerName string) (bool, *errs.ServiceError) {
+	provider, ok := c.ProvidersConfig.SupportedProviders.GetByName(providerName)
+	if !ok {
+		return false, errs.ProviderNotSupported(fmt.Sprintf("cloud provider '%s' is unsupported", providerName))
+	}
+	reg, ok := provider.Regions.GetByName(region)
+	if !ok {
+		return false, errs.RegionNotSupported(fmt.Sprintf("'%s' region in '%s' cloud provider is unsupported", region, providerName))
+	}
+	return reg.IsMultiAZSupported(), nil
+}
+
 // Read the contents of file into the providers config
 func readFileProvidersConfig(file string, val *ProviderConfiguration) error {
 	fileContents, err := shared.ReadFile(file)
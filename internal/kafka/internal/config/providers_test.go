@@ -362,6 +362,87 @@ func Test_GetInstanceLimit(t *testing.T) {
 	}
 }
 
+func Test_IsMultiAZSupported(t *testing.T) {
+	type args struct {
+		region       string
+		providerName string
+	}
+
+	supportsMultiAZ := false
+	singleAZRegion := Region{
+		Default:                true,
+		Name:                   "single-az-region",
+		SupportedInstanceTypes: instTypeMap,
+		SupportsMultiAZ:        &supportsMultiAZ,
+	}
+	config := ProviderConfig{
+		ProvidersConfig: ProviderConfiguration{
+			SupportedProviders: ProviderList{
+				{
+					Name:    providerName,
+					Default: true,
+					Regions: RegionList{region, singleAZRegion},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		args    args
+		want    bool
+		wantErr bool
+	}{
+		{
+			name: "should return true if multi AZ support is not set for the region",
+			args: args{
+				region:       regionName,
+				providerName: providerName,
+			},
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name: "should return false if the region does not support multi AZ",
+			args: args{
+				region:       singleAZRegion.Name,
+				providerName: providerName,
+			},
+			want:    false,
+			wantErr: false,
+		},
+		{
+			name: "should return an error for not supported provider",
+			args: args{
+				region:       regionName,
+				providerName: "invalid",
+			},
+			want:    false,
+			wantErr: true,
+		},
+		{
+			name: "should return an error for not supported region",
+			args: args{
+				region:       "invalid",
+				providerName: providerName,
+			},
+			want:    false,
+			wantErr: true,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			supported, err := config.IsMultiAZSupported(tt.args.region, tt.args.providerName)
+			g.Expect(err != nil).To(gomega.Equal(tt.wantErr))
+			g.Expect(supported).To(gomega.Equal(tt.want))
+		})
+	}
+}
+
 func Test_readFileProvidersConfig(t *testing.T) {
 	type args struct {
 		file string
@@ -107,11 +107,26 @@ func (h adminKafkaHandler) Delete(w http.ResponseWriter, r *http.Request) {
 	handlers.HandleDelete(w, r, cfg, http.StatusAccepted)
 }
 
+func (h adminKafkaHandler) ForceDelete(w http.ResponseWriter, r *http.Request) {
+	cfg := &handlers.HandlerConfig{
+		Action: func() (i interface{}, serviceError *errors.ServiceError) {
+			id := mux.Vars(r)["id"]
+			ctx := r.Context()
+
+			err := h.kafkaService.ForceDelete(ctx, id)
+			return nil, err
+		},
+	}
+
+	handlers.HandleDelete(w, r, cfg, http.StatusNoContent)
+}
+
 func (h *adminKafkaHandler) Update(w http.ResponseWriter, r *http.Request) {
 
 	id := mux.Vars(r)["id"]
 	ctx := r.Context()
-	kafkaRequest, err := h.kafkaService.Get(ctx, id)
+	// the kafka is read from the database rather than the cache as the update is based on it
+	kafkaRequest, err := h.kafkaService.GetById(id)
 
 	var kafkaUpdateReq private.KafkaUpdateRequest
 	cfg := &handlers.HandlerConfig{
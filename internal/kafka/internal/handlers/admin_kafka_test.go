@@ -233,6 +233,54 @@ func Test_Delete(t *testing.T) {
 	}
 }
 
+func Test_ForceDelete(t *testing.T) {
+	type fields struct {
+		kafkaService services.KafkaService
+	}
+
+	tests := []struct {
+		name           string
+		fields         fields
+		wantStatusCode int
+	}{
+		{
+			name: "should successfully force delete the kafka",
+			fields: fields{
+				kafkaService: &services.KafkaServiceMock{
+					ForceDeleteFunc: func(ctx context.Context, id string) *errors.ServiceError {
+						return nil
+					},
+				},
+			},
+			wantStatusCode: http.StatusNoContent,
+		},
+		{
+			name: "should return an error if the kafka cannot be force deleted",
+			fields: fields{
+				kafkaService: &services.KafkaServiceMock{
+					ForceDeleteFunc: func(ctx context.Context, id string) *errors.ServiceError {
+						return errors.NotFound("not found")
+					},
+				},
+			},
+			wantStatusCode: http.StatusNotFound,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			h := NewAdminKafkaHandler(tt.fields.kafkaService, nil, nil, nil)
+			req, rw := GetHandlerParams("DELETE", "/kafkas/{id}/force", nil, t)
+			h.ForceDelete(rw, req)
+			resp := rw.Result()
+			g.Expect(resp.StatusCode).To(gomega.Equal(tt.wantStatusCode))
+			resp.Body.Close()
+		})
+	}
+}
+
 func Test_adminKafkaHandler_Update(t *testing.T) {
 	type fields struct {
 		kafkaService   services.KafkaService
@@ -266,7 +314,7 @@ func Test_adminKafkaHandler_Update(t *testing.T) {
 					},
 				},
 				kafkaService: &services.KafkaServiceMock{
-					GetFunc: func(ctx context.Context, id string) (*dbapi.KafkaRequest, *errors.ServiceError) {
+					GetByIdFunc: func(id string) (*dbapi.KafkaRequest, *errors.ServiceError) {
 						return nil, errors.GeneralError("test")
 					},
 					VerifyAndUpdateKafkaAdminFunc: func(ctx context.Context, kafkaRequest *dbapi.KafkaRequest) *errors.ServiceError {
@@ -285,7 +333,7 @@ func Test_adminKafkaHandler_Update(t *testing.T) {
 			name: "should return an error if kafka to update can't be found",
 			fields: fields{
 				kafkaService: &services.KafkaServiceMock{
-					GetFunc: func(ctx context.Context, id string) (*dbapi.KafkaRequest, *errors.ServiceError) {
+					GetByIdFunc: func(id string) (*dbapi.KafkaRequest, *errors.ServiceError) {
 						return nil, nil
 					},
 				},
@@ -300,7 +348,7 @@ func Test_adminKafkaHandler_Update(t *testing.T) {
 			name: "should return an error if kafka version is already being upgraded",
 			fields: fields{
 				kafkaService: &services.KafkaServiceMock{
-					GetFunc: func(ctx context.Context, id string) (*dbapi.KafkaRequest, *errors.ServiceError) {
+					GetByIdFunc: func(id string) (*dbapi.KafkaRequest, *errors.ServiceError) {
 						return &dbapi.KafkaRequest{
 							Status:         constants.KafkaRequestStatusPreparing.String(),
 							KafkaUpgrading: true,
@@ -318,7 +366,7 @@ func Test_adminKafkaHandler_Update(t *testing.T) {
 			name: "should return an error if strimzi version is already being upgraded",
 			fields: fields{
 				kafkaService: &services.KafkaServiceMock{
-					GetFunc: func(ctx context.Context, id string) (*dbapi.KafkaRequest, *errors.ServiceError) {
+					GetByIdFunc: func(id string) (*dbapi.KafkaRequest, *errors.ServiceError) {
 						return &dbapi.KafkaRequest{
 							Status:           constants.KafkaRequestStatusPreparing.String(),
 							StrimziUpgrading: true,
@@ -336,7 +384,7 @@ func Test_adminKafkaHandler_Update(t *testing.T) {
 			name: "should return an error if ibp version is already being upgraded",
 			fields: fields{
 				kafkaService: &services.KafkaServiceMock{
-					GetFunc: func(ctx context.Context, id string) (*dbapi.KafkaRequest, *errors.ServiceError) {
+					GetByIdFunc: func(id string) (*dbapi.KafkaRequest, *errors.ServiceError) {
 						return &dbapi.KafkaRequest{
 							Status:            constants.KafkaRequestStatusPreparing.String(),
 							KafkaIBPUpgrading: true,
@@ -370,7 +418,7 @@ func Test_adminKafkaHandler_Update(t *testing.T) {
 					},
 				},
 				kafkaService: &services.KafkaServiceMock{
-					GetFunc: func(ctx context.Context, id string) (*dbapi.KafkaRequest, *errors.ServiceError) {
+					GetByIdFunc: func(id string) (*dbapi.KafkaRequest, *errors.ServiceError) {
 						return mocks.BuildKafkaRequest(
 							mocks.With(mocks.STATUS, constants.KafkaRequestStatusAccepted.String()),
 							mocks.With(mocks.ID, "id"),
@@ -415,7 +463,7 @@ func Test_adminKafkaHandler_Update(t *testing.T) {
 					},
 				},
 				kafkaService: &services.KafkaServiceMock{
-					GetFunc: func(ctx context.Context, id string) (*dbapi.KafkaRequest, *errors.ServiceError) {
+					GetByIdFunc: func(id string) (*dbapi.KafkaRequest, *errors.ServiceError) {
 						return &dbapi.KafkaRequest{
 							Status: constants.KafkaRequestStatusPreparing.String(),
 							Meta: api.Meta{
@@ -462,7 +510,7 @@ func Test_adminKafkaHandler_Update(t *testing.T) {
 					},
 				},
 				kafkaService: &services.KafkaServiceMock{
-					GetFunc: func(ctx context.Context, id string) (*dbapi.KafkaRequest, *errors.ServiceError) {
+					GetByIdFunc: func(id string) (*dbapi.KafkaRequest, *errors.ServiceError) {
 						return &dbapi.KafkaRequest{
 							Status: constants.KafkaRequestStatusPreparing.String(),
 							Meta: api.Meta{
@@ -510,7 +558,7 @@ func Test_adminKafkaHandler_Update(t *testing.T) {
 					},
 				},
 				kafkaService: &services.KafkaServiceMock{
-					GetFunc: func(ctx context.Context, id string) (*dbapi.KafkaRequest, *errors.ServiceError) {
+					GetByIdFunc: func(id string) (*dbapi.KafkaRequest, *errors.ServiceError) {
 						return &dbapi.KafkaRequest{
 							Status: constants.KafkaRequestStatusDeprovision.String(),
 							Meta: api.Meta{
@@ -558,7 +606,7 @@ func Test_adminKafkaHandler_Update(t *testing.T) {
 					},
 				},
 				kafkaService: &services.KafkaServiceMock{
-					GetFunc: func(ctx context.Context, id string) (*dbapi.KafkaRequest, *errors.ServiceError) {
+					GetByIdFunc: func(id string) (*dbapi.KafkaRequest, *errors.ServiceError) {
 						return &dbapi.KafkaRequest{
 							Status: constants.KafkaRequestStatusDeleting.String(),
 							Meta: api.Meta{
@@ -605,7 +653,7 @@ func Test_adminKafkaHandler_Update(t *testing.T) {
 					},
 				},
 				kafkaService: &services.KafkaServiceMock{
-					GetFunc: func(ctx context.Context, id string) (*dbapi.KafkaRequest, *errors.ServiceError) {
+					GetByIdFunc: func(id string) (*dbapi.KafkaRequest, *errors.ServiceError) {
 						return &dbapi.KafkaRequest{
 							Status: constants.KafkaRequestStatusReady.String(),
 							Meta: api.Meta{
@@ -653,7 +701,7 @@ func Test_adminKafkaHandler_Update(t *testing.T) {
 					},
 				},
 				kafkaService: &services.KafkaServiceMock{
-					GetFunc: func(ctx context.Context, id string) (*dbapi.KafkaRequest, *errors.ServiceError) {
+					GetByIdFunc: func(id string) (*dbapi.KafkaRequest, *errors.ServiceError) {
 						return &dbapi.KafkaRequest{
 							Status: constants.KafkaRequestStatusSuspending.String(),
 							Meta: api.Meta{
@@ -701,7 +749,7 @@ func Test_adminKafkaHandler_Update(t *testing.T) {
 					},
 				},
 				kafkaService: &services.KafkaServiceMock{
-					GetFunc: func(ctx context.Context, id string) (*dbapi.KafkaRequest, *errors.ServiceError) {
+					GetByIdFunc: func(id string) (*dbapi.KafkaRequest, *errors.ServiceError) {
 						return &dbapi.KafkaRequest{
 							Status: constants.KafkaRequestStatusReady.String(),
 							Meta: api.Meta{
@@ -749,7 +797,7 @@ func Test_adminKafkaHandler_Update(t *testing.T) {
 					},
 				},
 				kafkaService: &services.KafkaServiceMock{
-					GetFunc: func(ctx context.Context, id string) (*dbapi.KafkaRequest, *errors.ServiceError) {
+					GetByIdFunc: func(id string) (*dbapi.KafkaRequest, *errors.ServiceError) {
 						return &dbapi.KafkaRequest{
 							Status: constants.KafkaRequestStatusReady.String(),
 							Meta: api.Meta{
@@ -797,7 +845,7 @@ func Test_adminKafkaHandler_Update(t *testing.T) {
 					},
 				},
 				kafkaService: &services.KafkaServiceMock{
-					GetFunc: func(ctx context.Context, id string) (*dbapi.KafkaRequest, *errors.ServiceError) {
+					GetByIdFunc: func(id string) (*dbapi.KafkaRequest, *errors.ServiceError) {
 						return &dbapi.KafkaRequest{
 							Status: constants.KafkaRequestStatusSuspended.String(),
 							Meta: api.Meta{
@@ -845,7 +893,7 @@ func Test_adminKafkaHandler_Update(t *testing.T) {
 					},
 				},
 				kafkaService: &services.KafkaServiceMock{
-					GetFunc: func(ctx context.Context, id string) (*dbapi.KafkaRequest, *errors.ServiceError) {
+					GetByIdFunc: func(id string) (*dbapi.KafkaRequest, *errors.ServiceError) {
 						return &dbapi.KafkaRequest{
 							Status: constants.KafkaRequestStatusSuspending.String(),
 							Meta: api.Meta{
@@ -44,28 +44,18 @@ func (h kafkaHandler) Create(w http.ResponseWriter, r *http.Request) {
 		MarshalInto: &kafkaRequestPayload,
 		Validate: []handlers.Validate{
 			handlers.ValidateAsyncEnabled(r, "creating kafka requests"),
-			handlers.ValidateLength(&kafkaRequestPayload.Name, "name", handlers.MinRequiredFieldLength, &MaxKafkaNameLength),
-			ValidKafkaClusterName(&kafkaRequestPayload.Name, "name"),
-			ValidateKafkaClusterNameIsUnique(&kafkaRequestPayload.Name, h.service, r.Context()),
 			ValidateKafkaClaims(ctx, ValidateUsername(), ValidateOrganisationId()),
-			ValidateCloudProvider(ctx, h.service, &kafkaRequestPayload, h.providerConfig, "creating kafka requests"),
-			ValidateKafkaPlan(ctx, h.service, h.kafkaConfig, &kafkaRequestPayload),
+			ValidateKafkaCreateRequest(ctx, h.service, h.kafkaConfig, h.providerConfig, &kafkaRequestPayload),
+			ValidateKafkaClusterNameIsUnique(&kafkaRequestPayload.Name, h.service, r.Context()),
 			ValidateBillingCloudAccountIdAndMarketplace(ctx, h.service, &kafkaRequestPayload),
-			ValidateBillingModel(&kafkaRequestPayload),
 		},
 		Action: func() (interface{}, *errors.ServiceError) {
-			convKafka := presenters.ConvertKafkaRequest(kafkaRequestPayload)
-
-			claims, _ := getClaims(ctx)
-			convKafka.Owner, _ = claims.GetUsername()
-			convKafka.OrganisationId, _ = claims.GetOrgId()
-			convKafka.OwnerAccountId, _ = claims.GetAccountId()
-
-			convKafka.InstanceType, convKafka.SizeId, _ = getInstanceTypeAndSize(ctx, h.service, h.kafkaConfig, &kafkaRequestPayload)
-
-			convKafka.CloudProvider, convKafka.Region, _ = getCloudProviderAndRegion(ctx, h.service, &kafkaRequestPayload, h.providerConfig)
+			convKafka, svcErr := newKafkaRequest(ctx, h.service, h.kafkaConfig, h.providerConfig, &kafkaRequestPayload)
+			if svcErr != nil {
+				return nil, svcErr
+			}
 
-			svcErr := h.service.RegisterKafkaJob(convKafka)
+			svcErr = h.service.RegisterKafkaJob(convKafka)
 			if svcErr != nil {
 				return nil, svcErr
 			}
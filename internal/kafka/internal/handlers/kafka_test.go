@@ -392,6 +392,9 @@ func Test_KafkaHandler_Create(t *testing.T) {
 					ListFunc: func(ctx context.Context, listArgs *s.ListArguments) (dbapi.KafkaList, *api.PagingMeta, *errors.ServiceError) {
 						return dbapi.KafkaList{}, &api.PagingMeta{}, nil
 					},
+					ValidateCreateRequestFunc: func(kafkaRequest *dbapi.KafkaRequest) *errors.ServiceError {
+						return nil
+					},
 					RegisterKafkaJobFunc: func(kafkaRequest *dbapi.KafkaRequest) *errors.ServiceError {
 						kafkaRequest.KafkaStorageSize = mocksupportedinstancetypes.DefaultMaxDataRetentionSize
 						return nil
@@ -420,6 +423,9 @@ func Test_KafkaHandler_Create(t *testing.T) {
 					ListFunc: func(ctx context.Context, listArgs *s.ListArguments) (dbapi.KafkaList, *api.PagingMeta, *errors.ServiceError) {
 						return dbapi.KafkaList{}, &api.PagingMeta{}, nil
 					},
+					ValidateCreateRequestFunc: func(kafkaRequest *dbapi.KafkaRequest) *errors.ServiceError {
+						return nil
+					},
 					RegisterKafkaJobFunc: func(kafkaRequest *dbapi.KafkaRequest) *errors.ServiceError {
 						return errors.GeneralError("create failed")
 					},
@@ -437,6 +443,30 @@ func Test_KafkaHandler_Create(t *testing.T) {
 			},
 			wantStatusCode: http.StatusInternalServerError,
 		},
+		{
+			name: "fails if the create request is not valid",
+			fields: fields{
+				service: &services.KafkaServiceMock{
+					ListFunc: func(ctx context.Context, listArgs *s.ListArguments) (dbapi.KafkaList, *api.PagingMeta, *errors.ServiceError) {
+						return dbapi.KafkaList{}, &api.PagingMeta{}, nil
+					},
+					ValidateCreateRequestFunc: func(kafkaRequest *dbapi.KafkaRequest) *errors.ServiceError {
+						return errors.InstanceTypeNotSupported("instance type 'standard' is not supported in region 'us-east-1'")
+					},
+					AssignInstanceTypeFunc: func(owner, organisationID string) (types.KafkaInstanceType, *errors.ServiceError) {
+						return types.STANDARD, nil
+					},
+				},
+				providerConfig: &supportedProviders,
+				kafkaConfig:    &fullKafkaConfig,
+			},
+			args: args{
+				url:  "/kafkas?async=true",
+				body: []byte(`{"name": "name", "cloud_provider": "aws", "region": "us-east-1"}`),
+				ctx:  ctx,
+			},
+			wantStatusCode: http.StatusBadRequest,
+		},
 		{
 			name: "fails if validation fails while async is not enabled",
 			args: args{
@@ -451,7 +481,18 @@ func Test_KafkaHandler_Create(t *testing.T) {
 					GetFunc: func(ctx context.Context, id string) (*dbapi.KafkaRequest, *errors.ServiceError) {
 						return mocks.BuildKafkaRequest(mocks.WithPredefinedTestValues()), nil
 					},
+					AssignInstanceTypeFunc: func(owner, organisationID string) (types.KafkaInstanceType, *errors.ServiceError) {
+						return types.STANDARD, nil
+					},
+					ValidateCreateRequestFunc: func(kafkaRequest *dbapi.KafkaRequest) *errors.ServiceError {
+						if kafkaRequest.Name == "" {
+							return errors.MalformedKafkaClusterName("name '' must be between 1 and 32 characters long")
+						}
+						return nil
+					},
 				},
+				providerConfig: &supportedProviders,
+				kafkaConfig:    &fullKafkaConfig,
 			},
 			args: args{
 				url:  "/kafkas?async=true",
@@ -470,6 +511,9 @@ func Test_KafkaHandler_Create(t *testing.T) {
 					ListFunc: func(ctx context.Context, listArgs *s.ListArguments) (dbapi.KafkaList, *api.PagingMeta, *errors.ServiceError) {
 						return dbapi.KafkaList{}, &api.PagingMeta{}, nil
 					},
+					AssignInstanceTypeFunc: func(owner, organisationID string) (types.KafkaInstanceType, *errors.ServiceError) {
+						return types.STANDARD, nil
+					},
 				},
 				providerConfig: &config.ProviderConfig{
 					ProvidersConfig: config.ProviderConfiguration{
@@ -529,6 +573,9 @@ func Test_KafkaHandler_Create(t *testing.T) {
 			name: "fails if ValidateBillingCloudAccountIdAndMarketplace fails",
 			fields: fields{
 				service: &services.KafkaServiceMock{
+					ValidateCreateRequestFunc: func(kafkaRequest *dbapi.KafkaRequest) *errors.ServiceError {
+						return nil
+					},
 					GetFunc: func(ctx context.Context, id string) (*dbapi.KafkaRequest, *errors.ServiceError) {
 						return mocks.BuildKafkaRequest(mocks.WithPredefinedTestValues()), nil
 					},
@@ -553,6 +600,12 @@ func Test_KafkaHandler_Create(t *testing.T) {
 			name: "fails if BillingModelValidation fails",
 			fields: fields{
 				service: &services.KafkaServiceMock{
+					ValidateCreateRequestFunc: func(kafkaRequest *dbapi.KafkaRequest) *errors.ServiceError {
+						if kafkaRequest.BillingModel != "" {
+							return errors.InvalidBillingAccount("invalid billing model: %s, only standard and marketplace are allowed", kafkaRequest.BillingModel)
+						}
+						return nil
+					},
 					GetFunc: func(ctx context.Context, id string) (*dbapi.KafkaRequest, *errors.ServiceError) {
 						return mocks.BuildKafkaRequest(mocks.WithPredefinedTestValues()), nil
 					},
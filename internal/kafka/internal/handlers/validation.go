@@ -3,12 +3,10 @@ package handlers
 import (
 	"context"
 	"fmt"
-	"regexp"
 	"strings"
 
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared/utils/arrays"
-	v1 "github.com/openshift-online/ocm-sdk-go/accountsmgmt/v1"
 
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
 
@@ -16,6 +14,7 @@ import (
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/dbapi"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/public"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/config"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/presenters"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/services"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/auth"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
@@ -25,22 +24,6 @@ import (
 	resource "k8s.io/apimachinery/pkg/api/resource"
 )
 
-var ValidKafkaClusterNameRegexp = regexp.MustCompile(`^[a-z]([-a-z0-9]*[a-z0-9])?$`)
-
-var MaxKafkaNameLength = 32
-
-func ValidateBillingModel(kafkaRequestPayload *public.KafkaRequestPayload) handlers.Validate {
-	return func() *errors.ServiceError {
-		// the billing model can only be either standard or marketplace
-		billingModel := shared.SafeString(kafkaRequestPayload.BillingModel)
-		if billingModel != "" && billingModel != string(v1.BillingModelStandard) && billingModel != string(v1.BillingModelMarketplace) {
-			return errors.InvalidBillingAccount("invalid billing model: %s, only %v and %v are allowed", billingModel,
-				v1.BillingModelStandard, v1.BillingModelMarketplace)
-		}
-		return nil
-	}
-}
-
 func ValidateBillingCloudAccountIdAndMarketplace(ctx context.Context, kafkaService services.KafkaService, kafkaRequestPayload *public.KafkaRequestPayload) handlers.Validate {
 	return func() *errors.ServiceError {
 		// both fields are optional
@@ -70,13 +53,42 @@ func ValidateBillingCloudAccountIdAndMarketplace(ctx context.Context, kafkaServi
 	}
 }
 
-func ValidKafkaClusterName(value *string, field string) handlers.Validate {
+// ValidateKafkaCreateRequest returns a validator running the create time validations of the kafka service against the
+// kafka request built from the payload, so that the REST API enforces the same rules as the kafka registration
+func ValidateKafkaCreateRequest(ctx context.Context, kafkaService services.KafkaService, kafkaConfig *config.KafkaConfig, providerConfig *config.ProviderConfig, kafkaRequestPayload *public.KafkaRequestPayload) handlers.Validate {
 	return func() *errors.ServiceError {
-		if !ValidKafkaClusterNameRegexp.MatchString(*value) {
-			return errors.MalformedKafkaClusterName("%s does not match %s", field, ValidKafkaClusterNameRegexp.String())
+		kafkaRequest, err := newKafkaRequest(ctx, kafkaService, kafkaConfig, providerConfig, kafkaRequestPayload)
+		if err != nil {
+			return err
 		}
-		return nil
+		return kafkaService.ValidateCreateRequest(kafkaRequest)
+	}
+}
+
+// newKafkaRequest builds the kafka request to be registered from the payload, the owner of the request and the
+// instance type, size, cloud provider and region defaulted from the payload
+func newKafkaRequest(ctx context.Context, kafkaService services.KafkaService, kafkaConfig *config.KafkaConfig, providerConfig *config.ProviderConfig, kafkaRequestPayload *public.KafkaRequestPayload) (*dbapi.KafkaRequest, *errors.ServiceError) {
+	kafkaRequest := presenters.ConvertKafkaRequest(*kafkaRequestPayload)
+
+	claims, err := getClaims(ctx)
+	if err != nil {
+		return nil, err
 	}
+	kafkaRequest.Owner, _ = claims.GetUsername()
+	kafkaRequest.OrganisationId, _ = claims.GetOrgId()
+	kafkaRequest.OwnerAccountId, _ = claims.GetAccountId()
+
+	kafkaRequest.InstanceType, kafkaRequest.SizeId, err = getInstanceTypeAndSize(ctx, kafkaService, kafkaConfig, kafkaRequestPayload)
+	if err != nil {
+		return nil, err
+	}
+
+	kafkaRequest.CloudProvider, kafkaRequest.Region, err = getCloudProviderAndRegion(ctx, kafkaService, kafkaRequestPayload, providerConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return kafkaRequest, nil
 }
 
 // ValidateKafkaClusterNameIsUnique returns a validator that validates that the kafka cluster name is unique
@@ -201,14 +213,6 @@ func getCloudProviderAndRegion(
 	return providerName, region.Name, nil
 }
 
-// ValidateCloudProvider returns a validator that validates provided provider and region
-func ValidateCloudProvider(ctx context.Context, kafkaService services.KafkaService, kafkaRequest *public.KafkaRequestPayload, providerConfig *config.ProviderConfig, action string) handlers.Validate {
-	return func() *errors.ServiceError {
-		_, _, err := getCloudProviderAndRegion(ctx, kafkaService, kafkaRequest, providerConfig)
-		return err
-	}
-}
-
 func getInstanceTypeAndSize(ctx context.Context, kafkaService services.KafkaService, kafkaConfig *config.KafkaConfig, kafkaRequestPayload *public.KafkaRequestPayload) (string, string, *errors.ServiceError) {
 	claims, err := getClaims(ctx)
 	if err != nil {
@@ -246,14 +250,6 @@ func getInstanceTypeAndSize(ctx context.Context, kafkaService services.KafkaServ
 	}
 }
 
-// ValidateKafkaPlan - validate the requested Kafka Plan
-func ValidateKafkaPlan(ctx context.Context, kafkaService services.KafkaService, kafkaConfig *config.KafkaConfig, kafkaRequestPayload *public.KafkaRequestPayload) handlers.Validate { // Validate plan
-	return func() *errors.ServiceError {
-		_, _, err := getInstanceTypeAndSize(ctx, kafkaService, kafkaConfig, kafkaRequestPayload)
-		return err
-	}
-}
-
 func ValidateKafkaUpdateFields(kafkaUpdateRequest *private.KafkaUpdateRequest) handlers.Validate {
 	return func() *errors.ServiceError {
 		if !(stringSet(&kafkaUpdateRequest.StrimziVersion) ||
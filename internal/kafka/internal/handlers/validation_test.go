@@ -94,61 +94,7 @@ func Test_Validation_validateKafkaClusterNameIsUnique(t *testing.T) {
 	}
 }
 
-func Test_Validations_validateKafkaClusterNames(t *testing.T) {
-	tests := []struct {
-		description string
-		name        string
-		expectError bool
-	}{
-		{
-			description: "valid kafka cluster name",
-			name:        "test-kafka1",
-			expectError: false,
-		},
-		{
-			description: "valid kafka cluster name with multiple '-'",
-			name:        "test-my-cluster",
-			expectError: false,
-		},
-		{
-			description: "invalid kafka cluster name begins with number",
-			name:        "1test-cluster",
-			expectError: true,
-		},
-		{
-			description: "invalid kafka cluster name with invalid characters",
-			name:        "test-c%*_2",
-			expectError: true,
-		},
-		{
-			description: "invalid kafka cluster name with upper-case letters",
-			name:        "Test-cluster",
-			expectError: true,
-		},
-		{
-			description: "invalid kafka cluster name with spaces",
-			name:        "test cluster",
-			expectError: true,
-		},
-	}
-
-	for _, testcase := range tests {
-		tt := testcase
-		t.Run(tt.name, func(t *testing.T) {
-			t.Parallel()
-			g := gomega.NewWithT(t)
-			validateFn := ValidKafkaClusterName(&tt.name, "name")
-			err := validateFn()
-			if tt.expectError {
-				g.Expect(err).Should(gomega.HaveOccurred())
-			} else {
-				g.Expect(err).ShouldNot(gomega.HaveOccurred())
-			}
-		})
-	}
-}
-
-func Test_Validation_validateCloudProvider(t *testing.T) {
+func Test_Validation_getCloudProviderAndRegion(t *testing.T) {
 	limit := int(5)
 
 	developerMap := config.InstanceTypeMap{
@@ -399,8 +345,7 @@ func Test_Validation_validateCloudProvider(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 			g := gomega.NewWithT(t)
-			validateFn := ValidateCloudProvider(context.Background(), tt.arg.kafkaService, &tt.arg.kafkaRequest, tt.arg.ProviderConfig, "creating-kafka")
-			err := validateFn()
+			_, _, err := getCloudProviderAndRegion(context.Background(), tt.arg.kafkaService, &tt.arg.kafkaRequest, tt.arg.ProviderConfig)
 			if !tt.want.wantErr && err != nil {
 				t.Errorf("validatedCloudProvider() expected not to throw error but threw %v", err)
 			} else if tt.want.wantErr {
@@ -718,7 +663,77 @@ func TestValidateBillingCloudAccountIdAndMarketplace(t *testing.T) {
 	}
 }
 
-func TestValidateKafkaPlan(t *testing.T) {
+func TestValidateKafkaCreateRequest(t *testing.T) {
+	type args struct {
+		ctx                 context.Context
+		kafkaRequestPayload *public.KafkaRequestPayload
+		validateErr         *errors.ServiceError
+	}
+
+	tests := []struct {
+		name string
+		args args
+		want *errors.ServiceError
+	}{
+		{
+			name: "should validate the kafka request built from the payload",
+			args: args{
+				ctx:                 ctx,
+				kafkaRequestPayload: &public.KafkaRequestPayload{Name: "name", CloudProvider: "aws", Region: "us-east-1"},
+			},
+			want: nil,
+		},
+		{
+			name: "should return the error of the kafka service validation",
+			args: args{
+				ctx:                 ctx,
+				kafkaRequestPayload: &public.KafkaRequestPayload{Name: "-name", CloudProvider: "aws", Region: "us-east-1"},
+				validateErr:         errors.MalformedKafkaClusterName("name '-name' does not match the name format"),
+			},
+			want: errors.MalformedKafkaClusterName("name '-name' does not match the name format"),
+		},
+		{
+			name: "should return an error when the kafka request cannot be built from the payload",
+			args: args{
+				ctx:                 ctx,
+				kafkaRequestPayload: &public.KafkaRequestPayload{Name: "name", CloudProvider: "gcp"},
+			},
+			want: errors.ProviderNotSupported("provider gcp is not supported, supported providers are: %s", supportedProviders.ProvidersConfig.SupportedProviders),
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			g := gomega.NewWithT(t)
+			var validated *dbapi.KafkaRequest
+			kafkaService := &services.KafkaServiceMock{
+				AssignInstanceTypeFunc: func(owner, organisationID string) (types.KafkaInstanceType, *errors.ServiceError) {
+					return types.STANDARD, nil
+				},
+				ValidateCreateRequestFunc: func(kafkaRequest *dbapi.KafkaRequest) *errors.ServiceError {
+					validated = kafkaRequest
+					return tt.args.validateErr
+				},
+			}
+			validateFn := ValidateKafkaCreateRequest(tt.args.ctx, kafkaService, &fullKafkaConfig, &supportedProviders, tt.args.kafkaRequestPayload)
+			g.Expect(validateFn()).To(gomega.Equal(tt.want))
+			if len(kafkaService.ValidateCreateRequestCalls()) == 0 {
+				return
+			}
+			// the kafka service validates the request with the defaults applied
+			g.Expect(validated.Name).To(gomega.Equal(tt.args.kafkaRequestPayload.Name))
+			g.Expect(validated.Owner).To(gomega.Equal("test-user"))
+			g.Expect(validated.InstanceType).To(gomega.Equal(types.STANDARD.String()))
+			g.Expect(validated.SizeId).To(gomega.Equal("x1"))
+			g.Expect(validated.CloudProvider).To(gomega.Equal("aws"))
+			g.Expect(validated.Region).To(gomega.Equal("us-east-1"))
+		})
+	}
+}
+
+func Test_getInstanceTypeAndSize(t *testing.T) {
 	type args struct {
 		ctx                 context.Context
 		kafkaService        services.KafkaService
@@ -931,8 +946,7 @@ func TestValidateKafkaPlan(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 			g := gomega.NewWithT(t)
-			validateFn := ValidateKafkaPlan(tt.args.ctx, tt.args.kafkaService, tt.args.kafkaConfig, tt.args.kafkaRequestPayload)
-			err := validateFn()
+			_, _, err := getInstanceTypeAndSize(tt.args.ctx, tt.args.kafkaService, tt.args.kafkaConfig, tt.args.kafkaRequestPayload)
 			g.Expect(err).To(gomega.Equal(tt.want))
 		})
 	}
@@ -1117,67 +1131,6 @@ func TestValidateKafkaStorageSize(t *testing.T) {
 	}
 }
 
-func Test_Validation_validateBillingModel(t *testing.T) {
-	type args struct {
-		kafkaRequest public.KafkaRequestPayload
-	}
-
-	tests := []struct {
-		name    string
-		arg     args
-		wantErr bool
-	}{
-		{
-			name: "do not throw an error when billing model is not provided",
-			arg: args{
-
-				kafkaRequest: public.KafkaRequestPayload{
-					BillingModel: nil,
-				},
-			},
-			wantErr: false,
-		},
-		{
-			name: "do not throw an error when marketplace model is provided",
-			arg: args{
-				kafkaRequest: public.KafkaRequestPayload{
-					BillingModel: &[]string{"marketplace"}[0],
-				},
-			},
-			wantErr: false,
-		},
-		{
-			name: "do not throw an error when standard model is provided",
-			arg: args{
-				kafkaRequest: public.KafkaRequestPayload{
-					BillingModel: &[]string{"standard"}[0],
-				},
-			},
-			wantErr: false,
-		},
-		{
-			name: "throw an error when unknown model is provided",
-			arg: args{
-				kafkaRequest: public.KafkaRequestPayload{
-					BillingModel: &[]string{"xyz"}[0],
-				},
-			},
-			wantErr: true,
-		},
-	}
-
-	for _, testcase := range tests {
-		tt := testcase
-		t.Run(tt.name, func(t *testing.T) {
-			g := gomega.NewWithT(t)
-
-			validateFn := ValidateBillingModel(&tt.arg.kafkaRequest)
-			err := validateFn()
-			g.Expect(err != nil).To(gomega.Equal(tt.wantErr))
-		})
-	}
-}
-
 func Test_validateVersionsCompatibility(t *testing.T) {
 	type args struct {
 		h              *adminKafkaHandler
@@ -0,0 +1,24 @@
+package migrations
+
+import (
+	"time"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+func addKafkaStatusUpdatedAt() *gormigrate.Migration {
+	type KafkaRequest struct {
+		StatusUpdatedAt *time.Time `json:"status_updated_at"`
+	}
+
+	return &gormigrate.Migration{
+		ID: "20220905100000",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&KafkaRequest{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropColumn(&KafkaRequest{}, "status_updated_at")
+		},
+	}
+}
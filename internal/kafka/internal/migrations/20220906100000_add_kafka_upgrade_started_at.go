@@ -0,0 +1,24 @@
+package migrations
+
+import (
+	"time"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+func addKafkaUpgradeStartedAt() *gormigrate.Migration {
+	type KafkaRequest struct {
+		UpgradeStartedAt *time.Time `json:"upgrade_started_at"`
+	}
+
+	return &gormigrate.Migration{
+		ID: "20220906100000",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&KafkaRequest{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropColumn(&KafkaRequest{}, "upgrade_started_at")
+		},
+	}
+}
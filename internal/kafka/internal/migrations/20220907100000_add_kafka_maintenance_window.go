@@ -0,0 +1,33 @@
+package migrations
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+func addKafkaMaintenanceWindow() *gormigrate.Migration {
+	type KafkaRequest struct {
+		MaintenanceWindowDay   string `json:"maintenance_window_day"`
+		MaintenanceWindowStart string `json:"maintenance_window_start"`
+		MaintenanceWindowEnd   string `json:"maintenance_window_end"`
+	}
+
+	return &gormigrate.Migration{
+		ID: "20220907100000",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&KafkaRequest{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			if err := tx.Migrator().DropColumn(&KafkaRequest{}, "maintenance_window_day"); err != nil {
+				return err
+			}
+			if err := tx.Migrator().DropColumn(&KafkaRequest{}, "maintenance_window_start"); err != nil {
+				return err
+			}
+			if err := tx.Migrator().DropColumn(&KafkaRequest{}, "maintenance_window_end"); err != nil {
+				return err
+			}
+			return nil
+		},
+	}
+}
@@ -0,0 +1,22 @@
+package migrations
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+func addKafkaDeprovisionReason() *gormigrate.Migration {
+	type KafkaRequest struct {
+		DeprovisionReason string `json:"deprovision_reason"`
+	}
+
+	return &gormigrate.Migration{
+		ID: "20221014100000",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&KafkaRequest{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropColumn(&KafkaRequest{}, "deprovision_reason")
+		},
+	}
+}
@@ -0,0 +1,22 @@
+package migrations
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+func addKafkaAnnotations() *gormigrate.Migration {
+	type KafkaRequest struct {
+		Annotations string `json:"annotations" gorm:"type:jsonb"`
+	}
+
+	return &gormigrate.Migration{
+		ID: "20221015100000",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&KafkaRequest{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropColumn(&KafkaRequest{}, "annotations")
+		},
+	}
+}
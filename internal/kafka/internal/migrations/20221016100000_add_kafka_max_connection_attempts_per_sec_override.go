@@ -0,0 +1,22 @@
+package migrations
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+func addKafkaMaxConnectionAttemptsPerSecOverride() *gormigrate.Migration {
+	type KafkaRequest struct {
+		MaxConnectionAttemptsPerSecOverride *int `json:"max_connection_attempts_per_sec_override"`
+	}
+
+	return &gormigrate.Migration{
+		ID: "20221016100000",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&KafkaRequest{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropColumn(&KafkaRequest{}, "max_connection_attempts_per_sec_override")
+		},
+	}
+}
@@ -0,0 +1,18 @@
+package migrations
+
+import (
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/go-gormigrate/gormigrate/v2"
+)
+
+// addKafkaNamespaceUniqueIndex prevents a namespace from being assigned to several kafkas that are not deleted, e.g.
+// when the namespaces are allocated from a pool
+func addKafkaNamespaceUniqueIndex() *gormigrate.Migration {
+	return db.CreateMigrationFromActions("20221017100000",
+		db.ExecAction(`
+			CREATE UNIQUE INDEX idx_kafka_requests_namespace ON kafka_requests (namespace) WHERE deleted_at IS NULL AND namespace <> ''
+		`, `
+			DROP INDEX idx_kafka_requests_namespace
+		`),
+	)
+}
@@ -0,0 +1,22 @@
+package migrations
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+func addKafkaCapacityConsumedOverride() *gormigrate.Migration {
+	type KafkaRequest struct {
+		CapacityConsumedOverride *int `json:"capacity_consumed_override"`
+	}
+
+	return &gormigrate.Migration{
+		ID: "20221018100000",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&KafkaRequest{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropColumn(&KafkaRequest{}, "capacity_consumed_override")
+		},
+	}
+}
@@ -0,0 +1,50 @@
+package migrations
+
+import (
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+func addCapacityRecomputation() *gormigrate.Migration {
+	type StreamingUnitCount struct {
+		ClusterID     string    `json:"cluster_id" gorm:"primaryKey"`
+		InstanceType  string    `json:"instance_type" gorm:"primaryKey"`
+		ClusterRecord string    `json:"cluster_record"`
+		CloudProvider string    `json:"cloud_provider"`
+		Region        string    `json:"region"`
+		Status        string    `json:"status"`
+		Count         int32     `json:"count"`
+		MaxUnits      int32     `json:"max_units"`
+		ComputedAt    time.Time `json:"computed_at"`
+	}
+
+	type RegionCapacityUsage struct {
+		CloudProvider    string    `json:"cloud_provider" gorm:"primaryKey"`
+		Region           string    `json:"region" gorm:"primaryKey"`
+		InstanceType     string    `json:"instance_type" gorm:"primaryKey"`
+		CapacityConsumed int       `json:"capacity_consumed"`
+		ComputedAt       time.Time `json:"computed_at"`
+	}
+
+	capacityRecomputationWorkerType := "capacity_recomputation"
+
+	return &gormigrate.Migration{
+		ID: "20221019100000",
+		Migrate: func(tx *gorm.DB) error {
+			if err := tx.AutoMigrate(&StreamingUnitCount{}, &RegionCapacityUsage{}); err != nil {
+				return err
+			}
+			return tx.Create(&api.LeaderLease{Expires: &db.KafkaAdditionalLeasesExpireTime, LeaseType: capacityRecomputationWorkerType, Leader: api.NewID()}).Error
+		},
+		Rollback: func(tx *gorm.DB) error {
+			if err := tx.Unscoped().Where("lease_type = ?", capacityRecomputationWorkerType).Delete(&api.LeaderLease{}).Error; err != nil {
+				return err
+			}
+			return tx.Migrator().DropTable(&StreamingUnitCount{}, &RegionCapacityUsage{})
+		},
+	}
+}
@@ -0,0 +1,22 @@
+package migrations
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+func addKafkaStatusBeforeDeprovision() *gormigrate.Migration {
+	type KafkaRequest struct {
+		StatusBeforeDeprovision string `json:"status_before_deprovision"`
+	}
+
+	return &gormigrate.Migration{
+		ID: "20221020100000",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&KafkaRequest{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropColumn(&KafkaRequest{}, "status_before_deprovision")
+		},
+	}
+}
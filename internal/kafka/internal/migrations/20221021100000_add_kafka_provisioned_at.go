@@ -0,0 +1,24 @@
+package migrations
+
+import (
+	"time"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+func addKafkaProvisionedAt() *gormigrate.Migration {
+	type KafkaRequest struct {
+		ProvisionedAt *time.Time `json:"provisioned_at"`
+	}
+
+	return &gormigrate.Migration{
+		ID: "20221021100000",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&KafkaRequest{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropColumn(&KafkaRequest{}, "provisioned_at")
+		},
+	}
+}
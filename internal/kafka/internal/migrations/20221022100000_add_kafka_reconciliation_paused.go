@@ -0,0 +1,22 @@
+package migrations
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+func addKafkaReconciliationPaused() *gormigrate.Migration {
+	type KafkaRequest struct {
+		ReconciliationPaused bool `json:"reconciliation_paused" gorm:"default:false"`
+	}
+
+	return &gormigrate.Migration{
+		ID: "20221022100000",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&KafkaRequest{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropColumn(&KafkaRequest{}, "reconciliation_paused")
+		},
+	}
+}
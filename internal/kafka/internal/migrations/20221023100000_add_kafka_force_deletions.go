@@ -0,0 +1,33 @@
+package migrations
+
+import (
+	"time"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+func addKafkaForceDeletions() *gormigrate.Migration {
+	type KafkaForceDeletion struct {
+		ID             string    `json:"id" gorm:"primaryKey"`
+		KafkaID        string    `json:"kafka_id" gorm:"index"`
+		Name           string    `json:"name"`
+		Owner          string    `json:"owner"`
+		OrganisationId string    `json:"organisation_id"`
+		ClusterID      string    `json:"cluster_id"`
+		SubscriptionId string    `json:"subscription_id"`
+		Status         string    `json:"status"`
+		DeletedBy      string    `json:"deleted_by"`
+		CreatedAt      time.Time `json:"created_at"`
+	}
+
+	return &gormigrate.Migration{
+		ID: "20221023100000",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&KafkaForceDeletion{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&KafkaForceDeletion{})
+		},
+	}
+}
@@ -85,6 +85,19 @@ var migrations = []*gormigrate.Migration{
 	addCleanupClusterExternalResourcesWorkerToLeaderLeases(),
 	addDeprovisioningClusterWorkerToLeaderLeases(),
 	addDynamicScaleDownWorkerToLeaderLeases(),
+	addKafkaStatusUpdatedAt(),
+	addKafkaUpgradeStartedAt(),
+	addKafkaMaintenanceWindow(),
+	addKafkaDeprovisionReason(),
+	addKafkaAnnotations(),
+	addKafkaMaxConnectionAttemptsPerSecOverride(),
+	addKafkaNamespaceUniqueIndex(),
+	addKafkaCapacityConsumedOverride(),
+	addCapacityRecomputation(),
+	addKafkaStatusBeforeDeprovision(),
+	addKafkaProvisionedAt(),
+	addKafkaReconciliationPaused(),
+	addKafkaForceDeletions(),
 }
 
 func New(dbConfig *db.DatabaseConfig) (*db.Migration, func(), error) {
@@ -35,6 +35,8 @@ func ConvertKafkaRequest(kafkaRequestPayload public.KafkaRequestPayload, dbKafka
 		kafka.ReauthenticationEnabled = *kafkaRequestPayload.ReauthenticationEnabled
 	} else {
 		kafka.ReauthenticationEnabled = true // true by default
+		// the default of the instance type is applied once the instance type is known
+		kafka.ReauthenticationUnspecified = true
 	}
 
 	return kafka
@@ -58,8 +60,8 @@ func PresentKafkaRequest(kafkaRequest *dbapi.KafkaRequest, kafkaConfig *config.K
 			maxPartitions = instanceSize.MaxPartitions
 			maxDataRetentionPeriod = instanceSize.MaxDataRetentionPeriod
 			maxConnectionAttemptsPerSec = instanceSize.MaxConnectionAttemptsPerSec
-			if instanceSize.LifespanSeconds != nil {
-				expiresAt = kafkaRequest.GetExpirationTime(*instanceSize.LifespanSeconds)
+			if lifespanSeconds := kafkaConfig.KafkaLifespan.GetLifespanSeconds(kafkaRequest.OrganisationId, instanceSize.LifespanSeconds); lifespanSeconds != nil {
+				expiresAt = kafkaRequest.GetExpirationTime(*lifespanSeconds)
 			}
 		}
 	}
@@ -41,6 +41,7 @@ func TestConvertKafkaRequest(t *testing.T) {
 				mock.With(mock.CLOUD_PROVIDER, mock.DefaultKafkaRequestProvider),
 				mock.With(mock.NAME, mock.DefaultKafkaRequestName),
 				mock.WithReauthenticationEnabled(reauthEnabled),
+				mock.WithReauthenticationUnspecified(true),
 			),
 		},
 		{
@@ -75,6 +76,7 @@ func TestConvertKafkaRequest(t *testing.T) {
 			want: mock.BuildKafkaRequest(
 				mock.WithPredefinedTestValues(),
 				mock.WithReauthenticationEnabled(reauthEnabled),
+				mock.WithReauthenticationUnspecified(true),
 			),
 		},
 	}
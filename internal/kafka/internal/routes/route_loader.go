@@ -261,6 +261,9 @@ func (s *options) buildApiBaseRouter(mainRouter *mux.Router, basePath string) er
 	adminRouter.HandleFunc("/kafkas/{id}", adminKafkaHandler.Delete).
 		Name(logger.NewLogEvent("admin-delete-kafka", "[admin] delete kafka by id").ToString()).
 		Methods(http.MethodDelete)
+	adminRouter.HandleFunc("/kafkas/{id}/force", adminKafkaHandler.ForceDelete).
+		Name(logger.NewLogEvent("admin-force-delete-kafka", "[admin] force delete kafka by id").ToString()).
+		Methods(http.MethodDelete)
 	adminRouter.HandleFunc("/kafkas/{id}", adminKafkaHandler.Update).
 		Name(logger.NewLogEvent("admin-update-kafka", "[admin] update kafka by id").ToString()).
 		Methods(http.MethodPatch)
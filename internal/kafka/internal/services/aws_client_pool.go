@@ -0,0 +1,38 @@
+package services
+
+import (
+	"sync"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/client/aws"
+)
+
+// awsClientPool reuses the AWS clients created by the kafka service, one per region. All the pooled clients are
+// discarded when the credentials change so that rotated credentials are used right away. The zero value is an empty pool.
+type awsClientPool struct {
+	mu          sync.Mutex
+	credentials aws.Config
+	clients     map[string]aws.AWSClient
+}
+
+// get returns the pooled client of the given region, the client is created with the given factory if there is none
+func (p *awsClientPool) get(factory aws.ClientFactory, credentials aws.Config, region string) (aws.AWSClient, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.clients == nil || p.credentials != credentials {
+		p.clients = map[string]aws.AWSClient{}
+		p.credentials = credentials
+	}
+
+	if client, ok := p.clients[region]; ok {
+		return client, nil
+	}
+
+	client, err := factory.NewClient(credentials, region)
+	if err != nil {
+		return nil, err
+	}
+	p.clients[region] = client
+
+	return client, nil
+}
@@ -0,0 +1,66 @@
+package services
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/config"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/client/aws"
+	"github.com/onsi/gomega"
+)
+
+type countingAWSClientFactory struct {
+	created map[string]int
+	err     error
+}
+
+func (f *countingAWSClientFactory) NewClient(credentials aws.Config, region string) (aws.AWSClient, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	f.created[region]++
+	return &aws.AWSClientMock{}, nil
+}
+
+func Test_kafkaService_getRoute53Client(t *testing.T) {
+	g := gomega.NewWithT(t)
+	factory := &countingAWSClientFactory{created: map[string]int{}}
+	awsConfig := &config.AWSConfig{
+		Route53AccessKey:       "access-key",
+		Route53SecretAccessKey: "secret-key",
+	}
+	k := &kafkaService{
+		awsConfig:        awsConfig,
+		awsClientFactory: factory,
+	}
+
+	// repeated calls to the same region reuse the client
+	first, err := k.getRoute53Client(aws.DefaultAWSRoute53Region)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	second, err := k.getRoute53Client(aws.DefaultAWSRoute53Region)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(second).To(gomega.BeIdenticalTo(first))
+	g.Expect(factory.created).To(gomega.Equal(map[string]int{aws.DefaultAWSRoute53Region: 1}))
+
+	// each region has its own client
+	other, err := k.getRoute53Client("eu-west-1")
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(other).ToNot(gomega.BeIdenticalTo(first))
+	g.Expect(factory.created).To(gomega.Equal(map[string]int{aws.DefaultAWSRoute53Region: 1, "eu-west-1": 1}))
+
+	// rotated credentials discard the pooled clients
+	awsConfig.Route53SecretAccessKey = "rotated-secret-key"
+	rotated, err := k.getRoute53Client(aws.DefaultAWSRoute53Region)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(rotated).ToNot(gomega.BeIdenticalTo(first))
+	g.Expect(factory.created).To(gomega.Equal(map[string]int{aws.DefaultAWSRoute53Region: 2, "eu-west-1": 1}))
+
+	// clients that fail to be created are not pooled
+	factory.err = fmt.Errorf("failed to create the client")
+	_, err = k.getRoute53Client("us-west-2")
+	g.Expect(err).To(gomega.HaveOccurred())
+	factory.err = nil
+	_, err = k.getRoute53Client("us-west-2")
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(factory.created["us-west-2"]).To(gomega.Equal(1))
+}
@@ -0,0 +1,226 @@
+package services
+
+import (
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	pkgerrors "github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+// The capacity recomputation worker periodically materializes the streaming unit counts of the clusters and the
+// capacity usage of the regions, so that the capacity metrics and the region capacity checks read them instead of
+// aggregating all the kafkas on each call. The materialized capacity is only read while it is not older than the
+// configured max age, the capacity is computed on each call otherwise.
+
+// isMaterializedCapacityFresh returns true if the capacity computed at the given time can be read
+func isMaterializedCapacityFresh(computedAt time.Time, maxAge time.Duration) bool {
+	return maxAge > 0 && time.Since(computedAt) <= maxAge
+}
+
+type regionInstanceType struct {
+	cloudProvider string
+	region        string
+	instanceType  string
+}
+
+type kafkaCapacityCount struct {
+	CloudProvider            string
+	Region                   string
+	InstanceType             string
+	SizeId                   string
+	CapacityConsumedOverride *int
+	Count                    int
+}
+
+func (c *clusterService) RecomputeStreamingUnitCounts() error {
+	// the counts are stamped with the time before the aggregation so that the kafkas created while it runs are
+	// counted again by the readers rather than missed
+	computedAt := time.Now()
+	counts, err := c.FindStreamingUnitCountByClusterAndInstanceType()
+	if err != nil {
+		return err
+	}
+
+	rows := make([]dbapi.StreamingUnitCount, 0, len(counts))
+	for _, count := range counts {
+		rows = append(rows, dbapi.StreamingUnitCount{
+			ClusterID:     count.ClusterId,
+			InstanceType:  count.InstanceType,
+			ClusterRecord: count.ID,
+			CloudProvider: count.CloudProvider,
+			Region:        count.Region,
+			Status:        count.Status,
+			Count:         count.Count,
+			MaxUnits:      count.MaxUnits,
+			ComputedAt:    computedAt,
+		})
+	}
+
+	if err := c.connectionFactory.New().Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("1 = 1").Delete(&dbapi.StreamingUnitCount{}).Error; err != nil {
+			return err
+		}
+		if len(rows) == 0 {
+			return nil
+		}
+		return tx.Create(&rows).Error
+	}); err != nil {
+		return pkgerrors.Wrap(err, "failed to materialize the streaming unit counts")
+	}
+
+	c.streamingUnitCountCache.Invalidate()
+	return nil
+}
+
+// findMaterializedStreamingUnitCount returns the streaming unit counts last materialized by the capacity recomputation
+// worker, or computes them if they are too old or have not been materialized
+func (c *clusterService) findMaterializedStreamingUnitCount() (KafkaStreamingUnitCountPerClusterList, error) {
+	maxAge := c.kafkaConfig.CapacityRecomputationMaxAge
+	if maxAge <= 0 {
+		return c.FindStreamingUnitCountByClusterAndInstanceType()
+	}
+
+	var rows []dbapi.StreamingUnitCount
+	if err := c.connectionFactory.New().Order("cluster_id, instance_type").Find(&rows).Error; err != nil {
+		return nil, pkgerrors.Wrap(err, "failed to read the materialized streaming unit counts")
+	}
+
+	counts := KafkaStreamingUnitCountPerClusterList{}
+	for _, row := range rows {
+		if !isMaterializedCapacityFresh(row.ComputedAt, maxAge) {
+			return c.FindStreamingUnitCountByClusterAndInstanceType()
+		}
+		counts = append(counts, KafkaStreamingUnitCountPerCluster{
+			Region:        row.Region,
+			InstanceType:  row.InstanceType,
+			ID:            row.ClusterRecord,
+			ClusterId:     row.ClusterID,
+			Count:         row.Count,
+			CloudProvider: row.CloudProvider,
+			MaxUnits:      row.MaxUnits,
+			Status:        row.Status,
+		})
+	}
+	if len(counts) == 0 {
+		return c.FindStreamingUnitCountByClusterAndInstanceType()
+	}
+
+	return counts, nil
+}
+
+// computeRegionCapacityUsage returns the capacity consumed in each supported region by each supported instance type,
+// with a single aggregation of the kafkas
+func (k *kafkaService) computeRegionCapacityUsage() (map[regionInstanceType]int, *errors.ServiceError) {
+	// pre-populate the usages of all the supported regions and instance types with zero values so that a region
+	// without kafkas is told apart from a region whose usage has not been materialized
+	usages := map[regionInstanceType]int{}
+	for _, provider := range k.providerConfig.ProvidersConfig.SupportedProviders {
+		for _, region := range provider.Regions {
+			for instanceType := range region.SupportedInstanceTypes {
+				usages[regionInstanceType{cloudProvider: provider.Name, region: region.Name, instanceType: instanceType}] = 0
+			}
+		}
+	}
+
+	var counts []*kafkaCapacityCount
+	if err := k.connectionFactory.New().
+		Model(&dbapi.KafkaRequest{}).
+		Select("cloud_provider, region, instance_type, size_id, capacity_consumed_override, count(1) as Count").
+		Group("cloud_provider, region, instance_type, size_id, capacity_consumed_override").
+		Scan(&counts).Error; err != nil {
+		return nil, errors.NewWithCause(errors.ErrorGeneral, err, "failed to count the capacity consumed in each region")
+	}
+
+	for _, count := range counts {
+		instanceSize, err := k.kafkaConfig.GetKafkaInstanceSize(count.InstanceType, count.SizeId)
+		if err != nil {
+			return nil, errors.NewWithCause(errors.ErrorInstancePlanNotSupported, err, "failed to count the capacity consumed in region '%s'", count.Region)
+		}
+		key := regionInstanceType{cloudProvider: count.CloudProvider, region: count.Region, instanceType: count.InstanceType}
+		usages[key] += capacityConsumed(count.CapacityConsumedOverride, instanceSize) * count.Count
+	}
+
+	return usages, nil
+}
+
+func (k *kafkaService) RecomputeRegionCapacityUsage() *errors.ServiceError {
+	// the usage is stamped with the time before the aggregation so that the kafkas created while it runs are added
+	// to it by findMaterializedRegionCapacityConsumed rather than missed
+	computedAt := time.Now()
+	usages, svcErr := k.computeRegionCapacityUsage()
+	if svcErr != nil {
+		return svcErr
+	}
+
+	rows := make([]dbapi.RegionCapacityUsage, 0, len(usages))
+	for key, capacityConsumed := range usages {
+		rows = append(rows, dbapi.RegionCapacityUsage{
+			CloudProvider:    key.cloudProvider,
+			Region:           key.region,
+			InstanceType:     key.instanceType,
+			CapacityConsumed: capacityConsumed,
+			ComputedAt:       computedAt,
+		})
+	}
+
+	if err := k.connectionFactory.New().Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("1 = 1").Delete(&dbapi.RegionCapacityUsage{}).Error; err != nil {
+			return err
+		}
+		if len(rows) == 0 {
+			return nil
+		}
+		return tx.Create(&rows).Error
+	}); err != nil {
+		return errors.NewWithCause(errors.ErrorGeneral, err, "failed to materialize the capacity consumed in each region")
+	}
+
+	return nil
+}
+
+// findMaterializedRegionCapacityConsumed returns the capacity consumed by the kafkas of the instance type of the given
+// kafka request in its region, from the usage last materialized by the capacity recomputation worker. The capacity of
+// the kafkas created since the usage has been materialized is added to it. false is returned if the usage is too old
+// or has not been materialized.
+func (k *kafkaService) findMaterializedRegionCapacityConsumed(kafkaRequest *dbapi.KafkaRequest) (int64, bool, *errors.ServiceError) {
+	maxAge := k.kafkaConfig.CapacityRecomputationMaxAge
+	if maxAge <= 0 {
+		return 0, false, nil
+	}
+
+	var usages []dbapi.RegionCapacityUsage
+	if err := k.connectionFactory.New().
+		Where("cloud_provider = ?", kafkaRequest.CloudProvider).
+		Where("region = ?", kafkaRequest.Region).
+		Where("instance_type = ?", kafkaRequest.InstanceType).
+		Limit(1).
+		Find(&usages).Error; err != nil {
+		return 0, false, errors.NewWithCause(errors.ErrorGeneral, err, "failed to read the materialized capacity consumed in region '%s'", kafkaRequest.Region)
+	}
+	if len(usages) == 0 || !isMaterializedCapacityFresh(usages[0].ComputedAt, maxAge) {
+		return 0, false, nil
+	}
+
+	var kafkas []*dbapi.KafkaRequest
+	if err := k.connectionFactory.New().Model(&dbapi.KafkaRequest{}).
+		Where("region = ?", kafkaRequest.Region).
+		Where("cloud_provider = ?", kafkaRequest.CloudProvider).
+		Where("instance_type = ?", kafkaRequest.InstanceType).
+		Where("created_at > ?", usages[0].ComputedAt).
+		Scan(&kafkas).Error; err != nil {
+		return 0, false, errors.NewWithCause(errors.ErrorGeneral, err, "failed to list the kafkas created in region '%s' since its capacity has been materialized", kafkaRequest.Region)
+	}
+
+	count := int64(usages[0].CapacityConsumed)
+	for _, kafka := range kafkas {
+		kafkaInstanceSize, err := k.kafkaConfig.GetKafkaInstanceSize(kafka.InstanceType, kafka.SizeId)
+		if err != nil {
+			return 0, false, errors.NewWithCause(errors.ErrorInstancePlanNotSupported, err, "failed to count the capacity consumed in region '%s'", kafkaRequest.Region)
+		}
+		count += int64(getCapacityConsumed(kafka, kafkaInstanceSize))
+	}
+
+	return count, true, nil
+}
@@ -0,0 +1,270 @@
+package services
+
+import (
+	"database/sql/driver"
+	"testing"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/config"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/converters"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/kafkas/types"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/onsi/gomega"
+
+	mocket "github.com/selvatico/go-mocket"
+)
+
+func mockStreamingUnitCountQueries() {
+	mocket.Catcher.NewMock().
+		WithQuery(`SELECT cloud_provider, region, count(1) as Count, size_id, cluster_id, instance_type, capacity_consumed_override FROM "kafka_requests"`).
+		WithReply([]map[string]interface{}{
+			{"region": testKafkaRequestRegion, "instance_type": "standard", "cluster_id": testClusterID, "cloud_provider": testKafkaRequestProvider, "Count": 2, "SizeId": "x1"},
+		})
+	mocket.Catcher.NewMock().
+		WithQuery(`SELECT * FROM "clusters"`).
+		WithReply([]map[string]interface{}{
+			{
+				"id":                      "cluster-record-id",
+				"region":                  testKafkaRequestRegion,
+				"cloud_provider":          testKafkaRequestProvider,
+				"cluster_id":              testClusterID,
+				"status":                  api.ClusterReady.String(),
+				"supported_instance_type": api.StandardTypeSupport.String(),
+				"dynamic_capacity_info":   []byte(`{"standard":{"max_nodes":10,"max_units":10,"remaining_units":8}}`),
+			},
+		})
+}
+
+func Test_clusterService_RecomputeStreamingUnitCounts(t *testing.T) {
+	g := gomega.NewWithT(t)
+	var deleted bool
+	var inserted []driver.NamedValue
+	mocket.Catcher.Reset()
+	mockStreamingUnitCountQueries()
+	mocket.Catcher.NewMock().
+		WithQuery(`DELETE FROM "streaming_unit_counts" WHERE 1 = 1`).
+		WithCallback(func(_ string, _ []driver.NamedValue) {
+			deleted = true
+		})
+	mocket.Catcher.NewMock().
+		WithQuery(`INSERT INTO "streaming_unit_counts" ("cluster_id","instance_type","cluster_record","cloud_provider","region","status","count","max_units","computed_at")`).
+		WithCallback(func(_ string, args []driver.NamedValue) {
+			inserted = args
+		})
+	mocket.Catcher.NewMock().WithExecException().WithQueryException()
+
+	c := &clusterService{
+		connectionFactory: db.NewMockConnectionFactory(nil),
+		kafkaConfig:       &defaultKafkaConf,
+	}
+
+	g.Expect(c.RecomputeStreamingUnitCounts()).To(gomega.Succeed())
+	g.Expect(deleted).To(gomega.BeTrue())
+	g.Expect(inserted).To(gomega.HaveLen(9))
+	values := []interface{}{}
+	for _, arg := range inserted[:8] {
+		values = append(values, arg.Value)
+	}
+	g.Expect(values).To(gomega.Equal([]interface{}{
+		testClusterID, "standard", "cluster-record-id", testKafkaRequestProvider, testKafkaRequestRegion, api.ClusterReady.String(), int64(2), int64(10),
+	}))
+}
+
+func Test_clusterService_FindCachedStreamingUnitCountByClusterAndInstanceType_Materialized(t *testing.T) {
+	materialized := []map[string]interface{}{
+		{
+			"cluster_id":     testClusterID,
+			"instance_type":  "standard",
+			"cluster_record": "cluster-record-id",
+			"cloud_provider": testKafkaRequestProvider,
+			"region":         testKafkaRequestRegion,
+			"status":         api.ClusterReady.String(),
+			"count":          5,
+			"max_units":      10,
+		},
+	}
+
+	tests := []struct {
+		name         string
+		maxAge       time.Duration
+		computedAt   time.Time
+		forceRefresh bool
+		want         int32
+	}{
+		{
+			name:       "should read the materialized counts when they are fresh",
+			maxAge:     time.Minute,
+			computedAt: time.Now(),
+			want:       5,
+		},
+		{
+			name:       "should compute the counts when the materialized counts are too old",
+			maxAge:     time.Minute,
+			computedAt: time.Now().Add(-2 * time.Minute),
+			want:       2,
+		},
+		{
+			name:       "should compute the counts when the capacity recomputation is disabled",
+			computedAt: time.Now(),
+			want:       2,
+		},
+		{
+			name:         "should compute the counts when a refresh is forced",
+			maxAge:       time.Minute,
+			computedAt:   time.Now(),
+			forceRefresh: true,
+			want:         2,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			materialized[0]["computed_at"] = tt.computedAt
+			mocket.Catcher.Reset().NewMock().
+				WithQuery(`SELECT * FROM "streaming_unit_counts" ORDER BY cluster_id, instance_type`).
+				WithReply(materialized)
+			mockStreamingUnitCountQueries()
+			mocket.Catcher.NewMock().WithExecException().WithQueryException()
+
+			c := &clusterService{
+				connectionFactory: db.NewMockConnectionFactory(nil),
+				kafkaConfig: &config.KafkaConfig{
+					SupportedInstanceTypes:      defaultKafkaConf.SupportedInstanceTypes,
+					CapacityRecomputationMaxAge: tt.maxAge,
+				},
+			}
+
+			counts, err := c.FindCachedStreamingUnitCountByClusterAndInstanceType(tt.forceRefresh)
+			g.Expect(err).ToNot(gomega.HaveOccurred())
+			g.Expect(counts.GetStreamingUnitCountForClusterAndInstanceType(testClusterID, "standard")).To(gomega.Equal(int(tt.want)))
+		})
+	}
+}
+
+func Test_kafkaService_RecomputeRegionCapacityUsage(t *testing.T) {
+	g := gomega.NewWithT(t)
+	override := 1
+	var inserted []driver.NamedValue
+	mocket.Catcher.Reset().NewMock().
+		WithQuery(`SELECT cloud_provider, region, instance_type, size_id, capacity_consumed_override, count(1) as Count FROM "kafka_requests"`).
+		WithReply([]map[string]interface{}{
+			{"cloud_provider": testKafkaRequestProvider, "region": testKafkaRequestRegion, "instance_type": "developer", "size_id": "x1", "capacity_consumed_override": nil, "count": 3},
+			// grandfathered kafkas consuming less capacity than their size
+			{"cloud_provider": testKafkaRequestProvider, "region": testKafkaRequestRegion, "instance_type": "developer", "size_id": "x1", "capacity_consumed_override": override, "count": 2},
+		})
+	mocket.Catcher.NewMock().WithQuery(`DELETE FROM "region_capacity_usages" WHERE 1 = 1`)
+	mocket.Catcher.NewMock().
+		WithQuery(`INSERT INTO "region_capacity_usages" ("cloud_provider","region","instance_type","capacity_consumed","computed_at")`).
+		WithCallback(func(_ string, args []driver.NamedValue) {
+			inserted = args
+		})
+	mocket.Catcher.NewMock().WithExecException().WithQueryException()
+
+	k := &kafkaService{
+		connectionFactory: db.NewMockConnectionFactory(nil),
+		kafkaConfig:       &defaultKafkaConf,
+		providerConfig:    buildProviderConfiguration(testKafkaRequestRegion, MaxClusterCapacity, MaxClusterCapacity, false),
+	}
+
+	g.Expect(k.RecomputeRegionCapacityUsage()).To(gomega.BeNil())
+
+	// the supported instance types of the region without kafkas are materialized with a zero usage
+	usages := map[string]interface{}{}
+	g.Expect(inserted).To(gomega.HaveLen(10))
+	for i := 0; i < len(inserted); i += 5 {
+		g.Expect(inserted[i].Value).To(gomega.Equal(testKafkaRequestProvider))
+		g.Expect(inserted[i+1].Value).To(gomega.Equal(testKafkaRequestRegion))
+		usages[inserted[i+2].Value.(string)] = inserted[i+3].Value
+	}
+	// the developer x1 size consumes 2 units of capacity
+	g.Expect(usages).To(gomega.Equal(map[string]interface{}{"developer": int64(3*2 + 2*1), "standard": int64(0)}))
+}
+
+func Test_kafkaService_capacityAvailableForRegionAndInstanceType_Materialized(t *testing.T) {
+	tests := []struct {
+		name                 string
+		computedAt           time.Time
+		capacityConsumed     int
+		createdSinceComputed bool
+		want                 bool
+	}{
+		{
+			name:             "should accept the kafka when the materialized usage leaves capacity",
+			computedAt:       time.Now(),
+			capacityConsumed: 1,
+			want:             true,
+		},
+		{
+			name:             "should reject the kafka when the materialized usage leaves no capacity",
+			computedAt:       time.Now(),
+			capacityConsumed: 2,
+			want:             false,
+		},
+		{
+			name:                 "should count the kafkas created since the usage has been materialized",
+			computedAt:           time.Now(),
+			capacityConsumed:     1,
+			createdSinceComputed: true,
+			want:                 false,
+		},
+		{
+			name:             "should count all the kafkas when the materialized usage is too old",
+			computedAt:       time.Now().Add(-2 * time.Minute),
+			capacityConsumed: 0,
+			want:             false,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			existingKafkas := converters.ConvertKafkaRequest(buildKafkaRequest(func(kafkaRequest *dbapi.KafkaRequest) {
+				kafkaRequest.InstanceType = types.STANDARD.String()
+			}))
+			createdKafkas := []map[string]interface{}{}
+			if tt.createdSinceComputed {
+				createdKafkas = existingKafkas
+			}
+			mocket.Catcher.Reset().NewMock().
+				WithQuery(`SELECT * FROM "region_capacity_usages" WHERE cloud_provider = $1 AND region = $2 AND instance_type = $3 LIMIT 1`).
+				WithReply([]map[string]interface{}{
+					{
+						"cloud_provider":    testKafkaRequestProvider,
+						"region":            testKafkaRequestRegion,
+						"instance_type":     types.STANDARD.String(),
+						"capacity_consumed": tt.capacityConsumed,
+						"computed_at":       tt.computedAt,
+					},
+				})
+			mocket.Catcher.NewMock().
+				WithQuery(`SELECT * FROM "kafka_requests" WHERE region = $1 AND cloud_provider = $2 AND instance_type = $3 AND created_at > $4`).
+				WithReply(createdKafkas)
+			mocket.Catcher.NewMock().
+				WithQuery(`SELECT * FROM "kafka_requests" WHERE region = $1 AND cloud_provider = $2 AND instance_type = $3 AND "kafka_requests"."deleted_at" IS NULL`).
+				WithReply(append(existingKafkas, existingKafkas...))
+			mocket.Catcher.NewMock().WithExecException().WithQueryException()
+
+			k := &kafkaService{
+				connectionFactory: db.NewMockConnectionFactory(nil),
+				kafkaConfig: &config.KafkaConfig{
+					SupportedInstanceTypes:      defaultKafkaConf.SupportedInstanceTypes,
+					CapacityRecomputationMaxAge: time.Minute,
+				},
+			}
+			kafkaRequest := buildKafkaRequest(func(kafkaRequest *dbapi.KafkaRequest) {
+				kafkaRequest.InstanceType = types.STANDARD.String()
+			})
+
+			// the standard x1 size consumes 1 unit of capacity
+			capacity := 2
+			got, err := k.capacityAvailableForRegionAndInstanceType(&capacity, kafkaRequest, false)
+			g.Expect(err).To(gomega.BeNil())
+			g.Expect(got).To(gomega.Equal(tt.want))
+		})
+	}
+}
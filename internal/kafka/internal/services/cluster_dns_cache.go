@@ -0,0 +1,77 @@
+package services
+
+import (
+	"sync"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/config"
+	apiErrors "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+)
+
+// ClusterDNSCache caches the DNS of the data plane clusters by cluster id, so that preparing many kafkas on the same
+// cluster does not look its DNS up for each of them. It is shared by the kafka service, which reads it, and the
+// cluster service, which invalidates the DNS of a cluster whenever it changes the cluster.
+type ClusterDNSCache struct {
+	ttl time.Duration
+	now func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]clusterDNSCacheEntry
+	// generation is increased by every invalidation, so that a DNS loaded concurrently is not cached stale
+	generation uint64
+}
+
+type clusterDNSCacheEntry struct {
+	clusterDNS string
+	expiresAt  time.Time
+}
+
+func NewClusterDNSCache(kafkaConfig *config.KafkaConfig) *ClusterDNSCache {
+	return &ClusterDNSCache{
+		ttl:     kafkaConfig.ClusterDNSCacheTTL,
+		now:     time.Now,
+		entries: map[string]clusterDNSCacheEntry{},
+	}
+}
+
+// getOrLoad returns the cached DNS of the given cluster if it has not expired yet, otherwise the DNS is loaded with
+// the given function and cached. Empty DNS are not cached.
+func (c *ClusterDNSCache) getOrLoad(clusterID string, load func() (string, *apiErrors.ServiceError)) (string, *apiErrors.ServiceError) {
+	if c == nil || c.ttl <= 0 {
+		return load()
+	}
+
+	c.mu.Lock()
+	if entry, ok := c.entries[clusterID]; ok && c.now().Before(entry.expiresAt) {
+		c.mu.Unlock()
+		return entry.clusterDNS, nil
+	}
+	generation := c.generation
+	c.mu.Unlock()
+
+	// the DNS is loaded without holding the lock as loading it may update the cluster, which invalidates its DNS
+	clusterDNS, err := load()
+	if err != nil {
+		return "", err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if clusterDNS != "" && generation == c.generation {
+		c.entries[clusterID] = clusterDNSCacheEntry{clusterDNS: clusterDNS, expiresAt: c.now().Add(c.ttl)}
+	}
+
+	return clusterDNS, nil
+}
+
+// Invalidate discards the cached DNS of the given cluster so that the next call loads it again
+func (c *ClusterDNSCache) Invalidate(clusterID string) {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.generation++
+	delete(c.entries, clusterID)
+}
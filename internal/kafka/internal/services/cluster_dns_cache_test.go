@@ -0,0 +1,118 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/config"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/client/keycloak"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/sso"
+	"github.com/onsi/gomega"
+
+	mocket "github.com/selvatico/go-mocket"
+)
+
+func Test_kafkaService_PrepareKafkaRequest_ClusterDNSCache(t *testing.T) {
+	g := gomega.NewWithT(t)
+	mocket.Catcher.Reset().NewMock().WithQuery(`UPDATE "kafka_requests"`)
+	mocket.Catcher.NewMock().WithQuery(`UPDATE "clusters"`)
+	mocket.Catcher.NewMock().WithExecException().WithQueryException()
+
+	lookups := map[string]int{}
+	clusterServiceMock := &ClusterServiceMock{
+		GetClusterDNSFunc: func(clusterID string) (string, *errors.ServiceError) {
+			lookups[clusterID]++
+			return "ingress." + clusterID + ".example.com", nil
+		},
+	}
+	now := time.Now()
+	cache := NewClusterDNSCache(&config.KafkaConfig{ClusterDNSCacheTTL: time.Minute})
+	cache.now = func() time.Time { return now }
+	k := &kafkaService{
+		connectionFactory: db.NewMockConnectionFactory(nil),
+		clusterService:    clusterServiceMock,
+		keycloakService: &sso.KeycloakServiceMock{
+			GetConfigFunc: func() *keycloak.KeycloakConfig {
+				return &keycloak.KeycloakConfig{}
+			},
+		},
+		kafkaConfig:     &config.KafkaConfig{},
+		awsConfig:       config.NewAWSConfig(),
+		clusterDNSCache: cache,
+	}
+	prepare := func(clusterID string) {
+		kafkaRequest := buildKafkaRequest(func(kafkaRequest *dbapi.KafkaRequest) {
+			kafkaRequest.ClusterID = clusterID
+		})
+		g.Expect(k.PrepareKafkaRequest(kafkaRequest)).To(gomega.BeNil())
+		g.Expect(kafkaRequest.BootstrapServerHost).To(gomega.HaveSuffix(".ingress." + clusterID + ".example.com"))
+	}
+
+	// repeated prepares on the same cluster look its DNS up once
+	for i := 0; i < 3; i++ {
+		prepare("cluster-1")
+	}
+	g.Expect(lookups).To(gomega.Equal(map[string]int{"cluster-1": 1}))
+
+	// the DNS of each cluster is cached separately
+	prepare("cluster-2")
+	prepare("cluster-2")
+	g.Expect(lookups).To(gomega.Equal(map[string]int{"cluster-1": 1, "cluster-2": 1}))
+
+	// a change of the cluster invalidates its DNS
+	c := &clusterService{
+		connectionFactory: db.NewMockConnectionFactory(nil),
+		clusterDNSCache:   cache,
+	}
+	g.Expect(c.Update(api.Cluster{Meta: api.Meta{ID: "id-1"}, ClusterID: "cluster-1", ClusterDNS: "ingress.cluster-1.example.com"})).To(gomega.BeNil())
+	prepare("cluster-1")
+	prepare("cluster-2")
+	g.Expect(lookups).To(gomega.Equal(map[string]int{"cluster-1": 2, "cluster-2": 1}))
+
+	// an expired DNS is looked up again
+	now = now.Add(2 * time.Minute)
+	prepare("cluster-2")
+	g.Expect(lookups).To(gomega.Equal(map[string]int{"cluster-1": 2, "cluster-2": 2}))
+
+	// a zero TTL disables caching
+	k.clusterDNSCache = NewClusterDNSCache(&config.KafkaConfig{})
+	prepare("cluster-1")
+	prepare("cluster-1")
+	g.Expect(lookups).To(gomega.Equal(map[string]int{"cluster-1": 4, "cluster-2": 2}))
+}
+
+func Test_ClusterDNSCache_getOrLoad(t *testing.T) {
+	g := gomega.NewWithT(t)
+	cache := NewClusterDNSCache(&config.KafkaConfig{ClusterDNSCacheTTL: time.Minute})
+	loads := 0
+
+	// errors and empty DNS are not cached
+	_, err := cache.getOrLoad("cluster-1", func() (string, *errors.ServiceError) {
+		loads++
+		return "", errors.GeneralError("failed to get cluster DNS")
+	})
+	g.Expect(err).ToNot(gomega.BeNil())
+	for i := 0; i < 2; i++ {
+		clusterDNS, err := cache.getOrLoad("cluster-1", func() (string, *errors.ServiceError) {
+			loads++
+			return "", nil
+		})
+		g.Expect(err).To(gomega.BeNil())
+		g.Expect(clusterDNS).To(gomega.BeEmpty())
+	}
+	g.Expect(loads).To(gomega.Equal(3))
+
+	// a DNS invalidated while it is loaded is not cached
+	for i := 0; i < 2; i++ {
+		_, _ = cache.getOrLoad("cluster-1", func() (string, *errors.ServiceError) {
+			loads++
+			cache.Invalidate("cluster-1")
+			return "ingress.cluster-1.example.com", nil
+		})
+	}
+	g.Expect(loads).To(gomega.Equal(5))
+}
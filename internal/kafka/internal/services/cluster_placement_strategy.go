@@ -20,7 +20,7 @@ func NewClusterPlacementStrategy(clusterService ClusterService, dataplaneCluster
 	case dataplaneClusterConfig.IsDataPlaneManualScalingEnabled():
 		clusterSelection = &FirstSchedulableWithinLimit{dataplaneClusterConfig, clusterService, kafkaConfig}
 	case dataplaneClusterConfig.IsDataPlaneAutoScalingEnabled():
-		clusterSelection = &FirstReadyWithCapacity{clusterService, kafkaConfig}
+		clusterSelection = &FirstReadyWithCapacity{clusterService, kafkaConfig, dataplaneClusterConfig}
 	default:
 		clusterSelection = &FirstReadyCluster{clusterService}
 	}
@@ -102,7 +102,7 @@ func (f *FirstSchedulableWithinLimit) FindCluster(kafka *dbapi.KafkaRequest) (*a
 	//we want to make sure the order of the ids configuration is always respected: e.g the first cluster in the configuration that passes all the checks should be picked first
 	for _, schClusterid := range clusterSchIds {
 		cnt := clusterWithinLimit[schClusterid]
-		if dataplaneClusterConfig.IsNumberOfKafkaWithinClusterLimit(schClusterid, cnt+kafkaInstanceSize.CapacityConsumed) {
+		if dataplaneClusterConfig.IsNumberOfKafkaWithinClusterLimit(schClusterid, cnt+getCapacityConsumed(kafka, kafkaInstanceSize)) {
 			return searchClusterObjInArray(clusterObj, schClusterid), nil
 		}
 	}
@@ -133,10 +133,12 @@ func (f *FirstSchedulableWithinLimit) findClusterKafkaInstanceCount(clusterIDs [
 	}
 }
 
-// FirstReadyWithCapacity finds and returns the first cluster in a Ready status with remaining capacity
+// FirstReadyWithCapacity finds and returns the first cluster in a Ready status with remaining capacity.
+// The streaming units reserved on the clusters by the node prewarming configuration are not part of the remaining capacity
 type FirstReadyWithCapacity struct {
-	ClusterService ClusterService
-	KafkaConfig    *config.KafkaConfig
+	ClusterService         ClusterService
+	KafkaConfig            *config.KafkaConfig
+	DataplaneClusterConfig *config.DataplaneClusterConfig
 }
 
 func (f *FirstReadyWithCapacity) FindCluster(kafka *dbapi.KafkaRequest) (*api.Cluster, error) {
@@ -165,13 +167,18 @@ func (f *FirstReadyWithCapacity) FindCluster(kafka *dbapi.KafkaRequest) (*api.Cl
 		return nil, errors.Wrapf(getInstanceSizeErr, "failed to get kafka instance size for cluster with criteria '%v'", criteria)
 	}
 
-	// Find first ready cluster that has remaining capacity (total streaming unit used by existing and requested kafka is within the cluster maxUnit limit)
+	reservedStreamingUnits, reservedStreamingUnitsErr := reservedStreamingUnitsForInstanceType(f.DataplaneClusterConfig, f.KafkaConfig, kafka.InstanceType)
+	if reservedStreamingUnitsErr != nil {
+		return nil, errors.Wrapf(reservedStreamingUnitsErr, "failed to get reserved streaming units for cluster with criteria '%v'", criteria)
+	}
+
+	// Find first ready cluster that has remaining capacity (total streaming unit used by existing, reserved and requested kafka is within the cluster maxUnit limit)
 	for _, cluster := range clusters {
 		currentStreamingUnitsUsed := streamingUnitCountPerRegionList.GetStreamingUnitCountForClusterAndInstanceType(cluster.ClusterID, kafka.InstanceType)
 		capacityInfo := cluster.RetrieveDynamicCapacityInfo()
 		maxStreamingUnits := capacityInfo[kafka.InstanceType].MaxUnits
 
-		if currentStreamingUnitsUsed+instanceSize.CapacityConsumed <= int(maxStreamingUnits) {
+		if currentStreamingUnitsUsed+reservedStreamingUnits+getCapacityConsumed(kafka, instanceSize) <= int(maxStreamingUnits) {
 			return cluster, nil
 		}
 	}
@@ -179,3 +186,23 @@ func (f *FirstReadyWithCapacity) FindCluster(kafka *dbapi.KafkaRequest) (*api.Cl
 	// no cluster found
 	return nil, nil
 }
+
+// reservedStreamingUnitsForInstanceType returns the streaming units reserved for the given instance type on each ready cluster supporting it.
+// Reservations are only made when data plane auto scaling is enabled, see GenerateReservedManagedKafkasByClusterID
+func reservedStreamingUnitsForInstanceType(dataplaneClusterConfig *config.DataplaneClusterConfig, kafkaConfig *config.KafkaConfig, instanceType string) (int, error) {
+	if dataplaneClusterConfig == nil || !dataplaneClusterConfig.IsDataPlaneAutoScalingEnabled() {
+		return 0, nil
+	}
+
+	nodePrewarmingConfig, ok := dataplaneClusterConfig.NodePrewarmingConfig.ForInstanceType(instanceType)
+	if !ok {
+		return 0, nil
+	}
+
+	baseStreamingUnitSize, err := kafkaConfig.GetKafkaInstanceSize(instanceType, nodePrewarmingConfig.BaseStreamingUnitSize)
+	if err != nil {
+		return 0, err
+	}
+
+	return nodePrewarmingConfig.NumReservedInstances * baseStreamingUnitSize.CapacityConsumed, nil
+}
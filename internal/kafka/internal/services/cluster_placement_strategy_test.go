@@ -574,3 +574,113 @@ func TestFirstReadyWithCapacity_FindCluster(t *testing.T) {
 		})
 	}
 }
+
+func TestFirstReadyWithCapacity_FindCluster_ReservedCapacity(t *testing.T) {
+	smallCluster := &api.Cluster{
+		ClusterID:           "small-cluster",
+		DynamicCapacityInfo: api.JSON([]byte(`{"standard":{"max_nodes":1,"max_units":4,"remaining_units":3}}`)),
+	}
+	bigCluster := &api.Cluster{
+		ClusterID:           "big-cluster",
+		DynamicCapacityInfo: api.JSON([]byte(`{"standard":{"max_nodes":2,"max_units":8,"remaining_units":7}}`)),
+	}
+
+	kafkaConfig := &config.KafkaConfig{
+		SupportedInstanceTypes: &config.KafkaSupportedInstanceTypesConfig{
+			Configuration: config.SupportedKafkaInstanceTypesConfig{
+				SupportedKafkaInstanceTypes: []config.KafkaInstanceType{
+					{
+						Id: types.STANDARD.String(),
+						Sizes: []config.KafkaInstanceSize{
+							{
+								Id:               "x1",
+								CapacityConsumed: 1,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	dataplaneClusterConfig := func(scalingType string, numReservedInstances int) *config.DataplaneClusterConfig {
+		c := config.NewDataplaneClusterConfig()
+		c.DataPlaneClusterScalingType = scalingType
+		if numReservedInstances > 0 {
+			c.NodePrewarmingConfig.Configuration[types.STANDARD.String()] = config.InstanceTypeNodePrewarmingConfig{
+				NumReservedInstances: numReservedInstances,
+			}
+		}
+		return c
+	}
+
+	tests := []struct {
+		name                   string
+		dataplaneClusterConfig *config.DataplaneClusterConfig
+		want                   *api.Cluster
+	}{
+		{
+			name:                   "should place the kafka on the first cluster when there are no reservations",
+			dataplaneClusterConfig: dataplaneClusterConfig(config.AutoScaling, 0),
+			want:                   smallCluster,
+		},
+		{
+			name:                   "should place the kafka on the first cluster when it has capacity left after its reservations",
+			dataplaneClusterConfig: dataplaneClusterConfig(config.AutoScaling, 2),
+			want:                   smallCluster,
+		},
+		{
+			name:                   "should skip the clusters whose capacity is held by reservations",
+			dataplaneClusterConfig: dataplaneClusterConfig(config.AutoScaling, 3),
+			want:                   bigCluster,
+		},
+		{
+			name:                   "should not place the kafka when the capacity of all the clusters is held by reservations",
+			dataplaneClusterConfig: dataplaneClusterConfig(config.AutoScaling, 7),
+			want:                   nil,
+		},
+		{
+			name:                   "should ignore the reservations when auto scaling is disabled",
+			dataplaneClusterConfig: dataplaneClusterConfig(config.ManualScaling, 3),
+			want:                   smallCluster,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			f := &FirstReadyWithCapacity{
+				ClusterService: &ClusterServiceMock{
+					FindAllClustersFunc: func(criteria FindClusterCriteria) ([]*api.Cluster, error) {
+						return []*api.Cluster{smallCluster, bigCluster}, nil
+					},
+					FindStreamingUnitCountByClusterAndInstanceTypeFunc: func() (KafkaStreamingUnitCountPerClusterList, error) {
+						return KafkaStreamingUnitCountPerClusterList{
+							{
+								ClusterId:    smallCluster.ClusterID,
+								InstanceType: types.STANDARD.String(),
+								Count:        1,
+							},
+							{
+								ClusterId:    bigCluster.ClusterID,
+								InstanceType: types.STANDARD.String(),
+								Count:        1,
+							},
+						}, nil
+					},
+				},
+				KafkaConfig:            kafkaConfig,
+				DataplaneClusterConfig: tt.dataplaneClusterConfig,
+			}
+
+			got, err := f.FindCluster(mockkafkas.BuildKafkaRequest(
+				mockkafkas.With(mockkafkas.INSTANCE_TYPE, types.STANDARD.String()),
+				mockkafkas.With(mockkafkas.SIZE_ID, "x1"),
+			))
+			g.Expect(err).ToNot(gomega.HaveOccurred())
+			g.Expect(got).To(gomega.Equal(tt.want))
+		})
+	}
+}
@@ -76,20 +76,32 @@ type ClusterService interface {
 	// Data Plane clusters that are in 'failed' state are not included in the response.
 	// Kafkas that are in deleting state won't be included in the count as they no longer consume resources in the data plane cluster.
 	FindStreamingUnitCountByClusterAndInstanceType() (KafkaStreamingUnitCountPerClusterList, error)
+	// FindCachedStreamingUnitCountByClusterAndInstanceType is the same as FindStreamingUnitCountByClusterAndInstanceType but
+	// reuses the counts computed within the configured cache TTL. Set forceRefresh to always compute and cache fresh counts.
+	// When the capacity recomputation is enabled, the counts last materialized by the capacity recomputation worker are
+	// read instead of being computed unless forceRefresh is set.
+	FindCachedStreamingUnitCountByClusterAndInstanceType(forceRefresh bool) (KafkaStreamingUnitCountPerClusterList, error)
+	// RecomputeStreamingUnitCounts computes the streaming unit counts per cluster and instance type and materializes them
+	// for FindCachedStreamingUnitCountByClusterAndInstanceType
+	RecomputeStreamingUnitCounts() error
 }
 
 type clusterService struct {
-	connectionFactory *db.ConnectionFactory
-	providerFactory   clusters.ProviderFactory
-	kafkaConfig       *config.KafkaConfig
+	connectionFactory       *db.ConnectionFactory
+	providerFactory         clusters.ProviderFactory
+	kafkaConfig             *config.KafkaConfig
+	streamingUnitCountCache *StreamingUnitCountCache
+	clusterDNSCache         *ClusterDNSCache
 }
 
 // NewClusterService creates a new client for the OSD Cluster Service
-func NewClusterService(connectionFactory *db.ConnectionFactory, providerFactory clusters.ProviderFactory, kafkaConfig *config.KafkaConfig) ClusterService {
+func NewClusterService(connectionFactory *db.ConnectionFactory, providerFactory clusters.ProviderFactory, kafkaConfig *config.KafkaConfig, streamingUnitCountCache *StreamingUnitCountCache, clusterDNSCache *ClusterDNSCache) ClusterService {
 	return &clusterService{
-		connectionFactory: connectionFactory,
-		providerFactory:   providerFactory,
-		kafkaConfig:       kafkaConfig,
+		connectionFactory:       connectionFactory,
+		providerFactory:         providerFactory,
+		kafkaConfig:             kafkaConfig,
+		streamingUnitCountCache: streamingUnitCountCache,
+		clusterDNSCache:         clusterDNSCache,
 	}
 }
 
@@ -196,6 +208,8 @@ func (c clusterService) Update(cluster api.Cluster) *apiErrors.ServiceError {
 	if err := dbConn.Updates(cluster).Error; err != nil {
 		return apiErrors.NewWithCause(apiErrors.ErrorGeneral, err, "failed to update cluster")
 	}
+	// the DNS of the cluster may have changed
+	c.clusterDNSCache.Invalidate(cluster.ClusterID)
 
 	return nil
 }
@@ -339,6 +353,7 @@ func (c clusterService) DeleteByClusterID(clusterID string) *apiErrors.ServiceEr
 	if err := dbConn.Delete(&api.Cluster{}, api.Cluster{ClusterID: clusterID}).Error; err != nil {
 		return apiErrors.NewWithCause(apiErrors.ErrorGeneral, err, "Unable to delete cluster with cluster_id %s", clusterID)
 	}
+	c.clusterDNSCache.Invalidate(clusterID)
 
 	glog.Infof("Cluster %s deleted successful", clusterID)
 	metrics.IncreaseClusterSuccessOperationsCountMetric(constants2.ClusterOperationDelete)
@@ -426,7 +441,7 @@ func (c clusterService) FindKafkaInstanceCount(clusterIDs []string) ([]ResKafkaI
 		if e != nil {
 			return nil, e
 		}
-		clusterIdCountMap[k.ClusterID] += kafkaInstanceSize.CapacityConsumed
+		clusterIdCountMap[k.ClusterID] += getCapacityConsumed(k, kafkaInstanceSize)
 	}
 
 	// the query above won't return a count for a clusterId if that cluster doesn't have any Kafkas,
@@ -717,6 +732,8 @@ type KafkaPerClusterCount struct {
 	Count         int32
 	CloudProvider string
 	SizeId        string
+	// CapacityConsumedOverride is the capacity consumed override shared by the counted kafkas, nil when they have none
+	CapacityConsumedOverride *int
 }
 
 type ClusterSelection struct {
@@ -729,6 +746,13 @@ type ClusterSelection struct {
 	Status                string
 }
 
+func (c *clusterService) FindCachedStreamingUnitCountByClusterAndInstanceType(forceRefresh bool) (KafkaStreamingUnitCountPerClusterList, error) {
+	if forceRefresh {
+		return c.streamingUnitCountCache.getOrCompute(true, c.FindStreamingUnitCountByClusterAndInstanceType)
+	}
+	return c.streamingUnitCountCache.getOrCompute(false, c.findMaterializedStreamingUnitCount)
+}
+
 func (c *clusterService) FindStreamingUnitCountByClusterAndInstanceType() (KafkaStreamingUnitCountPerClusterList, error) {
 
 	var clusters []*ClusterSelection
@@ -778,8 +802,8 @@ func (c *clusterService) FindStreamingUnitCountByClusterAndInstanceType() (Kafka
 	dbConn = c.connectionFactory.New()
 	var kafkasPerCluster []*KafkaPerClusterCount
 	if err := dbConn.Model(&dbapi.KafkaRequest{}).
-		Select("cloud_provider, region, count(1) as Count, size_id, cluster_id, instance_type").
-		Group("size_id, cluster_id, cloud_provider, region, instance_type").
+		Select("cloud_provider, region, count(1) as Count, size_id, cluster_id, instance_type, capacity_consumed_override").
+		Group("size_id, cluster_id, cloud_provider, region, instance_type, capacity_consumed_override").
 		Where("status not in (?)", kafkaStatusesThatNoLongerConsumeResourcesInTheDataPlane).
 		Scan(&kafkasPerCluster).Error; err != nil {
 		return nil, errors.Wrap(err, "failed to perform count query on kafkas table")
@@ -791,7 +815,7 @@ func (c *clusterService) FindStreamingUnitCountByClusterAndInstanceType() (Kafka
 			return nil, err
 		}
 
-		streamingUnitCount := int32(instSize.CapacityConsumed) * kafkaCountPerCluster.Count
+		streamingUnitCount := int32(capacityConsumed(kafkaCountPerCluster.CapacityConsumedOverride, instSize)) * kafkaCountPerCluster.Count
 		for i, streamingUnitCountPerRegion := range streamingUnitsCountPerCluster {
 			if streamingUnitCountPerRegion.isSame(kafkaCountPerCluster) {
 				streamingUnitsCountPerCluster[i].Count += streamingUnitCount
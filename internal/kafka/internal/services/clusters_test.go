@@ -1,6 +1,7 @@
 package services
 
 import (
+	"database/sql/driver"
 	"encoding/json"
 	"fmt"
 	"testing"
@@ -2628,7 +2629,7 @@ func Test_clusterService_FindStreamingUnitCountByClusterAndInstanceType(t *testi
 			setupFunc: func() {
 				mocket.Catcher.Reset().
 					NewMock().
-					WithQuery(`SELECT cloud_provider, region, count(1) as Count, size_id, cluster_id, instance_type FROM "kafka_requests"`).
+					WithQuery(`SELECT cloud_provider, region, count(1) as Count, size_id, cluster_id, instance_type, capacity_consumed_override FROM "kafka_requests"`).
 					WithReply([]map[string]interface{}{})
 
 				mocket.Catcher.NewMock().
@@ -2649,7 +2650,7 @@ func Test_clusterService_FindStreamingUnitCountByClusterAndInstanceType(t *testi
 			setupFunc: func() {
 				mocket.Catcher.Reset().
 					NewMock().
-					WithQuery(`SELECT cloud_provider, region, count(1) as Count, size_id, cluster_id, instance_type FROM "kafka_requests"`).
+					WithQuery(`SELECT cloud_provider, region, count(1) as Count, size_id, cluster_id, instance_type, capacity_consumed_override FROM "kafka_requests"`).
 					WithQueryException().
 					WithExecException()
 
@@ -2670,7 +2671,7 @@ func Test_clusterService_FindStreamingUnitCountByClusterAndInstanceType(t *testi
 			setupFunc: func() {
 				mocket.Catcher.Reset().
 					NewMock().
-					WithQuery(`SELECT cloud_provider, region, count(1) as Count, size_id, cluster_id, instance_type FROM "kafka_requests"`).
+					WithQuery(`SELECT cloud_provider, region, count(1) as Count, size_id, cluster_id, instance_type, capacity_consumed_override FROM "kafka_requests"`).
 					WithReply([]map[string]interface{}{})
 
 				mocket.Catcher.NewMock().
@@ -2690,12 +2691,14 @@ func Test_clusterService_FindStreamingUnitCountByClusterAndInstanceType(t *testi
 			setupFunc: func() {
 				counters := []map[string]interface{}{
 					{
-						"region":         "us-east-1",
-						"instance_type":  "standard",
-						"cluster_id":     testClusterID1,
-						"cloud_provider": testKafkaRequestProvider,
-						"Count":          8,
-						"SizeId":         "x1",
+						// the columns of the mocked reply are taken from its first row
+						"region":                     "us-east-1",
+						"instance_type":              "standard",
+						"cluster_id":                 testClusterID1,
+						"cloud_provider":             testKafkaRequestProvider,
+						"Count":                      8,
+						"SizeId":                     "x1",
+						"capacity_consumed_override": nil,
 					},
 					{
 						"region":         "us-east-1",
@@ -2705,6 +2708,16 @@ func Test_clusterService_FindStreamingUnitCountByClusterAndInstanceType(t *testi
 						"Count":          2,
 						"SizeId":         "x2",
 					},
+					{
+						// grandfathered kafkas consuming less capacity than their size
+						"region":                     "us-east-1",
+						"instance_type":              "standard",
+						"cluster_id":                 testClusterID1,
+						"cloud_provider":             testKafkaRequestProvider,
+						"Count":                      3,
+						"SizeId":                     "x2",
+						"capacity_consumed_override": 1,
+					},
 					{
 						"region":         "eu-west-1",
 						"instance_type":  "developer",
@@ -2716,7 +2729,7 @@ func Test_clusterService_FindStreamingUnitCountByClusterAndInstanceType(t *testi
 				}
 				mocket.Catcher.Reset().
 					NewMock().
-					WithQuery(`SELECT cloud_provider, region, count(1) as Count, size_id, cluster_id, instance_type FROM "kafka_requests"`).
+					WithQuery(`SELECT cloud_provider, region, count(1) as Count, size_id, cluster_id, instance_type, capacity_consumed_override FROM "kafka_requests"`).
 					WithReply(counters)
 
 				mocket.Catcher.NewMock().
@@ -2752,7 +2765,7 @@ func Test_clusterService_FindStreamingUnitCountByClusterAndInstanceType(t *testi
 					Region:        "us-east-1",
 					InstanceType:  "standard",
 					ClusterId:     testClusterID1,
-					Count:         12,
+					Count:         15,
 					MaxUnits:      20,
 					CloudProvider: "aws",
 				},
@@ -2806,3 +2819,70 @@ func Test_clusterService_FindStreamingUnitCountByClusterAndInstanceType(t *testi
 		})
 	}
 }
+
+func Test_clusterService_FindCachedStreamingUnitCountByClusterAndInstanceType(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	var clusterQueries int
+	mocket.Catcher.Reset().
+		NewMock().
+		WithQuery(`SELECT cloud_provider, region, count(1) as Count, size_id, cluster_id, instance_type, capacity_consumed_override FROM "kafka_requests"`).
+		WithReply([]map[string]interface{}{})
+	mocket.Catcher.NewMock().
+		WithQuery(`SELECT * FROM "clusters"`).
+		WithCallback(func(_ string, _ []driver.NamedValue) {
+			clusterQueries++
+		}).
+		WithReply([]map[string]interface{}{
+			{
+				"cluster_id":              "test-cluster",
+				"region":                  "us-east-1",
+				"cloud_provider":          "aws",
+				"status":                  api.ClusterReady.String(),
+				"supported_instance_type": "standard",
+			},
+		})
+	mocket.Catcher.NewMock().WithQueryException().WithExecException()
+
+	now := time.Now()
+	cache := NewStreamingUnitCountCache(&config.KafkaConfig{StreamingUnitCountCacheTTL: time.Minute})
+	cache.now = func() time.Time { return now }
+	c := &clusterService{
+		connectionFactory:       db.NewMockConnectionFactory(nil),
+		kafkaConfig:             &config.KafkaConfig{SupportedInstanceTypes: &config.KafkaSupportedInstanceTypesConfig{}},
+		streamingUnitCountCache: cache,
+	}
+
+	// calls within the TTL query the database once
+	for i := 0; i < 5; i++ {
+		counts, err := c.FindCachedStreamingUnitCountByClusterAndInstanceType(false)
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+		g.Expect(counts).To(gomega.HaveLen(1))
+	}
+	g.Expect(clusterQueries).To(gomega.Equal(1))
+
+	// force refresh queries the database
+	_, err := c.FindCachedStreamingUnitCountByClusterAndInstanceType(true)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(clusterQueries).To(gomega.Equal(2))
+
+	// invalidation queries the database on the next call
+	cache.Invalidate()
+	_, err = c.FindCachedStreamingUnitCountByClusterAndInstanceType(false)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(clusterQueries).To(gomega.Equal(3))
+
+	// an expired TTL queries the database
+	now = now.Add(2 * time.Minute)
+	_, err = c.FindCachedStreamingUnitCountByClusterAndInstanceType(false)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(clusterQueries).To(gomega.Equal(4))
+
+	// a zero TTL disables caching
+	c.streamingUnitCountCache = NewStreamingUnitCountCache(&config.KafkaConfig{})
+	for i := 0; i < 2; i++ {
+		_, err = c.FindCachedStreamingUnitCountByClusterAndInstanceType(false)
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+	}
+	g.Expect(clusterQueries).To(gomega.Equal(6))
+}
@@ -48,6 +48,9 @@ var _ ClusterService = &ClusterServiceMock{}
 //			FindAllClustersFunc: func(criteria FindClusterCriteria) ([]*api.Cluster, error) {
 //				panic("mock out the FindAllClusters method")
 //			},
+//			FindCachedStreamingUnitCountByClusterAndInstanceTypeFunc: func(forceRefresh bool) (KafkaStreamingUnitCountPerClusterList, error) {
+//				panic("mock out the FindCachedStreamingUnitCountByClusterAndInstanceType method")
+//			},
 //			FindClusterFunc: func(criteria FindClusterCriteria) (*api.Cluster, error) {
 //				panic("mock out the FindCluster method")
 //			},
@@ -90,6 +93,9 @@ var _ ClusterService = &ClusterServiceMock{}
 //			ListGroupByProviderAndRegionFunc: func(providers []string, regions []string, status []string) ([]*ResGroupCPRegion, *apiErrors.ServiceError) {
 //				panic("mock out the ListGroupByProviderAndRegion method")
 //			},
+//			RecomputeStreamingUnitCountsFunc: func() error {
+//				panic("mock out the RecomputeStreamingUnitCounts method")
+//			},
 //			RegisterClusterJobFunc: func(clusterRequest *api.Cluster) *apiErrors.ServiceError {
 //				panic("mock out the RegisterClusterJob method")
 //			},
@@ -136,6 +142,9 @@ type ClusterServiceMock struct {
 	// FindAllClustersFunc mocks the FindAllClusters method.
 	FindAllClustersFunc func(criteria FindClusterCriteria) ([]*api.Cluster, error)
 
+	// FindCachedStreamingUnitCountByClusterAndInstanceTypeFunc mocks the FindCachedStreamingUnitCountByClusterAndInstanceType method.
+	FindCachedStreamingUnitCountByClusterAndInstanceTypeFunc func(forceRefresh bool) (KafkaStreamingUnitCountPerClusterList, error)
+
 	// FindClusterFunc mocks the FindCluster method.
 	FindClusterFunc func(criteria FindClusterCriteria) (*api.Cluster, error)
 
@@ -178,6 +187,9 @@ type ClusterServiceMock struct {
 	// ListGroupByProviderAndRegionFunc mocks the ListGroupByProviderAndRegion method.
 	ListGroupByProviderAndRegionFunc func(providers []string, regions []string, status []string) ([]*ResGroupCPRegion, *apiErrors.ServiceError)
 
+	// RecomputeStreamingUnitCountsFunc mocks the RecomputeStreamingUnitCounts method.
+	RecomputeStreamingUnitCountsFunc func() error
+
 	// RegisterClusterJobFunc mocks the RegisterClusterJob method.
 	RegisterClusterJobFunc func(clusterRequest *api.Cluster) *apiErrors.ServiceError
 
@@ -243,6 +255,11 @@ type ClusterServiceMock struct {
 			// Criteria is the criteria argument value.
 			Criteria FindClusterCriteria
 		}
+		// FindCachedStreamingUnitCountByClusterAndInstanceType holds details about calls to the FindCachedStreamingUnitCountByClusterAndInstanceType method.
+		FindCachedStreamingUnitCountByClusterAndInstanceType []struct {
+			// ForceRefresh is the forceRefresh argument value.
+			ForceRefresh bool
+		}
 		// FindCluster holds details about calls to the FindCluster method.
 		FindCluster []struct {
 			// Criteria is the criteria argument value.
@@ -321,6 +338,9 @@ type ClusterServiceMock struct {
 			// Status is the status argument value.
 			Status []string
 		}
+		// RecomputeStreamingUnitCounts holds details about calls to the RecomputeStreamingUnitCounts method.
+		RecomputeStreamingUnitCounts []struct {
+		}
 		// RegisterClusterJob holds details about calls to the RegisterClusterJob method.
 		RegisterClusterJob []struct {
 			// ClusterRequest is the clusterRequest argument value.
@@ -346,33 +366,35 @@ type ClusterServiceMock struct {
 			Status api.ClusterStatus
 		}
 	}
-	lockApplyResources                                 sync.RWMutex
-	lockCheckClusterStatus                             sync.RWMutex
-	lockCheckStrimziVersionReady                       sync.RWMutex
-	lockConfigureAndSaveIdentityProvider               sync.RWMutex
-	lockCountByStatus                                  sync.RWMutex
-	lockCreate                                         sync.RWMutex
-	lockDelete                                         sync.RWMutex
-	lockDeleteByClusterID                              sync.RWMutex
-	lockFindAllClusters                                sync.RWMutex
-	lockFindCluster                                    sync.RWMutex
-	lockFindClusterByID                                sync.RWMutex
-	lockFindKafkaInstanceCount                         sync.RWMutex
-	lockFindNonEmptyClusterById                        sync.RWMutex
-	lockFindStreamingUnitCountByClusterAndInstanceType sync.RWMutex
-	lockGetClientId                                    sync.RWMutex
-	lockGetClusterDNS                                  sync.RWMutex
-	lockGetExternalID                                  sync.RWMutex
-	lockInstallClusterLogging                          sync.RWMutex
-	lockInstallStrimzi                                 sync.RWMutex
-	lockIsStrimziKafkaVersionAvailableInCluster        sync.RWMutex
-	lockListAllClusterIds                              sync.RWMutex
-	lockListByStatus                                   sync.RWMutex
-	lockListGroupByProviderAndRegion                   sync.RWMutex
-	lockRegisterClusterJob                             sync.RWMutex
-	lockUpdate                                         sync.RWMutex
-	lockUpdateMultiClusterStatus                       sync.RWMutex
-	lockUpdateStatus                                   sync.RWMutex
+	lockApplyResources                                       sync.RWMutex
+	lockCheckClusterStatus                                   sync.RWMutex
+	lockCheckStrimziVersionReady                             sync.RWMutex
+	lockConfigureAndSaveIdentityProvider                     sync.RWMutex
+	lockCountByStatus                                        sync.RWMutex
+	lockCreate                                               sync.RWMutex
+	lockDelete                                               sync.RWMutex
+	lockDeleteByClusterID                                    sync.RWMutex
+	lockFindAllClusters                                      sync.RWMutex
+	lockFindCachedStreamingUnitCountByClusterAndInstanceType sync.RWMutex
+	lockFindCluster                                          sync.RWMutex
+	lockFindClusterByID                                      sync.RWMutex
+	lockFindKafkaInstanceCount                               sync.RWMutex
+	lockFindNonEmptyClusterById                              sync.RWMutex
+	lockFindStreamingUnitCountByClusterAndInstanceType       sync.RWMutex
+	lockGetClientId                                          sync.RWMutex
+	lockGetClusterDNS                                        sync.RWMutex
+	lockGetExternalID                                        sync.RWMutex
+	lockInstallClusterLogging                                sync.RWMutex
+	lockInstallStrimzi                                       sync.RWMutex
+	lockIsStrimziKafkaVersionAvailableInCluster              sync.RWMutex
+	lockListAllClusterIds                                    sync.RWMutex
+	lockListByStatus                                         sync.RWMutex
+	lockListGroupByProviderAndRegion                         sync.RWMutex
+	lockRecomputeStreamingUnitCounts                         sync.RWMutex
+	lockRegisterClusterJob                                   sync.RWMutex
+	lockUpdate                                               sync.RWMutex
+	lockUpdateMultiClusterStatus                             sync.RWMutex
+	lockUpdateStatus                                         sync.RWMutex
 }
 
 // ApplyResources calls ApplyResourcesFunc.
@@ -675,6 +697,38 @@ func (mock *ClusterServiceMock) FindAllClustersCalls() []struct {
 	return calls
 }
 
+// FindCachedStreamingUnitCountByClusterAndInstanceType calls FindCachedStreamingUnitCountByClusterAndInstanceTypeFunc.
+func (mock *ClusterServiceMock) FindCachedStreamingUnitCountByClusterAndInstanceType(forceRefresh bool) (KafkaStreamingUnitCountPerClusterList, error) {
+	if mock.FindCachedStreamingUnitCountByClusterAndInstanceTypeFunc == nil {
+		panic("ClusterServiceMock.FindCachedStreamingUnitCountByClusterAndInstanceTypeFunc: method is nil but ClusterService.FindCachedStreamingUnitCountByClusterAndInstanceType was just called")
+	}
+	callInfo := struct {
+		ForceRefresh bool
+	}{
+		ForceRefresh: forceRefresh,
+	}
+	mock.lockFindCachedStreamingUnitCountByClusterAndInstanceType.Lock()
+	mock.calls.FindCachedStreamingUnitCountByClusterAndInstanceType = append(mock.calls.FindCachedStreamingUnitCountByClusterAndInstanceType, callInfo)
+	mock.lockFindCachedStreamingUnitCountByClusterAndInstanceType.Unlock()
+	return mock.FindCachedStreamingUnitCountByClusterAndInstanceTypeFunc(forceRefresh)
+}
+
+// FindCachedStreamingUnitCountByClusterAndInstanceTypeCalls gets all the calls that were made to FindCachedStreamingUnitCountByClusterAndInstanceType.
+// Check the length with:
+//
+//	len(mockedClusterService.FindCachedStreamingUnitCountByClusterAndInstanceTypeCalls())
+func (mock *ClusterServiceMock) FindCachedStreamingUnitCountByClusterAndInstanceTypeCalls() []struct {
+	ForceRefresh bool
+} {
+	var calls []struct {
+		ForceRefresh bool
+	}
+	mock.lockFindCachedStreamingUnitCountByClusterAndInstanceType.RLock()
+	calls = mock.calls.FindCachedStreamingUnitCountByClusterAndInstanceType
+	mock.lockFindCachedStreamingUnitCountByClusterAndInstanceType.RUnlock()
+	return calls
+}
+
 // FindCluster calls FindClusterFunc.
 func (mock *ClusterServiceMock) FindCluster(criteria FindClusterCriteria) (*api.Cluster, error) {
 	if mock.FindClusterFunc == nil {
@@ -1137,6 +1191,33 @@ func (mock *ClusterServiceMock) ListGroupByProviderAndRegionCalls() []struct {
 	return calls
 }
 
+// RecomputeStreamingUnitCounts calls RecomputeStreamingUnitCountsFunc.
+func (mock *ClusterServiceMock) RecomputeStreamingUnitCounts() error {
+	if mock.RecomputeStreamingUnitCountsFunc == nil {
+		panic("ClusterServiceMock.RecomputeStreamingUnitCountsFunc: method is nil but ClusterService.RecomputeStreamingUnitCounts was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockRecomputeStreamingUnitCounts.Lock()
+	mock.calls.RecomputeStreamingUnitCounts = append(mock.calls.RecomputeStreamingUnitCounts, callInfo)
+	mock.lockRecomputeStreamingUnitCounts.Unlock()
+	return mock.RecomputeStreamingUnitCountsFunc()
+}
+
+// RecomputeStreamingUnitCountsCalls gets all the calls that were made to RecomputeStreamingUnitCounts.
+// Check the length with:
+//
+//	len(mockedClusterService.RecomputeStreamingUnitCountsCalls())
+func (mock *ClusterServiceMock) RecomputeStreamingUnitCountsCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockRecomputeStreamingUnitCounts.RLock()
+	calls = mock.calls.RecomputeStreamingUnitCounts
+	mock.lockRecomputeStreamingUnitCounts.RUnlock()
+	return calls
+}
+
 // RegisterClusterJob calls RegisterClusterJobFunc.
 func (mock *ClusterServiceMock) RegisterClusterJob(clusterRequest *api.Cluster) *apiErrors.ServiceError {
 	if mock.RegisterClusterJobFunc == nil {
@@ -0,0 +1,99 @@
+package services
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+)
+
+// CostAllocationRecord attributes the cost of a kafka during a time window to its owner and organisation
+type CostAllocationRecord struct {
+	KafkaID        string
+	Name           string
+	Owner          string
+	OrganisationId string
+	InstanceType   string
+	SizeId         string
+	// StreamingUnits is the number of streaming units the kafka is charged for, as defined by its instance size
+	StreamingUnits int
+	CloudProvider  string
+	Region         string
+	// Tags are the annotations of the kafka, they allow to further allocate its cost e.g. to a cost center
+	Tags      map[string]string
+	CreatedAt time.Time
+	// DeletedAt is nil when the kafka has not been deleted
+	DeletedAt *time.Time
+	// ActiveDuration is the time the kafka existed within the window, to charge kafkas created or deleted during it
+	ActiveDuration time.Duration
+	// Error is the reason why the streaming units of the kafka could not be determined, in which case they are 0. It is
+	// empty otherwise
+	Error string
+}
+
+func (k *kafkaService) ExportCostAllocation(from time.Time, to time.Time) ([]CostAllocationRecord, *errors.ServiceError) {
+	if from.After(to) {
+		return nil, errors.Validation("the start of the range '%s' must not be after its end '%s'", from.Format(time.RFC3339), to.Format(time.RFC3339))
+	}
+
+	// the kafkas deleted during the window are charged for the time they existed within it
+	var kafkas []*dbapi.KafkaRequest
+	if err := k.connectionFactory.New().
+		Unscoped().
+		Select("id", "name", "owner", "organisation_id", "instance_type", "size_id", "cloud_provider", "region", "annotations", "created_at", "deleted_at").
+		Where("created_at <= ?", to).
+		Where("deleted_at IS NULL OR deleted_at >= ?", from).
+		Order("created_at, id").
+		Find(&kafkas).Error; err != nil {
+		return nil, errors.NewWithCause(errors.ErrorGeneral, err, "failed to find the kafkas existing between '%s' and '%s'", from.Format(time.RFC3339), to.Format(time.RFC3339))
+	}
+
+	records := make([]CostAllocationRecord, 0, len(kafkas))
+	for _, kafka := range kafkas {
+		// a kafka whose size is not supported anymore is still reported, so that a single kafka doesn't prevent the
+		// export of the others
+		var streamingUnits int
+		var recordErr string
+		if instanceSize, err := k.kafkaConfig.GetKafkaInstanceSize(kafka.InstanceType, kafka.SizeId); err != nil {
+			recordErr = fmt.Sprintf("failed to get the size of kafka %q: %s", kafka.ID, err.Error())
+		} else {
+			streamingUnits = instanceSize.QuotaConsumed
+		}
+		tags, err := kafka.GetAnnotations()
+		if err != nil {
+			return nil, errors.NewWithCause(errors.ErrorGeneral, err, "failed to read the annotations of kafka %q", kafka.ID)
+		}
+
+		activeFrom, activeTo := kafka.CreatedAt, to
+		if activeFrom.Before(from) {
+			activeFrom = from
+		}
+		var deletedAt *time.Time
+		if kafka.DeletedAt.Valid {
+			deletedAt = &kafka.DeletedAt.Time
+			if deletedAt.Before(to) {
+				activeTo = *deletedAt
+			}
+		}
+
+		records = append(records, CostAllocationRecord{
+			KafkaID:        kafka.ID,
+			Name:           kafka.Name,
+			Owner:          kafka.Owner,
+			OrganisationId: kafka.OrganisationId,
+			InstanceType:   kafka.InstanceType,
+			SizeId:         kafka.SizeId,
+			StreamingUnits: streamingUnits,
+			CloudProvider:  kafka.CloudProvider,
+			Region:         kafka.Region,
+			Tags:           tags,
+			CreatedAt:      kafka.CreatedAt,
+			DeletedAt:      deletedAt,
+			ActiveDuration: activeTo.Sub(activeFrom),
+			Error:          recordErr,
+		})
+	}
+
+	return records, nil
+}
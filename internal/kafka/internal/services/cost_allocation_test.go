@@ -0,0 +1,189 @@
+package services
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/kafkas/types"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/onsi/gomega"
+
+	mocket "github.com/selvatico/go-mocket"
+)
+
+func Test_kafkaService_ExportCostAllocation(t *testing.T) {
+	to := time.Now()
+	from := to.Add(-30 * 24 * time.Hour)
+	createdMidWindow := from.Add(10 * 24 * time.Hour)
+	deletedMidWindow := from.Add(20 * 24 * time.Hour)
+	kafkaRow := func(id string, instanceType types.KafkaInstanceType, createdAt time.Time, deletedAt *time.Time) map[string]interface{} {
+		row := map[string]interface{}{
+			"id":              id,
+			"name":            "name-" + id,
+			"owner":           testUser,
+			"organisation_id": "org-id",
+			"instance_type":   instanceType.String(),
+			"size_id":         "x1",
+			"cloud_provider":  testKafkaRequestProvider,
+			"region":          testKafkaRequestRegion,
+			"created_at":      createdAt,
+		}
+		if deletedAt != nil {
+			row["deleted_at"] = *deletedAt
+		}
+		return row
+	}
+	taggedKafka := kafkaRow("tagged", types.STANDARD, from.Add(-time.Hour), nil)
+	taggedKafka["annotations"] = []byte(`{"cost-center":"engineering"}`)
+	unsupportedKafka := kafkaRow("unsupported", types.STANDARD, createdMidWindow, nil)
+	unsupportedKafka["size_id"] = "x100"
+	_, sizeErr := defaultKafkaConf.GetKafkaInstanceSize(types.STANDARD.String(), "x100")
+	unsupportedSizeErr := fmt.Sprintf("failed to get the size of kafka %q: %s", "unsupported", sizeErr)
+
+	tests := []struct {
+		name    string
+		from    time.Time
+		to      time.Time
+		reply   []map[string]interface{}
+		want    []CostAllocationRecord
+		wantErr bool
+	}{
+		{
+			name: "should charge the kafkas created or deleted during the window for the time they existed within it",
+			from: from,
+			to:   to,
+			reply: []map[string]interface{}{
+				kafkaRow("created", types.STANDARD, createdMidWindow, nil),
+				kafkaRow("deleted", types.DEVELOPER, from.Add(-time.Hour), &deletedMidWindow),
+			},
+			want: []CostAllocationRecord{
+				{
+					KafkaID:        "created",
+					Name:           "name-created",
+					Owner:          testUser,
+					OrganisationId: "org-id",
+					InstanceType:   types.STANDARD.String(),
+					SizeId:         "x1",
+					StreamingUnits: 1,
+					CloudProvider:  testKafkaRequestProvider,
+					Region:         testKafkaRequestRegion,
+					Tags:           map[string]string{},
+					CreatedAt:      createdMidWindow,
+					ActiveDuration: to.Sub(createdMidWindow),
+				},
+				{
+					KafkaID:        "deleted",
+					Name:           "name-deleted",
+					Owner:          testUser,
+					OrganisationId: "org-id",
+					InstanceType:   types.DEVELOPER.String(),
+					SizeId:         "x1",
+					StreamingUnits: 2,
+					CloudProvider:  testKafkaRequestProvider,
+					Region:         testKafkaRequestRegion,
+					Tags:           map[string]string{},
+					CreatedAt:      from.Add(-time.Hour),
+					DeletedAt:      &deletedMidWindow,
+					ActiveDuration: deletedMidWindow.Sub(from),
+				},
+			},
+		},
+		{
+			name:  "should charge the kafkas existing during the whole window for the whole window and return their tags",
+			from:  from,
+			to:    to,
+			reply: []map[string]interface{}{taggedKafka},
+			want: []CostAllocationRecord{
+				{
+					KafkaID:        "tagged",
+					Name:           "name-tagged",
+					Owner:          testUser,
+					OrganisationId: "org-id",
+					InstanceType:   types.STANDARD.String(),
+					SizeId:         "x1",
+					StreamingUnits: 1,
+					CloudProvider:  testKafkaRequestProvider,
+					Region:         testKafkaRequestRegion,
+					Tags:           map[string]string{"cost-center": "engineering"},
+					CreatedAt:      from.Add(-time.Hour),
+					ActiveDuration: to.Sub(from),
+				},
+			},
+		},
+		{
+			name:  "should return no record when no kafka existed during the window",
+			from:  from,
+			to:    to,
+			reply: []map[string]interface{}{},
+			want:  []CostAllocationRecord{},
+		},
+		{
+			name:  "should report the kafkas whose size is not supported anymore with no streaming units and the error",
+			from:  from,
+			to:    to,
+			reply: []map[string]interface{}{unsupportedKafka, taggedKafka},
+			want: []CostAllocationRecord{
+				{
+					KafkaID:        "unsupported",
+					Name:           "name-unsupported",
+					Owner:          testUser,
+					OrganisationId: "org-id",
+					InstanceType:   types.STANDARD.String(),
+					SizeId:         "x100",
+					StreamingUnits: 0,
+					CloudProvider:  testKafkaRequestProvider,
+					Region:         testKafkaRequestRegion,
+					Tags:           map[string]string{},
+					CreatedAt:      createdMidWindow,
+					ActiveDuration: to.Sub(createdMidWindow),
+					Error:          unsupportedSizeErr,
+				},
+				{
+					KafkaID:        "tagged",
+					Name:           "name-tagged",
+					Owner:          testUser,
+					OrganisationId: "org-id",
+					InstanceType:   types.STANDARD.String(),
+					SizeId:         "x1",
+					StreamingUnits: 1,
+					CloudProvider:  testKafkaRequestProvider,
+					Region:         testKafkaRequestRegion,
+					Tags:           map[string]string{"cost-center": "engineering"},
+					CreatedAt:      from.Add(-time.Hour),
+					ActiveDuration: to.Sub(from),
+				},
+			},
+		},
+		{
+			name:    "should return an error when the window starts after it ends",
+			from:    to,
+			to:      from,
+			wantErr: true,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			mocket.Catcher.Reset()
+			mocket.Catcher.NewMock().
+				WithQuery(`FROM "kafka_requests" WHERE created_at <= $1 AND (deleted_at IS NULL OR deleted_at >= $2)`).
+				WithReply(tt.reply)
+			mocket.Catcher.NewMock().WithExecException().WithQueryException()
+
+			k := &kafkaService{
+				connectionFactory: db.NewMockConnectionFactory(nil),
+				kafkaConfig:       &defaultKafkaConf,
+			}
+			got, err := k.ExportCostAllocation(tt.from, tt.to)
+			if tt.wantErr {
+				g.Expect(err).ToNot(gomega.BeNil())
+				return
+			}
+			g.Expect(err).To(gomega.BeNil())
+			g.Expect(got).To(gomega.Equal(tt.want))
+		})
+	}
+}
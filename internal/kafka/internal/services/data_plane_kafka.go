@@ -232,7 +232,11 @@ func (d *dataPlaneKafkaService) setKafkaClusterReady(kafka *dbapi.KafkaRequest)
 		return err
 	}
 
-	err = d.kafkaService.Updates(kafka, map[string]interface{}{"admin_api_server_url": kafka.AdminApiServerURL, "failed_reason": "", "status": constants2.KafkaRequestStatusReady.String()})
+	fields := map[string]interface{}{"admin_api_server_url": kafka.AdminApiServerURL, "failed_reason": "", "status": constants2.KafkaRequestStatusReady.String()}
+	if shouldSendMetric {
+		fields["provisioned_at"] = time.Now()
+	}
+	err = d.kafkaService.Updates(kafka, fields)
 	if err != nil {
 		return serviceError.NewWithCause(err.Code, err, "failed to update kafka cluster %s", kafka.ID)
 	}
@@ -314,6 +318,18 @@ func (d *dataPlaneKafkaService) setKafkaRequestVersionFields(kafka *dbapi.KafkaR
 
 	}
 
+	// keep track of when the current upgrade started so that stuck upgrades can be detected
+	upgrading := kafka.StrimziUpgrading || kafka.KafkaUpgrading || kafka.KafkaIBPUpgrading
+	if upgrading && kafka.UpgradeStartedAt == nil {
+		now := time.Now()
+		kafka.UpgradeStartedAt = &now
+		needsUpdate = true
+	}
+	if !upgrading && kafka.UpgradeStartedAt != nil {
+		kafka.UpgradeStartedAt = nil
+		needsUpdate = true
+	}
+
 	if needsUpdate {
 		versionFields := map[string]interface{}{
 			"actual_strimzi_version":   kafka.ActualStrimziVersion,
@@ -322,6 +338,7 @@ func (d *dataPlaneKafkaService) setKafkaRequestVersionFields(kafka *dbapi.KafkaR
 			"strimzi_upgrading":        kafka.StrimziUpgrading,
 			"kafka_upgrading":          kafka.KafkaUpgrading,
 			"kafka_ibp_upgrading":      kafka.KafkaIBPUpgrading,
+			"upgrade_started_at":       kafka.UpgradeStartedAt,
 		}
 
 		if err := d.kafkaService.Updates(kafka, versionFields); err != nil {
@@ -362,12 +379,8 @@ func (d *dataPlaneKafkaService) setKafkaClusterFailed(kafka *dbapi.KafkaRequest,
 
 func (d *dataPlaneKafkaService) setKafkaClusterDeleting(kafka *dbapi.KafkaRequest) *serviceError.ServiceError {
 	// If the Kafka cluster is deleted from the data plane cluster, we will make it as "deleting" in db and the reconcilier will ensure it is cleaned up properly
-	if ok, updateErr := d.kafkaService.UpdateStatus(kafka.ID, constants2.KafkaRequestStatusDeleting); ok {
-		if updateErr != nil {
-			return serviceError.NewWithCause(updateErr.Code, updateErr, "failed to update status %s for kafka cluster %s", constants2.KafkaRequestStatusDeleting, kafka.ID)
-		} else {
-			metrics.UpdateKafkaRequestsStatusSinceCreatedMetric(constants2.KafkaRequestStatusDeleting, kafka.ID, kafka.ClusterID, time.Since(kafka.CreatedAt))
-		}
+	if ok, updateErr := d.kafkaService.UpdateStatus(kafka.ID, constants2.KafkaRequestStatusDeleting); ok && updateErr != nil {
+		return serviceError.NewWithCause(updateErr.Code, updateErr, "failed to update status %s for kafka cluster %s", constants2.KafkaRequestStatusDeleting, kafka.ID)
 	}
 	return nil
 }
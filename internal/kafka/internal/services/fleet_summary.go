@@ -0,0 +1,115 @@
+package services
+
+import (
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared/utils/arrays"
+)
+
+// FleetSummaryExpiryWindow is how soon the kafkas counted as nearing expiry in the fleet summary expire
+const FleetSummaryExpiryWindow = 24 * time.Hour
+
+// FleetSummary summarizes the kafkas of all the users, e.g. for the landing page of the admin dashboard
+type FleetSummary struct {
+	Total                int
+	CountByStatus        map[string]int
+	CountByInstanceType  map[string]int
+	CountByCloudProvider map[string]int
+	// StreamingUnits is the number of streaming units consumed by the kafkas that are not being deleted
+	StreamingUnits int
+	// NearingExpiry is the number of kafkas that are not being deleted and expire within FleetSummaryExpiryWindow,
+	// including the expired ones that have not been deprovisioned yet
+	NearingExpiry int
+}
+
+func (k *kafkaService) GetFleetSummary() (*FleetSummary, *errors.ServiceError) {
+	type fleetCount struct {
+		Status        string
+		InstanceType  string
+		SizeId        string
+		CloudProvider string
+		Count         int
+	}
+
+	// a single grouped query counts the kafkas for all the breakdowns
+	var counts []fleetCount
+	if err := k.connectionFactory.New().
+		Model(&dbapi.KafkaRequest{}).
+		Select("status, instance_type, size_id, cloud_provider, count(1) AS count").
+		Group("status, instance_type, size_id, cloud_provider").
+		Scan(&counts).Error; err != nil {
+		return nil, errors.NewWithCause(errors.ErrorGeneral, err, "failed to count the kafkas of the fleet")
+	}
+
+	summary := &FleetSummary{
+		CountByStatus:        map[string]int{},
+		CountByInstanceType:  map[string]int{},
+		CountByCloudProvider: map[string]int{},
+	}
+	for _, count := range counts {
+		summary.Total += count.Count
+		summary.CountByStatus[count.Status] += count.Count
+		summary.CountByInstanceType[count.InstanceType] += count.Count
+		summary.CountByCloudProvider[count.CloudProvider] += count.Count
+		if arrays.Contains(kafkaDeletionStatuses, count.Status) {
+			continue
+		}
+
+		instanceSize, err := k.kafkaConfig.GetKafkaInstanceSize(count.InstanceType, count.SizeId)
+		if err != nil {
+			return nil, errors.NewWithCause(errors.ErrorInstancePlanNotSupported, err, "failed to get the size %q of %s kafkas", count.SizeId, count.InstanceType)
+		}
+		summary.StreamingUnits += instanceSize.QuotaConsumed * count.Count
+	}
+
+	nearingExpiry, svcErr := k.countKafkasNearingExpiry()
+	if svcErr != nil {
+		return nil, svcErr
+	}
+	summary.NearingExpiry = nearingExpiry
+
+	return summary, nil
+}
+
+// countKafkasNearingExpiry returns the number of kafkas that are not being deleted and expire within
+// FleetSummaryExpiryWindow. Only the kafkas of the instance types having a size with a lifespan are read.
+func (k *kafkaService) countKafkasNearingExpiry() (int, *errors.ServiceError) {
+	var typesWithLifespan []string
+	for _, kafkaInstanceType := range k.kafkaConfig.SupportedInstanceTypes.Configuration.SupportedKafkaInstanceTypes {
+		if kafkaInstanceType.HasAnInstanceSizeWithLifespan() {
+			typesWithLifespan = append(typesWithLifespan, kafkaInstanceType.Id)
+		}
+	}
+	if len(typesWithLifespan) == 0 {
+		return 0, nil
+	}
+
+	var kafkas []*dbapi.KafkaRequest
+	if err := k.connectionFactory.New().
+		Model(&dbapi.KafkaRequest{}).
+		Select("id", "instance_type", "size_id", "organisation_id", "created_at").
+		Where("instance_type IN (?)", typesWithLifespan).
+		Where("status NOT IN (?)", kafkaDeletionStatuses).
+		Find(&kafkas).Error; err != nil {
+		return 0, errors.NewWithCause(errors.ErrorGeneral, err, "failed to find the kafkas with a lifespan")
+	}
+
+	nearingExpiry := 0
+	expiryLimit := time.Now().Add(FleetSummaryExpiryWindow)
+	for _, kafka := range kafkas {
+		instanceSize, err := k.kafkaConfig.GetKafkaInstanceSize(kafka.InstanceType, kafka.SizeId)
+		if err != nil {
+			return 0, errors.NewWithCause(errors.ErrorInstancePlanNotSupported, err, "failed to get the size of kafka %q", kafka.ID)
+		}
+		// the organisation of the kafka may have its own lifespan
+		if lifespanSeconds := k.kafkaConfig.KafkaLifespan.GetLifespanSeconds(kafka.OrganisationId, instanceSize.LifespanSeconds); lifespanSeconds != nil {
+			if kafka.GetExpirationTime(*lifespanSeconds).Before(expiryLimit) {
+				nearingExpiry++
+			}
+		}
+	}
+
+	return nearingExpiry, nil
+}
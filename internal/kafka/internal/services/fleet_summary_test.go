@@ -0,0 +1,139 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	constants2 "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/constants"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/kafkas/types"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/onsi/gomega"
+
+	mocket "github.com/selvatico/go-mocket"
+)
+
+func Test_kafkaService_GetFleetSummary(t *testing.T) {
+	now := time.Now()
+	developerLifespan := time.Duration(*kafkaSupportedInstanceTypesConfig.Configuration.SupportedKafkaInstanceTypes[1].Sizes[0].LifespanSeconds) * time.Second
+	countRow := func(status constants2.KafkaStatus, instanceType types.KafkaInstanceType, sizeId string, cloudProvider string, count int) map[string]interface{} {
+		return map[string]interface{}{
+			"status":         status.String(),
+			"instance_type":  instanceType.String(),
+			"size_id":        sizeId,
+			"cloud_provider": cloudProvider,
+			"count":          count,
+		}
+	}
+	developerRow := func(id string, createdAt time.Time) map[string]interface{} {
+		return map[string]interface{}{
+			"id":            id,
+			"instance_type": types.DEVELOPER.String(),
+			"size_id":       "x1",
+			"created_at":    createdAt,
+		}
+	}
+
+	tests := []struct {
+		name          string
+		counts        []map[string]interface{}
+		countsErr     bool
+		developers    []map[string]interface{}
+		developersErr bool
+		want          *FleetSummary
+		wantErr       bool
+	}{
+		{
+			name: "should summarize the kafkas of all the users",
+			counts: []map[string]interface{}{
+				countRow(constants2.KafkaRequestStatusReady, types.STANDARD, "x1", "aws", 3),
+				countRow(constants2.KafkaRequestStatusFailed, types.STANDARD, "x1", "gcp", 1),
+				// kafkas being deleted do not consume streaming units
+				countRow(constants2.KafkaRequestStatusDeprovision, types.STANDARD, "x1", "aws", 2),
+				countRow(constants2.KafkaRequestStatusReady, types.DEVELOPER, "x1", "aws", 2),
+			},
+			developers: []map[string]interface{}{
+				developerRow("expiring", now.Add(-developerLifespan+time.Hour)),
+				developerRow("expired", now.Add(-developerLifespan-time.Hour)),
+				developerRow("new", now.Add(-time.Hour)),
+			},
+			want: &FleetSummary{
+				Total: 8,
+				CountByStatus: map[string]int{
+					constants2.KafkaRequestStatusReady.String():       5,
+					constants2.KafkaRequestStatusFailed.String():      1,
+					constants2.KafkaRequestStatusDeprovision.String(): 2,
+				},
+				CountByInstanceType: map[string]int{
+					types.STANDARD.String():  6,
+					types.DEVELOPER.String(): 2,
+				},
+				CountByCloudProvider: map[string]int{
+					"aws": 7,
+					"gcp": 1,
+				},
+				StreamingUnits: 8,
+				NearingExpiry:  2,
+			},
+		},
+		{
+			name:       "should return an empty summary when there is no kafka",
+			counts:     []map[string]interface{}{},
+			developers: []map[string]interface{}{},
+			want: &FleetSummary{
+				CountByStatus:        map[string]int{},
+				CountByInstanceType:  map[string]int{},
+				CountByCloudProvider: map[string]int{},
+			},
+		},
+		{
+			name: "should return an error when the size of kafkas is not supported anymore",
+			counts: []map[string]interface{}{
+				countRow(constants2.KafkaRequestStatusReady, types.STANDARD, "x100", "aws", 1),
+			},
+			developers: []map[string]interface{}{},
+			wantErr:    true,
+		},
+		{
+			name:      "should return an error when the kafkas cannot be counted",
+			countsErr: true,
+			wantErr:   true,
+		},
+		{
+			name:          "should return an error when the kafkas with a lifespan cannot be found",
+			counts:        []map[string]interface{}{},
+			developersErr: true,
+			wantErr:       true,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			mocket.Catcher.Reset()
+			if !tt.countsErr {
+				mocket.Catcher.NewMock().
+					WithQuery(`count(1) AS count FROM "kafka_requests"`).
+					WithReply(tt.counts)
+			}
+			if !tt.developersErr {
+				mocket.Catcher.NewMock().
+					WithQuery(`FROM "kafka_requests" WHERE instance_type IN ($1) AND status NOT IN`).
+					WithReply(tt.developers)
+			}
+			mocket.Catcher.NewMock().WithExecException().WithQueryException()
+
+			k := &kafkaService{
+				connectionFactory: db.NewMockConnectionFactory(nil),
+				kafkaConfig:       &defaultKafkaConf,
+			}
+			got, err := k.GetFleetSummary()
+			if tt.wantErr {
+				g.Expect(err).ToNot(gomega.BeNil())
+				return
+			}
+			g.Expect(err).To(gomega.BeNil())
+			g.Expect(got).To(gomega.Equal(tt.want))
+		})
+	}
+}
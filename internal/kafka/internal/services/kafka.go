@@ -3,8 +3,9 @@ package services
 import (
 	"context"
 	"fmt"
+	"regexp"
+	"sort"
 	"strings"
-	"sync"
 
 	apiErrors "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/sso"
@@ -27,23 +28,39 @@ import (
 	"time"
 
 	"github.com/golang/glog"
+	amsv1 "github.com/openshift-online/ocm-sdk-go/accountsmgmt/v1"
 
 	managedkafka "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api/managedkafkas.managedkafka.bf2.org/v1"
 	v1 "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api/managedkafkas.managedkafka.bf2.org/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sValidation "k8s.io/apimachinery/pkg/util/validation"
 
 	"github.com/aws/aws-sdk-go/service/route53"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/auth"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/client/aws"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/client/keycloak"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/metrics"
 )
 
 var kafkaDeletionStatuses = []string{constants2.KafkaRequestStatusDeleting.String(), constants2.KafkaRequestStatusDeprovision.String()}
+
+// kafkaUpdateIgnoredStatuses are the statuses of the kafkas whose updates are ignored: the kafkas under deletion and the
+// kafkas pending deletion, which are left untouched so that they can be restored if their deletion is cancelled
+var kafkaUpdateIgnoredStatuses = append([]string{constants2.KafkaRequestStatusDeprovisionPending.String()}, kafkaDeletionStatuses...)
+
+// multiAZByInstanceType is the multi AZ mode mandated by each instance type
+var multiAZByInstanceType = map[types.KafkaInstanceType]bool{
+	types.STANDARD:  true,
+	types.DEVELOPER: false,
+}
+
 var kafkaManagedCRStatuses = []string{
 	constants2.KafkaRequestStatusProvisioning.String(),
+	constants2.KafkaRequestStatusDeprovisionPending.String(),
 	constants2.KafkaRequestStatusDeprovision.String(),
 	constants2.KafkaRequestStatusReady.String(),
 	constants2.KafkaRequestStatusFailed.String(),
@@ -56,13 +73,29 @@ type KafkaRoutesAction string
 
 const KafkaRoutesActionCreate KafkaRoutesAction = "CREATE"
 const KafkaRoutesActionDelete KafkaRoutesAction = "DELETE"
-const CanaryServiceAccountPrefix = "canary"
 
 type CNameRecordStatus struct {
 	Id     *string
 	Status *string
 }
 
+// CNAMEChangeResult is the result of the CNAME records change of a single kafka within a batched change
+type CNAMEChangeResult struct {
+	// Region is the Route53 region the change has been sent to. It is empty if it could not be determined
+	Region string
+	// ChangeId is the id of the Route53 change of the region batch. It is nil if the change failed
+	ChangeId *string
+	// ChangeStatus is the status of the Route53 change of the region batch. It is nil if the change failed
+	ChangeStatus *string
+	// Error is the reason why the change failed. It is nil if the change succeeded
+	Error *errors.ServiceError
+}
+
+// Succeeded returns true if the CNAME records of the kafka have been successfully changed
+func (r *CNAMEChangeResult) Succeeded() bool {
+	return r.Error == nil
+}
+
 //go:generate moq -out kafkaservice_moq.go . KafkaService
 type KafkaService interface {
 	// PrepareKafkaRequest sets any required information (i.e. bootstrap server host, sso client id and secret)
@@ -71,15 +104,105 @@ type KafkaService interface {
 	PrepareKafkaRequest(kafkaRequest *dbapi.KafkaRequest) *errors.ServiceError
 	// Get method will retrieve the kafkaRequest instance that the give ctx has access to from the database.
 	// This should be used when you want to make sure the result is filtered based on the request context.
+	// The kafka requests read by admins may be served from a cache, see KafkaConfig.KafkaRequestCacheTTL.
 	Get(ctx context.Context, id string) (*dbapi.KafkaRequest, *errors.ServiceError)
+	// GetByIdForContext is Get telling admins whether a kafka that is not theirs does not exist (not found error) or
+	// belongs to another user or organisation (forbidden error). Other callers get an opaque not found error in both cases,
+	// so that the existence of the kafkas of other users and organisations is not disclosed.
+	GetByIdForContext(ctx context.Context, id string) (*dbapi.KafkaRequest, *errors.ServiceError)
+	// GetWithFields is the same as Get but only loads the given columns of the kafka request, the other fields
+	// of the returned kafka request are left empty. An error is returned if any of the columns does not exist.
+	GetWithFields(ctx context.Context, id string, columns []string) (*dbapi.KafkaRequest, *errors.ServiceError)
+	// GetWithRoutes is the same as Get but also returns the decoded DNS routes of the kafka request. The routes are
+	// empty when they are not set or cannot be decoded.
+	GetWithRoutes(ctx context.Context, id string) (*KafkaWithRoutes, *errors.ServiceError)
+	// GetByName is the same as Get but looks the kafka request up by its name. If several kafka requests with the given
+	// name are accessible the oldest one is returned.
+	GetByName(ctx context.Context, name string) (*dbapi.KafkaRequest, *errors.ServiceError)
 	// GetById method will retrieve the KafkaRequest instance from the database without checking any permissions.
-	// You should only use this if you are sure permission check is not required.
+	// You should only use this if you are sure permission check is not required. It is never served from the cache,
+	// so it must be used by callers that need the latest state of the kafka request, e.g. to update it.
 	GetById(id string) (*dbapi.KafkaRequest, *errors.ServiceError)
+	// GetByIdIncludingDeleted is the same as GetById but also returns soft deleted kafka requests, e.g. for investigating
+	// a kafka after its deletion. This must only be made available to admins.
+	GetByIdIncludingDeleted(id string) (*dbapi.KafkaRequest, *errors.ServiceError)
+	// GetClusterForKafka returns the data plane cluster the given kafka is assigned to. A not found error is returned if
+	// the kafka does not exist, is not assigned to a cluster yet or if its cluster does not exist anymore.
+	// This must only be made available to admins.
+	GetClusterForKafka(id string) (*api.Cluster, *errors.ServiceError)
+	// GetByNamespace returns the kafka request provisioned in the given namespace of the given data plane cluster, e.g.
+	// for debugging the data plane, including when the namespace comes from the namespace pool and is not derived from
+	// the id of the kafka. A not found error is returned if no kafka is in the namespace.
+	// This must only be made available to admins.
+	GetByNamespace(clusterID string, namespace string) (*dbapi.KafkaRequest, *errors.ServiceError)
 	// Delete cleans up all dependencies for a Kafka request and soft deletes the Kafka Request record from the database.
 	// The Kafka Request in the database will be updated with a deleted_at timestamp.
 	Delete(*dbapi.KafkaRequest) *errors.ServiceError
+	// ForceDelete removes a Kafka that cannot be cleaned up by the data plane. The dependencies cleaned up by Delete (quota,
+	// canary service account, CNAME records) are removed on a best-effort basis, failures are logged and ignored.
+	// The Kafka Request record is hard deleted from the database, an audit record of the deletion made by the admin in the
+	// given ctx is stored in the same transaction.
+	// This must only be made available to admins.
+	ForceDelete(ctx context.Context, id string) *errors.ServiceError
 	List(ctx context.Context, listArgs *services.ListArguments) (dbapi.KafkaList, *api.PagingMeta, *errors.ServiceError)
+	// ListWithClusterDetails is the same as List but also returns the status and DNS of the cluster hosting each kafka request
+	// when includeClusterDetails is true
+	ListWithClusterDetails(ctx context.Context, listArgs *services.ListArguments, includeClusterDetails bool) ([]*KafkaWithClusterDetails, *api.PagingMeta, *errors.ServiceError)
+	// GetOwnerSummary returns the number of kafkas the user in the given ctx has access to, their number by status, the
+	// streaming units they consume and when the first of them expires, e.g. for the dashboard of the user
+	GetOwnerSummary(ctx context.Context) (*OwnerKafkaSummary, *errors.ServiceError)
+	// GetFleetSummary returns the number of kafkas of all the users, their number by status, instance type and cloud
+	// provider, the streaming units they consume and the number of them nearing expiry. This must only be made
+	// available to admins.
+	GetFleetSummary() (*FleetSummary, *errors.ServiceError)
+	// ListByRegion returns the kafka requests of all the users in the given cloud provider and region, applying the search,
+	// ordering and paging of the list arguments. This is meant for internal use (e.g. capacity planning) and must not be made
+	// available to end users.
+	ListByRegion(provider string, region string, listArgs *services.ListArguments) (dbapi.KafkaList, *api.PagingMeta, *errors.ServiceError)
+	// ListByInstanceType returns the kafka requests of all the users with the given instance type, applying the search,
+	// ordering and paging of the list arguments. This is meant for internal use (e.g. migrating the kafkas off a deprecated
+	// instance type) and must not be made available to end users.
+	ListByInstanceType(instanceType types.KafkaInstanceType, listArgs *services.ListArguments) (dbapi.KafkaList, *api.PagingMeta, *errors.ServiceError)
+	// ListByQuotaType returns the kafka requests of all the users created with the given quota type (e.g. "ams" or
+	// "quota-management-list"), applying the search, ordering and paging of the list arguments. This is meant for internal
+	// use (e.g. planning a migration to another quota type) and must not be made available to end users.
+	ListByQuotaType(quotaType string, listArgs *services.ListArguments) (dbapi.KafkaList, *api.PagingMeta, *errors.ServiceError)
+	// ListReauthDisabled returns the kafka requests of all the users with reauthentication disabled, applying the search,
+	// ordering and paging of the list arguments. This is meant for internal use (e.g. security audits) and must not be
+	// made available to end users.
+	ListReauthDisabled(listArgs *services.ListArguments) (dbapi.KafkaList, *api.PagingMeta, *errors.ServiceError)
+	// ListByCreatedRange returns the kafka requests of all the users created between from and to (both included), applying
+	// the search, ordering and paging of the list arguments. This is meant for internal use (e.g. reporting) and must not
+	// be made available to end users.
+	ListByCreatedRange(from time.Time, to time.Time, listArgs *services.ListArguments) (dbapi.KafkaList, *api.PagingMeta, *errors.ServiceError)
+	// ListByDesiredStrimziVersion returns the kafka requests of all the users whose desired strimzi version is the given
+	// version, applying the search, ordering and paging of the list arguments. This is meant for internal use (e.g.
+	// upgrading the kafkas in waves) and must not be made available to end users.
+	ListByDesiredStrimziVersion(version string, listArgs *services.ListArguments) (dbapi.KafkaList, *api.PagingMeta, *errors.ServiceError)
+	// ListByBillingCloudAccountId returns the kafka requests of all the users billed to the given marketplace cloud
+	// account, applying the search, ordering and paging of the list arguments. This is meant for internal use (e.g.
+	// reconciling the marketplace billing) and must not be made available to end users.
+	ListByBillingCloudAccountId(accountId string, listArgs *services.ListArguments) (dbapi.KafkaList, *api.PagingMeta, *errors.ServiceError)
+	// BumpStrimziVersion sets the desired strimzi version of the given kafkas to the target version in a single
+	// transaction, their eligibility being checked beforehand. The kafkas whose cluster does not have the target version ready with their desired kafka and IBP
+	// versions, the kafkas outside of their maintenance window, as well as the kafkas under or pending deletion, are
+	// skipped. The returned value is the number of bumped
+	// kafkas. This must only be made available to admins.
+	BumpStrimziVersion(ids []string, targetVersion string) (int64, *errors.ServiceError)
 	GetManagedKafkaByClusterID(clusterID string) ([]managedkafka.ManagedKafka, *errors.ServiceError)
+	// GetManagedKafkaByClusterIDChangedSince is the same as GetManagedKafkaByClusterID but only returns the managed kafkas
+	// of the kafka requests updated after the given time, so that the data plane doesn't have to rebuild the unchanged ones.
+	// The kafka requests deleted after the given time are returned as well, marked as deleted
+	GetManagedKafkaByClusterIDChangedSince(clusterID string, since time.Time) ([]managedkafka.ManagedKafka, *errors.ServiceError)
+	// ExportManagedKafkaCRsYAML returns the ManagedKafka CRs returned by GetManagedKafkaByClusterID as a multi-document
+	// YAML, e.g. to debug a data plane cluster. The document is empty when there is no kafka on the cluster. The CRs may
+	// contain credentials, this must only be made available to admins.
+	ExportManagedKafkaCRsYAML(clusterID string) ([]byte, *errors.ServiceError)
+	// PauseReconciliation annotates the ManagedKafka CR of the kafka with the given id sent to the data plane, so that
+	// the agent stops reconciling it without it being deprovisioned. This must only be made available to admins.
+	PauseReconciliation(id string) *errors.ServiceError
+	// ResumeReconciliation removes the pause annotation from the ManagedKafka CR of the kafka with the given id
+	ResumeReconciliation(id string) *errors.ServiceError
 	// GenerateReservedManagedKafkasByClusterID returns a list of reserved managed
 	// kafkas for a given clusterID. The number of generated reserved managed
 	// kafkas in the cluster is the sum of the specified number of reserved
@@ -90,35 +213,189 @@ type KafkaService interface {
 	// 1..<num_reserved_instances>_for_the_given_instance_type>
 	// Each generated reserved kafka has a namespace equal to its name
 	GenerateReservedManagedKafkasByClusterID(clusterID string) ([]managedkafka.ManagedKafka, *errors.ServiceError)
+	// GetStreamingUnitUsageByClusterID returns, for each instance type supported by the given cluster, the streaming units
+	// held by the reserved kafkas generated for the cluster alongside the streaming units consumed by its real kafkas
+	GetStreamingUnitUsageByClusterID(clusterID string) ([]StreamingUnitUsage, *errors.ServiceError)
 	RegisterKafkaJob(kafkaRequest *dbapi.KafkaRequest) *errors.ServiceError
+	// ValidateCreateRequest runs the stateless validations of a kafka creation request: the name format, the instance
+	// type and size, the cloud provider and region, whether the region is in maintenance and the coherence of the multi
+	// AZ attribute with the instance type and region. The first failing validation is returned.
+	ValidateCreateRequest(kafkaRequest *dbapi.KafkaRequest) *errors.ServiceError
+	// RecomputeRegionCapacityUsage computes the capacity consumed by the kafkas of each instance type in each region and
+	// materializes it for the region capacity checks
+	RecomputeRegionCapacityUsage() *errors.ServiceError
+	// GetRegionStatus returns whether the given region of the given cloud provider is open for new kafkas of the given
+	// instance type, considering its maintenance mode and its remaining capacity, and why it is closed otherwise
+	GetRegionStatus(provider, region, instanceType string) (*RegionStatus, *errors.ServiceError)
+	// GetCapacityReport returns the capacity consumed, the limit, the remaining capacity and the status of each supported
+	// region for each of its instance types, aggregating the kafkas once
+	GetCapacityReport() ([]RegionCapacityReport, *errors.ServiceError)
+	// GetProvisioningDuration returns the p50, p95 and p99 of the time taken by the kafkas provisioned between from and to
+	// (both included) to become ready after being created, for each instance type, cloud provider and region having such
+	// kafkas
+	GetProvisioningDuration(from time.Time, to time.Time) ([]ProvisioningDurationPercentiles, *errors.ServiceError)
+	// ExportCostAllocation returns a cost allocation record for each kafka, deleted or not, that existed between from and
+	// to (both included), with the streaming units it is charged for and the time it existed within the window. The
+	// record of a kafka whose size cannot be resolved is reported with 0 streaming units and the reason in its Error
+	ExportCostAllocation(from time.Time, to time.Time) ([]CostAllocationRecord, *errors.ServiceError)
+	// RegisterKafkaJobWithDeferredQuota registers a new kafka in 'pending_quota' status without reserving quota.
+	// ConfirmQuota must then be called to reserve the quota and accept the kafka, or AbortPendingQuota to discard it.
+	// Pending kafkas that are neither confirmed nor aborted are deleted by DeleteExpiredPendingQuotaKafkas.
+	RegisterKafkaJobWithDeferredQuota(kafkaRequest *dbapi.KafkaRequest) *errors.ServiceError
+	// ConfirmQuota reserves the quota of a kafka in 'pending_quota' status and moves it to 'accepted'
+	ConfirmQuota(id string) *errors.ServiceError
+	// AbortPendingQuota hard deletes a kafka in 'pending_quota' status
+	AbortPendingQuota(id string) *errors.ServiceError
+	// DeleteExpiredPendingQuotaKafkas hard deletes the kafkas that have been in 'pending_quota' status for longer than
+	// constants.PendingQuotaKafkaMaxDuration. The returned value is the number of deleted kafkas.
+	DeleteExpiredPendingQuotaKafkas() (int64, *errors.ServiceError)
+	// BackfillQuotaType sets the quota type of the kafkas without one (created before the quota type was persisted) to the
+	// configured quota type, so that the right quota service is used when they are deleted. Soft deleted kafkas are
+	// left untouched. The returned value is the number of updated kafkas.
+	BackfillQuotaType() (int64, *errors.ServiceError)
 	ListByStatus(status ...constants2.KafkaStatus) ([]*dbapi.KafkaRequest, *errors.ServiceError)
+	// StreamAll calls fn for every kafka request, loading them from the database in batches of the given size so that
+	// the whole fleet is never held in memory. Iteration stops on the first error returned by fn or when ctx is done.
+	// This must only be made available to internal components and admins as kafkas are not filtered by owner.
+	StreamAll(ctx context.Context, batchSize int, fn func(*dbapi.KafkaRequest) error) *errors.ServiceError
 	// UpdateStatus change the status of the Kafka cluster
 	// The returned boolean is to be used to know if the update has been tried or not. An update is not tried if the
 	// original status is 'deprovision' (cluster in deprovision state can't be change state) or if the final status is the
 	// same as the original status. The error will contain any error encountered when attempting to update or the reason
-	// why no attempt has been done
+	// why no attempt has been done. The status since created metric is updated only when the status is changed
 	UpdateStatus(id string, status constants2.KafkaStatus) (bool, *errors.ServiceError)
+	// GetDeprovisionReason returns why the kafka with the given id has been deprovisioned, it is empty when the kafka
+	// has not been deprovisioned
+	GetDeprovisionReason(id string) (constants2.KafkaDeprovisionReason, *errors.ServiceError)
+	// GetBillingModel returns how the kafka with the given id that the given ctx has access to is billed
+	GetBillingModel(ctx context.Context, id string) (*KafkaBillingModel, *errors.ServiceError)
 	Update(kafkaRequest *dbapi.KafkaRequest) *errors.ServiceError
 	// Updates() updates the given fields of a kafka. This takes in a map so that even zero-fields can be updated.
 	// Use this only when you want to update the multiple columns that may contain zero-fields, otherwise use the `KafkaService.Update()` method.
 	// See https://gorm.io/docs/update.html#Updates-multiple-columns for more info
 	Updates(kafkaRequest *dbapi.KafkaRequest, values map[string]interface{}) *errors.ServiceError
+	// SetReauthenticationBulk enables or disables the reauthentication of the given kafkas in a single update, ignoring the
+	// kafkas under deletion. The updated_at timestamp of the kafkas is bumped so that their ManagedKafka CR is regenerated.
+	// The returned value is the number of updated kafkas.
+	SetReauthenticationBulk(ids []string, enabled bool) (int64, *errors.ServiceError)
+	// ValidateRoutes checks that every route has a valid DNS domain and a router, so that they can be used to change
+	// CNAME records. The returned validation error lists all the invalid routes.
+	ValidateRoutes(routes []dbapi.DataPlaneKafkaRoute) *errors.ServiceError
 	ChangeKafkaCNAMErecords(kafkaRequest *dbapi.KafkaRequest, action KafkaRoutesAction) (*route53.ChangeResourceRecordSetsOutput, *errors.ServiceError)
+	// ChangeKafkaCNAMErecordsBatch applies the given action to the CNAME records of all the given kafkas, sending a single
+	// change batch per Route53 region. The returned map contains the result of the change for each kafka, keyed by kafka id.
+	// As a Route53 change batch is applied atomically, all the kafkas within a failed region batch are reported as failed.
+	ChangeKafkaCNAMErecordsBatch(kafkaRequests []*dbapi.KafkaRequest, action KafkaRoutesAction) map[string]*CNAMEChangeResult
 	GetCNAMERecordStatus(kafkaRequest *dbapi.KafkaRequest) (*CNameRecordStatus, error)
+	// RecreateRoutes re-creates the CNAME records of the stored routes of the given kafka and updates its routes creation id.
+	// It can be used to repair the DNS of a kafka whose records have been removed. Only kafkas in a steady state
+	// (i.e. ready or suspended) can have their routes recreated.
+	RecreateRoutes(id string) *errors.ServiceError
 	AssignInstanceType(owner string, organisationID string) (types.KafkaInstanceType, *errors.ServiceError)
+	// GetAllowedInstanceTypes returns the supported instance types the user in the given ctx can create a kafka of, given
+	// their quota, e.g. to only offer those in the creation form. The developer instance type is only returned when
+	// developer instances are allowed and the user does not already own the maximum number of them.
+	GetAllowedInstanceTypes(ctx context.Context) ([]types.KafkaInstanceType, *errors.ServiceError)
+	// RegisterKafkaDeprovisionJob deprovisions a kafka. When a deprovision grace period is configured, the kafka is first
+	// put in 'deprovision_pending' status, in which its deletion can be cancelled with CancelDeprovision, and is
+	// deprovisioned by PromoteExpiredDeprovisionPendingKafkas once the period is over or by a second deprovision request
 	RegisterKafkaDeprovisionJob(ctx context.Context, id string) *errors.ServiceError
+	// CancelDeprovision restores a kafka in 'deprovision_pending' status to the status it had before its deprovision
+	CancelDeprovision(ctx context.Context, id string) *errors.ServiceError
+	// PromoteExpiredDeprovisionPendingKafkas deprovisions the kafkas that have been in 'deprovision_pending' status for
+	// longer than the deprovision grace period. The returned value is the number of deprovisioned kafkas.
+	PromoteExpiredDeprovisionPendingKafkas() (int64, *errors.ServiceError)
 	// DeprovisionKafkaForUsers registers all kafkas for deprovisioning given the list of owners
 	DeprovisionKafkaForUsers(users []string) *errors.ServiceError
 	DeprovisionExpiredKafkas() *errors.ServiceError
 	CountByStatus(status []constants2.KafkaStatus) ([]KafkaStatusCount, error)
+	// CountByClusterAndStatus returns the number of kafkas in each status on the given cluster.
+	// Statuses without any kafka on the cluster are not included in the returned map.
+	CountByClusterAndStatus(clusterID string) (map[constants2.KafkaStatus]int, error)
+	// CountByMultiAZ returns the number of multi AZ (true) and single AZ (false) kafkas, in the given region only unless
+	// the region is empty. Both modes are always included in the returned map.
+	CountByMultiAZ(region string) (map[bool]int, error)
 	ListKafkasWithRoutesNotCreated() ([]*dbapi.KafkaRequest, *errors.ServiceError)
+	// ListDuplicateBootstrapHosts returns the bootstrap server hosts that are shared by more than one kafka request
+	// together with the ids of the kafka requests using each of them
+	ListDuplicateBootstrapHosts() (map[string][]string, *errors.ServiceError)
 	VerifyAndUpdateKafkaAdmin(ctx context.Context, kafkaRequest *dbapi.KafkaRequest) *errors.ServiceError
 	ListComponentVersions() ([]KafkaComponentVersions, error)
+	// ListStuckUpgrades returns the component versions of the kafkas that have been upgrading (strimzi, kafka or kafka ibp)
+	// for longer than the given duration
+	ListStuckUpgrades(olderThan time.Duration) ([]KafkaComponentVersions, error)
+	// SetMaintenanceWindow sets the weekly maintenance window of the given kafka. Upgrades of a kafka with a maintenance
+	// window can only be started within the window. day is a lowercase day of the week and start and end are UTC times in
+	// HH:MM format. The maintenance window is removed when all of them are empty.
+	SetMaintenanceWindow(id string, day string, start string, end string) *errors.ServiceError
+	// SetAnnotations replaces the custom annotations of the given kafka, which are added to its ManagedKafka CR.
+	// Annotations with the reserved bf2.org/ prefix are rejected. All the custom annotations are removed when empty.
+	SetAnnotations(id string, annotations map[string]string) *errors.ServiceError
+	// SetMaxConnectionAttemptsPerSecOverride overrides the maximum connection attempts per second of the given kafka, e.g. to
+	// throttle a noisy kafka without changing its size. The override is capped to the limit of the size of the kafka and
+	// removed when nil.
+	SetMaxConnectionAttemptsPerSecOverride(id string, override *int) *errors.ServiceError
+	// SetCapacityConsumedOverride overrides the capacity consumed by the given kafka, e.g. for a grandfathered plan consuming
+	// less capacity than its size. The override is used by the capacity accounting and the streaming unit counts and is
+	// removed when nil.
+	SetCapacityConsumedOverride(id string, override *int) *errors.ServiceError
+	// CancelUpgrade reverts the desired strimzi, kafka and kafka ibp versions of the given kafka to its actual versions and
+	// clears its upgrading flags, so that a stuck upgrade is abandoned by the data plane.
+	// This must only be made available to admins.
+	CancelUpgrade(id string) *errors.ServiceError
+	// RetryFailed moves the given failed kafka back to the accepted status so that the workers provision it again, reusing
+	// its record and its quota. It is refused when the region has no capacity left for the kafka or when no ready cluster
+	// can host it.
+	RetryFailed(id string) *errors.ServiceError
+	// SetKafkaStorageSize updates the storage size of the given kafka. The requested size cannot be smaller than the current
+	// storage size of the kafka nor than the min data retention size of the kafka instance size, and cannot be greater
+	// than the max data retention size of the kafka instance size.
+	// This must only be made available to admins.
+	SetKafkaStorageSize(id string, size string) *errors.ServiceError
+	// GetQuotaCost returns the quota consumed by a kafka of the given instance type and size.
+	// An error is returned if the instance type or the size are not supported.
+	GetQuotaCost(instanceType types.KafkaInstanceType, sizeId string) (int, *errors.ServiceError)
 	HasAvailableCapacityInRegion(kafkaRequest *dbapi.KafkaRequest) (bool, *errors.ServiceError)
 	// GetAvailableSizesInRegion returns a list of ids of the Kafka instance sizes that can still be created according to the specified criteria
 	GetAvailableSizesInRegion(criteria *FindClusterCriteria) ([]string, *errors.ServiceError)
 	ValidateBillingAccount(externalId string, instanceType types.KafkaInstanceType, billingCloudAccountId string, marketplace *string) *errors.ServiceError
+	// InvalidateBillingAccounts discards the cached billing accounts of the organisation with the given external id
+	InvalidateBillingAccounts(externalId string) *errors.ServiceError
 	AssignBootstrapServerHost(kafkaRequest *dbapi.KafkaRequest) error
+	// RepairMissingNamespaces sets the namespace to kafka-<id> for all the non deleted kafka requests that do not have one.
+	// The returned value is the number of kafka requests that have been repaired.
+	RepairMissingNamespaces() (int64, *errors.ServiceError)
+	// RepairMultiAZ sets the multi AZ mode mandated by their instance type on the kafka requests that are not being
+	// deleted and have another one, e.g. legacy kafka requests. The returned value is the number of kafka requests that
+	// have been repaired.
+	RepairMultiAZ() (int64, *errors.ServiceError)
+	// ListStuckDeprovisioning returns the kafka requests in 'deprovision' or 'deleting' status whose status
+	// has not changed for longer than the given duration
+	ListStuckDeprovisioning(olderThan time.Duration) ([]*dbapi.KafkaRequest, *errors.ServiceError)
+	// FailStaleProvisioningKafkas marks as failed the kafka requests in 'accepted', 'preparing' or 'provisioning' status
+	// that have been created for longer than the provisioning timeout of their instance type. The kafka requests of the
+	// instance types without a provisioning timeout are never failed. The ids of the kafka requests failed are returned,
+	// along with the error if failing one of them fails.
+	FailStaleProvisioningKafkas() ([]string, *errors.ServiceError)
+	// ListKafkasMissingCanaryAccount returns the kafka requests that have been prepared but do not have a canary service
+	// account, e.g. because they were created while the authentication on the kafkas was disabled. Nothing is returned
+	// when the authentication on the kafkas is disabled.
+	ListKafkasMissingCanaryAccount() ([]*dbapi.KafkaRequest, *errors.ServiceError)
+	// RepairCanaryAccounts creates the missing canary service accounts of the kafka requests returned by
+	// ListKafkasMissingCanaryAccount and records them. The ids of the kafka requests repaired, or that would be repaired
+	// when dryRun is true, are returned. The ids repaired so far are returned along with the error if a repair fails.
+	RepairCanaryAccounts(dryRun bool) ([]string, *errors.ServiceError)
+	// ListOrphanedCanaryAccounts returns the canary service accounts in the SSO whose kafka does not exist anymore,
+	// e.g. because the deletion of the kafka did not reach the SSO. Nothing is returned when the authentication on the
+	// kafkas is disabled.
+	ListOrphanedCanaryAccounts() ([]OrphanedCanaryAccount, *errors.ServiceError)
+	// CleanupOrphanedCanaryAccounts deletes the canary service accounts returned by ListOrphanedCanaryAccounts. The
+	// client ids of the service accounts deleted, or that would be deleted when dryRun is true, are returned. The client
+	// ids deleted so far are returned along with the error if a deletion fails.
+	CleanupOrphanedCanaryAccounts(dryRun bool) ([]string, *errors.ServiceError)
+	// ExplainPlacement runs the cluster placement checks against every cluster in the given provider and region and
+	// returns, for each of them, the reasons why a kafka matching the criteria can or cannot be placed on it.
+	ExplainPlacement(criteria *FindClusterCriteria) (*PlacementExplanation, *errors.ServiceError)
 }
 
 var _ KafkaService = &kafkaService{}
@@ -130,15 +407,22 @@ type kafkaService struct {
 	kafkaConfig              *config.KafkaConfig
 	awsConfig                *config.AWSConfig
 	quotaServiceFactory      QuotaServiceFactory
-	mu                       sync.Mutex
 	awsClientFactory         aws.ClientFactory
 	authService              authorization.Authorization
 	dataplaneClusterConfig   *config.DataplaneClusterConfig
 	providerConfig           *config.ProviderConfig
 	clusterPlacementStrategy ClusterPlacementStrategy
+	streamingUnitCountCache  *StreamingUnitCountCache
+	lifecycleEventSink       KafkaLifecycleEventSink
+	kafkaRequestCache        *KafkaRequestCache
+	namespaceAllocator       KafkaNamespaceAllocator
+	clusterDNSCache          *ClusterDNSCache
+
+	// awsClients reuses the route53 clients across calls, see getRoute53Client
+	awsClients awsClientPool
 }
 
-func NewKafkaService(connectionFactory *db.ConnectionFactory, clusterService ClusterService, keycloakService sso.KafkaKeycloakService, kafkaConfig *config.KafkaConfig, dataplaneClusterConfig *config.DataplaneClusterConfig, awsConfig *config.AWSConfig, quotaServiceFactory QuotaServiceFactory, awsClientFactory aws.ClientFactory, authorizationService authorization.Authorization, providerConfig *config.ProviderConfig, clusterPlacementStrategy ClusterPlacementStrategy) *kafkaService {
+func NewKafkaService(connectionFactory *db.ConnectionFactory, clusterService ClusterService, keycloakService sso.KafkaKeycloakService, kafkaConfig *config.KafkaConfig, dataplaneClusterConfig *config.DataplaneClusterConfig, awsConfig *config.AWSConfig, quotaServiceFactory QuotaServiceFactory, awsClientFactory aws.ClientFactory, authorizationService authorization.Authorization, providerConfig *config.ProviderConfig, clusterPlacementStrategy ClusterPlacementStrategy, streamingUnitCountCache *StreamingUnitCountCache, lifecycleEventSink KafkaLifecycleEventSink, namespaceAllocator KafkaNamespaceAllocator, clusterDNSCache *ClusterDNSCache) *kafkaService {
 	return &kafkaService{
 		connectionFactory:        connectionFactory,
 		clusterService:           clusterService,
@@ -151,6 +435,11 @@ func NewKafkaService(connectionFactory *db.ConnectionFactory, clusterService Clu
 		dataplaneClusterConfig:   dataplaneClusterConfig,
 		providerConfig:           providerConfig,
 		clusterPlacementStrategy: clusterPlacementStrategy,
+		streamingUnitCountCache:  streamingUnitCountCache,
+		kafkaRequestCache:        NewKafkaRequestCache(kafkaConfig),
+		lifecycleEventSink:       lifecycleEventSink,
+		namespaceAllocator:       namespaceAllocator,
+		clusterDNSCache:          clusterDNSCache,
 	}
 }
 
@@ -163,7 +452,23 @@ func (k *kafkaService) ValidateBillingAccount(externalId string, instanceType ty
 	return quotaService.ValidateBillingAccount(externalId, instanceType, billingCloudAccountId, marketplace)
 }
 
+func (k *kafkaService) InvalidateBillingAccounts(externalId string) *errors.ServiceError {
+	quotaService, factoryErr := k.quotaServiceFactory.GetQuotaService(api.QuotaType(k.kafkaConfig.Quota.Type))
+	if factoryErr != nil {
+		return errors.NewWithCause(errors.ErrorGeneral, factoryErr, "unable to invalidate the cached billing accounts")
+	}
+
+	quotaService.InvalidateBillingAccounts(externalId)
+	return nil
+}
+
 func (k *kafkaService) HasAvailableCapacityInRegion(kafkaRequest *dbapi.KafkaRequest) (bool, *errors.ServiceError) {
+	return k.hasAvailableCapacityInRegion(kafkaRequest, false)
+}
+
+// hasAvailableCapacityInRegion is the same as HasAvailableCapacityInRegion. capacityAlreadyConsumed tells whether the
+// given kafka request is already persisted, and therefore already counted in the capacity consumed in its region
+func (k *kafkaService) hasAvailableCapacityInRegion(kafkaRequest *dbapi.KafkaRequest, capacityAlreadyConsumed bool) (bool, *errors.ServiceError) {
 	// get region limit for instance type
 	regInstTypeLimit, e := k.providerConfig.GetInstanceLimit(kafkaRequest.Region, kafkaRequest.CloudProvider, kafkaRequest.InstanceType)
 	if e != nil {
@@ -179,15 +484,44 @@ func (k *kafkaService) HasAvailableCapacityInRegion(kafkaRequest *dbapi.KafkaReq
 		return true, nil
 	}
 	// check capacity
-	return k.capacityAvailableForRegionAndInstanceType(regInstTypeLimit, kafkaRequest)
+	return k.capacityAvailableForRegionAndInstanceType(regInstTypeLimit, kafkaRequest, capacityAlreadyConsumed)
 }
 
-func (k *kafkaService) capacityAvailableForRegionAndInstanceType(instTypeRegCapacity *int, kafkaRequest *dbapi.KafkaRequest) (bool, *errors.ServiceError) {
+func (k *kafkaService) capacityAvailableForRegionAndInstanceType(instTypeRegCapacity *int, kafkaRequest *dbapi.KafkaRequest, capacityAlreadyConsumed bool) (bool, *errors.ServiceError) {
 	errMessage := fmt.Sprintf("Failed to check kafka capacity for region '%s' and instance type '%s'", kafkaRequest.Region, kafkaRequest.InstanceType)
 
-	dbConn := k.connectionFactory.New()
+	count, svcErr := k.capacityConsumedInRegion(kafkaRequest)
+	if svcErr != nil {
+		return false, svcErr
+	}
 
-	var count int64
+	kafkaInstanceSize, e := k.kafkaConfig.GetKafkaInstanceSize(kafkaRequest.InstanceType, kafkaRequest.SizeId)
+	if e != nil {
+		return false, errors.NewWithCause(errors.ErrorInstancePlanNotSupported, e, errMessage)
+	}
+
+	if !capacityAlreadyConsumed {
+		count += int64(getCapacityConsumed(kafkaRequest, kafkaInstanceSize))
+	}
+
+	return instTypeRegCapacity == nil || count <= int64(*instTypeRegCapacity), nil
+}
+
+// capacityConsumedInRegion returns the capacity consumed by the kafkas of the instance type of the given kafka request in
+// its region. The given kafka request is counted when it has already been persisted, callers checking a new request
+// have to add its capacity themselves
+func (k *kafkaService) capacityConsumedInRegion(kafkaRequest *dbapi.KafkaRequest) (int64, *errors.ServiceError) {
+	errMessage := fmt.Sprintf("Failed to check kafka capacity for region '%s' and instance type '%s'", kafkaRequest.Region, kafkaRequest.InstanceType)
+
+	count, materialized, svcErr := k.findMaterializedRegionCapacityConsumed(kafkaRequest)
+	if svcErr != nil {
+		return 0, svcErr
+	}
+	if materialized {
+		return count, nil
+	}
+
+	dbConn := k.connectionFactory.New()
 
 	var kafkas []*dbapi.KafkaRequest
 
@@ -196,25 +530,149 @@ func (k *kafkaService) capacityAvailableForRegionAndInstanceType(instTypeRegCapa
 		Where("cloud_provider = ?", kafkaRequest.CloudProvider).
 		Where("instance_type = ?", kafkaRequest.InstanceType).
 		Scan(&kafkas).Error; err != nil {
-		return false, errors.NewWithCause(errors.ErrorGeneral, err, errMessage)
+		return 0, errors.NewWithCause(errors.ErrorGeneral, err, errMessage)
 	}
 
 	for _, kafka := range kafkas {
 		kafkaInstanceSize, e := k.kafkaConfig.GetKafkaInstanceSize(kafka.InstanceType, kafka.SizeId)
 		if e != nil {
-			return false, errors.NewWithCause(errors.ErrorInstancePlanNotSupported, e, errMessage)
+			return 0, errors.NewWithCause(errors.ErrorInstancePlanNotSupported, e, errMessage)
 		}
-		count += int64(kafkaInstanceSize.CapacityConsumed)
+		count += int64(getCapacityConsumed(kafka, kafkaInstanceSize))
 	}
 
-	kafkaInstanceSize, e := k.kafkaConfig.GetKafkaInstanceSize(kafkaRequest.InstanceType, kafkaRequest.SizeId)
-	if e != nil {
-		return false, errors.NewWithCause(errors.ErrorInstancePlanNotSupported, e, errMessage)
+	return count, nil
+}
+
+// RegionStatusReason is why a region is closed for new kafkas
+type RegionStatusReason string
+
+const (
+	// RegionStatusReasonDisabled is set when the instance type is not supported in the region or its limit is zero
+	RegionStatusReasonDisabled RegionStatusReason = "disabled"
+	// RegionStatusReasonMaintenance is set when the region is in maintenance
+	RegionStatusReasonMaintenance RegionStatusReason = "maintenance"
+	// RegionStatusReasonFull is set when the region has not enough remaining capacity for a kafka of the instance type
+	RegionStatusReasonFull RegionStatusReason = "full"
+)
+
+// RegionStatus tells whether a region is open for new kafkas of an instance type
+type RegionStatus struct {
+	Open bool
+	// Reason is why the region is closed, it is empty when the region is open
+	Reason RegionStatusReason
+	// RemainingCapacity is the capacity, in streaming units, left in the region for the instance type. It is nil when
+	// the capacity of the region is not limited
+	RemainingCapacity *int
+}
+
+func (k *kafkaService) GetRegionStatus(provider, region, instanceType string) (*RegionStatus, *errors.ServiceError) {
+	supportedProvider, ok := k.providerConfig.ProvidersConfig.SupportedProviders.GetByName(provider)
+	if !ok {
+		return nil, errors.ProviderNotSupported("provider '%s' is not supported", provider)
+	}
+	supportedRegion, ok := supportedProvider.Regions.GetByName(region)
+	if !ok {
+		return nil, errors.RegionNotSupported("region '%s' is not supported for provider '%s'", region, provider)
+	}
+
+	// the consumed capacity only matters for the enabled instance types with a limit
+	var consumed int64
+	if limit := supportedRegion.SupportedInstanceTypes[instanceType].Limit; limit != nil && *limit > 0 {
+		var err *errors.ServiceError
+		consumed, err = k.capacityConsumedInRegion(&dbapi.KafkaRequest{CloudProvider: provider, Region: region, InstanceType: instanceType})
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	count += int64(kafkaInstanceSize.CapacityConsumed)
+	return k.regionStatus(supportedRegion, instanceType, int(consumed))
+}
 
-	return instTypeRegCapacity == nil || count <= int64(*instTypeRegCapacity), nil
+// regionStatus returns the status of the region for the instance type given the capacity consumed in it
+func (k *kafkaService) regionStatus(region config.Region, instanceType string, consumed int) (*RegionStatus, *errors.ServiceError) {
+	instanceTypeConfig, ok := region.SupportedInstanceTypes[instanceType]
+	if !ok || (instanceTypeConfig.Limit != nil && *instanceTypeConfig.Limit == 0) {
+		zero := 0
+		return &RegionStatus{Reason: RegionStatusReasonDisabled, RemainingCapacity: &zero}, nil
+	}
+
+	status := &RegionStatus{Open: true}
+	if instanceTypeConfig.Limit != nil {
+		remainingCapacity := *instanceTypeConfig.Limit - consumed
+		if remainingCapacity < 0 {
+			remainingCapacity = 0
+		}
+		status.RemainingCapacity = &remainingCapacity
+	}
+
+	if region.MaintenanceMode {
+		status.Open = false
+		status.Reason = RegionStatusReasonMaintenance
+		return status, nil
+	}
+
+	if status.RemainingCapacity != nil {
+		// the region is full when the smallest kafka of the instance type does not fit in it anymore
+		smallestSize, err := k.kafkaConfig.GetFirstAvailableSize(instanceType)
+		if err != nil {
+			return nil, errors.NewWithCause(errors.ErrorInstanceTypeNotSupported, err, "failed to get the status of region '%s'", region.Name)
+		}
+		if *status.RemainingCapacity < smallestSize.CapacityConsumed {
+			status.Open = false
+			status.Reason = RegionStatusReasonFull
+		}
+	}
+
+	return status, nil
+}
+
+// RegionCapacityReport is the capacity of a region for an instance type
+type RegionCapacityReport struct {
+	CloudProvider string
+	Region        string
+	InstanceType  string
+	// CapacityConsumed is the capacity, in streaming units, consumed by the kafkas of the instance type in the region
+	CapacityConsumed int
+	// Limit is the capacity of the region for the instance type, it is nil when the capacity is not limited
+	Limit *int
+	RegionStatus
+}
+
+func (k *kafkaService) GetCapacityReport() ([]RegionCapacityReport, *errors.ServiceError) {
+	usages, err := k.computeRegionCapacityUsage()
+	if err != nil {
+		return nil, err
+	}
+
+	var reports []RegionCapacityReport
+	for _, provider := range k.providerConfig.ProvidersConfig.SupportedProviders {
+		for _, region := range provider.Regions {
+			instanceTypes := make([]string, 0, len(region.SupportedInstanceTypes))
+			for instanceType := range region.SupportedInstanceTypes {
+				instanceTypes = append(instanceTypes, instanceType)
+			}
+			sort.Strings(instanceTypes)
+
+			for _, instanceType := range instanceTypes {
+				consumed := usages[regionInstanceType{cloudProvider: provider.Name, region: region.Name, instanceType: instanceType}]
+				status, err := k.regionStatus(region, instanceType, consumed)
+				if err != nil {
+					return nil, err
+				}
+				reports = append(reports, RegionCapacityReport{
+					CloudProvider:    provider.Name,
+					Region:           region.Name,
+					InstanceType:     instanceType,
+					CapacityConsumed: consumed,
+					Limit:            region.SupportedInstanceTypes[instanceType].Limit,
+					RegionStatus:     *status,
+				})
+			}
+		}
+	}
+
+	return reports, nil
 }
 
 func (k *kafkaService) GetAvailableSizesInRegion(criteria *FindClusterCriteria) ([]string, *errors.ServiceError) {
@@ -279,6 +737,146 @@ func (k *kafkaService) GetAvailableSizesInRegion(criteria *FindClusterCriteria)
 	return availableSizes, nil
 }
 
+// PlacementExplanation describes the outcome of the cluster placement checks for a given criteria
+type PlacementExplanation struct {
+	Criteria FindClusterCriteria
+	Clusters []ClusterPlacementExplanation
+}
+
+// ClusterPlacementExplanation holds the placement outcome for a single candidate cluster.
+// Reasons is empty when the cluster can accept the kafka
+type ClusterPlacementExplanation struct {
+	ClusterID string
+	Eligible  bool
+	Reasons   []string
+}
+
+// HasEligibleCluster returns true if at least one of the candidate clusters can accept the kafka
+func (p *PlacementExplanation) HasEligibleCluster() bool {
+	for _, c := range p.Clusters {
+		if c.Eligible {
+			return true
+		}
+	}
+	return false
+}
+
+// String returns a human readable summary of the placement explanation e.g. "cluster X: instance type not supported; cluster Y: full"
+func (p *PlacementExplanation) String() string {
+	if len(p.Clusters) == 0 {
+		return fmt.Sprintf("no clusters found for provider '%s' and region '%s'", p.Criteria.Provider, p.Criteria.Region)
+	}
+
+	summaries := []string{}
+	for _, c := range p.Clusters {
+		if c.Eligible {
+			summaries = append(summaries, fmt.Sprintf("cluster %s: eligible", c.ClusterID))
+		} else {
+			summaries = append(summaries, fmt.Sprintf("cluster %s: %s", c.ClusterID, strings.Join(c.Reasons, ", ")))
+		}
+	}
+	return strings.Join(summaries, "; ")
+}
+
+func (k *kafkaService) ExplainPlacement(criteria *FindClusterCriteria) (*PlacementExplanation, *errors.ServiceError) {
+	if criteria == nil {
+		return nil, errors.GeneralError("unable to explain placement: criteria was not specified")
+	}
+
+	instanceType, err := k.kafkaConfig.SupportedInstanceTypes.Configuration.GetKafkaInstanceTypeByID(criteria.SupportedInstanceType)
+	if err != nil {
+		return nil, errors.InstanceTypeNotSupported("unable to explain placement: %s", err.Error())
+	}
+
+	// the sizes are ordered starting with the smallest one, if it does not fit in a cluster no other size will
+	var capacityConsumed int
+	if len(instanceType.Sizes) > 0 {
+		capacityConsumed = instanceType.Sizes[0].CapacityConsumed
+	}
+
+	// only filter by provider and region so that the clusters failing any of the other checks are part of the explanation
+	clusters, findErr := k.clusterService.FindAllClusters(FindClusterCriteria{
+		Provider: criteria.Provider,
+		Region:   criteria.Region,
+	})
+	if findErr != nil {
+		return nil, errors.NewWithCause(errors.ErrorGeneral, findErr, "failed to find clusters with criteria '%v'", criteria)
+	}
+
+	explanation := &PlacementExplanation{
+		Criteria: *criteria,
+		Clusters: []ClusterPlacementExplanation{},
+	}
+	if len(clusters) == 0 {
+		return explanation, nil
+	}
+
+	clusterIDs := []string{}
+	for _, cluster := range clusters {
+		clusterIDs = append(clusterIDs, cluster.ClusterID)
+	}
+
+	kafkaCountPerCluster := map[string]int{}
+	var streamingUnitCounts KafkaStreamingUnitCountPerClusterList
+	var reservedStreamingUnits int
+	if k.dataplaneClusterConfig.IsDataPlaneAutoScalingEnabled() {
+		streamingUnitCounts, findErr = k.clusterService.FindStreamingUnitCountByClusterAndInstanceType()
+		if findErr != nil {
+			return nil, errors.NewWithCause(errors.ErrorGeneral, findErr, "failed to get count of streaming units by cluster and instance type")
+		}
+		reserved, reservedErr := reservedStreamingUnitsForInstanceType(k.dataplaneClusterConfig, k.kafkaConfig, criteria.SupportedInstanceType)
+		if reservedErr != nil {
+			return nil, errors.NewWithCause(errors.ErrorGeneral, reservedErr, "failed to get reserved streaming units for instance type '%s'", criteria.SupportedInstanceType)
+		}
+		reservedStreamingUnits = reserved
+	} else {
+		kafkaCounts, countErr := k.clusterService.FindKafkaInstanceCount(clusterIDs)
+		if countErr != nil {
+			return nil, errors.NewWithCause(errors.ErrorGeneral, countErr, "failed to find kafka instance count for clusters '%v'", clusterIDs)
+		}
+		for _, c := range kafkaCounts {
+			kafkaCountPerCluster[c.Clusterid] = c.Count
+		}
+	}
+
+	for _, cluster := range clusters {
+		reasons := []string{}
+		if cluster.Status != api.ClusterReady {
+			reasons = append(reasons, fmt.Sprintf("cluster is not ready (status: %s)", cluster.Status))
+		}
+		if cluster.MultiAZ != criteria.MultiAZ {
+			reasons = append(reasons, fmt.Sprintf("multi AZ mismatch (cluster: %t, requested: %t)", cluster.MultiAZ, criteria.MultiAZ))
+		}
+		if !arrays.Contains(cluster.GetSupportedInstanceTypes(), criteria.SupportedInstanceType) {
+			reasons = append(reasons, fmt.Sprintf("instance type '%s' not supported", criteria.SupportedInstanceType))
+		}
+
+		if k.dataplaneClusterConfig.IsDataPlaneAutoScalingEnabled() {
+			used := streamingUnitCounts.GetStreamingUnitCountForClusterAndInstanceType(cluster.ClusterID, criteria.SupportedInstanceType)
+			maxUnits := int(cluster.RetrieveDynamicCapacityInfo()[criteria.SupportedInstanceType].MaxUnits)
+			if used+reservedStreamingUnits+capacityConsumed > maxUnits {
+				reasons = append(reasons, fmt.Sprintf("full (%d/%d streaming units used)", used+reservedStreamingUnits, maxUnits))
+			}
+		} else {
+			clusterConfig := k.dataplaneClusterConfig.ClusterConfig
+			if !clusterConfig.IsClusterSchedulable(cluster.ClusterID) {
+				reasons = append(reasons, "cluster is not schedulable")
+			}
+			if !clusterConfig.IsNumberOfKafkaWithinClusterLimit(cluster.ClusterID, kafkaCountPerCluster[cluster.ClusterID]+capacityConsumed) {
+				reasons = append(reasons, "full (kafka instance limit reached)")
+			}
+		}
+
+		explanation.Clusters = append(explanation.Clusters, ClusterPlacementExplanation{
+			ClusterID: cluster.ClusterID,
+			Eligible:  len(reasons) == 0,
+			Reasons:   reasons,
+		})
+	}
+
+	return explanation, nil
+}
+
 func (k *kafkaService) AssignInstanceType(owner string, organisationId string) (types.KafkaInstanceType, *errors.ServiceError) {
 	quotaService, factoryErr := k.quotaServiceFactory.GetQuotaService(api.QuotaType(k.kafkaConfig.Quota.Type))
 	if factoryErr != nil {
@@ -296,44 +894,189 @@ func (k *kafkaService) AssignInstanceType(owner string, organisationId string) (
 	return types.DEVELOPER, nil
 }
 
+func (k *kafkaService) GetAllowedInstanceTypes(ctx context.Context) ([]types.KafkaInstanceType, *errors.ServiceError) {
+	claims, err := auth.GetClaimsFromContext(ctx)
+	if err != nil {
+		return nil, errors.NewWithCause(errors.ErrorUnauthenticated, err, "user not authenticated")
+	}
+	owner, _ := claims.GetUsername()
+	if owner == "" {
+		return nil, errors.Unauthenticated("user not authenticated")
+	}
+	organisationId, _ := claims.GetOrgId()
+
+	quotaService, factoryErr := k.quotaServiceFactory.GetQuotaService(api.QuotaType(k.kafkaConfig.Quota.Type))
+	if factoryErr != nil {
+		return nil, errors.NewWithCause(errors.ErrorGeneral, factoryErr, "unable to check quota")
+	}
+
+	allowed := []types.KafkaInstanceType{}
+	for _, supportedInstanceType := range k.kafkaConfig.SupportedInstanceTypes.Configuration.SupportedKafkaInstanceTypes {
+		instanceType := types.KafkaInstanceType(supportedInstanceType.Id)
+		// the same developer instance rules as when reserving the quota apply
+		if instanceType == types.DEVELOPER {
+			if !k.kafkaConfig.Quota.AllowDeveloperInstance {
+				continue
+			}
+			count, svcErr := k.countDeveloperInstances(owner, organisationId)
+			if svcErr != nil {
+				return nil, svcErr
+			}
+			if count >= int64(k.kafkaConfig.Quota.MaxAllowedDeveloperInstances) {
+				continue
+			}
+		}
+
+		hasQuota, svcErr := quotaService.CheckIfQuotaIsDefinedForInstanceType(owner, organisationId, instanceType)
+		if svcErr != nil {
+			return nil, svcErr
+		}
+		if hasQuota {
+			allowed = append(allowed, instanceType)
+		}
+	}
+
+	return allowed, nil
+}
+
+// countDeveloperInstances returns the number of developer kafkas the given owner has in the given organisation
+func (k *kafkaService) countDeveloperInstances(owner string, organisationId string) (int64, *errors.ServiceError) {
+	var count int64
+	if err := k.connectionFactory.New().
+		Model(&dbapi.KafkaRequest{}).
+		Where("instance_type = ?", types.DEVELOPER).
+		Where("owner = ?", owner).
+		Where("organisation_id = ?", organisationId).
+		Count(&count).
+		Error; err != nil {
+		return 0, errors.NewWithCause(errors.ErrorGeneral, err, "failed to count kafka %s instances", types.DEVELOPER.String())
+	}
+	return count, nil
+}
+
+// reasons of the quota reservation failures, used as label of the kafka quota reservation failures metric
+const (
+	quotaReservationFailureDeveloperInstanceNotAllowed   = "developer_instance_not_allowed"
+	quotaReservationFailureDeveloperInstanceLimitReached = "developer_instance_limit_reached"
+	quotaReservationFailureInsufficientQuota             = "insufficient_quota"
+	quotaReservationFailureMaxAllowedInstancesReached    = "max_allowed_instances_reached"
+	quotaReservationFailureInvalidBillingAccount         = "invalid_billing_account"
+	quotaReservationFailureQuotaServiceError             = "quota_service_error"
+)
+
 // reserveQuota - reserves quota for the given kafka request. If a RHOSAK quota has been assigned, it will try to reserve RHOSAK quota, otherwise it will try with RHOSAKTrial
+// Every failure is recorded in the kafka quota reservation failures metric.
 func (k *kafkaService) reserveQuota(kafkaRequest *dbapi.KafkaRequest) (subscriptionId string, err *errors.ServiceError) {
+	subscriptionId, reason, err := k.doReserveQuota(kafkaRequest)
+	if err != nil {
+		metrics.IncreaseKafkaQuotaReservationFailuresCountMetric(reason)
+	}
+	return subscriptionId, err
+}
+
+// quotaReservationFailureReason returns the reason, as recorded in the kafka quota reservation failures metric, of an error returned by a quota service
+func quotaReservationFailureReason(err *errors.ServiceError) string {
+	switch err.Code {
+	case errors.ErrorInsufficientQuota:
+		return quotaReservationFailureInsufficientQuota
+	case errors.ErrorMaxAllowedInstanceReached:
+		return quotaReservationFailureMaxAllowedInstancesReached
+	case errors.ErrorBillingAccountInvalid:
+		return quotaReservationFailureInvalidBillingAccount
+	default:
+		return quotaReservationFailureQuotaServiceError
+	}
+}
+
+func (k *kafkaService) doReserveQuota(kafkaRequest *dbapi.KafkaRequest) (string, string, *errors.ServiceError) {
 	if kafkaRequest.InstanceType == types.DEVELOPER.String() {
 		instType, err := k.kafkaConfig.SupportedInstanceTypes.Configuration.GetKafkaInstanceTypeByID(kafkaRequest.InstanceType)
 
 		if err != nil {
-			return "", errors.NewWithCause(errors.ErrorGeneral, err, "unable to reserve quota")
+			return "", quotaReservationFailureQuotaServiceError, errors.NewWithCause(errors.ErrorGeneral, err, "unable to reserve quota")
 		}
 
 		if !k.kafkaConfig.Quota.AllowDeveloperInstance {
-			return "", errors.NewWithCause(errors.ErrorForbidden, err, "kafka %s instances are not allowed", instType.DisplayName)
+			return "", quotaReservationFailureDeveloperInstanceNotAllowed, errors.NewWithCause(errors.ErrorForbidden, err, "kafka %s instances are not allowed", instType.DisplayName)
 		}
 
 		//N DEVELOPER instance is admitted. Let's check if the user already owns N instances
-		dbConn := k.connectionFactory.New()
-		var count int64
-		if err := dbConn.Model(&dbapi.KafkaRequest{}).
-			Where("instance_type = ?", types.DEVELOPER).
-			Where("owner = ?", kafkaRequest.Owner).
-			Where("organisation_id = ?", kafkaRequest.OrganisationId).
-			Count(&count).
-			Error; err != nil {
-			return "", errors.NewWithCause(errors.ErrorGeneral, err, "failed to count kafka %s instances", instType.DisplayName)
+		count, countErr := k.countDeveloperInstances(kafkaRequest.Owner, kafkaRequest.OrganisationId)
+		if countErr != nil {
+			return "", quotaReservationFailureQuotaServiceError, countErr
+		}
+		// a kafka whose quota reservation has been deferred is already persisted and therefore counted
+		if kafkaRequest.Status == constants2.KafkaRequestStatusPendingQuota.String() {
+			count--
 		}
 
 		maxAllowedDeveloperInstances := k.kafkaConfig.Quota.MaxAllowedDeveloperInstances
 
 		if count >= int64(maxAllowedDeveloperInstances) {
-			return "", errors.TooManyKafkaInstancesReached(fmt.Sprintf("only %d %s instance is allowed", maxAllowedDeveloperInstances, instType.DisplayName))
+			return "", quotaReservationFailureDeveloperInstanceLimitReached, errors.TooManyKafkaInstancesReached(fmt.Sprintf("only %d %s instance is allowed", maxAllowedDeveloperInstances, instType.DisplayName))
 		}
 	}
 
 	quotaService, factoryErr := k.quotaServiceFactory.GetQuotaService(api.QuotaType(k.kafkaConfig.Quota.Type))
 	if factoryErr != nil {
-		return "", errors.NewWithCause(errors.ErrorGeneral, factoryErr, "unable to check quota")
+		return "", quotaReservationFailureQuotaServiceError, errors.NewWithCause(errors.ErrorGeneral, factoryErr, "unable to check quota")
 	}
-	subscriptionId, err = quotaService.ReserveQuota(kafkaRequest, types.KafkaInstanceType(kafkaRequest.InstanceType))
-	return subscriptionId, err
+	subscriptionId, err := quotaService.ReserveQuota(kafkaRequest, types.KafkaInstanceType(kafkaRequest.InstanceType))
+	if err != nil {
+		return "", quotaReservationFailureReason(err), err
+	}
+	// the marketplace is resolved from the billing accounts of the organisation when it has not been requested
+	if err := ValidateMarketplaceCloudProvider(kafkaRequest.Marketplace, kafkaRequest.CloudProvider, k.kafkaConfig); err != nil {
+		if deleteErr := quotaService.DeleteQuota(subscriptionId); deleteErr != nil {
+			glog.Errorf("failed to release quota '%s' of kafka request '%s': %v", subscriptionId, kafkaRequest.ID, deleteErr)
+		}
+		return "", quotaReservationFailureInvalidBillingAccount, err
+	}
+	return subscriptionId, "", nil
+}
+
+// registrationLockNamespace is the first key of the postgres advisory locks taken by lockRegistration, it keeps them apart
+// from the advisory locks that could be taken for other purposes
+const registrationLockNamespace = 20221021
+
+// lockRegistration serializes the kafka registrations happening in the same cloud provider and region, so that the capacity
+// checks, the cluster placement and the quota reservation of a registration are not affected by a concurrent one.
+// Registrations in different regions can proceed concurrently, unless they are made by the same organisation (or by the
+// same user when there is no organisation) as its quota checks span all the regions.
+// The lock is per region (and not per region and instance type) because data plane clusters within a region can host
+// several instance types.
+// The locks are postgres transaction level advisory locks, so they serialize the registrations across all the replicas of
+// the fleet manager and are released by the database when the transaction holding them ends, even if the replica dies.
+// Two keys can hash to the same lock, which only serializes registrations that could otherwise have run concurrently.
+// Lock ordering: the tenant (organisation or owner) lock is always taken first, then the region lock. Callers must not
+// call lockRegistration while already holding a registration lock, otherwise two registrations can deadlock.
+// The returned function ends the transaction holding the locks, thus releasing them.
+func (k *kafkaService) lockRegistration(kafkaRequest *dbapi.KafkaRequest) (func(), *errors.ServiceError) {
+	tx := k.connectionFactory.New().Begin()
+	if tx.Error != nil {
+		return nil, errors.NewWithCause(errors.ErrorGeneral, tx.Error, "unable to lock the registration of kafka request")
+	}
+	unlock := func() {
+		if err := tx.Rollback().Error; err != nil {
+			glog.Errorf("failed to release the registration locks of kafka request '%s': %v", kafkaRequest.ID, err)
+		}
+	}
+	for _, key := range registrationLockKeys(kafkaRequest) {
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(?, hashtext(?))", registrationLockNamespace, key).Error; err != nil {
+			unlock()
+			return nil, errors.NewWithCause(errors.ErrorGeneral, err, "unable to lock the registration of kafka request")
+		}
+	}
+	return unlock, nil
+}
+
+// registrationLockKeys returns the keys of the locks taken by lockRegistration, in the order they must be taken
+func registrationLockKeys(kafkaRequest *dbapi.KafkaRequest) []string {
+	tenantKey := fmt.Sprintf("organisation/%s", kafkaRequest.OrganisationId)
+	if kafkaRequest.OrganisationId == "" {
+		tenantKey = fmt.Sprintf("owner/%s", kafkaRequest.Owner)
+	}
+	return []string{tenantKey, fmt.Sprintf("region/%s/%s", kafkaRequest.CloudProvider, kafkaRequest.Region)}
 }
 
 // RegisterKafkaJob registers a new job in the kafka table.
@@ -345,19 +1088,125 @@ func (k *kafkaService) reserveQuota(kafkaRequest *dbapi.KafkaRequest) (subscript
 // If region limits have not been reached and if the scaling mode is manual, then we check if there is a cluster that has capacity left
 // to accomodate this Kafka. If so, the registration of the kafka is accepted. Otherwise, it is rejected.
 func (k *kafkaService) RegisterKafkaJob(kafkaRequest *dbapi.KafkaRequest) *errors.ServiceError {
-	k.mu.Lock()
-	defer k.mu.Unlock()
+	return k.registerKafkaJob(kafkaRequest, false)
+}
+
+// RegisterKafkaJobWithDeferredQuota performs the same checks as RegisterKafkaJob but does not reserve quota.
+// The Kafka is persisted in 'pending_quota' status and is only accepted once ConfirmQuota is called.
+func (k *kafkaService) RegisterKafkaJobWithDeferredQuota(kafkaRequest *dbapi.KafkaRequest) *errors.ServiceError {
+	return k.registerKafkaJob(kafkaRequest, true)
+}
+
+// ValidKafkaNameRegexp is the format of the kafka names
+var ValidKafkaNameRegexp = regexp.MustCompile(`^[a-z]([-a-z0-9]*[a-z0-9])?$`)
+
+// MaxKafkaNameLength is the maximum length of the kafka names
+var MaxKafkaNameLength = 32
+
+var validBillingModels = []string{"", string(amsv1.BillingModelStandard), string(amsv1.BillingModelMarketplace)}
+
+// marketplaceCloudProviders are the cloud providers offering each marketplace. The marketplaces not listed here
+// (e.g. the Red Hat marketplace "rhm") can be used with every cloud provider
+var marketplaceCloudProviders = map[string]cloudproviders.CloudProviderID{
+	"aws":   cloudproviders.AWS,
+	"azure": cloudproviders.Azure,
+}
+
+// ValidateMarketplaceCloudProvider validates that the marketplace a kafka is billed through is offered by the cloud
+// provider the kafka is created in, unless cross cloud billing is allowed
+func ValidateMarketplaceCloudProvider(marketplace string, cloudProvider string, kafkaConfig *config.KafkaConfig) *errors.ServiceError {
+	marketplaceProvider, ok := marketplaceCloudProviders[marketplace]
+	if !ok || kafkaConfig.AllowCrossCloudMarketplaceBilling {
+		return nil
+	}
+	if cloudProvider != marketplaceProvider.String() {
+		return errors.Validation("marketplace %s cannot be used with cloud provider %s, it can only be used with cloud provider %s", marketplace, cloudProvider, marketplaceProvider)
+	}
+	return nil
+}
+
+func (k *kafkaService) ValidateCreateRequest(kafkaRequest *dbapi.KafkaRequest) *errors.ServiceError {
+	if len(kafkaRequest.Name) < 1 || len(kafkaRequest.Name) > MaxKafkaNameLength {
+		return errors.MalformedKafkaClusterName("name '%s' must be between 1 and %d characters long", kafkaRequest.Name, MaxKafkaNameLength)
+	}
+	if !ValidKafkaNameRegexp.MatchString(kafkaRequest.Name) {
+		return errors.MalformedKafkaClusterName("name '%s' does not match %s", kafkaRequest.Name, ValidKafkaNameRegexp.String())
+	}
+
+	instanceType, err := k.kafkaConfig.SupportedInstanceTypes.Configuration.GetKafkaInstanceTypeByID(kafkaRequest.InstanceType)
+	if err != nil {
+		return errors.InstanceTypeNotSupported("instance type '%s' is not supported", kafkaRequest.InstanceType)
+	}
+	if _, err := instanceType.GetKafkaInstanceSizeByID(kafkaRequest.SizeId); err != nil {
+		return errors.InstancePlanNotSupported("size '%s' is not supported for instance type '%s'", kafkaRequest.SizeId, kafkaRequest.InstanceType)
+	}
+
+	supportedProviders := k.providerConfig.ProvidersConfig.SupportedProviders
+	provider, ok := supportedProviders.GetByName(kafkaRequest.CloudProvider)
+	if !ok {
+		return errors.ProviderNotSupported("provider '%s' is not supported, supported providers are: %s", kafkaRequest.CloudProvider, supportedProviders)
+	}
+	region, ok := provider.Regions.GetByName(kafkaRequest.Region)
+	if !ok {
+		return errors.RegionNotSupported("region '%s' is not supported for provider '%s', supported regions are: %s", kafkaRequest.Region, kafkaRequest.CloudProvider, provider.Regions)
+	}
+	if !region.IsInstanceTypeSupported(config.InstanceType(kafkaRequest.InstanceType)) {
+		return errors.InstanceTypeNotSupported("instance type '%s' is not supported in region '%s'", kafkaRequest.InstanceType, kafkaRequest.Region)
+	}
+	if region.MaintenanceMode {
+		return errors.RegionNotSupported("region '%s' is in maintenance, no kafka can be created in it at this moment", kafkaRequest.Region)
+	}
+
+	// the instance type determines whether the kafka is multi AZ, which the region must be able to host
+	multiAZ := kafkaRequest.MultiAZ
+	if instanceTypeMultiAZ, ok := multiAZByInstanceType[types.KafkaInstanceType(kafkaRequest.InstanceType)]; ok {
+		multiAZ = instanceTypeMultiAZ
+	}
+	if multiAZ && !region.IsMultiAZSupported() {
+		return errors.InstanceTypeNotSupported("instance type '%s' requires multi AZ which is not supported in region '%s'", kafkaRequest.InstanceType, kafkaRequest.Region)
+	}
+
+	if !arrays.Contains(validBillingModels, kafkaRequest.BillingModel) {
+		return errors.InvalidBillingAccount("invalid billing model: %s, only %s and %s are allowed", kafkaRequest.BillingModel, amsv1.BillingModelStandard, amsv1.BillingModelMarketplace)
+	}
+	if err := ValidateMarketplaceCloudProvider(kafkaRequest.Marketplace, kafkaRequest.CloudProvider, k.kafkaConfig); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (k *kafkaService) registerKafkaJob(kafkaRequest *dbapi.KafkaRequest, deferQuota bool) *errors.ServiceError {
+	unlock, err := k.lockRegistration(kafkaRequest)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	annotations, annotationsErr := kafkaRequest.GetAnnotations()
+	if annotationsErr != nil {
+		return errors.FieldValidationError("invalid annotations: %s", annotationsErr.Error())
+	}
+	if err := dbapi.ValidateAnnotations(annotations); err != nil {
+		return errors.FieldValidationError(err.Error())
+	}
+
 	// we need to pre-populate the ID to be able to reserve the quota
 	kafkaRequest.ID = api.NewID()
 
 	// The Instance Type determines the MultiAZ attribute. The previously value
 	// set for the MultiAZ attribute in the request (if any) is ignored.
 	// TODO improve this
-	switch kafkaRequest.InstanceType {
-	case types.STANDARD.String():
-		kafkaRequest.MultiAZ = true
-	case types.DEVELOPER.String():
-		kafkaRequest.MultiAZ = false
+	if multiAZ, ok := multiAZByInstanceType[types.KafkaInstanceType(kafkaRequest.InstanceType)]; ok {
+		kafkaRequest.MultiAZ = multiAZ
+	}
+
+	if err := k.ValidateCreateRequest(kafkaRequest); err != nil {
+		return err
+	}
+
+	if kafkaRequest.ReauthenticationUnspecified {
+		kafkaRequest.ReauthenticationEnabled = k.kafkaConfig.IsReauthenticationEnabledByDefault(kafkaRequest.InstanceType)
 	}
 
 	hasCapacity, err := k.HasAvailableCapacityInRegion(kafkaRequest)
@@ -390,15 +1239,18 @@ func (k *kafkaService) RegisterKafkaJob(kafkaRequest *dbapi.KafkaRequest) *error
 		kafkaRequest.ClusterID = cluster.ClusterID
 	}
 
-	subscriptionId, err := k.reserveQuota(kafkaRequest)
-
-	if err != nil {
-		return err
+	status := constants2.KafkaRequestStatusPendingQuota
+	if !deferQuota {
+		subscriptionId, err := k.reserveQuota(kafkaRequest)
+		if err != nil {
+			return err
+		}
+		kafkaRequest.SubscriptionId = subscriptionId
+		status = constants2.KafkaRequestStatusAccepted
 	}
 
 	dbConn := k.connectionFactory.New()
-	kafkaRequest.SubscriptionId = subscriptionId
-	kafkaRequest.Status = constants2.KafkaRequestStatusAccepted.String()
+	kafkaRequest.Status = status.String()
 
 	// when creating new kafka - default storage size is assigned
 	instanceType, instanceTypeErr := k.kafkaConfig.SupportedInstanceTypes.Configuration.GetKafkaInstanceTypeByID(kafkaRequest.InstanceType)
@@ -421,38 +1273,146 @@ func (k *kafkaService) RegisterKafkaJob(kafkaRequest *dbapi.KafkaRequest) *error
 	if err := dbConn.Create(kafkaRequest).Error; err != nil {
 		return errors.NewWithCause(errors.ErrorGeneral, err, "failed to create kafka request") //hide the db error to http caller
 	}
+	k.streamingUnitCountCache.Invalidate()
+	k.emitLifecycleEvent(KafkaLifecycleEventCreated, kafkaRequest)
 
-	metrics.UpdateKafkaRequestsStatusSinceCreatedMetric(constants2.KafkaRequestStatusAccepted, kafkaRequest.ID, kafkaRequest.ClusterID, time.Since(kafkaRequest.CreatedAt))
+	if !deferQuota {
+		metrics.UpdateKafkaRequestsStatusSinceCreatedMetric(constants2.KafkaRequestStatusAccepted, kafkaRequest.ID, kafkaRequest.ClusterID, time.Since(kafkaRequest.CreatedAt))
+	}
 	return nil
 }
 
-func (k *kafkaService) PrepareKafkaRequest(kafkaRequest *dbapi.KafkaRequest) *errors.ServiceError {
-	kafkaRequest.Namespace = fmt.Sprintf("kafka-%s", strings.ToLower(kafkaRequest.ID))
-
-	err := k.AssignBootstrapServerHost(kafkaRequest)
+func (k *kafkaService) ConfirmQuota(id string) *errors.ServiceError {
+	kafkaRequest, err := k.GetById(id)
 	if err != nil {
-		return errors.NewWithCause(errors.ErrorGeneral, err, "error assigning bootstrap server host to kafka %s", kafkaRequest.ID)
+		return err
 	}
 
-	if k.keycloakService.GetConfig().EnableAuthenticationOnKafka {
-		clientId := strings.ToLower(fmt.Sprintf("%s-%s", CanaryServiceAccountPrefix, kafkaRequest.ID))
-		serviceAccountRequest := sso.CompleteServiceAccountRequest{
-			Owner:          kafkaRequest.Owner,
-			OwnerAccountId: kafkaRequest.OwnerAccountId,
-			ClientId:       clientId,
-			OrgId:          kafkaRequest.OrganisationId,
-			Name:           fmt.Sprintf("canary-service-account-for-kafka %s", kafkaRequest.ID),
-			Description:    fmt.Sprintf("canary service account for kafka %s", kafkaRequest.ID),
-		}
+	unlock, err := k.lockRegistration(kafkaRequest)
+	if err != nil {
+		return err
+	}
+	defer unlock()
 
-		canaryServiceAccount, err := k.keycloakService.CreateServiceAccountInternal(serviceAccountRequest)
+	if kafkaRequest.Status != constants2.KafkaRequestStatusPendingQuota.String() {
+		return errors.BadRequest("kafka request %s is not waiting for its quota to be confirmed (status: %s)", id, kafkaRequest.Status)
+	}
 
-		if err != nil {
-			return errors.FailedToCreateSSOClient("failed to  create canary service account %s:%v", kafkaRequest.ID, err)
+	subscriptionId, err := k.reserveQuota(kafkaRequest)
+	if err != nil {
+		return err
+	}
+
+	dbConn := k.connectionFactory.New()
+	// the status condition prevents a concurrent abort or expiry of the pending kafka from being overridden
+	result := dbConn.Model(kafkaRequest).
+		Where("status = ?", constants2.KafkaRequestStatusPendingQuota.String()).
+		Updates(map[string]interface{}{
+			"subscription_id":   subscriptionId,
+			"status":            constants2.KafkaRequestStatusAccepted.String(),
+			"status_updated_at": time.Now(),
+		})
+	if result.Error != nil {
+		k.releaseQuota(kafkaRequest, subscriptionId)
+		return errors.NewWithCause(errors.ErrorGeneral, result.Error, "failed to confirm quota of kafka request %s", id)
+	}
+	if result.RowsAffected == 0 {
+		k.releaseQuota(kafkaRequest, subscriptionId)
+		return errors.BadRequest("kafka request %s is not waiting for its quota to be confirmed", id)
+	}
+	k.kafkaRequestCache.Invalidate(id)
+
+	metrics.UpdateKafkaRequestsStatusSinceCreatedMetric(constants2.KafkaRequestStatusAccepted, kafkaRequest.ID, kafkaRequest.ClusterID, time.Since(kafkaRequest.CreatedAt))
+	return nil
+}
+
+// releaseQuota deletes a quota reservation that could not be associated with its kafka request
+func (k *kafkaService) releaseQuota(kafkaRequest *dbapi.KafkaRequest, subscriptionId string) {
+	quotaService, factoryErr := k.quotaServiceFactory.GetQuotaService(api.QuotaType(kafkaRequest.QuotaType))
+	if factoryErr != nil {
+		glog.Errorf("failed to release quota of kafka request '%s': %v", kafkaRequest.ID, factoryErr)
+		return
+	}
+	if err := quotaService.DeleteQuota(subscriptionId); err != nil {
+		glog.Errorf("failed to release quota '%s' of kafka request '%s': %v", subscriptionId, kafkaRequest.ID, err)
+	}
+}
+
+func (k *kafkaService) AbortPendingQuota(id string) *errors.ServiceError {
+	dbConn := k.connectionFactory.New()
+	result := dbConn.Unscoped().
+		Where("id = ?", id).
+		Where("status = ?", constants2.KafkaRequestStatusPendingQuota.String()).
+		Delete(&dbapi.KafkaRequest{})
+	if result.Error != nil {
+		return errors.NewWithCause(errors.ErrorGeneral, result.Error, "failed to abort kafka request %s", id)
+	}
+	if result.RowsAffected == 0 {
+		return errors.BadRequest("kafka request %s does not exist or is not waiting for its quota to be confirmed", id)
+	}
+	k.kafkaRequestCache.Invalidate(id)
+
+	return nil
+}
+
+func (k *kafkaService) DeleteExpiredPendingQuotaKafkas() (int64, *errors.ServiceError) {
+	dbConn := k.connectionFactory.New()
+	result := dbConn.Unscoped().
+		Where("status = ?", constants2.KafkaRequestStatusPendingQuota.String()).
+		Where("created_at < ?", time.Now().Add(-constants2.PendingQuotaKafkaMaxDuration)).
+		Delete(&dbapi.KafkaRequest{})
+	if result.Error != nil {
+		return 0, errors.NewWithCause(errors.ErrorGeneral, result.Error, "failed to delete expired kafka requests pending quota")
+	}
+
+	if result.RowsAffected > 0 {
+		k.kafkaRequestCache.InvalidateAll()
+		glog.Infof("deleted %d kafka request(s) whose quota has not been confirmed within %s", result.RowsAffected, constants2.PendingQuotaKafkaMaxDuration)
+	}
+
+	return result.RowsAffected, nil
+}
+
+func (k *kafkaService) BackfillQuotaType() (int64, *errors.ServiceError) {
+	dbConn := k.connectionFactory.New()
+	result := dbConn.Model(&dbapi.KafkaRequest{}).
+		Where("quota_type = ? OR quota_type IS NULL", "").
+		Update("quota_type", k.kafkaConfig.Quota.Type)
+	if result.Error != nil {
+		return 0, errors.NewWithCause(errors.ErrorGeneral, result.Error, "failed to backfill the quota type of kafka requests")
+	}
+
+	if result.RowsAffected > 0 {
+		k.kafkaRequestCache.InvalidateAll()
+		glog.Infof("set the quota type of %d kafka request(s) without one to '%s'", result.RowsAffected, k.kafkaConfig.Quota.Type)
+	}
+
+	return result.RowsAffected, nil
+}
+
+func (k *kafkaService) PrepareKafkaRequest(kafkaRequest *dbapi.KafkaRequest) *errors.ServiceError {
+	namespace, serr := k.allocateNamespace(kafkaRequest)
+	if serr != nil {
+		return errors.NewWithCause(errors.ErrorGeneral, serr, "error allocating namespace to kafka %s", kafkaRequest.ID)
+	}
+	kafkaRequest.Namespace = namespace
+
+	err := k.AssignBootstrapServerHost(kafkaRequest)
+	if err != nil {
+		return errors.NewWithCause(errors.ErrorGeneral, err, "error assigning bootstrap server host to kafka %s", kafkaRequest.ID)
+	}
+
+	createdCanaryServiceAccount := false
+	if keycloakConfig := k.keycloakService.GetConfig(); keycloakConfig.EnableAuthenticationOnKafka {
+		canaryServiceAccount, err := k.keycloakService.CreateServiceAccountInternal(buildCanaryServiceAccountRequest(keycloakConfig, kafkaRequest))
+
+		if err != nil {
+			return errors.FailedToCreateSSOClient("failed to  create canary service account %s:%v", kafkaRequest.ID, err)
 		}
 
 		kafkaRequest.CanaryServiceAccountClientID = canaryServiceAccount.ClientID
 		kafkaRequest.CanaryServiceAccountClientSecret = canaryServiceAccount.ClientSecret
+		createdCanaryServiceAccount = true
 	}
 
 	// Update the Kafka Request record in the database
@@ -469,12 +1429,33 @@ func (k *kafkaService) PrepareKafkaRequest(kafkaRequest *dbapi.KafkaRequest) *er
 		Namespace:                        kafkaRequest.Namespace,
 	}
 	if err := k.Update(updatedKafkaRequest); err != nil {
+		// don't leak the canary service account created above, a new one is created on the next attempt
+		if createdCanaryServiceAccount {
+			if keycloakErr := k.keycloakService.DeleteServiceAccountInternal(kafkaRequest.CanaryServiceAccountClientID); keycloakErr != nil {
+				glog.Warningf("failed to delete canary service account '%s' of kafka '%s' after a failed update: %v", kafkaRequest.CanaryServiceAccountClientID, kafkaRequest.ID, keycloakErr)
+			}
+			kafkaRequest.CanaryServiceAccountClientID = ""
+			kafkaRequest.CanaryServiceAccountClientSecret = ""
+		}
 		return errors.NewWithCause(errors.ErrorGeneral, err, "failed to update kafka request")
 	}
 
 	return nil
 }
 
+// buildCanaryServiceAccountRequest returns the request creating the canary service account of the given kafka, whose
+// client id is derived from the kafka id
+func buildCanaryServiceAccountRequest(keycloakConfig *keycloak.KeycloakConfig, kafkaRequest *dbapi.KafkaRequest) sso.CompleteServiceAccountRequest {
+	return sso.CompleteServiceAccountRequest{
+		Owner:          kafkaRequest.Owner,
+		OwnerAccountId: kafkaRequest.OwnerAccountId,
+		ClientId:       keycloakConfig.CanaryServiceAccountClientID(kafkaRequest.ID),
+		OrgId:          kafkaRequest.OrganisationId,
+		Name:           keycloakConfig.CanaryServiceAccountName(kafkaRequest.ID),
+		Description:    fmt.Sprintf("canary service account for kafka %s", kafkaRequest.ID),
+	}
+}
+
 func (k *kafkaService) ListByStatus(status ...constants2.KafkaStatus) ([]*dbapi.KafkaRequest, *errors.ServiceError) {
 	if len(status) == 0 {
 		return nil, errors.GeneralError("no status provided")
@@ -490,36 +1471,118 @@ func (k *kafkaService) ListByStatus(status ...constants2.KafkaStatus) ([]*dbapi.
 	return kafkas, nil
 }
 
-func (k *kafkaService) Get(ctx context.Context, id string) (*dbapi.KafkaRequest, *errors.ServiceError) {
-	if id == "" {
-		return nil, errors.Validation("id is undefined")
+func (k *kafkaService) StreamAll(ctx context.Context, batchSize int, fn func(*dbapi.KafkaRequest) error) *errors.ServiceError {
+	if batchSize <= 0 {
+		return errors.Validation("batch size must be greater than 0")
 	}
+	dbConn := k.connectionFactory.New().WithContext(ctx)
 
-	claims, err := auth.GetClaimsFromContext(ctx)
+	var kafkas []*dbapi.KafkaRequest
+	err := dbConn.FindInBatches(&kafkas, batchSize, func(tx *gorm.DB, batch int) error {
+		for _, kafka := range kafkas {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			if err := fn(kafka); err != nil {
+				return err
+			}
+		}
+		return nil
+	}).Error
 	if err != nil {
-		return nil, errors.NewWithCause(errors.ErrorUnauthenticated, err, "user not authenticated")
+		return errors.NewWithCause(errors.ErrorGeneral, err, "failed to stream kafka requests")
 	}
 
-	dbConn := k.connectionFactory.New().Where("id = ?", id)
+	return nil
+}
+
+func (k *kafkaService) Get(ctx context.Context, id string) (*dbapi.KafkaRequest, *errors.ServiceError) {
+	// only admins, who can read any kafka, are served from the cache
+	if auth.GetIsAdminFromContext(ctx) {
+		return k.kafkaRequestCache.getOrLoad(id, func() (*dbapi.KafkaRequest, *errors.ServiceError) {
+			return k.get(ctx, id, nil)
+		})
+	}
+	return k.get(ctx, id, nil)
+}
 
-	var user string
+func (k *kafkaService) GetByIdForContext(ctx context.Context, id string) (*dbapi.KafkaRequest, *errors.ServiceError) {
 	if !auth.GetIsAdminFromContext(ctx) {
-		user, _ = claims.GetUsername()
-		if user == "" {
-			return nil, errors.Unauthenticated("user not authenticated")
-		}
+		return k.Get(ctx, id)
+	}
 
-		orgId, _ := claims.GetOrgId()
-		filterByOrganisationId := auth.GetFilterByOrganisationFromContext(ctx)
+	// the existence is checked first so that a kafka that does not exist is reported as not found
+	if _, svcErr := k.GetById(id); svcErr != nil {
+		return nil, svcErr
+	}
 
-		// filter by organisationId if a user is part of an organisation and is not allowed as a service account
-		if filterByOrganisationId {
-			dbConn = dbConn.Where("organisation_id = ?", orgId)
-		} else {
-			dbConn = dbConn.Where("owner = ?", user)
+	// the kafka is then read as the admin user would read it without the admin privileges
+	kafkaRequest, svcErr := k.Get(auth.SetIsAdminContext(ctx, false), id)
+	if svcErr != nil && svcErr.Is404() {
+		return nil, errors.Forbidden("not allowed to read KafkaResource with id='%s'", id)
+	}
+	return kafkaRequest, svcErr
+}
+
+func (k *kafkaService) GetWithFields(ctx context.Context, id string, columns []string) (*dbapi.KafkaRequest, *errors.ServiceError) {
+	if len(columns) == 0 {
+		return nil, errors.BadRequest("at least one column must be selected")
+	}
+
+	stmt := &gorm.Statement{DB: k.connectionFactory.New()}
+	if err := stmt.Parse(&dbapi.KafkaRequest{}); err != nil {
+		return nil, errors.NewWithCause(errors.ErrorGeneral, err, "unable to parse kafka request schema")
+	}
+	for _, c := range columns {
+		if _, ok := stmt.Schema.FieldsByDBName[c]; !ok {
+			return nil, errors.BadRequest("invalid column name: '%s'", c)
 		}
 	}
 
+	return k.get(ctx, id, columns)
+}
+
+// KafkaWithRoutes is a kafka request along with its decoded DNS routes
+type KafkaWithRoutes struct {
+	*dbapi.KafkaRequest
+	DNSRoutes []dbapi.DataPlaneKafkaRoute
+}
+
+func (k *kafkaService) GetWithRoutes(ctx context.Context, id string) (*KafkaWithRoutes, *errors.ServiceError) {
+	kafkaRequest, err := k.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	routes, routesErr := kafkaRequest.GetRoutes()
+	if routesErr != nil {
+		// malformed routes must not prevent the kafka request from being shown
+		glog.Warningf("unable to decode the routes of kafka '%s': %v", kafkaRequest.ID, routesErr)
+		routes = nil
+	}
+	if routes == nil {
+		routes = []dbapi.DataPlaneKafkaRoute{}
+	}
+
+	return &KafkaWithRoutes{KafkaRequest: kafkaRequest, DNSRoutes: routes}, nil
+}
+
+// get returns the kafka request with the given id that is visible to the user in the context.
+// Only the given columns are loaded, all of them are loaded when no column is given.
+func (k *kafkaService) get(ctx context.Context, id string, columns []string) (*dbapi.KafkaRequest, *errors.ServiceError) {
+	if id == "" {
+		return nil, errors.Validation("id is undefined")
+	}
+
+	dbConn, user, serr := k.filterByCaller(ctx, k.connectionFactory.New().Where("id = ?", id))
+	if serr != nil {
+		return nil, serr
+	}
+
+	if len(columns) > 0 {
+		dbConn = dbConn.Select(columns)
+	}
+
 	var kafkaRequest dbapi.KafkaRequest
 	if err := dbConn.First(&kafkaRequest).Error; err != nil {
 		resourceTypeStr := "KafkaResource"
@@ -531,6 +1594,57 @@ func (k *kafkaService) Get(ctx context.Context, id string) (*dbapi.KafkaRequest,
 	return &kafkaRequest, nil
 }
 
+// GetByName returns the kafka request with the given name that the given ctx has access to.
+// Kafka names are only unique within the scope of the user that creates them, so when the caller has access
+// to several kafka requests with the same name (e.g. admins) the oldest one is returned.
+func (k *kafkaService) GetByName(ctx context.Context, name string) (*dbapi.KafkaRequest, *errors.ServiceError) {
+	if name == "" {
+		return nil, errors.Validation("name is undefined")
+	}
+
+	dbConn, user, serr := k.filterByCaller(ctx, k.connectionFactory.New().Where("name = ?", name))
+	if serr != nil {
+		return nil, serr
+	}
+
+	var kafkaRequest dbapi.KafkaRequest
+	if err := dbConn.Order("created_at").First(&kafkaRequest).Error; err != nil {
+		resourceTypeStr := "KafkaResource"
+		if user != "" {
+			resourceTypeStr = fmt.Sprintf("%s for user %s", resourceTypeStr, user)
+		}
+		return nil, services.HandleGetError(resourceTypeStr, "name", name, err)
+	}
+	return &kafkaRequest, nil
+}
+
+// filterByCaller restricts the query to the kafka requests the user in the given ctx has access to, admins have access to
+// all the kafka requests. The username is returned for non admin users.
+func (k *kafkaService) filterByCaller(ctx context.Context, dbConn *gorm.DB) (*gorm.DB, string, *errors.ServiceError) {
+	claims, err := auth.GetClaimsFromContext(ctx)
+	if err != nil {
+		return nil, "", errors.NewWithCause(errors.ErrorUnauthenticated, err, "user not authenticated")
+	}
+
+	if auth.GetIsAdminFromContext(ctx) {
+		return dbConn, "", nil
+	}
+
+	user, _ := claims.GetUsername()
+	if user == "" {
+		return nil, "", errors.Unauthenticated("user not authenticated")
+	}
+
+	orgId, _ := claims.GetOrgId()
+	filterByOrganisationId := auth.GetFilterByOrganisationFromContext(ctx)
+
+	// filter by organisationId if a user is part of an organisation and is not allowed as a service account
+	if filterByOrganisationId {
+		return dbConn.Where("organisation_id = ?", orgId), user, nil
+	}
+	return dbConn.Where("owner = ?", user), user, nil
+}
+
 func (k *kafkaService) GetById(id string) (*dbapi.KafkaRequest, *errors.ServiceError) {
 	if id == "" {
 		return nil, errors.Validation("id is undefined")
@@ -544,16 +1658,168 @@ func (k *kafkaService) GetById(id string) (*dbapi.KafkaRequest, *errors.ServiceE
 	return &kafkaRequest, nil
 }
 
+func (k *kafkaService) GetByIdIncludingDeleted(id string) (*dbapi.KafkaRequest, *errors.ServiceError) {
+	if id == "" {
+		return nil, errors.Validation("id is undefined")
+	}
+
+	dbConn := k.connectionFactory.New()
+	var kafkaRequest dbapi.KafkaRequest
+	if err := dbConn.Unscoped().Where("id = ?", id).First(&kafkaRequest).Error; err != nil {
+		return nil, services.HandleGetError("KafkaResource", "id", id, err)
+	}
+	return &kafkaRequest, nil
+}
+
+func (k *kafkaService) GetClusterForKafka(id string) (*api.Cluster, *errors.ServiceError) {
+	kafkaRequest, err := k.GetById(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if kafkaRequest.ClusterID == "" {
+		return nil, errors.NotFound("kafka '%s' is not assigned to a cluster", id)
+	}
+
+	cluster, err := k.clusterService.FindClusterByID(kafkaRequest.ClusterID)
+	if err != nil {
+		return nil, errors.NewWithCause(errors.ErrorGeneral, err, "failed to find cluster '%s' of kafka '%s'", kafkaRequest.ClusterID, id)
+	}
+	if cluster == nil {
+		return nil, errors.NotFound("cluster '%s' of kafka '%s' not found", kafkaRequest.ClusterID, id)
+	}
+	return cluster, nil
+}
+
+func (k *kafkaService) GetByNamespace(clusterID string, namespace string) (*dbapi.KafkaRequest, *errors.ServiceError) {
+	if clusterID == "" {
+		return nil, errors.Validation("cluster id is undefined")
+	}
+	if namespace == "" {
+		return nil, errors.Validation("namespace is undefined")
+	}
+
+	dbConn := k.connectionFactory.New()
+	var kafkaRequest dbapi.KafkaRequest
+	if err := dbConn.Where("cluster_id = ? AND namespace = ?", clusterID, namespace).First(&kafkaRequest).Error; err != nil {
+		return nil, services.HandleGetError("KafkaResource", "namespace", namespace, err)
+	}
+	return &kafkaRequest, nil
+}
+
 // RegisterKafkaDeprovisionJob registers a kafka deprovision job in the kafka table
 func (k *kafkaService) RegisterKafkaDeprovisionJob(ctx context.Context, id string) *errors.ServiceError {
+	kafkaRequest, svcErr := k.getKafkaToDeprovision(ctx, id)
+	if svcErr != nil {
+		return svcErr
+	}
+
+	// a deprovision request of a kafka pending deletion confirms its deletion, which has already been counted
+	if kafkaRequest.Status == constants2.KafkaRequestStatusDeprovisionPending.String() {
+		if _, err := k.updateStatus(id, constants2.KafkaRequestStatusDeprovision, map[string]interface{}{"status_before_deprovision": ""}); err != nil {
+			return services.HandleGetError("KafkaResource", "id", id, err)
+		}
+		return nil
+	}
+
+	// repeated deprovision requests of a kafka that is already being deleted are no-ops,
+	// only the request that started the deletion is counted in the metrics
+	if arrays.Contains(kafkaDeletionStatuses, kafkaRequest.Status) {
+		glog.V(5).Infof("kafka %s is already in %s status, ignoring the deprovision request", id, kafkaRequest.Status)
+		return nil
+	}
+
+	metrics.IncreaseKafkaTotalOperationsCountMetric(constants2.KafkaOperationDeprovision)
+
+	deprovisionStatus := constants2.KafkaRequestStatusDeprovision
+	deprovisionReason := constants2.KafkaDeprovisionReasonUserRequest
+	if auth.GetIsAdminFromContext(ctx) {
+		deprovisionReason = constants2.KafkaDeprovisionReasonAdminRequest
+	}
+	fields := map[string]interface{}{"deprovision_reason": deprovisionReason}
+	if k.kafkaConfig.DeprovisionGracePeriod > 0 {
+		deprovisionStatus = constants2.KafkaRequestStatusDeprovisionPending
+		fields["status_before_deprovision"] = kafkaRequest.Status
+	}
+
+	if executed, err := k.updateStatus(id, deprovisionStatus, fields); executed {
+		if err != nil {
+			return services.HandleGetError("KafkaResource", "id", id, err)
+		}
+		metrics.IncreaseKafkaSuccessOperationsCountMetric(constants2.KafkaOperationDeprovision)
+	}
+
+	return nil
+}
+
+func (k *kafkaService) CancelDeprovision(ctx context.Context, id string) *errors.ServiceError {
+	kafkaRequest, svcErr := k.getKafkaToDeprovision(ctx, id)
+	if svcErr != nil {
+		return svcErr
+	}
+	if kafkaRequest.Status != constants2.KafkaRequestStatusDeprovisionPending.String() || kafkaRequest.StatusBeforeDeprovision == "" {
+		return errors.BadRequest("kafka request %s is not pending deletion", id)
+	}
+
+	// the status is checked again in the update in case the deletion has been confirmed in the meantime
+	result := k.connectionFactory.New().
+		Model(&dbapi.KafkaRequest{}).
+		Where("id = ?", id).
+		Where("status = ?", constants2.KafkaRequestStatusDeprovisionPending.String()).
+		Updates(map[string]interface{}{
+			"status":                    kafkaRequest.StatusBeforeDeprovision,
+			"status_updated_at":         time.Now(),
+			"deprovision_reason":        "",
+			"status_before_deprovision": "",
+		})
+	if result.Error != nil {
+		return errors.NewWithCause(errors.ErrorGeneral, result.Error, "failed to cancel the deletion of kafka request %s", id)
+	}
+	if result.RowsAffected == 0 {
+		return errors.BadRequest("kafka request %s is not pending deletion", id)
+	}
+	k.kafkaRequestCache.Invalidate(id)
+
+	kafkaRequest.Status = kafkaRequest.StatusBeforeDeprovision
+	metrics.UpdateKafkaRequestsStatusSinceCreatedMetric(constants2.KafkaStatus(kafkaRequest.Status), kafkaRequest.ID, kafkaRequest.ClusterID, time.Since(kafkaRequest.CreatedAt))
+	k.emitLifecycleEvent(KafkaLifecycleEventStatusChanged, kafkaRequest)
+
+	return nil
+}
+
+func (k *kafkaService) PromoteExpiredDeprovisionPendingKafkas() (int64, *errors.ServiceError) {
+	result := k.connectionFactory.New().
+		Model(&dbapi.KafkaRequest{}).
+		Where("status = ?", constants2.KafkaRequestStatusDeprovisionPending.String()).
+		Where("status_updated_at < ?", time.Now().Add(-k.kafkaConfig.DeprovisionGracePeriod)).
+		Updates(map[string]interface{}{
+			"status":                    constants2.KafkaRequestStatusDeprovision.String(),
+			"status_updated_at":         time.Now(),
+			"status_before_deprovision": "",
+		})
+	if result.Error != nil {
+		return 0, errors.NewWithCause(errors.ErrorGeneral, result.Error, "failed to deprovision kafka requests pending deletion")
+	}
+
+	if result.RowsAffected > 0 {
+		k.kafkaRequestCache.InvalidateAll()
+		glog.Infof("deprovisioned %d kafka request(s) whose deletion has not been cancelled within %s", result.RowsAffected, k.kafkaConfig.DeprovisionGracePeriod)
+	}
+
+	return result.RowsAffected, nil
+}
+
+// getKafkaToDeprovision returns the kafka with the given id if the caller is an admin, an admin of the organisation of
+// the kafka or its owner
+func (k *kafkaService) getKafkaToDeprovision(ctx context.Context, id string) (*dbapi.KafkaRequest, *errors.ServiceError) {
 	if id == "" {
-		return errors.Validation("id is undefined")
+		return nil, errors.Validation("id is undefined")
 	}
 
 	// filter kafka request by owner to only retrieve request of the current authenticated user
 	claims, err := auth.GetClaimsFromContext(ctx)
 	if err != nil {
-		return errors.NewWithCause(errors.ErrorUnauthenticated, err, "user not authenticated")
+		return nil, errors.NewWithCause(errors.ErrorUnauthenticated, err, "user not authenticated")
 	}
 
 	dbConn := k.connectionFactory.New()
@@ -570,21 +1836,9 @@ func (k *kafkaService) RegisterKafkaDeprovisionJob(ctx context.Context, id strin
 
 	var kafkaRequest dbapi.KafkaRequest
 	if err := dbConn.First(&kafkaRequest).Error; err != nil {
-		return services.HandleGetError("KafkaResource", "id", id, err)
-	}
-	metrics.IncreaseKafkaTotalOperationsCountMetric(constants2.KafkaOperationDeprovision)
-
-	deprovisionStatus := constants2.KafkaRequestStatusDeprovision
-
-	if executed, err := k.UpdateStatus(id, deprovisionStatus); executed {
-		if err != nil {
-			return services.HandleGetError("KafkaResource", "id", id, err)
-		}
-		metrics.IncreaseKafkaSuccessOperationsCountMetric(constants2.KafkaOperationDeprovision)
-		metrics.UpdateKafkaRequestsStatusSinceCreatedMetric(deprovisionStatus, kafkaRequest.ID, kafkaRequest.ClusterID, time.Since(kafkaRequest.CreatedAt))
+		return nil, services.HandleGetError("KafkaResource", "id", id, err)
 	}
-
-	return nil
+	return &kafkaRequest, nil
 }
 
 func (k *kafkaService) DeprovisionKafkaForUsers(users []string) *errors.ServiceError {
@@ -592,7 +1846,11 @@ func (k *kafkaService) DeprovisionKafkaForUsers(users []string) *errors.ServiceE
 		Model(&dbapi.KafkaRequest{}).
 		Where("owner IN (?)", users).
 		Where("status NOT IN (?)", kafkaDeletionStatuses).
-		Update("status", constants2.KafkaRequestStatusDeprovision)
+		Updates(map[string]interface{}{
+			"status":             constants2.KafkaRequestStatusDeprovision,
+			"status_updated_at":  time.Now(),
+			"deprovision_reason": constants2.KafkaDeprovisionReasonUserOffboarded,
+		})
 
 	err := dbConn.Error
 	if err != nil {
@@ -600,6 +1858,7 @@ func (k *kafkaService) DeprovisionKafkaForUsers(users []string) *errors.ServiceE
 	}
 
 	if dbConn.RowsAffected >= 1 {
+		k.kafkaRequestCache.InvalidateAll()
 		glog.Infof("%v kafkas are now deprovisioning for users %v", dbConn.RowsAffected, users)
 		var counter int64 = 0
 		for ; counter < dbConn.RowsAffected; counter++ {
@@ -643,9 +1902,11 @@ func (k *kafkaService) DeprovisionExpiredKafkas() *errors.ServiceError {
 		if err != nil {
 			return errors.NewWithCause(errors.ErrorGeneral, err, "unable to deprovision expired kafkas")
 		}
-		if kafkaInstanceSize.LifespanSeconds != nil {
-			glog.V(10).Infof("Kafka size associated to kafka ID '%s' has '%d' lifespanSeconds", existingKafkaRequest.ID, *kafkaInstanceSize.LifespanSeconds)
-			expTime := existingKafkaRequest.GetExpirationTime(*kafkaInstanceSize.LifespanSeconds)
+		// the organisation of the kafka may have its own lifespan
+		lifespanSeconds := k.kafkaConfig.KafkaLifespan.GetLifespanSeconds(existingKafkaRequest.OrganisationId, kafkaInstanceSize.LifespanSeconds)
+		if lifespanSeconds != nil {
+			glog.V(10).Infof("Kafka ID '%s' has '%d' lifespanSeconds", existingKafkaRequest.ID, *lifespanSeconds)
+			expTime := existingKafkaRequest.GetExpirationTime(*lifespanSeconds)
 			glog.V(10).Infof("Expiration time of kafka ID '%s' is '%s'", existingKafkaRequest.ID, expTime)
 			if timeNow.After(*expTime) {
 				glog.V(10).Infof("Kafka ID '%s' has expired", existingKafkaRequest.ID)
@@ -659,11 +1920,16 @@ func (k *kafkaService) DeprovisionExpiredKafkas() *errors.ServiceError {
 	if len(kafkasToDeprovisionIDs) > 0 {
 		glog.V(10).Infof("Kafka IDs to mark with status %s: %+v", constants2.KafkaRequestStatusDeprovision, kafkasToDeprovisionIDs)
 		db = dbConn.Where("id IN (?)", kafkasToDeprovisionIDs).
-			Updates(map[string]interface{}{"status": constants2.KafkaRequestStatusDeprovision})
+			Updates(map[string]interface{}{
+				"status":             constants2.KafkaRequestStatusDeprovision,
+				"status_updated_at":  time.Now(),
+				"deprovision_reason": constants2.KafkaDeprovisionReasonExpired,
+			})
 		err = db.Error
 		if err != nil {
 			return errors.NewWithCause(errors.ErrorGeneral, err, "unable to deprovision expired kafkas")
 		}
+		k.kafkaRequestCache.Invalidate(kafkasToDeprovisionIDs...)
 		if db.RowsAffected >= 1 {
 			glog.Infof("%v kafka_request's lifespans are over their lifespan and have had their status updated to deprovisioning", db.RowsAffected)
 			var counter int64 = 0
@@ -680,8 +1946,9 @@ func (k *kafkaService) DeprovisionExpiredKafkas() *errors.ServiceError {
 func (k *kafkaService) Delete(kafkaRequest *dbapi.KafkaRequest) *errors.ServiceError {
 	dbConn := k.connectionFactory.New()
 
-	// if the we don't have the clusterID we can only delete the row from the database
-	if kafkaRequest.ClusterID != "" {
+	// if the we don't have the clusterID we can only delete the row from the database.
+	// The external resources are left behind in the test environments configured to skip their cleanup
+	if kafkaRequest.ClusterID != "" && !k.kafkaConfig.SkipExternalCleanupOnDelete {
 		// delete the kafka client in mas sso
 		if k.keycloakService.GetConfig().EnableAuthenticationOnKafka {
 			if kafkaRequest.CanaryServiceAccountClientID != "" {
@@ -715,6 +1982,9 @@ func (k *kafkaService) Delete(kafkaRequest *dbapi.KafkaRequest) *errors.ServiceE
 	if err := dbConn.Delete(kafkaRequest).Error; err != nil {
 		return errors.NewWithCause(errors.ErrorGeneral, err, "unable to delete kafka request with id %s", kafkaRequest.ID)
 	}
+	k.streamingUnitCountCache.Invalidate()
+	k.kafkaRequestCache.Invalidate(kafkaRequest.ID)
+	k.emitLifecycleEvent(KafkaLifecycleEventDeleted, kafkaRequest)
 
 	metrics.IncreaseKafkaTotalOperationsCountMetric(constants2.KafkaOperationDelete)
 	metrics.IncreaseKafkaSuccessOperationsCountMetric(constants2.KafkaOperationDelete)
@@ -722,26 +1992,89 @@ func (k *kafkaService) Delete(kafkaRequest *dbapi.KafkaRequest) *errors.ServiceE
 	return nil
 }
 
-// List returns all Kafka requests belonging to a user.
-func (k *kafkaService) List(ctx context.Context, listArgs *services.ListArguments) (dbapi.KafkaList, *api.PagingMeta, *errors.ServiceError) {
-	var kafkaRequestList dbapi.KafkaList
-	dbConn := k.connectionFactory.New()
-	pagingMeta := &api.PagingMeta{
-		Page: listArgs.Page,
-		Size: listArgs.Size,
+func (k *kafkaService) ForceDelete(ctx context.Context, id string) *errors.ServiceError {
+	claims, claimsErr := auth.GetClaimsFromContext(ctx)
+	if claimsErr != nil {
+		return errors.NewWithCause(errors.ErrorUnauthenticated, claimsErr, "user not authenticated")
 	}
+	admin, _ := claims.GetUsername()
 
-	claims, err := auth.GetClaimsFromContext(ctx)
+	metrics.IncreaseKafkaTotalOperationsCountMetric(constants2.KafkaOperationForceDelete)
+
+	kafkaRequest, err := k.GetById(id)
 	if err != nil {
-		return nil, nil, errors.NewWithCause(errors.ErrorUnauthenticated, err, "user not authenticated")
+		return err
 	}
 
-	if !auth.GetIsAdminFromContext(ctx) {
-		user, _ := claims.GetUsername()
-		if user == "" {
-			return nil, nil, errors.Unauthenticated("user not authenticated")
-		}
-
+	quotaService, factoryErr := k.quotaServiceFactory.GetQuotaService(api.QuotaType(kafkaRequest.QuotaType))
+	if factoryErr != nil {
+		glog.Warningf("force delete of kafka '%s': failed to get quota service: %v", id, factoryErr)
+	} else if quotaErr := quotaService.DeleteQuota(kafkaRequest.SubscriptionId); quotaErr != nil {
+		glog.Warningf("force delete of kafka '%s': failed to delete subscription '%s': %v", id, kafkaRequest.SubscriptionId, quotaErr)
+	}
+
+	if kafkaRequest.ClusterID != "" {
+		if k.keycloakService.GetConfig().EnableAuthenticationOnKafka && kafkaRequest.CanaryServiceAccountClientID != "" {
+			if keycloakErr := k.keycloakService.DeleteServiceAccountInternal(kafkaRequest.CanaryServiceAccountClientID); keycloakErr != nil {
+				glog.Warningf("force delete of kafka '%s': failed to delete canary service account '%s': %v", id, kafkaRequest.CanaryServiceAccountClientID, keycloakErr)
+			}
+		}
+
+		routes, routesErr := kafkaRequest.GetRoutes()
+		if routesErr != nil {
+			glog.Warningf("force delete of kafka '%s': failed to get routes: %v", id, routesErr)
+		} else if routes != nil && k.kafkaConfig.EnableKafkaCNAMERegistration {
+			if _, cnameErr := k.ChangeKafkaCNAMErecords(kafkaRequest, KafkaRoutesActionDelete); cnameErr != nil {
+				glog.Warningf("force delete of kafka '%s': failed to delete CNAME records: %v", id, cnameErr)
+			}
+		}
+	}
+
+	// the audit record is only kept if the kafka request is deleted
+	deletion := &dbapi.KafkaForceDeletion{
+		ID:             api.NewID(),
+		KafkaID:        kafkaRequest.ID,
+		Name:           kafkaRequest.Name,
+		Owner:          kafkaRequest.Owner,
+		OrganisationId: kafkaRequest.OrganisationId,
+		ClusterID:      kafkaRequest.ClusterID,
+		SubscriptionId: kafkaRequest.SubscriptionId,
+		Status:         kafkaRequest.Status,
+		DeletedBy:      admin,
+	}
+	if err := k.connectionFactory.New().Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(deletion).Error; err != nil {
+			return err
+		}
+		return tx.Unscoped().Delete(kafkaRequest).Error
+	}); err != nil {
+		return errors.NewWithCause(errors.ErrorGeneral, err, "unable to force delete kafka request with id %s", id)
+	}
+	k.streamingUnitCountCache.Invalidate()
+	k.kafkaRequestCache.Invalidate(id)
+	k.emitLifecycleEvent(KafkaLifecycleEventDeleted, kafkaRequest)
+
+	glog.Infof("kafka request '%s' has been force deleted by '%s'", id, admin)
+	metrics.IncreaseKafkaSuccessOperationsCountMetric(constants2.KafkaOperationForceDelete)
+
+	return nil
+}
+
+// List returns all Kafka requests belonging to a user.
+func (k *kafkaService) List(ctx context.Context, listArgs *services.ListArguments) (dbapi.KafkaList, *api.PagingMeta, *errors.ServiceError) {
+	dbConn := k.connectionFactory.New()
+
+	claims, err := auth.GetClaimsFromContext(ctx)
+	if err != nil {
+		return nil, nil, errors.NewWithCause(errors.ErrorUnauthenticated, err, "user not authenticated")
+	}
+
+	if !auth.GetIsAdminFromContext(ctx) {
+		user, _ := claims.GetUsername()
+		if user == "" {
+			return nil, nil, errors.Unauthenticated("user not authenticated")
+		}
+
 		orgId, _ := claims.GetOrgId()
 		filterByOrganisationId := auth.GetFilterByOrganisationFromContext(ctx)
 
@@ -755,6 +2088,211 @@ func (k *kafkaService) List(ctx context.Context, listArgs *services.ListArgument
 		}
 	}
 
+	return listKafkaRequests(dbConn, listArgs)
+}
+
+// KafkaWithClusterDetails is a kafka request along with the details of the data plane cluster hosting it.
+// The cluster details are empty when the kafka request is not assigned to a cluster
+type KafkaWithClusterDetails struct {
+	*dbapi.KafkaRequest
+	ClusterStatus api.ClusterStatus
+	ClusterDNS    string
+}
+
+func (k *kafkaService) ListWithClusterDetails(ctx context.Context, listArgs *services.ListArguments, includeClusterDetails bool) ([]*KafkaWithClusterDetails, *api.PagingMeta, *errors.ServiceError) {
+	kafkaRequests, pagingMeta, err := k.List(ctx, listArgs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	result := make([]*KafkaWithClusterDetails, 0, len(kafkaRequests))
+	clusterIDs := []string{}
+	for _, kafkaRequest := range kafkaRequests {
+		result = append(result, &KafkaWithClusterDetails{KafkaRequest: kafkaRequest})
+		if kafkaRequest.ClusterID != "" && !arrays.Contains(clusterIDs, kafkaRequest.ClusterID) {
+			clusterIDs = append(clusterIDs, kafkaRequest.ClusterID)
+		}
+	}
+
+	if !includeClusterDetails || len(clusterIDs) == 0 {
+		return result, pagingMeta, nil
+	}
+
+	// the clusters are looked up separately instead of being joined to the kafka requests query
+	// as the columns in the search and order by arguments would become ambiguous
+	var clusters []api.Cluster
+	if err := k.connectionFactory.New().
+		Select("cluster_id", "status", "cluster_dns").
+		Where("cluster_id IN ?", clusterIDs).
+		Find(&clusters).Error; err != nil {
+		return nil, nil, errors.NewWithCause(errors.ErrorGeneral, err, "unable to list the clusters of the kafka requests")
+	}
+
+	clustersByID := make(map[string]api.Cluster, len(clusters))
+	for _, cluster := range clusters {
+		clustersByID[cluster.ClusterID] = cluster
+	}
+	for _, kafka := range result {
+		if cluster, ok := clustersByID[kafka.ClusterID]; ok {
+			kafka.ClusterStatus = cluster.Status
+			kafka.ClusterDNS = cluster.ClusterDNS
+		}
+	}
+
+	return result, pagingMeta, nil
+}
+
+func (k *kafkaService) ListByRegion(provider string, region string, listArgs *services.ListArguments) (dbapi.KafkaList, *api.PagingMeta, *errors.ServiceError) {
+	dbConn := k.connectionFactory.New().
+		Where("cloud_provider = ?", provider).
+		Where("region = ?", region)
+
+	return listKafkaRequests(dbConn, listArgs)
+}
+
+func (k *kafkaService) ListByInstanceType(instanceType types.KafkaInstanceType, listArgs *services.ListArguments) (dbapi.KafkaList, *api.PagingMeta, *errors.ServiceError) {
+	dbConn := k.connectionFactory.New().
+		Where("instance_type = ?", instanceType.String())
+
+	return listKafkaRequests(dbConn, listArgs)
+}
+
+func (k *kafkaService) ListByQuotaType(quotaType string, listArgs *services.ListArguments) (dbapi.KafkaList, *api.PagingMeta, *errors.ServiceError) {
+	dbConn := k.connectionFactory.New().
+		Where("quota_type = ?", quotaType)
+
+	return listKafkaRequests(dbConn, listArgs)
+}
+
+func (k *kafkaService) ListReauthDisabled(listArgs *services.ListArguments) (dbapi.KafkaList, *api.PagingMeta, *errors.ServiceError) {
+	dbConn := k.connectionFactory.New().
+		Where("reauthentication_enabled = ?", false)
+
+	return listKafkaRequests(dbConn, listArgs)
+}
+
+func (k *kafkaService) ListByCreatedRange(from time.Time, to time.Time, listArgs *services.ListArguments) (dbapi.KafkaList, *api.PagingMeta, *errors.ServiceError) {
+	if from.After(to) {
+		return nil, nil, errors.Validation("the start of the range '%s' must not be after its end '%s'", from.Format(time.RFC3339), to.Format(time.RFC3339))
+	}
+
+	dbConn := k.connectionFactory.New().
+		Where("created_at BETWEEN ? AND ?", from, to)
+
+	return listKafkaRequests(dbConn, listArgs)
+}
+
+func (k *kafkaService) ListByDesiredStrimziVersion(version string, listArgs *services.ListArguments) (dbapi.KafkaList, *api.PagingMeta, *errors.ServiceError) {
+	if version == "" {
+		return nil, nil, errors.Validation("desired strimzi version is undefined")
+	}
+
+	dbConn := k.connectionFactory.New().
+		Where("desired_strimzi_version = ?", version)
+
+	return listKafkaRequests(dbConn, listArgs)
+}
+
+func (k *kafkaService) ListByBillingCloudAccountId(accountId string, listArgs *services.ListArguments) (dbapi.KafkaList, *api.PagingMeta, *errors.ServiceError) {
+	if accountId == "" {
+		return nil, nil, errors.Validation("billing cloud account id is undefined")
+	}
+
+	dbConn := k.connectionFactory.New().
+		Where("billing_cloud_account_id = ?", accountId)
+
+	return listKafkaRequests(dbConn, listArgs)
+}
+
+func (k *kafkaService) BumpStrimziVersion(ids []string, targetVersion string) (int64, *errors.ServiceError) {
+	if targetVersion == "" {
+		return 0, errors.Validation("target strimzi version is undefined")
+	}
+	if len(ids) == 0 {
+		return 0, nil
+	}
+
+	// the eligibility is computed before opening the transaction, the cluster lookups must not hold it open
+	var kafkas []*dbapi.KafkaRequest
+	if err := k.connectionFactory.New().
+		Where("id IN (?)", ids).
+		Where("status NOT IN (?)", kafkaUpdateIgnoredStatuses).
+		Find(&kafkas).Error; err != nil {
+		return 0, errors.NewWithCause(errors.ErrorGeneral, err, "failed to find the kafkas to bump")
+	}
+
+	now := time.Now()
+	clusters := map[string]*api.Cluster{}
+	var eligibleIds []string
+	for _, kafka := range kafkas {
+		if kafka.DesiredStrimziVersion == targetVersion {
+			continue
+		}
+		// upgrades of kafkas with a maintenance window can only be started within the window
+		if !kafka.IsInMaintenanceWindow(now) {
+			glog.Infof("skipping the strimzi version bump of kafka %s: it is outside of its maintenance window", kafka.ID)
+			continue
+		}
+		if kafka.ClusterID == "" {
+			glog.Infof("skipping the strimzi version bump of kafka %s: it is not assigned to a cluster", kafka.ID)
+			continue
+		}
+
+		cluster, found := clusters[kafka.ClusterID]
+		if !found {
+			var svcErr *errors.ServiceError
+			cluster, svcErr = k.clusterService.FindClusterByID(kafka.ClusterID)
+			if svcErr != nil {
+				return 0, svcErr
+			}
+			clusters[kafka.ClusterID] = cluster
+		}
+		if cluster == nil {
+			glog.Infof("skipping the strimzi version bump of kafka %s: cluster %s not found", kafka.ID, kafka.ClusterID)
+			continue
+		}
+
+		available, err := k.clusterService.IsStrimziKafkaVersionAvailableInCluster(cluster, targetVersion, kafka.DesiredKafkaVersion, kafka.DesiredKafkaIBPVersion)
+		if err != nil || !available {
+			glog.Infof("skipping the strimzi version bump of kafka %s: strimzi version %s is not available with kafka version %s and IBP version %s in cluster %s", kafka.ID, targetVersion, kafka.DesiredKafkaVersion, kafka.DesiredKafkaIBPVersion, kafka.ClusterID)
+			continue
+		}
+		eligibleIds = append(eligibleIds, kafka.ID)
+	}
+
+	if len(eligibleIds) == 0 {
+		return 0, nil
+	}
+
+	var bumped int64
+	if err := k.connectionFactory.New().Transaction(func(tx *gorm.DB) error {
+		// the status condition prevents the kafkas deleted since they have been read from being bumped
+		result := tx.Model(&dbapi.KafkaRequest{}).
+			Where("id IN (?)", eligibleIds).
+			Where("status NOT IN (?)", kafkaUpdateIgnoredStatuses).
+			Update("desired_strimzi_version", targetVersion)
+		if result.Error != nil {
+			return result.Error
+		}
+		bumped = result.RowsAffected
+		return nil
+	}); err != nil {
+		return 0, errors.NewWithCause(errors.ErrorGeneral, err, "failed to bump the strimzi version of kafkas")
+	}
+	k.kafkaRequestCache.Invalidate(eligibleIds...)
+
+	return bumped, nil
+}
+
+// listKafkaRequests applies the search query, ordering and paging of the given list arguments to the given query
+// and returns the matching kafka requests
+func listKafkaRequests(dbConn *gorm.DB, listArgs *services.ListArguments) (dbapi.KafkaList, *api.PagingMeta, *errors.ServiceError) {
+	var kafkaRequestList dbapi.KafkaList
+	pagingMeta := &api.PagingMeta{
+		Page: listArgs.Page,
+		Size: listArgs.Size,
+	}
+
 	// Apply search query
 	if len(listArgs.Search) > 0 {
 		searchDbQuery, err := coreServices.NewQueryParser().Parse(listArgs.Search)
@@ -792,11 +2330,72 @@ func (k *kafkaService) List(ctx context.Context, listArgs *services.ListArgument
 }
 
 func (k *kafkaService) GetManagedKafkaByClusterID(clusterID string) ([]managedkafka.ManagedKafka, *errors.ServiceError) {
-	dbConn := k.connectionFactory.New().
+	return k.listManagedKafkas(k.managedKafkasQuery(clusterID))
+}
+
+func (k *kafkaService) GetManagedKafkaByClusterIDChangedSince(clusterID string, since time.Time) ([]managedkafka.ManagedKafka, *errors.ServiceError) {
+	changed, err := k.listManagedKafkas(k.managedKafkasQuery(clusterID).Where("updated_at > ?", since))
+	if err != nil {
+		return nil, err
+	}
+
+	// the kafka requests soft deleted since the given time are returned as deleted, otherwise the data plane would
+	// never learn about their removal and keep their CR
+	removed, err := k.listManagedKafkas(k.connectionFactory.New().
+		Unscoped().
+		Where("cluster_id = ?", clusterID).
+		Where("bootstrap_server_host != ''").
+		Where("deleted_at > ?", since))
+	if err != nil {
+		return nil, err
+	}
+	for i := range removed {
+		removed[i].Spec.Deleted = true
+	}
+
+	return append(changed, removed...), nil
+}
+
+func (k *kafkaService) PauseReconciliation(id string) *errors.ServiceError {
+	return k.setReconciliationPaused(id, true)
+}
+
+func (k *kafkaService) ResumeReconciliation(id string) *errors.ServiceError {
+	return k.setReconciliationPaused(id, false)
+}
+
+func (k *kafkaService) setReconciliationPaused(id string, paused bool) *errors.ServiceError {
+	if id == "" {
+		return errors.Validation("id is undefined")
+	}
+
+	// the updated_at timestamp is bumped so that the CR of a paused or resumed kafka is sent again to the agents syncing
+	// changes
+	result := k.connectionFactory.New().
+		Model(&dbapi.KafkaRequest{}).
+		Where("id = ?", id).
+		Update("reconciliation_paused", paused)
+	if result.Error != nil {
+		return errors.NewWithCause(errors.ErrorGeneral, result.Error, "failed to update the reconciliation of kafka request %s", id)
+	}
+	if result.RowsAffected == 0 {
+		return errors.NotFound("KafkaResource with id='%s' not found", id)
+	}
+	k.kafkaRequestCache.Invalidate(id)
+
+	return nil
+}
+
+// managedKafkasQuery returns the query of the kafka requests of the given cluster having a ManagedKafka CR
+func (k *kafkaService) managedKafkasQuery(clusterID string) *gorm.DB {
+	return k.connectionFactory.New().
 		Where("cluster_id = ?", clusterID).
 		Where("status IN (?)", kafkaManagedCRStatuses).
 		Where("bootstrap_server_host != ''")
+}
 
+// listManagedKafkas builds the ManagedKafka CRs of the kafka requests returned by the given query
+func (k *kafkaService) listManagedKafkas(dbConn *gorm.DB) ([]managedkafka.ManagedKafka, *errors.ServiceError) {
 	var kafkaRequestList dbapi.KafkaList
 	if err := dbConn.Find(&kafkaRequestList).Error; err != nil {
 		return nil, errors.NewWithCause(errors.ErrorGeneral, err, "unable to list kafka requests")
@@ -857,124 +2456,624 @@ func (k *kafkaService) GenerateReservedManagedKafkasByClusterID(clusterID string
 
 	}
 
-	return reservedKafkas, nil
-}
+	return reservedKafkas, nil
+}
+
+// StreamingUnitUsage is the number of streaming units of an instance type held by the reserved
+// and by the real kafkas of a cluster
+type StreamingUnitUsage struct {
+	ClusterID     string
+	CloudProvider string
+	Region        string
+	InstanceType  string
+	Reserved      int
+	Used          int
+}
+
+func (k *kafkaService) GetStreamingUnitUsageByClusterID(clusterID string) ([]StreamingUnitUsage, *errors.ServiceError) {
+	reservedKafkas, svcErr := k.GenerateReservedManagedKafkasByClusterID(clusterID)
+	if svcErr != nil {
+		return nil, svcErr
+	}
+
+	reservedStreamingUnits := map[string]int{}
+	for _, reservedKafka := range reservedKafkas {
+		instanceType := reservedKafka.Labels["bf2.org/kafkaInstanceProfileType"]
+		nodePrewarmingConfig, ok := k.dataplaneClusterConfig.NodePrewarmingConfig.ForInstanceType(instanceType)
+		if !ok {
+			continue
+		}
+		instanceSize, err := k.kafkaConfig.GetKafkaInstanceSize(instanceType, nodePrewarmingConfig.BaseStreamingUnitSize)
+		if err != nil {
+			return nil, errors.NewWithCause(errors.ErrorGeneral, err, "failed to get the size of the reserved kafkas of instance type %s", instanceType)
+		}
+		reservedStreamingUnits[instanceType] += instanceSize.CapacityConsumed
+	}
+
+	streamingUnitCounts, err := k.clusterService.FindCachedStreamingUnitCountByClusterAndInstanceType(false)
+	if err != nil {
+		return nil, errors.NewWithCause(errors.ErrorGeneral, err, "failed to count the streaming units of cluster %s", clusterID)
+	}
+
+	var usage []StreamingUnitUsage
+	for _, count := range streamingUnitCounts {
+		if count.ClusterId != clusterID {
+			continue
+		}
+		usage = append(usage, StreamingUnitUsage{
+			ClusterID:     count.ClusterId,
+			CloudProvider: count.CloudProvider,
+			Region:        count.Region,
+			InstanceType:  count.InstanceType,
+			Reserved:      reservedStreamingUnits[count.InstanceType],
+			Used:          int(count.Count),
+		})
+	}
+
+	return usage, nil
+}
+
+func (k *kafkaService) Update(kafkaRequest *dbapi.KafkaRequest) *errors.ServiceError {
+	dbConn := k.connectionFactory.New().
+		Model(kafkaRequest).
+		Where("status not IN (?)", kafkaUpdateIgnoredStatuses) // ignore updates of kafka under or pending deletion
+
+	if err := dbConn.Updates(kafkaRequest).Error; err != nil {
+		return errors.NewWithCause(errors.ErrorGeneral, err, "Failed to update kafka")
+	}
+	k.kafkaRequestCache.Invalidate(kafkaRequest.ID)
+
+	return nil
+}
+
+func (k *kafkaService) Updates(kafkaRequest *dbapi.KafkaRequest, fields map[string]interface{}) *errors.ServiceError {
+	dbConn := k.connectionFactory.New().
+		Model(kafkaRequest).
+		Where("status not IN (?)", kafkaUpdateIgnoredStatuses) // ignore updates of kafka under or pending deletion
+
+	if err := dbConn.Updates(fields).Error; err != nil {
+		return errors.NewWithCause(errors.ErrorGeneral, err, "Failed to update kafka")
+	}
+	k.kafkaRequestCache.Invalidate(kafkaRequest.ID)
+
+	return nil
+}
+
+func (k *kafkaService) SetReauthenticationBulk(ids []string, enabled bool) (int64, *errors.ServiceError) {
+	if len(ids) == 0 {
+		return 0, nil
+	}
+
+	// a single statement updates all the kafkas or none of them
+	result := k.connectionFactory.New().
+		Model(&dbapi.KafkaRequest{}).
+		Where("id IN (?)", ids).
+		Where("status not IN (?)", kafkaUpdateIgnoredStatuses). // ignore updates of kafka under or pending deletion
+		Updates(map[string]interface{}{"reauthentication_enabled": enabled})
+	if result.Error != nil {
+		return 0, errors.NewWithCause(errors.ErrorGeneral, result.Error, "failed to update the reauthentication of kafkas")
+	}
+	k.kafkaRequestCache.Invalidate(ids...)
+
+	return result.RowsAffected, nil
+}
+
+func (k *kafkaService) GetQuotaCost(instanceType types.KafkaInstanceType, sizeId string) (int, *errors.ServiceError) {
+	kafkaInstanceType, err := k.kafkaConfig.SupportedInstanceTypes.Configuration.GetKafkaInstanceTypeByID(instanceType.String())
+	if err != nil {
+		return 0, errors.InstanceTypeNotSupported(err.Error())
+	}
+
+	size, err := kafkaInstanceType.GetKafkaInstanceSizeByID(sizeId)
+	if err != nil {
+		return 0, errors.InstancePlanNotSupported(err.Error())
+	}
+
+	return size.QuotaConsumed, nil
+}
+
+func (k *kafkaService) SetKafkaStorageSize(id string, size string) *errors.ServiceError {
+	kafkaRequest, err := k.GetById(id)
+	if err != nil {
+		return err
+	}
+
+	requestedSize, parseErr := resource.ParseQuantity(size)
+	if parseErr != nil {
+		return errors.FieldValidationError("unable to parse requested storage size: '%s'", size)
+	}
+
+	currentSize, parseErr := resource.ParseQuantity(kafkaRequest.KafkaStorageSize)
+	if parseErr != nil {
+		return errors.FieldValidationError("unable to parse current storage size: '%s'", kafkaRequest.KafkaStorageSize)
+	}
+
+	if requestedSize.Cmp(currentSize) < 0 {
+		return errors.FieldValidationError("requested storage size '%s' should not be smaller than the current storage size '%s'", size, kafkaRequest.KafkaStorageSize)
+	}
+
+	instanceSize, sizeErr := k.kafkaConfig.GetKafkaInstanceSize(kafkaRequest.InstanceType, kafkaRequest.SizeId)
+	if sizeErr != nil {
+		return errors.NewWithCause(errors.ErrorInstancePlanNotSupported, sizeErr, "unable to get the instance size of kafka '%s'", id)
+	}
+
+	maxSize, parseErr := instanceSize.MaxDataRetentionSize.ToK8Quantity()
+	if parseErr != nil {
+		return errors.NewWithCause(errors.ErrorGeneral, parseErr, "unable to parse max data retention size of kafka '%s'", id)
+	}
+
+	if requestedSize.Cmp(*maxSize) > 0 {
+		return errors.FieldValidationError("requested storage size '%s' should not be greater than the max data retention size '%s'", size, instanceSize.MaxDataRetentionSize.String())
+	}
+
+	if instanceSize.MinDataRetentionSize != "" {
+		minSize, parseErr := instanceSize.MinDataRetentionSize.ToK8Quantity()
+		if parseErr != nil {
+			return errors.NewWithCause(errors.ErrorGeneral, parseErr, "unable to parse min data retention size of kafka '%s'", id)
+		}
+
+		if requestedSize.Cmp(*minSize) < 0 {
+			return errors.FieldValidationError("requested storage size '%s' should not be smaller than the min data retention size '%s'", size, instanceSize.MinDataRetentionSize.String())
+		}
+	}
+
+	return k.Updates(kafkaRequest, map[string]interface{}{"kafka_storage_size": size})
+}
+
+func (k *kafkaService) SetMaintenanceWindow(id string, day string, start string, end string) *errors.ServiceError {
+	if day != "" || start != "" || end != "" {
+		if err := dbapi.ValidateMaintenanceWindow(day, start, end); err != nil {
+			return errors.FieldValidationError(err.Error())
+		}
+	}
+
+	kafkaRequest, err := k.GetById(id)
+	if err != nil {
+		return err
+	}
+
+	return k.Updates(kafkaRequest, map[string]interface{}{
+		"maintenance_window_day":   day,
+		"maintenance_window_start": start,
+		"maintenance_window_end":   end,
+	})
+}
+
+func (k *kafkaService) SetAnnotations(id string, annotations map[string]string) *errors.ServiceError {
+	if err := dbapi.ValidateAnnotations(annotations); err != nil {
+		return errors.FieldValidationError(err.Error())
+	}
+
+	kafkaRequest, err := k.GetById(id)
+	if err != nil {
+		return err
+	}
+
+	if err := kafkaRequest.SetAnnotations(annotations); err != nil {
+		return errors.NewWithCause(errors.ErrorGeneral, err, "failed to set annotations of kafka request '%s'", id)
+	}
+
+	return k.Updates(kafkaRequest, map[string]interface{}{"annotations": kafkaRequest.Annotations})
+}
+
+func (k *kafkaService) SetMaxConnectionAttemptsPerSecOverride(id string, override *int) *errors.ServiceError {
+	if override != nil && *override <= 0 {
+		return errors.FieldValidationError("max connection attempts per second override must be greater than 0, got %d", *override)
+	}
+
+	kafkaRequest, err := k.GetById(id)
+	if err != nil {
+		return err
+	}
+
+	return k.Updates(kafkaRequest, map[string]interface{}{"max_connection_attempts_per_sec_override": override})
+}
+
+func (k *kafkaService) SetCapacityConsumedOverride(id string, override *int) *errors.ServiceError {
+	if override != nil && *override <= 0 {
+		return errors.FieldValidationError("capacity consumed override must be greater than 0, got %d", *override)
+	}
+
+	kafkaRequest, err := k.GetById(id)
+	if err != nil {
+		return err
+	}
+
+	return k.Updates(kafkaRequest, map[string]interface{}{"capacity_consumed_override": override})
+}
+
+func (k *kafkaService) CancelUpgrade(id string) *errors.ServiceError {
+	kafkaRequest, err := k.GetById(id)
+	if err != nil {
+		return err
+	}
+
+	if kafkaRequest.ActualStrimziVersion == "" || kafkaRequest.ActualKafkaVersion == "" || kafkaRequest.ActualKafkaIBPVersion == "" {
+		return errors.BadRequest("unable to cancel the upgrade of kafka '%s': the actual strimzi, kafka and kafka ibp versions must be known", id)
+	}
+
+	glog.Infof("cancelling upgrade of kafka '%s': reverting desired versions (strimzi: '%s', kafka: '%s', kafka ibp: '%s') to the actual versions (strimzi: '%s', kafka: '%s', kafka ibp: '%s')",
+		id, kafkaRequest.DesiredStrimziVersion, kafkaRequest.DesiredKafkaVersion, kafkaRequest.DesiredKafkaIBPVersion,
+		kafkaRequest.ActualStrimziVersion, kafkaRequest.ActualKafkaVersion, kafkaRequest.ActualKafkaIBPVersion)
+
+	return k.Updates(kafkaRequest, map[string]interface{}{
+		"desired_strimzi_version":   kafkaRequest.ActualStrimziVersion,
+		"desired_kafka_version":     kafkaRequest.ActualKafkaVersion,
+		"desired_kafka_ibp_version": kafkaRequest.ActualKafkaIBPVersion,
+		"strimzi_upgrading":         false,
+		"kafka_upgrading":           false,
+		"kafka_ibp_upgrading":       false,
+		"upgrade_started_at":        nil,
+	})
+}
+
+func (k *kafkaService) VerifyAndUpdateKafkaAdmin(ctx context.Context, kafkaRequest *dbapi.KafkaRequest) *errors.ServiceError {
+	if !auth.GetIsAdminFromContext(ctx) {
+		return errors.New(errors.ErrorUnauthenticated, "User not authenticated")
+	}
+
+	// upgrades of kafkas with a maintenance window can only be started within the window
+	if kafkaRequest.HasMaintenanceWindow() && !kafkaRequest.IsInMaintenanceWindow(time.Now()) {
+		existingKafkaRequest, err := k.GetById(kafkaRequest.ID)
+		if err != nil {
+			return err
+		}
+		if existingKafkaRequest.DesiredStrimziVersion != kafkaRequest.DesiredStrimziVersion ||
+			existingKafkaRequest.DesiredKafkaVersion != kafkaRequest.DesiredKafkaVersion ||
+			existingKafkaRequest.DesiredKafkaIBPVersion != kafkaRequest.DesiredKafkaIBPVersion {
+			return errors.New(errors.ErrorValidation, "Unable to upgrade kafka '%s' outside of its maintenance window (%s UTC)", kafkaRequest.ID, kafkaRequest.GetMaintenanceWindow())
+		}
+	}
+
+	// only updated specified columns to avoid changing other columns e.g Status
+	updatableFields := map[string]interface{}{
+		"kafka_storage_size":        kafkaRequest.KafkaStorageSize,
+		"desired_strimzi_version":   kafkaRequest.DesiredStrimziVersion,
+		"desired_kafka_version":     kafkaRequest.DesiredKafkaVersion,
+		"desired_kafka_ibp_version": kafkaRequest.DesiredKafkaIBPVersion,
+		"status":                    kafkaRequest.Status,
+	}
+
+	dbConn := k.connectionFactory.New().
+		Model(kafkaRequest)
+
+	if err := dbConn.Updates(updatableFields).Error; err != nil {
+		return errors.NewWithCause(errors.ErrorGeneral, err, "Failed to update kafka")
+	}
+	k.kafkaRequestCache.Invalidate(kafkaRequest.ID)
+
+	return nil
+}
+
+func (k *kafkaService) RetryFailed(id string) *errors.ServiceError {
+	kafkaRequest, err := k.GetById(id)
+	if err != nil {
+		return err
+	}
+
+	if kafkaRequest.Status != constants2.KafkaRequestStatusFailed.String() {
+		return errors.BadRequest("unable to retry kafka '%s': only failed kafkas can be retried, its status is '%s'", id, kafkaRequest.Status)
+	}
+
+	// the capacity check and the placement must not race with the registrations in the same region
+	unlock, err := k.lockRegistration(kafkaRequest)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	hasCapacity, err := k.hasAvailableCapacityInRegion(kafkaRequest, true)
+	if err != nil {
+		return err
+	}
+	if !hasCapacity {
+		logger.Logger.Warningf("Capacity exhausted in '%s' region for '%s' instance type, unable to retry kafka '%s'", kafkaRequest.Region, kafkaRequest.InstanceType, id)
+		return errors.TooManyKafkaInstancesReached("Region %s cannot accept instance type: %s at this moment", kafkaRequest.Region, kafkaRequest.InstanceType)
+	}
+
+	// a new placement id makes the kas-fleetshard-operator place the managed kafka again rather than keeping it rejected
+	updates := map[string]interface{}{"failed_reason": "", "placement_id": api.NewID()}
+	if kafkaRequest.ClusterID != "" {
+		// the managed kafka of the failed kafka is still on its cluster, so the kafka is kept there
+		cluster, err := k.clusterService.FindClusterByID(kafkaRequest.ClusterID)
+		if err != nil {
+			return err
+		}
+		if cluster == nil || cluster.Status != api.ClusterReady {
+			return errors.TooManyKafkaInstancesReached("cluster %s of kafka %s cannot accept kafkas at this moment", kafkaRequest.ClusterID, id)
+		}
+	} else if !k.dataplaneClusterConfig.IsDataPlaneAutoScalingEnabled() {
+		cluster, e := k.clusterPlacementStrategy.FindCluster(kafkaRequest)
+		if e != nil || cluster == nil {
+			if e != nil {
+				logger.Logger.Error(fmt.Errorf("no available cluster found to retry kafka '%s': %w", id, e))
+			}
+			return errors.TooManyKafkaInstancesReached("Region %s cannot accept instance type: %s at this moment", kafkaRequest.Region, kafkaRequest.InstanceType)
+		}
+		updates["cluster_id"] = cluster.ClusterID
+	}
+
+	glog.Infof("retrying failed kafka '%s' (failed reason: '%s')", id, kafkaRequest.FailedReason)
+	if _, err := k.updateStatus(id, constants2.KafkaRequestStatusAccepted, updates); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (k *kafkaService) UpdateStatus(id string, status constants2.KafkaStatus) (bool, *errors.ServiceError) {
+	return k.updateStatus(id, status, nil)
+}
+
+// updateStatus is the same as UpdateStatus but also updates the given fields along with the status.
+// The status since created metric is only updated here, when the status actually changes, so that callers don't have
+// to and each transition is reported once.
+func (k *kafkaService) updateStatus(id string, status constants2.KafkaStatus, fields map[string]interface{}) (bool, *errors.ServiceError) {
+	dbConn := k.connectionFactory.New()
+
+	kafka, err := k.GetById(id)
+	if err != nil {
+		return true, errors.NewWithCause(errors.ErrorGeneral, err, "failed to update status")
+	}
+	// only allow to change the status to "deleting" if the cluster is already in "deprovision" status
+	if kafka.Status == constants2.KafkaRequestStatusDeprovision.String() && status != constants2.KafkaRequestStatusDeleting {
+		return false, errors.GeneralError("failed to update status: cluster is deprovisioning")
+	}
+	// a kafka pending deletion can only be deprovisioned, its deletion is cancelled by CancelDeprovision
+	if kafka.Status == constants2.KafkaRequestStatusDeprovisionPending.String() && status != constants2.KafkaRequestStatusDeprovision {
+		return false, errors.GeneralError("failed to update status: cluster is pending deletion")
+	}
+
+	if kafka.Status == status.String() {
+		// no update needed
+		return false, errors.GeneralError("failed to update status: the cluster %s is already in %s state", id, status.String())
+	}
+
+	updates := map[string]interface{}{"status": status, "status_updated_at": time.Now()}
+	for field, value := range fields {
+		updates[field] = value
+	}
+	if err := dbConn.Model(&dbapi.KafkaRequest{Meta: api.Meta{ID: id}}).Updates(updates).Error; err != nil {
+		return true, errors.NewWithCause(errors.ErrorGeneral, err, "Failed to update kafka status")
+	}
+	k.kafkaRequestCache.Invalidate(id)
+	kafka.Status = status.String()
+	metrics.UpdateKafkaRequestsStatusSinceCreatedMetric(status, kafka.ID, kafka.ClusterID, time.Since(kafka.CreatedAt))
+	k.emitLifecycleEvent(KafkaLifecycleEventStatusChanged, kafka)
+
+	return true, nil
+}
+
+func (k *kafkaService) GetDeprovisionReason(id string) (constants2.KafkaDeprovisionReason, *errors.ServiceError) {
+	if id == "" {
+		return "", errors.Validation("id is undefined")
+	}
+
+	var kafkaRequest dbapi.KafkaRequest
+	if err := k.connectionFactory.New().Select("deprovision_reason").Where("id = ?", id).First(&kafkaRequest).Error; err != nil {
+		return "", services.HandleGetError("KafkaResource", "id", id, err)
+	}
+	return constants2.KafkaDeprovisionReason(kafkaRequest.DeprovisionReason), nil
+}
+
+// KafkaBillingModel is how a kafka is billed
+type KafkaBillingModel struct {
+	QuotaType string
+	// BillingModel is the AMS billing model of the kafka, e.g. "standard" or "marketplace-aws". The kafkas that are not
+	// billed through AMS have the "standard" billing model.
+	BillingModel string
+	// Marketplace is the cloud provider of the marketplace the kafka is billed through (e.g. "aws", "rhm" or "azure").
+	// It is empty unless the kafka is billed through a marketplace, as is BillingCloudAccountId.
+	Marketplace           string
+	BillingCloudAccountId string
+}
+
+// marketplaceBillingModels are the AMS billing models of the kafkas billed through the marketplace of each cloud provider
+var marketplaceBillingModels = map[string]amsv1.BillingModel{
+	"aws":   amsv1.BillingModelMarketplaceAWS,
+	"rhm":   amsv1.BillingModelMarketplace,
+	"azure": amsv1.BillingModelMarketplaceAzure,
+}
+
+func (k *kafkaService) GetBillingModel(ctx context.Context, id string) (*KafkaBillingModel, *errors.ServiceError) {
+	kafkaRequest, err := k.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	billingModel := &KafkaBillingModel{
+		QuotaType:             kafkaRequest.QuotaType,
+		BillingModel:          string(amsv1.BillingModelStandard),
+		Marketplace:           kafkaRequest.Marketplace,
+		BillingCloudAccountId: kafkaRequest.BillingCloudAccountId,
+	}
+	if kafkaRequest.QuotaType != api.AMSQuotaType.String() {
+		return billingModel, nil
+	}
 
-func (k *kafkaService) Update(kafkaRequest *dbapi.KafkaRequest) *errors.ServiceError {
-	dbConn := k.connectionFactory.New().
-		Model(kafkaRequest).
-		Where("status not IN (?)", kafkaDeletionStatuses) // ignore updates of kafka under deletion
+	// the marketplace billing model stored with the kafka does not tell which marketplace it is billed through
+	if marketplaceBillingModel, ok := marketplaceBillingModels[kafkaRequest.Marketplace]; ok {
+		billingModel.BillingModel = string(marketplaceBillingModel)
+	} else if kafkaRequest.BillingModel != "" {
+		billingModel.BillingModel = kafkaRequest.BillingModel
+	}
+	return billingModel, nil
+}
 
-	if err := dbConn.Updates(kafkaRequest).Error; err != nil {
-		return errors.NewWithCause(errors.ErrorGeneral, err, "Failed to update kafka")
+func (k *kafkaService) ValidateRoutes(routes []dbapi.DataPlaneKafkaRoute) *errors.ServiceError {
+	var invalidRoutes []string
+	for i, r := range routes {
+		var reasons []string
+		if r.Domain == "" {
+			reasons = append(reasons, "domain is empty")
+		} else if errs := k8sValidation.IsDNS1123Subdomain(r.Domain); len(errs) > 0 {
+			reasons = append(reasons, fmt.Sprintf("domain '%s' is not a valid DNS name", r.Domain))
+		}
+		if r.Router == "" {
+			reasons = append(reasons, "router is empty")
+		}
+		if r.Apex && r.RouterHostedZoneId == "" {
+			reasons = append(reasons, "router hosted zone id is empty for an apex route")
+		}
+		if len(reasons) > 0 {
+			invalidRoutes = append(invalidRoutes, fmt.Sprintf("route %d: %s", i, strings.Join(reasons, ", ")))
+		}
 	}
 
+	if len(invalidRoutes) > 0 {
+		return errors.Validation("invalid routes: %s", strings.Join(invalidRoutes, "; "))
+	}
 	return nil
 }
 
-func (k *kafkaService) Updates(kafkaRequest *dbapi.KafkaRequest, fields map[string]interface{}) *errors.ServiceError {
-	dbConn := k.connectionFactory.New().
-		Model(kafkaRequest).
-		Where("status not IN (?)", kafkaDeletionStatuses) // ignore updates of kafka under deletion
+func (k *kafkaService) ChangeKafkaCNAMErecords(kafkaRequest *dbapi.KafkaRequest, action KafkaRoutesAction) (*route53.ChangeResourceRecordSetsOutput, *errors.ServiceError) {
+	routes, err := kafkaRequest.GetRoutes()
+	if routes == nil || err != nil {
+		return nil, errors.NewWithCause(errors.ErrorGeneral, err, "failed to get routes")
+	}
 
-	if err := dbConn.Updates(fields).Error; err != nil {
-		return errors.NewWithCause(errors.ErrorGeneral, err, "Failed to update kafka")
+	if validationErr := k.ValidateRoutes(routes); validationErr != nil {
+		return nil, validationErr
 	}
 
-	return nil
-}
+	domainRecordBatch := buildKafkaClusterCNAMESRecordBatch(routes, string(action))
 
-func (k *kafkaService) VerifyAndUpdateKafkaAdmin(ctx context.Context, kafkaRequest *dbapi.KafkaRequest) *errors.ServiceError {
-	if !auth.GetIsAdminFromContext(ctx) {
-		return errors.New(errors.ErrorUnauthenticated, "User not authenticated")
+	route53Region, err := k.getRoute53RegionFromKafkaRequest(kafkaRequest)
+	if err != nil {
+		return nil, errors.NewWithCause(errors.ErrorGeneral, err, "error getting route 53 region from kafka request")
 	}
 
-	// only updated specified columns to avoid changing other columns e.g Status
-	updatableFields := map[string]interface{}{
-		"kafka_storage_size":        kafkaRequest.KafkaStorageSize,
-		"desired_strimzi_version":   kafkaRequest.DesiredStrimziVersion,
-		"desired_kafka_version":     kafkaRequest.DesiredKafkaVersion,
-		"desired_kafka_ibp_version": kafkaRequest.DesiredKafkaIBPVersion,
-		"status":                    kafkaRequest.Status,
+	awsClient, err := k.getRoute53Client(route53Region)
+	if err != nil {
+		return nil, errors.NewWithCause(errors.ErrorGeneral, err, "Unable to create aws client")
 	}
 
-	dbConn := k.connectionFactory.New().
-		Model(kafkaRequest)
+	changeRecordsOutput, err := k.changeResourceRecordSets(awsClient, kafkaRequest.CloudProvider, domainRecordBatch)
+	if err != nil {
+		return nil, errors.NewWithCause(errors.ErrorGeneral, err, "Unable to create domain record sets")
+	}
 
-	if err := dbConn.Updates(updatableFields).Error; err != nil {
-		return errors.NewWithCause(errors.ErrorGeneral, err, "Failed to update kafka")
+	return changeRecordsOutput, nil
+}
+
+// changeResourceRecordSets sends the record changes to the hosted zone of the domain of the given cloud provider
+func (k *kafkaService) changeResourceRecordSets(awsClient aws.AWSClient, cloudProvider string, recordChangeBatch *route53.ChangeBatch) (*route53.ChangeResourceRecordSetsOutput, error) {
+	domainName, hostedZoneID := k.kafkaConfig.GetKafkaDomain(cloudProvider)
+	if hostedZoneID != "" {
+		return awsClient.ChangeResourceRecordSetsInHostedZone(hostedZoneID, recordChangeBatch)
 	}
+	return awsClient.ChangeResourceRecordSets(domainName, recordChangeBatch)
+}
 
-	return nil
+// cnameChangeBatchKey identifies the kafkas whose CNAME records can be changed in a single Route53 change
+type cnameChangeBatchKey struct {
+	route53Region string
+	cloudProvider string
 }
 
-func (k *kafkaService) UpdateStatus(id string, status constants2.KafkaStatus) (bool, *errors.ServiceError) {
-	dbConn := k.connectionFactory.New()
+func (k *kafkaService) ChangeKafkaCNAMErecordsBatch(kafkaRequests []*dbapi.KafkaRequest, action KafkaRoutesAction) map[string]*CNAMEChangeResult {
+	results := map[string]*CNAMEChangeResult{}
+	kafkasPerBatch := map[cnameChangeBatchKey][]*dbapi.KafkaRequest{}
+	changesPerBatch := map[cnameChangeBatchKey][]*route53.Change{}
 
-	if kafka, err := k.GetById(id); err != nil {
-		return true, errors.NewWithCause(errors.ErrorGeneral, err, "failed to update status")
-	} else {
-		// only allow to change the status to "deleting" if the cluster is already in "deprovision" status
-		if kafka.Status == constants2.KafkaRequestStatusDeprovision.String() && status != constants2.KafkaRequestStatusDeleting {
-			return false, errors.GeneralError("failed to update status: cluster is deprovisioning")
+	for _, kafkaRequest := range kafkaRequests {
+		routes, err := kafkaRequest.GetRoutes()
+		if routes == nil || err != nil {
+			results[kafkaRequest.ID] = &CNAMEChangeResult{Error: errors.NewWithCause(errors.ErrorGeneral, err, "failed to get routes")}
+			continue
 		}
 
-		if kafka.Status == status.String() {
-			// no update needed
-			return false, errors.GeneralError("failed to update status: the cluster %s is already in %s state", id, status.String())
+		if validationErr := k.ValidateRoutes(routes); validationErr != nil {
+			results[kafkaRequest.ID] = &CNAMEChangeResult{Error: validationErr}
+			continue
+		}
+
+		route53Region, err := k.getRoute53RegionFromKafkaRequest(kafkaRequest)
+		if err != nil {
+			results[kafkaRequest.ID] = &CNAMEChangeResult{Error: errors.NewWithCause(errors.ErrorGeneral, err, "error getting route 53 region from kafka request")}
+			continue
 		}
+
+		// kafkas of different cloud providers may use different hosted zones
+		key := cnameChangeBatchKey{route53Region: route53Region, cloudProvider: kafkaRequest.CloudProvider}
+		kafkasPerBatch[key] = append(kafkasPerBatch[key], kafkaRequest)
+		changesPerBatch[key] = append(changesPerBatch[key], buildKafkaClusterCNAMESRecordBatch(routes, string(action)).Changes...)
 	}
 
-	if err := dbConn.Model(&dbapi.KafkaRequest{Meta: api.Meta{ID: id}}).Update("status", status).Error; err != nil {
-		return true, errors.NewWithCause(errors.ErrorGeneral, err, "Failed to update kafka status")
+	for key, kafkas := range kafkasPerBatch {
+		result := &CNAMEChangeResult{Region: key.route53Region}
+
+		awsClient, err := k.getRoute53Client(key.route53Region)
+		if err != nil {
+			result.Error = errors.NewWithCause(errors.ErrorGeneral, err, "Unable to create aws client")
+		} else {
+			changeRecordsOutput, err := k.changeResourceRecordSets(awsClient, key.cloudProvider, &route53.ChangeBatch{Changes: changesPerBatch[key]})
+			if err != nil {
+				result.Error = errors.NewWithCause(errors.ErrorGeneral, err, "Unable to create domain record sets")
+			} else if changeRecordsOutput != nil && changeRecordsOutput.ChangeInfo != nil {
+				result.ChangeId = changeRecordsOutput.ChangeInfo.Id
+				result.ChangeStatus = changeRecordsOutput.ChangeInfo.Status
+			}
+		}
+
+		for _, kafkaRequest := range kafkas {
+			kafkaResult := *result
+			results[kafkaRequest.ID] = &kafkaResult
+		}
 	}
 
-	return true, nil
+	return results
 }
 
-func (k *kafkaService) ChangeKafkaCNAMErecords(kafkaRequest *dbapi.KafkaRequest, action KafkaRoutesAction) (*route53.ChangeResourceRecordSetsOutput, *errors.ServiceError) {
-	routes, err := kafkaRequest.GetRoutes()
-	if routes == nil || err != nil {
-		return nil, errors.NewWithCause(errors.ErrorGeneral, err, "failed to get routes")
+func (k *kafkaService) RecreateRoutes(id string) *errors.ServiceError {
+	kafkaRequest, err := k.GetById(id)
+	if err != nil {
+		return err
 	}
 
-	domainRecordBatch := buildKafkaClusterCNAMESRecordBatch(routes, string(action))
-
-	awsConfig := aws.Config{
-		AccessKeyID:     k.awsConfig.Route53AccessKey,
-		SecretAccessKey: k.awsConfig.Route53SecretAccessKey,
+	steadyStatuses := []string{constants2.KafkaRequestStatusReady.String(), constants2.KafkaRequestStatusSuspended.String()}
+	if !arrays.Contains(steadyStatuses, kafkaRequest.Status) {
+		return errors.BadRequest("unable to recreate routes of kafka '%s' in '%s' status. Supported statuses are: %v", id, kafkaRequest.Status, steadyStatuses)
 	}
 
-	route53Region, err := k.getRoute53RegionFromKafkaRequest(kafkaRequest)
-	if err != nil {
-		return nil, errors.NewWithCause(errors.ErrorGeneral, err, "error getting route 53 region from kafka request")
+	routes, routesErr := kafkaRequest.GetRoutes()
+	if routesErr != nil {
+		return errors.NewWithCause(errors.ErrorGeneral, routesErr, "failed to get routes")
+	}
+	if len(routes) == 0 {
+		return errors.BadRequest("unable to recreate routes of kafka '%s': kafka has no routes", id)
 	}
 
-	awsClient, err := k.awsClientFactory.NewClient(awsConfig, route53Region)
+	changeOutput, err := k.ChangeKafkaCNAMErecords(kafkaRequest, KafkaRoutesActionCreate)
 	if err != nil {
-		return nil, errors.NewWithCause(errors.ErrorGeneral, err, "Unable to create aws client")
+		return err
 	}
 
-	changeRecordsOutput, err := awsClient.ChangeResourceRecordSets(k.kafkaConfig.KafkaDomainName, domainRecordBatch)
-	if err != nil {
-		return nil, errors.NewWithCause(errors.ErrorGeneral, err, "Unable to create domain record sets")
+	if changeOutput == nil || changeOutput.ChangeInfo == nil || changeOutput.ChangeInfo.Id == nil || changeOutput.ChangeInfo.Status == nil {
+		return errors.GeneralError("unable to recreate routes of kafka '%s': no change info returned", id)
 	}
 
-	return changeRecordsOutput, nil
+	return k.Updates(kafkaRequest, map[string]interface{}{
+		"routes_creation_id": *changeOutput.ChangeInfo.Id,
+		"routes_created":     *changeOutput.ChangeInfo.Status == "INSYNC",
+	})
 }
 
-func (k *kafkaService) GetCNAMERecordStatus(kafkaRequest *dbapi.KafkaRequest) (*CNameRecordStatus, error) {
-	awsConfig := aws.Config{
+// getRoute53Client returns the route53 client of the given region. The clients are reused across calls as long as the
+// route53 credentials do not change.
+func (k *kafkaService) getRoute53Client(region string) (aws.AWSClient, error) {
+	return k.awsClients.get(k.awsClientFactory, aws.Config{
 		AccessKeyID:     k.awsConfig.Route53AccessKey,
 		SecretAccessKey: k.awsConfig.Route53SecretAccessKey,
-	}
+	}, region)
+}
 
+func (k *kafkaService) GetCNAMERecordStatus(kafkaRequest *dbapi.KafkaRequest) (*CNameRecordStatus, error) {
 	route53Region, err := k.getRoute53RegionFromKafkaRequest(kafkaRequest)
 	if err != nil {
 		return nil, errors.NewWithCause(errors.ErrorGeneral, err, "error getting route 53 region from kafka request")
 	}
 
-	awsClient, err := k.awsClientFactory.NewClient(awsConfig, route53Region)
+	awsClient, err := k.getRoute53Client(route53Region)
 	if err != nil {
 		return nil, errors.NewWithCause(errors.ErrorGeneral, err, "Unable to create aws client")
 	}
@@ -1019,6 +3118,45 @@ func (k *kafkaService) CountByStatus(status []constants2.KafkaStatus) ([]KafkaSt
 	return results, nil
 }
 
+func (k *kafkaService) CountByClusterAndStatus(clusterID string) (map[constants2.KafkaStatus]int, error) {
+	dbConn := k.connectionFactory.New()
+	var results []KafkaStatusCount
+	if err := dbConn.Model(&dbapi.KafkaRequest{}).Select("status as Status, count(1) as Count").Where("cluster_id = ?", clusterID).Group("status").Scan(&results).Error; err != nil {
+		return nil, errors.NewWithCause(errors.ErrorGeneral, err, "Failed to count kafkas of cluster %q", clusterID)
+	}
+
+	counts := map[constants2.KafkaStatus]int{}
+	for _, r := range results {
+		counts[r.Status] = r.Count
+	}
+
+	return counts, nil
+}
+
+type KafkaMultiAZCount struct {
+	MultiAZ bool
+	Count   int
+}
+
+func (k *kafkaService) CountByMultiAZ(region string) (map[bool]int, error) {
+	dbConn := k.connectionFactory.New().Model(&dbapi.KafkaRequest{})
+	if region != "" {
+		dbConn = dbConn.Where("region = ?", region)
+	}
+
+	var results []KafkaMultiAZCount
+	if err := dbConn.Select("multi_az, count(1) as count").Group("multi_az").Scan(&results).Error; err != nil {
+		return nil, errors.NewWithCause(errors.ErrorGeneral, err, "Failed to count kafkas by multi AZ mode")
+	}
+
+	counts := map[bool]int{true: 0, false: 0}
+	for _, r := range results {
+		counts[r.MultiAZ] = r.Count
+	}
+
+	return counts, nil
+}
+
 type KafkaComponentVersions struct {
 	ID                     string
 	ClusterID              string
@@ -1031,17 +3169,31 @@ type KafkaComponentVersions struct {
 	DesiredKafkaIBPVersion string
 	ActualKafkaIBPVersion  string
 	KafkaIBPUpgrading      bool
+	UpgradeStartedAt       *time.Time
 }
 
 func (k *kafkaService) ListComponentVersions() ([]KafkaComponentVersions, error) {
 	dbConn := k.connectionFactory.New()
 	var results []KafkaComponentVersions
-	if err := dbConn.Model(&dbapi.KafkaRequest{}).Select("id", "cluster_id", "desired_strimzi_version", "actual_strimzi_version", "strimzi_upgrading", "desired_kafka_version", "actual_kafka_version", "kafka_upgrading", "desired_kafka_ibp_version", "actual_kafka_ibp_version", "kafka_ibp_upgrading").Scan(&results).Error; err != nil {
+	if err := dbConn.Model(&dbapi.KafkaRequest{}).Select("id", "cluster_id", "desired_strimzi_version", "actual_strimzi_version", "strimzi_upgrading", "desired_kafka_version", "actual_kafka_version", "kafka_upgrading", "desired_kafka_ibp_version", "actual_kafka_ibp_version", "kafka_ibp_upgrading", "upgrade_started_at").Scan(&results).Error; err != nil {
 		return nil, errors.NewWithCause(errors.ErrorGeneral, err, "failed to list component versions")
 	}
 	return results, nil
 }
 
+func (k *kafkaService) ListStuckUpgrades(olderThan time.Duration) ([]KafkaComponentVersions, error) {
+	dbConn := k.connectionFactory.New()
+	var results []KafkaComponentVersions
+	if err := dbConn.Model(&dbapi.KafkaRequest{}).
+		Select("id", "cluster_id", "desired_strimzi_version", "actual_strimzi_version", "strimzi_upgrading", "desired_kafka_version", "actual_kafka_version", "kafka_upgrading", "desired_kafka_ibp_version", "actual_kafka_ibp_version", "kafka_ibp_upgrading", "upgrade_started_at").
+		Where("strimzi_upgrading = ? OR kafka_upgrading = ? OR kafka_ibp_upgrading = ?", true, true, true).
+		Where("upgrade_started_at < ?", time.Now().Add(-olderThan)).
+		Scan(&results).Error; err != nil {
+		return nil, errors.NewWithCause(errors.ErrorGeneral, err, "failed to list kafkas with a stuck upgrade")
+	}
+	return results, nil
+}
+
 func (k *kafkaService) ListKafkasWithRoutesNotCreated() ([]*dbapi.KafkaRequest, *errors.ServiceError) {
 	dbConn := k.connectionFactory.New()
 	var results []*dbapi.KafkaRequest
@@ -1051,6 +3203,287 @@ func (k *kafkaService) ListKafkasWithRoutesNotCreated() ([]*dbapi.KafkaRequest,
 	return results, nil
 }
 
+func (k *kafkaService) ListDuplicateBootstrapHosts() (map[string][]string, *errors.ServiceError) {
+	dbConn := k.connectionFactory.New()
+	duplicateHosts := dbConn.Model(&dbapi.KafkaRequest{}).
+		Select("bootstrap_server_host").
+		Where("bootstrap_server_host <> ''").
+		Group("bootstrap_server_host").
+		Having("count(1) > 1")
+
+	var results []struct {
+		ID                  string
+		BootstrapServerHost string
+	}
+	if err := k.connectionFactory.New().Model(&dbapi.KafkaRequest{}).
+		Select("id", "bootstrap_server_host").
+		Where("bootstrap_server_host IN (?)", duplicateHosts).
+		Order("id").
+		Scan(&results).Error; err != nil {
+		return nil, errors.NewWithCause(errors.ErrorGeneral, err, "failed to list kafka requests with duplicate bootstrap server hosts")
+	}
+
+	hosts := map[string][]string{}
+	for _, r := range results {
+		hosts[r.BootstrapServerHost] = append(hosts[r.BootstrapServerHost], r.ID)
+	}
+	return hosts, nil
+}
+
+func (k *kafkaService) ListStuckDeprovisioning(olderThan time.Duration) ([]*dbapi.KafkaRequest, *errors.ServiceError) {
+	dbConn := k.connectionFactory.New()
+	var results []*dbapi.KafkaRequest
+	// kafkas whose status has been changed before the status_updated_at column existed fall back to updated_at
+	if err := dbConn.Where("status IN (?)", kafkaDeletionStatuses).
+		Where("COALESCE(status_updated_at, updated_at) < ?", time.Now().Add(-olderThan)).
+		Find(&results).Error; err != nil {
+		return nil, errors.NewWithCause(errors.ErrorGeneral, err, "failed to list kafka requests stuck in deprovisioning")
+	}
+	return results, nil
+}
+
+func (k *kafkaService) FailStaleProvisioningKafkas() ([]string, *errors.ServiceError) {
+	timeouts := map[string]time.Duration{}
+	for _, instanceType := range k.kafkaConfig.SupportedInstanceTypes.Configuration.SupportedKafkaInstanceTypes {
+		if timeout, ok := instanceType.GetProvisioningTimeout(); ok {
+			timeouts[instanceType.Id] = timeout
+		}
+	}
+	if len(timeouts) == 0 {
+		return []string{}, nil
+	}
+
+	instanceTypes := make([]string, 0, len(timeouts))
+	for instanceType := range timeouts {
+		instanceTypes = append(instanceTypes, instanceType)
+	}
+	statuses := []string{
+		constants2.KafkaRequestStatusAccepted.String(),
+		constants2.KafkaRequestStatusPreparing.String(),
+		constants2.KafkaRequestStatusProvisioning.String(),
+	}
+
+	var kafkas []*dbapi.KafkaRequest
+	if err := k.connectionFactory.New().
+		Where("status IN (?)", statuses).
+		Where("instance_type IN (?)", instanceTypes).
+		Find(&kafkas).Error; err != nil {
+		return nil, errors.NewWithCause(errors.ErrorGeneral, err, "failed to list kafka requests being provisioned")
+	}
+
+	failed := []string{}
+	for _, kafka := range kafkas {
+		timeout := timeouts[kafka.InstanceType]
+		if time.Since(kafka.CreatedAt) <= timeout {
+			continue
+		}
+		failedReason := fmt.Sprintf("%s Kafka was not provisioned within %s", kafka.InstanceType, timeout)
+		if _, err := k.updateStatus(kafka.ID, constants2.KafkaRequestStatusFailed, map[string]interface{}{"failed_reason": failedReason}); err != nil {
+			return failed, errors.NewWithCause(errors.ErrorGeneral, err, "failed to fail stale kafka request %q", kafka.ID)
+		}
+		metrics.IncreaseKafkaFailureOperationsCountMetric(constants2.KafkaOperationCreate)
+		glog.Infof("kafka request %q failed after not being provisioned within %s", kafka.ID, timeout)
+		failed = append(failed, kafka.ID)
+	}
+
+	return failed, nil
+}
+
+func (k *kafkaService) ListKafkasMissingCanaryAccount() ([]*dbapi.KafkaRequest, *errors.ServiceError) {
+	if !k.keycloakService.GetConfig().EnableAuthenticationOnKafka {
+		return []*dbapi.KafkaRequest{}, nil
+	}
+
+	// the canary service account is created when the kafka is prepared, failed and deleted kafkas do not need one
+	statuses := []string{
+		constants2.KafkaRequestStatusProvisioning.String(),
+		constants2.KafkaRequestStatusReady.String(),
+		constants2.KafkaRequestStatusSuspending.String(),
+		constants2.KafkaRequestStatusSuspended.String(),
+		constants2.KafkaRequestStatusResuming.String(),
+	}
+
+	dbConn := k.connectionFactory.New()
+	var results []*dbapi.KafkaRequest
+	if err := dbConn.Where("status IN (?)", statuses).
+		Where("canary_service_account_client_id = '' OR canary_service_account_client_id IS NULL").
+		Order("created_at").
+		Find(&results).Error; err != nil {
+		return nil, errors.NewWithCause(errors.ErrorGeneral, err, "failed to list kafka requests missing a canary service account")
+	}
+	return results, nil
+}
+
+func (k *kafkaService) RepairCanaryAccounts(dryRun bool) ([]string, *errors.ServiceError) {
+	kafkaRequests, err := k.ListKafkasMissingCanaryAccount()
+	if err != nil {
+		return nil, err
+	}
+
+	repaired := []string{}
+	if dryRun {
+		for _, kafkaRequest := range kafkaRequests {
+			repaired = append(repaired, kafkaRequest.ID)
+		}
+		return repaired, nil
+	}
+
+	keycloakConfig := k.keycloakService.GetConfig()
+	for _, kafkaRequest := range kafkaRequests {
+		canaryServiceAccount, err := k.keycloakService.CreateServiceAccountInternal(buildCanaryServiceAccountRequest(keycloakConfig, kafkaRequest))
+		if err != nil {
+			return repaired, errors.FailedToCreateSSOClient("failed to create canary service account of kafka %s: %v", kafkaRequest.ID, err)
+		}
+
+		if err := k.Updates(kafkaRequest, map[string]interface{}{
+			"canary_service_account_client_id":     canaryServiceAccount.ClientID,
+			"canary_service_account_client_secret": canaryServiceAccount.ClientSecret,
+		}); err != nil {
+			// don't leak the canary service account created above, a new one is created on the next repair
+			if keycloakErr := k.keycloakService.DeleteServiceAccountInternal(canaryServiceAccount.ClientID); keycloakErr != nil {
+				glog.Warningf("failed to delete canary service account '%s' of kafka '%s' after a failed update: %v", canaryServiceAccount.ClientID, kafkaRequest.ID, keycloakErr)
+			}
+			return repaired, errors.NewWithCause(errors.ErrorGeneral, err, "failed to record the canary service account of kafka %s", kafkaRequest.ID)
+		}
+
+		kafkaRequest.CanaryServiceAccountClientID = canaryServiceAccount.ClientID
+		kafkaRequest.CanaryServiceAccountClientSecret = canaryServiceAccount.ClientSecret
+		repaired = append(repaired, kafkaRequest.ID)
+	}
+
+	if len(repaired) > 0 {
+		glog.Infof("repaired canary service account of %d kafka request(s)", len(repaired))
+	}
+
+	return repaired, nil
+}
+
+// OrphanedCanaryAccount is a canary service account whose kafka does not exist anymore
+type OrphanedCanaryAccount struct {
+	KafkaID  string
+	ClientID string
+	Name     string
+}
+
+func (k *kafkaService) ListOrphanedCanaryAccounts() ([]OrphanedCanaryAccount, *errors.ServiceError) {
+	keycloakConfig := k.keycloakService.GetConfig()
+	if !keycloakConfig.EnableAuthenticationOnKafka {
+		return []OrphanedCanaryAccount{}, nil
+	}
+
+	serviceAccounts, err := k.keycloakService.ListInternalServiceAccounts(keycloakConfig.CanaryServiceAccountClientIDPrefix())
+	if err != nil {
+		return nil, errors.NewWithCause(errors.ErrorGeneral, err, "failed to list the canary service accounts")
+	}
+
+	candidates := []OrphanedCanaryAccount{}
+	kafkaIDs := []string{}
+	clientIDs := []string{}
+	for _, serviceAccount := range serviceAccounts {
+		// some SSO providers generate the client ids, the service accounts are then named after the client id requested
+		kafkaID, ok := keycloakConfig.CanaryServiceAccountKafkaID(serviceAccount.ClientID)
+		if !ok {
+			kafkaID, ok = keycloakConfig.CanaryServiceAccountKafkaID(serviceAccount.Name)
+		}
+		if !ok {
+			continue
+		}
+		candidates = append(candidates, OrphanedCanaryAccount{KafkaID: kafkaID, ClientID: serviceAccount.ClientID, Name: serviceAccount.Name})
+		kafkaIDs = append(kafkaIDs, kafkaID)
+		clientIDs = append(clientIDs, serviceAccount.ClientID)
+	}
+	if len(candidates) == 0 {
+		return candidates, nil
+	}
+
+	// a service account is never considered orphaned while a kafka that is not deleted has its id or uses it
+	var liveKafkas []*dbapi.KafkaRequest
+	if err := k.connectionFactory.New().
+		Select("id", "canary_service_account_client_id").
+		Where("id IN (?) OR canary_service_account_client_id IN (?)", kafkaIDs, clientIDs).
+		Find(&liveKafkas).Error; err != nil {
+		return nil, errors.NewWithCause(errors.ErrorGeneral, err, "failed to list the kafka requests of the canary service accounts")
+	}
+	liveIDs := map[string]struct{}{}
+	for _, kafkaRequest := range liveKafkas {
+		liveIDs[kafkaRequest.ID] = struct{}{}
+		if kafkaRequest.CanaryServiceAccountClientID != "" {
+			liveIDs[kafkaRequest.CanaryServiceAccountClientID] = struct{}{}
+		}
+	}
+
+	orphaned := []OrphanedCanaryAccount{}
+	for _, candidate := range candidates {
+		_, liveKafka := liveIDs[candidate.KafkaID]
+		_, liveClientID := liveIDs[candidate.ClientID]
+		if !liveKafka && !liveClientID {
+			orphaned = append(orphaned, candidate)
+		}
+	}
+	return orphaned, nil
+}
+
+func (k *kafkaService) CleanupOrphanedCanaryAccounts(dryRun bool) ([]string, *errors.ServiceError) {
+	orphaned, err := k.ListOrphanedCanaryAccounts()
+	if err != nil {
+		return nil, err
+	}
+
+	deleted := []string{}
+	for _, account := range orphaned {
+		if !dryRun {
+			if err := k.keycloakService.DeleteServiceAccountInternal(account.ClientID); err != nil {
+				return deleted, errors.NewWithCause(errors.ErrorGeneral, err, "failed to delete the orphaned canary service account '%s' of kafka '%s'", account.ClientID, account.KafkaID)
+			}
+			glog.Infof("deleted orphaned canary service account '%s' of kafka '%s'", account.ClientID, account.KafkaID)
+		}
+		deleted = append(deleted, account.ClientID)
+	}
+
+	return deleted, nil
+}
+
+func (k *kafkaService) RepairMissingNamespaces() (int64, *errors.ServiceError) {
+	dbConn := k.connectionFactory.New()
+	result := dbConn.Model(&dbapi.KafkaRequest{}).
+		Where("namespace = '' OR namespace IS NULL").
+		Update("namespace", gorm.Expr("CONCAT('kafka-', LOWER(id))"))
+	if result.Error != nil {
+		return 0, errors.NewWithCause(errors.ErrorGeneral, result.Error, "failed to repair kafka requests with missing namespace")
+	}
+
+	if result.RowsAffected > 0 {
+		k.kafkaRequestCache.InvalidateAll()
+		glog.Infof("repaired namespace of %d kafka request(s)", result.RowsAffected)
+	}
+
+	return result.RowsAffected, nil
+}
+
+func (k *kafkaService) RepairMultiAZ() (int64, *errors.ServiceError) {
+	var repaired int64
+	for _, instanceType := range []types.KafkaInstanceType{types.STANDARD, types.DEVELOPER} {
+		multiAZ := multiAZByInstanceType[instanceType]
+		result := k.connectionFactory.New().
+			Model(&dbapi.KafkaRequest{}).
+			Where("instance_type = ?", instanceType.String()).
+			Where("multi_az <> ?", multiAZ).
+			Where("status NOT IN (?)", kafkaDeletionStatuses).
+			Update("multi_az", multiAZ)
+		if result.Error != nil {
+			return repaired, errors.NewWithCause(errors.ErrorGeneral, result.Error, "failed to repair the multi AZ mode of %s kafka requests", instanceType.String())
+		}
+		repaired += result.RowsAffected
+	}
+
+	if repaired > 0 {
+		k.kafkaRequestCache.InvalidateAll()
+		glog.Infof("repaired multi AZ mode of %d kafka request(s)", repaired)
+	}
+
+	return repaired, nil
+}
+
 func buildManagedKafkaCR(kafkaRequest *dbapi.KafkaRequest, kafkaConfig *config.KafkaConfig, keycloakService sso.KeycloakService) (*managedkafka.ManagedKafka, *errors.ServiceError) {
 	k, err := kafkaConfig.GetKafkaInstanceSize(kafkaRequest.InstanceType, kafkaRequest.SizeId)
 	if err != nil {
@@ -1086,7 +3519,7 @@ func buildManagedKafkaCR(kafkaRequest *dbapi.KafkaRequest, kafkaConfig *config.K
 				MaxDataRetentionSize:        kafkaRequest.KafkaStorageSize,
 				MaxPartitions:               k.MaxPartitions,
 				MaxDataRetentionPeriod:      k.MaxDataRetentionPeriod,
-				MaxConnectionAttemptsPerSec: k.MaxConnectionAttemptsPerSec,
+				MaxConnectionAttemptsPerSec: getMaxConnectionAttemptsPerSec(kafkaRequest, k),
 			},
 			Endpoint: managedkafka.EndpointSpec{
 				BootstrapServerHost: kafkaRequest.BootstrapServerHost,
@@ -1102,6 +3535,28 @@ func buildManagedKafkaCR(kafkaRequest *dbapi.KafkaRequest, kafkaConfig *config.K
 		Status: managedkafka.ManagedKafkaStatus{},
 	}
 
+	// the agent only performs upgrades during the maintenance window of the kafka, if any
+	if kafkaRequest.HasMaintenanceWindow() {
+		managedKafkaCR.ObjectMeta.Annotations["bf2.org/maintenanceWindow"] = kafkaRequest.GetMaintenanceWindow()
+	}
+
+	// the CR of a paused kafka is still sent, so that the agent does not take it as deleted, but it is left alone
+	if kafkaRequest.ReconciliationPaused {
+		managedKafkaCR.ObjectMeta.Annotations["bf2.org/pauseReconciliation"] = "true"
+	}
+
+	// custom annotations never overwrite the annotations set by kas-fleet-manager
+	customAnnotations, annotationsErr := kafkaRequest.GetAnnotations()
+	if annotationsErr != nil {
+		return nil, errors.NewWithCause(errors.ErrorGeneral, annotationsErr, "failed to get annotations of kafka request '%s'", kafkaRequest.ID)
+	}
+	for key, value := range customAnnotations {
+		if strings.HasPrefix(key, dbapi.ReservedAnnotationPrefix) {
+			continue
+		}
+		managedKafkaCR.ObjectMeta.Annotations[key] = value
+	}
+
 	keycloakConfig := keycloakService.GetConfig()
 	keycloakRealmConfig := keycloakService.GetRealmConfig()
 
@@ -1143,6 +3598,29 @@ func buildManagedKafkaCR(kafkaRequest *dbapi.KafkaRequest, kafkaConfig *config.K
 	return managedKafkaCR, nil
 }
 
+// getMaxConnectionAttemptsPerSec returns the max connection attempts per second override of the kafka request capped to
+// the limit of its size, or the limit of its size if the kafka request has no override
+func getMaxConnectionAttemptsPerSec(kafkaRequest *dbapi.KafkaRequest, instanceSize *config.KafkaInstanceSize) int {
+	override := kafkaRequest.MaxConnectionAttemptsPerSecOverride
+	if override == nil || *override > instanceSize.MaxConnectionAttemptsPerSec {
+		return instanceSize.MaxConnectionAttemptsPerSec
+	}
+	return *override
+}
+
+// getCapacityConsumed returns the capacity consumed override of the kafka request, or the capacity consumed by its size
+// if the kafka request has no override
+func getCapacityConsumed(kafkaRequest *dbapi.KafkaRequest, instanceSize *config.KafkaInstanceSize) int {
+	return capacityConsumed(kafkaRequest.CapacityConsumedOverride, instanceSize)
+}
+
+func capacityConsumed(override *int, instanceSize *config.KafkaInstanceSize) int {
+	if override == nil || *override <= 0 {
+		return instanceSize.CapacityConsumed
+	}
+	return *override
+}
+
 // buildReservedManagedKafkaCR builds a Reserved Managed Kafka CR.
 // The ID, K8s object ID, K8s namespace and PlacementID are all set to
 // the provided kafkaID.
@@ -1219,7 +3697,7 @@ func buildKafkaOwner(kafkaRequest *dbapi.KafkaRequest, kafkaConfig *config.Kafka
 func buildKafkaClusterCNAMESRecordBatch(routes []dbapi.DataPlaneKafkaRoute, action string) *route53.ChangeBatch {
 	var changes []*route53.Change
 	for _, r := range routes {
-		c := buildResourceRecordChange(r.Domain, r.Router, action)
+		c := buildResourceRecordChange(r, action)
 		changes = append(changes, c)
 	}
 	recordChangeBatch := &route53.ChangeBatch{
@@ -1229,7 +3707,31 @@ func buildKafkaClusterCNAMESRecordBatch(routes []dbapi.DataPlaneKafkaRoute, acti
 	return recordChangeBatch
 }
 
-func buildResourceRecordChange(recordName string, clusterIngress string, action string) *route53.Change {
+// buildResourceRecordChange builds the change of the CNAME record of the given route, or of its alias record
+// if the route is a zone apex
+func buildResourceRecordChange(route dbapi.DataPlaneKafkaRoute, action string) *route53.Change {
+	recordName := route.Domain
+	clusterIngress := route.Router
+
+	if route.Apex {
+		recordType := "A"
+		routerHostedZoneId := route.RouterHostedZoneId
+		evaluateTargetHealth := false
+
+		return &route53.Change{
+			Action: &action,
+			ResourceRecordSet: &route53.ResourceRecordSet{
+				Name: &recordName,
+				Type: &recordType,
+				AliasTarget: &route53.AliasTarget{
+					DNSName:              &clusterIngress,
+					HostedZoneId:         &routerHostedZoneId,
+					EvaluateTargetHealth: &evaluateTargetHealth,
+				},
+			},
+		}
+	}
+
 	recordType := "CNAME"
 	recordTTL := int64(300)
 
@@ -1257,7 +3759,10 @@ func (k *kafkaService) AssignBootstrapServerHost(kafkaRequest *dbapi.KafkaReques
 		return errors.NewWithCause(errors.ErrorGeneral, replaceErr, "generated host is not valid")
 	}
 
-	clusterDNS, err := k.clusterService.GetClusterDNS(kafkaRequest.ClusterID)
+	// many kafkas are usually prepared on the same cluster, its DNS is cached to not look it up for each of them
+	clusterDNS, err := k.clusterDNSCache.getOrLoad(kafkaRequest.ClusterID, func() (string, *errors.ServiceError) {
+		return k.clusterService.GetClusterDNS(kafkaRequest.ClusterID)
+	})
 	if err != nil {
 		return errors.NewWithCause(errors.ErrorGeneral, err, "error retrieving cluster DNS")
 	}
@@ -1266,7 +3771,8 @@ func (k *kafkaService) AssignBootstrapServerHost(kafkaRequest *dbapi.KafkaReques
 
 	if k.kafkaConfig.EnableKafkaCNAMERegistration {
 		// If we enable KafkaTLS, the bootstrapServerHost should use the external domain name rather than the cluster domain
-		kafkaRequest.BootstrapServerHost = fmt.Sprintf("%s.%s", truncatedKafkaIdentifier, k.kafkaConfig.KafkaDomainName)
+		domainName, _ := k.kafkaConfig.GetKafkaDomain(kafkaRequest.CloudProvider)
+		kafkaRequest.BootstrapServerHost = fmt.Sprintf("%s.%s", truncatedKafkaIdentifier, domainName)
 	} else {
 		kafkaRequest.BootstrapServerHost = fmt.Sprintf("%s.%s", truncatedKafkaIdentifier, clusterDNS)
 	}
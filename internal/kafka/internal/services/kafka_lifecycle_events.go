@@ -0,0 +1,176 @@
+package services
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/config"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/golang/glog"
+)
+
+type KafkaLifecycleEventType string
+
+const (
+	KafkaLifecycleEventCreated       KafkaLifecycleEventType = "org.bf2.kafka.created"
+	KafkaLifecycleEventStatusChanged KafkaLifecycleEventType = "org.bf2.kafka.status_changed"
+	KafkaLifecycleEventDeleted       KafkaLifecycleEventType = "org.bf2.kafka.deleted"
+
+	kafkaLifecycleEventSource      = "kas-fleet-manager"
+	kafkaLifecycleEventSpecVersion = "1.0"
+	kafkaLifecycleEventContentType = "application/cloudevents+json"
+)
+
+// KafkaLifecycleEvent is a lifecycle change of a kafka in the CloudEvents structured JSON format
+type KafkaLifecycleEvent struct {
+	SpecVersion     string                  `json:"specversion"`
+	ID              string                  `json:"id"`
+	Source          string                  `json:"source"`
+	Type            KafkaLifecycleEventType `json:"type"`
+	Subject         string                  `json:"subject"`
+	Time            time.Time               `json:"time"`
+	DataContentType string                  `json:"datacontenttype"`
+	Data            KafkaLifecycleEventData `json:"data"`
+}
+
+type KafkaLifecycleEventData struct {
+	ID             string `json:"id"`
+	Name           string `json:"name"`
+	Owner          string `json:"owner"`
+	OrganisationID string `json:"organisation_id"`
+	CloudProvider  string `json:"cloud_provider"`
+	Region         string `json:"region"`
+	ClusterID      string `json:"cluster_id"`
+	InstanceType   string `json:"instance_type"`
+	SizeID         string `json:"size_id"`
+	Status         string `json:"status"`
+}
+
+func newKafkaLifecycleEvent(eventType KafkaLifecycleEventType, kafkaRequest *dbapi.KafkaRequest) KafkaLifecycleEvent {
+	return KafkaLifecycleEvent{
+		SpecVersion:     kafkaLifecycleEventSpecVersion,
+		ID:              api.NewID(),
+		Source:          kafkaLifecycleEventSource,
+		Type:            eventType,
+		Subject:         kafkaRequest.ID,
+		Time:            time.Now().UTC(),
+		DataContentType: "application/json",
+		Data: KafkaLifecycleEventData{
+			ID:             kafkaRequest.ID,
+			Name:           kafkaRequest.Name,
+			Owner:          kafkaRequest.Owner,
+			OrganisationID: kafkaRequest.OrganisationId,
+			CloudProvider:  kafkaRequest.CloudProvider,
+			Region:         kafkaRequest.Region,
+			ClusterID:      kafkaRequest.ClusterID,
+			InstanceType:   kafkaRequest.InstanceType,
+			SizeID:         kafkaRequest.SizeId,
+			Status:         kafkaRequest.Status,
+		},
+	}
+}
+
+// KafkaLifecycleEventSink publishes the lifecycle events of the kafkas to downstream systems
+//
+//go:generate moq -out kafka_lifecycle_event_sink_moq.go . KafkaLifecycleEventSink
+type KafkaLifecycleEventSink interface {
+	Emit(event KafkaLifecycleEvent) error
+}
+
+// NewKafkaLifecycleEventSink returns a sink posting the events to the configured URL in the background, or a sink
+// discarding them when no URL is configured
+func NewKafkaLifecycleEventSink(kafkaConfig *config.KafkaConfig) KafkaLifecycleEventSink {
+	if kafkaConfig.LifecycleEventsSinkURL == "" {
+		return &noopKafkaLifecycleEventSink{}
+	}
+	return newAsyncKafkaLifecycleEventSink(&httpKafkaLifecycleEventSink{
+		url:    kafkaConfig.LifecycleEventsSinkURL,
+		client: &http.Client{Timeout: kafkaConfig.LifecycleEventsSinkTimeout},
+	}, kafkaLifecycleEventQueueSize)
+}
+
+type noopKafkaLifecycleEventSink struct{}
+
+func (s *noopKafkaLifecycleEventSink) Emit(event KafkaLifecycleEvent) error {
+	return nil
+}
+
+// kafkaLifecycleEventQueueSize is the number of events that can wait to be published, the events emitted while the
+// queue is full are dropped
+const kafkaLifecycleEventQueueSize = 1000
+
+// asyncKafkaLifecycleEventSink publishes the events to the given sink from a single background goroutine, so that
+// emitting an event never waits for a slow sink. A slow sink can neither pile up goroutines nor memory: the events are
+// posted one at a time, each post being bounded by the sink timeout, and at most queueSize events wait to be posted.
+type asyncKafkaLifecycleEventSink struct {
+	sink   KafkaLifecycleEventSink
+	events chan KafkaLifecycleEvent
+}
+
+func newAsyncKafkaLifecycleEventSink(sink KafkaLifecycleEventSink, queueSize int) *asyncKafkaLifecycleEventSink {
+	s := &asyncKafkaLifecycleEventSink{
+		sink:   sink,
+		events: make(chan KafkaLifecycleEvent, queueSize),
+	}
+	go s.run()
+	return s
+}
+
+func (s *asyncKafkaLifecycleEventSink) run() {
+	for event := range s.events {
+		if err := s.sink.Emit(event); err != nil {
+			glog.Warningf("failed to emit %s event for kafka '%s': %v", event.Type, event.Subject, err)
+		}
+	}
+}
+
+func (s *asyncKafkaLifecycleEventSink) Emit(event KafkaLifecycleEvent) error {
+	select {
+	case s.events <- event:
+		return nil
+	default:
+		return fmt.Errorf("the kafka lifecycle event queue is full")
+	}
+}
+
+// httpKafkaLifecycleEventSink posts each event in the CloudEvents structured mode, e.g. to the topic endpoint of a
+// Kafka HTTP bridge
+type httpKafkaLifecycleEventSink struct {
+	url    string
+	client *http.Client
+}
+
+func (s *httpKafkaLifecycleEventSink) Emit(event KafkaLifecycleEvent) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	resp, err := s.client.Post(s.url, kafkaLifecycleEventContentType, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("unexpected status code %d from the kafka lifecycle event sink", resp.StatusCode)
+	}
+	return nil
+}
+
+// emitLifecycleEvent publishes a lifecycle event of the given kafka. It must only be called once the change has been
+// committed and a failure to publish the event is only logged, so that it never affects the operation itself.
+// The event is only queued by the configured sink, it does not wait for the event to be posted.
+func (k *kafkaService) emitLifecycleEvent(eventType KafkaLifecycleEventType, kafkaRequest *dbapi.KafkaRequest) {
+	if k.lifecycleEventSink == nil {
+		return
+	}
+
+	if err := k.lifecycleEventSink.Emit(newKafkaLifecycleEvent(eventType, kafkaRequest)); err != nil {
+		glog.Warningf("failed to emit %s event for kafka '%s': %v", eventType, kafkaRequest.ID, err)
+	}
+}
@@ -0,0 +1,280 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/config"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/converters"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/kafkas/types"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/auth"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/client/keycloak"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/sso"
+	"github.com/onsi/gomega"
+
+	mocket "github.com/selvatico/go-mocket"
+)
+
+func Test_NewKafkaLifecycleEventSink(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	g.Expect(NewKafkaLifecycleEventSink(&config.KafkaConfig{})).To(gomega.Equal(&noopKafkaLifecycleEventSink{}))
+	sink := NewKafkaLifecycleEventSink(&config.KafkaConfig{
+		LifecycleEventsSinkURL:     "http://localhost/topics/kafka-events",
+		LifecycleEventsSinkTimeout: time.Second,
+	})
+	g.Expect(sink).To(gomega.BeAssignableToTypeOf(&asyncKafkaLifecycleEventSink{}))
+	g.Expect(sink.(*asyncKafkaLifecycleEventSink).sink).To(gomega.Equal(&httpKafkaLifecycleEventSink{
+		url:    "http://localhost/topics/kafka-events",
+		client: &http.Client{Timeout: time.Second},
+	}))
+}
+
+func Test_asyncKafkaLifecycleEventSink_Emit(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	release := make(chan struct{})
+	emitted := make(chan KafkaLifecycleEvent, 2)
+	sink := newAsyncKafkaLifecycleEventSink(&KafkaLifecycleEventSinkMock{
+		EmitFunc: func(event KafkaLifecycleEvent) error {
+			<-release
+			emitted <- event
+			return nil
+		},
+	}, 1)
+
+	// the first event is being posted and the second one waits in the queue, the third one does not fit in it
+	first := newKafkaLifecycleEvent(KafkaLifecycleEventCreated, buildKafkaRequest(nil))
+	g.Expect(sink.Emit(first)).To(gomega.Succeed())
+	g.Eventually(func() int { return len(sink.events) }).Should(gomega.Equal(0))
+	second := newKafkaLifecycleEvent(KafkaLifecycleEventDeleted, buildKafkaRequest(nil))
+	g.Expect(sink.Emit(second)).To(gomega.Succeed())
+	g.Expect(sink.Emit(newKafkaLifecycleEvent(KafkaLifecycleEventDeleted, buildKafkaRequest(nil)))).ToNot(gomega.Succeed())
+
+	close(release)
+	g.Eventually(emitted).Should(gomega.Receive(gomega.Equal(first)))
+	g.Eventually(emitted).Should(gomega.Receive(gomega.Equal(second)))
+}
+
+func Test_httpKafkaLifecycleEventSink_Emit(t *testing.T) {
+	tests := []struct {
+		name       string
+		statusCode int
+		wantErr    bool
+	}{
+		{
+			name:       "should post the event in the CloudEvents structured format",
+			statusCode: http.StatusOK,
+			wantErr:    false,
+		},
+		{
+			name:       "should return an error if the sink does not accept the event",
+			statusCode: http.StatusInternalServerError,
+			wantErr:    true,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+
+			var received KafkaLifecycleEvent
+			var contentType string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				contentType = r.Header.Get("Content-Type")
+				g.Expect(json.NewDecoder(r.Body).Decode(&received)).To(gomega.Succeed())
+				w.WriteHeader(tt.statusCode)
+			}))
+			defer server.Close()
+
+			sink := &httpKafkaLifecycleEventSink{
+				url:    server.URL,
+				client: &http.Client{Timeout: time.Second},
+			}
+			event := newKafkaLifecycleEvent(KafkaLifecycleEventCreated, buildKafkaRequest(nil))
+
+			err := sink.Emit(event)
+			g.Expect(err != nil).To(gomega.Equal(tt.wantErr))
+			g.Expect(contentType).To(gomega.Equal(kafkaLifecycleEventContentType))
+			g.Expect(received.SpecVersion).To(gomega.Equal("1.0"))
+			g.Expect(received.ID).To(gomega.Equal(event.ID))
+			g.Expect(received.Type).To(gomega.Equal(KafkaLifecycleEventCreated))
+			g.Expect(received.Subject).To(gomega.Equal(event.Data.ID))
+			g.Expect(received.Data).To(gomega.Equal(event.Data))
+		})
+	}
+}
+
+func Test_kafkaService_LifecycleEvents(t *testing.T) {
+	newSink := func(emitErr error) (*KafkaLifecycleEventSinkMock, *[]KafkaLifecycleEvent) {
+		events := []KafkaLifecycleEvent{}
+		return &KafkaLifecycleEventSinkMock{
+			EmitFunc: func(event KafkaLifecycleEvent) error {
+				events = append(events, event)
+				return emitErr
+			},
+		}, &events
+	}
+
+	t.Run("should emit a created event when a kafka is registered", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+		sink, events := newSink(nil)
+		mocket.Catcher.Reset()
+		mocket.Catcher.NewMock().WithQuery(`INSERT INTO "kafka_requests"`)
+		// the region has no kafka yet
+		mocket.Catcher.NewMock().WithQuery(`SELECT * FROM "kafka_requests"`).WithReply([]map[string]interface{}{})
+		mocket.Catcher.NewMock().WithQuery("pg_advisory_xact_lock")
+		mocket.Catcher.NewMock().WithQueryException().WithExecException()
+
+		kafkaConfig := defaultKafkaConf
+		k := &kafkaService{
+			connectionFactory: db.NewMockConnectionFactory(nil),
+			kafkaConfig:       &kafkaConfig,
+			awsConfig:         config.NewAWSConfig(),
+			providerConfig:    buildProviderConfiguration(testKafkaRequestRegion, MaxClusterCapacity, MaxClusterCapacity, false),
+			dataplaneClusterConfig: buildDataplaneClusterConfig([]config.ManualCluster{
+				buildManualCluster(1, api.AllInstanceTypeSupport.String(), testKafkaRequestRegion),
+			}),
+			clusterPlacementStrategy: &ClusterPlacementStrategyMock{
+				FindClusterFunc: func(kafka *dbapi.KafkaRequest) (*api.Cluster, error) {
+					return &api.Cluster{ClusterID: testClusterID}, nil
+				},
+			},
+			quotaServiceFactory: &QuotaServiceFactoryMock{
+				GetQuotaServiceFunc: func(quotaType api.QuotaType) (QuotaService, *errors.ServiceError) {
+					return &QuotaServiceMock{
+						CheckIfQuotaIsDefinedForInstanceTypeFunc: func(owner string, organisationID string, instanceType types.KafkaInstanceType) (bool, *errors.ServiceError) {
+							return true, nil
+						},
+						ReserveQuotaFunc: func(kafka *dbapi.KafkaRequest, instanceType types.KafkaInstanceType) (string, *errors.ServiceError) {
+							return "fake-subscription-id", nil
+						},
+					}, nil
+				},
+			},
+			lifecycleEventSink: sink,
+		}
+		kafkaRequest := buildKafkaRequest(func(kafkaRequest *dbapi.KafkaRequest) {
+			kafkaRequest.ID = ""
+			kafkaRequest.InstanceType = types.STANDARD.String()
+		})
+
+		g.Expect(k.RegisterKafkaJob(kafkaRequest)).To(gomega.BeNil())
+		g.Expect(*events).To(gomega.HaveLen(1))
+		g.Expect((*events)[0].Type).To(gomega.Equal(KafkaLifecycleEventCreated))
+		g.Expect((*events)[0].Subject).To(gomega.Equal(kafkaRequest.ID))
+		g.Expect((*events)[0].Data.InstanceType).To(gomega.Equal(types.STANDARD.String()))
+	})
+
+	t.Run("should emit a deleted event when a kafka is deleted", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+		sink, events := newSink(nil)
+		mocket.Catcher.Reset().NewMock().WithQuery(`UPDATE "kafka_requests" SET "deleted_at"`)
+		mocket.Catcher.NewMock().WithExecException().WithQueryException()
+
+		k := &kafkaService{
+			connectionFactory:  db.NewMockConnectionFactory(nil),
+			kafkaConfig:        &config.KafkaConfig{},
+			lifecycleEventSink: sink,
+		}
+		kafkaRequest := buildKafkaRequest(func(kafkaRequest *dbapi.KafkaRequest) {
+			kafkaRequest.ID = testID
+			kafkaRequest.ClusterID = ""
+		})
+
+		g.Expect(k.Delete(kafkaRequest)).To(gomega.BeNil())
+		g.Expect(*events).To(gomega.HaveLen(1))
+		g.Expect((*events)[0].Type).To(gomega.Equal(KafkaLifecycleEventDeleted))
+		g.Expect((*events)[0].Subject).To(gomega.Equal(testID))
+	})
+
+	t.Run("should emit a deleted event when a kafka is force deleted", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+		sink, events := newSink(nil)
+		mocket.Catcher.Reset().NewMock().
+			WithQuery(`SELECT * FROM "kafka_requests" WHERE id = $1`).
+			WithReply(converters.ConvertKafkaRequest(buildKafkaRequest(func(kafkaRequest *dbapi.KafkaRequest) {
+				kafkaRequest.ID = testID
+				kafkaRequest.ClusterID = ""
+			})))
+		mocket.Catcher.NewMock().WithQuery(`INSERT INTO "kafka_force_deletions"`)
+		mocket.Catcher.NewMock().WithQuery(`DELETE FROM "kafka_requests"`)
+		mocket.Catcher.NewMock().WithExecException().WithQueryException()
+
+		authHelper, err := auth.NewAuthHelper(JwtKeyFile, JwtCAFile, "")
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+		account, err := authHelper.NewAccount("admin-user", "", "", "")
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+		jwt, err := authHelper.CreateJWTWithClaims(account, nil)
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+
+		k := &kafkaService{
+			connectionFactory: db.NewMockConnectionFactory(nil),
+			kafkaConfig:       &config.KafkaConfig{},
+			quotaServiceFactory: &QuotaServiceFactoryMock{
+				GetQuotaServiceFunc: func(quotaType api.QuotaType) (QuotaService, *errors.ServiceError) {
+					return &QuotaServiceMock{
+						DeleteQuotaFunc: func(subscriptionId string) *errors.ServiceError {
+							return nil
+						},
+					}, nil
+				},
+			},
+			lifecycleEventSink: sink,
+		}
+
+		g.Expect(k.ForceDelete(auth.SetTokenInContext(context.TODO(), jwt), testID)).To(gomega.BeNil())
+		g.Expect(*events).To(gomega.HaveLen(1))
+		g.Expect((*events)[0].Type).To(gomega.Equal(KafkaLifecycleEventDeleted))
+		g.Expect((*events)[0].Subject).To(gomega.Equal(testID))
+	})
+
+	t.Run("should not emit an event when the kafka cannot be deleted", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+		sink, events := newSink(nil)
+		mocket.Catcher.Reset().NewMock().WithExecException().WithQueryException()
+
+		k := &kafkaService{
+			connectionFactory: db.NewMockConnectionFactory(nil),
+			kafkaConfig:       &config.KafkaConfig{},
+			keycloakService: &sso.KeycloakServiceMock{
+				GetConfigFunc: func() *keycloak.KeycloakConfig {
+					return &keycloak.KeycloakConfig{}
+				},
+			},
+			lifecycleEventSink: sink,
+		}
+
+		g.Expect(k.Delete(buildKafkaRequest(nil))).ToNot(gomega.BeNil())
+		g.Expect(*events).To(gomega.BeEmpty())
+	})
+
+	t.Run("should not fail the deletion when the event cannot be emitted", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+		sink, events := newSink(fmt.Errorf("sink unavailable"))
+		mocket.Catcher.Reset().NewMock().WithQuery(`UPDATE "kafka_requests" SET "deleted_at"`)
+		mocket.Catcher.NewMock().WithExecException().WithQueryException()
+
+		k := &kafkaService{
+			connectionFactory:  db.NewMockConnectionFactory(nil),
+			kafkaConfig:        &config.KafkaConfig{},
+			lifecycleEventSink: sink,
+		}
+		kafkaRequest := buildKafkaRequest(func(kafkaRequest *dbapi.KafkaRequest) {
+			kafkaRequest.ClusterID = ""
+		})
+
+		g.Expect(k.Delete(kafkaRequest)).To(gomega.BeNil())
+		g.Expect(*events).To(gomega.HaveLen(1))
+	})
+}
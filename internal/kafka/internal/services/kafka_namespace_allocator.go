@@ -0,0 +1,103 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/config"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared/utils/arrays"
+)
+
+// KafkaNamespaceAllocator assigns the data plane namespace of the kafkas
+//
+//go:generate moq -out kafka_namespace_allocator_moq.go . KafkaNamespaceAllocator
+type KafkaNamespaceAllocator interface {
+	// Allocate returns the namespace of the given kafka request. The namespace is not used by any other kafka that
+	// is not deleted, i.e. the namespaces are returned to the allocator once the kafkas using them are deleted.
+	Allocate(kafkaRequest *dbapi.KafkaRequest) (string, *errors.ServiceError)
+}
+
+// NewKafkaNamespaceAllocator returns an allocator assigning the namespaces of the configured pool, or an allocator
+// deriving the namespace of each kafka from its id when there is no pool
+func NewKafkaNamespaceAllocator(connectionFactory *db.ConnectionFactory, kafkaConfig *config.KafkaConfig) KafkaNamespaceAllocator {
+	if len(kafkaConfig.KafkaNamespacePool) == 0 {
+		return &defaultKafkaNamespaceAllocator{}
+	}
+	return &poolKafkaNamespaceAllocator{
+		connectionFactory: connectionFactory,
+		pool:              kafkaConfig.KafkaNamespacePool,
+	}
+}
+
+func buildKafkaNamespace(kafkaRequest *dbapi.KafkaRequest) string {
+	return fmt.Sprintf("kafka-%s", strings.ToLower(kafkaRequest.ID))
+}
+
+// defaultKafkaNamespaceAllocator derives the namespace kafka-<id> of each kafka, which is unique as the ids are
+type defaultKafkaNamespaceAllocator struct{}
+
+func (a *defaultKafkaNamespaceAllocator) Allocate(kafkaRequest *dbapi.KafkaRequest) (string, *errors.ServiceError) {
+	return buildKafkaNamespace(kafkaRequest), nil
+}
+
+// poolKafkaNamespaceAllocator assigns the first namespace of the pool that is not used by a kafka. The namespace is
+// recorded right away so that it is not allocated to another kafka.
+type poolKafkaNamespaceAllocator struct {
+	connectionFactory *db.ConnectionFactory
+	pool              []string
+}
+
+func (a *poolKafkaNamespaceAllocator) Allocate(kafkaRequest *dbapi.KafkaRequest) (string, *errors.ServiceError) {
+	// a kafka prepared again keeps the namespace it has already been allocated
+	if arrays.Contains(a.pool, kafkaRequest.Namespace) {
+		return kafkaRequest.Namespace, nil
+	}
+
+	// the deleted kafkas are ignored, which returns their namespaces to the pool
+	var usedNamespaces []string
+	if err := a.connectionFactory.New().
+		Model(&dbapi.KafkaRequest{}).
+		Where("namespace IN ?", a.pool).
+		Pluck("namespace", &usedNamespaces).Error; err != nil {
+		return "", errors.NewWithCause(errors.ErrorGeneral, err, "failed to list the namespaces of the kafka namespace pool in use")
+	}
+
+	var lastErr error
+	for _, namespace := range a.pool {
+		if arrays.Contains(usedNamespaces, namespace) {
+			continue
+		}
+
+		// the unique index on the namespace of the kafkas that are not deleted prevents a namespace from being
+		// allocated to several kafkas concurrently, the next namespace is tried when this one has just been allocated
+		result := a.connectionFactory.New().
+			Model(&dbapi.KafkaRequest{}).
+			Where("id = ?", kafkaRequest.ID).
+			Update("namespace", namespace)
+		if result.Error != nil {
+			lastErr = result.Error
+			continue
+		}
+		if result.RowsAffected == 0 {
+			return "", errors.NotFound("kafka request with id '%s' not found", kafkaRequest.ID)
+		}
+
+		return namespace, nil
+	}
+
+	if lastErr != nil {
+		return "", errors.NewWithCause(errors.ErrorGeneral, lastErr, "failed to allocate a namespace of the kafka namespace pool to kafka '%s'", kafkaRequest.ID)
+	}
+	return "", errors.GeneralError("no namespace of the kafka namespace pool is available for kafka '%s'", kafkaRequest.ID)
+}
+
+// allocateNamespace returns the namespace of the given kafka request, derived from its id when no allocator is set
+func (k *kafkaService) allocateNamespace(kafkaRequest *dbapi.KafkaRequest) (string, *errors.ServiceError) {
+	if k.namespaceAllocator == nil {
+		return buildKafkaNamespace(kafkaRequest), nil
+	}
+	return k.namespaceAllocator.Allocate(kafkaRequest)
+}
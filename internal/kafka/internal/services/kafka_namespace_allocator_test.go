@@ -0,0 +1,190 @@
+package services
+
+import (
+	"database/sql/driver"
+	"testing"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/config"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/client/keycloak"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/sso"
+	"github.com/onsi/gomega"
+
+	mocket "github.com/selvatico/go-mocket"
+)
+
+func Test_NewKafkaNamespaceAllocator(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	g.Expect(NewKafkaNamespaceAllocator(db.NewMockConnectionFactory(nil), &config.KafkaConfig{})).To(gomega.BeAssignableToTypeOf(&defaultKafkaNamespaceAllocator{}))
+	g.Expect(NewKafkaNamespaceAllocator(db.NewMockConnectionFactory(nil), &config.KafkaConfig{KafkaNamespacePool: []string{"pool-1"}})).To(gomega.BeAssignableToTypeOf(&poolKafkaNamespaceAllocator{}))
+}
+
+func Test_defaultKafkaNamespaceAllocator_Allocate(t *testing.T) {
+	g := gomega.NewWithT(t)
+	kafkaRequest := buildKafkaRequest(func(kafkaRequest *dbapi.KafkaRequest) {
+		kafkaRequest.ID = "C8a2bqqfhm0bosna5ng0"
+	})
+
+	namespace, err := (&defaultKafkaNamespaceAllocator{}).Allocate(kafkaRequest)
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(namespace).To(gomega.Equal("kafka-c8a2bqqfhm0bosna5ng0"))
+}
+
+func Test_poolKafkaNamespaceAllocator_Allocate(t *testing.T) {
+	pool := []string{"pool-1", "pool-2", "pool-3"}
+
+	tests := []struct {
+		name             string
+		namespace        string
+		usedNamespaces   []string
+		updateErr        bool
+		updateRowsNum    int64
+		want             string
+		wantErr          bool
+		wantUpdatedValue interface{}
+	}{
+		{
+			name:             "should allocate the first namespace of the pool that is not in use",
+			usedNamespaces:   []string{"pool-1"},
+			updateRowsNum:    1,
+			want:             "pool-2",
+			wantUpdatedValue: "pool-2",
+		},
+		{
+			name:      "should keep the namespace of the pool already allocated to the kafka",
+			namespace: "pool-3",
+			want:      "pool-3",
+		},
+		{
+			name:           "should return an error when all the namespaces of the pool are in use",
+			usedNamespaces: pool,
+			wantErr:        true,
+		},
+		{
+			name:      "should return an error when the namespace cannot be recorded",
+			updateErr: true,
+			wantErr:   true,
+		},
+		{
+			name:             "should return an error when the kafka does not exist",
+			updateRowsNum:    0,
+			wantErr:          true,
+			wantUpdatedValue: "pool-1",
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			var updatedValue interface{}
+			used := []map[string]interface{}{}
+			for _, namespace := range tt.usedNamespaces {
+				used = append(used, map[string]interface{}{"namespace": namespace})
+			}
+			mocket.Catcher.Reset()
+			// the namespaces of the deleted kafkas are not in use
+			mocket.Catcher.NewMock().
+				WithQuery(`SELECT "namespace" FROM "kafka_requests" WHERE namespace IN ($1,$2,$3) AND "kafka_requests"."deleted_at" IS NULL`).
+				WithReply(used)
+			if tt.updateErr {
+				mocket.Catcher.NewMock().WithQuery(`UPDATE "kafka_requests" SET "namespace"=$1`).WithExecException()
+			} else {
+				mocket.Catcher.NewMock().
+					WithQuery(`UPDATE "kafka_requests" SET "namespace"=$1`).
+					WithCallback(func(_ string, args []driver.NamedValue) {
+						updatedValue = args[0].Value
+					}).
+					WithRowsNum(tt.updateRowsNum)
+			}
+			mocket.Catcher.NewMock().WithExecException().WithQueryException()
+
+			allocator := &poolKafkaNamespaceAllocator{
+				connectionFactory: db.NewMockConnectionFactory(nil),
+				pool:              pool,
+			}
+			kafkaRequest := buildKafkaRequest(func(kafkaRequest *dbapi.KafkaRequest) {
+				kafkaRequest.ID = testID
+				kafkaRequest.Namespace = tt.namespace
+			})
+
+			got, err := allocator.Allocate(kafkaRequest)
+			g.Expect(err != nil).To(gomega.Equal(tt.wantErr))
+			g.Expect(got).To(gomega.Equal(tt.want))
+			if tt.wantUpdatedValue == nil {
+				g.Expect(updatedValue).To(gomega.BeNil())
+			} else {
+				g.Expect(updatedValue).To(gomega.Equal(tt.wantUpdatedValue))
+			}
+		})
+	}
+}
+
+func Test_kafkaService_PrepareKafkaRequest_NamespaceAllocator(t *testing.T) {
+	tests := []struct {
+		name          string
+		allocator     KafkaNamespaceAllocator
+		wantNamespace string
+		wantErr       bool
+	}{
+		{
+			name:          "should derive the namespace from the kafka id when there is no allocator",
+			wantNamespace: "kafka-" + testID,
+		},
+		{
+			name: "should use the namespace returned by the allocator",
+			allocator: &KafkaNamespaceAllocatorMock{
+				AllocateFunc: func(kafkaRequest *dbapi.KafkaRequest) (string, *errors.ServiceError) {
+					return "pool-1", nil
+				},
+			},
+			wantNamespace: "pool-1",
+		},
+		{
+			name: "should return an error when the allocator fails",
+			allocator: &KafkaNamespaceAllocatorMock{
+				AllocateFunc: func(kafkaRequest *dbapi.KafkaRequest) (string, *errors.ServiceError) {
+					return "", errors.GeneralError("no namespace is available")
+				},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			mocket.Catcher.Reset().NewMock().WithQuery(`UPDATE "kafka_requests"`)
+			mocket.Catcher.NewMock().WithExecException().WithQueryException()
+
+			k := &kafkaService{
+				connectionFactory: db.NewMockConnectionFactory(nil),
+				clusterService: &ClusterServiceMock{
+					GetClusterDNSFunc: func(string) (string, *errors.ServiceError) {
+						return "clusterDNS", nil
+					},
+				},
+				keycloakService: &sso.KeycloakServiceMock{
+					GetConfigFunc: func() *keycloak.KeycloakConfig {
+						return &keycloak.KeycloakConfig{}
+					},
+				},
+				kafkaConfig:        &config.KafkaConfig{},
+				awsConfig:          config.NewAWSConfig(),
+				namespaceAllocator: tt.allocator,
+			}
+
+			kafkaRequest := buildKafkaRequest(func(kafkaRequest *dbapi.KafkaRequest) {
+				kafkaRequest.ID = testID
+				kafkaRequest.Namespace = ""
+			})
+			err := k.PrepareKafkaRequest(kafkaRequest)
+			g.Expect(err != nil).To(gomega.Equal(tt.wantErr))
+			g.Expect(kafkaRequest.Namespace).To(gomega.Equal(tt.wantNamespace))
+		})
+	}
+}
@@ -0,0 +1,133 @@
+package services
+
+import (
+	"container/list"
+	"sync"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/config"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+)
+
+// KafkaRequestCache is a small LRU cache of kafka requests by id. It avoids hitting the database when the same kafka
+// is read repeatedly, e.g. by admin tools during an incident. Entries expire after the TTL and are invalidated by
+// the kafka service whenever it changes a kafka. A nil cache disables caching.
+type KafkaRequestCache struct {
+	ttl     time.Duration
+	maxSize int
+	now     func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	// lru holds the entries from the most to the least recently used one
+	lru *list.List
+	// generation is increased by every invalidation, so that a kafka loaded concurrently is not cached stale
+	generation uint64
+}
+
+type kafkaRequestCacheEntry struct {
+	kafkaRequest dbapi.KafkaRequest
+	expiresAt    time.Time
+}
+
+// NewKafkaRequestCache returns nil, i.e. caching is disabled, unless both the TTL and the size of the cache are set
+func NewKafkaRequestCache(kafkaConfig *config.KafkaConfig) *KafkaRequestCache {
+	if kafkaConfig.KafkaRequestCacheTTL <= 0 || kafkaConfig.KafkaRequestCacheSize <= 0 {
+		return nil
+	}
+
+	return &KafkaRequestCache{
+		ttl:     kafkaConfig.KafkaRequestCacheTTL,
+		maxSize: kafkaConfig.KafkaRequestCacheSize,
+		now:     time.Now,
+		entries: map[string]*list.Element{},
+		lru:     list.New(),
+	}
+}
+
+// getOrLoad returns a copy of the cached kafka request with the given id if it has not expired yet, otherwise the
+// kafka request is loaded with the given function and cached
+func (c *KafkaRequestCache) getOrLoad(id string, load func() (*dbapi.KafkaRequest, *errors.ServiceError)) (*dbapi.KafkaRequest, *errors.ServiceError) {
+	if c == nil {
+		return load()
+	}
+
+	c.mu.Lock()
+	if element, ok := c.entries[id]; ok {
+		entry := element.Value.(*kafkaRequestCacheEntry)
+		if c.now().Before(entry.expiresAt) {
+			c.lru.MoveToFront(element)
+			kafkaRequest := entry.kafkaRequest
+			c.mu.Unlock()
+			return &kafkaRequest, nil
+		}
+		c.remove(element)
+	}
+	generation := c.generation
+	c.mu.Unlock()
+
+	// the kafka is loaded without holding the lock so that reads of other kafkas are not blocked
+	kafkaRequest, err := load()
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if generation == c.generation {
+		c.add(*kafkaRequest)
+	}
+
+	return kafkaRequest, nil
+}
+
+func (c *KafkaRequestCache) add(kafkaRequest dbapi.KafkaRequest) {
+	if element, ok := c.entries[kafkaRequest.ID]; ok {
+		c.remove(element)
+	}
+
+	entry := &kafkaRequestCacheEntry{
+		kafkaRequest: kafkaRequest,
+		expiresAt:    c.now().Add(c.ttl),
+	}
+	c.entries[kafkaRequest.ID] = c.lru.PushFront(entry)
+
+	for c.lru.Len() > c.maxSize {
+		c.remove(c.lru.Back())
+	}
+}
+
+func (c *KafkaRequestCache) remove(element *list.Element) {
+	entry := c.lru.Remove(element).(*kafkaRequestCacheEntry)
+	delete(c.entries, entry.kafkaRequest.ID)
+}
+
+// Invalidate discards the cached kafka requests with the given ids
+func (c *KafkaRequestCache) Invalidate(ids ...string) {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.generation++
+	for _, id := range ids {
+		if element, ok := c.entries[id]; ok {
+			c.remove(element)
+		}
+	}
+}
+
+// InvalidateAll discards all the cached kafka requests, e.g. after a bulk update of kafkas
+func (c *KafkaRequestCache) InvalidateAll() {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.generation++
+	c.entries = map[string]*list.Element{}
+	c.lru.Init()
+}
@@ -0,0 +1,292 @@
+package services
+
+import (
+	"context"
+	"database/sql/driver"
+	"testing"
+	"time"
+
+	constants2 "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/constants"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/config"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/converters"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/auth"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/onsi/gomega"
+
+	mocket "github.com/selvatico/go-mocket"
+)
+
+func Test_NewKafkaRequestCache(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	g.Expect(NewKafkaRequestCache(&config.KafkaConfig{KafkaRequestCacheSize: 10})).To(gomega.BeNil())
+	g.Expect(NewKafkaRequestCache(&config.KafkaConfig{KafkaRequestCacheTTL: time.Minute})).To(gomega.BeNil())
+	g.Expect(NewKafkaRequestCache(&config.KafkaConfig{KafkaRequestCacheTTL: time.Minute, KafkaRequestCacheSize: 10})).ToNot(gomega.BeNil())
+}
+
+func Test_KafkaRequestCache(t *testing.T) {
+	type loader struct {
+		loads int
+		load  func(id string) func() (*dbapi.KafkaRequest, *errors.ServiceError)
+	}
+	newLoader := func() *loader {
+		l := &loader{}
+		l.load = func(id string) func() (*dbapi.KafkaRequest, *errors.ServiceError) {
+			return func() (*dbapi.KafkaRequest, *errors.ServiceError) {
+				l.loads++
+				return buildKafkaRequest(func(kafkaRequest *dbapi.KafkaRequest) {
+					kafkaRequest.ID = id
+				}), nil
+			}
+		}
+		return l
+	}
+	newCache := func(now *time.Time, maxSize int) *KafkaRequestCache {
+		cache := NewKafkaRequestCache(&config.KafkaConfig{KafkaRequestCacheTTL: time.Minute, KafkaRequestCacheSize: maxSize})
+		cache.now = func() time.Time { return *now }
+		return cache
+	}
+
+	t.Run("should load the kafka request on every read when the cache is disabled", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+		l := newLoader()
+		var cache *KafkaRequestCache
+
+		for i := 0; i < 2; i++ {
+			kafkaRequest, err := cache.getOrLoad("a", l.load("a"))
+			g.Expect(err).To(gomega.BeNil())
+			g.Expect(kafkaRequest.ID).To(gomega.Equal("a"))
+		}
+		g.Expect(l.loads).To(gomega.Equal(2))
+		cache.Invalidate("a")
+		cache.InvalidateAll()
+	})
+
+	t.Run("should serve repeated reads within the TTL from the cache", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+		l := newLoader()
+		now := time.Now()
+		cache := newCache(&now, 10)
+
+		_, _ = cache.getOrLoad("a", l.load("a"))
+		now = now.Add(30 * time.Second)
+		kafkaRequest, err := cache.getOrLoad("a", l.load("a"))
+		g.Expect(err).To(gomega.BeNil())
+		g.Expect(kafkaRequest.ID).To(gomega.Equal("a"))
+		g.Expect(l.loads).To(gomega.Equal(1))
+
+		// the cached entry is not changed by the callers
+		kafkaRequest.Status = constants2.KafkaRequestStatusFailed.String()
+		kafkaRequest, _ = cache.getOrLoad("a", l.load("a"))
+		g.Expect(kafkaRequest.Status).ToNot(gomega.Equal(constants2.KafkaRequestStatusFailed.String()))
+
+		now = now.Add(time.Minute)
+		_, _ = cache.getOrLoad("a", l.load("a"))
+		g.Expect(l.loads).To(gomega.Equal(2))
+	})
+
+	t.Run("should not cache kafka requests that fail to load", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+		now := time.Now()
+		cache := newCache(&now, 10)
+		loads := 0
+		load := func() (*dbapi.KafkaRequest, *errors.ServiceError) {
+			loads++
+			return nil, errors.NotFound("not found")
+		}
+
+		for i := 0; i < 2; i++ {
+			_, err := cache.getOrLoad("a", load)
+			g.Expect(err).ToNot(gomega.BeNil())
+		}
+		g.Expect(loads).To(gomega.Equal(2))
+	})
+
+	t.Run("should evict the least recently used kafka request", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+		l := newLoader()
+		now := time.Now()
+		cache := newCache(&now, 2)
+
+		_, _ = cache.getOrLoad("a", l.load("a"))
+		_, _ = cache.getOrLoad("b", l.load("b"))
+		_, _ = cache.getOrLoad("a", l.load("a"))
+		_, _ = cache.getOrLoad("c", l.load("c"))
+		g.Expect(l.loads).To(gomega.Equal(3))
+
+		_, _ = cache.getOrLoad("a", l.load("a"))
+		g.Expect(l.loads).To(gomega.Equal(3))
+		_, _ = cache.getOrLoad("b", l.load("b"))
+		g.Expect(l.loads).To(gomega.Equal(4))
+	})
+
+	t.Run("should load the kafka requests again once they are invalidated", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+		l := newLoader()
+		now := time.Now()
+		cache := newCache(&now, 10)
+
+		_, _ = cache.getOrLoad("a", l.load("a"))
+		_, _ = cache.getOrLoad("b", l.load("b"))
+		cache.Invalidate("a")
+		_, _ = cache.getOrLoad("a", l.load("a"))
+		_, _ = cache.getOrLoad("b", l.load("b"))
+		g.Expect(l.loads).To(gomega.Equal(3))
+
+		cache.InvalidateAll()
+		_, _ = cache.getOrLoad("a", l.load("a"))
+		_, _ = cache.getOrLoad("b", l.load("b"))
+		g.Expect(l.loads).To(gomega.Equal(5))
+	})
+
+	t.Run("should not cache a kafka request invalidated while it was loaded", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+		l := newLoader()
+		now := time.Now()
+		cache := newCache(&now, 10)
+
+		_, _ = cache.getOrLoad("a", func() (*dbapi.KafkaRequest, *errors.ServiceError) {
+			cache.Invalidate("a")
+			return l.load("a")()
+		})
+		_, _ = cache.getOrLoad("a", l.load("a"))
+		g.Expect(l.loads).To(gomega.Equal(2))
+	})
+}
+
+func Test_kafkaService_Get_Cache(t *testing.T) {
+	authHelper, err := auth.NewAuthHelper(JwtKeyFile, JwtCAFile, "")
+	if err != nil {
+		t.Fatalf("failed to create auth helper: %s", err.Error())
+	}
+	account, err := authHelper.NewAccount(testUser, "", "", "")
+	if err != nil {
+		t.Fatal("failed to build a new account")
+	}
+	jwt, err := authHelper.CreateJWTWithClaims(account, nil)
+	if err != nil {
+		t.Fatalf("failed to create jwt: %s", err.Error())
+	}
+	ownerCtx := auth.SetTokenInContext(context.TODO(), jwt)
+	adminCtx := auth.SetIsAdminContext(ownerCtx, true)
+
+	kafkaRequest := buildKafkaRequest(func(kafkaRequest *dbapi.KafkaRequest) {
+		kafkaRequest.ID = testID
+		kafkaRequest.ClusterID = ""
+		kafkaRequest.Status = constants2.KafkaRequestStatusReady.String()
+	})
+
+	tests := []struct {
+		name   string
+		mutate func(k *kafkaService) *errors.ServiceError
+	}{
+		{
+			name: "Update",
+			mutate: func(k *kafkaService) *errors.ServiceError {
+				return k.Update(kafkaRequest)
+			},
+		},
+		{
+			name: "Updates",
+			mutate: func(k *kafkaService) *errors.ServiceError {
+				return k.Updates(kafkaRequest, map[string]interface{}{"reauthentication_enabled": false})
+			},
+		},
+		{
+			name: "UpdateStatus",
+			mutate: func(k *kafkaService) *errors.ServiceError {
+				_, err := k.UpdateStatus(testID, constants2.KafkaRequestStatusSuspending)
+				return err
+			},
+		},
+		{
+			name: "Delete",
+			mutate: func(k *kafkaService) *errors.ServiceError {
+				return k.Delete(kafkaRequest)
+			},
+		},
+		{
+			name: "SetReauthenticationBulk",
+			mutate: func(k *kafkaService) *errors.ServiceError {
+				_, err := k.SetReauthenticationBulk([]string{testID}, false)
+				return err
+			},
+		},
+	}
+
+	setupDB := func() *int {
+		selects := 0
+		mocket.Catcher.Reset()
+		mocket.Catcher.NewMock().
+			WithQuery(`SELECT * FROM "kafka_requests" WHERE id = $1`).
+			WithArgs(testID).
+			WithCallback(func(_ string, _ []driver.NamedValue) {
+				selects++
+			}).
+			WithReply(converters.ConvertKafkaRequest(kafkaRequest))
+		return &selects
+	}
+	newKafkaService := func() *kafkaService {
+		return &kafkaService{
+			connectionFactory: db.NewMockConnectionFactory(nil),
+			kafkaConfig:       &config.KafkaConfig{},
+			kafkaRequestCache: NewKafkaRequestCache(&config.KafkaConfig{KafkaRequestCacheTTL: time.Minute, KafkaRequestCacheSize: 10}),
+		}
+	}
+
+	t.Run("should serve the repeated reads of an admin from the cache", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+		selects := setupDB()
+		k := newKafkaService()
+
+		for i := 0; i < 3; i++ {
+			got, err := k.Get(adminCtx, testID)
+			g.Expect(err).To(gomega.BeNil())
+			g.Expect(got.ID).To(gomega.Equal(testID))
+		}
+		g.Expect(*selects).To(gomega.Equal(1))
+	})
+
+	t.Run("should not cache the reads of other users nor GetById", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+		selects := setupDB()
+		mocket.Catcher.NewMock().
+			WithQuery(`SELECT * FROM "kafka_requests" WHERE id = $1 AND owner = $2`).
+			WithArgs(testID, testUser).
+			WithReply(converters.ConvertKafkaRequest(kafkaRequest))
+		k := newKafkaService()
+
+		_, err := k.Get(adminCtx, testID)
+		g.Expect(err).To(gomega.BeNil())
+		_, err = k.GetById(testID)
+		g.Expect(err).To(gomega.BeNil())
+		g.Expect(*selects).To(gomega.Equal(2))
+
+		_, err = k.Get(ownerCtx, testID)
+		g.Expect(err).To(gomega.BeNil())
+		_, err = k.Get(ownerCtx, testID)
+		g.Expect(err).To(gomega.BeNil())
+		g.Expect(k.kafkaRequestCache.lru.Len()).To(gomega.Equal(1))
+	})
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run("should invalidate the cached kafka request on "+tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			selects := setupDB()
+			k := newKafkaService()
+
+			_, err := k.Get(adminCtx, testID)
+			g.Expect(err).To(gomega.BeNil())
+			g.Expect(tt.mutate(k)).To(gomega.BeNil())
+			g.Expect(k.kafkaRequestCache.entries).ToNot(gomega.HaveKey(testID))
+
+			before := *selects
+			_, err = k.Get(adminCtx, testID)
+			g.Expect(err).To(gomega.BeNil())
+			g.Expect(*selects).To(gomega.Equal(before + 1))
+		})
+	}
+}
@@ -2,6 +2,7 @@ package services
 
 import (
 	"context"
+	"database/sql/driver"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -25,11 +26,15 @@ import (
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/client/keycloak"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/metrics"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/authorization"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/sso"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared/utils/arrays"
 	"github.com/onsi/gomega"
 	goerrors "github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	mocket "github.com/selvatico/go-mocket"
 	"gorm.io/gorm"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -320,6 +325,455 @@ func Test_kafkaService_Get(t *testing.T) {
 	}
 }
 
+func Test_kafkaService_GetByIdForContext(t *testing.T) {
+	authHelper, err := auth.NewAuthHelper(JwtKeyFile, JwtCAFile, "")
+	if err != nil {
+		t.Fatalf("failed to create auth helper: %s", err.Error())
+	}
+	newCtx := func(username string, orgId string, orgAdmin bool) context.Context {
+		account, err := authHelper.NewAccount(username, "", "", orgId)
+		if err != nil {
+			t.Fatal("failed to build a new account")
+		}
+		jwt, err := authHelper.CreateJWTWithClaims(account, map[string]interface{}{"is_org_admin": orgAdmin})
+		if err != nil {
+			t.Fatalf("failed to create jwt: %s", err.Error())
+		}
+		return auth.SetTokenInContext(context.TODO(), jwt)
+	}
+	ownerCtx := newCtx(testUser, "", false)
+	otherUserCtx := newCtx("other-user", "", false)
+	orgAdminCtx := auth.SetFilterByOrganisationContext(newCtx("other-user", "org-a", true), true)
+	otherOrgAdminCtx := auth.SetFilterByOrganisationContext(newCtx("other-user", "org-b", true), true)
+	adminCtx := auth.SetIsAdminContext(newCtx("other-user", "", false), true)
+	ownerAdminCtx := auth.SetIsAdminContext(newCtx(testUser, "", false), true)
+
+	kafkaRequest := buildKafkaRequest(func(kafkaRequest *dbapi.KafkaRequest) {
+		kafkaRequest.OrganisationId = "org-a"
+	})
+
+	tests := []struct {
+		name        string
+		ctx         context.Context
+		id          string
+		want        *dbapi.KafkaRequest
+		wantErrCode errors.ServiceErrorCode
+	}{
+		{
+			name: "should return their kafka to an admin",
+			ctx:  ownerAdminCtx,
+			id:   testID,
+			want: kafkaRequest,
+		},
+		{
+			name:        "should return a forbidden error to an admin when the kafka belongs to another user",
+			ctx:         adminCtx,
+			id:          testID,
+			wantErrCode: errors.ErrorForbidden,
+		},
+		{
+			name:        "should return a not found error to an admin when the kafka does not exist",
+			ctx:         adminCtx,
+			id:          "unknown",
+			wantErrCode: errors.ErrorNotFound,
+		},
+		{
+			name: "should return the kafka of their organisation to an organisation admin",
+			ctx:  orgAdminCtx,
+			id:   testID,
+			want: kafkaRequest,
+		},
+		{
+			name:        "should return a not found error to an organisation admin when the kafka belongs to another organisation",
+			ctx:         otherOrgAdminCtx,
+			id:          testID,
+			wantErrCode: errors.ErrorNotFound,
+		},
+		{
+			name:        "should return a not found error to an organisation admin when the kafka does not exist",
+			ctx:         otherOrgAdminCtx,
+			id:          "unknown",
+			wantErrCode: errors.ErrorNotFound,
+		},
+		{
+			name: "should return the kafka to its owner",
+			ctx:  ownerCtx,
+			id:   testID,
+			want: kafkaRequest,
+		},
+		{
+			name:        "should return a not found error to a user when the kafka belongs to another user",
+			ctx:         otherUserCtx,
+			id:          testID,
+			wantErrCode: errors.ErrorNotFound,
+		},
+		{
+			name:        "should return a not found error to a user when the kafka does not exist",
+			ctx:         otherUserCtx,
+			id:          "unknown",
+			wantErrCode: errors.ErrorNotFound,
+		},
+		{
+			name:        "should return an error when the user is not authenticated",
+			ctx:         context.TODO(),
+			id:          testID,
+			wantErrCode: errors.ErrorUnauthenticated,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			mocket.Catcher.Reset()
+			mocket.Catcher.NewMock().
+				WithQuery(`SELECT * FROM "kafka_requests"`).
+				WithArgs(testID, testUser).
+				WithReply(converters.ConvertKafkaRequest(kafkaRequest))
+			mocket.Catcher.NewMock().
+				WithQuery(`SELECT * FROM "kafka_requests"`).
+				WithArgs(testID, "org-a").
+				WithReply(converters.ConvertKafkaRequest(kafkaRequest))
+			mocket.Catcher.NewMock().
+				WithQuery(`SELECT * FROM "kafka_requests"`).
+				WithArgs(testID).
+				WithReply(converters.ConvertKafkaRequest(kafkaRequest))
+			// any other kafka request is not found
+			mocket.Catcher.NewMock().WithQuery("SELECT").WithReply([]map[string]interface{}{})
+			mocket.Catcher.NewMock().WithExecException()
+
+			k := &kafkaService{
+				connectionFactory: db.NewMockConnectionFactory(nil),
+			}
+			got, err := k.GetByIdForContext(tt.ctx, tt.id)
+			if tt.wantErrCode != 0 {
+				g.Expect(err).ToNot(gomega.BeNil())
+				g.Expect(err.Code).To(gomega.Equal(tt.wantErrCode))
+				return
+			}
+			g.Expect(err).To(gomega.BeNil())
+			// the organisation id is not part of the mocked reply
+			g.Expect(got.ID).To(gomega.Equal(tt.want.ID))
+			g.Expect(got.Owner).To(gomega.Equal(tt.want.Owner))
+		})
+	}
+}
+
+func Test_kafkaService_GetByName(t *testing.T) {
+	authHelper, err := auth.NewAuthHelper(JwtKeyFile, JwtCAFile, "")
+	if err != nil {
+		t.Fatalf("failed to create auth helper: %s", err.Error())
+	}
+	newCtx := func(orgId string) context.Context {
+		account, err := authHelper.NewAccount(testUser, "", "", orgId)
+		if err != nil {
+			t.Fatal("failed to build a new account")
+		}
+		jwt, err := authHelper.CreateJWTWithClaims(account, nil)
+		if err != nil {
+			t.Fatalf("failed to create jwt: %s", err.Error())
+		}
+		return auth.SetTokenInContext(context.TODO(), jwt)
+	}
+	ownerCtx := newCtx("")
+	orgCtx := auth.SetFilterByOrganisationContext(newCtx("org-a"), true)
+	otherOrgCtx := auth.SetFilterByOrganisationContext(newCtx("org-b"), true)
+	adminCtx := auth.SetIsAdminContext(newCtx(""), true)
+
+	kafkaRequest := buildKafkaRequest(func(kafkaRequest *dbapi.KafkaRequest) {
+		kafkaRequest.OrganisationId = "org-a"
+	})
+
+	tests := []struct {
+		name        string
+		ctx         context.Context
+		kafkaName   string
+		want        *dbapi.KafkaRequest
+		wantErrCode errors.ServiceErrorCode
+	}{
+		{
+			name:        "should return an error when the name is undefined",
+			ctx:         ownerCtx,
+			kafkaName:   "",
+			wantErrCode: errors.ErrorValidation,
+		},
+		{
+			name:        "should return an error when the user is not authenticated",
+			ctx:         context.TODO(),
+			kafkaName:   testKafkaRequestName,
+			wantErrCode: errors.ErrorUnauthenticated,
+		},
+		{
+			name:      "should return the kafka request of the owner with the given name",
+			ctx:       ownerCtx,
+			kafkaName: testKafkaRequestName,
+			want:      kafkaRequest,
+		},
+		{
+			name:      "should return the kafka request of the organisation with the given name",
+			ctx:       orgCtx,
+			kafkaName: testKafkaRequestName,
+			want:      kafkaRequest,
+		},
+		{
+			name:      "should return the oldest kafka request with the given name to an admin",
+			ctx:       adminCtx,
+			kafkaName: testKafkaRequestName,
+			want:      kafkaRequest,
+		},
+		{
+			name:        "should return a not found error when no kafka request has the given name",
+			ctx:         ownerCtx,
+			kafkaName:   "unknown",
+			wantErrCode: errors.ErrorNotFound,
+		},
+		{
+			name:        "should not return the kafka request of another organisation",
+			ctx:         otherOrgCtx,
+			kafkaName:   testKafkaRequestName,
+			wantErrCode: errors.ErrorNotFound,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			mocket.Catcher.Reset()
+			mocket.Catcher.NewMock().
+				WithQuery(`SELECT * FROM "kafka_requests" WHERE name = $1 AND owner = $2`).
+				WithArgs(testKafkaRequestName, testUser).
+				WithReply(converters.ConvertKafkaRequest(kafkaRequest))
+			mocket.Catcher.NewMock().
+				WithQuery(`SELECT * FROM "kafka_requests" WHERE name = $1 AND (organisation_id = $2)`).
+				WithArgs(testKafkaRequestName, "org-a").
+				WithReply(converters.ConvertKafkaRequest(kafkaRequest))
+			mocket.Catcher.NewMock().
+				WithQuery(`SELECT * FROM "kafka_requests" WHERE name = $1 AND "kafka_requests"."deleted_at" IS NULL ORDER BY created_at`).
+				WithArgs(testKafkaRequestName).
+				WithReply(converters.ConvertKafkaRequest(kafkaRequest))
+			// any other kafka request is not found
+			mocket.Catcher.NewMock().WithQuery("SELECT").WithReply([]map[string]interface{}{})
+			mocket.Catcher.NewMock().WithExecException()
+
+			k := &kafkaService{
+				connectionFactory: db.NewMockConnectionFactory(nil),
+			}
+			got, err := k.GetByName(tt.ctx, tt.kafkaName)
+			if tt.wantErrCode != 0 {
+				g.Expect(err).ToNot(gomega.BeNil())
+				g.Expect(err.Code).To(gomega.Equal(tt.wantErrCode))
+				return
+			}
+			g.Expect(err).To(gomega.BeNil())
+			g.Expect(got.ID).To(gomega.Equal(tt.want.ID))
+			g.Expect(got.Name).To(gomega.Equal(tt.want.Name))
+		})
+	}
+}
+
+func Test_kafkaService_GetWithFields(t *testing.T) {
+	type fields struct {
+		connectionFactory *db.ConnectionFactory
+	}
+	type args struct {
+		ctx     context.Context
+		id      string
+		columns []string
+	}
+
+	authHelper, err := auth.NewAuthHelper(JwtKeyFile, JwtCAFile, "")
+	if err != nil {
+		t.Fatalf("failed to create auth helper: %s", err.Error())
+	}
+	account, err := authHelper.NewAccount(testUser, "", "", "")
+	if err != nil {
+		t.Fatal("failed to build a new account")
+	}
+
+	jwt, err := authHelper.CreateJWTWithClaims(account, nil)
+	if err != nil {
+		t.Fatalf("failed to create jwt: %s", err.Error())
+	}
+	authenticatedCtx := auth.SetTokenInContext(context.TODO(), jwt)
+
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    *dbapi.KafkaRequest
+		wantErr bool
+		setupFn func()
+	}{
+		{
+			name: "error when no column is given",
+			fields: fields{
+				connectionFactory: db.NewMockConnectionFactory(nil),
+			},
+			args: args{
+				ctx: authenticatedCtx,
+				id:  testID,
+			},
+			wantErr: true,
+		},
+		{
+			name: "error when an unknown column is given",
+			fields: fields{
+				connectionFactory: db.NewMockConnectionFactory(nil),
+			},
+			args: args{
+				ctx:     authenticatedCtx,
+				id:      testID,
+				columns: []string{"status", "unknown"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "error when sql where query fails",
+			fields: fields{
+				connectionFactory: db.NewMockConnectionFactory(nil),
+			},
+			args: args{
+				ctx:     authenticatedCtx,
+				id:      testID,
+				columns: []string{"status"},
+			},
+			wantErr: true,
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().WithQuery("SELECT").WithQueryException()
+			},
+		},
+		{
+			name: "only the requested columns are populated",
+			fields: fields{
+				connectionFactory: db.NewMockConnectionFactory(nil),
+			},
+			args: args{
+				ctx:     authenticatedCtx,
+				id:      testID,
+				columns: []string{"id", "status"},
+			},
+			want: &dbapi.KafkaRequest{
+				Meta: api.Meta{
+					ID: testID,
+				},
+				Status: constants2.KafkaRequestStatusReady.String(),
+			},
+			setupFn: func() {
+				mocket.Catcher.Reset().
+					NewMock().
+					WithQuery(`SELECT "id","status" FROM "kafka_requests" WHERE id = $1 AND owner = $2`).
+					WithArgs(testID, testUser).
+					WithReply([]map[string]interface{}{{"id": testID, "status": constants2.KafkaRequestStatusReady.String()}})
+				mocket.Catcher.NewMock().WithExecException().WithQueryException()
+			},
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			if tt.setupFn != nil {
+				tt.setupFn()
+			}
+			k := &kafkaService{
+				connectionFactory: tt.fields.connectionFactory,
+			}
+			got, err := k.GetWithFields(tt.args.ctx, tt.args.id, tt.args.columns)
+			g.Expect(err != nil).To(gomega.Equal(tt.wantErr))
+			g.Expect(got).To(gomega.Equal(tt.want))
+		})
+	}
+}
+
+func Test_kafkaService_GetWithRoutes(t *testing.T) {
+	authHelper, err := auth.NewAuthHelper(JwtKeyFile, JwtCAFile, "")
+	if err != nil {
+		t.Fatalf("failed to create auth helper: %s", err.Error())
+	}
+	account, err := authHelper.NewAccount(testUser, "", "", "")
+	if err != nil {
+		t.Fatal("failed to build a new account")
+	}
+
+	jwt, err := authHelper.CreateJWTWithClaims(account, nil)
+	if err != nil {
+		t.Fatalf("failed to create jwt: %s", err.Error())
+	}
+	authenticatedCtx := auth.SetTokenInContext(context.TODO(), jwt)
+
+	kafkaRequest := buildKafkaRequest(func(kafkaRequest *dbapi.KafkaRequest) {
+		kafkaRequest.ID = testID
+	})
+
+	tests := []struct {
+		name       string
+		routes     interface{}
+		wantRoutes []dbapi.DataPlaneKafkaRoute
+		wantErr    bool
+		setupFn    func()
+	}{
+		{
+			name:    "should return an error when the kafka request is not found",
+			wantErr: true,
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().WithQuery("SELECT").WithQueryException()
+			},
+		},
+		{
+			name:   "should return the decoded routes of the kafka request",
+			routes: []byte(`[{"domain": "test.example.com", "router": "test.rhcloud.com"}, {"domain": "broker-0.test.example.com", "router": "test.rhcloud.com"}]`),
+			wantRoutes: []dbapi.DataPlaneKafkaRoute{
+				{Domain: "test.example.com", Router: "test.rhcloud.com"},
+				{Domain: "broker-0.test.example.com", Router: "test.rhcloud.com"},
+			},
+		},
+		{
+			name:       "should return empty routes when the kafka request has no routes",
+			wantRoutes: []dbapi.DataPlaneKafkaRoute{},
+		},
+		{
+			name: "should return empty routes when the routes of the kafka request are malformed",
+			// the routes column only holds valid JSON, so they are malformed when they are not a list of routes
+			routes:     []byte(`{"domain": "test.example.com"}`),
+			wantRoutes: []dbapi.DataPlaneKafkaRoute{},
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			if tt.setupFn != nil {
+				tt.setupFn()
+			} else {
+				reply := converters.ConvertKafkaRequest(kafkaRequest)
+				if tt.routes != nil {
+					reply[0]["routes"] = tt.routes
+				}
+				mocket.Catcher.Reset().
+					NewMock().
+					WithQuery(`SELECT * FROM "kafka_requests" WHERE id = $1 AND owner = $2`).
+					WithArgs(testID, testUser).
+					WithReply(reply)
+				mocket.Catcher.NewMock().WithExecException().WithQueryException()
+			}
+			k := &kafkaService{
+				connectionFactory: db.NewMockConnectionFactory(nil),
+			}
+
+			got, err := k.GetWithRoutes(authenticatedCtx, testID)
+			g.Expect(err != nil).To(gomega.Equal(tt.wantErr))
+			if tt.wantErr {
+				return
+			}
+			g.Expect(got.ID).To(gomega.Equal(testID))
+			g.Expect(got.DNSRoutes).To(gomega.Equal(tt.wantRoutes))
+		})
+	}
+}
+
 func Test_kafkaService_GetById(t *testing.T) {
 	type fields struct {
 		connectionFactory *db.ConnectionFactory
@@ -400,19 +854,383 @@ func Test_kafkaService_GetById(t *testing.T) {
 	}
 }
 
-func Test_kafkaService_PrepareKafkaRequest(t *testing.T) {
-	type fields struct {
-		connectionFactory *db.ConnectionFactory
-		clusterService    ClusterService
-		keycloakService   sso.KeycloakService
-		kafkaConfig       *config.KafkaConfig
-		kafkaService      KafkaService
-	}
-	type args struct {
-		kafkaRequest *dbapi.KafkaRequest
-	}
-
-	longKafkaName := "long-kafka-name-which-will-be-truncated-since-route-host-names-are-limited-to-63-characters"
+func Test_kafkaService_GetByIdIncludingDeleted(t *testing.T) {
+	deletedKafka := buildKafkaRequest(func(kafkaRequest *dbapi.KafkaRequest) {
+		kafkaRequest.DeletedAt = gorm.DeletedAt{Time: time.Now(), Valid: true}
+	})
+
+	tests := []struct {
+		name    string
+		id      string
+		wantErr bool
+		setupFn func()
+	}{
+		{
+			name:    "error when kafka id is undefined",
+			id:      "",
+			wantErr: true,
+		},
+		{
+			name:    "error when sql where query fails",
+			id:      testID,
+			wantErr: true,
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().WithQuery("SELECT").WithQueryException()
+			},
+		},
+		{
+			name: "should return a soft deleted kafka",
+			id:   testID,
+			setupFn: func() {
+				mocket.Catcher.Reset()
+				// only the query not filtering out the soft deleted kafkas returns the kafka
+				mocket.Catcher.NewMock().
+					WithQuery(`SELECT * FROM "kafka_requests" WHERE id = $1 ORDER BY`).
+					WithArgs(testID).
+					WithReply(converters.ConvertKafkaRequest(deletedKafka))
+				mocket.Catcher.NewMock().
+					WithQuery(`SELECT * FROM "kafka_requests" WHERE id = $1 AND "kafka_requests"."deleted_at" IS NULL`).
+					WithArgs(testID).
+					WithReply([]map[string]interface{}{})
+				mocket.Catcher.NewMock().WithExecException().WithQueryException()
+			},
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			if tt.setupFn != nil {
+				tt.setupFn()
+			}
+			k := &kafkaService{
+				connectionFactory: db.NewMockConnectionFactory(nil),
+			}
+			got, err := k.GetByIdIncludingDeleted(tt.id)
+			g.Expect(err != nil).To(gomega.Equal(tt.wantErr))
+			if tt.wantErr {
+				return
+			}
+			g.Expect(got.ID).To(gomega.Equal(testID))
+
+			// the soft deleted kafka is not returned by GetById
+			_, err = k.GetById(tt.id)
+			g.Expect(err).ToNot(gomega.BeNil())
+			g.Expect(err.Is404()).To(gomega.BeTrue())
+		})
+	}
+}
+
+func Test_kafkaService_GetClusterForKafka(t *testing.T) {
+	cluster := &api.Cluster{ClusterID: testClusterID, Status: api.ClusterReady}
+
+	tests := []struct {
+		name         string
+		kafka        *dbapi.KafkaRequest
+		findClusters func(clusterID string) (*api.Cluster, *errors.ServiceError)
+		want         *api.Cluster
+		wantErrCode  errors.ServiceErrorCode
+	}{
+		{
+			name:  "should return the cluster of the kafka",
+			kafka: buildKafkaRequest(nil),
+			findClusters: func(clusterID string) (*api.Cluster, *errors.ServiceError) {
+				if clusterID == testClusterID {
+					return cluster, nil
+				}
+				return nil, nil
+			},
+			want: cluster,
+		},
+		{
+			name:  "should return a not found error when the cluster of the kafka does not exist",
+			kafka: buildKafkaRequest(nil),
+			findClusters: func(clusterID string) (*api.Cluster, *errors.ServiceError) {
+				return nil, nil
+			},
+			wantErrCode: errors.ErrorNotFound,
+		},
+		{
+			name: "should return a not found error when the kafka is not assigned to a cluster",
+			kafka: buildKafkaRequest(func(kafkaRequest *dbapi.KafkaRequest) {
+				kafkaRequest.ClusterID = ""
+			}),
+			wantErrCode: errors.ErrorNotFound,
+		},
+		{
+			name:  "should return an error when the cluster cannot be read",
+			kafka: buildKafkaRequest(nil),
+			findClusters: func(clusterID string) (*api.Cluster, *errors.ServiceError) {
+				return nil, errors.GeneralError("failed to find cluster")
+			},
+			wantErrCode: errors.ErrorGeneral,
+		},
+		{
+			name:        "should return a not found error when the kafka does not exist",
+			wantErrCode: errors.ErrorNotFound,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+
+			reply := []map[string]interface{}{}
+			if tt.kafka != nil {
+				reply = converters.ConvertKafkaRequest(tt.kafka)
+			}
+			mocket.Catcher.Reset().NewMock().
+				WithQuery(`SELECT * FROM "kafka_requests" WHERE id = $1`).
+				WithArgs(testID).
+				WithReply(reply)
+			mocket.Catcher.NewMock().WithExecException().WithQueryException()
+
+			k := &kafkaService{
+				connectionFactory: db.NewMockConnectionFactory(nil),
+				clusterService:    &ClusterServiceMock{FindClusterByIDFunc: tt.findClusters},
+			}
+			got, err := k.GetClusterForKafka(testID)
+			if tt.wantErrCode != 0 {
+				g.Expect(err).ToNot(gomega.BeNil())
+				g.Expect(err.Code).To(gomega.Equal(tt.wantErrCode))
+				return
+			}
+			g.Expect(err).To(gomega.BeNil())
+			g.Expect(got).To(gomega.Equal(tt.want))
+		})
+	}
+}
+
+func Test_kafkaService_GetByNamespace(t *testing.T) {
+	const poolNamespace = "pool-namespace-1"
+	kafka := buildKafkaRequest(func(kafkaRequest *dbapi.KafkaRequest) {
+		kafkaRequest.Namespace = poolNamespace
+	})
+
+	tests := []struct {
+		name        string
+		clusterID   string
+		namespace   string
+		wantErrCode errors.ServiceErrorCode
+	}{
+		{
+			name:      "should return the kafka in the namespace of the cluster",
+			clusterID: testClusterID,
+			namespace: poolNamespace,
+		},
+		{
+			name:        "should return a not found error when no kafka is in the namespace",
+			clusterID:   testClusterID,
+			namespace:   "kafka-unknown",
+			wantErrCode: errors.ErrorNotFound,
+		},
+		{
+			name:        "should return a not found error when the namespace is on another cluster",
+			clusterID:   "other-cluster-id",
+			namespace:   poolNamespace,
+			wantErrCode: errors.ErrorNotFound,
+		},
+		{
+			name:        "should return an error when the cluster id is undefined",
+			namespace:   poolNamespace,
+			wantErrCode: errors.ErrorValidation,
+		},
+		{
+			name:        "should return an error when the namespace is undefined",
+			clusterID:   testClusterID,
+			wantErrCode: errors.ErrorValidation,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+
+			reply := converters.ConvertKafkaRequest(kafka)
+			reply[0]["namespace"] = poolNamespace
+			mocket.Catcher.Reset()
+			mocket.Catcher.NewMock().
+				WithQuery(`SELECT * FROM "kafka_requests" WHERE (cluster_id = $1 AND namespace = $2)`).
+				WithArgs(testClusterID, poolNamespace).
+				WithReply(reply)
+			mocket.Catcher.NewMock().
+				WithQuery(`SELECT * FROM "kafka_requests" WHERE (cluster_id = $1 AND namespace = $2)`).
+				WithReply([]map[string]interface{}{})
+			mocket.Catcher.NewMock().WithExecException().WithQueryException()
+
+			k := &kafkaService{
+				connectionFactory: db.NewMockConnectionFactory(nil),
+			}
+			got, err := k.GetByNamespace(tt.clusterID, tt.namespace)
+			if tt.wantErrCode != 0 {
+				g.Expect(err).ToNot(gomega.BeNil())
+				g.Expect(err.Code).To(gomega.Equal(tt.wantErrCode))
+				return
+			}
+			g.Expect(err).To(gomega.BeNil())
+			g.Expect(got.ID).To(gomega.Equal(kafka.ID))
+			g.Expect(got.Namespace).To(gomega.Equal(poolNamespace))
+		})
+	}
+}
+
+func Test_kafkaService_BackfillQuotaType(t *testing.T) {
+	tests := []struct {
+		name      string
+		rowsNum   int
+		wantCount int64
+		wantErr   bool
+		setupFn   func(rowsNum int, query *string, args *[]driver.NamedValue)
+	}{
+		{
+			name:      "should set the configured quota type on the kafkas without one",
+			rowsNum:   2,
+			wantCount: 2,
+			setupFn: func(rowsNum int, query *string, args *[]driver.NamedValue) {
+				mocket.Catcher.Reset().NewMock().
+					WithQuery(`UPDATE "kafka_requests" SET "quota_type"=$1`).
+					WithRowsNum(int64(rowsNum)).
+					WithCallback(func(sql string, namedArgs []driver.NamedValue) {
+						*query = sql
+						*args = namedArgs
+					})
+				mocket.Catcher.NewMock().WithExecException().WithQueryException()
+			},
+		},
+		{
+			name:      "should return an error if the kafkas cannot be updated",
+			wantErr:   true,
+			wantCount: 0,
+			setupFn: func(rowsNum int, query *string, args *[]driver.NamedValue) {
+				mocket.Catcher.Reset().NewMock().WithExecException().WithQueryException()
+			},
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			var query string
+			var args []driver.NamedValue
+			tt.setupFn(tt.rowsNum, &query, &args)
+			k := &kafkaService{
+				connectionFactory: db.NewMockConnectionFactory(nil),
+				kafkaConfig:       config.NewKafkaConfig(),
+			}
+			k.kafkaConfig.Quota.Type = api.AMSQuotaType.String()
+
+			count, err := k.BackfillQuotaType()
+			g.Expect(err != nil).To(gomega.Equal(tt.wantErr))
+			g.Expect(count).To(gomega.Equal(tt.wantCount))
+			if tt.wantErr {
+				return
+			}
+			// only the kafkas with an empty quota type that are not soft deleted are updated, gorm scopes the batch update
+			g.Expect(query).To(gomega.ContainSubstring(`(quota_type = $3 OR quota_type IS NULL) AND "kafka_requests"."deleted_at" IS NULL`))
+			g.Expect(args).To(gomega.HaveLen(3))
+			g.Expect(args[0].Value).To(gomega.Equal(api.AMSQuotaType.String()))
+			g.Expect(args[2].Value).To(gomega.Equal(""))
+		})
+	}
+}
+
+func Test_kafkaService_SetReauthenticationBulk(t *testing.T) {
+	tests := []struct {
+		name      string
+		ids       []string
+		enabled   bool
+		wantCount int64
+		wantErr   bool
+		setupFn   func(args *[]driver.NamedValue)
+	}{
+		{
+			name:      "should enable the reauthentication of the kafkas",
+			ids:       []string{"kafka-a", "kafka-b"},
+			enabled:   true,
+			wantCount: 2,
+			setupFn: func(args *[]driver.NamedValue) {
+				mocket.Catcher.Reset().NewMock().
+					WithQuery(`UPDATE "kafka_requests" SET "reauthentication_enabled"=$1,"updated_at"=$2 WHERE id IN ($3,$4)`).
+					WithRowsNum(2).
+					WithCallback(func(_ string, namedArgs []driver.NamedValue) {
+						*args = namedArgs
+					})
+				mocket.Catcher.NewMock().WithExecException().WithQueryException()
+			},
+		},
+		{
+			name:      "should disable the reauthentication of the kafkas",
+			ids:       []string{"kafka-a", "kafka-b"},
+			enabled:   false,
+			wantCount: 2,
+			setupFn: func(args *[]driver.NamedValue) {
+				mocket.Catcher.Reset().NewMock().
+					WithQuery(`UPDATE "kafka_requests" SET "reauthentication_enabled"=$1,"updated_at"=$2 WHERE id IN ($3,$4)`).
+					WithRowsNum(2).
+					WithCallback(func(_ string, namedArgs []driver.NamedValue) {
+						*args = namedArgs
+					})
+				mocket.Catcher.NewMock().WithExecException().WithQueryException()
+			},
+		},
+		{
+			name:      "should not update anything when no kafka is given",
+			ids:       []string{},
+			enabled:   true,
+			wantCount: 0,
+			setupFn: func(args *[]driver.NamedValue) {
+				mocket.Catcher.Reset().NewMock().WithExecException().WithQueryException()
+			},
+		},
+		{
+			name:    "should return an error if the kafkas cannot be updated",
+			ids:     []string{"kafka-a"},
+			enabled: true,
+			wantErr: true,
+			setupFn: func(args *[]driver.NamedValue) {
+				mocket.Catcher.Reset().NewMock().WithExecException().WithQueryException()
+			},
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			var args []driver.NamedValue
+			tt.setupFn(&args)
+			k := &kafkaService{
+				connectionFactory: db.NewMockConnectionFactory(nil),
+			}
+
+			count, err := k.SetReauthenticationBulk(tt.ids, tt.enabled)
+			g.Expect(err != nil).To(gomega.Equal(tt.wantErr))
+			g.Expect(count).To(gomega.Equal(tt.wantCount))
+			if len(args) > 0 {
+				g.Expect(args[0].Value).To(gomega.Equal(tt.enabled))
+			}
+		})
+	}
+}
+
+func Test_kafkaService_PrepareKafkaRequest(t *testing.T) {
+	type fields struct {
+		connectionFactory *db.ConnectionFactory
+		clusterService    ClusterService
+		keycloakService   sso.KeycloakService
+		kafkaConfig       *config.KafkaConfig
+		kafkaService      KafkaService
+	}
+	type args struct {
+		kafkaRequest *dbapi.KafkaRequest
+	}
+
+	longKafkaName := "long-kafka-name-which-will-be-truncated-since-route-host-names-are-limited-to-63-characters"
 
 	tests := []struct {
 		name                    string
@@ -623,46 +1441,194 @@ func Test_kafkaService_PrepareKafkaRequest(t *testing.T) {
 	}
 }
 
-func Test_kafkaService_RegisterKafkaDeprovisionJob(t *testing.T) {
-	type fields struct {
-		connectionFactory *db.ConnectionFactory
-		quotaService      QuotaService
-	}
-	type args struct {
-		kafkaRequest *dbapi.KafkaRequest
-	}
+func Test_kafkaService_PrepareKafkaRequest_CanaryServiceAccountCleanup(t *testing.T) {
+	const canaryClientID = "canary-client-id"
+
 	tests := []struct {
-		name       string
-		fields     fields
-		args       args
-		wantErr    bool
-		wantErrMsg string
-		setupFn    func()
+		name              string
+		updateFails       bool
+		deleteErr         *errors.ServiceError
+		wantErr           bool
+		wantDeleteCalls   int
+		wantCanaryAccount string
 	}{
 		{
-			name: "error when id is undefined",
-			fields: fields{
-				connectionFactory: db.NewMockConnectionFactory(nil),
-				quotaService: &QuotaServiceMock{
-					DeleteQuotaFunc: func(id string) *errors.ServiceError {
-						return nil
-					},
-				},
-			},
-			args: args{
-				kafkaRequest: buildKafkaRequest(func(kafkaRequest *dbapi.KafkaRequest) {
-					kafkaRequest.ID = testID
-				}),
-			},
-			wantErr: true,
+			name:              "should keep the canary service account when the update succeeds",
+			wantDeleteCalls:   0,
+			wantCanaryAccount: canaryClientID,
 		},
 		{
-			name: "error when sql where query fails",
-			fields: fields{
+			name:            "should delete the canary service account when the update fails",
+			updateFails:     true,
+			wantErr:         true,
+			wantDeleteCalls: 1,
+		},
+		{
+			name:            "should return the update error when the canary service account cannot be deleted",
+			updateFails:     true,
+			deleteErr:       errors.FailedToDeleteServiceAccount("failed to delete service account"),
+			wantErr:         true,
+			wantDeleteCalls: 1,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			if tt.updateFails {
+				mocket.Catcher.Reset().NewMock().WithExecException().WithQueryException()
+			} else {
+				mocket.Catcher.Reset().NewMock().WithQuery(`UPDATE "kafka_requests"`)
+				mocket.Catcher.NewMock().WithExecException().WithQueryException()
+			}
+
+			keycloakService := &sso.KeycloakServiceMock{
+				GetConfigFunc: func() *keycloak.KeycloakConfig {
+					return &keycloak.KeycloakConfig{
+						KafkaRealm: &keycloak.KeycloakRealmConfig{
+							ClientID: "test",
+						},
+						EnableAuthenticationOnKafka: true,
+					}
+				},
+				CreateServiceAccountInternalFunc: func(request sso.CompleteServiceAccountRequest) (*api.ServiceAccount, *errors.ServiceError) {
+					return &api.ServiceAccount{ClientID: canaryClientID, ClientSecret: "secret"}, nil
+				},
+				DeleteServiceAccountInternalFunc: func(clientId string) *errors.ServiceError {
+					return tt.deleteErr
+				},
+			}
+			k := &kafkaService{
 				connectionFactory: db.NewMockConnectionFactory(nil),
-				quotaService: &QuotaServiceMock{
-					DeleteQuotaFunc: func(id string) *errors.ServiceError {
-						return nil
+				clusterService: &ClusterServiceMock{
+					GetClusterDNSFunc: func(string) (string, *errors.ServiceError) {
+						return "clusterDNS", nil
+					},
+				},
+				keycloakService: keycloakService,
+				kafkaConfig:     &config.KafkaConfig{},
+				awsConfig:       config.NewAWSConfig(),
+			}
+
+			kafkaRequest := buildKafkaRequest(nil)
+			err := k.PrepareKafkaRequest(kafkaRequest)
+			g.Expect(err != nil).To(gomega.Equal(tt.wantErr))
+			g.Expect(keycloakService.DeleteServiceAccountInternalCalls()).To(gomega.HaveLen(tt.wantDeleteCalls))
+			for _, call := range keycloakService.DeleteServiceAccountInternalCalls() {
+				g.Expect(call.ClientId).To(gomega.Equal(canaryClientID))
+			}
+			g.Expect(kafkaRequest.CanaryServiceAccountClientID).To(gomega.Equal(tt.wantCanaryAccount))
+		})
+	}
+}
+
+func Test_kafkaService_PrepareKafkaRequest_CanaryServiceAccountTemplates(t *testing.T) {
+	tests := []struct {
+		name             string
+		clientIDTemplate string
+		nameTemplate     string
+		wantClientID     string
+		wantName         string
+	}{
+		{
+			name:         "should use the default canary service account client id and name",
+			wantClientID: "canary-" + testID,
+			wantName:     "canary-service-account-for-kafka " + testID,
+		},
+		{
+			name:             "should use the configured canary service account templates",
+			clientIDTemplate: "Fleet-A-canary-{id}",
+			nameTemplate:     "fleet-a canary for kafka {id}",
+			wantClientID:     "fleet-a-canary-" + testID,
+			wantName:         "fleet-a canary for kafka " + testID,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			mocket.Catcher.Reset().NewMock().WithQuery(`UPDATE "kafka_requests"`)
+			mocket.Catcher.NewMock().WithExecException().WithQueryException()
+
+			keycloakService := &sso.KeycloakServiceMock{
+				GetConfigFunc: func() *keycloak.KeycloakConfig {
+					return &keycloak.KeycloakConfig{
+						EnableAuthenticationOnKafka:          true,
+						CanaryServiceAccountClientIDTemplate: tt.clientIDTemplate,
+						CanaryServiceAccountNameTemplate:     tt.nameTemplate,
+					}
+				},
+				CreateServiceAccountInternalFunc: func(request sso.CompleteServiceAccountRequest) (*api.ServiceAccount, *errors.ServiceError) {
+					return &api.ServiceAccount{ClientID: request.ClientId, ClientSecret: "secret"}, nil
+				},
+			}
+			k := &kafkaService{
+				connectionFactory: db.NewMockConnectionFactory(nil),
+				clusterService: &ClusterServiceMock{
+					GetClusterDNSFunc: func(string) (string, *errors.ServiceError) {
+						return "clusterDNS", nil
+					},
+				},
+				keycloakService: keycloakService,
+				kafkaConfig:     &config.KafkaConfig{},
+				awsConfig:       config.NewAWSConfig(),
+			}
+
+			kafkaRequest := buildKafkaRequest(func(kafkaRequest *dbapi.KafkaRequest) {
+				kafkaRequest.ID = testID
+			})
+			g.Expect(k.PrepareKafkaRequest(kafkaRequest)).To(gomega.BeNil())
+			g.Expect(keycloakService.CreateServiceAccountInternalCalls()).To(gomega.HaveLen(1))
+			request := keycloakService.CreateServiceAccountInternalCalls()[0].Request
+			g.Expect(request.ClientId).To(gomega.Equal(tt.wantClientID))
+			g.Expect(request.Name).To(gomega.Equal(tt.wantName))
+			g.Expect(kafkaRequest.CanaryServiceAccountClientID).To(gomega.Equal(tt.wantClientID))
+		})
+	}
+}
+
+func Test_kafkaService_RegisterKafkaDeprovisionJob(t *testing.T) {
+	type fields struct {
+		connectionFactory *db.ConnectionFactory
+		quotaService      QuotaService
+	}
+	type args struct {
+		kafkaRequest *dbapi.KafkaRequest
+	}
+	tests := []struct {
+		name       string
+		fields     fields
+		args       args
+		wantErr    bool
+		wantErrMsg string
+		setupFn    func()
+	}{
+		{
+			name: "error when id is undefined",
+			fields: fields{
+				connectionFactory: db.NewMockConnectionFactory(nil),
+				quotaService: &QuotaServiceMock{
+					DeleteQuotaFunc: func(id string) *errors.ServiceError {
+						return nil
+					},
+				},
+			},
+			args: args{
+				kafkaRequest: buildKafkaRequest(func(kafkaRequest *dbapi.KafkaRequest) {
+					kafkaRequest.ID = testID
+				}),
+			},
+			wantErr: true,
+		},
+		{
+			name: "error when sql where query fails",
+			fields: fields{
+				connectionFactory: db.NewMockConnectionFactory(nil),
+				quotaService: &QuotaServiceMock{
+					DeleteQuotaFunc: func(id string) *errors.ServiceError {
+						return nil
 					},
 				},
 			},
@@ -716,6 +1682,200 @@ func Test_kafkaService_RegisterKafkaDeprovisionJob(t *testing.T) {
 	}
 }
 
+func Test_kafkaService_RegisterKafkaDeprovisionJob_RepeatedRequests(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	authHelper, err := auth.NewAuthHelper(JwtKeyFile, JwtCAFile, "")
+	if err != nil {
+		t.Fatalf("failed to create auth helper: %s", err.Error())
+	}
+	account, err := authHelper.NewAccount(testUser, "", "", "")
+	if err != nil {
+		t.Fatal("failed to build a new account")
+	}
+	jwt, err := authHelper.CreateJWTWithClaims(account, nil)
+	if err != nil {
+		t.Fatalf("failed to create jwt: %s", err.Error())
+	}
+	authenticatedCtx := auth.SetTokenInContext(context.TODO(), jwt)
+
+	mockKafkaWithStatus := func(status constants2.KafkaStatus) {
+		mocket.Catcher.Reset()
+		mocket.Catcher.NewMock().
+			WithQuery(`SELECT * FROM "kafka_requests" WHERE id = $1`).
+			WithReply(converters.ConvertKafkaRequest(buildKafkaRequest(func(kafkaRequest *dbapi.KafkaRequest) {
+				kafkaRequest.Status = status.String()
+			})))
+		mocket.Catcher.NewMock().WithQuery(`UPDATE "kafka_requests" SET`).WithReply(nil)
+		mocket.Catcher.NewMock().WithExecException().WithQueryException()
+	}
+
+	k := &kafkaService{
+		connectionFactory: db.NewMockConnectionFactory(nil),
+		kafkaConfig:       config.NewKafkaConfig(),
+	}
+	metrics.Reset()
+
+	// the first request moves the kafka to deprovision
+	mockKafkaWithStatus(constants2.KafkaRequestStatusReady)
+	g.Expect(k.RegisterKafkaDeprovisionJob(authenticatedCtx, testID)).To(gomega.BeNil())
+
+	// the repeated requests find the kafka already being deleted
+	mockKafkaWithStatus(constants2.KafkaRequestStatusDeprovision)
+	g.Expect(k.RegisterKafkaDeprovisionJob(authenticatedCtx, testID)).To(gomega.BeNil())
+	mockKafkaWithStatus(constants2.KafkaRequestStatusDeleting)
+	g.Expect(k.RegisterKafkaDeprovisionJob(authenticatedCtx, testID)).To(gomega.BeNil())
+
+	successCountMetric := metrics.KasFleetManager + "_" + metrics.KafkaOperationsSuccessCount
+	totalCountMetric := metrics.KasFleetManager + "_" + metrics.KafkaOperationsTotalCount
+	g.Expect(testutil.GatherAndCompare(prometheus.DefaultGatherer, strings.NewReader(fmt.Sprintf(`# HELP %[1]s number of successful kafka operations
+# TYPE %[1]s counter
+%[1]s{operation="deprovision"} 1
+# HELP %[2]s number of total kafka operations
+# TYPE %[2]s counter
+%[2]s{operation="deprovision"} 1
+`, successCountMetric, totalCountMetric)), successCountMetric, totalCountMetric)).To(gomega.Succeed())
+}
+
+func Test_kafkaService_DeprovisionGracePeriod(t *testing.T) {
+	authHelper, err := auth.NewAuthHelper(JwtKeyFile, JwtCAFile, "")
+	if err != nil {
+		t.Fatalf("failed to create auth helper: %s", err.Error())
+	}
+	account, err := authHelper.NewAccount(testUser, "", "", "")
+	if err != nil {
+		t.Fatal("failed to build a new account")
+	}
+	jwt, err := authHelper.CreateJWTWithClaims(account, nil)
+	if err != nil {
+		t.Fatalf("failed to create jwt: %s", err.Error())
+	}
+	authenticatedCtx := auth.SetTokenInContext(context.TODO(), jwt)
+
+	tests := []struct {
+		name                 string
+		status               constants2.KafkaStatus
+		updatedRows          int
+		action               func(k *kafkaService) *errors.ServiceError
+		wantErr              bool
+		wantUpdatedStatus    constants2.KafkaStatus
+		wantStatusBeforeKept bool
+	}{
+		{
+			name:        "should put the kafka pending deletion when deprovisioned",
+			status:      constants2.KafkaRequestStatusReady,
+			updatedRows: 1,
+			action: func(k *kafkaService) *errors.ServiceError {
+				return k.RegisterKafkaDeprovisionJob(authenticatedCtx, testID)
+			},
+			wantUpdatedStatus:    constants2.KafkaRequestStatusDeprovisionPending,
+			wantStatusBeforeKept: true,
+		},
+		{
+			name:        "should deprovision a kafka pending deletion when deprovisioned again",
+			status:      constants2.KafkaRequestStatusDeprovisionPending,
+			updatedRows: 1,
+			action: func(k *kafkaService) *errors.ServiceError {
+				return k.RegisterKafkaDeprovisionJob(authenticatedCtx, testID)
+			},
+			wantUpdatedStatus: constants2.KafkaRequestStatusDeprovision,
+		},
+		{
+			name:              "should restore the status of a kafka pending deletion when its deletion is cancelled",
+			status:            constants2.KafkaRequestStatusDeprovisionPending,
+			updatedRows:       1,
+			action:            func(k *kafkaService) *errors.ServiceError { return k.CancelDeprovision(authenticatedCtx, testID) },
+			wantUpdatedStatus: constants2.KafkaRequestStatusReady,
+		},
+		{
+			name:        "should not cancel the deletion of a kafka that is not pending deletion",
+			status:      constants2.KafkaRequestStatusDeprovision,
+			updatedRows: 1,
+			action:      func(k *kafkaService) *errors.ServiceError { return k.CancelDeprovision(authenticatedCtx, testID) },
+			wantErr:     true,
+		},
+		{
+			name:              "should not cancel the deletion of a kafka whose deletion has been confirmed in the meantime",
+			status:            constants2.KafkaRequestStatusDeprovisionPending,
+			updatedRows:       0,
+			action:            func(k *kafkaService) *errors.ServiceError { return k.CancelDeprovision(authenticatedCtx, testID) },
+			wantErr:           true,
+			wantUpdatedStatus: constants2.KafkaRequestStatusReady,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+
+			reply := converters.ConvertKafkaRequest(buildKafkaRequest(func(kafkaRequest *dbapi.KafkaRequest) {
+				kafkaRequest.Status = tt.status.String()
+			}))
+			if tt.status == constants2.KafkaRequestStatusDeprovisionPending {
+				reply[0]["status_before_deprovision"] = constants2.KafkaRequestStatusReady.String()
+			}
+			var updateArgs []interface{}
+			mocket.Catcher.Reset()
+			mocket.Catcher.NewMock().WithQuery(`SELECT * FROM "kafka_requests" WHERE id = $1`).WithReply(reply)
+			mocket.Catcher.NewMock().
+				WithQuery(`UPDATE "kafka_requests" SET`).
+				WithCallback(func(_ string, args []driver.NamedValue) {
+					for _, arg := range args {
+						updateArgs = append(updateArgs, arg.Value)
+					}
+				}).
+				WithRowsNum(int64(tt.updatedRows))
+			mocket.Catcher.NewMock().WithExecException().WithQueryException()
+
+			k := &kafkaService{
+				connectionFactory: db.NewMockConnectionFactory(nil),
+				kafkaConfig:       config.NewKafkaConfig(),
+			}
+			k.kafkaConfig.DeprovisionGracePeriod = time.Hour
+
+			err := tt.action(k)
+			g.Expect(err != nil).To(gomega.Equal(tt.wantErr))
+			if tt.wantUpdatedStatus == "" {
+				g.Expect(updateArgs).To(gomega.BeEmpty())
+				return
+			}
+			g.Expect(updateArgs).To(gomega.ContainElement(tt.wantUpdatedStatus.String()))
+			if tt.wantStatusBeforeKept {
+				g.Expect(updateArgs).To(gomega.ContainElement(tt.status.String()))
+			}
+		})
+	}
+}
+
+func Test_kafkaService_PromoteExpiredDeprovisionPendingKafkas(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	var status, statusUpdatedBefore interface{}
+	mocket.Catcher.Reset()
+	mocket.Catcher.NewMock().
+		WithQuery(`UPDATE "kafka_requests" SET`).
+		WithCallback(func(_ string, args []driver.NamedValue) {
+			// the status and its timestamp are set before the pending status and the deadline are filtered on
+			status = args[len(args)-2].Value
+			statusUpdatedBefore = args[len(args)-1].Value
+		}).
+		WithRowsNum(2)
+	mocket.Catcher.NewMock().WithExecException().WithQueryException()
+
+	k := &kafkaService{
+		connectionFactory: db.NewMockConnectionFactory(nil),
+		kafkaConfig:       config.NewKafkaConfig(),
+	}
+	k.kafkaConfig.DeprovisionGracePeriod = time.Hour
+
+	promoted, err := k.PromoteExpiredDeprovisionPendingKafkas()
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(promoted).To(gomega.Equal(int64(2)))
+	g.Expect(status).To(gomega.Equal(constants2.KafkaRequestStatusDeprovisionPending.String()))
+	g.Expect(statusUpdatedBefore).To(gomega.BeTemporally("~", time.Now().Add(-time.Hour), time.Minute))
+}
+
 func Test_kafkaService_Delete(t *testing.T) {
 	type fields struct {
 		connectionFactory *db.ConnectionFactory
@@ -887,6 +2047,185 @@ func Test_kafkaService_Delete(t *testing.T) {
 	}
 }
 
+func Test_kafkaService_Delete_SkipExternalCleanup(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	var deletedID interface{}
+	mocket.Catcher.Reset().NewMock().WithQuery(`UPDATE "kafka_requests" SET "deleted_at"`).
+		WithCallback(func(_ string, args []driver.NamedValue) {
+			deletedID = args[len(args)-1].Value
+		}).WithRowsNum(1)
+	mocket.Catcher.NewMock().WithExecException().WithQueryException()
+	metrics.Reset()
+
+	// the keycloak mock panics when any of its methods is called and there is no aws client factory for the CNAME
+	// records, so no external call is attempted
+	k := &kafkaService{
+		connectionFactory: db.NewMockConnectionFactory(nil),
+		keycloakService:   &sso.KeycloakServiceMock{},
+		kafkaConfig: &config.KafkaConfig{
+			EnableKafkaCNAMERegistration: true,
+			SkipExternalCleanupOnDelete:  true,
+		},
+		awsConfig: config.NewAWSConfig(),
+	}
+	kafkaRequest := buildKafkaRequest(func(kafkaRequest *dbapi.KafkaRequest) {
+		kafkaRequest.CanaryServiceAccountClientID = "canary-id"
+		kafkaRequest.Routes = api.JSON(`[{"domain": "admin-server-test.kafka.bf2.dev", "router": "router.bf2.dev"}]`)
+	})
+
+	g.Expect(k.Delete(kafkaRequest)).To(gomega.BeNil())
+	g.Expect(deletedID).To(gomega.Equal(testID))
+
+	successCountMetric := metrics.KasFleetManager + "_" + metrics.KafkaOperationsSuccessCount
+	g.Expect(testutil.GatherAndCompare(prometheus.DefaultGatherer, strings.NewReader(fmt.Sprintf(`# HELP %[1]s number of successful kafka operations
+# TYPE %[1]s counter
+%[1]s{operation="delete"} 1
+`, successCountMetric)), successCountMetric)).To(gomega.Succeed())
+}
+
+func Test_registrationLockKeys(t *testing.T) {
+	tests := []struct {
+		name           string
+		first          *dbapi.KafkaRequest
+		second         *dbapi.KafkaRequest
+		wantSharedLock bool
+	}{
+		{
+			name:  "registrations of different organisations in different regions should proceed concurrently",
+			first: buildKafkaRequest(nil),
+			second: buildKafkaRequest(func(kafkaRequest *dbapi.KafkaRequest) {
+				kafkaRequest.Region = "eu-west-1"
+				kafkaRequest.OrganisationId = "another-organisation"
+			}),
+			wantSharedLock: false,
+		},
+		{
+			name:  "registrations of different organisations in the same region but different cloud providers should proceed concurrently",
+			first: buildKafkaRequest(nil),
+			second: buildKafkaRequest(func(kafkaRequest *dbapi.KafkaRequest) {
+				kafkaRequest.CloudProvider = cloudproviders.GCP.String()
+				kafkaRequest.OrganisationId = "another-organisation"
+			}),
+			wantSharedLock: false,
+		},
+		{
+			name: "registrations of users without organisation in different regions should proceed concurrently",
+			first: buildKafkaRequest(func(kafkaRequest *dbapi.KafkaRequest) {
+				kafkaRequest.OrganisationId = ""
+			}),
+			second: buildKafkaRequest(func(kafkaRequest *dbapi.KafkaRequest) {
+				kafkaRequest.Region = "eu-west-1"
+				kafkaRequest.OrganisationId = ""
+				kafkaRequest.Owner = "another-owner"
+			}),
+			wantSharedLock: false,
+		},
+		{
+			name:  "registrations in the same region should be serialized",
+			first: buildKafkaRequest(nil),
+			second: buildKafkaRequest(func(kafkaRequest *dbapi.KafkaRequest) {
+				kafkaRequest.InstanceType = types.DEVELOPER.String()
+				kafkaRequest.OrganisationId = "another-organisation"
+			}),
+			wantSharedLock: true,
+		},
+		{
+			name: "registrations of the same organisation in different regions should be serialized",
+			first: buildKafkaRequest(func(kafkaRequest *dbapi.KafkaRequest) {
+				kafkaRequest.OrganisationId = "organisation"
+			}),
+			second: buildKafkaRequest(func(kafkaRequest *dbapi.KafkaRequest) {
+				kafkaRequest.Region = "eu-west-1"
+				kafkaRequest.OrganisationId = "organisation"
+				kafkaRequest.Owner = "another-owner"
+			}),
+			wantSharedLock: true,
+		},
+		{
+			name: "registrations of the same user without organisation in different regions should be serialized",
+			first: buildKafkaRequest(func(kafkaRequest *dbapi.KafkaRequest) {
+				kafkaRequest.OrganisationId = ""
+			}),
+			second: buildKafkaRequest(func(kafkaRequest *dbapi.KafkaRequest) {
+				kafkaRequest.Region = "eu-west-1"
+				kafkaRequest.OrganisationId = ""
+			}),
+			wantSharedLock: true,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			firstKeys := registrationLockKeys(tt.first)
+			secondKeys := registrationLockKeys(tt.second)
+			g.Expect(firstKeys).To(gomega.HaveLen(2))
+			g.Expect(secondKeys).To(gomega.HaveLen(2))
+
+			sharedLock := false
+			for _, key := range secondKeys {
+				if arrays.Contains(firstKeys, key) {
+					sharedLock = true
+				}
+			}
+			g.Expect(sharedLock).To(gomega.Equal(tt.wantSharedLock))
+		})
+	}
+}
+
+func Test_kafkaService_lockRegistration(t *testing.T) {
+	tests := []struct {
+		name     string
+		setupFn  func()
+		wantErr  bool
+		wantKeys []string
+	}{
+		{
+			name: "should take the tenant lock then the region lock",
+			setupFn: func() {
+				mocket.Catcher.Reset()
+			},
+			wantKeys: []string{"organisation/test-organisation", "region/" + testKafkaRequestProvider + "/" + testKafkaRequestRegion},
+		},
+		{
+			name: "should return an error when a lock cannot be taken",
+			setupFn: func() {
+				mocket.Catcher.Reset()
+				mocket.Catcher.NewMock().WithQuery("pg_advisory_xact_lock").WithExecException()
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			tt.setupFn()
+			var keys []string
+			mocket.Catcher.NewMock().WithQuery("pg_advisory_xact_lock").
+				WithCallback(func(_ string, args []driver.NamedValue) {
+					keys = append(keys, args[1].Value.(string))
+				})
+
+			k := &kafkaService{
+				connectionFactory: db.NewMockConnectionFactory(nil),
+			}
+			unlock, err := k.lockRegistration(buildKafkaRequest(func(kafkaRequest *dbapi.KafkaRequest) {
+				kafkaRequest.OrganisationId = "test-organisation"
+			}))
+			g.Expect(err != nil).To(gomega.Equal(tt.wantErr))
+			if tt.wantErr {
+				return
+			}
+			g.Expect(keys).To(gomega.Equal(tt.wantKeys))
+			unlock()
+		})
+	}
+}
+
 func Test_kafkaService_RegisterKafkaJob(t *testing.T) {
 
 	type fields struct {
@@ -937,6 +2276,10 @@ func Test_kafkaService_RegisterKafkaJob(t *testing.T) {
 		t.Fatal("failed to convert available strimzi versions to json")
 	}
 
+	supportsMultiAZ := false
+	singleAZProviderConfig := buildProviderConfiguration(testKafkaRequestRegion, MaxClusterCapacity, MaxClusterCapacity, false)
+	singleAZProviderConfig.ProvidersConfig.SupportedProviders[0].Regions[0].SupportsMultiAZ = &supportsMultiAZ
+
 	mockCluster := &api.Cluster{
 		Meta: api.Meta{
 			ID:        testID,
@@ -995,6 +2338,7 @@ func Test_kafkaService_RegisterKafkaJob(t *testing.T) {
 						kafkaRequest.InstanceType = types.STANDARD.String()
 					})))
 				mocket.Catcher.NewMock().WithQuery(`INSERT INTO "kafka_requests"`)
+				mocket.Catcher.NewMock().WithQuery("pg_advisory_xact_lock")
 				mocket.Catcher.NewMock().WithQueryException().WithExecException()
 			},
 			error: errorCheck{
@@ -1046,6 +2390,7 @@ func Test_kafkaService_RegisterKafkaJob(t *testing.T) {
 					})))
 				mocket.Catcher.NewMock().WithQuery(`INSERT INTO "kafka_requests"`)
 				mocket.Catcher.NewMock().WithQuery(``)
+				mocket.Catcher.NewMock().WithQuery("pg_advisory_xact_lock")
 				mocket.Catcher.NewMock().WithQueryException().WithExecException()
 
 			},
@@ -1090,6 +2435,7 @@ func Test_kafkaService_RegisterKafkaJob(t *testing.T) {
 						kafkaRequest.InstanceType = types.STANDARD.String()
 					})))
 				mocket.Catcher.NewMock().WithQuery(`INSERT INTO "kafka_requests"`)
+				mocket.Catcher.NewMock().WithQuery("pg_advisory_xact_lock")
 				mocket.Catcher.NewMock().WithQueryException().WithExecException()
 			},
 			error: errorCheck{
@@ -1133,6 +2479,7 @@ func Test_kafkaService_RegisterKafkaJob(t *testing.T) {
 						kafkaRequest.InstanceType = types.STANDARD.String()
 					})))
 				mocket.Catcher.NewMock().WithQuery(`INSERT INTO "kafka_requests"`)
+				mocket.Catcher.NewMock().WithQuery("pg_advisory_xact_lock")
 				mocket.Catcher.NewMock().WithQueryException().WithExecException()
 			},
 			error: errorCheck{
@@ -1178,12 +2525,35 @@ func Test_kafkaService_RegisterKafkaJob(t *testing.T) {
 			},
 		},
 		{
-			name: "should register kafka job successful when developer instances count for the user is less than max-allowed-developer-instances",
+			name: "unsuccessful registering standard kafka job in a region that does not support multi AZ",
 			fields: fields{
 				connectionFactory:      db.NewMockConnectionFactory(nil),
 				clusterService:         nil,
 				dataplaneClusterConfig: buildDataplaneClusterConfig(defaultDataplaneClusterConfig),
-				providerConfig:         buildProviderConfiguration(testKafkaRequestRegion, MaxClusterCapacity, MaxClusterCapacity, false),
+				providerConfig:         singleAZProviderConfig,
+				kafkaConfig:            defaultKafkaConf,
+			},
+			args: args{
+				kafkaRequest: buildKafkaRequest(func(kafkaRequest *dbapi.KafkaRequest) {
+					// we need to empty to ID otherwise an UPDATE will be performed instead of an insert
+					kafkaRequest.ID = ""
+					kafkaRequest.InstanceType = types.STANDARD.String()
+					kafkaRequest.SizeId = "x1"
+				}),
+			},
+			error: errorCheck{
+				wantErr:  true,
+				code:     errors.ErrorInstanceTypeNotSupported,
+				httpCode: http.StatusBadRequest,
+			},
+		},
+		{
+			name: "should register developer kafka job successful in a region that does not support multi AZ",
+			fields: fields{
+				connectionFactory:      db.NewMockConnectionFactory(nil),
+				clusterService:         nil,
+				dataplaneClusterConfig: buildDataplaneClusterConfig(defaultDataplaneClusterConfig),
+				providerConfig:         singleAZProviderConfig,
 				kafkaConfig: config.KafkaConfig{
 					Quota: &config.KafkaQuotaConfig{
 						Type:                         api.QuotaManagementListQuotaType.String(),
@@ -1234,6 +2604,7 @@ func Test_kafkaService_RegisterKafkaJob(t *testing.T) {
 						kafkaRequest.OrganisationId = "org-id"
 					})))
 				mocket.Catcher.NewMock().WithQuery(`INSERT INTO "kafka_requests"`)
+				mocket.Catcher.NewMock().WithQuery("pg_advisory_xact_lock")
 				mocket.Catcher.NewMock().WithQueryException().WithExecException()
 			},
 			error: errorCheck{
@@ -1241,7 +2612,7 @@ func Test_kafkaService_RegisterKafkaJob(t *testing.T) {
 			},
 		},
 		{
-			name: "should register kafka job unsuccessful when developer kafka instances count for the user has reached the max-allowed-developer-instances limit",
+			name: "should register kafka job successful when developer instances count for the user is less than max-allowed-developer-instances",
 			fields: fields{
 				connectionFactory:      db.NewMockConnectionFactory(nil),
 				clusterService:         nil,
@@ -1251,7 +2622,71 @@ func Test_kafkaService_RegisterKafkaJob(t *testing.T) {
 					Quota: &config.KafkaQuotaConfig{
 						Type:                         api.QuotaManagementListQuotaType.String(),
 						AllowDeveloperInstance:       true,
-						MaxAllowedDeveloperInstances: 2,
+						MaxAllowedDeveloperInstances: 1,
+					},
+					SupportedInstanceTypes: &kafkaSupportedInstanceTypesConfig,
+				},
+				clusterPlmtStrategy: &ClusterPlacementStrategyMock{
+					FindClusterFunc: func(kafka *dbapi.KafkaRequest) (*api.Cluster, error) {
+						return mockCluster, nil
+					},
+				},
+				quotaService: &QuotaServiceMock{
+					CheckIfQuotaIsDefinedForInstanceTypeFunc: func(owner string, organisationID string, instanceType types.KafkaInstanceType) (bool, *errors.ServiceError) {
+						return true, nil
+					},
+					ReserveQuotaFunc: func(kafka *dbapi.KafkaRequest, instanceType types.KafkaInstanceType) (string, *errors.ServiceError) {
+						return "subscription-id", nil
+					},
+				},
+			},
+			args: args{
+				kafkaRequest: buildKafkaRequest(func(kafkaRequest *dbapi.KafkaRequest) {
+					// we need to empty to ID otherwise an UPDATE will be performed instead of an insert
+					kafkaRequest.ID = ""
+					kafkaRequest.InstanceType = types.DEVELOPER.String()
+					kafkaRequest.SizeId = "x1"
+					kafkaRequest.Owner = testUser
+					kafkaRequest.OrganisationId = "org-id"
+				}),
+			},
+			setupFn: func() {
+				totalCountResponse := []map[string]interface{}{{"count": 0}}
+
+				mocket.Catcher.Reset()
+				mocket.Catcher.NewMock().WithQuery(`SELECT count(1) FROM "kafka_requests" WHERE instance_type = $1 AND owner = $2 AND (organisation_id = $3) AND "kafka_requests"."deleted_at" IS NULL`).
+					WithArgs(types.DEVELOPER.String(), testUser, "org-id").
+					WithReply(totalCountResponse)
+				mocket.Catcher.NewMock().
+					WithQuery(`SELECT * FROM "kafka_requests" WHERE region = $1 AND cloud_provider = $2 AND instance_type = $3 AND "kafka_requests"."deleted_at" IS NULL`).
+					WithArgs("us-east-1", "aws", "developer").
+					WithReply(converters.ConvertKafkaRequest(buildKafkaRequest(func(kafkaRequest *dbapi.KafkaRequest) {
+						kafkaRequest.ID = ""
+						kafkaRequest.InstanceType = types.DEVELOPER.String()
+						kafkaRequest.SizeId = "x1"
+						kafkaRequest.Owner = testUser
+						kafkaRequest.OrganisationId = "org-id"
+					})))
+				mocket.Catcher.NewMock().WithQuery(`INSERT INTO "kafka_requests"`)
+				mocket.Catcher.NewMock().WithQuery("pg_advisory_xact_lock")
+				mocket.Catcher.NewMock().WithQueryException().WithExecException()
+			},
+			error: errorCheck{
+				wantErr: false,
+			},
+		},
+		{
+			name: "should register kafka job unsuccessful when developer kafka instances count for the user has reached the max-allowed-developer-instances limit",
+			fields: fields{
+				connectionFactory:      db.NewMockConnectionFactory(nil),
+				clusterService:         nil,
+				dataplaneClusterConfig: buildDataplaneClusterConfig(defaultDataplaneClusterConfig),
+				providerConfig:         buildProviderConfiguration(testKafkaRequestRegion, MaxClusterCapacity, MaxClusterCapacity, false),
+				kafkaConfig: config.KafkaConfig{
+					Quota: &config.KafkaQuotaConfig{
+						Type:                         api.QuotaManagementListQuotaType.String(),
+						AllowDeveloperInstance:       true,
+						MaxAllowedDeveloperInstances: 2,
 					},
 					SupportedInstanceTypes: &kafkaSupportedInstanceTypesConfig,
 				},
@@ -1297,6 +2732,7 @@ func Test_kafkaService_RegisterKafkaJob(t *testing.T) {
 				mocket.Catcher.NewMock().WithQuery(`SELECT count(1) FROM "kafka_requests" WHERE instance_type = $1 AND owner = $2 AND (organisation_id = $3) AND "kafka_requests"."deleted_at" IS NULL`).
 					WithArgs(types.DEVELOPER.String(), testUser, "org-id").
 					WithReply(totalCountResponse)
+				mocket.Catcher.NewMock().WithQuery("pg_advisory_xact_lock")
 				mocket.Catcher.NewMock().WithQueryException().WithExecException()
 			},
 			error: errorCheck{
@@ -1388,6 +2824,7 @@ func Test_kafkaService_RegisterKafkaJob(t *testing.T) {
 						kafkaRequest.InstanceType = types.STANDARD.String()
 					})))
 				mocket.Catcher.NewMock().WithQuery(`INSERT INTO "kafka_requests"`)
+				mocket.Catcher.NewMock().WithQuery("pg_advisory_xact_lock")
 				mocket.Catcher.NewMock().WithQueryException().WithExecException()
 			},
 			error: errorCheck{
@@ -1433,6 +2870,7 @@ func Test_kafkaService_RegisterKafkaJob(t *testing.T) {
 						kafkaRequest.InstanceType = types.STANDARD.String()
 					})))
 				mocket.Catcher.NewMock().WithQuery(`INSERT INTO "kafka_requests"`)
+				mocket.Catcher.NewMock().WithQuery("pg_advisory_xact_lock")
 				mocket.Catcher.NewMock().WithQueryException().WithExecException()
 			},
 			error: errorCheck{
@@ -1476,6 +2914,7 @@ func Test_kafkaService_RegisterKafkaJob(t *testing.T) {
 						kafkaRequest.InstanceType = types.STANDARD.String()
 					})))
 				mocket.Catcher.NewMock().WithQuery(`INSERT INTO "kafka_requests"`)
+				mocket.Catcher.NewMock().WithQuery("pg_advisory_xact_lock")
 				mocket.Catcher.NewMock().WithQueryException().WithExecException()
 			},
 			error: errorCheck{
@@ -1511,6 +2950,7 @@ func Test_kafkaService_RegisterKafkaJob(t *testing.T) {
 			setupFn: func() {
 				mocket.Catcher.Reset().NewMock().WithQuery(`SELECT * FROM "kafka_requests" WHERE region = $1 AND cloud_provider = $2 AND "kafka_requests"."deleted_at" IS NULL`).WithReply([]map[string]interface{}{})
 				mocket.Catcher.NewMock().WithQuery("INSERT").WithExecException()
+				mocket.Catcher.NewMock().WithQuery("pg_advisory_xact_lock")
 				mocket.Catcher.NewMock().WithExecException().WithQueryException()
 			},
 			error: errorCheck{
@@ -1561,6 +3001,97 @@ func Test_kafkaService_RegisterKafkaJob(t *testing.T) {
 	}
 }
 
+func Test_kafkaService_RegisterKafkaJob_DefaultReauthentication(t *testing.T) {
+	kafkaConfig := defaultKafkaConf
+	kafkaConfig.ReauthenticationDisabledInstanceTypes = []string{types.DEVELOPER.String()}
+	mockCluster := &api.Cluster{
+		Region:        testKafkaRequestRegion,
+		ClusterID:     testClusterID,
+		CloudProvider: testKafkaRequestProvider,
+		Status:        api.ClusterReady,
+	}
+
+	tests := []struct {
+		name                        string
+		instanceType                types.KafkaInstanceType
+		reauthenticationEnabled     bool
+		reauthenticationUnspecified bool
+		want                        bool
+	}{
+		{
+			name:                        "should enable the reauthentication of a standard kafka when unspecified",
+			instanceType:                types.STANDARD,
+			reauthenticationUnspecified: true,
+			want:                        true,
+		},
+		{
+			name:                        "should disable the reauthentication of a developer kafka when unspecified",
+			instanceType:                types.DEVELOPER,
+			reauthenticationEnabled:     true,
+			reauthenticationUnspecified: true,
+			want:                        false,
+		},
+		{
+			name:                    "should preserve the reauthentication of a developer kafka when enabled in the request",
+			instanceType:            types.DEVELOPER,
+			reauthenticationEnabled: true,
+			want:                    true,
+		},
+		{
+			name:                    "should preserve the reauthentication of a standard kafka when disabled in the request",
+			instanceType:            types.STANDARD,
+			reauthenticationEnabled: false,
+			want:                    false,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			mocket.Catcher.Reset().NewMock().
+				WithQuery(`SELECT * FROM "kafka_requests" WHERE region = $1 AND cloud_provider = $2 AND instance_type = $3`).
+				WithReply([]map[string]interface{}{})
+			mocket.Catcher.NewMock().WithQuery(`INSERT INTO "kafka_requests"`)
+			mocket.Catcher.NewMock().WithQuery("pg_advisory_xact_lock")
+			mocket.Catcher.NewMock().WithQueryException().WithExecException()
+
+			k := &kafkaService{
+				connectionFactory:      db.NewMockConnectionFactory(nil),
+				kafkaConfig:            &kafkaConfig,
+				awsConfig:              config.NewAWSConfig(),
+				providerConfig:         buildProviderConfiguration(testKafkaRequestRegion, MaxClusterCapacity, MaxClusterCapacity, false),
+				dataplaneClusterConfig: buildDataplaneClusterConfig(nil),
+				clusterPlacementStrategy: &ClusterPlacementStrategyMock{
+					FindClusterFunc: func(kafka *dbapi.KafkaRequest) (*api.Cluster, error) {
+						return mockCluster, nil
+					},
+				},
+				quotaServiceFactory: &QuotaServiceFactoryMock{
+					GetQuotaServiceFunc: func(quotaType api.QuotaType) (QuotaService, *errors.ServiceError) {
+						return &QuotaServiceMock{
+							ReserveQuotaFunc: func(kafka *dbapi.KafkaRequest, instanceType types.KafkaInstanceType) (string, *errors.ServiceError) {
+								return "subscription-id", nil
+							},
+						}, nil
+					},
+				},
+			}
+			kafkaRequest := buildKafkaRequest(func(kafkaRequest *dbapi.KafkaRequest) {
+				// we need to empty to ID otherwise an UPDATE will be performed instead of an insert
+				kafkaRequest.ID = ""
+				kafkaRequest.InstanceType = tt.instanceType.String()
+				kafkaRequest.ReauthenticationEnabled = tt.reauthenticationEnabled
+				kafkaRequest.ReauthenticationUnspecified = tt.reauthenticationUnspecified
+			})
+
+			g.Expect(k.RegisterKafkaJob(kafkaRequest)).To(gomega.BeNil())
+			g.Expect(kafkaRequest.ReauthenticationEnabled).To(gomega.Equal(tt.want))
+		})
+	}
+}
+
 func Test_AssignInstanceType(t *testing.T) {
 	type fields struct {
 		quotaService QuotaService
@@ -1642,20 +3173,7 @@ func Test_AssignInstanceType(t *testing.T) {
 	}
 }
 
-func Test_kafkaService_List(t *testing.T) {
-	type fields struct {
-		connectionFactory *db.ConnectionFactory
-	}
-	type args struct {
-		ctx      context.Context
-		listArgs *services.ListArguments
-	}
-
-	type want struct {
-		kafkaList  dbapi.KafkaList
-		pagingMeta *api.PagingMeta
-	}
-
+func Test_kafkaService_GetAllowedInstanceTypes(t *testing.T) {
 	authHelper, err := auth.NewAuthHelper(JwtKeyFile, JwtCAFile, "")
 	if err != nil {
 		t.Fatalf("failed to create auth helper: %s", err.Error())
@@ -1664,522 +3182,713 @@ func Test_kafkaService_List(t *testing.T) {
 	if err != nil {
 		t.Fatal("failed to build a new account")
 	}
-
 	jwt, err := authHelper.CreateJWTWithClaims(account, nil)
 	if err != nil {
 		t.Fatalf("failed to create jwt: %s", err.Error())
 	}
-	ctx := context.TODO()
-	authenticatedCtx := auth.SetTokenInContext(ctx, jwt)
+	authenticatedCtx := auth.SetTokenInContext(context.TODO(), jwt)
 
-	adminCtx := context.TODO()
-	adminCtx = auth.SetIsAdminContext(adminCtx, true)
-	authenticatedAdminCtx := auth.SetTokenInContext(adminCtx, jwt)
+	// the quota list grants the standard instance type to the users it registers and the developer one to the others
+	quotaFor := func(hasStandardQuota bool) *QuotaServiceMock {
+		return &QuotaServiceMock{
+			CheckIfQuotaIsDefinedForInstanceTypeFunc: func(owner string, organisationID string, instanceType types.KafkaInstanceType) (bool, *errors.ServiceError) {
+				return hasStandardQuota == (instanceType == types.STANDARD), nil
+			},
+		}
+	}
+	quotaConfig := func(allowDeveloperInstance bool) *config.KafkaQuotaConfig {
+		quota := config.NewKafkaQuotaConfig()
+		quota.AllowDeveloperInstance = allowDeveloperInstance
+		return quota
+	}
 
 	tests := []struct {
-		name    string
-		fields  fields
-		args    args
-		want    want
-		wantErr bool
-		setupFn func(dbapi.KafkaList)
+		name                    string
+		ctx                     context.Context
+		quotaService            QuotaService
+		quota                   *config.KafkaQuotaConfig
+		ownedDeveloperInstances int
+		want                    []types.KafkaInstanceType
+		wantErr                 bool
 	}{
 		{
-			name: "success: list with default values for admin context",
-			fields: fields{
-				connectionFactory: db.NewMockConnectionFactory(nil),
-			},
-			args: args{
-				ctx: authenticatedAdminCtx,
-				listArgs: &services.ListArguments{
-					Page: 1,
-					Size: 100,
+			name:         "should return the standard instance type for a user having quota",
+			ctx:          authenticatedCtx,
+			quotaService: quotaFor(true),
+			quota:        quotaConfig(true),
+			want:         []types.KafkaInstanceType{types.STANDARD},
+		},
+		{
+			name:         "should return the developer instance type for a user without quota",
+			ctx:          authenticatedCtx,
+			quotaService: quotaFor(false),
+			quota:        quotaConfig(true),
+			want:         []types.KafkaInstanceType{types.DEVELOPER},
+		},
+		{
+			name:                    "should not return the developer instance type when the user already owns the maximum number of developer instances",
+			ctx:                     authenticatedCtx,
+			quotaService:            quotaFor(false),
+			quota:                   quotaConfig(true),
+			ownedDeveloperInstances: 1,
+			want:                    []types.KafkaInstanceType{},
+		},
+		{
+			name:         "should not return the developer instance type when developer instances are not allowed",
+			ctx:          authenticatedCtx,
+			quotaService: quotaFor(false),
+			quota:        quotaConfig(false),
+			want:         []types.KafkaInstanceType{},
+		},
+		{
+			name: "should return an error when the quota cannot be checked",
+			ctx:  authenticatedCtx,
+			quotaService: &QuotaServiceMock{
+				CheckIfQuotaIsDefinedForInstanceTypeFunc: func(owner string, organisationID string, instanceType types.KafkaInstanceType) (bool, *errors.ServiceError) {
+					return false, errors.GeneralError("failed to check quota")
 				},
 			},
-			want: want{
-				kafkaList: dbapi.KafkaList{
-					&dbapi.KafkaRequest{
-						Region:        testKafkaRequestRegion,
-						ClusterID:     testClusterID,
-						CloudProvider: testKafkaRequestProvider,
-						MultiAZ:       false,
-						Name:          "dummy-cluster-name",
-						Status:        "accepted",
-						Owner:         testUser,
-						Meta: api.Meta{
-							CreatedAt: time.Now(),
-							UpdatedAt: time.Now(),
-							DeletedAt: gorm.DeletedAt{Valid: true},
-						},
-					},
-					&dbapi.KafkaRequest{
-						Region:        testKafkaRequestRegion,
-						ClusterID:     testClusterID,
-						CloudProvider: testKafkaRequestProvider,
-						MultiAZ:       false,
-						Name:          "dummy-cluster-name2",
-						Status:        "accepted",
-						Owner:         testUser,
-						Meta: api.Meta{
-							CreatedAt: time.Now(),
-							UpdatedAt: time.Now(),
-							DeletedAt: gorm.DeletedAt{Valid: true},
-						},
-					},
+			quota:   quotaConfig(true),
+			wantErr: true,
+		},
+		{
+			name:         "should return an error when the user is not authenticated",
+			ctx:          context.TODO(),
+			quotaService: quotaFor(true),
+			quota:        quotaConfig(true),
+			wantErr:      true,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			mocket.Catcher.Reset()
+			mocket.Catcher.NewMock().
+				WithQuery(`FROM "kafka_requests" WHERE instance_type = $1 AND owner = $2`).
+				WithReply([]map[string]interface{}{{"count": tt.ownedDeveloperInstances}})
+			mocket.Catcher.NewMock().WithExecException().WithQueryException()
+
+			k := &kafkaService{
+				connectionFactory: db.NewMockConnectionFactory(nil),
+				kafkaConfig: &config.KafkaConfig{
+					Quota:                  tt.quota,
+					SupportedInstanceTypes: &kafkaSupportedInstanceTypesConfig,
 				},
-				pagingMeta: &api.PagingMeta{
-					Page:  1,
-					Size:  2,
-					Total: 2,
+				quotaServiceFactory: &QuotaServiceFactoryMock{
+					GetQuotaServiceFunc: func(quotaType api.QuotaType) (QuotaService, *errors.ServiceError) {
+						return tt.quotaService, nil
+					},
 				},
-			},
-			wantErr: false,
-			setupFn: func(kafkaList dbapi.KafkaList) {
-				mocket.Catcher.Reset()
+			}
+			got, err := k.GetAllowedInstanceTypes(tt.ctx)
+			if tt.wantErr {
+				g.Expect(err).ToNot(gomega.BeNil())
+				return
+			}
+			g.Expect(err).To(gomega.BeNil())
+			g.Expect(got).To(gomega.Equal(tt.want))
+		})
+	}
+}
 
-				// total count query
-				totalCountResponse := []map[string]interface{}{{"count": len(kafkaList)}}
-				mocket.Catcher.NewMock().WithQuery(`SELECT count(1) FROM "kafka_requests"`).WithReply(totalCountResponse)
+func Test_kafkaService_ForceDelete(t *testing.T) {
+	type fields struct {
+		connectionFactory *db.ConnectionFactory
+		keycloakService   sso.KeycloakService
+		kafkaConfig       *config.KafkaConfig
+		awsClient         aws.AWSClient
+		quotaService      QuotaService
+	}
+	type args struct {
+		ctx context.Context
+		id  string
+	}
 
-				// actual query to return list of kafka requests based on filters
-				query := fmt.Sprintf(`SELECT * FROM "%s"`, kafkaRequestTableName)
-				response := converters.ConvertKafkaRequestList(kafkaList)
-				mocket.Catcher.NewMock().WithQuery(query).WithReply(response)
-				mocket.Catcher.NewMock().WithExecException().WithQueryException()
+	authHelper, err := auth.NewAuthHelper(JwtKeyFile, JwtCAFile, "")
+	if err != nil {
+		t.Fatalf("failed to create auth helper: %s", err.Error())
+	}
+	account, err := authHelper.NewAccount("admin-user", "", "", "")
+	if err != nil {
+		t.Fatal("failed to build a new account")
+	}
+	jwt, err := authHelper.CreateJWTWithClaims(account, nil)
+	if err != nil {
+		t.Fatalf("failed to create jwt: %s", err.Error())
+	}
+	adminCtx := auth.SetIsAdminContext(auth.SetTokenInContext(context.TODO(), jwt), true)
+
+	kafkaWithRoutes := converters.ConvertKafkaRequest(buildKafkaRequest(func(kafkaRequest *dbapi.KafkaRequest) {
+		kafkaRequest.CanaryServiceAccountClientID = "canary-id"
+	}))
+	kafkaWithRoutes[0]["routes"] = []byte(`[{"domain": "test.example.com", "router": "test.rhcloud.com"}]`)
+
+	failingDependencies := fields{
+		connectionFactory: db.NewMockConnectionFactory(nil),
+		keycloakService: &sso.KeycloakServiceMock{
+			GetConfigFunc: func() *keycloak.KeycloakConfig {
+				return &keycloak.KeycloakConfig{
+					EnableAuthenticationOnKafka: true,
+				}
+			},
+			DeleteServiceAccountInternalFunc: func(clientId string) *errors.ServiceError {
+				return errors.FailedToDeleteServiceAccount("failed to delete service account")
 			},
 		},
-		{
-			name: "success: list with default values",
-			fields: fields{
-				connectionFactory: db.NewMockConnectionFactory(nil),
-			},
-			args: args{
-				ctx: authenticatedCtx,
-				listArgs: &services.ListArguments{
-					Page: 1,
-					Size: 100,
-				},
+		kafkaConfig: &config.KafkaConfig{
+			EnableKafkaCNAMERegistration: true,
+			KafkaDomainName:              "rhcloud.com",
+		},
+		awsClient: &aws.AWSClientMock{
+			ChangeResourceRecordSetsFunc: func(dnsName string, recordChangeBatch *route53.ChangeBatch) (*route53.ChangeResourceRecordSetsOutput, error) {
+				return nil, goerrors.Errorf("failed to change resource record sets")
 			},
-			want: want{
-				kafkaList: dbapi.KafkaList{
-					&dbapi.KafkaRequest{
-						Region:        testKafkaRequestRegion,
-						ClusterID:     testClusterID,
-						CloudProvider: testKafkaRequestProvider,
-						MultiAZ:       false,
-						Name:          "dummy-cluster-name",
-						Status:        "accepted",
-						Owner:         testUser,
-						Meta: api.Meta{
-							CreatedAt: time.Now(),
-							UpdatedAt: time.Now(),
-							DeletedAt: gorm.DeletedAt{Valid: true},
-						},
-					},
-					&dbapi.KafkaRequest{
-						Region:        testKafkaRequestRegion,
-						ClusterID:     testClusterID,
-						CloudProvider: testKafkaRequestProvider,
-						MultiAZ:       false,
-						Name:          "dummy-cluster-name2",
-						Status:        "accepted",
-						Owner:         testUser,
-						Meta: api.Meta{
-							CreatedAt: time.Now(),
-							UpdatedAt: time.Now(),
-							DeletedAt: gorm.DeletedAt{Valid: true},
-						},
-					},
-				},
-				pagingMeta: &api.PagingMeta{
-					Page:  1,
-					Size:  2,
-					Total: 2,
-				},
+		},
+		quotaService: &QuotaServiceMock{
+			DeleteQuotaFunc: func(subscriptionId string) *errors.ServiceError {
+				return errors.GeneralError("failed to delete quota")
 			},
-			wantErr: false,
-			setupFn: func(kafkaList dbapi.KafkaList) {
-				mocket.Catcher.Reset()
-
-				// total count query
-				totalCountResponse := []map[string]interface{}{{"count": len(kafkaList)}}
-				mocket.Catcher.NewMock().WithQuery(`SELECT count(1) FROM "kafka_requests"`).WithReply(totalCountResponse)
+		},
+	}
 
-				// actual query to return list of kafka requests based on filters
-				query := fmt.Sprintf(`SELECT * FROM "%s"`, kafkaRequestTableName)
-				response := converters.ConvertKafkaRequestList(kafkaList)
-				mocket.Catcher.NewMock().WithQuery(query).WithReply(response)
+	var deletionArgs []interface{}
+	tests := []struct {
+		name             string
+		fields           fields
+		args             args
+		wantErr          bool
+		wantDeletionArgs []interface{}
+		setupFn          func()
+	}{
+		{
+			name:   "should return an error if the user is not authenticated",
+			fields: failingDependencies,
+			args: args{
+				ctx: context.TODO(),
+				id:  testID,
+			},
+			wantErr: true,
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().
+					WithQuery(`SELECT * FROM "kafka_requests" WHERE id = $1`).
+					WithArgs(testID).
+					WithReply(kafkaWithRoutes)
+				mocket.Catcher.NewMock().WithQuery(`INSERT INTO "kafka_force_deletions"`)
+				mocket.Catcher.NewMock().WithQuery(`DELETE FROM "kafka_requests"`)
 				mocket.Catcher.NewMock().WithExecException().WithQueryException()
 			},
 		},
 		{
-			name: "success: list with specified size",
-			fields: fields{
-				connectionFactory: db.NewMockConnectionFactory(nil),
-			},
+			name:   "should return an error if the kafka request cannot be found",
+			fields: failingDependencies,
 			args: args{
-				ctx: authenticatedCtx,
-				listArgs: &services.ListArguments{
-					Page: 1,
-					Size: 1,
-				},
-			},
-			want: want{
-				kafkaList: dbapi.KafkaList{
-					&dbapi.KafkaRequest{
-						Region:        testKafkaRequestRegion,
-						ClusterID:     testClusterID,
-						CloudProvider: testKafkaRequestProvider,
-						MultiAZ:       false,
-						Name:          "dummy-cluster-name",
-						Status:        "accepted",
-						Owner:         testUser,
-						Meta: api.Meta{
-							CreatedAt: time.Now(),
-							UpdatedAt: time.Now(),
-							DeletedAt: gorm.DeletedAt{Valid: true},
-						},
-					},
-				},
-				pagingMeta: &api.PagingMeta{
-					Page:  1,
-					Size:  1,
-					Total: 5,
-				},
+				ctx: adminCtx,
+				id:  testID,
 			},
-			wantErr: false,
-			setupFn: func(kafkaList dbapi.KafkaList) {
-				mocket.Catcher.Reset()
-
-				// total count query
-				totalCountResponse := []map[string]interface{}{{"count": "5"}}
-				mocket.Catcher.NewMock().WithQuery(`SELECT count(1) FROM "kafka_requests"`).WithReply(totalCountResponse)
-
-				// actual query to return list of kafka requests based on filters
-				query := fmt.Sprintf(`SELECT * FROM "%s"`, kafkaRequestTableName)
-				response := converters.ConvertKafkaRequestList(kafkaList)
-
-				mocket.Catcher.NewMock().WithQuery(query).WithReply(response)
-				mocket.Catcher.NewMock().WithExecException().WithQueryException()
+			wantErr: true,
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().WithExecException().WithQueryException()
 			},
 		},
 		{
-			name: "success: return empty list if no kafka requests available for user",
-			fields: fields{
-				connectionFactory: db.NewMockConnectionFactory(nil),
-			},
+			name:   "should hard delete the kafka request and record its deletion even when the cleanup of its dependencies fails",
+			fields: failingDependencies,
 			args: args{
-				ctx: authenticatedCtx,
-				listArgs: &services.ListArguments{
-					Page: 1,
-					Size: 100,
-				},
-			},
-			want: want{
-				kafkaList: dbapi.KafkaList{},
-				pagingMeta: &api.PagingMeta{
-					Page:  1,
-					Size:  0,
-					Total: 0,
-				},
+				ctx: adminCtx,
+				id:  testID,
 			},
-			wantErr: false,
-			setupFn: func(kafkaList dbapi.KafkaList) {
-				mocket.Catcher.Reset()
-
-				// total count query
-				totalCountResponse := []map[string]interface{}{{"count": len(kafkaList)}}
-				mocket.Catcher.NewMock().WithQuery(`SELECT count(1) FROM "kafka_requests"`).WithReply(totalCountResponse)
-
-				// actual query to return list of kafka requests based on filters
-				query := fmt.Sprintf(`SELECT * FROM "%s"`, kafkaRequestTableName)
-				response := converters.ConvertKafkaRequestList(kafkaList)
-
-				mocket.Catcher.NewMock().WithQuery(query).WithReply(response)
+			wantErr:          false,
+			wantDeletionArgs: []interface{}{testID, "admin-user"},
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().
+					WithQuery(`SELECT * FROM "kafka_requests" WHERE id = $1`).
+					WithArgs(testID).
+					WithReply(kafkaWithRoutes)
+				mocket.Catcher.NewMock().
+					WithQuery(`INSERT INTO "kafka_force_deletions"`).
+					WithCallback(func(_ string, args []driver.NamedValue) {
+						for _, arg := range args {
+							deletionArgs = append(deletionArgs, arg.Value)
+						}
+					})
+				mocket.Catcher.NewMock().WithQuery(`DELETE FROM "kafka_requests"`)
 				mocket.Catcher.NewMock().WithExecException().WithQueryException()
 			},
 		},
 		{
-			name: "fail: user credentials not available in context",
-			fields: fields{
-				connectionFactory: db.NewMockConnectionFactory(nil),
-			},
+			name:   "should return an error if the deletion cannot be recorded",
+			fields: failingDependencies,
 			args: args{
-				ctx: context.TODO(),
-				listArgs: &services.ListArguments{
-					Page: 1,
-					Size: 100,
-				},
-			},
-			want: want{
-				kafkaList:  nil,
-				pagingMeta: nil,
+				ctx: adminCtx,
+				id:  testID,
 			},
 			wantErr: true,
-			setupFn: func(kafkaList dbapi.KafkaList) {
-				mocket.Catcher.Reset()
-
-				totalCountResponse := []map[string]interface{}{{"count": len(kafkaList)}}
-				mocket.Catcher.NewMock().WithQuery("SELECT count(1) FROM \"kafka_requests\"").WithReply(totalCountResponse)
-
-				query := fmt.Sprintf(`SELECT * FROM "%s"`, kafkaRequestTableName)
-				response := converters.ConvertKafkaRequestList(kafkaList)
-
-				mocket.Catcher.NewMock().WithQuery(query).WithReply(response)
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().
+					WithQuery(`SELECT * FROM "kafka_requests" WHERE id = $1`).
+					WithArgs(testID).
+					WithReply(kafkaWithRoutes)
+				mocket.Catcher.NewMock().WithQuery(`DELETE FROM "kafka_requests"`)
 				mocket.Catcher.NewMock().WithExecException().WithQueryException()
 			},
 		},
 		{
-			name: "fail: database returns an error",
-			fields: fields{
-				connectionFactory: db.NewMockConnectionFactory(nil),
-			},
+			name:   "should return an error if the kafka request cannot be deleted from the database",
+			fields: failingDependencies,
 			args: args{
-				ctx: authenticatedCtx,
-				listArgs: &services.ListArguments{
-					Page: 1,
-					Size: 100,
-				},
-			},
-			want: want{
-				kafkaList: dbapi.KafkaList{},
-				pagingMeta: &api.PagingMeta{
-					Page:  1,
-					Size:  0,
-					Total: 0,
-				},
+				ctx: adminCtx,
+				id:  testID,
 			},
 			wantErr: true,
-			setupFn: func(kafkaList dbapi.KafkaList) {
-				mocket.Catcher.Reset().NewMock().WithQuery("SELECT").WithQueryException()
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().
+					WithQuery(`SELECT * FROM "kafka_requests" WHERE id = $1`).
+					WithArgs(testID).
+					WithReply(kafkaWithRoutes)
+				mocket.Catcher.NewMock().WithQuery(`INSERT INTO "kafka_force_deletions"`)
+				mocket.Catcher.NewMock().WithExecException().WithQueryException()
 			},
 		},
 	}
 
 	for _, testcase := range tests {
 		tt := testcase
-
 		t.Run(tt.name, func(t *testing.T) {
 			g := gomega.NewWithT(t)
-			tt.setupFn(tt.want.kafkaList)
+			deletionArgs = nil
+			tt.setupFn()
 			k := &kafkaService{
 				connectionFactory: tt.fields.connectionFactory,
-				kafkaConfig:       config.NewKafkaConfig(),
+				keycloakService:   tt.fields.keycloakService,
+				kafkaConfig:       tt.fields.kafkaConfig,
+				awsClientFactory:  aws.NewMockClientFactory(tt.fields.awsClient),
 				awsConfig:         config.NewAWSConfig(),
+				quotaServiceFactory: &QuotaServiceFactoryMock{
+					GetQuotaServiceFunc: func(quotaType api.QuotaType) (QuotaService, *errors.ServiceError) {
+						return tt.fields.quotaService, nil
+					},
+				},
 			}
-
-			result, pagingMeta, err := k.List(tt.args.ctx, tt.args.listArgs)
-
-			// check errors
-			if (err != nil) != tt.wantErr {
-				t.Errorf("kafkaService.List() error = %v, wantErr %v", err, tt.wantErr)
-				return
-			}
-
-			// compare wanted vs actual pagingMeta result
-			g.Expect(pagingMeta).To(gomega.Equal(tt.want.pagingMeta))
-
-			// compare wanted vs actual results
-			if len(result) != len(tt.want.kafkaList) {
-				t.Errorf("kafka.Service.List(): total number of results: got = %d want = %d", len(result), len(tt.want.kafkaList))
-			}
-
-			for i, got := range result {
-				g.Expect(got).To(gomega.Equal(tt.want.kafkaList[i]))
+			g.Expect(k.ForceDelete(tt.args.ctx, tt.args.id) != nil).To(gomega.Equal(tt.wantErr))
+			if tt.wantDeletionArgs != nil {
+				g.Expect(deletionArgs).To(gomega.ContainElements(tt.wantDeletionArgs...))
 			}
 		})
 	}
 }
 
-func Test_kafkaService_ListByStatus(t *testing.T) {
-	type fields struct {
-		connectionFactory *db.ConnectionFactory
-		clusterService    ClusterService
-	}
+func Test_kafkaService_GetQuotaCost(t *testing.T) {
 	type args struct {
-		status constants2.KafkaStatus
+		instanceType types.KafkaInstanceType
+		sizeId       string
 	}
+
 	tests := []struct {
-		name    string
-		fields  fields
-		args    args
-		want    []*dbapi.KafkaRequest
-		wantErr bool
-		setupFn func()
+		name        string
+		args        args
+		want        int
+		wantErrCode errors.ServiceErrorCode
 	}{
 		{
-			name: "fail when database returns an error",
+			name: "should return the quota consumed by a standard size",
+			args: args{
+				instanceType: types.STANDARD,
+				sizeId:       "x1",
+			},
+			want: 1,
+		},
+		{
+			name: "should return the quota consumed by a developer size",
+			args: args{
+				instanceType: types.DEVELOPER,
+				sizeId:       "x1",
+			},
+			want: 2,
+		},
+		{
+			name: "should return an error if the instance type is not supported",
+			args: args{
+				instanceType: types.KafkaInstanceType("unsupported"),
+				sizeId:       "x1",
+			},
+			wantErrCode: errors.ErrorInstanceTypeNotSupported,
+		},
+		{
+			name: "should return an error if the size is not supported by the instance type",
+			args: args{
+				instanceType: types.STANDARD,
+				sizeId:       "x99",
+			},
+			wantErrCode: errors.ErrorInstancePlanNotSupported,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			k := &kafkaService{
+				kafkaConfig: &defaultKafkaConf,
+			}
+			got, err := k.GetQuotaCost(tt.args.instanceType, tt.args.sizeId)
+			if tt.wantErrCode != 0 {
+				g.Expect(err).ToNot(gomega.BeNil())
+				g.Expect(err.Code).To(gomega.Equal(tt.wantErrCode))
+				return
+			}
+			g.Expect(err).To(gomega.BeNil())
+			g.Expect(got).To(gomega.Equal(tt.want))
+		})
+	}
+}
+
+func Test_kafkaService_reserveQuota_FailureMetric(t *testing.T) {
+	type fields struct {
+		quotaConfig  *config.KafkaQuotaConfig
+		quotaService QuotaService
+	}
+
+	developerKafka := buildKafkaRequest(func(kafkaRequest *dbapi.KafkaRequest) {
+		kafkaRequest.InstanceType = types.DEVELOPER.String()
+		kafkaRequest.OrganisationId = "org-id"
+	})
+	standardKafka := buildKafkaRequest(func(kafkaRequest *dbapi.KafkaRequest) {
+		kafkaRequest.InstanceType = types.STANDARD.String()
+	})
+	buildQuotaService := func(err *errors.ServiceError) QuotaService {
+		return &QuotaServiceMock{
+			ReserveQuotaFunc: func(kafka *dbapi.KafkaRequest, instanceType types.KafkaInstanceType) (string, *errors.ServiceError) {
+				return "", err
+			},
+		}
+	}
+
+	tests := []struct {
+		name         string
+		fields       fields
+		kafkaRequest *dbapi.KafkaRequest
+		wantReason   string
+		setupFn      func()
+	}{
+		{
+			name: "should record developer instances not being allowed",
 			fields: fields{
-				connectionFactory: db.NewMockConnectionFactory(nil),
+				quotaConfig: &config.KafkaQuotaConfig{
+					AllowDeveloperInstance: false,
+				},
 			},
-			wantErr: true,
+			kafkaRequest: developerKafka,
+			wantReason:   quotaReservationFailureDeveloperInstanceNotAllowed,
 			setupFn: func() {
-				mocket.Catcher.Reset().NewMock().WithQuery("SELECT").WithQueryException()
-				mocket.Catcher.NewMock().WithExecException().WithQueryException()
+				mocket.Catcher.Reset().NewMock().WithExecException().WithQueryException()
 			},
 		},
 		{
-			name: "success",
+			name: "should record the developer instances limit being reached",
 			fields: fields{
-				connectionFactory: db.NewMockConnectionFactory(nil),
+				quotaConfig: &config.KafkaQuotaConfig{
+					AllowDeveloperInstance:       true,
+					MaxAllowedDeveloperInstances: 1,
+				},
 			},
-			want: []*dbapi.KafkaRequest{buildKafkaRequest(nil)},
+			kafkaRequest: developerKafka,
+			wantReason:   quotaReservationFailureDeveloperInstanceLimitReached,
 			setupFn: func() {
 				mocket.Catcher.Reset().NewMock().
-					WithQuery(`SELECT * FROM "kafka_requests" WHERE status IN ($1)`).
-					WithArgs("").
-					WithReply(converters.ConvertKafkaRequest(buildKafkaRequest(nil)))
+					WithQuery(`SELECT count(1) FROM "kafka_requests" WHERE instance_type = $1 AND owner = $2 AND (organisation_id = $3)`).
+					WithReply([]map[string]interface{}{{"count": 1}})
 				mocket.Catcher.NewMock().WithExecException().WithQueryException()
 			},
 		},
+		{
+			name: "should record insufficient quota",
+			fields: fields{
+				quotaConfig:  config.NewKafkaQuotaConfig(),
+				quotaService: buildQuotaService(errors.InsufficientQuotaError("insufficient quota")),
+			},
+			kafkaRequest: standardKafka,
+			wantReason:   quotaReservationFailureInsufficientQuota,
+			setupFn:      func() {},
+		},
+		{
+			name: "should record the maximum number of allowed instances being reached",
+			fields: fields{
+				quotaConfig:  config.NewKafkaQuotaConfig(),
+				quotaService: buildQuotaService(errors.MaximumAllowedInstanceReached("maximum allowed instances reached")),
+			},
+			kafkaRequest: standardKafka,
+			wantReason:   quotaReservationFailureMaxAllowedInstancesReached,
+			setupFn:      func() {},
+		},
+		{
+			name: "should record an invalid billing account",
+			fields: fields{
+				quotaConfig:  config.NewKafkaQuotaConfig(),
+				quotaService: buildQuotaService(errors.InvalidBillingAccount("invalid billing account")),
+			},
+			kafkaRequest: standardKafka,
+			wantReason:   quotaReservationFailureInvalidBillingAccount,
+			setupFn:      func() {},
+		},
+		{
+			name: "should record a quota service error",
+			fields: fields{
+				quotaConfig:  config.NewKafkaQuotaConfig(),
+				quotaService: buildQuotaService(errors.GeneralError("quota service unreachable")),
+			},
+			kafkaRequest: standardKafka,
+			wantReason:   quotaReservationFailureQuotaServiceError,
+			setupFn:      func() {},
+		},
 	}
 
 	for _, testcase := range tests {
 		tt := testcase
-
 		t.Run(tt.name, func(t *testing.T) {
 			g := gomega.NewWithT(t)
 			tt.setupFn()
+			metrics.Reset()
 			k := &kafkaService{
-				connectionFactory: tt.fields.connectionFactory,
-				clusterService:    tt.fields.clusterService,
-				kafkaConfig:       config.NewKafkaConfig(),
-				awsConfig:         config.NewAWSConfig(),
+				connectionFactory: db.NewMockConnectionFactory(nil),
+				kafkaConfig: &config.KafkaConfig{
+					Quota:                  tt.fields.quotaConfig,
+					SupportedInstanceTypes: &kafkaSupportedInstanceTypesConfig,
+				},
+				quotaServiceFactory: &QuotaServiceFactoryMock{
+					GetQuotaServiceFunc: func(quotaType api.QuotaType) (QuotaService, *errors.ServiceError) {
+						return tt.fields.quotaService, nil
+					},
+				},
 			}
-			got, err := k.ListByStatus(tt.args.status)
-			if (err != nil) != tt.wantErr {
-				t.Errorf("kafkaService.ListByStatus() error = %v, wantErr %v", err, tt.wantErr)
-				return
+			_, err := k.reserveQuota(tt.kafkaRequest)
+			g.Expect(err).ToNot(gomega.BeNil())
+			g.Expect(testutil.ToFloat64(metrics.KafkaQuotaReservationFailuresCountMetric.WithLabelValues(tt.wantReason))).To(gomega.Equal(1.0))
+			g.Expect(testutil.CollectAndCount(metrics.KafkaQuotaReservationFailuresCountMetric)).To(gomega.Equal(1))
+		})
+	}
+}
+
+func Test_kafkaService_reserveQuota_MarketplaceCloudProvider(t *testing.T) {
+	tests := []struct {
+		name                   string
+		resolvedMarketplace    string
+		allowCrossCloudBilling bool
+		wantErr                bool
+		wantQuotaDeleted       bool
+		wantSubscriptionID     string
+	}{
+		{
+			name:                "should keep the quota when the resolved marketplace is offered by the cloud provider",
+			resolvedMarketplace: "aws",
+			wantSubscriptionID:  "subscription-id",
+		},
+		{
+			name:                "should release the quota when the resolved marketplace is not offered by the cloud provider",
+			resolvedMarketplace: "azure",
+			wantErr:             true,
+			wantQuotaDeleted:    true,
+		},
+		{
+			name:                   "should keep the quota when cross cloud billing is allowed",
+			resolvedMarketplace:    "azure",
+			allowCrossCloudBilling: true,
+			wantSubscriptionID:     "subscription-id",
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			quotaService := &QuotaServiceMock{
+				ReserveQuotaFunc: func(kafka *dbapi.KafkaRequest, instanceType types.KafkaInstanceType) (string, *errors.ServiceError) {
+					kafka.Marketplace = tt.resolvedMarketplace
+					return "subscription-id", nil
+				},
+				DeleteQuotaFunc: func(subscriptionId string) *errors.ServiceError {
+					return nil
+				},
 			}
-			g.Expect(got).To(gomega.Equal(tt.want))
+			k := &kafkaService{
+				connectionFactory: db.NewMockConnectionFactory(nil),
+				kafkaConfig: &config.KafkaConfig{
+					Quota:                             config.NewKafkaQuotaConfig(),
+					SupportedInstanceTypes:            &kafkaSupportedInstanceTypesConfig,
+					AllowCrossCloudMarketplaceBilling: tt.allowCrossCloudBilling,
+				},
+				quotaServiceFactory: &QuotaServiceFactoryMock{
+					GetQuotaServiceFunc: func(quotaType api.QuotaType) (QuotaService, *errors.ServiceError) {
+						return quotaService, nil
+					},
+				},
+			}
+			kafkaRequest := buildKafkaRequest(func(kafkaRequest *dbapi.KafkaRequest) {
+				kafkaRequest.InstanceType = types.STANDARD.String()
+			})
+
+			subscriptionID, err := k.reserveQuota(kafkaRequest)
+			g.Expect(err != nil).To(gomega.Equal(tt.wantErr))
+			if tt.wantErr {
+				g.Expect(err.Code).To(gomega.Equal(errors.ErrorValidation))
+			}
+			g.Expect(subscriptionID).To(gomega.Equal(tt.wantSubscriptionID))
+			g.Expect(len(quotaService.DeleteQuotaCalls()) == 1).To(gomega.Equal(tt.wantQuotaDeleted))
 		})
 	}
 }
 
-func Test_kafkaService_UpdateStatus(t *testing.T) {
+func Test_kafkaService_ConfirmQuota(t *testing.T) {
 	type fields struct {
 		connectionFactory *db.ConnectionFactory
-		clusterService    ClusterService
+		quotaService      *QuotaServiceMock
 	}
 	type args struct {
-		id     string
-		status constants2.KafkaStatus
+		id string
+	}
+
+	buildKafkaReply := func(status constants2.KafkaStatus) []map[string]interface{} {
+		return converters.ConvertKafkaRequest(buildKafkaRequest(func(kafkaRequest *dbapi.KafkaRequest) {
+			kafkaRequest.Status = status.String()
+			kafkaRequest.InstanceType = types.STANDARD.String()
+		}))
 	}
+	buildQuotaService := func(reserveErr *errors.ServiceError) *QuotaServiceMock {
+		return &QuotaServiceMock{
+			ReserveQuotaFunc: func(kafka *dbapi.KafkaRequest, instanceType types.KafkaInstanceType) (string, *errors.ServiceError) {
+				if reserveErr != nil {
+					return "", reserveErr
+				}
+				return "subscription-id", nil
+			},
+			DeleteQuotaFunc: func(subscriptionId string) *errors.ServiceError {
+				return nil
+			},
+		}
+	}
+
 	tests := []struct {
-		name         string
-		fields       fields
-		args         args
-		wantErr      bool
-		wantExecuted bool
-		setupFn      func()
+		name              string
+		fields            fields
+		args              args
+		wantErr           bool
+		wantQuotaReleased bool
+		setupFn           func()
 	}{
 		{
-			name:         "fail when database returns an error",
-			wantExecuted: true,
+			name: "should reserve the quota and accept a kafka pending quota",
 			fields: fields{
 				connectionFactory: db.NewMockConnectionFactory(nil),
+				quotaService:      buildQuotaService(nil),
 			},
-			wantErr: true,
+			args: args{
+				id: testID,
+			},
+			wantErr: false,
 			setupFn: func() {
-				mocket.Catcher.Reset().NewMock().WithQuery("UPDATE").WithExecException()
+				mocket.Catcher.Reset().NewMock().
+					WithQuery(`SELECT * FROM "kafka_requests" WHERE id = $1`).
+					WithArgs(testID).
+					WithReply(buildKafkaReply(constants2.KafkaRequestStatusPendingQuota))
+				mocket.Catcher.NewMock().
+					WithQuery(`UPDATE "kafka_requests" SET "status"=$1,"status_updated_at"=$2,"subscription_id"=$3`).
+					WithRowsNum(1)
+				mocket.Catcher.NewMock().WithQuery("pg_advisory_xact_lock")
+				mocket.Catcher.NewMock().WithExecException().WithQueryException()
 			},
 		},
 		{
-			name: "refuse execution because cluster in deprovisioning state",
+			name: "should return an error if the kafka is not pending quota",
 			fields: fields{
 				connectionFactory: db.NewMockConnectionFactory(nil),
+				quotaService:      buildQuotaService(nil),
 			},
-			wantErr:      true,
-			wantExecuted: false,
+			args: args{
+				id: testID,
+			},
+			wantErr: true,
 			setupFn: func() {
-				mocket.Catcher.NewMock().
+				mocket.Catcher.Reset().NewMock().
 					WithQuery(`SELECT * FROM "kafka_requests" WHERE id = $1`).
 					WithArgs(testID).
-					WithReply(converters.ConvertKafkaRequest(buildKafkaRequest(func(kafkaRequest *dbapi.KafkaRequest) {
-						kafkaRequest.Status = constants2.KafkaRequestStatusDeprovision.String()
-					})))
+					WithReply(buildKafkaReply(constants2.KafkaRequestStatusAccepted))
+				mocket.Catcher.NewMock().WithQuery("pg_advisory_xact_lock")
 				mocket.Catcher.NewMock().WithExecException().WithQueryException()
 			},
-			args: args{
-				id:     testID,
-				status: constants2.KafkaRequestStatusPreparing,
-			},
 		},
 		{
-			name: "success when because cluster in deprovisioning state but status to update is deleted ",
+			name: "should return an error if the quota cannot be reserved",
 			fields: fields{
 				connectionFactory: db.NewMockConnectionFactory(nil),
+				quotaService:      buildQuotaService(errors.InsufficientQuotaError("insufficient quota")),
 			},
-			wantErr:      false,
-			wantExecuted: true,
+			args: args{
+				id: testID,
+			},
+			wantErr: true,
 			setupFn: func() {
-				mocket.Catcher.Reset().NewMock().WithQuery(`UPDATE "kafka_requests" SET "status"=$1`)
-				mocket.Catcher.NewMock().
+				mocket.Catcher.Reset().NewMock().
 					WithQuery(`SELECT * FROM "kafka_requests" WHERE id = $1`).
 					WithArgs(testID).
-					WithReply(converters.ConvertKafkaRequest(buildKafkaRequest(func(kafkaRequest *dbapi.KafkaRequest) {
-						kafkaRequest.Status = constants2.KafkaRequestStatusDeprovision.String()
-					})))
+					WithReply(buildKafkaReply(constants2.KafkaRequestStatusPendingQuota))
+				mocket.Catcher.NewMock().WithQuery("pg_advisory_xact_lock")
 				mocket.Catcher.NewMock().WithExecException().WithQueryException()
 			},
-			args: args{
-				id:     testID,
-				status: constants2.KafkaRequestStatusDeleting,
-			},
 		},
 		{
-			name:         "success",
-			wantExecuted: true,
+			name: "should release the reserved quota if the kafka is no longer pending quota when updating it",
 			fields: fields{
 				connectionFactory: db.NewMockConnectionFactory(nil),
+				quotaService:      buildQuotaService(nil),
 			},
+			args: args{
+				id: testID,
+			},
+			wantErr:           true,
+			wantQuotaReleased: true,
 			setupFn: func() {
 				mocket.Catcher.Reset().NewMock().
 					WithQuery(`SELECT * FROM "kafka_requests" WHERE id = $1`).
 					WithArgs(testID).
-					WithReply(converters.ConvertKafkaRequest(buildKafkaRequest(func(kafkaRequest *dbapi.KafkaRequest) {
-						kafkaRequest.Status = constants2.KafkaRequestStatusPreparing.String()
-					})))
-				mocket.Catcher.NewMock().WithQuery(`UPDATE "kafka_requests" SET "status"=$1`)
+					WithReply(buildKafkaReply(constants2.KafkaRequestStatusPendingQuota))
+				mocket.Catcher.NewMock().
+					WithQuery(`UPDATE "kafka_requests" SET "status"=$1,"status_updated_at"=$2,"subscription_id"=$3`).
+					WithRowsNum(0)
+				mocket.Catcher.NewMock().WithQuery("pg_advisory_xact_lock")
 				mocket.Catcher.NewMock().WithExecException().WithQueryException()
 			},
-			args: args{
-				id: testID,
-			},
 		},
 	}
+
 	for _, testcase := range tests {
 		tt := testcase
-
 		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
 			tt.setupFn()
-			k := kafkaService{
+			k := &kafkaService{
 				connectionFactory: tt.fields.connectionFactory,
-				clusterService:    tt.fields.clusterService,
-				kafkaConfig:       config.NewKafkaConfig(),
-				awsConfig:         config.NewAWSConfig(),
-			}
-			executed, err := k.UpdateStatus(tt.args.id, tt.args.status)
-			if executed != tt.wantExecuted {
-				t.Error("kafkaService.UpdateStatus() error = should have refused execution but didn't")
-				return
-			}
-			if (err != nil) != tt.wantErr {
-				t.Errorf("kafkaService.UpdateStatus() error = %v, wantErr %v", err, tt.wantErr)
-				return
+				kafkaConfig:       &defaultKafkaConf,
+				quotaServiceFactory: &QuotaServiceFactoryMock{
+					GetQuotaServiceFunc: func(quotaType api.QuotaType) (QuotaService, *errors.ServiceError) {
+						return tt.fields.quotaService, nil
+					},
+				},
 			}
+			g.Expect(k.ConfirmQuota(tt.args.id) != nil).To(gomega.Equal(tt.wantErr))
+			g.Expect(len(tt.fields.quotaService.DeleteQuotaCalls()) > 0).To(gomega.Equal(tt.wantQuotaReleased))
 		})
 	}
 }
 
-func Test_kafkaService_Update(t *testing.T) {
+func Test_kafkaService_AbortPendingQuota(t *testing.T) {
 	type fields struct {
 		connectionFactory *db.ConnectionFactory
-		clusterService    ClusterService
 	}
 	type args struct {
-		kafkaRequest *dbapi.KafkaRequest
+		id string
 	}
+
 	tests := []struct {
 		name    string
 		fields  fields
@@ -2188,307 +3897,484 @@ func Test_kafkaService_Update(t *testing.T) {
 		setupFn func()
 	}{
 		{
-			name: "fail when database returns an error",
+			name: "should hard delete a kafka pending quota",
+			fields: fields{
+				connectionFactory: db.NewMockConnectionFactory(nil),
+			},
 			args: args{
-				kafkaRequest: buildKafkaRequest(nil),
+				id: testID,
 			},
+			wantErr: false,
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().
+					WithQuery(`DELETE FROM "kafka_requests" WHERE id = $1 AND status = $2`).
+					WithArgs(testID, constants2.KafkaRequestStatusPendingQuota.String()).
+					WithRowsNum(1)
+				mocket.Catcher.NewMock().WithExecException().WithQueryException()
+			},
+		},
+		{
+			name: "should return an error if there is no kafka pending quota with the given id",
 			fields: fields{
 				connectionFactory: db.NewMockConnectionFactory(nil),
 			},
+			args: args{
+				id: testID,
+			},
 			wantErr: true,
 			setupFn: func() {
-				mocket.Catcher.Reset().NewMock().WithQuery("UPDATE").WithExecException()
+				mocket.Catcher.Reset().NewMock().
+					WithQuery(`DELETE FROM "kafka_requests" WHERE id = $1 AND status = $2`).
+					WithArgs(testID, constants2.KafkaRequestStatusPendingQuota.String()).
+					WithRowsNum(0)
 				mocket.Catcher.NewMock().WithExecException().WithQueryException()
 			},
 		},
 		{
-			name: "success",
-			args: args{
-				kafkaRequest: buildKafkaRequest(nil),
-			},
+			name: "should return an error if the kafka cannot be deleted",
 			fields: fields{
 				connectionFactory: db.NewMockConnectionFactory(nil),
 			},
+			args: args{
+				id: testID,
+			},
+			wantErr: true,
 			setupFn: func() {
-				mocket.Catcher.Reset().NewMock().WithQuery(`UPDATE "kafka_requests"`)
-				mocket.Catcher.NewMock().WithQueryException().WithExecException()
+				mocket.Catcher.Reset().NewMock().WithExecException().WithQueryException()
 			},
 		},
 	}
+
 	for _, testcase := range tests {
 		tt := testcase
-
 		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
 			tt.setupFn()
-			k := kafkaService{
+			k := &kafkaService{
 				connectionFactory: tt.fields.connectionFactory,
-				clusterService:    tt.fields.clusterService,
-				kafkaConfig:       config.NewKafkaConfig(),
-				awsConfig:         config.NewAWSConfig(),
-			}
-			err := k.Update(tt.args.kafkaRequest)
-			if (err != nil) != tt.wantErr {
-				t.Errorf("kafkaService.Update() error = %v, wantErr %v", err, tt.wantErr)
-				return
 			}
+			g.Expect(k.AbortPendingQuota(tt.args.id) != nil).To(gomega.Equal(tt.wantErr))
 		})
 	}
 }
 
-func Test_kafkaService_Updates(t *testing.T) {
-	type fields struct {
-		connectionFactory *db.ConnectionFactory
-		clusterService    ClusterService
+func Test_kafkaService_ListWithClusterDetails(t *testing.T) {
+	authHelper, err := auth.NewAuthHelper(JwtKeyFile, JwtCAFile, "")
+	if err != nil {
+		t.Fatalf("failed to create auth helper: %s", err.Error())
 	}
-	type args struct {
-		kafkaRequest *dbapi.KafkaRequest
+	account, err := authHelper.NewAccount(testUser, "", "", "")
+	if err != nil {
+		t.Fatal("failed to build a new account")
+	}
+	jwt, err := authHelper.CreateJWTWithClaims(account, nil)
+	if err != nil {
+		t.Fatalf("failed to create jwt: %s", err.Error())
+	}
+	authenticatedCtx := auth.SetTokenInContext(context.TODO(), jwt)
+
+	kafkaList := dbapi.KafkaList{
+		buildKafkaRequest(func(kafkaRequest *dbapi.KafkaRequest) {
+			kafkaRequest.ID = "assigned"
+			kafkaRequest.ClusterID = "ready-cluster"
+		}),
+		buildKafkaRequest(func(kafkaRequest *dbapi.KafkaRequest) {
+			kafkaRequest.ID = "assigned-to-missing-cluster"
+			kafkaRequest.ClusterID = "missing-cluster"
+		}),
+		buildKafkaRequest(func(kafkaRequest *dbapi.KafkaRequest) {
+			kafkaRequest.ID = "unassigned"
+			kafkaRequest.ClusterID = ""
+		}),
 	}
+
+	type clusterDetails struct {
+		status api.ClusterStatus
+		dns    string
+	}
+
 	tests := []struct {
-		name    string
-		fields  fields
-		args    args
-		wantErr bool
-		setupFn func()
+		name                  string
+		includeClusterDetails bool
+		clustersQueryErr      bool
+		want                  map[string]clusterDetails
+		wantErr               bool
 	}{
 		{
-			name: "fail when database returns an error",
-			args: args{
-				kafkaRequest: buildKafkaRequest(nil),
-			},
-			fields: fields{
-				connectionFactory: db.NewMockConnectionFactory(nil),
-			},
-			wantErr: true,
-			setupFn: func() {
-				mocket.Catcher.Reset().NewMock().WithQuery("UPDATE").WithExecException()
-				mocket.Catcher.NewMock().WithExecException().WithQueryException()
+			name:                  "should include the details of the clusters hosting the kafkas",
+			includeClusterDetails: true,
+			want: map[string]clusterDetails{
+				"assigned":                    {status: api.ClusterReady, dns: "apps.ready-cluster.example.com"},
+				"assigned-to-missing-cluster": {},
+				"unassigned":                  {},
 			},
 		},
 		{
-			name: "success",
-			args: args{
-				kafkaRequest: buildKafkaRequest(nil),
-			},
-			fields: fields{
-				connectionFactory: db.NewMockConnectionFactory(nil),
-			},
-			setupFn: func() {
-				mocket.Catcher.Reset().NewMock().WithQuery(`UPDATE "kafka_requests"`)
-				mocket.Catcher.NewMock().WithQueryException().WithExecException()
+			name:                  "should not look the clusters up when their details are not requested",
+			includeClusterDetails: false,
+			clustersQueryErr:      true,
+			want: map[string]clusterDetails{
+				"assigned":                    {},
+				"assigned-to-missing-cluster": {},
+				"unassigned":                  {},
 			},
 		},
+		{
+			name:                  "should return an error if the clusters cannot be looked up",
+			includeClusterDetails: true,
+			clustersQueryErr:      true,
+			wantErr:               true,
+		},
 	}
+
 	for _, testcase := range tests {
 		tt := testcase
-
 		t.Run(tt.name, func(t *testing.T) {
-			tt.setupFn()
-			k := kafkaService{
-				connectionFactory: tt.fields.connectionFactory,
-				clusterService:    tt.fields.clusterService,
-				kafkaConfig:       config.NewKafkaConfig(),
-				awsConfig:         config.NewAWSConfig(),
+			g := gomega.NewWithT(t)
+			mocket.Catcher.Reset()
+			clustersQuery := mocket.Catcher.NewMock().
+				WithQuery(`SELECT "cluster_id","status","cluster_dns" FROM "clusters" WHERE cluster_id IN`).
+				WithArgs("ready-cluster", "missing-cluster")
+			if tt.clustersQueryErr {
+				clustersQuery.WithQueryException()
+			} else {
+				clustersQuery.WithReply([]map[string]interface{}{
+					{"cluster_id": "ready-cluster", "status": api.ClusterReady.String(), "cluster_dns": "apps.ready-cluster.example.com"},
+				})
 			}
-			err := k.Updates(tt.args.kafkaRequest, map[string]interface{}{
-				"id":    "idsds",
-				"owner": "",
-			})
-			if (err != nil) != tt.wantErr {
-				t.Errorf("kafkaService.Update() error = %v, wantErr %v", err, tt.wantErr)
+			mocket.Catcher.NewMock().WithQuery(`SELECT count(1) FROM "kafka_requests"`).WithReply([]map[string]interface{}{{"count": len(kafkaList)}})
+			mocket.Catcher.NewMock().WithQuery(`SELECT * FROM "kafka_requests"`).WithReply(converters.ConvertKafkaRequestList(kafkaList))
+			mocket.Catcher.NewMock().WithExecException().WithQueryException()
+
+			k := &kafkaService{
+				connectionFactory: db.NewMockConnectionFactory(nil),
+			}
+			got, _, err := k.ListWithClusterDetails(authenticatedCtx, &services.ListArguments{Page: 1, Size: 100}, tt.includeClusterDetails)
+			g.Expect(err != nil).To(gomega.Equal(tt.wantErr))
+			if tt.wantErr {
 				return
 			}
+
+			details := map[string]clusterDetails{}
+			for _, kafka := range got {
+				details[kafka.ID] = clusterDetails{status: kafka.ClusterStatus, dns: kafka.ClusterDNS}
+			}
+			g.Expect(details).To(gomega.Equal(tt.want))
 		})
 	}
 }
 
-func Test_kafkaService_DeprovisionKafkaForUsers(t *testing.T) {
+func Test_kafkaService_List(t *testing.T) {
 	type fields struct {
 		connectionFactory *db.ConnectionFactory
 	}
 	type args struct {
-		users []string
+		ctx      context.Context
+		listArgs *services.ListArguments
 	}
 
-	tests := []struct {
-		name    string
-		fields  fields
-		args    args
-		wantErr bool
-		setupFn func()
-	}{
-		{
-			name: "should receive error when update fails",
-			fields: fields{
-				connectionFactory: db.NewMockConnectionFactory(nil),
-			},
-			wantErr: true,
-			setupFn: func() {
-				mocket.Catcher.Reset().NewMock().WithQuery("UPDATE").WithError(fmt.Errorf("some update error"))
-				mocket.Catcher.NewMock().WithExecException().WithQueryException()
-			},
-			args: args{users: []string{"user"}},
-		},
-		{
-			name: "should not receive error when update succeed",
-			fields: fields{
-				connectionFactory: db.NewMockConnectionFactory(nil),
-			},
-			wantErr: false,
-			args:    args{users: []string{"user"}},
-			setupFn: func() {
-				mocket.Catcher.Reset().NewMock().WithQuery(`UPDATE "kafka_requests" SET "status"`)
-				mocket.Catcher.NewMock().WithExecException().WithQueryException()
-			},
-		},
+	type want struct {
+		kafkaList  dbapi.KafkaList
+		pagingMeta *api.PagingMeta
 	}
 
-	for _, testcase := range tests {
-		tt := testcase
-
-		t.Run(tt.name, func(t *testing.T) {
-			g := gomega.NewWithT(t)
-			tt.setupFn()
-			k := kafkaService{
-				connectionFactory: tt.fields.connectionFactory,
-			}
-			err := k.DeprovisionKafkaForUsers(tt.args.users)
-			g.Expect(err != nil).To(gomega.Equal(tt.wantErr))
-		})
+	authHelper, err := auth.NewAuthHelper(JwtKeyFile, JwtCAFile, "")
+	if err != nil {
+		t.Fatalf("failed to create auth helper: %s", err.Error())
+	}
+	account, err := authHelper.NewAccount(testUser, "", "", "")
+	if err != nil {
+		t.Fatal("failed to build a new account")
 	}
-}
 
-func Test_kafkaService_DeprovisionExpiredKafkas(t *testing.T) {
-	type fields struct {
-		connectionFactory *db.ConnectionFactory
+	jwt, err := authHelper.CreateJWTWithClaims(account, nil)
+	if err != nil {
+		t.Fatalf("failed to create jwt: %s", err.Error())
 	}
+	ctx := context.TODO()
+	authenticatedCtx := auth.SetTokenInContext(ctx, jwt)
 
-	const instanceType = "type1"
-	const instanceSize = "size4"
+	adminCtx := context.TODO()
+	adminCtx = auth.SetIsAdminContext(adminCtx, true)
+	authenticatedAdminCtx := auth.SetTokenInContext(adminCtx, jwt)
 
 	tests := []struct {
 		name    string
 		fields  fields
+		args    args
+		want    want
 		wantErr bool
-		setupFn func()
+		setupFn func(dbapi.KafkaList)
 	}{
 		{
-			name: "fail when database update throws an error",
+			name: "success: list with default values for admin context",
 			fields: fields{
 				connectionFactory: db.NewMockConnectionFactory(nil),
 			},
-			wantErr: true,
-			setupFn: func() {
-				mocket.Catcher.Reset().NewMock().WithQuery(`SELECT * FROM "kafka_requests" WHERE instance_type IN ($1) AND status NOT IN ($2,$3)`).WithReply([]map[string]interface{}{{"id": "kafkainstance1", "instance_type": instanceType, "size_id": instanceSize}})
-				mocket.Catcher.NewMock().WithQuery(`UPDATE "kafka_requests" SET "status"=$1,"updated_at"=$2 WHERE id IN ($3)`).WithError(fmt.Errorf("an update error"))
+			args: args{
+				ctx: authenticatedAdminCtx,
+				listArgs: &services.ListArguments{
+					Page: 1,
+					Size: 100,
+				},
+			},
+			want: want{
+				kafkaList: dbapi.KafkaList{
+					&dbapi.KafkaRequest{
+						Region:        testKafkaRequestRegion,
+						ClusterID:     testClusterID,
+						CloudProvider: testKafkaRequestProvider,
+						MultiAZ:       false,
+						Name:          "dummy-cluster-name",
+						Status:        "accepted",
+						Owner:         testUser,
+						Meta: api.Meta{
+							CreatedAt: time.Now(),
+							UpdatedAt: time.Now(),
+							DeletedAt: gorm.DeletedAt{Valid: true},
+						},
+					},
+					&dbapi.KafkaRequest{
+						Region:        testKafkaRequestRegion,
+						ClusterID:     testClusterID,
+						CloudProvider: testKafkaRequestProvider,
+						MultiAZ:       false,
+						Name:          "dummy-cluster-name2",
+						Status:        "accepted",
+						Owner:         testUser,
+						Meta: api.Meta{
+							CreatedAt: time.Now(),
+							UpdatedAt: time.Now(),
+							DeletedAt: gorm.DeletedAt{Valid: true},
+						},
+					},
+				},
+				pagingMeta: &api.PagingMeta{
+					Page:  1,
+					Size:  2,
+					Total: 2,
+				},
+			},
+			wantErr: false,
+			setupFn: func(kafkaList dbapi.KafkaList) {
+				mocket.Catcher.Reset()
+
+				// total count query
+				totalCountResponse := []map[string]interface{}{{"count": len(kafkaList)}}
+				mocket.Catcher.NewMock().WithQuery(`SELECT count(1) FROM "kafka_requests"`).WithReply(totalCountResponse)
+
+				// actual query to return list of kafka requests based on filters
+				query := fmt.Sprintf(`SELECT * FROM "%s"`, kafkaRequestTableName)
+				response := converters.ConvertKafkaRequestList(kafkaList)
+				mocket.Catcher.NewMock().WithQuery(query).WithReply(response)
 				mocket.Catcher.NewMock().WithExecException().WithQueryException()
 			},
 		},
 		{
-			name: "success when database does not throw an error",
+			name: "success: list with default values",
 			fields: fields{
 				connectionFactory: db.NewMockConnectionFactory(nil),
 			},
-			wantErr: false,
-			setupFn: func() {
-				mocket.Catcher.Reset().NewMock().WithQuery(`SELECT * FROM "kafka_requests" WHERE instance_type IN ($1) AND status NOT IN ($2,$3)`).WithReply([]map[string]interface{}{{"id": "kafkainstance1", "instance_type": instanceType, "size_id": instanceSize}})
-				mocket.Catcher.NewMock().WithQuery(`UPDATE "kafka_requests" SET "status"=$1,"updated_at"=$2 WHERE id IN ($3)`)
-				mocket.Catcher.NewMock().WithExecException().WithQueryException()
+			args: args{
+				ctx: authenticatedCtx,
+				listArgs: &services.ListArguments{
+					Page: 1,
+					Size: 100,
+				},
 			},
-		},
-	}
-
-	for _, testcase := range tests {
-		tt := testcase
-
-		t.Run(tt.name, func(t *testing.T) {
-			g := gomega.NewWithT(t)
-			if tt.setupFn != nil {
-				tt.setupFn()
-			}
-			k := &kafkaService{
-				connectionFactory: tt.fields.connectionFactory,
-				kafkaConfig:       config.NewKafkaConfig(),
-			}
-			k.kafkaConfig.SupportedInstanceTypes.Configuration = config.SupportedKafkaInstanceTypesConfig{
-				SupportedKafkaInstanceTypes: []config.KafkaInstanceType{
-					{
-						Id: instanceType,
-						Sizes: []config.KafkaInstanceSize{
-							{Id: "size1"},
-							{Id: instanceSize, LifespanSeconds: &[]int{1234}[0]},
+			want: want{
+				kafkaList: dbapi.KafkaList{
+					&dbapi.KafkaRequest{
+						Region:        testKafkaRequestRegion,
+						ClusterID:     testClusterID,
+						CloudProvider: testKafkaRequestProvider,
+						MultiAZ:       false,
+						Name:          "dummy-cluster-name",
+						Status:        "accepted",
+						Owner:         testUser,
+						Meta: api.Meta{
+							CreatedAt: time.Now(),
+							UpdatedAt: time.Now(),
+							DeletedAt: gorm.DeletedAt{Valid: true},
+						},
+					},
+					&dbapi.KafkaRequest{
+						Region:        testKafkaRequestRegion,
+						ClusterID:     testClusterID,
+						CloudProvider: testKafkaRequestProvider,
+						MultiAZ:       false,
+						Name:          "dummy-cluster-name2",
+						Status:        "accepted",
+						Owner:         testUser,
+						Meta: api.Meta{
+							CreatedAt: time.Now(),
+							UpdatedAt: time.Now(),
+							DeletedAt: gorm.DeletedAt{Valid: true},
 						},
 					},
 				},
-			}
-			err := k.DeprovisionExpiredKafkas()
-			g.Expect(err != nil).To(gomega.Equal(tt.wantErr))
-		})
-	}
-}
+				pagingMeta: &api.PagingMeta{
+					Page:  1,
+					Size:  2,
+					Total: 2,
+				},
+			},
+			wantErr: false,
+			setupFn: func(kafkaList dbapi.KafkaList) {
+				mocket.Catcher.Reset()
 
-func Test_KafkaService_CountByStatus(t *testing.T) {
-	type fields struct {
-		connectionFactory *db.ConnectionFactory
-	}
-	type args struct {
-		status []constants2.KafkaStatus
-	}
-	tests := []struct {
-		name      string
-		fields    fields
-		args      args
-		wantErr   bool
-		want      []KafkaStatusCount
-		setupFunc func()
-	}{
+				// total count query
+				totalCountResponse := []map[string]interface{}{{"count": len(kafkaList)}}
+				mocket.Catcher.NewMock().WithQuery(`SELECT count(1) FROM "kafka_requests"`).WithReply(totalCountResponse)
+
+				// actual query to return list of kafka requests based on filters
+				query := fmt.Sprintf(`SELECT * FROM "%s"`, kafkaRequestTableName)
+				response := converters.ConvertKafkaRequestList(kafkaList)
+				mocket.Catcher.NewMock().WithQuery(query).WithReply(response)
+				mocket.Catcher.NewMock().WithExecException().WithQueryException()
+			},
+		},
 		{
-			name:   "should return the counts of Kafkas in different status",
-			fields: fields{connectionFactory: db.NewMockConnectionFactory(nil)},
+			name: "success: list with specified size",
+			fields: fields{
+				connectionFactory: db.NewMockConnectionFactory(nil),
+			},
 			args: args{
-				status: []constants2.KafkaStatus{constants2.KafkaRequestStatusAccepted, constants2.KafkaRequestStatusReady, constants2.KafkaRequestStatusProvisioning},
+				ctx: authenticatedCtx,
+				listArgs: &services.ListArguments{
+					Page: 1,
+					Size: 1,
+				},
 			},
-			wantErr: false,
-			setupFunc: func() {
-				counters := []map[string]interface{}{
-					{
-						"status": "accepted",
-						"count":  2,
-					},
-					{
-						"status": "ready",
-						"count":  1,
+			want: want{
+				kafkaList: dbapi.KafkaList{
+					&dbapi.KafkaRequest{
+						Region:        testKafkaRequestRegion,
+						ClusterID:     testClusterID,
+						CloudProvider: testKafkaRequestProvider,
+						MultiAZ:       false,
+						Name:          "dummy-cluster-name",
+						Status:        "accepted",
+						Owner:         testUser,
+						Meta: api.Meta{
+							CreatedAt: time.Now(),
+							UpdatedAt: time.Now(),
+							DeletedAt: gorm.DeletedAt{Valid: true},
+						},
 					},
-				}
-				mocket.Catcher.Reset().
-					NewMock().
-					WithQuery(`SELECT status as Status, count(1) as Count FROM "kafka_requests" WHERE status in ($1,$2,$3)`).
-					WithArgs(constants2.KafkaRequestStatusAccepted.String(), constants2.KafkaRequestStatusReady.String(), constants2.KafkaRequestStatusProvisioning.String()).
-					WithReply(counters)
+				},
+				pagingMeta: &api.PagingMeta{
+					Page:  1,
+					Size:  1,
+					Total: 5,
+				},
+			},
+			wantErr: false,
+			setupFn: func(kafkaList dbapi.KafkaList) {
+				mocket.Catcher.Reset()
+
+				// total count query
+				totalCountResponse := []map[string]interface{}{{"count": "5"}}
+				mocket.Catcher.NewMock().WithQuery(`SELECT count(1) FROM "kafka_requests"`).WithReply(totalCountResponse)
+
+				// actual query to return list of kafka requests based on filters
+				query := fmt.Sprintf(`SELECT * FROM "%s"`, kafkaRequestTableName)
+				response := converters.ConvertKafkaRequestList(kafkaList)
+
+				mocket.Catcher.NewMock().WithQuery(query).WithReply(response)
 				mocket.Catcher.NewMock().WithExecException().WithQueryException()
 			},
-			want: []KafkaStatusCount{{
-				Status: constants2.KafkaRequestStatusAccepted,
-				Count:  2,
-			}, {
-				Status: constants2.KafkaRequestStatusReady,
-				Count:  1,
-			}, {
-				Status: constants2.KafkaRequestStatusProvisioning,
-				Count:  0,
-			}},
 		},
 		{
-			name:   "should return error",
-			fields: fields{connectionFactory: db.NewMockConnectionFactory(nil)},
+			name: "success: return empty list if no kafka requests available for user",
+			fields: fields{
+				connectionFactory: db.NewMockConnectionFactory(nil),
+			},
 			args: args{
-				status: []constants2.KafkaStatus{constants2.KafkaRequestStatusAccepted, constants2.KafkaRequestStatusReady},
+				ctx: authenticatedCtx,
+				listArgs: &services.ListArguments{
+					Page: 1,
+					Size: 100,
+				},
+			},
+			want: want{
+				kafkaList: dbapi.KafkaList{},
+				pagingMeta: &api.PagingMeta{
+					Page:  1,
+					Size:  0,
+					Total: 0,
+				},
+			},
+			wantErr: false,
+			setupFn: func(kafkaList dbapi.KafkaList) {
+				mocket.Catcher.Reset()
+
+				// total count query
+				totalCountResponse := []map[string]interface{}{{"count": len(kafkaList)}}
+				mocket.Catcher.NewMock().WithQuery(`SELECT count(1) FROM "kafka_requests"`).WithReply(totalCountResponse)
+
+				// actual query to return list of kafka requests based on filters
+				query := fmt.Sprintf(`SELECT * FROM "%s"`, kafkaRequestTableName)
+				response := converters.ConvertKafkaRequestList(kafkaList)
+
+				mocket.Catcher.NewMock().WithQuery(query).WithReply(response)
+				mocket.Catcher.NewMock().WithExecException().WithQueryException()
+			},
+		},
+		{
+			name: "fail: user credentials not available in context",
+			fields: fields{
+				connectionFactory: db.NewMockConnectionFactory(nil),
+			},
+			args: args{
+				ctx: context.TODO(),
+				listArgs: &services.ListArguments{
+					Page: 1,
+					Size: 100,
+				},
+			},
+			want: want{
+				kafkaList:  nil,
+				pagingMeta: nil,
 			},
 			wantErr: true,
-			setupFunc: func() {
-				mocket.Catcher.Reset().NewMock().WithQuery(`SELECT`).WithQueryException()
+			setupFn: func(kafkaList dbapi.KafkaList) {
+				mocket.Catcher.Reset()
+
+				totalCountResponse := []map[string]interface{}{{"count": len(kafkaList)}}
+				mocket.Catcher.NewMock().WithQuery("SELECT count(1) FROM \"kafka_requests\"").WithReply(totalCountResponse)
+
+				query := fmt.Sprintf(`SELECT * FROM "%s"`, kafkaRequestTableName)
+				response := converters.ConvertKafkaRequestList(kafkaList)
+
+				mocket.Catcher.NewMock().WithQuery(query).WithReply(response)
 				mocket.Catcher.NewMock().WithExecException().WithQueryException()
 			},
-			want: nil,
+		},
+		{
+			name: "fail: database returns an error",
+			fields: fields{
+				connectionFactory: db.NewMockConnectionFactory(nil),
+			},
+			args: args{
+				ctx: authenticatedCtx,
+				listArgs: &services.ListArguments{
+					Page: 1,
+					Size: 100,
+				},
+			},
+			want: want{
+				kafkaList: dbapi.KafkaList{},
+				pagingMeta: &api.PagingMeta{
+					Page:  1,
+					Size:  0,
+					Total: 0,
+				},
+			},
+			wantErr: true,
+			setupFn: func(kafkaList dbapi.KafkaList) {
+				mocket.Catcher.Reset().NewMock().WithQuery("SELECT").WithQueryException()
+			},
 		},
 	}
 
@@ -2497,1307 +4383,5394 @@ func Test_KafkaService_CountByStatus(t *testing.T) {
 
 		t.Run(tt.name, func(t *testing.T) {
 			g := gomega.NewWithT(t)
-			if tt.setupFunc != nil {
-				tt.setupFunc()
-			}
+			tt.setupFn(tt.want.kafkaList)
 			k := &kafkaService{
 				connectionFactory: tt.fields.connectionFactory,
+				kafkaConfig:       config.NewKafkaConfig(),
+				awsConfig:         config.NewAWSConfig(),
 			}
-			status, err := k.CountByStatus(tt.args.status)
-			if !tt.wantErr && err != nil {
-				t.Errorf("unexpected error for CountByStatus: %v", err)
-			}
-			g.Expect(status).To(gomega.Equal(tt.want))
-		})
-	}
-}
 
-func Test_KafkaService_ChangeKafkaCNAMErecords(t *testing.T) {
-	type fields struct {
-		awsClient aws.AWSClient
-	}
+			result, pagingMeta, err := k.List(tt.args.ctx, tt.args.listArgs)
 
-	type args struct {
-		kafkaRequest *dbapi.KafkaRequest
-		action       KafkaRoutesAction
+			// check errors
+			if (err != nil) != tt.wantErr {
+				t.Errorf("kafkaService.List() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			// compare wanted vs actual pagingMeta result
+			g.Expect(pagingMeta).To(gomega.Equal(tt.want.pagingMeta))
+
+			// compare wanted vs actual results
+			if len(result) != len(tt.want.kafkaList) {
+				t.Errorf("kafka.Service.List(): total number of results: got = %d want = %d", len(result), len(tt.want.kafkaList))
+			}
+
+			for i, got := range result {
+				g.Expect(got).To(gomega.Equal(tt.want.kafkaList[i]))
+			}
+		})
+	}
+}
+
+func Test_kafkaService_ListByRegion(t *testing.T) {
+	type args struct {
+		provider string
+		region   string
+		listArgs *services.ListArguments
+	}
+
+	buildKafka := func(name string, instanceType types.KafkaInstanceType, sizeId string) *dbapi.KafkaRequest {
+		return buildKafkaRequest(func(kafkaRequest *dbapi.KafkaRequest) {
+			kafkaRequest.ID = name
+			kafkaRequest.Name = name
+			kafkaRequest.Status = constants2.KafkaRequestStatusReady.String()
+			kafkaRequest.InstanceType = instanceType.String()
+			kafkaRequest.SizeId = sizeId
+		})
+	}
+	standardKafka := buildKafka("kafka-a", types.STANDARD, "x2")
+	developerKafka := buildKafka("kafka-b", types.DEVELOPER, "x1")
+
+	setupRegionQueries := func(total int, page dbapi.KafkaList) func() {
+		return func() {
+			mocket.Catcher.Reset()
+			mocket.Catcher.NewMock().
+				WithQuery(`SELECT count(1) FROM "kafka_requests" WHERE cloud_provider = $1 AND region = $2`).
+				WithArgs(testKafkaRequestProvider, testKafkaRequestRegion).
+				WithReply([]map[string]interface{}{{"count": total}})
+			mocket.Catcher.NewMock().
+				WithQuery(`SELECT * FROM "kafka_requests" WHERE cloud_provider = $1 AND region = $2`).
+				WithReply(converters.ConvertKafkaRequestList(page))
+			mocket.Catcher.NewMock().WithExecException().WithQueryException()
+		}
 	}
 
 	tests := []struct {
-		name    string
-		fields  fields
-		args    args
-		wantErr bool
+		name           string
+		args           args
+		wantKafkas     dbapi.KafkaList
+		wantPagingMeta *api.PagingMeta
+		wantErr        bool
+		setupFn        func()
 	}{
 		{
-			name: "should create CNAMEs for kafka",
-			fields: fields{
-				awsClient: &aws.AWSClientMock{
-					ChangeResourceRecordSetsFunc: func(dnsName string, recordChangeBatch *route53.ChangeBatch) (*route53.ChangeResourceRecordSetsOutput, error) {
-						if len(recordChangeBatch.Changes) != 1 {
-							return nil, goerrors.Errorf("number of record changes should be 1")
-						}
-						if *recordChangeBatch.Changes[0].Action != "CREATE" {
-							return nil, goerrors.Errorf("the action of the record change is not CREATE")
-						}
-						return nil, nil
-					},
-					ListHostedZonesByNameInputFunc: func(dnsName string) (*route53.ListHostedZonesByNameOutput, error) {
-						return nil, nil
-					},
-				},
-			},
+			name: "should return the first page of the kafkas in the region",
 			args: args{
-				kafkaRequest: &dbapi.KafkaRequest{
-					Meta: api.Meta{
-						ID: "test-kafka-id",
-					},
-					Name:          "test-kafka-cname",
-					Routes:        []byte("[{\"domain\": \"test-kafka-id.example.com\", \"router\": \"test-kafka-id.rhcloud.com\"}]"),
-					Region:        testKafkaRequestRegion,
-					CloudProvider: cloudproviders.AWS.String(),
-				},
-				action: KafkaRoutesActionCreate,
+				provider: testKafkaRequestProvider,
+				region:   testKafkaRequestRegion,
+				listArgs: &services.ListArguments{Page: 1, Size: 1},
 			},
+			wantKafkas:     dbapi.KafkaList{standardKafka},
+			wantPagingMeta: &api.PagingMeta{Page: 1, Size: 1, Total: 2},
+			setupFn:        setupRegionQueries(2, dbapi.KafkaList{standardKafka}),
 		},
 		{
-			name: "should delete CNAMEs for kafka",
-			fields: fields{
-				awsClient: &aws.AWSClientMock{
-					ChangeResourceRecordSetsFunc: func(dnsName string, recordChangeBatch *route53.ChangeBatch) (*route53.ChangeResourceRecordSetsOutput, error) {
-						if len(recordChangeBatch.Changes) != 1 {
-							return nil, goerrors.Errorf("number of record changes should be 1")
-						}
-						if *recordChangeBatch.Changes[0].Action != "DELETE" {
-							return nil, goerrors.Errorf("the action of the record change is not DELETE")
-						}
-						return nil, nil
-					},
-					ListHostedZonesByNameInputFunc: func(dnsName string) (*route53.ListHostedZonesByNameOutput, error) {
-						return nil, nil
-					},
-				},
-			},
+			name: "should return the second page of the kafkas in the region",
 			args: args{
-				kafkaRequest: &dbapi.KafkaRequest{
-					Meta: api.Meta{
-						ID: "test-kafka-id",
-					},
-					Name:          "test-kafka-cname",
-					Routes:        []byte("[{\"domain\": \"test-kafka-id.example.com\", \"router\": \"test-kafka-id.rhcloud.com\"}]"),
-					Region:        testKafkaRequestRegion,
-					CloudProvider: cloudproviders.AWS.String(),
-				},
-				action: KafkaRoutesActionDelete,
+				provider: testKafkaRequestProvider,
+				region:   testKafkaRequestRegion,
+				listArgs: &services.ListArguments{Page: 2, Size: 1},
 			},
+			wantKafkas:     dbapi.KafkaList{developerKafka},
+			wantPagingMeta: &api.PagingMeta{Page: 2, Size: 1, Total: 2},
+			setupFn:        setupRegionQueries(2, dbapi.KafkaList{developerKafka}),
 		},
 		{
-			name: "should return error if it fails to get routes",
-			fields: fields{
-				awsClient: &aws.AWSClientMock{
-					ChangeResourceRecordSetsFunc: func(dnsName string, recordChangeBatch *route53.ChangeBatch) (*route53.ChangeResourceRecordSetsOutput, error) {
-						if len(recordChangeBatch.Changes) != 1 {
-							return nil, goerrors.Errorf("number of record changes should be 1")
-						}
-						if *recordChangeBatch.Changes[0].Action != "CREATE" {
-							return nil, goerrors.Errorf("the action of the record change is not CREATE")
-						}
-						return nil, nil
-					},
-					ListHostedZonesByNameInputFunc: func(dnsName string) (*route53.ListHostedZonesByNameOutput, error) {
-						return nil, nil
-					},
-				},
+			name: "should limit the page size to the number of kafkas in the region",
+			args: args{
+				provider: testKafkaRequestProvider,
+				region:   testKafkaRequestRegion,
+				listArgs: &services.ListArguments{Page: 1, Size: 100},
 			},
+			wantKafkas:     dbapi.KafkaList{standardKafka, developerKafka},
+			wantPagingMeta: &api.PagingMeta{Page: 1, Size: 2, Total: 2},
+			setupFn:        setupRegionQueries(2, dbapi.KafkaList{standardKafka, developerKafka}),
+		},
+		{
+			name: "should return an error if the kafkas cannot be listed",
 			args: args{
-				kafkaRequest: &dbapi.KafkaRequest{
-					Meta: api.Meta{
-						ID: "test-kafka-id",
-					},
-					Name:   "test-kafka-cname",
-					Region: testKafkaRequestRegion,
-				},
-				action: KafkaRoutesActionCreate,
+				provider: testKafkaRequestProvider,
+				region:   testKafkaRequestRegion,
+				listArgs: &services.ListArguments{Page: 1, Size: 100},
 			},
 			wantErr: true,
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().WithQuery("SELECT").WithQueryException()
+			},
 		},
 	}
 
 	for _, testcase := range tests {
 		tt := testcase
-
 		t.Run(tt.name, func(t *testing.T) {
-			kafkaService := &kafkaService{
-				awsClientFactory: aws.NewMockClientFactory(tt.fields.awsClient),
-				awsConfig: &config.AWSConfig{
-					Route53AccessKey:       "test-route-53-key",
-					Route53SecretAccessKey: "test-route-53-secret-key",
-				},
-				kafkaConfig: &config.KafkaConfig{
-					KafkaDomainName: "rhcloud.com",
-				},
+			g := gomega.NewWithT(t)
+			tt.setupFn()
+			k := &kafkaService{
+				connectionFactory: db.NewMockConnectionFactory(nil),
 			}
 
-			_, err := kafkaService.ChangeKafkaCNAMErecords(tt.args.kafkaRequest, tt.args.action)
-			if err != nil && !tt.wantErr {
-				t.Errorf("unexpected error for ChangeKafkaCNAMErecords %v", err)
+			result, pagingMeta, err := k.ListByRegion(tt.args.provider, tt.args.region, tt.args.listArgs)
+			g.Expect(err != nil).To(gomega.Equal(tt.wantErr))
+			if tt.wantErr {
+				return
+			}
+			g.Expect(pagingMeta).To(gomega.Equal(tt.wantPagingMeta))
+			g.Expect(result).To(gomega.HaveLen(len(tt.wantKafkas)))
+			for i, got := range result {
+				g.Expect(got.ID).To(gomega.Equal(tt.wantKafkas[i].ID))
+				g.Expect(got.InstanceType).To(gomega.Equal(tt.wantKafkas[i].InstanceType))
+				g.Expect(got.SizeId).To(gomega.Equal(tt.wantKafkas[i].SizeId))
+				g.Expect(got.Status).To(gomega.Equal(tt.wantKafkas[i].Status))
 			}
 		})
 	}
-
 }
 
-func Test_KafkaService_ListComponentVersions(t *testing.T) {
-	type fields struct {
-		connectionFactory *db.ConnectionFactory
+func Test_kafkaService_ListByInstanceType(t *testing.T) {
+	buildKafka := func(name string, sizeId string, status constants2.KafkaStatus) *dbapi.KafkaRequest {
+		return buildKafkaRequest(func(kafkaRequest *dbapi.KafkaRequest) {
+			kafkaRequest.ID = name
+			kafkaRequest.Name = name
+			kafkaRequest.Status = status.String()
+			kafkaRequest.InstanceType = types.STANDARD.String()
+			kafkaRequest.SizeId = sizeId
+		})
+	}
+	readyKafka := buildKafka("kafka-a", "x1", constants2.KafkaRequestStatusReady)
+	suspendedKafka := buildKafka("kafka-b", "x2", constants2.KafkaRequestStatusSuspended)
+
+	setupInstanceTypeQueries := func(total int, page dbapi.KafkaList) func() {
+		return func() {
+			mocket.Catcher.Reset()
+			mocket.Catcher.NewMock().
+				WithQuery(`SELECT count(1) FROM "kafka_requests" WHERE instance_type = $1`).
+				WithArgs(types.STANDARD.String()).
+				WithReply([]map[string]interface{}{{"count": total}})
+			mocket.Catcher.NewMock().
+				WithQuery(`SELECT * FROM "kafka_requests" WHERE instance_type = $1`).
+				WithArgs(types.STANDARD.String()).
+				WithReply(converters.ConvertKafkaRequestList(page))
+			mocket.Catcher.NewMock().WithExecException().WithQueryException()
+		}
 	}
+
 	tests := []struct {
-		name      string
-		fields    fields
-		wantErr   bool
-		want      []KafkaComponentVersions
-		setupFunc func()
+		name           string
+		listArgs       *services.ListArguments
+		wantKafkas     dbapi.KafkaList
+		wantPagingMeta *api.PagingMeta
+		wantErr        bool
+		setupFn        func()
 	}{
 		{
-			name:    "should return the component versions for Kafka",
-			fields:  fields{connectionFactory: db.NewMockConnectionFactory(nil)},
-			wantErr: false,
-			setupFunc: func() {
-				versions := []map[string]interface{}{
-					{
-						"id":                        "1",
-						"cluster_id":                "cluster1",
-						"desired_strimzi_version":   "1.0.1",
-						"actual_strimzi_version":    "1.0.0",
-						"strimzi_upgrading":         true,
-						"desired_kafka_version":     "2.0.1",
-						"actual_kafka_version":      "2.0.0",
-						"kafka_upgrading":           false,
-						"desired_kafka_ibp_version": "2.0",
-						"actual_kafka_ibp_version":  "2.0",
-						"kafka_ibp_upgrading":       false,
-					},
-					{
-						"id":                        "2",
-						"cluster_id":                "cluster2",
-						"desired_strimzi_version":   "1.0.1",
-						"actual_strimzi_version":    "1.0.0",
-						"strimzi_upgrading":         false,
-						"desired_kafka_version":     "2.0.1",
-						"actual_kafka_version":      "2.0.0",
-						"kafka_upgrading":           false,
-						"desired_kafka_ibp_version": "2.2",
-						"actual_kafka_ibp_version":  "2.1",
-						"kafka_ibp_upgrading":       true,
-					},
-				}
-				mocket.Catcher.Reset().
-					NewMock().
-					WithQuery(`SELECT "id","cluster_id","desired_strimzi_version","actual_strimzi_version","strimzi_upgrading","desired_kafka_version","actual_kafka_version","kafka_upgrading","desired_kafka_ibp_version","actual_kafka_ibp_version","kafka_ibp_upgrading"`).
-					WithReply(versions)
-				mocket.Catcher.NewMock().WithExecException().WithQueryException()
-			},
-			want: []KafkaComponentVersions{{
-				ID:                     "1",
-				ClusterID:              "cluster1",
-				DesiredStrimziVersion:  "1.0.1",
-				ActualStrimziVersion:   "1.0.0",
-				StrimziUpgrading:       true,
-				DesiredKafkaVersion:    "2.0.1",
-				ActualKafkaVersion:     "2.0.0",
-				KafkaUpgrading:         false,
-				DesiredKafkaIBPVersion: "2.0",
-				ActualKafkaIBPVersion:  "2.0",
-				KafkaIBPUpgrading:      false,
-			}, {
-				ID:                     "2",
-				ClusterID:              "cluster2",
-				DesiredStrimziVersion:  "1.0.1",
-				ActualStrimziVersion:   "1.0.0",
-				StrimziUpgrading:       false,
-				DesiredKafkaVersion:    "2.0.1",
-				ActualKafkaVersion:     "2.0.0",
-				KafkaUpgrading:         false,
-				DesiredKafkaIBPVersion: "2.2",
-				ActualKafkaIBPVersion:  "2.1",
-				KafkaIBPUpgrading:      true,
-			}},
+			name:           "should return the first page of the kafkas with the instance type",
+			listArgs:       &services.ListArguments{Page: 1, Size: 1},
+			wantKafkas:     dbapi.KafkaList{readyKafka},
+			wantPagingMeta: &api.PagingMeta{Page: 1, Size: 1, Total: 2},
+			setupFn:        setupInstanceTypeQueries(2, dbapi.KafkaList{readyKafka}),
 		},
 		{
-			name:    "should return error",
-			fields:  fields{connectionFactory: db.NewMockConnectionFactory(nil)},
-			wantErr: true,
-			setupFunc: func() {
-				mocket.Catcher.Reset().NewMock().WithQuery(`SELECT`).WithQueryException()
-				mocket.Catcher.NewMock().WithExecException().WithQueryException()
+			name:           "should return the second page of the kafkas with the instance type",
+			listArgs:       &services.ListArguments{Page: 2, Size: 1},
+			wantKafkas:     dbapi.KafkaList{suspendedKafka},
+			wantPagingMeta: &api.PagingMeta{Page: 2, Size: 1, Total: 2},
+			setupFn:        setupInstanceTypeQueries(2, dbapi.KafkaList{suspendedKafka}),
+		},
+		{
+			name:           "should limit the page size to the number of kafkas with the instance type",
+			listArgs:       &services.ListArguments{Page: 1, Size: 100},
+			wantKafkas:     dbapi.KafkaList{readyKafka, suspendedKafka},
+			wantPagingMeta: &api.PagingMeta{Page: 1, Size: 2, Total: 2},
+			setupFn:        setupInstanceTypeQueries(2, dbapi.KafkaList{readyKafka, suspendedKafka}),
+		},
+		{
+			name:     "should return an error if the kafkas cannot be listed",
+			listArgs: &services.ListArguments{Page: 1, Size: 100},
+			wantErr:  true,
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().WithQuery("SELECT").WithQueryException()
 			},
-			want: nil,
 		},
 	}
 
 	for _, testcase := range tests {
 		tt := testcase
-
 		t.Run(tt.name, func(t *testing.T) {
 			g := gomega.NewWithT(t)
-			if tt.setupFunc != nil {
-				tt.setupFunc()
-			}
+			tt.setupFn()
 			k := &kafkaService{
-				connectionFactory: tt.fields.connectionFactory,
+				connectionFactory: db.NewMockConnectionFactory(nil),
 			}
-			result, err := k.ListComponentVersions()
-			if !tt.wantErr && err != nil {
-				t.Errorf("unexpected error for ListComponentVersions: %v", err)
+
+			result, pagingMeta, err := k.ListByInstanceType(types.STANDARD, tt.listArgs)
+			g.Expect(err != nil).To(gomega.Equal(tt.wantErr))
+			if tt.wantErr {
+				return
+			}
+			g.Expect(pagingMeta).To(gomega.Equal(tt.wantPagingMeta))
+			g.Expect(result).To(gomega.HaveLen(len(tt.wantKafkas)))
+			for i, got := range result {
+				g.Expect(got.ID).To(gomega.Equal(tt.wantKafkas[i].ID))
+				g.Expect(got.InstanceType).To(gomega.Equal(types.STANDARD.String()))
+				g.Expect(got.SizeId).To(gomega.Equal(tt.wantKafkas[i].SizeId))
+				g.Expect(got.Status).To(gomega.Equal(tt.wantKafkas[i].Status))
 			}
-			g.Expect(result).To(gomega.Equal(tt.want))
 		})
 	}
 }
 
-func Test_kafkaService_GetAvailableSizesInRegion(t *testing.T) {
-	type fields struct {
-		connectionFactory        *db.ConnectionFactory
-		kafkaConfig              *config.KafkaConfig
-		dataplaneClusterConfig   *config.DataplaneClusterConfig
-		providerConfig           *config.ProviderConfig
-		clusterPlacementStrategy ClusterPlacementStrategy
+func Test_kafkaService_ListByQuotaType(t *testing.T) {
+	buildKafka := func(name string, quotaType api.QuotaType) *dbapi.KafkaRequest {
+		return buildKafkaRequest(func(kafkaRequest *dbapi.KafkaRequest) {
+			kafkaRequest.ID = name
+			kafkaRequest.Name = name
+			kafkaRequest.QuotaType = quotaType.String()
+		})
 	}
-	type args struct {
-		criteria *FindClusterCriteria
+	amsKafka := buildKafka("kafka-a", api.AMSQuotaType)
+	quotaListKafka := buildKafka("kafka-b", api.QuotaManagementListQuotaType)
+
+	setupQuotaTypeQueries := func() {
+		mocket.Catcher.Reset()
+		for _, kafka := range []*dbapi.KafkaRequest{amsKafka, quotaListKafka} {
+			mocket.Catcher.NewMock().
+				WithQuery(`SELECT count(1) FROM "kafka_requests" WHERE quota_type = $1`).
+				WithArgs(kafka.QuotaType).
+				WithReply([]map[string]interface{}{{"count": 1}})
+			reply := converters.ConvertKafkaRequest(kafka)
+			reply[0]["quota_type"] = kafka.QuotaType
+			mocket.Catcher.NewMock().
+				WithQuery(`SELECT * FROM "kafka_requests" WHERE quota_type = $1`).
+				WithArgs(kafka.QuotaType).
+				WithReply(reply)
+		}
+		mocket.Catcher.NewMock().WithExecException().WithQueryException()
 	}
 
-	defaultCluster := buildManualCluster(1, api.AllInstanceTypeSupport.String(), testKafkaRequestRegion)
-	defaultDataplaneClusterConfig := []config.ManualCluster{defaultCluster}
+	tests := []struct {
+		name           string
+		quotaType      api.QuotaType
+		wantKafkas     dbapi.KafkaList
+		wantPagingMeta *api.PagingMeta
+		wantErr        bool
+		setupFn        func()
+	}{
+		{
+			name:           "should return the kafkas created with the ams quota type",
+			quotaType:      api.AMSQuotaType,
+			wantKafkas:     dbapi.KafkaList{amsKafka},
+			wantPagingMeta: &api.PagingMeta{Page: 1, Size: 1, Total: 1},
+			setupFn:        setupQuotaTypeQueries,
+		},
+		{
+			name:           "should return the kafkas created with the quota management list quota type",
+			quotaType:      api.QuotaManagementListQuotaType,
+			wantKafkas:     dbapi.KafkaList{quotaListKafka},
+			wantPagingMeta: &api.PagingMeta{Page: 1, Size: 1, Total: 1},
+			setupFn:        setupQuotaTypeQueries,
+		},
+		{
+			name:      "should return an error if the kafkas cannot be listed",
+			quotaType: api.AMSQuotaType,
+			wantErr:   true,
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().WithQuery("SELECT").WithQueryException()
+			},
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			tt.setupFn()
+			k := &kafkaService{
+				connectionFactory: db.NewMockConnectionFactory(nil),
+			}
+
+			result, pagingMeta, err := k.ListByQuotaType(tt.quotaType.String(), &services.ListArguments{Page: 1, Size: 100})
+			g.Expect(err != nil).To(gomega.Equal(tt.wantErr))
+			if tt.wantErr {
+				return
+			}
+			g.Expect(pagingMeta).To(gomega.Equal(tt.wantPagingMeta))
+			g.Expect(result).To(gomega.HaveLen(len(tt.wantKafkas)))
+			for i, got := range result {
+				g.Expect(got.ID).To(gomega.Equal(tt.wantKafkas[i].ID))
+				g.Expect(got.QuotaType).To(gomega.Equal(tt.quotaType.String()))
+			}
+		})
+	}
+}
+
+func Test_kafkaService_ListByDesiredStrimziVersion(t *testing.T) {
+	buildKafka := func(name string, desiredStrimziVersion string) *dbapi.KafkaRequest {
+		return buildKafkaRequest(func(kafkaRequest *dbapi.KafkaRequest) {
+			kafkaRequest.ID = name
+			kafkaRequest.Name = name
+			kafkaRequest.DesiredStrimziVersion = desiredStrimziVersion
+		})
+	}
+	oldVersionKafka := buildKafka("kafka-a", "strimzi-cluster-operator.v0.23.0-0")
+	newVersionKafka := buildKafka("kafka-b", "strimzi-cluster-operator.v0.24.0-0")
+
+	setupDesiredVersionQueries := func() {
+		mocket.Catcher.Reset()
+		for _, kafka := range []*dbapi.KafkaRequest{oldVersionKafka, newVersionKafka} {
+			mocket.Catcher.NewMock().
+				WithQuery(`SELECT count(1) FROM "kafka_requests" WHERE desired_strimzi_version = $1`).
+				WithArgs(kafka.DesiredStrimziVersion).
+				WithReply([]map[string]interface{}{{"count": 1}})
+			reply := converters.ConvertKafkaRequest(kafka)
+			reply[0]["desired_strimzi_version"] = kafka.DesiredStrimziVersion
+			mocket.Catcher.NewMock().
+				WithQuery(`SELECT * FROM "kafka_requests" WHERE desired_strimzi_version = $1`).
+				WithArgs(kafka.DesiredStrimziVersion).
+				WithReply(reply)
+		}
+		mocket.Catcher.NewMock().WithExecException().WithQueryException()
+	}
+
+	tests := []struct {
+		name           string
+		version        string
+		wantKafkas     dbapi.KafkaList
+		wantPagingMeta *api.PagingMeta
+		wantErr        bool
+		setupFn        func()
+	}{
+		{
+			name:           "should return the kafkas with the given desired strimzi version",
+			version:        oldVersionKafka.DesiredStrimziVersion,
+			wantKafkas:     dbapi.KafkaList{oldVersionKafka},
+			wantPagingMeta: &api.PagingMeta{Page: 1, Size: 1, Total: 1},
+			setupFn:        setupDesiredVersionQueries,
+		},
+		{
+			name:           "should return the kafkas with another desired strimzi version",
+			version:        newVersionKafka.DesiredStrimziVersion,
+			wantKafkas:     dbapi.KafkaList{newVersionKafka},
+			wantPagingMeta: &api.PagingMeta{Page: 1, Size: 1, Total: 1},
+			setupFn:        setupDesiredVersionQueries,
+		},
+		{
+			name:    "should return an error if the version is undefined",
+			version: "",
+			wantErr: true,
+			setupFn: setupDesiredVersionQueries,
+		},
+		{
+			name:    "should return an error if the kafkas cannot be listed",
+			version: oldVersionKafka.DesiredStrimziVersion,
+			wantErr: true,
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().WithQuery("SELECT").WithQueryException()
+			},
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			tt.setupFn()
+			k := &kafkaService{
+				connectionFactory: db.NewMockConnectionFactory(nil),
+			}
+
+			result, pagingMeta, err := k.ListByDesiredStrimziVersion(tt.version, &services.ListArguments{Page: 1, Size: 100})
+			g.Expect(err != nil).To(gomega.Equal(tt.wantErr))
+			if tt.wantErr {
+				return
+			}
+			g.Expect(pagingMeta).To(gomega.Equal(tt.wantPagingMeta))
+			g.Expect(result).To(gomega.HaveLen(len(tt.wantKafkas)))
+			for i, got := range result {
+				g.Expect(got.ID).To(gomega.Equal(tt.wantKafkas[i].ID))
+				g.Expect(got.DesiredStrimziVersion).To(gomega.Equal(tt.version))
+			}
+		})
+	}
+}
+
+func Test_kafkaService_ListByBillingCloudAccountId(t *testing.T) {
+	buildKafka := func(name string, billingCloudAccountId string) *dbapi.KafkaRequest {
+		return buildKafkaRequest(func(kafkaRequest *dbapi.KafkaRequest) {
+			kafkaRequest.ID = name
+			kafkaRequest.Name = name
+			kafkaRequest.BillingModel = "marketplace"
+			kafkaRequest.Marketplace = "aws"
+			kafkaRequest.BillingCloudAccountId = billingCloudAccountId
+		})
+	}
+	firstAccountKafka := buildKafka("kafka-a", "account-a")
+	secondAccountKafka := buildKafka("kafka-b", "account-b")
+
+	setupBillingCloudAccountQueries := func() {
+		mocket.Catcher.Reset()
+		for _, kafka := range []*dbapi.KafkaRequest{firstAccountKafka, secondAccountKafka} {
+			mocket.Catcher.NewMock().
+				WithQuery(`SELECT count(1) FROM "kafka_requests" WHERE billing_cloud_account_id = $1`).
+				WithArgs(kafka.BillingCloudAccountId).
+				WithReply([]map[string]interface{}{{"count": 1}})
+			reply := converters.ConvertKafkaRequest(kafka)
+			reply[0]["billing_cloud_account_id"] = kafka.BillingCloudAccountId
+			mocket.Catcher.NewMock().
+				WithQuery(`SELECT * FROM "kafka_requests" WHERE billing_cloud_account_id = $1`).
+				WithArgs(kafka.BillingCloudAccountId).
+				WithReply(reply)
+		}
+		mocket.Catcher.NewMock().WithExecException().WithQueryException()
+	}
+
+	tests := []struct {
+		name           string
+		accountId      string
+		wantKafkas     dbapi.KafkaList
+		wantPagingMeta *api.PagingMeta
+		wantErr        bool
+		setupFn        func()
+	}{
+		{
+			name:           "should return the kafkas billed to the given cloud account",
+			accountId:      firstAccountKafka.BillingCloudAccountId,
+			wantKafkas:     dbapi.KafkaList{firstAccountKafka},
+			wantPagingMeta: &api.PagingMeta{Page: 1, Size: 1, Total: 1},
+			setupFn:        setupBillingCloudAccountQueries,
+		},
+		{
+			name:           "should return the kafkas billed to another cloud account",
+			accountId:      secondAccountKafka.BillingCloudAccountId,
+			wantKafkas:     dbapi.KafkaList{secondAccountKafka},
+			wantPagingMeta: &api.PagingMeta{Page: 1, Size: 1, Total: 1},
+			setupFn:        setupBillingCloudAccountQueries,
+		},
+		{
+			name:    "should return an error if the cloud account id is undefined",
+			wantErr: true,
+			setupFn: setupBillingCloudAccountQueries,
+		},
+		{
+			name:      "should return an error if the kafkas cannot be listed",
+			accountId: firstAccountKafka.BillingCloudAccountId,
+			wantErr:   true,
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().WithQuery("SELECT").WithQueryException()
+			},
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			tt.setupFn()
+			k := &kafkaService{
+				connectionFactory: db.NewMockConnectionFactory(nil),
+			}
+
+			result, pagingMeta, err := k.ListByBillingCloudAccountId(tt.accountId, &services.ListArguments{Page: 1, Size: 100})
+			g.Expect(err != nil).To(gomega.Equal(tt.wantErr))
+			if tt.wantErr {
+				return
+			}
+			g.Expect(pagingMeta).To(gomega.Equal(tt.wantPagingMeta))
+			g.Expect(result).To(gomega.HaveLen(len(tt.wantKafkas)))
+			for i, got := range result {
+				g.Expect(got.ID).To(gomega.Equal(tt.wantKafkas[i].ID))
+				g.Expect(got.BillingCloudAccountId).To(gomega.Equal(tt.accountId))
+			}
+		})
+	}
+}
+
+func Test_kafkaService_BumpStrimziVersion(t *testing.T) {
+	const targetVersion = "strimzi-cluster-operator.v0.24.0-0"
+	buildKafka := func(id string, clusterID string, kafkaVersion string) *dbapi.KafkaRequest {
+		return buildKafkaRequest(func(kafkaRequest *dbapi.KafkaRequest) {
+			kafkaRequest.ID = id
+			kafkaRequest.ClusterID = clusterID
+			kafkaRequest.DesiredStrimziVersion = "strimzi-cluster-operator.v0.23.0-0"
+			kafkaRequest.DesiredKafkaVersion = kafkaVersion
+			kafkaRequest.DesiredKafkaIBPVersion = "2.7"
+		})
+	}
+	reply := func(kafkas ...*dbapi.KafkaRequest) []map[string]interface{} {
+		var rows []map[string]interface{}
+		for _, kafka := range kafkas {
+			row := converters.ConvertKafkaRequest(kafka)[0]
+			row["desired_strimzi_version"] = kafka.DesiredStrimziVersion
+			row["desired_kafka_version"] = kafka.DesiredKafkaVersion
+			row["desired_kafka_ibp_version"] = kafka.DesiredKafkaIBPVersion
+			row["maintenance_window_day"] = kafka.MaintenanceWindowDay
+			row["maintenance_window_start"] = kafka.MaintenanceWindowStart
+			row["maintenance_window_end"] = kafka.MaintenanceWindowEnd
+			rows = append(rows, row)
+		}
+		return rows
+	}
+	// the maintenance window of the kafka is tomorrow, so that the bump is never within it
+	outsideMaintenanceWindow := buildKafka("outside-maintenance-window", "cluster-a", "2.7.0")
+	outsideMaintenanceWindow.MaintenanceWindowDay = strings.ToLower(time.Now().UTC().AddDate(0, 0, 1).Weekday().String())
+	outsideMaintenanceWindow.MaintenanceWindowStart = "00:00"
+	outsideMaintenanceWindow.MaintenanceWindowEnd = "24:00"
+	// the target version is only available with kafka 2.7.0 in cluster-a
+	clusterService := &ClusterServiceMock{
+		FindClusterByIDFunc: func(clusterID string) (*api.Cluster, *errors.ServiceError) {
+			if clusterID == "unknown-cluster" {
+				return nil, nil
+			}
+			return &api.Cluster{ClusterID: clusterID}, nil
+		},
+		IsStrimziKafkaVersionAvailableInClusterFunc: func(cluster *api.Cluster, strimziVersion, kafkaVersion, ibpVersion string) (bool, error) {
+			return cluster.ClusterID == "cluster-a" && strimziVersion == targetVersion && kafkaVersion == "2.7.0", nil
+		},
+	}
+
+	tests := []struct {
+		name         string
+		ids          []string
+		version      string
+		kafkas       []*dbapi.KafkaRequest
+		wantBumped   int64
+		wantUpdated  []interface{}
+		wantErr      bool
+		findClusters func(clusterID string) (*api.Cluster, *errors.ServiceError)
+	}{
+		{
+			name:    "should only bump the kafkas whose cluster has the target version available",
+			ids:     []string{"eligible", "unsupported-kafka-version", "other-cluster", "unknown-cluster", "unassigned", "outside-maintenance-window"},
+			version: targetVersion,
+			kafkas: []*dbapi.KafkaRequest{
+				buildKafka("eligible", "cluster-a", "2.7.0"),
+				buildKafka("unsupported-kafka-version", "cluster-a", "2.6.0"),
+				buildKafka("other-cluster", "cluster-b", "2.7.0"),
+				buildKafka("unknown-cluster", "unknown-cluster", "2.7.0"),
+				buildKafka("unassigned", "", "2.7.0"),
+				outsideMaintenanceWindow,
+			},
+			wantBumped:  1,
+			wantUpdated: []interface{}{targetVersion, "eligible"},
+		},
+		{
+			name:    "should not bump any kafka when none is eligible",
+			ids:     []string{"other-cluster"},
+			version: targetVersion,
+			kafkas: []*dbapi.KafkaRequest{
+				buildKafka("other-cluster", "cluster-b", "2.7.0"),
+			},
+			wantBumped: 0,
+		},
+		{
+			name:    "should return an error when the target version is undefined",
+			ids:     []string{"eligible"},
+			version: "",
+			wantErr: true,
+		},
+		{
+			name:    "should return an error when a cluster cannot be looked up",
+			ids:     []string{"eligible"},
+			version: targetVersion,
+			kafkas: []*dbapi.KafkaRequest{
+				buildKafka("eligible", "cluster-a", "2.7.0"),
+			},
+			wantErr: true,
+			findClusters: func(clusterID string) (*api.Cluster, *errors.ServiceError) {
+				return nil, errors.GeneralError("failed to find cluster")
+			},
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+
+			var updated []interface{}
+			var updateQuery string
+			mocket.Catcher.Reset()
+			mocket.Catcher.NewMock().
+				WithQuery(`SELECT * FROM "kafka_requests" WHERE id IN (`).
+				WithReply(reply(tt.kafkas...))
+			mocket.Catcher.NewMock().
+				WithQuery(`UPDATE "kafka_requests" SET "desired_strimzi_version"=$1`).
+				WithCallback(func(query string, args []driver.NamedValue) {
+					updateQuery = query
+					for _, arg := range args {
+						updated = append(updated, arg.Value)
+					}
+				}).
+				WithRowsNum(tt.wantBumped)
+			mocket.Catcher.NewMock().WithExecException().WithQueryException()
+
+			k := &kafkaService{
+				connectionFactory: db.NewMockConnectionFactory(nil),
+				clusterService:    clusterService,
+			}
+			if tt.findClusters != nil {
+				k.clusterService = &ClusterServiceMock{FindClusterByIDFunc: tt.findClusters}
+			}
+
+			bumped, err := k.BumpStrimziVersion(tt.ids, tt.version)
+			g.Expect(err != nil).To(gomega.Equal(tt.wantErr))
+			g.Expect(bumped).To(gomega.Equal(tt.wantBumped))
+			if tt.wantUpdated == nil {
+				g.Expect(updated).To(gomega.BeEmpty())
+				return
+			}
+			g.Expect(updated).To(gomega.ContainElements(tt.wantUpdated...))
+			// the kafkas deleted since they have been read are not bumped
+			g.Expect(updateQuery).To(gomega.ContainSubstring("status NOT IN"))
+			for _, id := range []string{"unsupported-kafka-version", "other-cluster", "unknown-cluster", "unassigned", "outside-maintenance-window"} {
+				g.Expect(updated).ToNot(gomega.ContainElement(id))
+			}
+		})
+	}
+}
+
+func Test_kafkaService_ListReauthDisabled(t *testing.T) {
+	buildKafka := func(name string, reauthenticationEnabled bool) *dbapi.KafkaRequest {
+		return buildKafkaRequest(func(kafkaRequest *dbapi.KafkaRequest) {
+			kafkaRequest.ID = name
+			kafkaRequest.Name = name
+			kafkaRequest.OrganisationId = "org-" + name
+			kafkaRequest.ReauthenticationEnabled = reauthenticationEnabled
+		})
+	}
+	reauthEnabledKafka := buildKafka("kafka-a", true)
+	reauthDisabledKafka := buildKafka("kafka-b", false)
+
+	toReply := func(kafkas ...*dbapi.KafkaRequest) []map[string]interface{} {
+		reply := converters.ConvertKafkaRequestList(kafkas)
+		for i, kafka := range kafkas {
+			reply[i]["organisation_id"] = kafka.OrganisationId
+			reply[i]["reauthentication_enabled"] = kafka.ReauthenticationEnabled
+		}
+		return reply
+	}
+
+	tests := []struct {
+		name           string
+		wantKafkas     dbapi.KafkaList
+		wantPagingMeta *api.PagingMeta
+		wantErr        bool
+		setupFn        func()
+	}{
+		{
+			name:           "should only return the kafkas with reauthentication disabled",
+			wantKafkas:     dbapi.KafkaList{reauthDisabledKafka},
+			wantPagingMeta: &api.PagingMeta{Page: 1, Size: 1, Total: 1},
+			setupFn: func() {
+				mocket.Catcher.Reset()
+				mocket.Catcher.NewMock().
+					WithQuery(`SELECT count(1) FROM "kafka_requests" WHERE reauthentication_enabled = $1`).
+					WithArgs(false).
+					WithReply([]map[string]interface{}{{"count": 1}})
+				mocket.Catcher.NewMock().
+					WithQuery(`SELECT * FROM "kafka_requests" WHERE reauthentication_enabled = $1`).
+					WithArgs(false).
+					WithReply(toReply(reauthDisabledKafka))
+				// the kafkas with reauthentication enabled are returned by any query not filtering them out
+				mocket.Catcher.NewMock().
+					WithQuery(`SELECT * FROM "kafka_requests"`).
+					WithReply(toReply(reauthEnabledKafka, reauthDisabledKafka))
+				mocket.Catcher.NewMock().WithExecException().WithQueryException()
+			},
+		},
+		{
+			name:    "should return an error if the kafkas cannot be listed",
+			wantErr: true,
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().WithQuery("SELECT").WithQueryException()
+			},
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			tt.setupFn()
+			k := &kafkaService{
+				connectionFactory: db.NewMockConnectionFactory(nil),
+			}
+
+			result, pagingMeta, err := k.ListReauthDisabled(&services.ListArguments{Page: 1, Size: 100})
+			g.Expect(err != nil).To(gomega.Equal(tt.wantErr))
+			if tt.wantErr {
+				return
+			}
+			g.Expect(pagingMeta).To(gomega.Equal(tt.wantPagingMeta))
+			g.Expect(result).To(gomega.HaveLen(len(tt.wantKafkas)))
+			for i, got := range result {
+				g.Expect(got.ID).To(gomega.Equal(tt.wantKafkas[i].ID))
+				g.Expect(got.ReauthenticationEnabled).To(gomega.BeFalse())
+				g.Expect(got.Owner).To(gomega.Equal(tt.wantKafkas[i].Owner))
+				g.Expect(got.OrganisationId).To(gomega.Equal(tt.wantKafkas[i].OrganisationId))
+			}
+		})
+	}
+}
+
+func Test_kafkaService_ListByCreatedRange(t *testing.T) {
+	to := time.Now()
+	from := to.Add(-24 * time.Hour)
+
+	buildKafka := func(name string, createdAt time.Time) *dbapi.KafkaRequest {
+		return buildKafkaRequest(func(kafkaRequest *dbapi.KafkaRequest) {
+			kafkaRequest.ID = name
+			kafkaRequest.Name = name
+			kafkaRequest.CreatedAt = createdAt
+		})
+	}
+	olderKafka := buildKafka("kafka-a", from.Add(-time.Hour))
+	inRangeKafka := buildKafka("kafka-b", from.Add(time.Hour))
+	newerKafka := buildKafka("kafka-c", to.Add(time.Hour))
+
+	tests := []struct {
+		name           string
+		from           time.Time
+		to             time.Time
+		wantKafkas     dbapi.KafkaList
+		wantPagingMeta *api.PagingMeta
+		wantErr        bool
+		setupFn        func()
+	}{
+		{
+			name:           "should only return the kafkas created within the range",
+			from:           from,
+			to:             to,
+			wantKafkas:     dbapi.KafkaList{inRangeKafka},
+			wantPagingMeta: &api.PagingMeta{Page: 1, Size: 1, Total: 1},
+			setupFn: func() {
+				mocket.Catcher.Reset()
+				mocket.Catcher.NewMock().
+					WithQuery(`SELECT count(1) FROM "kafka_requests" WHERE (created_at BETWEEN $1 AND $2)`).
+					WithArgs(from, to).
+					WithReply([]map[string]interface{}{{"count": 1}})
+				mocket.Catcher.NewMock().
+					WithQuery(`SELECT * FROM "kafka_requests" WHERE (created_at BETWEEN $1 AND $2)`).
+					WithArgs(from, to).
+					WithReply(converters.ConvertKafkaRequest(inRangeKafka))
+				// the kafkas out of the range are returned by any query not filtering them out
+				mocket.Catcher.NewMock().
+					WithQuery(`SELECT * FROM "kafka_requests"`).
+					WithReply(converters.ConvertKafkaRequestList(dbapi.KafkaList{olderKafka, inRangeKafka, newerKafka}))
+				mocket.Catcher.NewMock().WithExecException().WithQueryException()
+			},
+		},
+		{
+			name:    "should return an error if the start of the range is after its end",
+			from:    to,
+			to:      from,
+			wantErr: true,
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().WithExecException().WithQueryException()
+			},
+		},
+		{
+			name:    "should return an error if the kafkas cannot be listed",
+			from:    from,
+			to:      to,
+			wantErr: true,
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().WithQuery("SELECT").WithQueryException()
+			},
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			tt.setupFn()
+			k := &kafkaService{
+				connectionFactory: db.NewMockConnectionFactory(nil),
+			}
+
+			result, pagingMeta, err := k.ListByCreatedRange(tt.from, tt.to, &services.ListArguments{Page: 1, Size: 100})
+			g.Expect(err != nil).To(gomega.Equal(tt.wantErr))
+			if tt.wantErr {
+				return
+			}
+			g.Expect(pagingMeta).To(gomega.Equal(tt.wantPagingMeta))
+			g.Expect(result).To(gomega.HaveLen(len(tt.wantKafkas)))
+			for i, got := range result {
+				g.Expect(got.ID).To(gomega.Equal(tt.wantKafkas[i].ID))
+			}
+		})
+	}
+}
+
+func Test_kafkaService_StreamAll(t *testing.T) {
+	ids := []string{"kafka-1", "kafka-2", "kafka-3"}
+	replyFor := func(ids ...string) []map[string]interface{} {
+		reply := []map[string]interface{}{}
+		for _, id := range ids {
+			reply = append(reply, map[string]interface{}{"id": id})
+		}
+		return reply
+	}
+
+	tests := []struct {
+		name        string
+		batchSize   int
+		cancelAfter int
+		wantVisited []string
+		wantErr     bool
+		setupFn     func()
+	}{
+		{
+			name:        "should return an error when the batch size is not positive",
+			batchSize:   0,
+			wantVisited: []string{},
+			wantErr:     true,
+		},
+		{
+			name:        "should visit all the kafka requests across batches",
+			batchSize:   2,
+			wantVisited: ids,
+			setupFn: func() {
+				mocket.Catcher.Reset()
+				// the mock of the second batch is registered first as the mock of the first batch matches both queries
+				mocket.Catcher.NewMock().
+					WithQuery(`"kafka_requests"."id" > $1`).
+					WithArgs(ids[1]).
+					WithReply(replyFor(ids[2]))
+				mocket.Catcher.NewMock().
+					WithQuery(`SELECT * FROM "kafka_requests"`).
+					WithReply(replyFor(ids[0], ids[1]))
+				mocket.Catcher.NewMock().WithExecException().WithQueryException()
+			},
+		},
+		{
+			name:        "should stop visiting the kafka requests when the context is cancelled",
+			batchSize:   2,
+			cancelAfter: 1,
+			wantVisited: ids[:1],
+			wantErr:     true,
+			setupFn: func() {
+				mocket.Catcher.Reset()
+				mocket.Catcher.NewMock().
+					WithQuery(`"kafka_requests"."id" > $1`).
+					WithArgs(ids[1]).
+					WithReply(replyFor(ids[2]))
+				mocket.Catcher.NewMock().
+					WithQuery(`SELECT * FROM "kafka_requests"`).
+					WithReply(replyFor(ids[0], ids[1]))
+				mocket.Catcher.NewMock().WithExecException().WithQueryException()
+			},
+		},
+		{
+			name:        "should return an error when the kafka requests cannot be read",
+			batchSize:   2,
+			wantVisited: []string{},
+			wantErr:     true,
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().WithQuery("SELECT").WithQueryException()
+			},
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			if tt.setupFn != nil {
+				tt.setupFn()
+			}
+			k := &kafkaService{
+				connectionFactory: db.NewMockConnectionFactory(nil),
+			}
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			visited := []string{}
+			err := k.StreamAll(ctx, tt.batchSize, func(kafka *dbapi.KafkaRequest) error {
+				visited = append(visited, kafka.ID)
+				if len(visited) == tt.cancelAfter {
+					cancel()
+				}
+				return nil
+			})
+			g.Expect(err != nil).To(gomega.Equal(tt.wantErr))
+			g.Expect(visited).To(gomega.Equal(tt.wantVisited))
+		})
+	}
+}
+
+func Test_kafkaService_ListByStatus(t *testing.T) {
+	type fields struct {
+		connectionFactory *db.ConnectionFactory
+		clusterService    ClusterService
+	}
+	type args struct {
+		status constants2.KafkaStatus
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    []*dbapi.KafkaRequest
+		wantErr bool
+		setupFn func()
+	}{
+		{
+			name: "fail when database returns an error",
+			fields: fields{
+				connectionFactory: db.NewMockConnectionFactory(nil),
+			},
+			wantErr: true,
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().WithQuery("SELECT").WithQueryException()
+				mocket.Catcher.NewMock().WithExecException().WithQueryException()
+			},
+		},
+		{
+			name: "success",
+			fields: fields{
+				connectionFactory: db.NewMockConnectionFactory(nil),
+			},
+			want: []*dbapi.KafkaRequest{buildKafkaRequest(nil)},
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().
+					WithQuery(`SELECT * FROM "kafka_requests" WHERE status IN ($1)`).
+					WithArgs("").
+					WithReply(converters.ConvertKafkaRequest(buildKafkaRequest(nil)))
+				mocket.Catcher.NewMock().WithExecException().WithQueryException()
+			},
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			tt.setupFn()
+			k := &kafkaService{
+				connectionFactory: tt.fields.connectionFactory,
+				clusterService:    tt.fields.clusterService,
+				kafkaConfig:       config.NewKafkaConfig(),
+				awsConfig:         config.NewAWSConfig(),
+			}
+			got, err := k.ListByStatus(tt.args.status)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("kafkaService.ListByStatus() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			g.Expect(got).To(gomega.Equal(tt.want))
+		})
+	}
+}
+
+func Test_kafkaService_UpdateStatus(t *testing.T) {
+	type fields struct {
+		connectionFactory *db.ConnectionFactory
+		clusterService    ClusterService
+	}
+	type args struct {
+		id     string
+		status constants2.KafkaStatus
+	}
+	tests := []struct {
+		name         string
+		fields       fields
+		args         args
+		wantErr      bool
+		wantExecuted bool
+		setupFn      func()
+	}{
+		{
+			name:         "fail when database returns an error",
+			wantExecuted: true,
+			fields: fields{
+				connectionFactory: db.NewMockConnectionFactory(nil),
+			},
+			wantErr: true,
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().WithQuery("UPDATE").WithExecException()
+			},
+		},
+		{
+			name: "refuse execution because cluster in deprovisioning state",
+			fields: fields{
+				connectionFactory: db.NewMockConnectionFactory(nil),
+			},
+			wantErr:      true,
+			wantExecuted: false,
+			setupFn: func() {
+				mocket.Catcher.NewMock().
+					WithQuery(`SELECT * FROM "kafka_requests" WHERE id = $1`).
+					WithArgs(testID).
+					WithReply(converters.ConvertKafkaRequest(buildKafkaRequest(func(kafkaRequest *dbapi.KafkaRequest) {
+						kafkaRequest.Status = constants2.KafkaRequestStatusDeprovision.String()
+					})))
+				mocket.Catcher.NewMock().WithExecException().WithQueryException()
+			},
+			args: args{
+				id:     testID,
+				status: constants2.KafkaRequestStatusPreparing,
+			},
+		},
+		{
+			name: "success when because cluster in deprovisioning state but status to update is deleted ",
+			fields: fields{
+				connectionFactory: db.NewMockConnectionFactory(nil),
+			},
+			wantErr:      false,
+			wantExecuted: true,
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().WithQuery(`UPDATE "kafka_requests" SET "status"=$1`)
+				mocket.Catcher.NewMock().
+					WithQuery(`SELECT * FROM "kafka_requests" WHERE id = $1`).
+					WithArgs(testID).
+					WithReply(converters.ConvertKafkaRequest(buildKafkaRequest(func(kafkaRequest *dbapi.KafkaRequest) {
+						kafkaRequest.Status = constants2.KafkaRequestStatusDeprovision.String()
+					})))
+				mocket.Catcher.NewMock().WithExecException().WithQueryException()
+			},
+			args: args{
+				id:     testID,
+				status: constants2.KafkaRequestStatusDeleting,
+			},
+		},
+		{
+			name:         "success",
+			wantExecuted: true,
+			fields: fields{
+				connectionFactory: db.NewMockConnectionFactory(nil),
+			},
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().
+					WithQuery(`SELECT * FROM "kafka_requests" WHERE id = $1`).
+					WithArgs(testID).
+					WithReply(converters.ConvertKafkaRequest(buildKafkaRequest(func(kafkaRequest *dbapi.KafkaRequest) {
+						kafkaRequest.Status = constants2.KafkaRequestStatusPreparing.String()
+					})))
+				mocket.Catcher.NewMock().WithQuery(`UPDATE "kafka_requests" SET "status"=$1`)
+				mocket.Catcher.NewMock().WithExecException().WithQueryException()
+			},
+			args: args{
+				id: testID,
+			},
+		},
+	}
+	for _, testcase := range tests {
+		tt := testcase
+
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupFn()
+			k := kafkaService{
+				connectionFactory: tt.fields.connectionFactory,
+				clusterService:    tt.fields.clusterService,
+				kafkaConfig:       config.NewKafkaConfig(),
+				awsConfig:         config.NewAWSConfig(),
+			}
+			executed, err := k.UpdateStatus(tt.args.id, tt.args.status)
+			if executed != tt.wantExecuted {
+				t.Error("kafkaService.UpdateStatus() error = should have refused execution but didn't")
+				return
+			}
+			if (err != nil) != tt.wantErr {
+				t.Errorf("kafkaService.UpdateStatus() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+		})
+	}
+}
+
+func Test_kafkaService_UpdateStatus_StatusSinceCreatedMetric(t *testing.T) {
+	statusSinceCreatedMetric := metrics.KasFleetManager + "_" + metrics.KafkaRequestsStatusSinceCreated
+
+	tests := []struct {
+		name          string
+		currentStatus constants2.KafkaStatus
+		status        constants2.KafkaStatus
+		wantMetrics   int
+	}{
+		{
+			name:          "should update the metric when the status changes",
+			currentStatus: constants2.KafkaRequestStatusPreparing,
+			status:        constants2.KafkaRequestStatusProvisioning,
+			wantMetrics:   1,
+		},
+		{
+			name:          "should not update the metric when the status does not change",
+			currentStatus: constants2.KafkaRequestStatusProvisioning,
+			status:        constants2.KafkaRequestStatusProvisioning,
+			wantMetrics:   0,
+		},
+		{
+			name:          "should not update the metric when the kafka is deprovisioning",
+			currentStatus: constants2.KafkaRequestStatusDeprovision,
+			status:        constants2.KafkaRequestStatusProvisioning,
+			wantMetrics:   0,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			metrics.Reset()
+
+			mocket.Catcher.Reset().NewMock().
+				WithQuery(`SELECT * FROM "kafka_requests" WHERE id = $1`).
+				WithArgs(testID).
+				WithReply(converters.ConvertKafkaRequest(buildKafkaRequest(func(kafkaRequest *dbapi.KafkaRequest) {
+					kafkaRequest.Status = tt.currentStatus.String()
+				})))
+			mocket.Catcher.NewMock().WithQuery(`UPDATE "kafka_requests" SET "status"=$1`)
+			mocket.Catcher.NewMock().WithExecException().WithQueryException()
+
+			k := kafkaService{
+				connectionFactory: db.NewMockConnectionFactory(nil),
+				kafkaConfig:       config.NewKafkaConfig(),
+			}
+			_, _ = k.UpdateStatus(testID, tt.status)
+
+			count, err := testutil.GatherAndCount(prometheus.DefaultGatherer, statusSinceCreatedMetric)
+			g.Expect(err).ToNot(gomega.HaveOccurred())
+			g.Expect(count).To(gomega.Equal(tt.wantMetrics))
+		})
+	}
+}
+
+func Test_kafkaService_Update(t *testing.T) {
+	type fields struct {
+		connectionFactory *db.ConnectionFactory
+		clusterService    ClusterService
+	}
+	type args struct {
+		kafkaRequest *dbapi.KafkaRequest
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		wantErr bool
+		setupFn func()
+	}{
+		{
+			name: "fail when database returns an error",
+			args: args{
+				kafkaRequest: buildKafkaRequest(nil),
+			},
+			fields: fields{
+				connectionFactory: db.NewMockConnectionFactory(nil),
+			},
+			wantErr: true,
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().WithQuery("UPDATE").WithExecException()
+				mocket.Catcher.NewMock().WithExecException().WithQueryException()
+			},
+		},
+		{
+			name: "success",
+			args: args{
+				kafkaRequest: buildKafkaRequest(nil),
+			},
+			fields: fields{
+				connectionFactory: db.NewMockConnectionFactory(nil),
+			},
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().WithQuery(`UPDATE "kafka_requests"`)
+				mocket.Catcher.NewMock().WithQueryException().WithExecException()
+			},
+		},
+	}
+	for _, testcase := range tests {
+		tt := testcase
+
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupFn()
+			k := kafkaService{
+				connectionFactory: tt.fields.connectionFactory,
+				clusterService:    tt.fields.clusterService,
+				kafkaConfig:       config.NewKafkaConfig(),
+				awsConfig:         config.NewAWSConfig(),
+			}
+			err := k.Update(tt.args.kafkaRequest)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("kafkaService.Update() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+		})
+	}
+}
+
+func Test_kafkaService_Updates(t *testing.T) {
+	type fields struct {
+		connectionFactory *db.ConnectionFactory
+		clusterService    ClusterService
+	}
+	type args struct {
+		kafkaRequest *dbapi.KafkaRequest
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		wantErr bool
+		setupFn func()
+	}{
+		{
+			name: "fail when database returns an error",
+			args: args{
+				kafkaRequest: buildKafkaRequest(nil),
+			},
+			fields: fields{
+				connectionFactory: db.NewMockConnectionFactory(nil),
+			},
+			wantErr: true,
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().WithQuery("UPDATE").WithExecException()
+				mocket.Catcher.NewMock().WithExecException().WithQueryException()
+			},
+		},
+		{
+			name: "success",
+			args: args{
+				kafkaRequest: buildKafkaRequest(nil),
+			},
+			fields: fields{
+				connectionFactory: db.NewMockConnectionFactory(nil),
+			},
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().WithQuery(`UPDATE "kafka_requests"`)
+				mocket.Catcher.NewMock().WithQueryException().WithExecException()
+			},
+		},
+	}
+	for _, testcase := range tests {
+		tt := testcase
+
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupFn()
+			k := kafkaService{
+				connectionFactory: tt.fields.connectionFactory,
+				clusterService:    tt.fields.clusterService,
+				kafkaConfig:       config.NewKafkaConfig(),
+				awsConfig:         config.NewAWSConfig(),
+			}
+			err := k.Updates(tt.args.kafkaRequest, map[string]interface{}{
+				"id":    "idsds",
+				"owner": "",
+			})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("kafkaService.Update() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+		})
+	}
+}
+
+func Test_kafkaService_DeprovisionKafkaForUsers(t *testing.T) {
+	type fields struct {
+		connectionFactory *db.ConnectionFactory
+	}
+	type args struct {
+		users []string
+	}
+
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		wantErr bool
+		setupFn func()
+	}{
+		{
+			name: "should receive error when update fails",
+			fields: fields{
+				connectionFactory: db.NewMockConnectionFactory(nil),
+			},
+			wantErr: true,
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().WithQuery("UPDATE").WithError(fmt.Errorf("some update error"))
+				mocket.Catcher.NewMock().WithExecException().WithQueryException()
+			},
+			args: args{users: []string{"user"}},
+		},
+		{
+			name: "should not receive error when update succeed",
+			fields: fields{
+				connectionFactory: db.NewMockConnectionFactory(nil),
+			},
+			wantErr: false,
+			args:    args{users: []string{"user"}},
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().WithQuery(`UPDATE "kafka_requests" SET "deprovision_reason"`)
+				mocket.Catcher.NewMock().WithExecException().WithQueryException()
+			},
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			tt.setupFn()
+			k := kafkaService{
+				connectionFactory: tt.fields.connectionFactory,
+			}
+			err := k.DeprovisionKafkaForUsers(tt.args.users)
+			g.Expect(err != nil).To(gomega.Equal(tt.wantErr))
+		})
+	}
+}
+
+func Test_kafkaService_DeprovisionExpiredKafkas(t *testing.T) {
+	type fields struct {
+		connectionFactory *db.ConnectionFactory
+	}
+
+	const instanceType = "type1"
+	const instanceSize = "size4"
+
+	tests := []struct {
+		name    string
+		fields  fields
+		wantErr bool
+		setupFn func()
+	}{
+		{
+			name: "fail when database update throws an error",
+			fields: fields{
+				connectionFactory: db.NewMockConnectionFactory(nil),
+			},
+			wantErr: true,
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().WithQuery(`SELECT * FROM "kafka_requests" WHERE instance_type IN ($1) AND status NOT IN ($2,$3)`).WithReply([]map[string]interface{}{{"id": "kafkainstance1", "instance_type": instanceType, "size_id": instanceSize}})
+				mocket.Catcher.NewMock().WithQuery(`UPDATE "kafka_requests" SET "deprovision_reason"=$1,"status"=$2,"status_updated_at"=$3,"updated_at"=$4 WHERE id IN ($5)`).WithError(fmt.Errorf("an update error"))
+				mocket.Catcher.NewMock().WithExecException().WithQueryException()
+			},
+		},
+		{
+			name: "success when database does not throw an error",
+			fields: fields{
+				connectionFactory: db.NewMockConnectionFactory(nil),
+			},
+			wantErr: false,
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().WithQuery(`SELECT * FROM "kafka_requests" WHERE instance_type IN ($1) AND status NOT IN ($2,$3)`).WithReply([]map[string]interface{}{{"id": "kafkainstance1", "instance_type": instanceType, "size_id": instanceSize}})
+				mocket.Catcher.NewMock().WithQuery(`UPDATE "kafka_requests" SET "deprovision_reason"=$1,"status"=$2,"status_updated_at"=$3,"updated_at"=$4 WHERE id IN ($5)`)
+				mocket.Catcher.NewMock().WithExecException().WithQueryException()
+			},
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			if tt.setupFn != nil {
+				tt.setupFn()
+			}
+			k := &kafkaService{
+				connectionFactory: tt.fields.connectionFactory,
+				kafkaConfig:       config.NewKafkaConfig(),
+			}
+			k.kafkaConfig.SupportedInstanceTypes.Configuration = config.SupportedKafkaInstanceTypesConfig{
+				SupportedKafkaInstanceTypes: []config.KafkaInstanceType{
+					{
+						Id: instanceType,
+						Sizes: []config.KafkaInstanceSize{
+							{Id: "size1"},
+							{Id: instanceSize, LifespanSeconds: &[]int{1234}[0]},
+						},
+					},
+				},
+			}
+			err := k.DeprovisionExpiredKafkas()
+			g.Expect(err != nil).To(gomega.Equal(tt.wantErr))
+		})
+	}
+}
+
+func Test_kafkaService_DeprovisionExpiredKafkas_OrganisationLifespan(t *testing.T) {
+	const instanceType = "developer"
+	const instanceSize = "x1"
+
+	tests := []struct {
+		name           string
+		organisationId string
+		wantExpired    bool
+	}{
+		{
+			name:           "should not deprovision the kafka of an organisation with an extended lifespan",
+			organisationId: "extended-org",
+			wantExpired:    false,
+		},
+		{
+			name:           "should deprovision the kafka of an organisation using the size lifespan",
+			organisationId: "other-org",
+			wantExpired:    true,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			expired := false
+			mocket.Catcher.Reset().NewMock().
+				WithQuery(`SELECT * FROM "kafka_requests" WHERE instance_type IN ($1) AND status NOT IN ($2,$3)`).
+				WithReply([]map[string]interface{}{{
+					"id":              "kafkainstance1",
+					"instance_type":   instanceType,
+					"size_id":         instanceSize,
+					"organisation_id": tt.organisationId,
+					// older than the size lifespan but not than the extended lifespan
+					"created_at": time.Now().Add(-3 * time.Hour),
+				}})
+			mocket.Catcher.NewMock().
+				WithQuery(`UPDATE "kafka_requests" SET "deprovision_reason"=$1,"status"=$2,"status_updated_at"=$3,"updated_at"=$4 WHERE id IN ($5)`).
+				WithCallback(func(_ string, _ []driver.NamedValue) {
+					expired = true
+				})
+			mocket.Catcher.NewMock().WithExecException().WithQueryException()
+
+			k := &kafkaService{
+				connectionFactory: db.NewMockConnectionFactory(nil),
+				kafkaConfig:       config.NewKafkaConfig(),
+			}
+			k.kafkaConfig.KafkaLifespan.OrganisationLifespanSeconds = map[string]int{"extended-org": 86400}
+			k.kafkaConfig.SupportedInstanceTypes.Configuration = config.SupportedKafkaInstanceTypesConfig{
+				SupportedKafkaInstanceTypes: []config.KafkaInstanceType{
+					{
+						Id: instanceType,
+						Sizes: []config.KafkaInstanceSize{
+							{Id: instanceSize, LifespanSeconds: &[]int{3600}[0]},
+						},
+					},
+				},
+			}
+
+			g.Expect(k.DeprovisionExpiredKafkas()).To(gomega.BeNil())
+			g.Expect(expired).To(gomega.Equal(tt.wantExpired))
+		})
+	}
+}
+
+func Test_kafkaService_GetBillingModel(t *testing.T) {
+	authHelper, err := auth.NewAuthHelper(JwtKeyFile, JwtCAFile, "")
+	if err != nil {
+		t.Fatalf("failed to create auth helper: %s", err.Error())
+	}
+	account, err := authHelper.NewAccount(testUser, "", "", "")
+	if err != nil {
+		t.Fatal("failed to build a new account")
+	}
+	jwt, err := authHelper.CreateJWTWithClaims(account, nil)
+	if err != nil {
+		t.Fatalf("failed to create jwt: %s", err.Error())
+	}
+	authenticatedCtx := auth.SetTokenInContext(context.TODO(), jwt)
+
+	tests := []struct {
+		name        string
+		kafka       *dbapi.KafkaRequest
+		want        *KafkaBillingModel
+		wantErrCode errors.ServiceErrorCode
+	}{
+		{
+			name: "should return the standard billing model of a kafka billed through AMS standard quota",
+			kafka: buildKafkaRequest(func(kafkaRequest *dbapi.KafkaRequest) {
+				kafkaRequest.QuotaType = api.AMSQuotaType.String()
+				kafkaRequest.BillingModel = "standard"
+			}),
+			want: &KafkaBillingModel{
+				QuotaType:    api.AMSQuotaType.String(),
+				BillingModel: "standard",
+			},
+		},
+		{
+			name: "should return the standard billing model of a kafka with quota from the quota management list",
+			kafka: buildKafkaRequest(func(kafkaRequest *dbapi.KafkaRequest) {
+				kafkaRequest.QuotaType = api.QuotaManagementListQuotaType.String()
+			}),
+			want: &KafkaBillingModel{
+				QuotaType:    api.QuotaManagementListQuotaType.String(),
+				BillingModel: "standard",
+			},
+		},
+		{
+			name: "should return the AWS marketplace billing model of a kafka billed through the AWS marketplace",
+			kafka: buildKafkaRequest(func(kafkaRequest *dbapi.KafkaRequest) {
+				kafkaRequest.QuotaType = api.AMSQuotaType.String()
+				kafkaRequest.BillingModel = "marketplace"
+				kafkaRequest.Marketplace = "aws"
+				kafkaRequest.BillingCloudAccountId = "aws-account-id"
+			}),
+			want: &KafkaBillingModel{
+				QuotaType:             api.AMSQuotaType.String(),
+				BillingModel:          "marketplace-aws",
+				Marketplace:           "aws",
+				BillingCloudAccountId: "aws-account-id",
+			},
+		},
+		{
+			name: "should return the Azure marketplace billing model of a kafka billed through the Azure marketplace",
+			kafka: buildKafkaRequest(func(kafkaRequest *dbapi.KafkaRequest) {
+				kafkaRequest.QuotaType = api.AMSQuotaType.String()
+				kafkaRequest.BillingModel = "marketplace"
+				kafkaRequest.Marketplace = "azure"
+				kafkaRequest.BillingCloudAccountId = "azure-account-id"
+			}),
+			want: &KafkaBillingModel{
+				QuotaType:             api.AMSQuotaType.String(),
+				BillingModel:          "marketplace-azure",
+				Marketplace:           "azure",
+				BillingCloudAccountId: "azure-account-id",
+			},
+		},
+		{
+			name:        "should return a not found error when the kafka is not visible to the caller",
+			wantErrCode: errors.ErrorNotFound,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+
+			reply := []map[string]interface{}{}
+			if tt.kafka != nil {
+				reply = converters.ConvertKafkaRequest(tt.kafka)
+				reply[0]["quota_type"] = tt.kafka.QuotaType
+				reply[0]["billing_model"] = tt.kafka.BillingModel
+				reply[0]["marketplace"] = tt.kafka.Marketplace
+				reply[0]["billing_cloud_account_id"] = tt.kafka.BillingCloudAccountId
+			}
+			mocket.Catcher.Reset()
+			mocket.Catcher.NewMock().
+				WithQuery(`SELECT * FROM "kafka_requests" WHERE id = $1 AND owner = $2`).
+				WithArgs(testID, testUser).
+				WithReply(reply)
+			mocket.Catcher.NewMock().WithExecException().WithQueryException()
+
+			k := &kafkaService{
+				connectionFactory: db.NewMockConnectionFactory(nil),
+			}
+			got, err := k.GetBillingModel(authenticatedCtx, testID)
+			if tt.wantErrCode != 0 {
+				g.Expect(err).ToNot(gomega.BeNil())
+				g.Expect(err.Code).To(gomega.Equal(tt.wantErrCode))
+				return
+			}
+			g.Expect(err).To(gomega.BeNil())
+			g.Expect(got).To(gomega.Equal(tt.want))
+		})
+	}
+}
+
+func Test_kafkaService_DeprovisionReason(t *testing.T) {
+	authHelper, err := auth.NewAuthHelper(JwtKeyFile, JwtCAFile, "")
+	if err != nil {
+		t.Fatalf("failed to create auth helper: %s", err.Error())
+	}
+	account, err := authHelper.NewAccount(testUser, "", "", "")
+	if err != nil {
+		t.Fatal("failed to build a new account")
+	}
+	jwt, err := authHelper.CreateJWTWithClaims(account, nil)
+	if err != nil {
+		t.Fatalf("failed to create jwt: %s", err.Error())
+	}
+	authenticatedCtx := auth.SetTokenInContext(context.TODO(), jwt)
+
+	const instanceType = "type1"
+	const instanceSize = "size1"
+
+	tests := []struct {
+		name        string
+		deprovision func(k *kafkaService) *errors.ServiceError
+		want        constants2.KafkaDeprovisionReason
+	}{
+		{
+			name: "should set the user request reason when the owner deprovisions the kafka",
+			deprovision: func(k *kafkaService) *errors.ServiceError {
+				return k.RegisterKafkaDeprovisionJob(authenticatedCtx, testID)
+			},
+			want: constants2.KafkaDeprovisionReasonUserRequest,
+		},
+		{
+			name: "should set the admin request reason when an admin deprovisions the kafka",
+			deprovision: func(k *kafkaService) *errors.ServiceError {
+				return k.RegisterKafkaDeprovisionJob(auth.SetIsAdminContext(authenticatedCtx, true), testID)
+			},
+			want: constants2.KafkaDeprovisionReasonAdminRequest,
+		},
+		{
+			name: "should set the expired reason when the kafka reaches the end of its lifespan",
+			deprovision: func(k *kafkaService) *errors.ServiceError {
+				return k.DeprovisionExpiredKafkas()
+			},
+			want: constants2.KafkaDeprovisionReasonExpired,
+		},
+		{
+			name: "should set the user offboarded reason when the kafkas of a user are deprovisioned",
+			deprovision: func(k *kafkaService) *errors.ServiceError {
+				return k.DeprovisionKafkaForUsers([]string{testUser})
+			},
+			want: constants2.KafkaDeprovisionReasonUserOffboarded,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+
+			var reasons []interface{}
+			mocket.Catcher.Reset()
+			mocket.Catcher.NewMock().
+				WithQuery(`SELECT * FROM "kafka_requests"`).
+				WithReply(converters.ConvertKafkaRequest(buildKafkaRequest(func(kafkaRequest *dbapi.KafkaRequest) {
+					kafkaRequest.Status = constants2.KafkaRequestStatusReady.String()
+					kafkaRequest.InstanceType = instanceType
+					kafkaRequest.SizeId = instanceSize
+				})))
+			mocket.Catcher.NewMock().
+				WithQuery(`UPDATE "kafka_requests" SET "deprovision_reason"=$1`).
+				WithCallback(func(_ string, args []driver.NamedValue) {
+					reasons = append(reasons, args[0].Value)
+				})
+			mocket.Catcher.NewMock().WithExecException().WithQueryException()
+
+			k := &kafkaService{
+				connectionFactory: db.NewMockConnectionFactory(nil),
+				kafkaConfig:       config.NewKafkaConfig(),
+			}
+			k.kafkaConfig.SupportedInstanceTypes.Configuration = config.SupportedKafkaInstanceTypesConfig{
+				SupportedKafkaInstanceTypes: []config.KafkaInstanceType{
+					{
+						Id:    instanceType,
+						Sizes: []config.KafkaInstanceSize{{Id: instanceSize, LifespanSeconds: &[]int{1}[0]}},
+					},
+				},
+			}
+
+			g.Expect(tt.deprovision(k)).To(gomega.BeNil())
+			g.Expect(reasons).To(gomega.Equal([]interface{}{tt.want.String()}))
+		})
+	}
+}
+
+func Test_kafkaService_GetDeprovisionReason(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	mocket.Catcher.Reset()
+	mocket.Catcher.NewMock().
+		WithQuery(`SELECT "deprovision_reason" FROM "kafka_requests" WHERE id = $1`).
+		WithArgs(testID).
+		WithReply([]map[string]interface{}{{"deprovision_reason": constants2.KafkaDeprovisionReasonExpired.String()}})
+	mocket.Catcher.NewMock().WithExecException().WithQueryException()
+
+	k := &kafkaService{
+		connectionFactory: db.NewMockConnectionFactory(nil),
+	}
+	reason, err := k.GetDeprovisionReason(testID)
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(reason).To(gomega.Equal(constants2.KafkaDeprovisionReasonExpired))
+
+	_, err = k.GetDeprovisionReason("")
+	g.Expect(err).ToNot(gomega.BeNil())
+}
+
+func Test_KafkaService_CountByStatus(t *testing.T) {
+	type fields struct {
+		connectionFactory *db.ConnectionFactory
+	}
+	type args struct {
+		status []constants2.KafkaStatus
+	}
+	tests := []struct {
+		name      string
+		fields    fields
+		args      args
+		wantErr   bool
+		want      []KafkaStatusCount
+		setupFunc func()
+	}{
+		{
+			name:   "should return the counts of Kafkas in different status",
+			fields: fields{connectionFactory: db.NewMockConnectionFactory(nil)},
+			args: args{
+				status: []constants2.KafkaStatus{constants2.KafkaRequestStatusAccepted, constants2.KafkaRequestStatusReady, constants2.KafkaRequestStatusProvisioning},
+			},
+			wantErr: false,
+			setupFunc: func() {
+				counters := []map[string]interface{}{
+					{
+						"status": "accepted",
+						"count":  2,
+					},
+					{
+						"status": "ready",
+						"count":  1,
+					},
+				}
+				mocket.Catcher.Reset().
+					NewMock().
+					WithQuery(`SELECT status as Status, count(1) as Count FROM "kafka_requests" WHERE status in ($1,$2,$3)`).
+					WithArgs(constants2.KafkaRequestStatusAccepted.String(), constants2.KafkaRequestStatusReady.String(), constants2.KafkaRequestStatusProvisioning.String()).
+					WithReply(counters)
+				mocket.Catcher.NewMock().WithExecException().WithQueryException()
+			},
+			want: []KafkaStatusCount{{
+				Status: constants2.KafkaRequestStatusAccepted,
+				Count:  2,
+			}, {
+				Status: constants2.KafkaRequestStatusReady,
+				Count:  1,
+			}, {
+				Status: constants2.KafkaRequestStatusProvisioning,
+				Count:  0,
+			}},
+		},
+		{
+			name:   "should return error",
+			fields: fields{connectionFactory: db.NewMockConnectionFactory(nil)},
+			args: args{
+				status: []constants2.KafkaStatus{constants2.KafkaRequestStatusAccepted, constants2.KafkaRequestStatusReady},
+			},
+			wantErr: true,
+			setupFunc: func() {
+				mocket.Catcher.Reset().NewMock().WithQuery(`SELECT`).WithQueryException()
+				mocket.Catcher.NewMock().WithExecException().WithQueryException()
+			},
+			want: nil,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			if tt.setupFunc != nil {
+				tt.setupFunc()
+			}
+			k := &kafkaService{
+				connectionFactory: tt.fields.connectionFactory,
+			}
+			status, err := k.CountByStatus(tt.args.status)
+			if !tt.wantErr && err != nil {
+				t.Errorf("unexpected error for CountByStatus: %v", err)
+			}
+			g.Expect(status).To(gomega.Equal(tt.want))
+		})
+	}
+}
+
+func Test_KafkaService_CountByClusterAndStatus(t *testing.T) {
+	type fields struct {
+		connectionFactory *db.ConnectionFactory
+	}
+	type args struct {
+		clusterID string
+	}
+	tests := []struct {
+		name      string
+		fields    fields
+		args      args
+		wantErr   bool
+		want      map[constants2.KafkaStatus]int
+		setupFunc func()
+	}{
+		{
+			name:   "should return the counts of Kafkas in each status on the cluster",
+			fields: fields{connectionFactory: db.NewMockConnectionFactory(nil)},
+			args: args{
+				clusterID: "cluster-id",
+			},
+			wantErr: false,
+			setupFunc: func() {
+				counters := []map[string]interface{}{
+					{
+						"status": "ready",
+						"count":  3,
+					},
+					{
+						"status": "provisioning",
+						"count":  1,
+					},
+					{
+						"status": "deprovision",
+						"count":  2,
+					},
+				}
+				mocket.Catcher.Reset().
+					NewMock().
+					WithQuery(`SELECT status as Status, count(1) as Count FROM "kafka_requests" WHERE cluster_id = $1`).
+					WithArgs("cluster-id").
+					WithReply(counters)
+				mocket.Catcher.NewMock().WithExecException().WithQueryException()
+			},
+			want: map[constants2.KafkaStatus]int{
+				constants2.KafkaRequestStatusReady:        3,
+				constants2.KafkaRequestStatusProvisioning: 1,
+				constants2.KafkaRequestStatusDeprovision:  2,
+			},
+		},
+		{
+			name:   "should return an empty map when there are no Kafkas on the cluster",
+			fields: fields{connectionFactory: db.NewMockConnectionFactory(nil)},
+			args: args{
+				clusterID: "cluster-id",
+			},
+			wantErr: false,
+			setupFunc: func() {
+				mocket.Catcher.Reset().
+					NewMock().
+					WithQuery(`SELECT status as Status, count(1) as Count FROM "kafka_requests" WHERE cluster_id = $1`).
+					WithArgs("cluster-id").
+					WithReply([]map[string]interface{}{})
+				mocket.Catcher.NewMock().WithExecException().WithQueryException()
+			},
+			want: map[constants2.KafkaStatus]int{},
+		},
+		{
+			name:   "should return error",
+			fields: fields{connectionFactory: db.NewMockConnectionFactory(nil)},
+			args: args{
+				clusterID: "cluster-id",
+			},
+			wantErr: true,
+			setupFunc: func() {
+				mocket.Catcher.Reset().NewMock().WithQuery(`SELECT`).WithQueryException()
+				mocket.Catcher.NewMock().WithExecException().WithQueryException()
+			},
+			want: nil,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			if tt.setupFunc != nil {
+				tt.setupFunc()
+			}
+			k := &kafkaService{
+				connectionFactory: tt.fields.connectionFactory,
+			}
+			counts, err := k.CountByClusterAndStatus(tt.args.clusterID)
+			g.Expect(err != nil).To(gomega.Equal(tt.wantErr))
+			g.Expect(counts).To(gomega.Equal(tt.want))
+		})
+	}
+}
+
+func Test_KafkaService_CountByMultiAZ(t *testing.T) {
+	counters := []map[string]interface{}{
+		{
+			"multi_az": true,
+			"count":    4,
+		},
+		{
+			"multi_az": false,
+			"count":    2,
+		},
+	}
+
+	tests := []struct {
+		name      string
+		region    string
+		wantErr   bool
+		want      map[bool]int
+		setupFunc func()
+	}{
+		{
+			name:    "should return the counts of multi AZ and single AZ Kafkas",
+			wantErr: false,
+			setupFunc: func() {
+				mocket.Catcher.Reset().
+					NewMock().
+					WithQuery(`SELECT multi_az, count(1) as count FROM "kafka_requests"`).
+					WithReply(counters)
+				mocket.Catcher.NewMock().WithExecException().WithQueryException()
+			},
+			want: map[bool]int{true: 4, false: 2},
+		},
+		{
+			name:    "should only count the Kafkas of the given region",
+			region:  testKafkaRequestRegion,
+			wantErr: false,
+			setupFunc: func() {
+				mocket.Catcher.Reset().
+					NewMock().
+					WithQuery(`SELECT multi_az, count(1) as count FROM "kafka_requests" WHERE region = $1`).
+					WithArgs(testKafkaRequestRegion).
+					WithReply(counters[1:])
+				mocket.Catcher.NewMock().WithExecException().WithQueryException()
+			},
+			want: map[bool]int{true: 0, false: 2},
+		},
+		{
+			name:    "should return zero counts when there are no Kafkas",
+			wantErr: false,
+			setupFunc: func() {
+				mocket.Catcher.Reset().
+					NewMock().
+					WithQuery(`SELECT multi_az, count(1) as count FROM "kafka_requests"`).
+					WithReply([]map[string]interface{}{})
+				mocket.Catcher.NewMock().WithExecException().WithQueryException()
+			},
+			want: map[bool]int{true: 0, false: 0},
+		},
+		{
+			name:    "should return error",
+			wantErr: true,
+			setupFunc: func() {
+				mocket.Catcher.Reset().NewMock().WithQuery(`SELECT`).WithQueryException()
+				mocket.Catcher.NewMock().WithExecException().WithQueryException()
+			},
+			want: nil,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			tt.setupFunc()
+			k := &kafkaService{
+				connectionFactory: db.NewMockConnectionFactory(nil),
+			}
+			counts, err := k.CountByMultiAZ(tt.region)
+			g.Expect(err != nil).To(gomega.Equal(tt.wantErr))
+			g.Expect(counts).To(gomega.Equal(tt.want))
+		})
+	}
+}
+
+func Test_KafkaService_ChangeKafkaCNAMErecords(t *testing.T) {
+	type fields struct {
+		awsClient aws.AWSClient
+	}
+
+	type args struct {
+		kafkaRequest *dbapi.KafkaRequest
+		action       KafkaRoutesAction
+	}
+
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "should create CNAMEs for kafka",
+			fields: fields{
+				awsClient: &aws.AWSClientMock{
+					ChangeResourceRecordSetsFunc: func(dnsName string, recordChangeBatch *route53.ChangeBatch) (*route53.ChangeResourceRecordSetsOutput, error) {
+						if len(recordChangeBatch.Changes) != 1 {
+							return nil, goerrors.Errorf("number of record changes should be 1")
+						}
+						if *recordChangeBatch.Changes[0].Action != "CREATE" {
+							return nil, goerrors.Errorf("the action of the record change is not CREATE")
+						}
+						return nil, nil
+					},
+					ListHostedZonesByNameInputFunc: func(dnsName string) (*route53.ListHostedZonesByNameOutput, error) {
+						return nil, nil
+					},
+				},
+			},
+			args: args{
+				kafkaRequest: &dbapi.KafkaRequest{
+					Meta: api.Meta{
+						ID: "test-kafka-id",
+					},
+					Name:          "test-kafka-cname",
+					Routes:        []byte("[{\"domain\": \"test-kafka-id.example.com\", \"router\": \"test-kafka-id.rhcloud.com\"}]"),
+					Region:        testKafkaRequestRegion,
+					CloudProvider: cloudproviders.AWS.String(),
+				},
+				action: KafkaRoutesActionCreate,
+			},
+		},
+		{
+			name: "should delete CNAMEs for kafka",
+			fields: fields{
+				awsClient: &aws.AWSClientMock{
+					ChangeResourceRecordSetsFunc: func(dnsName string, recordChangeBatch *route53.ChangeBatch) (*route53.ChangeResourceRecordSetsOutput, error) {
+						if len(recordChangeBatch.Changes) != 1 {
+							return nil, goerrors.Errorf("number of record changes should be 1")
+						}
+						if *recordChangeBatch.Changes[0].Action != "DELETE" {
+							return nil, goerrors.Errorf("the action of the record change is not DELETE")
+						}
+						return nil, nil
+					},
+					ListHostedZonesByNameInputFunc: func(dnsName string) (*route53.ListHostedZonesByNameOutput, error) {
+						return nil, nil
+					},
+				},
+			},
+			args: args{
+				kafkaRequest: &dbapi.KafkaRequest{
+					Meta: api.Meta{
+						ID: "test-kafka-id",
+					},
+					Name:          "test-kafka-cname",
+					Routes:        []byte("[{\"domain\": \"test-kafka-id.example.com\", \"router\": \"test-kafka-id.rhcloud.com\"}]"),
+					Region:        testKafkaRequestRegion,
+					CloudProvider: cloudproviders.AWS.String(),
+				},
+				action: KafkaRoutesActionDelete,
+			},
+		},
+		{
+			name: "should return error if it fails to get routes",
+			fields: fields{
+				awsClient: &aws.AWSClientMock{
+					ChangeResourceRecordSetsFunc: func(dnsName string, recordChangeBatch *route53.ChangeBatch) (*route53.ChangeResourceRecordSetsOutput, error) {
+						if len(recordChangeBatch.Changes) != 1 {
+							return nil, goerrors.Errorf("number of record changes should be 1")
+						}
+						if *recordChangeBatch.Changes[0].Action != "CREATE" {
+							return nil, goerrors.Errorf("the action of the record change is not CREATE")
+						}
+						return nil, nil
+					},
+					ListHostedZonesByNameInputFunc: func(dnsName string) (*route53.ListHostedZonesByNameOutput, error) {
+						return nil, nil
+					},
+				},
+			},
+			args: args{
+				kafkaRequest: &dbapi.KafkaRequest{
+					Meta: api.Meta{
+						ID: "test-kafka-id",
+					},
+					Name:   "test-kafka-cname",
+					Region: testKafkaRequestRegion,
+				},
+				action: KafkaRoutesActionCreate,
+			},
+			wantErr: true,
+		},
+		{
+			name: "should return error without changing any record if a route is invalid",
+			fields: fields{
+				awsClient: &aws.AWSClientMock{
+					ChangeResourceRecordSetsFunc: func(dnsName string, recordChangeBatch *route53.ChangeBatch) (*route53.ChangeResourceRecordSetsOutput, error) {
+						return nil, goerrors.Errorf("records should not be changed")
+					},
+				},
+			},
+			args: args{
+				kafkaRequest: &dbapi.KafkaRequest{
+					Meta: api.Meta{
+						ID: "test-kafka-id",
+					},
+					Name:          "test-kafka-cname",
+					Routes:        []byte("[{\"domain\": \"\", \"router\": \"test-kafka-id.rhcloud.com\"}]"),
+					Region:        testKafkaRequestRegion,
+					CloudProvider: cloudproviders.AWS.String(),
+				},
+				action: KafkaRoutesActionCreate,
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+
+		t.Run(tt.name, func(t *testing.T) {
+			kafkaService := &kafkaService{
+				awsClientFactory: aws.NewMockClientFactory(tt.fields.awsClient),
+				awsConfig: &config.AWSConfig{
+					Route53AccessKey:       "test-route-53-key",
+					Route53SecretAccessKey: "test-route-53-secret-key",
+				},
+				kafkaConfig: &config.KafkaConfig{
+					KafkaDomainName: "rhcloud.com",
+				},
+			}
+
+			_, err := kafkaService.ChangeKafkaCNAMErecords(tt.args.kafkaRequest, tt.args.action)
+			if err != nil && !tt.wantErr {
+				t.Errorf("unexpected error for ChangeKafkaCNAMErecords %v", err)
+			}
+		})
+	}
+
+}
+
+func Test_kafkaService_ValidateRoutes(t *testing.T) {
+	tests := []struct {
+		name    string
+		routes  []dbapi.DataPlaneKafkaRoute
+		wantErr bool
+	}{
+		{
+			name: "should accept valid routes",
+			routes: []dbapi.DataPlaneKafkaRoute{
+				{Domain: "test-kafka-id.kafka.bf2.dev", Router: "router.rhcloud.com"},
+				{Domain: "admin-server-test-kafka-id.kafka.bf2.dev", Router: "router.rhcloud.com"},
+			},
+			wantErr: false,
+		},
+		{
+			name:    "should accept an empty list of routes",
+			routes:  []dbapi.DataPlaneKafkaRoute{},
+			wantErr: false,
+		},
+		{
+			name: "should reject a route with an empty domain",
+			routes: []dbapi.DataPlaneKafkaRoute{
+				{Domain: "test-kafka-id.kafka.bf2.dev", Router: "router.rhcloud.com"},
+				{Domain: "", Router: "router.rhcloud.com"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "should reject a route with an invalid DNS domain",
+			routes: []dbapi.DataPlaneKafkaRoute{
+				{Domain: "test_kafka.kafka.bf2.dev", Router: "router.rhcloud.com"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "should reject a route with an empty router",
+			routes: []dbapi.DataPlaneKafkaRoute{
+				{Domain: "test-kafka-id.kafka.bf2.dev", Router: ""},
+			},
+			wantErr: true,
+		},
+		{
+			name: "should accept an apex route with a router hosted zone id",
+			routes: []dbapi.DataPlaneKafkaRoute{
+				{Domain: "kafka.bf2.dev", Router: "router.rhcloud.com", Apex: true, RouterHostedZoneId: "router-zone-id"},
+			},
+			wantErr: false,
+		},
+		{
+			name: "should reject an apex route with an empty router hosted zone id",
+			routes: []dbapi.DataPlaneKafkaRoute{
+				{Domain: "kafka.bf2.dev", Router: "router.rhcloud.com", Apex: true},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			k := &kafkaService{}
+			err := k.ValidateRoutes(tt.routes)
+			g.Expect(err != nil).To(gomega.Equal(tt.wantErr))
+			if tt.wantErr {
+				g.Expect(err.Code).To(gomega.Equal(errors.ErrorValidation))
+			}
+		})
+	}
+}
+
+func Test_buildResourceRecordChange(t *testing.T) {
+	action := "CREATE"
+	recordTTL := int64(300)
+	evaluateTargetHealth := false
+	cnameType := "CNAME"
+	aliasType := "A"
+	domain := "admin-server-test.kafka.bf2.dev"
+	apexDomain := "kafka.bf2.dev"
+	router := "router.rhcloud.com"
+	routerHostedZoneId := "router-zone-id"
+
+	tests := []struct {
+		name  string
+		route dbapi.DataPlaneKafkaRoute
+		want  *route53.Change
+	}{
+		{
+			name: "should build a CNAME record change for a normal route",
+			route: dbapi.DataPlaneKafkaRoute{
+				Domain: domain,
+				Router: router,
+			},
+			want: &route53.Change{
+				Action: &action,
+				ResourceRecordSet: &route53.ResourceRecordSet{
+					Name: &domain,
+					Type: &cnameType,
+					TTL:  &recordTTL,
+					ResourceRecords: []*route53.ResourceRecord{
+						{
+							Value: &router,
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "should build an alias record change for an apex route",
+			route: dbapi.DataPlaneKafkaRoute{
+				Domain:             apexDomain,
+				Router:             router,
+				Apex:               true,
+				RouterHostedZoneId: routerHostedZoneId,
+			},
+			want: &route53.Change{
+				Action: &action,
+				ResourceRecordSet: &route53.ResourceRecordSet{
+					Name: &apexDomain,
+					Type: &aliasType,
+					AliasTarget: &route53.AliasTarget{
+						DNSName:              &router,
+						HostedZoneId:         &routerHostedZoneId,
+						EvaluateTargetHealth: &evaluateTargetHealth,
+					},
+				},
+			},
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			g.Expect(buildResourceRecordChange(tt.route, action)).To(gomega.Equal(tt.want))
+		})
+	}
+}
+
+func Test_KafkaService_ChangeKafkaCNAMErecords_CloudProviderDomains(t *testing.T) {
+	tests := []struct {
+		name             string
+		cloudProvider    string
+		wantDnsName      string
+		wantHostedZoneId string
+	}{
+		{
+			name:          "should change the CNAMEs of an AWS kafka in the hosted zone of the kafka domain name",
+			cloudProvider: cloudproviders.AWS.String(),
+			wantDnsName:   "rhcloud.com",
+		},
+		{
+			name:             "should change the CNAMEs of a GCP kafka in the hosted zone of the GCP domain",
+			cloudProvider:    cloudproviders.GCP.String(),
+			wantHostedZoneId: "gcp-zone-id",
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			awsClient := &aws.AWSClientMock{
+				ChangeResourceRecordSetsFunc: func(dnsName string, recordChangeBatch *route53.ChangeBatch) (*route53.ChangeResourceRecordSetsOutput, error) {
+					return nil, nil
+				},
+				ChangeResourceRecordSetsInHostedZoneFunc: func(hostedZoneId string, recordChangeBatch *route53.ChangeBatch) (*route53.ChangeResourceRecordSetsOutput, error) {
+					return nil, nil
+				},
+			}
+			kafkaService := &kafkaService{
+				awsClientFactory: aws.NewMockClientFactory(awsClient),
+				awsConfig: &config.AWSConfig{
+					Route53AccessKey:       "test-route-53-key",
+					Route53SecretAccessKey: "test-route-53-secret-key",
+				},
+				kafkaConfig: &config.KafkaConfig{
+					KafkaDomainName: "rhcloud.com",
+					CloudProviderDomains: map[string]config.KafkaDomainConfig{
+						cloudproviders.GCP.String(): {
+							DomainName:   "gcp.rhcloud.com",
+							HostedZoneID: "gcp-zone-id",
+						},
+					},
+				},
+			}
+			kafkaRequest := &dbapi.KafkaRequest{
+				Meta: api.Meta{
+					ID: "test-kafka-id",
+				},
+				Name:          "test-kafka-cname",
+				Routes:        []byte("[{\"domain\": \"test-kafka-id.example.com\", \"router\": \"test-kafka-id.rhcloud.com\"}]"),
+				Region:        testKafkaRequestRegion,
+				CloudProvider: tt.cloudProvider,
+			}
+
+			_, err := kafkaService.ChangeKafkaCNAMErecords(kafkaRequest, KafkaRoutesActionCreate)
+			g.Expect(err).ToNot(gomega.HaveOccurred())
+			if tt.wantHostedZoneId != "" {
+				g.Expect(awsClient.ChangeResourceRecordSetsCalls()).To(gomega.BeEmpty())
+				g.Expect(awsClient.ChangeResourceRecordSetsInHostedZoneCalls()).To(gomega.HaveLen(1))
+				g.Expect(awsClient.ChangeResourceRecordSetsInHostedZoneCalls()[0].HostedZoneId).To(gomega.Equal(tt.wantHostedZoneId))
+			} else {
+				g.Expect(awsClient.ChangeResourceRecordSetsInHostedZoneCalls()).To(gomega.BeEmpty())
+				g.Expect(awsClient.ChangeResourceRecordSetsCalls()).To(gomega.HaveLen(1))
+				g.Expect(awsClient.ChangeResourceRecordSetsCalls()[0].DnsName).To(gomega.Equal(tt.wantDnsName))
+			}
+		})
+	}
+}
+
+func Test_KafkaService_ChangeKafkaCNAMErecordsBatch(t *testing.T) {
+	type fields struct {
+		awsClient aws.AWSClient
+	}
+
+	type args struct {
+		kafkaRequests []*dbapi.KafkaRequest
+		action        KafkaRoutesAction
+	}
+
+	changeId := "change-id"
+	changeStatus := "PENDING"
+	routes := func(id string) []byte {
+		return []byte(fmt.Sprintf(`[{"domain": "%s.example.com", "router": "%s.rhcloud.com"}]`, id, id))
+	}
+	awsKafka1 := buildKafkaRequest(func(kafkaRequest *dbapi.KafkaRequest) {
+		kafkaRequest.ID = "aws-kafka-1"
+		kafkaRequest.Routes = routes(kafkaRequest.ID)
+	})
+	awsKafka2 := buildKafkaRequest(func(kafkaRequest *dbapi.KafkaRequest) {
+		kafkaRequest.ID = "aws-kafka-2"
+		kafkaRequest.Routes = routes(kafkaRequest.ID)
+	})
+	unknownProviderKafka := buildKafkaRequest(func(kafkaRequest *dbapi.KafkaRequest) {
+		kafkaRequest.ID = "unknown-provider-kafka"
+		kafkaRequest.CloudProvider = "unknown"
+		kafkaRequest.Routes = routes(kafkaRequest.ID)
+	})
+	noRoutesKafka := buildKafkaRequest(func(kafkaRequest *dbapi.KafkaRequest) {
+		kafkaRequest.ID = "no-routes-kafka"
+	})
+
+	tests := []struct {
+		name          string
+		fields        fields
+		args          args
+		wantSucceeded map[string]bool
+		wantChangeId  map[string]*string
+	}{
+		{
+			name: "should send a single batch per region and report the result per kafka",
+			fields: fields{
+				awsClient: &aws.AWSClientMock{
+					ChangeResourceRecordSetsFunc: func(dnsName string, recordChangeBatch *route53.ChangeBatch) (*route53.ChangeResourceRecordSetsOutput, error) {
+						if len(recordChangeBatch.Changes) != 2 {
+							return nil, goerrors.Errorf("number of record changes should be 2")
+						}
+						return &route53.ChangeResourceRecordSetsOutput{
+							ChangeInfo: &route53.ChangeInfo{
+								Id:     &changeId,
+								Status: &changeStatus,
+							},
+						}, nil
+					},
+				},
+			},
+			args: args{
+				kafkaRequests: []*dbapi.KafkaRequest{awsKafka1, awsKafka2, unknownProviderKafka, noRoutesKafka},
+				action:        KafkaRoutesActionCreate,
+			},
+			wantSucceeded: map[string]bool{
+				awsKafka1.ID:            true,
+				awsKafka2.ID:            true,
+				unknownProviderKafka.ID: false,
+				noRoutesKafka.ID:        false,
+			},
+			wantChangeId: map[string]*string{
+				awsKafka1.ID:            &changeId,
+				awsKafka2.ID:            &changeId,
+				unknownProviderKafka.ID: nil,
+				noRoutesKafka.ID:        nil,
+			},
+		},
+		{
+			name: "should report all the kafkas of a region as failed when the region batch fails",
+			fields: fields{
+				awsClient: &aws.AWSClientMock{
+					ChangeResourceRecordSetsFunc: func(dnsName string, recordChangeBatch *route53.ChangeBatch) (*route53.ChangeResourceRecordSetsOutput, error) {
+						return nil, goerrors.Errorf("invalid change batch")
+					},
+				},
+			},
+			args: args{
+				kafkaRequests: []*dbapi.KafkaRequest{awsKafka1, awsKafka2},
+				action:        KafkaRoutesActionCreate,
+			},
+			wantSucceeded: map[string]bool{
+				awsKafka1.ID: false,
+				awsKafka2.ID: false,
+			},
+			wantChangeId: map[string]*string{
+				awsKafka1.ID: nil,
+				awsKafka2.ID: nil,
+			},
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			kafkaService := &kafkaService{
+				awsClientFactory: aws.NewMockClientFactory(tt.fields.awsClient),
+				awsConfig: &config.AWSConfig{
+					Route53AccessKey:       "test-route-53-key",
+					Route53SecretAccessKey: "test-route-53-secret-key",
+				},
+				kafkaConfig: &config.KafkaConfig{
+					KafkaDomainName: "rhcloud.com",
+				},
+			}
+
+			results := kafkaService.ChangeKafkaCNAMErecordsBatch(tt.args.kafkaRequests, tt.args.action)
+			g.Expect(results).To(gomega.HaveLen(len(tt.wantSucceeded)))
+			for id, succeeded := range tt.wantSucceeded {
+				g.Expect(results).To(gomega.HaveKey(id))
+				g.Expect(results[id].Succeeded()).To(gomega.Equal(succeeded))
+				g.Expect(results[id].ChangeId).To(gomega.Equal(tt.wantChangeId[id]))
+			}
+		})
+	}
+}
+
+func Test_kafkaService_RecreateRoutes(t *testing.T) {
+	type fields struct {
+		connectionFactory *db.ConnectionFactory
+		awsClient         aws.AWSClient
+	}
+	type args struct {
+		id string
+	}
+
+	changeId := "change-id"
+	changeStatus := "INSYNC"
+	successfulAWSClient := &aws.AWSClientMock{
+		ChangeResourceRecordSetsFunc: func(dnsName string, recordChangeBatch *route53.ChangeBatch) (*route53.ChangeResourceRecordSetsOutput, error) {
+			if *recordChangeBatch.Changes[0].Action != "CREATE" {
+				return nil, goerrors.Errorf("the action of the record change is not CREATE")
+			}
+			return &route53.ChangeResourceRecordSetsOutput{
+				ChangeInfo: &route53.ChangeInfo{
+					Id:     &changeId,
+					Status: &changeStatus,
+				},
+			}, nil
+		},
+	}
+	buildKafkaReply := func(status constants2.KafkaStatus, routes []byte) []map[string]interface{} {
+		reply := converters.ConvertKafkaRequest(buildKafkaRequest(func(kafkaRequest *dbapi.KafkaRequest) {
+			kafkaRequest.Status = status.String()
+		}))
+		reply[0]["routes"] = routes
+		return reply
+	}
+	routes := []byte(`[{"domain": "test.example.com", "router": "test.rhcloud.com"}]`)
+
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		wantErr bool
+		setupFn func()
+	}{
+		{
+			name: "should recreate the routes of a ready kafka",
+			fields: fields{
+				connectionFactory: db.NewMockConnectionFactory(nil),
+				awsClient:         successfulAWSClient,
+			},
+			args: args{
+				id: testID,
+			},
+			wantErr: false,
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().
+					WithQuery(`SELECT * FROM "kafka_requests" WHERE id = $1`).
+					WithArgs(testID).
+					WithReply(buildKafkaReply(constants2.KafkaRequestStatusReady, routes))
+				mocket.Catcher.NewMock().WithQuery(`UPDATE "kafka_requests" SET "routes_created"=$1,"routes_creation_id"=$2`)
+				mocket.Catcher.NewMock().WithExecException().WithQueryException()
+			},
+		},
+		{
+			name: "should return an error if the kafka is not in a steady state",
+			fields: fields{
+				connectionFactory: db.NewMockConnectionFactory(nil),
+				awsClient:         successfulAWSClient,
+			},
+			args: args{
+				id: testID,
+			},
+			wantErr: true,
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().
+					WithQuery(`SELECT * FROM "kafka_requests" WHERE id = $1`).
+					WithArgs(testID).
+					WithReply(buildKafkaReply(constants2.KafkaRequestStatusProvisioning, routes))
+				mocket.Catcher.NewMock().WithQuery(`UPDATE "kafka_requests" SET "routes_created"=$1,"routes_creation_id"=$2`)
+				mocket.Catcher.NewMock().WithExecException().WithQueryException()
+			},
+		},
+		{
+			name: "should return an error if the kafka has no routes",
+			fields: fields{
+				connectionFactory: db.NewMockConnectionFactory(nil),
+				awsClient:         successfulAWSClient,
+			},
+			args: args{
+				id: testID,
+			},
+			wantErr: true,
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().
+					WithQuery(`SELECT * FROM "kafka_requests" WHERE id = $1`).
+					WithArgs(testID).
+					WithReply(buildKafkaReply(constants2.KafkaRequestStatusReady, []byte(`[]`)))
+				mocket.Catcher.NewMock().WithQuery(`UPDATE "kafka_requests" SET "routes_created"=$1,"routes_creation_id"=$2`)
+				mocket.Catcher.NewMock().WithExecException().WithQueryException()
+			},
+		},
+		{
+			name: "should return an error if the CNAME records cannot be created",
+			fields: fields{
+				connectionFactory: db.NewMockConnectionFactory(nil),
+				awsClient: &aws.AWSClientMock{
+					ChangeResourceRecordSetsFunc: func(dnsName string, recordChangeBatch *route53.ChangeBatch) (*route53.ChangeResourceRecordSetsOutput, error) {
+						return nil, goerrors.Errorf("failed to change resource record sets")
+					},
+				},
+			},
+			args: args{
+				id: testID,
+			},
+			wantErr: true,
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().
+					WithQuery(`SELECT * FROM "kafka_requests" WHERE id = $1`).
+					WithArgs(testID).
+					WithReply(buildKafkaReply(constants2.KafkaRequestStatusReady, routes))
+				mocket.Catcher.NewMock().WithQuery(`UPDATE "kafka_requests" SET "routes_created"=$1,"routes_creation_id"=$2`)
+				mocket.Catcher.NewMock().WithExecException().WithQueryException()
+			},
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			tt.setupFn()
+			k := &kafkaService{
+				connectionFactory: tt.fields.connectionFactory,
+				awsClientFactory:  aws.NewMockClientFactory(tt.fields.awsClient),
+				awsConfig:         config.NewAWSConfig(),
+				kafkaConfig: &config.KafkaConfig{
+					KafkaDomainName: "rhcloud.com",
+				},
+			}
+			g.Expect(k.RecreateRoutes(tt.args.id) != nil).To(gomega.Equal(tt.wantErr))
+		})
+	}
+}
+
+func Test_KafkaService_ListStuckUpgrades(t *testing.T) {
+	upgradeStartedAt := time.Now().Add(-3 * time.Hour)
+
+	tests := []struct {
+		name      string
+		olderThan time.Duration
+		wantErr   bool
+		wantIDs   []string
+		setupFunc func()
+	}{
+		{
+			name:      "should return the kafkas whose upgrade has been running for longer than the given duration",
+			olderThan: 2 * time.Hour,
+			wantErr:   false,
+			wantIDs:   []string{"1"},
+			setupFunc: func() {
+				versions := []map[string]interface{}{
+					{
+						"id":                        "1",
+						"cluster_id":                "cluster1",
+						"desired_strimzi_version":   "1.0.1",
+						"actual_strimzi_version":    "1.0.0",
+						"strimzi_upgrading":         true,
+						"desired_kafka_version":     "2.0.0",
+						"actual_kafka_version":      "2.0.0",
+						"kafka_upgrading":           false,
+						"desired_kafka_ibp_version": "2.0",
+						"actual_kafka_ibp_version":  "2.0",
+						"kafka_ibp_upgrading":       false,
+						"upgrade_started_at":        upgradeStartedAt,
+					},
+				}
+				mocket.Catcher.Reset().
+					NewMock().
+					WithQuery(`SELECT "id","cluster_id","desired_strimzi_version","actual_strimzi_version","strimzi_upgrading","desired_kafka_version","actual_kafka_version","kafka_upgrading","desired_kafka_ibp_version","actual_kafka_ibp_version","kafka_ibp_upgrading","upgrade_started_at" FROM "kafka_requests" WHERE`).
+					WithReply(versions)
+				mocket.Catcher.NewMock().WithExecException().WithQueryException()
+			},
+		},
+		{
+			name:      "should return an empty list when no upgrade is stuck",
+			olderThan: 2 * time.Hour,
+			wantErr:   false,
+			wantIDs:   []string{},
+			setupFunc: func() {
+				mocket.Catcher.Reset().
+					NewMock().
+					WithQuery(`SELECT "id","cluster_id","desired_strimzi_version","actual_strimzi_version","strimzi_upgrading","desired_kafka_version","actual_kafka_version","kafka_upgrading","desired_kafka_ibp_version","actual_kafka_ibp_version","kafka_ibp_upgrading","upgrade_started_at" FROM "kafka_requests" WHERE`).
+					WithReply([]map[string]interface{}{})
+				mocket.Catcher.NewMock().WithExecException().WithQueryException()
+			},
+		},
+		{
+			name:      "should return an error if the kafkas cannot be listed",
+			olderThan: 2 * time.Hour,
+			wantErr:   true,
+			setupFunc: func() {
+				mocket.Catcher.Reset().NewMock().WithQuery(`SELECT`).WithQueryException()
+				mocket.Catcher.NewMock().WithExecException().WithQueryException()
+			},
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			tt.setupFunc()
+			k := &kafkaService{
+				connectionFactory: db.NewMockConnectionFactory(nil),
+			}
+
+			got, err := k.ListStuckUpgrades(tt.olderThan)
+			g.Expect(err != nil).To(gomega.Equal(tt.wantErr))
+			if tt.wantErr {
+				return
+			}
+			g.Expect(got).To(gomega.HaveLen(len(tt.wantIDs)))
+			for i, versions := range got {
+				g.Expect(versions.ID).To(gomega.Equal(tt.wantIDs[i]))
+				g.Expect(versions.UpgradeStartedAt).ToNot(gomega.BeNil())
+				g.Expect(*versions.UpgradeStartedAt).To(gomega.BeTemporally("<", time.Now().Add(-tt.olderThan)))
+			}
+		})
+	}
+}
+
+func Test_kafkaService_CancelUpgrade(t *testing.T) {
+	buildKafkaReply := func(actualStrimziVersion, actualKafkaVersion, actualKafkaIBPVersion string) []map[string]interface{} {
+		reply := converters.ConvertKafkaRequest(buildKafkaRequest(nil))
+		reply[0]["desired_strimzi_version"] = "strimzi-2"
+		reply[0]["actual_strimzi_version"] = actualStrimziVersion
+		reply[0]["strimzi_upgrading"] = true
+		reply[0]["desired_kafka_version"] = "kafka-2"
+		reply[0]["actual_kafka_version"] = actualKafkaVersion
+		reply[0]["kafka_upgrading"] = true
+		reply[0]["desired_kafka_ibp_version"] = "ibp-2"
+		reply[0]["actual_kafka_ibp_version"] = actualKafkaIBPVersion
+		reply[0]["kafka_ibp_upgrading"] = false
+		return reply
+	}
+	const updateQuery = `UPDATE "kafka_requests" SET "desired_kafka_ibp_version"=$1,"desired_kafka_version"=$2,"desired_strimzi_version"=$3,"kafka_ibp_upgrading"=$4,"kafka_upgrading"=$5,"strimzi_upgrading"=$6,"upgrade_started_at"=$7`
+
+	tests := []struct {
+		name        string
+		wantErr     bool
+		wantUpdated []interface{}
+		setupFn     func(updated *[]interface{})
+	}{
+		{
+			name:        "should reset the desired versions to the actual versions",
+			wantErr:     false,
+			wantUpdated: []interface{}{"ibp-1", "kafka-1", "strimzi-1", false, false, false, nil},
+			setupFn: func(updated *[]interface{}) {
+				mocket.Catcher.Reset().NewMock().
+					WithQuery(`SELECT * FROM "kafka_requests" WHERE id = $1`).
+					WithArgs(testID).
+					WithReply(buildKafkaReply("strimzi-1", "kafka-1", "ibp-1"))
+				mocket.Catcher.NewMock().
+					WithQuery(updateQuery).
+					WithCallback(func(_ string, args []driver.NamedValue) {
+						for _, arg := range args[:7] {
+							*updated = append(*updated, arg.Value)
+						}
+					})
+				mocket.Catcher.NewMock().WithExecException().WithQueryException()
+			},
+		},
+		{
+			name:    "should return an error if an actual version is not known",
+			wantErr: true,
+			setupFn: func(updated *[]interface{}) {
+				mocket.Catcher.Reset().NewMock().
+					WithQuery(`SELECT * FROM "kafka_requests" WHERE id = $1`).
+					WithArgs(testID).
+					WithReply(buildKafkaReply("strimzi-1", "", "ibp-1"))
+				mocket.Catcher.NewMock().
+					WithQuery(updateQuery).
+					WithCallback(func(_ string, args []driver.NamedValue) {
+						*updated = append(*updated, "unexpected update")
+					})
+				mocket.Catcher.NewMock().WithExecException().WithQueryException()
+			},
+		},
+		{
+			name:    "should return an error if the kafka cannot be found",
+			wantErr: true,
+			setupFn: func(updated *[]interface{}) {
+				mocket.Catcher.Reset().NewMock().WithExecException().WithQueryException()
+			},
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			var updated []interface{}
+			tt.setupFn(&updated)
+			k := &kafkaService{
+				connectionFactory: db.NewMockConnectionFactory(nil),
+			}
+			g.Expect(k.CancelUpgrade(testID) != nil).To(gomega.Equal(tt.wantErr))
+			g.Expect(updated).To(gomega.Equal(tt.wantUpdated))
+		})
+	}
+}
+
+func Test_kafkaService_RetryFailed(t *testing.T) {
+	buildFailedKafka := func(clusterID string) *dbapi.KafkaRequest {
+		return buildKafkaRequest(func(kafkaRequest *dbapi.KafkaRequest) {
+			kafkaRequest.InstanceType = types.STANDARD.String()
+			kafkaRequest.Status = constants2.KafkaRequestStatusFailed.String()
+			kafkaRequest.FailedReason = "Kafka reported as failed from the data plane"
+			kafkaRequest.ClusterID = clusterID
+		})
+	}
+	buildKafkaReply := func(kafkaRequest *dbapi.KafkaRequest) []map[string]interface{} {
+		reply := converters.ConvertKafkaRequest(kafkaRequest)
+		reply[0]["failed_reason"] = kafkaRequest.FailedReason
+		return reply
+	}
+	readyCluster := &api.Cluster{ClusterID: testClusterID, Status: api.ClusterReady}
+	const capacityQuery = `SELECT * FROM "kafka_requests" WHERE region = $1 AND cloud_provider = $2 AND instance_type = $3 AND "kafka_requests"."deleted_at" IS NULL`
+	const updateQuery = `UPDATE "kafka_requests" SET `
+
+	type fields struct {
+		clusterService      ClusterService
+		clusterPlmtStrategy ClusterPlacementStrategy
+	}
+
+	tests := []struct {
+		name        string
+		fields      fields
+		kafka       *dbapi.KafkaRequest
+		regionKafka []*dbapi.KafkaRequest
+		wantErrCode errors.ServiceErrorCode
+		wantUpdated []interface{}
+	}{
+		{
+			name: "should move a failed kafka back to accepted on its cluster, reusing the capacity it already consumes",
+			fields: fields{
+				clusterService: &ClusterServiceMock{
+					FindClusterByIDFunc: func(clusterID string) (*api.Cluster, *errors.ServiceError) {
+						return readyCluster, nil
+					},
+				},
+			},
+			kafka:       buildFailedKafka(testClusterID),
+			regionKafka: []*dbapi.KafkaRequest{buildFailedKafka(testClusterID)},
+			wantUpdated: []interface{}{"", constants2.KafkaRequestStatusAccepted.String()},
+		},
+		{
+			name: "should place a failed kafka without cluster on an available cluster",
+			fields: fields{
+				clusterPlmtStrategy: &ClusterPlacementStrategyMock{
+					FindClusterFunc: func(kafka *dbapi.KafkaRequest) (*api.Cluster, error) {
+						return readyCluster, nil
+					},
+				},
+			},
+			kafka:       buildFailedKafka(""),
+			regionKafka: []*dbapi.KafkaRequest{buildFailedKafka("")},
+			wantUpdated: []interface{}{testClusterID, "", constants2.KafkaRequestStatusAccepted.String()},
+		},
+		{
+			name: "should refuse to retry a failed kafka when the region has no capacity left",
+			fields: fields{
+				clusterService: &ClusterServiceMock{
+					FindClusterByIDFunc: func(clusterID string) (*api.Cluster, *errors.ServiceError) {
+						return readyCluster, nil
+					},
+				},
+			},
+			kafka: buildFailedKafka(testClusterID),
+			regionKafka: []*dbapi.KafkaRequest{
+				buildFailedKafka(testClusterID),
+				buildKafkaRequest(func(kafkaRequest *dbapi.KafkaRequest) {
+					kafkaRequest.ID = "another-kafka"
+					kafkaRequest.InstanceType = types.STANDARD.String()
+				}),
+			},
+			wantErrCode: errors.ErrorTooManyKafkaInstancesReached,
+		},
+		{
+			name: "should refuse to retry a failed kafka when its cluster is not ready",
+			fields: fields{
+				clusterService: &ClusterServiceMock{
+					FindClusterByIDFunc: func(clusterID string) (*api.Cluster, *errors.ServiceError) {
+						return &api.Cluster{ClusterID: testClusterID, Status: api.ClusterDeprovisioning}, nil
+					},
+				},
+			},
+			kafka:       buildFailedKafka(testClusterID),
+			regionKafka: []*dbapi.KafkaRequest{buildFailedKafka(testClusterID)},
+			wantErrCode: errors.ErrorTooManyKafkaInstancesReached,
+		},
+		{
+			name: "should refuse to retry a failed kafka without cluster when no cluster is available",
+			fields: fields{
+				clusterPlmtStrategy: &ClusterPlacementStrategyMock{
+					FindClusterFunc: func(kafka *dbapi.KafkaRequest) (*api.Cluster, error) {
+						return nil, nil
+					},
+				},
+			},
+			kafka:       buildFailedKafka(""),
+			regionKafka: []*dbapi.KafkaRequest{buildFailedKafka("")},
+			wantErrCode: errors.ErrorTooManyKafkaInstancesReached,
+		},
+		{
+			name: "should refuse to retry a kafka that is not failed",
+			kafka: buildKafkaRequest(func(kafkaRequest *dbapi.KafkaRequest) {
+				kafkaRequest.InstanceType = types.STANDARD.String()
+				kafkaRequest.Status = constants2.KafkaRequestStatusReady.String()
+			}),
+			wantErrCode: errors.ErrorBadRequest,
+		},
+		{
+			name:        "should return an error if the kafka cannot be found",
+			wantErrCode: errors.ErrorNotFound,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+
+			var updated []interface{}
+			reply := []map[string]interface{}{}
+			if tt.kafka != nil {
+				reply = buildKafkaReply(tt.kafka)
+			}
+			mocket.Catcher.Reset().NewMock().
+				WithQuery(`SELECT * FROM "kafka_requests" WHERE id = $1`).
+				WithArgs(testID).
+				WithReply(reply)
+			mocket.Catcher.NewMock().
+				WithQuery(capacityQuery).
+				WithReply(converters.ConvertKafkaRequestList(tt.regionKafka))
+			mocket.Catcher.NewMock().
+				WithQuery(updateQuery).
+				WithCallback(func(_ string, args []driver.NamedValue) {
+					// the update times and the id of the kafka are not checked
+					for _, arg := range args[:len(args)-1] {
+						if _, ok := arg.Value.(time.Time); !ok {
+							updated = append(updated, arg.Value)
+						}
+					}
+				})
+			mocket.Catcher.NewMock().WithQuery("pg_advisory_xact_lock")
+			mocket.Catcher.NewMock().WithExecException().WithQueryException()
+
+			k := &kafkaService{
+				connectionFactory:        db.NewMockConnectionFactory(nil),
+				kafkaConfig:              &defaultKafkaConf,
+				dataplaneClusterConfig:   buildDataplaneClusterConfig(nil),
+				providerConfig:           buildProviderConfiguration(testKafkaRequestRegion, 1, 1, false),
+				clusterService:           tt.fields.clusterService,
+				clusterPlacementStrategy: tt.fields.clusterPlmtStrategy,
+			}
+			err := k.RetryFailed(testID)
+			if tt.wantErrCode != 0 {
+				g.Expect(err).ToNot(gomega.BeNil())
+				g.Expect(err.Code).To(gomega.Equal(tt.wantErrCode))
+				g.Expect(updated).To(gomega.BeNil())
+				return
+			}
+			g.Expect(err).To(gomega.BeNil())
+			// the kafka is given a new placement id, set before its status
+			g.Expect(updated).To(gomega.HaveLen(len(tt.wantUpdated) + 1))
+			placementId := updated[len(updated)-2]
+			g.Expect(placementId).ToNot(gomega.BeEmpty())
+			g.Expect(placementId).ToNot(gomega.Equal(tt.kafka.PlacementId))
+			g.Expect(append(updated[:len(updated)-2], updated[len(updated)-1])).To(gomega.Equal(tt.wantUpdated))
+		})
+	}
+}
+
+func Test_KafkaService_ListComponentVersions(t *testing.T) {
+	type fields struct {
+		connectionFactory *db.ConnectionFactory
+	}
+	tests := []struct {
+		name      string
+		fields    fields
+		wantErr   bool
+		want      []KafkaComponentVersions
+		setupFunc func()
+	}{
+		{
+			name:    "should return the component versions for Kafka",
+			fields:  fields{connectionFactory: db.NewMockConnectionFactory(nil)},
+			wantErr: false,
+			setupFunc: func() {
+				versions := []map[string]interface{}{
+					{
+						"id":                        "1",
+						"cluster_id":                "cluster1",
+						"desired_strimzi_version":   "1.0.1",
+						"actual_strimzi_version":    "1.0.0",
+						"strimzi_upgrading":         true,
+						"desired_kafka_version":     "2.0.1",
+						"actual_kafka_version":      "2.0.0",
+						"kafka_upgrading":           false,
+						"desired_kafka_ibp_version": "2.0",
+						"actual_kafka_ibp_version":  "2.0",
+						"kafka_ibp_upgrading":       false,
+					},
+					{
+						"id":                        "2",
+						"cluster_id":                "cluster2",
+						"desired_strimzi_version":   "1.0.1",
+						"actual_strimzi_version":    "1.0.0",
+						"strimzi_upgrading":         false,
+						"desired_kafka_version":     "2.0.1",
+						"actual_kafka_version":      "2.0.0",
+						"kafka_upgrading":           false,
+						"desired_kafka_ibp_version": "2.2",
+						"actual_kafka_ibp_version":  "2.1",
+						"kafka_ibp_upgrading":       true,
+					},
+				}
+				mocket.Catcher.Reset().
+					NewMock().
+					WithQuery(`SELECT "id","cluster_id","desired_strimzi_version","actual_strimzi_version","strimzi_upgrading","desired_kafka_version","actual_kafka_version","kafka_upgrading","desired_kafka_ibp_version","actual_kafka_ibp_version","kafka_ibp_upgrading"`).
+					WithReply(versions)
+				mocket.Catcher.NewMock().WithExecException().WithQueryException()
+			},
+			want: []KafkaComponentVersions{{
+				ID:                     "1",
+				ClusterID:              "cluster1",
+				DesiredStrimziVersion:  "1.0.1",
+				ActualStrimziVersion:   "1.0.0",
+				StrimziUpgrading:       true,
+				DesiredKafkaVersion:    "2.0.1",
+				ActualKafkaVersion:     "2.0.0",
+				KafkaUpgrading:         false,
+				DesiredKafkaIBPVersion: "2.0",
+				ActualKafkaIBPVersion:  "2.0",
+				KafkaIBPUpgrading:      false,
+			}, {
+				ID:                     "2",
+				ClusterID:              "cluster2",
+				DesiredStrimziVersion:  "1.0.1",
+				ActualStrimziVersion:   "1.0.0",
+				StrimziUpgrading:       false,
+				DesiredKafkaVersion:    "2.0.1",
+				ActualKafkaVersion:     "2.0.0",
+				KafkaUpgrading:         false,
+				DesiredKafkaIBPVersion: "2.2",
+				ActualKafkaIBPVersion:  "2.1",
+				KafkaIBPUpgrading:      true,
+			}},
+		},
+		{
+			name:    "should return error",
+			fields:  fields{connectionFactory: db.NewMockConnectionFactory(nil)},
+			wantErr: true,
+			setupFunc: func() {
+				mocket.Catcher.Reset().NewMock().WithQuery(`SELECT`).WithQueryException()
+				mocket.Catcher.NewMock().WithExecException().WithQueryException()
+			},
+			want: nil,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			if tt.setupFunc != nil {
+				tt.setupFunc()
+			}
+			k := &kafkaService{
+				connectionFactory: tt.fields.connectionFactory,
+			}
+			result, err := k.ListComponentVersions()
+			if !tt.wantErr && err != nil {
+				t.Errorf("unexpected error for ListComponentVersions: %v", err)
+			}
+			g.Expect(result).To(gomega.Equal(tt.want))
+		})
+	}
+}
+
+func Test_kafkaService_GetAvailableSizesInRegion(t *testing.T) {
+	type fields struct {
+		connectionFactory        *db.ConnectionFactory
+		kafkaConfig              *config.KafkaConfig
+		dataplaneClusterConfig   *config.DataplaneClusterConfig
+		providerConfig           *config.ProviderConfig
+		clusterPlacementStrategy ClusterPlacementStrategy
+	}
+	type args struct {
+		criteria *FindClusterCriteria
+	}
+
+	defaultCluster := buildManualCluster(1, api.AllInstanceTypeSupport.String(), testKafkaRequestRegion)
+	defaultDataplaneClusterConfig := []config.ManualCluster{defaultCluster}
+
+	dynamicScalingEnabledDataplaneClusterConfig := config.NewDataplaneClusterConfig()
+	dynamicScalingEnabledDataplaneClusterConfig.DataPlaneClusterScalingType = config.AutoScaling
+	testCriteria := &FindClusterCriteria{
+		Provider:              defaultCluster.CloudProvider,
+		Region:                defaultCluster.Region,
+		MultiAZ:               defaultCluster.MultiAZ,
+		SupportedInstanceType: "standard",
+	}
+
+	tests := []struct {
+		name        string
+		fields      fields
+		args        args
+		result      []string
+		wantErr     bool
+		expectedErr *errors.ServiceError
+		setupFn     func()
+	}{
+		{
+			name: "when dynamic scaling is disabled, should return all available sizes in region if capacity and limit have not been reached",
+			fields: fields{
+				connectionFactory:      db.NewMockConnectionFactory(nil),
+				kafkaConfig:            &defaultKafkaConf,
+				dataplaneClusterConfig: buildDataplaneClusterConfig(defaultDataplaneClusterConfig),
+				providerConfig:         buildProviderConfiguration("us-east-1", 1000, 1000, false),
+				clusterPlacementStrategy: &ClusterPlacementStrategyMock{
+					FindClusterFunc: func(kafka *dbapi.KafkaRequest) (*api.Cluster, error) {
+						return mocks.BuildCluster(nil), nil
+					},
+				},
+			},
+			args: args{
+				criteria: testCriteria,
+			},
+			result:  []string{"x1"},
+			wantErr: false,
+			setupFn: func() {
+				mocket.Catcher.Reset().
+					NewMock().
+					WithQuery(`SELECT * FROM "kafka_requests" WHERE region = $1 AND cloud_provider = $2 AND instance_type = $3`).
+					WithArgs(testCriteria.Region, testCriteria.Provider, testCriteria.SupportedInstanceType).
+					WithReply(nil)
+				mocket.Catcher.NewMock().WithExecException().WithQueryException()
+			},
+		},
+		{
+			name: "when dynamic scaling is enabled, should return all available sizes in region if limit has not been reached even when there is no cluster capacity",
+			fields: fields{
+				connectionFactory: db.NewMockConnectionFactory(nil),
+				kafkaConfig: &config.KafkaConfig{
+					SupportedInstanceTypes: &config.KafkaSupportedInstanceTypesConfig{
+						Configuration: config.SupportedKafkaInstanceTypesConfig{
+							SupportedKafkaInstanceTypes: []config.KafkaInstanceType{
+								{
+									Id: api.StandardTypeSupport.String(),
+									Sizes: []config.KafkaInstanceSize{
+										config.KafkaInstanceSize{
+											Id:               "x1",
+											CapacityConsumed: 1,
+										},
+										config.KafkaInstanceSize{
+											Id:               "x2",
+											CapacityConsumed: 2,
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+				dataplaneClusterConfig: dynamicScalingEnabledDataplaneClusterConfig,
+				providerConfig:         buildProviderConfiguration("us-east-1", 1000, 1000, false),
+				clusterPlacementStrategy: &ClusterPlacementStrategyMock{
+					FindClusterFunc: func(kafka *dbapi.KafkaRequest) (*api.Cluster, error) {
+						return nil, nil
+					},
+				},
+			},
+			args: args{
+				criteria: testCriteria,
+			},
+			result:  []string{"x1", "x2"},
+			wantErr: false,
+			setupFn: func() {
+				mocket.Catcher.Reset().
+					NewMock().
+					WithQuery(`SELECT * FROM "kafka_requests" WHERE region = $1 AND cloud_provider = $2 AND instance_type = $3`).
+					WithArgs(testCriteria.Region, testCriteria.Provider, testCriteria.SupportedInstanceType).
+					WithReply(nil)
+				mocket.Catcher.NewMock().WithExecException().WithQueryException()
+			},
+		},
+		{
+			name: "when dynamic scaling is enabled, should return nil if region limit has been reached",
+			fields: fields{
+				connectionFactory:      db.NewMockConnectionFactory(nil),
+				kafkaConfig:            &defaultKafkaConf,
+				dataplaneClusterConfig: dynamicScalingEnabledDataplaneClusterConfig,
+				providerConfig:         buildProviderConfiguration("us-east-1", 1, 1, false),
+				clusterPlacementStrategy: &ClusterPlacementStrategyMock{
+					FindClusterFunc: func(kafka *dbapi.KafkaRequest) (*api.Cluster, error) {
+						return mocks.BuildCluster(nil), nil
+					},
+				},
+			},
+			args: args{
+				criteria: testCriteria,
+			},
+			result:  nil,
+			wantErr: false,
+			setupFn: func() {
+				mocket.Catcher.Reset().
+					NewMock().
+					WithQuery(`SELECT * FROM "kafka_requests" WHERE region = $1 AND cloud_provider = $2 AND instance_type = $3`).
+					WithArgs(testCriteria.Region, testCriteria.Provider, testCriteria.SupportedInstanceType).
+					WithReply([]map[string]interface{}{
+						{
+							"region":         testCriteria.Region,
+							"cloud_provider": testCriteria.Provider,
+							"multi_az":       testCriteria.MultiAZ,
+							"name":           testKafkaRequestName,
+							"size_id":        "x1",
+							"instance_type":  testCriteria.SupportedInstanceType,
+						},
+					})
+				mocket.Catcher.NewMock().WithExecException().WithQueryException()
+			},
+		},
+		{
+			name: "should return nil if no cluster capacity is left",
+			fields: fields{
+				connectionFactory:      db.NewMockConnectionFactory(nil),
+				kafkaConfig:            &defaultKafkaConf,
+				dataplaneClusterConfig: buildDataplaneClusterConfig(defaultDataplaneClusterConfig),
+				providerConfig:         buildProviderConfiguration("us-east-1", 1000, 1000, false),
+				clusterPlacementStrategy: &ClusterPlacementStrategyMock{
+					FindClusterFunc: func(kafka *dbapi.KafkaRequest) (*api.Cluster, error) {
+						return nil, nil
+					},
+				},
+			},
+			args: args{
+				criteria: testCriteria,
+			},
+			setupFn: func() {
+				mocket.Catcher.Reset().
+					NewMock().
+					WithQuery(`SELECT * FROM "kafka_requests" WHERE region = $1 AND cloud_provider = $2 AND instance_type = $3`).
+					WithArgs(testCriteria.Region, testCriteria.Provider, testCriteria.SupportedInstanceType).
+					WithReply(nil)
+				mocket.Catcher.NewMock().WithExecException().WithQueryException()
+			},
+			result:  nil,
+			wantErr: false,
+		},
+		{
+			name: "should return nil if region limit has been reached",
+			fields: fields{
+				connectionFactory:      db.NewMockConnectionFactory(nil),
+				kafkaConfig:            &defaultKafkaConf,
+				dataplaneClusterConfig: buildDataplaneClusterConfig(defaultDataplaneClusterConfig),
+				providerConfig:         buildProviderConfiguration("us-east-1", 1, 1, false),
+				clusterPlacementStrategy: &ClusterPlacementStrategyMock{
+					FindClusterFunc: func(kafka *dbapi.KafkaRequest) (*api.Cluster, error) {
+						return mocks.BuildCluster(nil), nil
+					},
+				},
+			},
+			args: args{
+				criteria: testCriteria,
+			},
+			setupFn: func() {
+				mocket.Catcher.Reset().
+					NewMock().
+					WithQuery(`SELECT * FROM "kafka_requests" WHERE region = $1 AND cloud_provider = $2 AND instance_type = $3`).
+					WithArgs(testCriteria.Region, testCriteria.Provider, testCriteria.SupportedInstanceType).
+					WithReply([]map[string]interface{}{
+						{
+							"region":         testCriteria.Region,
+							"cloud_provider": testCriteria.Provider,
+							"multi_az":       testCriteria.MultiAZ,
+							"name":           testKafkaRequestName,
+							"size_id":        "x1",
+							"instance_type":  testCriteria.SupportedInstanceType,
+						},
+					})
+				mocket.Catcher.NewMock().WithExecException().WithQueryException()
+			},
+			result:  nil,
+			wantErr: false,
+		},
+		{
+			name:    "should return an error if criteria was not defined",
+			fields:  fields{},
+			args:    args{},
+			result:  nil,
+			wantErr: true,
+			expectedErr: &errors.ServiceError{
+				Code: errors.ErrorGeneral,
+			},
+		},
+		{
+			name: "should return an error if instance type in criteria is not supported",
+			fields: fields{
+				kafkaConfig: &defaultKafkaConf,
+			},
+			args: args{
+				criteria: &FindClusterCriteria{
+					Provider:              defaultCluster.CloudProvider,
+					Region:                defaultCluster.Region,
+					MultiAZ:               defaultCluster.MultiAZ,
+					SupportedInstanceType: "unsupported",
+				},
+			},
+			result:  nil,
+			wantErr: true,
+			expectedErr: &errors.ServiceError{
+				Code: errors.ErrorInstanceTypeNotSupported,
+			},
+		},
+		{
+			name: "should return an error if db query failed",
+			fields: fields{
+				connectionFactory:      db.NewMockConnectionFactory(nil),
+				kafkaConfig:            &defaultKafkaConf,
+				dataplaneClusterConfig: buildDataplaneClusterConfig(defaultDataplaneClusterConfig),
+				providerConfig:         buildProviderConfiguration("us-east-1", 1000, 1000, false),
+				clusterPlacementStrategy: &ClusterPlacementStrategyMock{
+					FindClusterFunc: func(kafka *dbapi.KafkaRequest) (*api.Cluster, error) {
+						return nil, nil
+					},
+				},
+			},
+			args: args{
+				criteria: testCriteria,
+			},
+			setupFn: func() {
+				mocket.Catcher.Reset().
+					NewMock().
+					WithQuery(`SELECT * FROM "kafka_requests" WHERE region = $1 AND cloud_provider = $2 AND instance_type = $3`).
+					WithArgs(testCriteria.Region, testCriteria.Provider, testCriteria.SupportedInstanceType).
+					WithQueryException()
+				mocket.Catcher.NewMock().WithExecException().WithQueryException()
+			},
+			result:  nil,
+			wantErr: true,
+			expectedErr: &errors.ServiceError{
+				Code: errors.ErrorGeneral,
+			},
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+
+			if tt.setupFn != nil {
+				tt.setupFn()
+			}
+
+			k := &kafkaService{
+				connectionFactory:        tt.fields.connectionFactory,
+				kafkaConfig:              tt.fields.kafkaConfig,
+				dataplaneClusterConfig:   tt.fields.dataplaneClusterConfig,
+				providerConfig:           tt.fields.providerConfig,
+				clusterPlacementStrategy: tt.fields.clusterPlacementStrategy,
+			}
+
+			got, err := k.GetAvailableSizesInRegion(tt.args.criteria)
+
+			g.Expect(tt.result).To(gomega.Equal(got))
+			g.Expect(tt.wantErr).To(gomega.Equal(err != nil))
+			if tt.wantErr && tt.expectedErr != nil {
+				g.Expect(tt.expectedErr.Code).To(gomega.Equal(err.Code))
+			}
+		})
+	}
+}
+func Test_kafkaService_ExplainPlacement(t *testing.T) {
+	type fields struct {
+		clusterService         ClusterService
+		kafkaConfig            *config.KafkaConfig
+		dataplaneClusterConfig *config.DataplaneClusterConfig
+	}
+	type args struct {
+		criteria *FindClusterCriteria
+	}
+
+	testCriteria := &FindClusterCriteria{
+		Provider:              testKafkaRequestProvider,
+		Region:                testKafkaRequestRegion,
+		MultiAZ:               true,
+		SupportedInstanceType: types.STANDARD.String(),
+	}
+
+	manualClusters := []config.ManualCluster{
+		{ClusterId: "eligible", Schedulable: true, KafkaInstanceLimit: 10},
+		{ClusterId: "unsupported-instance-type", Schedulable: true, KafkaInstanceLimit: 10},
+		{ClusterId: "full", Schedulable: true, KafkaInstanceLimit: 1},
+		{ClusterId: "unschedulable", Schedulable: false, KafkaInstanceLimit: 10},
+		{ClusterId: "not-ready", Schedulable: true, KafkaInstanceLimit: 10},
+	}
+
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    *PlacementExplanation
+		wantErr bool
+	}{
+		{
+			name:    "should return an error if criteria is not specified",
+			fields:  fields{kafkaConfig: &defaultKafkaConf},
+			args:    args{criteria: nil},
+			wantErr: true,
+		},
+		{
+			name:   "should return an error if the instance type is not supported",
+			fields: fields{kafkaConfig: &defaultKafkaConf},
+			args: args{
+				criteria: &FindClusterCriteria{SupportedInstanceType: "unsupported"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "should return an error if clusters cannot be retrieved",
+			fields: fields{
+				kafkaConfig: &defaultKafkaConf,
+				clusterService: &ClusterServiceMock{
+					FindAllClustersFunc: func(criteria FindClusterCriteria) ([]*api.Cluster, error) {
+						return nil, fmt.Errorf("failed to find clusters")
+					},
+				},
+				dataplaneClusterConfig: buildDataplaneClusterConfig(manualClusters),
+			},
+			args:    args{criteria: testCriteria},
+			wantErr: true,
+		},
+		{
+			name: "should return an empty explanation if there are no clusters in the region",
+			fields: fields{
+				kafkaConfig: &defaultKafkaConf,
+				clusterService: &ClusterServiceMock{
+					FindAllClustersFunc: func(criteria FindClusterCriteria) ([]*api.Cluster, error) {
+						return nil, nil
+					},
+				},
+				dataplaneClusterConfig: buildDataplaneClusterConfig(manualClusters),
+			},
+			args: args{criteria: testCriteria},
+			want: &PlacementExplanation{
+				Criteria: *testCriteria,
+				Clusters: []ClusterPlacementExplanation{},
+			},
+		},
+		{
+			name: "should return a reason per cluster when manual scaling is enabled",
+			fields: fields{
+				kafkaConfig: &defaultKafkaConf,
+				clusterService: &ClusterServiceMock{
+					FindAllClustersFunc: func(criteria FindClusterCriteria) ([]*api.Cluster, error) {
+						return []*api.Cluster{
+							{ClusterID: "eligible", Status: api.ClusterReady, MultiAZ: true, SupportedInstanceType: api.AllInstanceTypeSupport.String()},
+							{ClusterID: "unsupported-instance-type", Status: api.ClusterReady, MultiAZ: true, SupportedInstanceType: api.DeveloperTypeSupport.String()},
+							{ClusterID: "full", Status: api.ClusterReady, MultiAZ: true, SupportedInstanceType: api.AllInstanceTypeSupport.String()},
+							{ClusterID: "unschedulable", Status: api.ClusterReady, MultiAZ: true, SupportedInstanceType: api.AllInstanceTypeSupport.String()},
+							{ClusterID: "not-ready", Status: api.ClusterProvisioning, MultiAZ: false, SupportedInstanceType: api.AllInstanceTypeSupport.String()},
+						}, nil
+					},
+					FindKafkaInstanceCountFunc: func(clusterIDs []string) ([]ResKafkaInstanceCount, error) {
+						return []ResKafkaInstanceCount{{Clusterid: "full", Count: 1}}, nil
+					},
+				},
+				dataplaneClusterConfig: buildDataplaneClusterConfig(manualClusters),
+			},
+			args: args{criteria: testCriteria},
+			want: &PlacementExplanation{
+				Criteria: *testCriteria,
+				Clusters: []ClusterPlacementExplanation{
+					{ClusterID: "eligible", Eligible: true, Reasons: []string{}},
+					{ClusterID: "unsupported-instance-type", Eligible: false, Reasons: []string{"instance type 'standard' not supported"}},
+					{ClusterID: "full", Eligible: false, Reasons: []string{"full (kafka instance limit reached)"}},
+					{ClusterID: "unschedulable", Eligible: false, Reasons: []string{"cluster is not schedulable"}},
+					{ClusterID: "not-ready", Eligible: false, Reasons: []string{"cluster is not ready (status: cluster_provisioning)", "multi AZ mismatch (cluster: false, requested: true)"}},
+				},
+			},
+		},
+		{
+			name: "should report a cluster as full when dynamic scaling is enabled and there are no streaming units left",
+			fields: fields{
+				kafkaConfig: &defaultKafkaConf,
+				clusterService: &ClusterServiceMock{
+					FindAllClustersFunc: func(criteria FindClusterCriteria) ([]*api.Cluster, error) {
+						return []*api.Cluster{
+							{
+								ClusterID:             "full",
+								Status:                api.ClusterReady,
+								MultiAZ:               true,
+								SupportedInstanceType: api.AllInstanceTypeSupport.String(),
+								DynamicCapacityInfo:   api.JSON([]byte(`{"standard":{"max_nodes":1,"max_units":1,"remaining_units":0}}`)),
+							},
+						}, nil
+					},
+					FindStreamingUnitCountByClusterAndInstanceTypeFunc: func() (KafkaStreamingUnitCountPerClusterList, error) {
+						return KafkaStreamingUnitCountPerClusterList{
+							{ClusterId: "full", InstanceType: types.STANDARD.String(), Count: 1},
+						}, nil
+					},
+				},
+				dataplaneClusterConfig: buildDataplaneClusterConfigWithAutoscalingOn(),
+			},
+			args: args{criteria: testCriteria},
+			want: &PlacementExplanation{
+				Criteria: *testCriteria,
+				Clusters: []ClusterPlacementExplanation{
+					{ClusterID: "full", Eligible: false, Reasons: []string{"full (1/1 streaming units used)"}},
+				},
+			},
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			k := &kafkaService{
+				clusterService:         tt.fields.clusterService,
+				kafkaConfig:            tt.fields.kafkaConfig,
+				dataplaneClusterConfig: tt.fields.dataplaneClusterConfig,
+			}
+			got, err := k.ExplainPlacement(tt.args.criteria)
+			g.Expect(err != nil).To(gomega.Equal(tt.wantErr))
+			g.Expect(got).To(gomega.Equal(tt.want))
+		})
+	}
+}
+
+func Test_kafkaService_GetManagedKafkaByClusterID(t *testing.T) {
+	type fields struct {
+		connectionFactory *db.ConnectionFactory
+		keycloakService   sso.KeycloakService
+		kafkaConfig       *config.KafkaConfig
+	}
+	type args struct {
+		clusterID string
+	}
+	kafkaRequestList := dbapi.KafkaList{
+		&dbapi.KafkaRequest{
+			ClusterID:    testClusterID,
+			InstanceType: "developer",
+			SizeId:       "x1",
+		},
+	}
+	managedkafkaCR, _ := buildManagedKafkaCR(
+		&dbapi.KafkaRequest{
+			ClusterID:    testClusterID,
+			InstanceType: "developer",
+			SizeId:       "x1",
+		},
+		&config.KafkaConfig{
+			EnableKafkaExternalCertificate: true,
+			EnableKafkaCNAMERegistration:   true,
+			SupportedInstanceTypes:         &kafkaSupportedInstanceTypesConfig,
+		},
+		&sso.KeycloakServiceMock{
+			GetConfigFunc: func() *keycloak.KeycloakConfig {
+				return &keycloak.KeycloakConfig{
+					EnableAuthenticationOnKafka: true,
+				}
+			},
+			GetRealmConfigFunc: func() *keycloak.KeycloakRealmConfig {
+				return &keycloak.KeycloakRealmConfig{}
+			},
+		})
 
-	dynamicScalingEnabledDataplaneClusterConfig := config.NewDataplaneClusterConfig()
-	dynamicScalingEnabledDataplaneClusterConfig.DataPlaneClusterScalingType = config.AutoScaling
-	testCriteria := &FindClusterCriteria{
-		Provider:              defaultCluster.CloudProvider,
-		Region:                defaultCluster.Region,
-		MultiAZ:               defaultCluster.MultiAZ,
-		SupportedInstanceType: "standard",
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    []managedkafka.ManagedKafka
+		wantErr *errors.ServiceError
+		setupFn func()
+	}{
+		{
+			name: "should return the kafka by cluster id",
+			fields: fields{
+				connectionFactory: db.NewMockConnectionFactory(nil),
+				keycloakService: &sso.KeycloakServiceMock{
+					GetConfigFunc: func() *keycloak.KeycloakConfig {
+						return &keycloak.KeycloakConfig{
+							EnableAuthenticationOnKafka: true,
+						}
+					},
+					GetRealmConfigFunc: func() *keycloak.KeycloakRealmConfig {
+						return &keycloak.KeycloakRealmConfig{}
+					},
+				},
+				kafkaConfig: &config.KafkaConfig{
+					EnableKafkaExternalCertificate: true,
+					EnableKafkaCNAMERegistration:   true,
+					SupportedInstanceTypes:         &kafkaSupportedInstanceTypesConfig,
+				},
+			},
+			args: args{
+				clusterID: testClusterID,
+			},
+			wantErr: nil,
+			want:    []managedkafka.ManagedKafka{*managedkafkaCR},
+			setupFn: func() {
+				mocket.Catcher.Reset()
+				query := fmt.Sprintf(`SELECT * FROM "%s"`, kafkaRequestTableName)
+				response := converters.ConvertKafkaRequestList(kafkaRequestList)
+				mocket.Catcher.NewMock().WithQuery(query).WithReply(response)
+				mocket.Catcher.NewMock().WithExecException().WithQueryException()
+			},
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		tt.setupFn()
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			k := &kafkaService{
+				connectionFactory: tt.fields.connectionFactory,
+				keycloakService:   tt.fields.keycloakService,
+				kafkaConfig:       tt.fields.kafkaConfig,
+			}
+			got, err := k.GetManagedKafkaByClusterID(tt.args.clusterID)
+			g.Expect(got).To(gomega.Equal(tt.want))
+			g.Expect(err).To(gomega.Equal(tt.wantErr))
+		})
+	}
+}
+
+func Test_kafkaService_GetManagedKafkaByClusterID_ReconciliationPaused(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	buildKafka := func(name string, paused bool) *dbapi.KafkaRequest {
+		return buildKafkaRequest(func(kafkaRequest *dbapi.KafkaRequest) {
+			kafkaRequest.ID = name
+			kafkaRequest.Name = name
+			kafkaRequest.InstanceType = "developer"
+			kafkaRequest.ReconciliationPaused = paused
+		})
+	}
+	activeKafka := buildKafka("active-kafka", false)
+	pausedKafka := buildKafka("paused-kafka", true)
+	reply := converters.ConvertKafkaRequestList(dbapi.KafkaList{activeKafka, pausedKafka})
+	reply[0]["reconciliation_paused"] = activeKafka.ReconciliationPaused
+	reply[1]["reconciliation_paused"] = pausedKafka.ReconciliationPaused
+
+	mocket.Catcher.Reset()
+	mocket.Catcher.NewMock().
+		WithQuery(`SELECT * FROM "kafka_requests" WHERE cluster_id = $1`).
+		WithReply(reply)
+	mocket.Catcher.NewMock().WithExecException().WithQueryException()
+
+	k := &kafkaService{
+		connectionFactory: db.NewMockConnectionFactory(nil),
+		keycloakService: &sso.KeycloakServiceMock{
+			GetConfigFunc: func() *keycloak.KeycloakConfig {
+				return &keycloak.KeycloakConfig{}
+			},
+			GetRealmConfigFunc: func() *keycloak.KeycloakRealmConfig {
+				return &keycloak.KeycloakRealmConfig{}
+			},
+		},
+		kafkaConfig: &config.KafkaConfig{
+			SupportedInstanceTypes: &kafkaSupportedInstanceTypesConfig,
+		},
 	}
 
+	// the CR of the paused kafka is kept so that the agent does not deprovision it, but it is annotated
+	managedKafkas, err := k.GetManagedKafkaByClusterID(testClusterID)
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(managedKafkas).To(gomega.HaveLen(2))
+	g.Expect(managedKafkas[0].Id).To(gomega.Equal(activeKafka.ID))
+	g.Expect(managedKafkas[0].Annotations).ToNot(gomega.HaveKey("bf2.org/pauseReconciliation"))
+	g.Expect(managedKafkas[1].Id).To(gomega.Equal(pausedKafka.ID))
+	g.Expect(managedKafkas[1].Annotations).To(gomega.HaveKeyWithValue("bf2.org/pauseReconciliation", "true"))
+}
+
+func Test_kafkaService_PauseReconciliation(t *testing.T) {
 	tests := []struct {
 		name        string
-		fields      fields
-		args        args
-		result      []string
-		wantErr     bool
-		expectedErr *errors.ServiceError
-		setupFn     func()
+		id          string
+		pause       bool
+		rowsNum     int64
+		wantErrCode errors.ServiceErrorCode
+	}{
+		{
+			name:    "should pause the reconciliation of a kafka",
+			id:      testID,
+			pause:   true,
+			rowsNum: 1,
+		},
+		{
+			name:    "should resume the reconciliation of a kafka",
+			id:      testID,
+			pause:   false,
+			rowsNum: 1,
+		},
+		{
+			name:        "should return a not found error when the kafka does not exist",
+			id:          testID,
+			pause:       true,
+			rowsNum:     0,
+			wantErrCode: errors.ErrorNotFound,
+		},
+		{
+			name:        "should return a validation error when the id is undefined",
+			id:          "",
+			pause:       true,
+			wantErrCode: errors.ErrorValidation,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+
+			var pausedArg interface{}
+			mocket.Catcher.Reset()
+			mocket.Catcher.NewMock().
+				WithQuery(`UPDATE "kafka_requests" SET "reconciliation_paused"=$1`).
+				WithCallback(func(_ string, args []driver.NamedValue) {
+					pausedArg = args[0].Value
+				}).
+				WithRowsNum(tt.rowsNum)
+			mocket.Catcher.NewMock().WithExecException().WithQueryException()
+
+			k := &kafkaService{
+				connectionFactory: db.NewMockConnectionFactory(nil),
+			}
+			var err *errors.ServiceError
+			if tt.pause {
+				err = k.PauseReconciliation(tt.id)
+			} else {
+				err = k.ResumeReconciliation(tt.id)
+			}
+			if tt.wantErrCode != 0 {
+				g.Expect(err).ToNot(gomega.BeNil())
+				g.Expect(err.Code).To(gomega.Equal(tt.wantErrCode))
+				return
+			}
+			g.Expect(err).To(gomega.BeNil())
+			g.Expect(pausedArg).To(gomega.Equal(tt.pause))
+		})
+	}
+}
+
+func Test_kafkaService_GetManagedKafkaByClusterIDChangedSince(t *testing.T) {
+	g := gomega.NewWithT(t)
+	since := time.Now().Add(-time.Minute)
+
+	buildKafka := func(name string, updatedAt time.Time) *dbapi.KafkaRequest {
+		return buildKafkaRequest(func(kafkaRequest *dbapi.KafkaRequest) {
+			kafkaRequest.ID = name
+			kafkaRequest.Name = name
+			kafkaRequest.InstanceType = "developer"
+			kafkaRequest.UpdatedAt = updatedAt
+		})
+	}
+	unchangedKafka := buildKafka("unchanged-kafka", since.Add(-time.Hour))
+	modifiedKafka := buildKafka("modified-kafka", since.Add(30*time.Second))
+	removedKafka := buildKafka("removed-kafka", since.Add(-time.Hour))
+	removedKafka.DeletedAt = gorm.DeletedAt{Time: since.Add(10 * time.Second), Valid: true}
+
+	var sinceArg, deletedSinceArg interface{}
+	mocket.Catcher.Reset()
+	// the database only returns the kafkas updated after the given time
+	mocket.Catcher.NewMock().
+		WithQuery(`AND bootstrap_server_host != '' AND updated_at > $`).
+		WithCallback(func(_ string, args []driver.NamedValue) {
+			sinceArg = args[len(args)-1].Value
+		}).
+		WithReply(converters.ConvertKafkaRequestList(dbapi.KafkaList{modifiedKafka}))
+	// and the kafkas deleted after the given time, the soft deleted rows being included
+	mocket.Catcher.NewMock().
+		WithQuery(`SELECT * FROM "kafka_requests" WHERE cluster_id = $1 AND bootstrap_server_host != '' AND deleted_at > $2`).
+		WithCallback(func(_ string, args []driver.NamedValue) {
+			deletedSinceArg = args[len(args)-1].Value
+		}).
+		WithReply(converters.ConvertKafkaRequestList(dbapi.KafkaList{removedKafka}))
+	mocket.Catcher.NewMock().
+		WithQuery(`SELECT * FROM "kafka_requests" WHERE cluster_id = $1`).
+		WithReply(converters.ConvertKafkaRequestList(dbapi.KafkaList{unchangedKafka, modifiedKafka}))
+	mocket.Catcher.NewMock().WithExecException().WithQueryException()
+
+	k := &kafkaService{
+		connectionFactory: db.NewMockConnectionFactory(nil),
+		keycloakService: &sso.KeycloakServiceMock{
+			GetConfigFunc: func() *keycloak.KeycloakConfig {
+				return &keycloak.KeycloakConfig{}
+			},
+			GetRealmConfigFunc: func() *keycloak.KeycloakRealmConfig {
+				return &keycloak.KeycloakRealmConfig{}
+			},
+		},
+		kafkaConfig: &config.KafkaConfig{
+			SupportedInstanceTypes: &kafkaSupportedInstanceTypesConfig,
+		},
+	}
+
+	changed, err := k.GetManagedKafkaByClusterIDChangedSince(testClusterID, since)
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(sinceArg).To(gomega.BeTemporally("==", since))
+	g.Expect(deletedSinceArg).To(gomega.BeTemporally("==", since))
+	g.Expect(changed).To(gomega.HaveLen(2))
+	g.Expect(changed[0].Id).To(gomega.Equal(modifiedKafka.ID))
+	g.Expect(changed[0].Spec.Deleted).To(gomega.BeFalse())
+	g.Expect(changed[1].Id).To(gomega.Equal(removedKafka.ID))
+	g.Expect(changed[1].Spec.Deleted).To(gomega.BeTrue())
+
+	// the unchanged kafka is still returned when all the managed kafkas are requested
+	all, err := k.GetManagedKafkaByClusterID(testClusterID)
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(all).To(gomega.HaveLen(2))
+}
+
+func Test_kafkaService_GenerateReservedManagedKafkasByClusterID(t *testing.T) {
+	type fields struct {
+		connectionFactory      *db.ConnectionFactory
+		kafkaConfig            *config.KafkaConfig
+		clusterService         ClusterService
+		dataplaneClusterConfig *config.DataplaneClusterConfig
+	}
+	type args struct {
+		clusterID string
+	}
+
+	strimziOperatorVersion := "strimzi-cluster-operator.from-cluster"
+
+	testDeveloperInstanceType, err := kafkaSupportedInstanceTypesConfig.Configuration.GetKafkaInstanceTypeByID("developer")
+	if err != nil {
+		panic("unexpected test error")
+	}
+	testDeveloperX1InstanceSize, err := testDeveloperInstanceType.GetKafkaInstanceSizeByID("x1")
+	if err != nil {
+		panic("unexpected test error")
+	}
+	testStandardInstanceType, err := kafkaSupportedInstanceTypesConfig.Configuration.GetKafkaInstanceTypeByID("standard")
+	if err != nil {
+		panic("unexpected test error")
+	}
+	testStandardX1InstanceSize, err := testStandardInstanceType.GetKafkaInstanceSizeByID("x1")
+	if err != nil {
+		panic("unexpected test error")
+	}
+
+	testAvailableStrimziVersions := []api.StrimziVersion{
+		{
+			Version: strimziOperatorVersion,
+			Ready:   true,
+			KafkaVersions: []api.KafkaVersion{
+				{
+					Version: "2.7.0",
+				},
+			},
+			KafkaIBPVersions: []api.KafkaIBPVersion{
+				{
+					Version: "2.7",
+				},
+			},
+		},
+	}
+	marshaledTestAvailableStrimziVersions, err := json.Marshal(testAvailableStrimziVersions)
+	if err != nil {
+		panic("unexpected test error")
+	}
+
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    []managedkafka.ManagedKafka
+		wantErr bool
 	}{
 		{
-			name: "when dynamic scaling is disabled, should return all available sizes in region if capacity and limit have not been reached",
+			name: "returns generated managed kafkas",
+			fields: fields{
+				connectionFactory: db.NewMockConnectionFactory(nil),
+				kafkaConfig: &config.KafkaConfig{
+					EnableKafkaExternalCertificate: true,
+					EnableKafkaCNAMERegistration:   true,
+					SupportedInstanceTypes:         &kafkaSupportedInstanceTypesConfig,
+				},
+				dataplaneClusterConfig: &config.DataplaneClusterConfig{
+					NodePrewarmingConfig: config.NodePrewarmingConfig{
+						Configuration: map[string]config.InstanceTypeNodePrewarmingConfig{
+							"developer": {
+								NumReservedInstances: 1,
+							},
+							"standard": {
+								NumReservedInstances: 2,
+							},
+						},
+					},
+				},
+				clusterService: &ClusterServiceMock{
+					FindClusterByIDFunc: func(clusterID string) (*api.Cluster, *errors.ServiceError) {
+						return &api.Cluster{
+							ClusterID:                clusterID,
+							Status:                   api.ClusterReady,
+							SupportedInstanceType:    "developer,standard",
+							AvailableStrimziVersions: marshaledTestAvailableStrimziVersions,
+						}, nil
+					},
+				},
+			},
+			args: args{
+				clusterID: testClusterID,
+			},
+			wantErr: false,
+			want: []managedkafka.ManagedKafka{
+				managedkafka.ManagedKafka{
+					Id: "reserved-kafka-developer-1",
+					TypeMeta: metav1.TypeMeta{
+						Kind:       "ManagedKafka",
+						APIVersion: "managedkafka.bf2.org/v1alpha1",
+					},
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "reserved-kafka-developer-1",
+						Namespace: "reserved-kafka-developer-1",
+						Annotations: map[string]string{
+							"bf2.org/id":          "reserved-kafka-developer-1",
+							"bf2.org/placementId": "reserved-kafka-developer-1",
+						},
+						Labels: map[string]string{
+							"bf2.org/kafkaInstanceProfileQuotaConsumed":    strconv.Itoa(testDeveloperX1InstanceSize.QuotaConsumed),
+							"bf2.org/kafkaInstanceProfileType":             "developer",
+							managedkafka.ManagedKafkaBf2DeploymentLabelKey: managedkafka.ManagedKafkaBf2DeploymentLabelValueReserved,
+						},
+					},
+					Spec: managedkafka.ManagedKafkaSpec{
+						Capacity: managedkafka.Capacity{
+							IngressPerSec:               testDeveloperX1InstanceSize.IngressThroughputPerSec.String(),
+							EgressPerSec:                testDeveloperX1InstanceSize.EgressThroughputPerSec.String(),
+							TotalMaxConnections:         testDeveloperX1InstanceSize.TotalMaxConnections,
+							MaxDataRetentionSize:        testDeveloperX1InstanceSize.MaxDataRetentionSize.String(),
+							MaxPartitions:               testDeveloperX1InstanceSize.MaxPartitions,
+							MaxDataRetentionPeriod:      testDeveloperX1InstanceSize.MaxDataRetentionPeriod,
+							MaxConnectionAttemptsPerSec: testDeveloperX1InstanceSize.MaxConnectionAttemptsPerSec,
+						},
+						Endpoint: managedkafka.EndpointSpec{
+							BootstrapServerHost: fmt.Sprintf("%s-dummyhost", "reserved-kafka-developer-1"),
+						},
+						Versions: managedkafka.VersionsSpec{
+							Strimzi:  testAvailableStrimziVersions[0].Version,
+							Kafka:    testAvailableStrimziVersions[0].KafkaVersions[0].Version,
+							KafkaIBP: testAvailableStrimziVersions[0].GetLatestKafkaIBPVersion().Version,
+						},
+						Deleted: false,
+						Owners:  []string{},
+					},
+					Status: managedkafka.ManagedKafkaStatus{},
+				},
+				managedkafka.ManagedKafka{
+					Id: "reserved-kafka-standard-1",
+					TypeMeta: metav1.TypeMeta{
+						Kind:       "ManagedKafka",
+						APIVersion: "managedkafka.bf2.org/v1alpha1",
+					},
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "reserved-kafka-standard-1",
+						Namespace: "reserved-kafka-standard-1",
+						Annotations: map[string]string{
+							"bf2.org/id":          "reserved-kafka-standard-1",
+							"bf2.org/placementId": "reserved-kafka-standard-1",
+						},
+						Labels: map[string]string{
+							"bf2.org/kafkaInstanceProfileQuotaConsumed":    strconv.Itoa(testStandardX1InstanceSize.QuotaConsumed),
+							"bf2.org/kafkaInstanceProfileType":             "standard",
+							managedkafka.ManagedKafkaBf2DeploymentLabelKey: managedkafka.ManagedKafkaBf2DeploymentLabelValueReserved,
+						},
+					},
+					Spec: managedkafka.ManagedKafkaSpec{
+						Capacity: managedkafka.Capacity{
+							IngressPerSec:               testStandardX1InstanceSize.IngressThroughputPerSec.String(),
+							EgressPerSec:                testStandardX1InstanceSize.EgressThroughputPerSec.String(),
+							TotalMaxConnections:         testStandardX1InstanceSize.TotalMaxConnections,
+							MaxDataRetentionSize:        testStandardX1InstanceSize.MaxDataRetentionSize.String(),
+							MaxPartitions:               testStandardX1InstanceSize.MaxPartitions,
+							MaxDataRetentionPeriod:      testStandardX1InstanceSize.MaxDataRetentionPeriod,
+							MaxConnectionAttemptsPerSec: testStandardX1InstanceSize.MaxConnectionAttemptsPerSec,
+						},
+						Endpoint: managedkafka.EndpointSpec{
+							BootstrapServerHost: fmt.Sprintf("%s-dummyhost", "reserved-kafka-standard-1"),
+						},
+						Versions: managedkafka.VersionsSpec{
+							Strimzi:  testAvailableStrimziVersions[0].Version,
+							Kafka:    testAvailableStrimziVersions[0].KafkaVersions[0].Version,
+							KafkaIBP: testAvailableStrimziVersions[0].GetLatestKafkaIBPVersion().Version,
+						},
+						Deleted: false,
+						Owners:  []string{},
+					},
+					Status: managedkafka.ManagedKafkaStatus{},
+				},
+				managedkafka.ManagedKafka{
+					Id: "reserved-kafka-standard-2",
+					TypeMeta: metav1.TypeMeta{
+						Kind:       "ManagedKafka",
+						APIVersion: "managedkafka.bf2.org/v1alpha1",
+					},
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "reserved-kafka-standard-2",
+						Namespace: "reserved-kafka-standard-2",
+						Annotations: map[string]string{
+							"bf2.org/id":          "reserved-kafka-standard-2",
+							"bf2.org/placementId": "reserved-kafka-standard-2",
+						},
+						Labels: map[string]string{
+							"bf2.org/kafkaInstanceProfileQuotaConsumed":    strconv.Itoa(testStandardX1InstanceSize.QuotaConsumed),
+							"bf2.org/kafkaInstanceProfileType":             "standard",
+							managedkafka.ManagedKafkaBf2DeploymentLabelKey: managedkafka.ManagedKafkaBf2DeploymentLabelValueReserved,
+						},
+					},
+					Spec: managedkafka.ManagedKafkaSpec{
+						Capacity: managedkafka.Capacity{
+							IngressPerSec:               testStandardX1InstanceSize.IngressThroughputPerSec.String(),
+							EgressPerSec:                testStandardX1InstanceSize.EgressThroughputPerSec.String(),
+							TotalMaxConnections:         testStandardX1InstanceSize.TotalMaxConnections,
+							MaxDataRetentionSize:        testStandardX1InstanceSize.MaxDataRetentionSize.String(),
+							MaxPartitions:               testStandardX1InstanceSize.MaxPartitions,
+							MaxDataRetentionPeriod:      testStandardX1InstanceSize.MaxDataRetentionPeriod,
+							MaxConnectionAttemptsPerSec: testStandardX1InstanceSize.MaxConnectionAttemptsPerSec,
+						},
+						Endpoint: managedkafka.EndpointSpec{
+							BootstrapServerHost: fmt.Sprintf("%s-dummyhost", "reserved-kafka-standard-2"),
+						},
+						Versions: managedkafka.VersionsSpec{
+							Strimzi:  testAvailableStrimziVersions[0].Version,
+							Kafka:    testAvailableStrimziVersions[0].KafkaVersions[0].Version,
+							KafkaIBP: testAvailableStrimziVersions[0].GetLatestKafkaIBPVersion().Version,
+						},
+						Deleted: false,
+						Owners:  []string{},
+					},
+					Status: managedkafka.ManagedKafkaStatus{},
+				},
+			},
+		},
+		{
+			name: "returns an empty list when pre warming is not enabled for any instance type",
 			fields: fields{
-				connectionFactory:      db.NewMockConnectionFactory(nil),
-				kafkaConfig:            &defaultKafkaConf,
-				dataplaneClusterConfig: buildDataplaneClusterConfig(defaultDataplaneClusterConfig),
-				providerConfig:         buildProviderConfiguration("us-east-1", 1000, 1000, false),
-				clusterPlacementStrategy: &ClusterPlacementStrategyMock{
-					FindClusterFunc: func(kafka *dbapi.KafkaRequest) (*api.Cluster, error) {
-						return mocks.BuildCluster(nil), nil
+				dataplaneClusterConfig: &config.DataplaneClusterConfig{
+					NodePrewarmingConfig: config.NodePrewarmingConfig{
+						Configuration: map[string]config.InstanceTypeNodePrewarmingConfig{},
+					},
+				},
+				clusterService: &ClusterServiceMock{
+					FindClusterByIDFunc: func(clusterID string) (*api.Cluster, *errors.ServiceError) {
+						return &api.Cluster{
+							ClusterID:                clusterID,
+							Status:                   api.ClusterReady,
+							SupportedInstanceType:    "developer,standard",
+							AvailableStrimziVersions: marshaledTestAvailableStrimziVersions,
+						}, nil
 					},
 				},
 			},
 			args: args{
-				criteria: testCriteria,
-			},
-			result:  []string{"x1"},
-			wantErr: false,
-			setupFn: func() {
-				mocket.Catcher.Reset().
-					NewMock().
-					WithQuery(`SELECT * FROM "kafka_requests" WHERE region = $1 AND cloud_provider = $2 AND instance_type = $3`).
-					WithArgs(testCriteria.Region, testCriteria.Provider, testCriteria.SupportedInstanceType).
-					WithReply(nil)
-				mocket.Catcher.NewMock().WithExecException().WithQueryException()
+				clusterID: testClusterID,
 			},
+			want: []managedkafka.ManagedKafka{},
 		},
 		{
-			name: "when dynamic scaling is enabled, should return all available sizes in region if limit has not been reached even when there is no cluster capacity",
+			name: "an error is returned when the provided ClusterID does not exist",
 			fields: fields{
-				connectionFactory: db.NewMockConnectionFactory(nil),
-				kafkaConfig: &config.KafkaConfig{
-					SupportedInstanceTypes: &config.KafkaSupportedInstanceTypesConfig{
-						Configuration: config.SupportedKafkaInstanceTypesConfig{
-							SupportedKafkaInstanceTypes: []config.KafkaInstanceType{
-								{
-									Id: api.StandardTypeSupport.String(),
-									Sizes: []config.KafkaInstanceSize{
-										config.KafkaInstanceSize{
-											Id:               "x1",
-											CapacityConsumed: 1,
-										},
-										config.KafkaInstanceSize{
-											Id:               "x2",
-											CapacityConsumed: 2,
-										},
-									},
-								},
-							},
-						},
-					},
+				dataplaneClusterConfig: &config.DataplaneClusterConfig{
+					DataPlaneClusterScalingType: config.AutoScaling,
 				},
-				dataplaneClusterConfig: dynamicScalingEnabledDataplaneClusterConfig,
-				providerConfig:         buildProviderConfiguration("us-east-1", 1000, 1000, false),
-				clusterPlacementStrategy: &ClusterPlacementStrategyMock{
-					FindClusterFunc: func(kafka *dbapi.KafkaRequest) (*api.Cluster, error) {
+				clusterService: &ClusterServiceMock{
+					FindClusterByIDFunc: func(clusterID string) (*api.Cluster, *errors.ServiceError) {
 						return nil, nil
 					},
 				},
 			},
 			args: args{
-				criteria: testCriteria,
+				clusterID: testClusterID,
 			},
-			result:  []string{"x1", "x2"},
-			wantErr: false,
-			setupFn: func() {
-				mocket.Catcher.Reset().
-					NewMock().
-					WithQuery(`SELECT * FROM "kafka_requests" WHERE region = $1 AND cloud_provider = $2 AND instance_type = $3`).
-					WithArgs(testCriteria.Region, testCriteria.Provider, testCriteria.SupportedInstanceType).
-					WithReply(nil)
-				mocket.Catcher.NewMock().WithExecException().WithQueryException()
+			wantErr: true,
+		},
+		{
+			name: "an error is returned when trying to get the provided cluster returns an error",
+			fields: fields{
+				dataplaneClusterConfig: &config.DataplaneClusterConfig{
+					DataPlaneClusterScalingType: config.AutoScaling,
+				},
+				clusterService: &ClusterServiceMock{
+					FindClusterByIDFunc: func(clusterID string) (*api.Cluster, *errors.ServiceError) {
+						return nil, errors.GeneralError("test error")
+					},
+				},
+			},
+			args: args{
+				clusterID: testClusterID,
 			},
+			wantErr: true,
 		},
 		{
-			name: "when dynamic scaling is enabled, should return nil if region limit has been reached",
+			name: "an empty list is returned when the provided ClusterID is not in ready status",
 			fields: fields{
-				connectionFactory:      db.NewMockConnectionFactory(nil),
-				kafkaConfig:            &defaultKafkaConf,
-				dataplaneClusterConfig: dynamicScalingEnabledDataplaneClusterConfig,
-				providerConfig:         buildProviderConfiguration("us-east-1", 1, 1, false),
-				clusterPlacementStrategy: &ClusterPlacementStrategyMock{
-					FindClusterFunc: func(kafka *dbapi.KafkaRequest) (*api.Cluster, error) {
-						return mocks.BuildCluster(nil), nil
+				dataplaneClusterConfig: &config.DataplaneClusterConfig{
+					DataPlaneClusterScalingType: config.AutoScaling,
+				},
+				clusterService: &ClusterServiceMock{
+					FindClusterByIDFunc: func(clusterID string) (*api.Cluster, *errors.ServiceError) {
+						return &api.Cluster{
+							ClusterID: clusterID,
+							Status:    api.ClusterWaitingForKasFleetShardOperator,
+						}, nil
+					},
+				},
+			},
+			args: args{
+				clusterID: testClusterID,
+			},
+			want: []managedkafka.ManagedKafka{},
+		},
+		{
+			name: "an error is returned when there are no ready strimzi versions",
+			fields: fields{
+				dataplaneClusterConfig: &config.DataplaneClusterConfig{},
+				clusterService: &ClusterServiceMock{
+					FindClusterByIDFunc: func(clusterID string) (*api.Cluster, *errors.ServiceError) {
+						availableStrimziVersions, err := json.Marshal([]api.StrimziVersion{
+							{
+								Version: strimziOperatorVersion,
+								Ready:   false,
+								KafkaVersions: []api.KafkaVersion{
+									{
+										Version: "2.7.0",
+									},
+								},
+								KafkaIBPVersions: []api.KafkaIBPVersion{
+									{
+										Version: "2.7",
+									},
+								},
+							},
+						})
+						if err != nil {
+							t.Fatal("failed to convert available strimzi versions to json")
+						}
+						return &api.Cluster{
+							ClusterID:                clusterID,
+							Status:                   api.ClusterReady,
+							AvailableStrimziVersions: availableStrimziVersions,
+						}, nil
 					},
 				},
 			},
 			args: args{
-				criteria: testCriteria,
-			},
-			result:  nil,
-			wantErr: false,
-			setupFn: func() {
-				mocket.Catcher.Reset().
-					NewMock().
-					WithQuery(`SELECT * FROM "kafka_requests" WHERE region = $1 AND cloud_provider = $2 AND instance_type = $3`).
-					WithArgs(testCriteria.Region, testCriteria.Provider, testCriteria.SupportedInstanceType).
-					WithReply([]map[string]interface{}{
-						{
-							"region":         testCriteria.Region,
-							"cloud_provider": testCriteria.Provider,
-							"multi_az":       testCriteria.MultiAZ,
-							"name":           testKafkaRequestName,
-							"size_id":        "x1",
-							"instance_type":  testCriteria.SupportedInstanceType,
-						},
-					})
-				mocket.Catcher.NewMock().WithExecException().WithQueryException()
+				clusterID: testClusterID,
 			},
+			wantErr: true,
 		},
 		{
-			name: "should return nil if no cluster capacity is left",
+			name: "an error is returned when there are no kafka versions in the latest ready strimzi version",
 			fields: fields{
-				connectionFactory:      db.NewMockConnectionFactory(nil),
-				kafkaConfig:            &defaultKafkaConf,
-				dataplaneClusterConfig: buildDataplaneClusterConfig(defaultDataplaneClusterConfig),
-				providerConfig:         buildProviderConfiguration("us-east-1", 1000, 1000, false),
-				clusterPlacementStrategy: &ClusterPlacementStrategyMock{
-					FindClusterFunc: func(kafka *dbapi.KafkaRequest) (*api.Cluster, error) {
-						return nil, nil
+				kafkaConfig: &config.KafkaConfig{
+					SupportedInstanceTypes: &kafkaSupportedInstanceTypesConfig,
+				},
+				dataplaneClusterConfig: &config.DataplaneClusterConfig{
+					DataPlaneClusterScalingType: config.AutoScaling,
+					NodePrewarmingConfig: config.NodePrewarmingConfig{
+						Configuration: map[string]config.InstanceTypeNodePrewarmingConfig{
+							"developer": {
+								NumReservedInstances: 1,
+							},
+							"standard": {
+								NumReservedInstances: 1,
+							},
+						},
+					},
+				},
+				clusterService: &ClusterServiceMock{
+					FindClusterByIDFunc: func(clusterID string) (*api.Cluster, *errors.ServiceError) {
+						availableStrimziVersions, err := json.Marshal([]api.StrimziVersion{
+							{
+								Version:       strimziOperatorVersion,
+								Ready:         true,
+								KafkaVersions: []api.KafkaVersion{},
+								KafkaIBPVersions: []api.KafkaIBPVersion{
+									{
+										Version: "2.7",
+									},
+								},
+							},
+						})
+						if err != nil {
+							t.Fatal("failed to convert available strimzi versions to json")
+						}
+						return &api.Cluster{
+							ClusterID:                clusterID,
+							Status:                   api.ClusterReady,
+							AvailableStrimziVersions: availableStrimziVersions,
+							SupportedInstanceType:    "developer",
+						}, nil
 					},
 				},
 			},
 			args: args{
-				criteria: testCriteria,
-			},
-			setupFn: func() {
-				mocket.Catcher.Reset().
-					NewMock().
-					WithQuery(`SELECT * FROM "kafka_requests" WHERE region = $1 AND cloud_provider = $2 AND instance_type = $3`).
-					WithArgs(testCriteria.Region, testCriteria.Provider, testCriteria.SupportedInstanceType).
-					WithReply(nil)
-				mocket.Catcher.NewMock().WithExecException().WithQueryException()
+				clusterID: testClusterID,
 			},
-			result:  nil,
-			wantErr: false,
+			wantErr: true,
 		},
 		{
-			name: "should return nil if region limit has been reached",
+			name: "an error is returned when there are no kafka ibp versions in the latest ready strimzi version",
 			fields: fields{
-				connectionFactory:      db.NewMockConnectionFactory(nil),
-				kafkaConfig:            &defaultKafkaConf,
-				dataplaneClusterConfig: buildDataplaneClusterConfig(defaultDataplaneClusterConfig),
-				providerConfig:         buildProviderConfiguration("us-east-1", 1, 1, false),
-				clusterPlacementStrategy: &ClusterPlacementStrategyMock{
-					FindClusterFunc: func(kafka *dbapi.KafkaRequest) (*api.Cluster, error) {
-						return mocks.BuildCluster(nil), nil
+				kafkaConfig: &config.KafkaConfig{
+					SupportedInstanceTypes: &kafkaSupportedInstanceTypesConfig,
+				},
+				dataplaneClusterConfig: &config.DataplaneClusterConfig{
+					NodePrewarmingConfig: config.NodePrewarmingConfig{
+						Configuration: map[string]config.InstanceTypeNodePrewarmingConfig{
+							"developer": {
+								NumReservedInstances: 1,
+							},
+							"standard": {
+								NumReservedInstances: 1,
+							},
+						},
+					},
+				},
+				clusterService: &ClusterServiceMock{
+					FindClusterByIDFunc: func(clusterID string) (*api.Cluster, *errors.ServiceError) {
+						availableStrimziVersions, err := json.Marshal([]api.StrimziVersion{
+							{
+								Version: strimziOperatorVersion,
+								Ready:   true,
+								KafkaVersions: []api.KafkaVersion{
+									api.KafkaVersion{
+										Version: "2.7.0",
+									},
+								},
+								KafkaIBPVersions: []api.KafkaIBPVersion{},
+							},
+						})
+						if err != nil {
+							t.Fatal("failed to convert available strimzi versions to json")
+						}
+						return &api.Cluster{
+							ClusterID:                clusterID,
+							Status:                   api.ClusterReady,
+							AvailableStrimziVersions: availableStrimziVersions,
+							SupportedInstanceType:    "developer",
+						}, nil
 					},
 				},
 			},
 			args: args{
-				criteria: testCriteria,
-			},
-			setupFn: func() {
-				mocket.Catcher.Reset().
-					NewMock().
-					WithQuery(`SELECT * FROM "kafka_requests" WHERE region = $1 AND cloud_provider = $2 AND instance_type = $3`).
-					WithArgs(testCriteria.Region, testCriteria.Provider, testCriteria.SupportedInstanceType).
-					WithReply([]map[string]interface{}{
-						{
-							"region":         testCriteria.Region,
-							"cloud_provider": testCriteria.Provider,
-							"multi_az":       testCriteria.MultiAZ,
-							"name":           testKafkaRequestName,
-							"size_id":        "x1",
-							"instance_type":  testCriteria.SupportedInstanceType,
-						},
-					})
-				mocket.Catcher.NewMock().WithExecException().WithQueryException()
+				clusterID: testClusterID,
 			},
-			result:  nil,
-			wantErr: false,
+			wantErr: true,
 		},
+	}
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			g := gomega.NewWithT(t)
+			k := &kafkaService{
+				connectionFactory:      tt.fields.connectionFactory,
+				clusterService:         tt.fields.clusterService,
+				kafkaConfig:            tt.fields.kafkaConfig,
+				dataplaneClusterConfig: tt.fields.dataplaneClusterConfig,
+			}
+			got, err := k.GenerateReservedManagedKafkasByClusterID(tt.args.clusterID)
+			g.Expect(err != nil).To(gomega.Equal(tt.wantErr))
+			g.Expect(got).Should(gomega.HaveLen(len(tt.want)))
+			g.Expect(got).To(gomega.Equal(tt.want))
+		})
+	}
+}
+
+func Test_kafkaService_GetStreamingUnitUsageByClusterID(t *testing.T) {
+	testStandardInstanceType, err := kafkaSupportedInstanceTypesConfig.Configuration.GetKafkaInstanceTypeByID("standard")
+	if err != nil {
+		panic("unexpected test error")
+	}
+	testStandardX1InstanceSize, err := testStandardInstanceType.GetKafkaInstanceSizeByID("x1")
+	if err != nil {
+		panic("unexpected test error")
+	}
+	testDeveloperInstanceType, err := kafkaSupportedInstanceTypesConfig.Configuration.GetKafkaInstanceTypeByID("developer")
+	if err != nil {
+		panic("unexpected test error")
+	}
+	testDeveloperX1InstanceSize, err := testDeveloperInstanceType.GetKafkaInstanceSizeByID("x1")
+	if err != nil {
+		panic("unexpected test error")
+	}
+
+	marshaledTestAvailableStrimziVersions, err := json.Marshal([]api.StrimziVersion{
 		{
-			name:    "should return an error if criteria was not defined",
-			fields:  fields{},
-			args:    args{},
-			result:  nil,
-			wantErr: true,
-			expectedErr: &errors.ServiceError{
-				Code: errors.ErrorGeneral,
+			Version:          "strimzi-cluster-operator.from-cluster",
+			Ready:            true,
+			KafkaVersions:    []api.KafkaVersion{{Version: "2.7.0"}},
+			KafkaIBPVersions: []api.KafkaIBPVersion{{Version: "2.7"}},
+		},
+	})
+	if err != nil {
+		panic("unexpected test error")
+	}
+
+	dataplaneClusterConfig := &config.DataplaneClusterConfig{
+		NodePrewarmingConfig: config.NodePrewarmingConfig{
+			Configuration: map[string]config.InstanceTypeNodePrewarmingConfig{
+				"developer": {
+					NumReservedInstances: 1,
+				},
+				"standard": {
+					NumReservedInstances: 2,
+				},
 			},
 		},
+	}
+	streamingUnitCounts := KafkaStreamingUnitCountPerClusterList{
+		{ClusterId: testClusterID, CloudProvider: "aws", Region: "us-east-1", InstanceType: "standard", Count: 3},
+		{ClusterId: testClusterID, CloudProvider: "aws", Region: "us-east-1", InstanceType: "developer", Count: 1},
+		{ClusterId: "other-cluster", CloudProvider: "aws", Region: "us-east-1", InstanceType: "standard", Count: 5},
+	}
+
+	tests := []struct {
+		name           string
+		clusterService ClusterService
+		want           []StreamingUnitUsage
+		wantErr        bool
+	}{
 		{
-			name: "should return an error if instance type in criteria is not supported",
-			fields: fields{
-				kafkaConfig: &defaultKafkaConf,
-			},
-			args: args{
-				criteria: &FindClusterCriteria{
-					Provider:              defaultCluster.CloudProvider,
-					Region:                defaultCluster.Region,
-					MultiAZ:               defaultCluster.MultiAZ,
-					SupportedInstanceType: "unsupported",
+			name: "should report the reserved and the used streaming units of each instance type of the cluster",
+			clusterService: &ClusterServiceMock{
+				FindClusterByIDFunc: func(clusterID string) (*api.Cluster, *errors.ServiceError) {
+					return &api.Cluster{
+						ClusterID:                clusterID,
+						Status:                   api.ClusterReady,
+						SupportedInstanceType:    "developer,standard",
+						AvailableStrimziVersions: marshaledTestAvailableStrimziVersions,
+					}, nil
+				},
+				FindCachedStreamingUnitCountByClusterAndInstanceTypeFunc: func(forceRefresh bool) (KafkaStreamingUnitCountPerClusterList, error) {
+					return streamingUnitCounts, nil
 				},
 			},
-			result:  nil,
-			wantErr: true,
-			expectedErr: &errors.ServiceError{
-				Code: errors.ErrorInstanceTypeNotSupported,
+			want: []StreamingUnitUsage{
+				{ClusterID: testClusterID, CloudProvider: "aws", Region: "us-east-1", InstanceType: "standard", Reserved: 2 * testStandardX1InstanceSize.CapacityConsumed, Used: 3},
+				{ClusterID: testClusterID, CloudProvider: "aws", Region: "us-east-1", InstanceType: "developer", Reserved: testDeveloperX1InstanceSize.CapacityConsumed, Used: 1},
 			},
 		},
 		{
-			name: "should return an error if db query failed",
-			fields: fields{
-				connectionFactory:      db.NewMockConnectionFactory(nil),
-				kafkaConfig:            &defaultKafkaConf,
-				dataplaneClusterConfig: buildDataplaneClusterConfig(defaultDataplaneClusterConfig),
-				providerConfig:         buildProviderConfiguration("us-east-1", 1000, 1000, false),
-				clusterPlacementStrategy: &ClusterPlacementStrategyMock{
-					FindClusterFunc: func(kafka *dbapi.KafkaRequest) (*api.Cluster, error) {
-						return nil, nil
-					},
+			name: "should report no reserved streaming units when the cluster is not ready",
+			clusterService: &ClusterServiceMock{
+				FindClusterByIDFunc: func(clusterID string) (*api.Cluster, *errors.ServiceError) {
+					return &api.Cluster{
+						ClusterID:             clusterID,
+						Status:                api.ClusterProvisioning,
+						SupportedInstanceType: "developer,standard",
+					}, nil
+				},
+				FindCachedStreamingUnitCountByClusterAndInstanceTypeFunc: func(forceRefresh bool) (KafkaStreamingUnitCountPerClusterList, error) {
+					return streamingUnitCounts, nil
 				},
 			},
-			args: args{
-				criteria: testCriteria,
+			want: []StreamingUnitUsage{
+				{ClusterID: testClusterID, CloudProvider: "aws", Region: "us-east-1", InstanceType: "standard", Reserved: 0, Used: 3},
+				{ClusterID: testClusterID, CloudProvider: "aws", Region: "us-east-1", InstanceType: "developer", Reserved: 0, Used: 1},
 			},
-			setupFn: func() {
-				mocket.Catcher.Reset().
-					NewMock().
-					WithQuery(`SELECT * FROM "kafka_requests" WHERE region = $1 AND cloud_provider = $2 AND instance_type = $3`).
-					WithArgs(testCriteria.Region, testCriteria.Provider, testCriteria.SupportedInstanceType).
-					WithQueryException()
-				mocket.Catcher.NewMock().WithExecException().WithQueryException()
+		},
+		{
+			name: "should return an error when the streaming units cannot be counted",
+			clusterService: &ClusterServiceMock{
+				FindClusterByIDFunc: func(clusterID string) (*api.Cluster, *errors.ServiceError) {
+					return &api.Cluster{ClusterID: clusterID, Status: api.ClusterProvisioning}, nil
+				},
+				FindCachedStreamingUnitCountByClusterAndInstanceTypeFunc: func(forceRefresh bool) (KafkaStreamingUnitCountPerClusterList, error) {
+					return nil, errors.GeneralError("failed to count")
+				},
 			},
-			result:  nil,
 			wantErr: true,
-			expectedErr: &errors.ServiceError{
-				Code: errors.ErrorGeneral,
-			},
 		},
 	}
 
 	for _, testcase := range tests {
 		tt := testcase
-
 		t.Run(tt.name, func(t *testing.T) {
 			g := gomega.NewWithT(t)
-
-			if tt.setupFn != nil {
-				tt.setupFn()
-			}
-
 			k := &kafkaService{
-				connectionFactory:        tt.fields.connectionFactory,
-				kafkaConfig:              tt.fields.kafkaConfig,
-				dataplaneClusterConfig:   tt.fields.dataplaneClusterConfig,
-				providerConfig:           tt.fields.providerConfig,
-				clusterPlacementStrategy: tt.fields.clusterPlacementStrategy,
+				clusterService:         tt.clusterService,
+				dataplaneClusterConfig: dataplaneClusterConfig,
+				kafkaConfig: &config.KafkaConfig{
+					SupportedInstanceTypes: &kafkaSupportedInstanceTypesConfig,
+				},
 			}
-
-			got, err := k.GetAvailableSizesInRegion(tt.args.criteria)
-
-			g.Expect(tt.result).To(gomega.Equal(got))
-			g.Expect(tt.wantErr).To(gomega.Equal(err != nil))
-			if tt.wantErr && tt.expectedErr != nil {
-				g.Expect(tt.expectedErr.Code).To(gomega.Equal(err.Code))
+			got, err := k.GetStreamingUnitUsageByClusterID(testClusterID)
+			g.Expect(err != nil).To(gomega.Equal(tt.wantErr))
+			if tt.wantErr {
+				g.Expect(got).To(gomega.BeNil())
+				return
 			}
+			g.Expect(got).To(gomega.Equal(tt.want))
 		})
 	}
 }
-func Test_kafkaService_GetManagedKafkaByClusterID(t *testing.T) {
+
+func Test_kafkaService_SetKafkaStorageSize(t *testing.T) {
 	type fields struct {
 		connectionFactory *db.ConnectionFactory
-		keycloakService   sso.KeycloakService
 		kafkaConfig       *config.KafkaConfig
 	}
 	type args struct {
-		clusterID string
+		id   string
+		size string
 	}
-	kafkaRequestList := dbapi.KafkaList{
-		&dbapi.KafkaRequest{
-			ClusterID:    testClusterID,
-			InstanceType: "developer",
-			SizeId:       "x1",
+
+	kafka := converters.ConvertKafkaRequest(buildKafkaRequest(func(kafkaRequest *dbapi.KafkaRequest) {
+		kafkaRequest.InstanceType = types.STANDARD.String()
+		kafkaRequest.SizeId = "x1"
+	}))
+	kafka[0]["kafka_storage_size"] = "60Gi"
+
+	minStorageKafkaConf := &config.KafkaConfig{
+		SupportedInstanceTypes: &config.KafkaSupportedInstanceTypesConfig{
+			Configuration: config.SupportedKafkaInstanceTypesConfig{
+				SupportedKafkaInstanceTypes: []config.KafkaInstanceType{
+					{
+						Id: types.STANDARD.String(),
+						Sizes: []config.KafkaInstanceSize{
+							{
+								Id:                   "x1",
+								MinDataRetentionSize: "90Gi",
+								MaxDataRetentionSize: "100Gi",
+							},
+						},
+					},
+				},
+			},
 		},
 	}
-	managedkafkaCR, _ := buildManagedKafkaCR(
-		&dbapi.KafkaRequest{
-			ClusterID:    testClusterID,
-			InstanceType: "developer",
-			SizeId:       "x1",
-		},
-		&config.KafkaConfig{
-			EnableKafkaExternalCertificate: true,
-			EnableKafkaCNAMERegistration:   true,
-			SupportedInstanceTypes:         &kafkaSupportedInstanceTypesConfig,
-		},
-		&sso.KeycloakServiceMock{
-			GetConfigFunc: func() *keycloak.KeycloakConfig {
-				return &keycloak.KeycloakConfig{
-					EnableAuthenticationOnKafka: true,
-				}
-			},
-			GetRealmConfigFunc: func() *keycloak.KeycloakRealmConfig {
-				return &keycloak.KeycloakRealmConfig{}
-			},
-		})
 
 	tests := []struct {
 		name    string
 		fields  fields
 		args    args
-		want    []managedkafka.ManagedKafka
-		wantErr *errors.ServiceError
+		wantErr bool
 		setupFn func()
 	}{
 		{
-			name: "should return the kafka by cluster id",
+			name: "should update the storage size when it is within the allowed bounds",
 			fields: fields{
 				connectionFactory: db.NewMockConnectionFactory(nil),
-				keycloakService: &sso.KeycloakServiceMock{
-					GetConfigFunc: func() *keycloak.KeycloakConfig {
-						return &keycloak.KeycloakConfig{
-							EnableAuthenticationOnKafka: true,
-						}
-					},
-					GetRealmConfigFunc: func() *keycloak.KeycloakRealmConfig {
-						return &keycloak.KeycloakRealmConfig{}
-					},
-				},
-				kafkaConfig: &config.KafkaConfig{
-					EnableKafkaExternalCertificate: true,
-					EnableKafkaCNAMERegistration:   true,
-					SupportedInstanceTypes:         &kafkaSupportedInstanceTypesConfig,
-				},
+				kafkaConfig:       &defaultKafkaConf,
 			},
 			args: args{
-				clusterID: testClusterID,
+				id:   testID,
+				size: "80Gi",
 			},
-			wantErr: nil,
-			want:    []managedkafka.ManagedKafka{*managedkafkaCR},
+			wantErr: false,
 			setupFn: func() {
-				mocket.Catcher.Reset()
-				query := fmt.Sprintf(`SELECT * FROM "%s"`, kafkaRequestTableName)
-				response := converters.ConvertKafkaRequestList(kafkaRequestList)
-				mocket.Catcher.NewMock().WithQuery(query).WithReply(response)
+				mocket.Catcher.Reset().NewMock().
+					WithQuery(`SELECT * FROM "kafka_requests" WHERE id = $1`).
+					WithArgs(testID).
+					WithReply(kafka)
+				mocket.Catcher.NewMock().WithQuery(`UPDATE "kafka_requests" SET "kafka_storage_size"=$1`)
+				mocket.Catcher.NewMock().WithExecException().WithQueryException()
+			},
+		},
+		{
+			name: "should return an error when the requested size is greater than the max data retention size",
+			fields: fields{
+				connectionFactory: db.NewMockConnectionFactory(nil),
+				kafkaConfig:       &defaultKafkaConf,
+			},
+			args: args{
+				id:   testID,
+				size: "200Gi",
+			},
+			wantErr: true,
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().
+					WithQuery(`SELECT * FROM "kafka_requests" WHERE id = $1`).
+					WithArgs(testID).
+					WithReply(kafka)
+				mocket.Catcher.NewMock().WithQuery(`UPDATE "kafka_requests" SET "kafka_storage_size"=$1`)
+				mocket.Catcher.NewMock().WithExecException().WithQueryException()
+			},
+		},
+		{
+			name: "should return an error when the requested size is smaller than the current size",
+			fields: fields{
+				connectionFactory: db.NewMockConnectionFactory(nil),
+				kafkaConfig:       &defaultKafkaConf,
+			},
+			args: args{
+				id:   testID,
+				size: "50Gi",
+			},
+			wantErr: true,
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().
+					WithQuery(`SELECT * FROM "kafka_requests" WHERE id = $1`).
+					WithArgs(testID).
+					WithReply(kafka)
+				mocket.Catcher.NewMock().WithQuery(`UPDATE "kafka_requests" SET "kafka_storage_size"=$1`)
+				mocket.Catcher.NewMock().WithExecException().WithQueryException()
+			},
+		},
+		{
+			name: "should update the storage size when it is not smaller than the min data retention size",
+			fields: fields{
+				connectionFactory: db.NewMockConnectionFactory(nil),
+				kafkaConfig:       minStorageKafkaConf,
+			},
+			args: args{
+				id:   testID,
+				size: "90Gi",
+			},
+			wantErr: false,
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().
+					WithQuery(`SELECT * FROM "kafka_requests" WHERE id = $1`).
+					WithArgs(testID).
+					WithReply(kafka)
+				mocket.Catcher.NewMock().WithQuery(`UPDATE "kafka_requests" SET "kafka_storage_size"=$1`)
+				mocket.Catcher.NewMock().WithExecException().WithQueryException()
+			},
+		},
+		{
+			name: "should return an error when the requested size is smaller than the min data retention size",
+			fields: fields{
+				connectionFactory: db.NewMockConnectionFactory(nil),
+				kafkaConfig:       minStorageKafkaConf,
+			},
+			args: args{
+				id:   testID,
+				size: "80Gi",
+			},
+			wantErr: true,
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().
+					WithQuery(`SELECT * FROM "kafka_requests" WHERE id = $1`).
+					WithArgs(testID).
+					WithReply(kafka)
+				mocket.Catcher.NewMock().WithQuery(`UPDATE "kafka_requests" SET "kafka_storage_size"=$1`)
+				mocket.Catcher.NewMock().WithExecException().WithQueryException()
+			},
+		},
+		{
+			name: "should return an error when the requested size cannot be parsed",
+			fields: fields{
+				connectionFactory: db.NewMockConnectionFactory(nil),
+				kafkaConfig:       &defaultKafkaConf,
+			},
+			args: args{
+				id:   testID,
+				size: "invalid",
+			},
+			wantErr: true,
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().
+					WithQuery(`SELECT * FROM "kafka_requests" WHERE id = $1`).
+					WithArgs(testID).
+					WithReply(kafka)
 				mocket.Catcher.NewMock().WithExecException().WithQueryException()
 			},
 		},
+		{
+			name: "should return an error when the kafka cannot be found",
+			fields: fields{
+				connectionFactory: db.NewMockConnectionFactory(nil),
+				kafkaConfig:       &defaultKafkaConf,
+			},
+			args: args{
+				id:   testID,
+				size: "80Gi",
+			},
+			wantErr: true,
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().WithExecException().WithQueryException()
+			},
+		},
 	}
 
 	for _, testcase := range tests {
 		tt := testcase
-		tt.setupFn()
 		t.Run(tt.name, func(t *testing.T) {
 			g := gomega.NewWithT(t)
+			tt.setupFn()
 			k := &kafkaService{
 				connectionFactory: tt.fields.connectionFactory,
-				keycloakService:   tt.fields.keycloakService,
 				kafkaConfig:       tt.fields.kafkaConfig,
 			}
-			got, err := k.GetManagedKafkaByClusterID(tt.args.clusterID)
-			g.Expect(got).To(gomega.Equal(tt.want))
-			g.Expect(err).To(gomega.Equal(tt.wantErr))
+			g.Expect(k.SetKafkaStorageSize(tt.args.id, tt.args.size) != nil).To(gomega.Equal(tt.wantErr))
 		})
 	}
 }
 
-func Test_kafkaService_GenerateReservedManagedKafkasByClusterID(t *testing.T) {
+func Test_kafkaService_VerifyAndUpdateKafkaAdmin(t *testing.T) {
 	type fields struct {
-		connectionFactory      *db.ConnectionFactory
-		kafkaConfig            *config.KafkaConfig
-		clusterService         ClusterService
-		dataplaneClusterConfig *config.DataplaneClusterConfig
+		connectionFactory *db.ConnectionFactory
+		clusterService    ClusterService
+		authService       authorization.Authorization
 	}
 	type args struct {
-		clusterID string
+		ctx          context.Context
+		kafkaRequest *dbapi.KafkaRequest
 	}
-
 	strimziOperatorVersion := "strimzi-cluster-operator.from-cluster"
-
-	testDeveloperInstanceType, err := kafkaSupportedInstanceTypesConfig.Configuration.GetKafkaInstanceTypeByID("developer")
-	if err != nil {
-		panic("unexpected test error")
-	}
-	testDeveloperX1InstanceSize, err := testDeveloperInstanceType.GetKafkaInstanceSizeByID("x1")
-	if err != nil {
-		panic("unexpected test error")
-	}
-	testStandardInstanceType, err := kafkaSupportedInstanceTypesConfig.Configuration.GetKafkaInstanceTypeByID("standard")
+	availableStrimziVersions, err := json.Marshal([]api.StrimziVersion{
+		{
+			Version: strimziOperatorVersion,
+			Ready:   true,
+			KafkaVersions: []api.KafkaVersion{
+				{
+					Version: "2.7.0",
+				},
+			},
+			KafkaIBPVersions: []api.KafkaIBPVersion{
+				{
+					Version: "2.7",
+				},
+			},
+		},
+	})
 	if err != nil {
-		panic("unexpected test error")
+		t.Fatal("failed to convert available strimzi versions to json")
 	}
-	testStandardX1InstanceSize, err := testStandardInstanceType.GetKafkaInstanceSizeByID("x1")
-	if err != nil {
-		panic("unexpected test error")
+	today := strings.ToLower(time.Now().UTC().Weekday().String())
+	tomorrow := strings.ToLower(time.Now().UTC().Add(24 * time.Hour).Weekday().String())
+	buildKafkaWithMaintenanceWindow := func(day string, desiredKafkaVersion string) *dbapi.KafkaRequest {
+		return &dbapi.KafkaRequest{
+			Meta: api.Meta{
+				ID: testID,
+			},
+			ClusterID:              "cluster-id",
+			ActualKafkaIBPVersion:  "2.7",
+			DesiredKafkaIBPVersion: "2.7",
+			ActualKafkaVersion:     "2.7.0",
+			DesiredKafkaVersion:    desiredKafkaVersion,
+			DesiredStrimziVersion:  strimziOperatorVersion,
+			KafkaStorageSize:       "100",
+			MaintenanceWindowDay:   day,
+			MaintenanceWindowStart: "00:00",
+			MaintenanceWindowEnd:   "24:00",
+		}
 	}
-
-	testAvailableStrimziVersions := []api.StrimziVersion{
+	storedKafka := converters.ConvertKafkaRequest(buildKafkaRequest(nil))
+	storedKafka[0]["desired_kafka_ibp_version"] = "2.7"
+	storedKafka[0]["desired_kafka_version"] = "2.7.0"
+	storedKafka[0]["desired_strimzi_version"] = strimziOperatorVersion
+	tests := []struct {
+		name      string
+		fields    fields
+		args      args
+		want      *errors.ServiceError
+		setupFunc func()
+	}{
+		{
+			name: "should upgrade a kafka within its maintenance window",
+			fields: fields{
+				connectionFactory: db.NewMockConnectionFactory(nil),
+			},
+			args: args{
+				ctx:          auth.SetIsAdminContext(context.TODO(), true),
+				kafkaRequest: buildKafkaWithMaintenanceWindow(today, "2.8.0"),
+			},
+			want: nil,
+			setupFunc: func() {
+				mocket.Catcher.Reset().NewMock().WithQuery(`UPDATE "kafka_requests"`)
+				mocket.Catcher.NewMock().WithExecException().WithQueryException()
+			},
+		},
+		{
+			name: "should refuse to upgrade a kafka outside of its maintenance window",
+			fields: fields{
+				connectionFactory: db.NewMockConnectionFactory(nil),
+			},
+			args: args{
+				ctx:          auth.SetIsAdminContext(context.TODO(), true),
+				kafkaRequest: buildKafkaWithMaintenanceWindow(tomorrow, "2.8.0"),
+			},
+			want: errors.New(errors.ErrorValidation, "Unable to upgrade kafka '%s' outside of its maintenance window (%s 00:00-24:00 UTC)", testID, tomorrow),
+			setupFunc: func() {
+				mocket.Catcher.Reset().NewMock().
+					WithQuery(`SELECT * FROM "kafka_requests" WHERE id = $1`).
+					WithArgs(testID).
+					WithReply(storedKafka)
+				mocket.Catcher.NewMock().WithQuery(`UPDATE "kafka_requests"`)
+				mocket.Catcher.NewMock().WithExecException().WithQueryException()
+			},
+		},
 		{
-			Version: strimziOperatorVersion,
-			Ready:   true,
-			KafkaVersions: []api.KafkaVersion{
-				{
-					Version: "2.7.0",
-				},
+			name: "should update a kafka outside of its maintenance window when its versions are not changed",
+			fields: fields{
+				connectionFactory: db.NewMockConnectionFactory(nil),
 			},
-			KafkaIBPVersions: []api.KafkaIBPVersion{
-				{
-					Version: "2.7",
-				},
+			args: args{
+				ctx:          auth.SetIsAdminContext(context.TODO(), true),
+				kafkaRequest: buildKafkaWithMaintenanceWindow(tomorrow, "2.7.0"),
+			},
+			want: nil,
+			setupFunc: func() {
+				mocket.Catcher.Reset().NewMock().
+					WithQuery(`SELECT * FROM "kafka_requests" WHERE id = $1`).
+					WithArgs(testID).
+					WithReply(storedKafka)
+				mocket.Catcher.NewMock().WithQuery(`UPDATE "kafka_requests"`)
+				mocket.Catcher.NewMock().WithExecException().WithQueryException()
 			},
 		},
-	}
-	marshaledTestAvailableStrimziVersions, err := json.Marshal(testAvailableStrimziVersions)
-	if err != nil {
-		panic("unexpected test error")
-	}
-
-	tests := []struct {
-		name    string
-		fields  fields
-		args    args
-		want    []managedkafka.ManagedKafka
-		wantErr bool
-	}{
 		{
-			name: "returns generated managed kafkas",
+			name: "should return nil if it can Verify And Update Kafka Admin ",
 			fields: fields{
 				connectionFactory: db.NewMockConnectionFactory(nil),
-				kafkaConfig: &config.KafkaConfig{
-					EnableKafkaExternalCertificate: true,
-					EnableKafkaCNAMERegistration:   true,
-					SupportedInstanceTypes:         &kafkaSupportedInstanceTypesConfig,
-				},
-				dataplaneClusterConfig: &config.DataplaneClusterConfig{
-					NodePrewarmingConfig: config.NodePrewarmingConfig{
-						Configuration: map[string]config.InstanceTypeNodePrewarmingConfig{
-							"developer": {
-								NumReservedInstances: 1,
-							},
-							"standard": {
-								NumReservedInstances: 2,
-							},
-						},
-					},
-				},
+				authService:       authorization.NewMockAuthorization(),
 				clusterService: &ClusterServiceMock{
 					FindClusterByIDFunc: func(clusterID string) (*api.Cluster, *errors.ServiceError) {
 						return &api.Cluster{
-							ClusterID:                clusterID,
-							Status:                   api.ClusterReady,
-							SupportedInstanceType:    "developer,standard",
-							AvailableStrimziVersions: marshaledTestAvailableStrimziVersions,
+							Meta: api.Meta{
+								ID: "id",
+							},
+							ClusterID:                "cluster-id",
+							AvailableStrimziVersions: availableStrimziVersions,
 						}, nil
 					},
 				},
 			},
 			args: args{
-				clusterID: testClusterID,
-			},
-			wantErr: false,
-			want: []managedkafka.ManagedKafka{
-				managedkafka.ManagedKafka{
-					Id: "reserved-kafka-developer-1",
-					TypeMeta: metav1.TypeMeta{
-						Kind:       "ManagedKafka",
-						APIVersion: "managedkafka.bf2.org/v1alpha1",
-					},
-					ObjectMeta: metav1.ObjectMeta{
-						Name:      "reserved-kafka-developer-1",
-						Namespace: "reserved-kafka-developer-1",
-						Annotations: map[string]string{
-							"bf2.org/id":          "reserved-kafka-developer-1",
-							"bf2.org/placementId": "reserved-kafka-developer-1",
-						},
-						Labels: map[string]string{
-							"bf2.org/kafkaInstanceProfileQuotaConsumed":    strconv.Itoa(testDeveloperX1InstanceSize.QuotaConsumed),
-							"bf2.org/kafkaInstanceProfileType":             "developer",
-							managedkafka.ManagedKafkaBf2DeploymentLabelKey: managedkafka.ManagedKafkaBf2DeploymentLabelValueReserved,
-						},
-					},
-					Spec: managedkafka.ManagedKafkaSpec{
-						Capacity: managedkafka.Capacity{
-							IngressPerSec:               testDeveloperX1InstanceSize.IngressThroughputPerSec.String(),
-							EgressPerSec:                testDeveloperX1InstanceSize.EgressThroughputPerSec.String(),
-							TotalMaxConnections:         testDeveloperX1InstanceSize.TotalMaxConnections,
-							MaxDataRetentionSize:        testDeveloperX1InstanceSize.MaxDataRetentionSize.String(),
-							MaxPartitions:               testDeveloperX1InstanceSize.MaxPartitions,
-							MaxDataRetentionPeriod:      testDeveloperX1InstanceSize.MaxDataRetentionPeriod,
-							MaxConnectionAttemptsPerSec: testDeveloperX1InstanceSize.MaxConnectionAttemptsPerSec,
-						},
-						Endpoint: managedkafka.EndpointSpec{
-							BootstrapServerHost: fmt.Sprintf("%s-dummyhost", "reserved-kafka-developer-1"),
-						},
-						Versions: managedkafka.VersionsSpec{
-							Strimzi:  testAvailableStrimziVersions[0].Version,
-							Kafka:    testAvailableStrimziVersions[0].KafkaVersions[0].Version,
-							KafkaIBP: testAvailableStrimziVersions[0].GetLatestKafkaIBPVersion().Version,
-						},
-						Deleted: false,
-						Owners:  []string{},
-					},
-					Status: managedkafka.ManagedKafkaStatus{},
-				},
-				managedkafka.ManagedKafka{
-					Id: "reserved-kafka-standard-1",
-					TypeMeta: metav1.TypeMeta{
-						Kind:       "ManagedKafka",
-						APIVersion: "managedkafka.bf2.org/v1alpha1",
-					},
-					ObjectMeta: metav1.ObjectMeta{
-						Name:      "reserved-kafka-standard-1",
-						Namespace: "reserved-kafka-standard-1",
-						Annotations: map[string]string{
-							"bf2.org/id":          "reserved-kafka-standard-1",
-							"bf2.org/placementId": "reserved-kafka-standard-1",
-						},
-						Labels: map[string]string{
-							"bf2.org/kafkaInstanceProfileQuotaConsumed":    strconv.Itoa(testStandardX1InstanceSize.QuotaConsumed),
-							"bf2.org/kafkaInstanceProfileType":             "standard",
-							managedkafka.ManagedKafkaBf2DeploymentLabelKey: managedkafka.ManagedKafkaBf2DeploymentLabelValueReserved,
-						},
-					},
-					Spec: managedkafka.ManagedKafkaSpec{
-						Capacity: managedkafka.Capacity{
-							IngressPerSec:               testStandardX1InstanceSize.IngressThroughputPerSec.String(),
-							EgressPerSec:                testStandardX1InstanceSize.EgressThroughputPerSec.String(),
-							TotalMaxConnections:         testStandardX1InstanceSize.TotalMaxConnections,
-							MaxDataRetentionSize:        testStandardX1InstanceSize.MaxDataRetentionSize.String(),
-							MaxPartitions:               testStandardX1InstanceSize.MaxPartitions,
-							MaxDataRetentionPeriod:      testStandardX1InstanceSize.MaxDataRetentionPeriod,
-							MaxConnectionAttemptsPerSec: testStandardX1InstanceSize.MaxConnectionAttemptsPerSec,
-						},
-						Endpoint: managedkafka.EndpointSpec{
-							BootstrapServerHost: fmt.Sprintf("%s-dummyhost", "reserved-kafka-standard-1"),
-						},
-						Versions: managedkafka.VersionsSpec{
-							Strimzi:  testAvailableStrimziVersions[0].Version,
-							Kafka:    testAvailableStrimziVersions[0].KafkaVersions[0].Version,
-							KafkaIBP: testAvailableStrimziVersions[0].GetLatestKafkaIBPVersion().Version,
-						},
-						Deleted: false,
-						Owners:  []string{},
+				ctx: auth.SetIsAdminContext(context.TODO(), true),
+				kafkaRequest: &dbapi.KafkaRequest{
+					Meta: api.Meta{
+						ID: "id",
 					},
-					Status: managedkafka.ManagedKafkaStatus{},
+					ClusterID:              "cluster-id",
+					ActualKafkaIBPVersion:  "2.7",
+					DesiredKafkaIBPVersion: "2.7",
+					ActualKafkaVersion:     "2.7",
+					DesiredKafkaVersion:    "2.7",
+					DesiredStrimziVersion:  "2.7",
+					KafkaStorageSize:       "100",
 				},
-				managedkafka.ManagedKafka{
-					Id: "reserved-kafka-standard-2",
-					TypeMeta: metav1.TypeMeta{
-						Kind:       "ManagedKafka",
-						APIVersion: "managedkafka.bf2.org/v1alpha1",
+			},
+			want: nil,
+			setupFunc: func() {
+				mocket.Catcher.Reset().NewMock().WithQuery(`UPDATE "kafka_requests"`).
+					WithReply(converters.ConvertKafkaRequest(buildKafkaRequest(nil)))
+				mocket.Catcher.NewMock().WithExecException().WithQueryException()
+			},
+		},
+		{
+			name: "should return error if user is not authenticated",
+			fields: fields{
+				connectionFactory: db.NewMockConnectionFactory(nil),
+				authService:       authorization.NewMockAuthorization(),
+				clusterService: &ClusterServiceMock{
+					FindClusterByIDFunc: func(clusterID string) (*api.Cluster, *errors.ServiceError) {
+						return &api.Cluster{
+							Meta: api.Meta{
+								ID: "id",
+							},
+							ClusterID:                "cluster-id",
+							AvailableStrimziVersions: availableStrimziVersions,
+						}, nil
 					},
-					ObjectMeta: metav1.ObjectMeta{
-						Name:      "reserved-kafka-standard-2",
-						Namespace: "reserved-kafka-standard-2",
-						Annotations: map[string]string{
-							"bf2.org/id":          "reserved-kafka-standard-2",
-							"bf2.org/placementId": "reserved-kafka-standard-2",
-						},
-						Labels: map[string]string{
-							"bf2.org/kafkaInstanceProfileQuotaConsumed":    strconv.Itoa(testStandardX1InstanceSize.QuotaConsumed),
-							"bf2.org/kafkaInstanceProfileType":             "standard",
-							managedkafka.ManagedKafkaBf2DeploymentLabelKey: managedkafka.ManagedKafkaBf2DeploymentLabelValueReserved,
-						},
+					IsStrimziKafkaVersionAvailableInClusterFunc: func(cluster *api.Cluster, strimziVersion, kafkaVersion, ibpVersion string) (bool, error) {
+						return true, nil
 					},
-					Spec: managedkafka.ManagedKafkaSpec{
-						Capacity: managedkafka.Capacity{
-							IngressPerSec:               testStandardX1InstanceSize.IngressThroughputPerSec.String(),
-							EgressPerSec:                testStandardX1InstanceSize.EgressThroughputPerSec.String(),
-							TotalMaxConnections:         testStandardX1InstanceSize.TotalMaxConnections,
-							MaxDataRetentionSize:        testStandardX1InstanceSize.MaxDataRetentionSize.String(),
-							MaxPartitions:               testStandardX1InstanceSize.MaxPartitions,
-							MaxDataRetentionPeriod:      testStandardX1InstanceSize.MaxDataRetentionPeriod,
-							MaxConnectionAttemptsPerSec: testStandardX1InstanceSize.MaxConnectionAttemptsPerSec,
-						},
-						Endpoint: managedkafka.EndpointSpec{
-							BootstrapServerHost: fmt.Sprintf("%s-dummyhost", "reserved-kafka-standard-2"),
-						},
-						Versions: managedkafka.VersionsSpec{
-							Strimzi:  testAvailableStrimziVersions[0].Version,
-							Kafka:    testAvailableStrimziVersions[0].KafkaVersions[0].Version,
-							KafkaIBP: testAvailableStrimziVersions[0].GetLatestKafkaIBPVersion().Version,
-						},
-						Deleted: false,
-						Owners:  []string{},
+					CheckStrimziVersionReadyFunc: func(cluster *api.Cluster, strimziVersion string) (bool, error) {
+						return true, nil
 					},
-					Status: managedkafka.ManagedKafkaStatus{},
 				},
 			},
+			args: args{
+				ctx: auth.SetIsAdminContext(context.TODO(), false),
+			},
+			want: errors.New(errors.ErrorUnauthenticated, "User not authenticated"),
+			setupFunc: func() {
+				mocket.Catcher.Reset()
+			},
 		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		tt.setupFunc()
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			k := &kafkaService{
+				connectionFactory: tt.fields.connectionFactory,
+				clusterService:    tt.fields.clusterService,
+				authService:       tt.fields.authService,
+			}
+			g.Expect(k.VerifyAndUpdateKafkaAdmin(tt.args.ctx, tt.args.kafkaRequest)).To(gomega.Equal(tt.want))
+		})
+	}
+}
+
+func Test_kafkaService_GetCNAMERecordStatus(t *testing.T) {
+	type fields struct {
+		awsConfig        *config.AWSConfig
+		awsClientFactory aws.ClientFactory
+	}
+
+	CNAME_Id := "CNAME_Id"
+	CNAME_Status := "CNAME_Status"
+	awsConfig := &config.AWSConfig{
+		AccountID:              "AccountID",
+		AccessKey:              "AccessKey",
+		SecretAccessKey:        "SecretAccessKey",
+		Route53AccessKey:       "Route53AccessKey",
+		Route53SecretAccessKey: "Route53SecretAccessKey",
+	}
+
+	type args struct {
+		kafkaRequest *dbapi.KafkaRequest
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    *CNameRecordStatus
+		wantErr bool
+	}{
 		{
-			name: "returns an empty list when pre warming is not enabled for any instance type",
+			name: "should get the CNAME record Status",
 			fields: fields{
-				dataplaneClusterConfig: &config.DataplaneClusterConfig{
-					NodePrewarmingConfig: config.NodePrewarmingConfig{
-						Configuration: map[string]config.InstanceTypeNodePrewarmingConfig{},
-					},
-				},
-				clusterService: &ClusterServiceMock{
-					FindClusterByIDFunc: func(clusterID string) (*api.Cluster, *errors.ServiceError) {
-						return &api.Cluster{
-							ClusterID:                clusterID,
-							Status:                   api.ClusterReady,
-							SupportedInstanceType:    "developer,standard",
-							AvailableStrimziVersions: marshaledTestAvailableStrimziVersions,
+				awsConfig: awsConfig,
+				awsClientFactory: aws.NewMockClientFactory(&aws.AWSClientMock{
+					GetChangeFunc: func(changeId string) (*route53.GetChangeOutput, error) {
+						return &route53.GetChangeOutput{
+							ChangeInfo: &route53.ChangeInfo{
+								Id:     &CNAME_Id,
+								Status: &CNAME_Status,
+							},
 						}, nil
 					},
-				},
+				}),
 			},
 			args: args{
-				clusterID: testClusterID,
+				kafkaRequest: &dbapi.KafkaRequest{
+					Region:        "us-east-1",
+					CloudProvider: cloudproviders.AWS.String(),
+				},
 			},
-			want: []managedkafka.ManagedKafka{},
+			want: &CNameRecordStatus{
+				Id:     &CNAME_Id,
+				Status: &CNAME_Status,
+			},
+			wantErr: false,
 		},
 		{
-			name: "an error is returned when the provided ClusterID does not exist",
+			name: "should return error when it fails to get CNAME status",
 			fields: fields{
-				dataplaneClusterConfig: &config.DataplaneClusterConfig{
-					DataPlaneClusterScalingType: config.AutoScaling,
-				},
-				clusterService: &ClusterServiceMock{
-					FindClusterByIDFunc: func(clusterID string) (*api.Cluster, *errors.ServiceError) {
-						return nil, nil
+				awsConfig: awsConfig,
+				awsClientFactory: aws.NewMockClientFactory(&aws.AWSClientMock{
+					GetChangeFunc: func(changeId string) (*route53.GetChangeOutput, error) {
+						return nil, errors.GeneralError("Unable to CNAME record status")
 					},
-				},
+				}),
 			},
 			args: args{
-				clusterID: testClusterID,
+				kafkaRequest: &dbapi.KafkaRequest{
+					Region: "us-east-1",
+				},
 			},
 			wantErr: true,
 		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			k := &kafkaService{
+				awsConfig:        tt.fields.awsConfig,
+				awsClientFactory: tt.fields.awsClientFactory,
+			}
+			got, err := k.GetCNAMERecordStatus(tt.args.kafkaRequest)
+			g.Expect(got).To(gomega.Equal(tt.want))
+			g.Expect(err != nil).To(gomega.Equal(tt.wantErr))
+		})
+	}
+}
+
+func Test_NewKafkaService(t *testing.T) {
+	type args struct {
+		connectionFactory        *db.ConnectionFactory
+		clusterService           ClusterService
+		keycloakService          sso.KafkaKeycloakService
+		kafkaConfig              *config.KafkaConfig
+		dataplaneClusterConfig   *config.DataplaneClusterConfig
+		awsConfig                *config.AWSConfig
+		quotaServiceFactory      QuotaServiceFactory
+		awsClientFactory         aws.ClientFactory
+		authorizationService     authorization.Authorization
+		providerConfig           *config.ProviderConfig
+		clusterPlacementStrategy ClusterPlacementStrategy
+		streamingUnitCountCache  *StreamingUnitCountCache
+		lifecycleEventSink       KafkaLifecycleEventSink
+		namespaceAllocator       KafkaNamespaceAllocator
+		clusterDNSCache          *ClusterDNSCache
+	}
+	tests := []struct {
+		name string
+		args args
+		want *kafkaService
+	}{
 		{
-			name: "an error is returned when trying to get the provided cluster returns an error",
-			fields: fields{
-				dataplaneClusterConfig: &config.DataplaneClusterConfig{
-					DataPlaneClusterScalingType: config.AutoScaling,
-				},
-				clusterService: &ClusterServiceMock{
-					FindClusterByIDFunc: func(clusterID string) (*api.Cluster, *errors.ServiceError) {
-						return nil, errors.GeneralError("test error")
-					},
-				},
-			},
+			name: "should return the kafka service",
 			args: args{
-				clusterID: testClusterID,
+				connectionFactory:        &db.ConnectionFactory{},
+				clusterService:           &ClusterServiceMock{},
+				keycloakService:          &sso.KeycloakServiceMock{},
+				kafkaConfig:              &config.KafkaConfig{},
+				dataplaneClusterConfig:   &config.DataplaneClusterConfig{},
+				awsConfig:                &config.AWSConfig{},
+				quotaServiceFactory:      &QuotaServiceFactoryMock{},
+				awsClientFactory:         &aws.MockClientFactory{},
+				providerConfig:           &config.ProviderConfig{},
+				clusterPlacementStrategy: &ClusterPlacementStrategyMock{},
+				streamingUnitCountCache:  &StreamingUnitCountCache{},
+				lifecycleEventSink:       &KafkaLifecycleEventSinkMock{},
+				namespaceAllocator:       &KafkaNamespaceAllocatorMock{},
+				clusterDNSCache:          &ClusterDNSCache{},
+			},
+			want: &kafkaService{
+				connectionFactory:        &db.ConnectionFactory{},
+				clusterService:           &ClusterServiceMock{},
+				keycloakService:          &sso.KeycloakServiceMock{},
+				kafkaConfig:              &config.KafkaConfig{},
+				dataplaneClusterConfig:   &config.DataplaneClusterConfig{},
+				awsConfig:                &config.AWSConfig{},
+				quotaServiceFactory:      &QuotaServiceFactoryMock{},
+				awsClientFactory:         &aws.MockClientFactory{},
+				providerConfig:           &config.ProviderConfig{},
+				clusterPlacementStrategy: &ClusterPlacementStrategyMock{},
+				streamingUnitCountCache:  &StreamingUnitCountCache{},
+				lifecycleEventSink:       &KafkaLifecycleEventSinkMock{},
+				namespaceAllocator:       &KafkaNamespaceAllocatorMock{},
+				clusterDNSCache:          &ClusterDNSCache{},
 			},
-			wantErr: true,
 		},
+	}
+
+	for _, testcase := range tests {
+		g := gomega.NewWithT(t)
+		tt := testcase
+		g.Expect(NewKafkaService(tt.args.connectionFactory, tt.args.clusterService, tt.args.keycloakService, tt.args.kafkaConfig, tt.args.dataplaneClusterConfig, tt.args.awsConfig, tt.args.quotaServiceFactory, tt.args.awsClientFactory, tt.args.authorizationService, tt.args.providerConfig, tt.args.clusterPlacementStrategy, tt.args.streamingUnitCountCache, tt.args.lifecycleEventSink, tt.args.namespaceAllocator, tt.args.clusterDNSCache)).To(gomega.Equal(tt.want))
+	}
+}
+
+func Test_kafkaService_ListKafkasWithRoutesNotCreated(t *testing.T) {
+	type fields struct {
+		connectionFactory *db.ConnectionFactory
+	}
+
+	tests := []struct {
+		name    string
+		fields  fields
+		want    []*dbapi.KafkaRequest
+		wantErr *errors.ServiceError
+		setupFn func()
+	}{
 		{
-			name: "an empty list is returned when the provided ClusterID is not in ready status",
+			name: "should return the kafka by cluster id",
 			fields: fields{
-				dataplaneClusterConfig: &config.DataplaneClusterConfig{
-					DataPlaneClusterScalingType: config.AutoScaling,
-				},
-				clusterService: &ClusterServiceMock{
-					FindClusterByIDFunc: func(clusterID string) (*api.Cluster, *errors.ServiceError) {
-						return &api.Cluster{
-							ClusterID: clusterID,
-							Status:    api.ClusterWaitingForKasFleetShardOperator,
-						}, nil
-					},
-				},
+				connectionFactory: db.NewMockConnectionFactory(nil),
 			},
-			args: args{
-				clusterID: testClusterID,
+			want: []*dbapi.KafkaRequest{buildKafkaRequest(nil)},
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().
+					WithQuery(`SELECT * FROM "kafka_requests"`).
+					WithReply(converters.ConvertKafkaRequest(buildKafkaRequest(nil)))
+				mocket.Catcher.NewMock().WithExecException().WithQueryException()
 			},
-			want: []managedkafka.ManagedKafka{},
 		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		tt.setupFn()
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			k := &kafkaService{
+				connectionFactory: tt.fields.connectionFactory,
+			}
+			g.Expect(k.ListKafkasWithRoutesNotCreated()).To(gomega.Equal(tt.want))
+		})
+	}
+}
+
+func Test_kafkaService_ListDuplicateBootstrapHosts(t *testing.T) {
+	type fields struct {
+		connectionFactory *db.ConnectionFactory
+	}
+
+	const query = `GROUP BY "bootstrap_server_host" HAVING count(1) > 1)`
+
+	tests := []struct {
+		name    string
+		fields  fields
+		want    map[string][]string
+		wantErr bool
+		setupFn func()
+	}{
 		{
-			name: "an error is returned when there are no ready strimzi versions",
+			name: "should return an error if the query fails",
 			fields: fields{
-				dataplaneClusterConfig: &config.DataplaneClusterConfig{},
-				clusterService: &ClusterServiceMock{
-					FindClusterByIDFunc: func(clusterID string) (*api.Cluster, *errors.ServiceError) {
-						availableStrimziVersions, err := json.Marshal([]api.StrimziVersion{
-							{
-								Version: strimziOperatorVersion,
-								Ready:   false,
-								KafkaVersions: []api.KafkaVersion{
-									{
-										Version: "2.7.0",
-									},
-								},
-								KafkaIBPVersions: []api.KafkaIBPVersion{
-									{
-										Version: "2.7",
-									},
-								},
-							},
-						})
-						if err != nil {
-							t.Fatal("failed to convert available strimzi versions to json")
-						}
-						return &api.Cluster{
-							ClusterID:                clusterID,
-							Status:                   api.ClusterReady,
-							AvailableStrimziVersions: availableStrimziVersions,
-						}, nil
-					},
-				},
-			},
-			args: args{
-				clusterID: testClusterID,
+				connectionFactory: db.NewMockConnectionFactory(nil),
 			},
+			want:    nil,
 			wantErr: true,
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().WithExecException().WithQueryException()
+			},
 		},
 		{
-			name: "an error is returned when there are no kafka versions in the latest ready strimzi version",
+			name: "should return the ids of the kafkas sharing a bootstrap server host",
 			fields: fields{
-				kafkaConfig: &config.KafkaConfig{
-					SupportedInstanceTypes: &kafkaSupportedInstanceTypesConfig,
-				},
-				dataplaneClusterConfig: &config.DataplaneClusterConfig{
-					DataPlaneClusterScalingType: config.AutoScaling,
-					NodePrewarmingConfig: config.NodePrewarmingConfig{
-						Configuration: map[string]config.InstanceTypeNodePrewarmingConfig{
-							"developer": {
-								NumReservedInstances: 1,
-							},
-							"standard": {
-								NumReservedInstances: 1,
-							},
-						},
-					},
-				},
-				clusterService: &ClusterServiceMock{
-					FindClusterByIDFunc: func(clusterID string) (*api.Cluster, *errors.ServiceError) {
-						availableStrimziVersions, err := json.Marshal([]api.StrimziVersion{
-							{
-								Version:       strimziOperatorVersion,
-								Ready:         true,
-								KafkaVersions: []api.KafkaVersion{},
-								KafkaIBPVersions: []api.KafkaIBPVersion{
-									{
-										Version: "2.7",
-									},
-								},
-							},
-						})
-						if err != nil {
-							t.Fatal("failed to convert available strimzi versions to json")
-						}
-						return &api.Cluster{
-							ClusterID:                clusterID,
-							Status:                   api.ClusterReady,
-							AvailableStrimziVersions: availableStrimziVersions,
-							SupportedInstanceType:    "developer",
-						}, nil
-					},
-				},
+				connectionFactory: db.NewMockConnectionFactory(nil),
 			},
-			args: args{
-				clusterID: testClusterID,
+			want: map[string][]string{
+				"duplicate.kafka.devshift.org": {"kafka-1", "kafka-2"},
+			},
+			wantErr: false,
+			setupFn: func() {
+				// the kafka with a unique host is filtered out by the query
+				mocket.Catcher.Reset().NewMock().
+					WithQuery(query).
+					WithReply([]map[string]interface{}{
+						{"id": "kafka-1", "bootstrap_server_host": "duplicate.kafka.devshift.org"},
+						{"id": "kafka-2", "bootstrap_server_host": "duplicate.kafka.devshift.org"},
+					})
+				mocket.Catcher.NewMock().WithExecException().WithQueryException()
 			},
-			wantErr: true,
 		},
 		{
-			name: "an error is returned when there are no kafka ibp versions in the latest ready strimzi version",
+			name: "should return an empty map if there is no duplicate bootstrap server host",
 			fields: fields{
-				kafkaConfig: &config.KafkaConfig{
-					SupportedInstanceTypes: &kafkaSupportedInstanceTypesConfig,
-				},
-				dataplaneClusterConfig: &config.DataplaneClusterConfig{
-					NodePrewarmingConfig: config.NodePrewarmingConfig{
-						Configuration: map[string]config.InstanceTypeNodePrewarmingConfig{
-							"developer": {
-								NumReservedInstances: 1,
-							},
-							"standard": {
-								NumReservedInstances: 1,
-							},
-						},
-					},
-				},
-				clusterService: &ClusterServiceMock{
-					FindClusterByIDFunc: func(clusterID string) (*api.Cluster, *errors.ServiceError) {
-						availableStrimziVersions, err := json.Marshal([]api.StrimziVersion{
-							{
-								Version: strimziOperatorVersion,
-								Ready:   true,
-								KafkaVersions: []api.KafkaVersion{
-									api.KafkaVersion{
-										Version: "2.7.0",
-									},
-								},
-								KafkaIBPVersions: []api.KafkaIBPVersion{},
-							},
-						})
-						if err != nil {
-							t.Fatal("failed to convert available strimzi versions to json")
-						}
-						return &api.Cluster{
-							ClusterID:                clusterID,
-							Status:                   api.ClusterReady,
-							AvailableStrimziVersions: availableStrimziVersions,
-							SupportedInstanceType:    "developer",
-						}, nil
-					},
-				},
+				connectionFactory: db.NewMockConnectionFactory(nil),
 			},
-			args: args{
-				clusterID: testClusterID,
+			want:    map[string][]string{},
+			wantErr: false,
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().
+					WithQuery(query).
+					WithReply([]map[string]interface{}{})
+				mocket.Catcher.NewMock().WithExecException().WithQueryException()
 			},
-			wantErr: true,
 		},
 	}
+
 	for _, testcase := range tests {
 		tt := testcase
+		tt.setupFn()
 		t.Run(tt.name, func(t *testing.T) {
-			t.Parallel()
 			g := gomega.NewWithT(t)
 			k := &kafkaService{
-				connectionFactory:      tt.fields.connectionFactory,
-				clusterService:         tt.fields.clusterService,
-				kafkaConfig:            tt.fields.kafkaConfig,
-				dataplaneClusterConfig: tt.fields.dataplaneClusterConfig,
+				connectionFactory: tt.fields.connectionFactory,
 			}
-			got, err := k.GenerateReservedManagedKafkasByClusterID(tt.args.clusterID)
+			got, err := k.ListDuplicateBootstrapHosts()
 			g.Expect(err != nil).To(gomega.Equal(tt.wantErr))
-			g.Expect(got).Should(gomega.HaveLen(len(tt.want)))
 			g.Expect(got).To(gomega.Equal(tt.want))
 		})
 	}
 }
 
-func Test_kafkaService_VerifyAndUpdateKafkaAdmin(t *testing.T) {
+func Test_kafkaService_ListStuckDeprovisioning(t *testing.T) {
 	type fields struct {
 		connectionFactory *db.ConnectionFactory
-		clusterService    ClusterService
-		authService       authorization.Authorization
 	}
 	type args struct {
-		ctx          context.Context
-		kafkaRequest *dbapi.KafkaRequest
+		olderThan time.Duration
 	}
-	strimziOperatorVersion := "strimzi-cluster-operator.from-cluster"
-	availableStrimziVersions, err := json.Marshal([]api.StrimziVersion{
+
+	stuckKafka := buildKafkaRequest(func(kafkaRequest *dbapi.KafkaRequest) {
+		statusUpdatedAt := time.Now().Add(-2 * time.Hour)
+		kafkaRequest.Status = constants2.KafkaRequestStatusDeprovision.String()
+		kafkaRequest.StatusUpdatedAt = &statusUpdatedAt
+	})
+
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    []*dbapi.KafkaRequest
+		wantErr bool
+		setupFn func()
+	}{
 		{
-			Version: strimziOperatorVersion,
-			Ready:   true,
-			KafkaVersions: []api.KafkaVersion{
-				{
-					Version: "2.7.0",
-				},
+			name: "should return an error if the query fails",
+			fields: fields{
+				connectionFactory: db.NewMockConnectionFactory(nil),
 			},
-			KafkaIBPVersions: []api.KafkaIBPVersion{
-				{
-					Version: "2.7",
-				},
+			args: args{
+				olderThan: time.Hour,
+			},
+			want:    nil,
+			wantErr: true,
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().WithExecException().WithQueryException()
 			},
 		},
-	})
-	if err != nil {
-		t.Fatal("failed to convert available strimzi versions to json")
-	}
-	tests := []struct {
-		name      string
-		fields    fields
-		args      args
-		want      *errors.ServiceError
-		setupFunc func()
-	}{
 		{
-			name: "should return nil if it can Verify And Update Kafka Admin ",
+			name: "should return the kafkas whose status changed before the threshold",
 			fields: fields{
 				connectionFactory: db.NewMockConnectionFactory(nil),
-				authService:       authorization.NewMockAuthorization(),
-				clusterService: &ClusterServiceMock{
-					FindClusterByIDFunc: func(clusterID string) (*api.Cluster, *errors.ServiceError) {
-						return &api.Cluster{
-							Meta: api.Meta{
-								ID: "id",
-							},
-							ClusterID:                "cluster-id",
-							AvailableStrimziVersions: availableStrimziVersions,
-						}, nil
-					},
-				},
 			},
 			args: args{
-				ctx: auth.SetIsAdminContext(context.TODO(), true),
-				kafkaRequest: &dbapi.KafkaRequest{
-					Meta: api.Meta{
-						ID: "id",
-					},
-					ClusterID:              "cluster-id",
-					ActualKafkaIBPVersion:  "2.7",
-					DesiredKafkaIBPVersion: "2.7",
-					ActualKafkaVersion:     "2.7",
-					DesiredKafkaVersion:    "2.7",
-					DesiredStrimziVersion:  "2.7",
-					KafkaStorageSize:       "100",
-				},
+				olderThan: time.Hour,
 			},
-			want: nil,
-			setupFunc: func() {
-				mocket.Catcher.Reset().NewMock().WithQuery(`UPDATE "kafka_requests"`).
-					WithReply(converters.ConvertKafkaRequest(buildKafkaRequest(nil)))
+			want:    []*dbapi.KafkaRequest{stuckKafka},
+			wantErr: false,
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().
+					WithQuery(`SELECT * FROM "kafka_requests" WHERE status IN ($1,$2) AND COALESCE(status_updated_at, updated_at) < $3`).
+					WithReply(converters.ConvertKafkaRequest(stuckKafka))
 				mocket.Catcher.NewMock().WithExecException().WithQueryException()
 			},
 		},
 		{
-			name: "should return error if user is not authenticated",
+			name: "should return an empty list if no kafka status changed before the threshold",
 			fields: fields{
 				connectionFactory: db.NewMockConnectionFactory(nil),
-				authService:       authorization.NewMockAuthorization(),
-				clusterService: &ClusterServiceMock{
-					FindClusterByIDFunc: func(clusterID string) (*api.Cluster, *errors.ServiceError) {
-						return &api.Cluster{
-							Meta: api.Meta{
-								ID: "id",
-							},
-							ClusterID:                "cluster-id",
-							AvailableStrimziVersions: availableStrimziVersions,
-						}, nil
-					},
-					IsStrimziKafkaVersionAvailableInClusterFunc: func(cluster *api.Cluster, strimziVersion, kafkaVersion, ibpVersion string) (bool, error) {
-						return true, nil
-					},
-					CheckStrimziVersionReadyFunc: func(cluster *api.Cluster, strimziVersion string) (bool, error) {
-						return true, nil
-					},
+			},
+			args: args{
+				olderThan: 3 * time.Hour,
+			},
+			want:    []*dbapi.KafkaRequest{},
+			wantErr: false,
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().
+					WithQuery(`SELECT * FROM "kafka_requests" WHERE status IN ($1,$2) AND COALESCE(status_updated_at, updated_at) < $3`).
+					WithReply([]map[string]interface{}{})
+				mocket.Catcher.NewMock().WithExecException().WithQueryException()
+			},
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		tt.setupFn()
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			k := &kafkaService{
+				connectionFactory: tt.fields.connectionFactory,
+			}
+			got, err := k.ListStuckDeprovisioning(tt.args.olderThan)
+			g.Expect(err != nil).To(gomega.Equal(tt.wantErr))
+			if !tt.wantErr {
+				g.Expect(got).To(gomega.HaveLen(len(tt.want)))
+				for i := range tt.want {
+					g.Expect(got[i].ID).To(gomega.Equal(tt.want[i].ID))
+					g.Expect(got[i].Status).To(gomega.Equal(tt.want[i].Status))
+				}
+			}
+		})
+	}
+}
+
+func Test_kafkaService_FailStaleProvisioningKafkas(t *testing.T) {
+	withProvisioningTimeouts := func(standardTimeoutSeconds, developerTimeoutSeconds *int) *config.KafkaConfig {
+		standard := kafkaSupportedInstanceTypesConfig.Configuration.SupportedKafkaInstanceTypes[0]
+		standard.ProvisioningTimeoutSeconds = standardTimeoutSeconds
+		developer := kafkaSupportedInstanceTypesConfig.Configuration.SupportedKafkaInstanceTypes[1]
+		developer.ProvisioningTimeoutSeconds = developerTimeoutSeconds
+		return &config.KafkaConfig{
+			SupportedInstanceTypes: &config.KafkaSupportedInstanceTypesConfig{
+				Configuration: config.SupportedKafkaInstanceTypesConfig{
+					SupportedKafkaInstanceTypes: []config.KafkaInstanceType{standard, developer},
 				},
 			},
-			args: args{
-				ctx: auth.SetIsAdminContext(context.TODO(), false),
+		}
+	}
+	twoHours := int((2 * time.Hour).Seconds())
+	thirtyMinutes := int((30 * time.Minute).Seconds())
+	kafkaCreatedAgo := func(id string, instanceType types.KafkaInstanceType, age time.Duration) *dbapi.KafkaRequest {
+		return buildKafkaRequest(func(kafkaRequest *dbapi.KafkaRequest) {
+			kafkaRequest.ID = id
+			kafkaRequest.InstanceType = instanceType.String()
+			kafkaRequest.Status = constants2.KafkaRequestStatusProvisioning.String()
+			kafkaRequest.CreatedAt = time.Now().Add(-age)
+		})
+	}
+	kafkas := []*dbapi.KafkaRequest{
+		kafkaCreatedAgo("standard-within-timeout", types.STANDARD, time.Hour),
+		kafkaCreatedAgo("standard-timed-out", types.STANDARD, 3*time.Hour),
+		kafkaCreatedAgo("developer-timed-out", types.DEVELOPER, time.Hour),
+		kafkaCreatedAgo("developer-within-timeout", types.DEVELOPER, 10*time.Minute),
+	}
+
+	tests := []struct {
+		name        string
+		kafkaConfig *config.KafkaConfig
+		setupFn     func()
+		want        []string
+		wantErr     bool
+	}{
+		{
+			name:        "should fail the kafkas created for longer than the provisioning timeout of their instance type",
+			kafkaConfig: withProvisioningTimeouts(&twoHours, &thirtyMinutes),
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().
+					WithQuery(`SELECT * FROM "kafka_requests" WHERE status IN ($1,$2,$3) AND instance_type IN (`).
+					WithReply(converters.ConvertKafkaRequestList(kafkas))
+				mocket.Catcher.NewMock().
+					WithQuery(`SELECT * FROM "kafka_requests" WHERE id = $1`).
+					WithReply(converters.ConvertKafkaRequest(kafkas[0]))
+				mocket.Catcher.NewMock().WithQuery(`UPDATE "kafka_requests" SET`)
+				mocket.Catcher.NewMock().WithExecException().WithQueryException()
+			},
+			want: []string{"standard-timed-out", "developer-timed-out"},
+		},
+		{
+			name:        "should not fail the kafkas of the instance types without a provisioning timeout",
+			kafkaConfig: withProvisioningTimeouts(nil, nil),
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().WithExecException().WithQueryException()
+			},
+			want: []string{},
+		},
+		{
+			name:        "should return an error when the kafkas being provisioned cannot be listed",
+			kafkaConfig: withProvisioningTimeouts(&twoHours, nil),
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().WithExecException().WithQueryException()
 			},
-			want: errors.New(errors.ErrorUnauthenticated, "User not authenticated"),
-			setupFunc: func() {
-				mocket.Catcher.Reset()
+			wantErr: true,
+		},
+		{
+			name:        "should return an error when a kafka cannot be failed",
+			kafkaConfig: withProvisioningTimeouts(&twoHours, &thirtyMinutes),
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().
+					WithQuery(`SELECT * FROM "kafka_requests" WHERE status IN ($1,$2,$3) AND instance_type IN (`).
+					WithReply(converters.ConvertKafkaRequestList(kafkas))
+				mocket.Catcher.NewMock().
+					WithQuery(`SELECT * FROM "kafka_requests" WHERE id = $1`).
+					WithReply(converters.ConvertKafkaRequest(kafkas[0]))
+				mocket.Catcher.NewMock().WithExecException().WithQueryException()
 			},
+			want:    []string{},
+			wantErr: true,
 		},
 	}
 
 	for _, testcase := range tests {
 		tt := testcase
-		tt.setupFunc()
 		t.Run(tt.name, func(t *testing.T) {
 			g := gomega.NewWithT(t)
+			tt.setupFn()
 			k := &kafkaService{
-				connectionFactory: tt.fields.connectionFactory,
-				clusterService:    tt.fields.clusterService,
-				authService:       tt.fields.authService,
+				connectionFactory: db.NewMockConnectionFactory(nil),
+				kafkaConfig:       tt.kafkaConfig,
+			}
+			metrics.Reset()
+			got, err := k.FailStaleProvisioningKafkas()
+			g.Expect(err != nil).To(gomega.Equal(tt.wantErr))
+			if tt.want == nil {
+				g.Expect(got).To(gomega.BeNil())
+				return
+			}
+			g.Expect(got).To(gomega.Equal(tt.want))
+			if len(tt.want) > 0 {
+				failureCountMetric := metrics.KasFleetManager + "_" + metrics.KafkaOperationsFailureCount
+				g.Expect(testutil.GatherAndCompare(prometheus.DefaultGatherer, strings.NewReader(fmt.Sprintf(`# HELP %[1]s number of failed kafka operations
+# TYPE %[1]s counter
+%[1]s{operation="create"} %[2]d
+`, failureCountMetric, len(tt.want))), failureCountMetric)).To(gomega.Succeed())
 			}
-			g.Expect(k.VerifyAndUpdateKafkaAdmin(tt.args.ctx, tt.args.kafkaRequest)).To(gomega.Equal(tt.want))
 		})
 	}
 }
 
-func Test_kafkaService_GetCNAMERecordStatus(t *testing.T) {
-	type fields struct {
-		awsConfig        *config.AWSConfig
-		awsClientFactory aws.ClientFactory
-	}
-
-	CNAME_Id := "CNAME_Id"
-	CNAME_Status := "CNAME_Status"
-	awsConfig := &config.AWSConfig{
-		AccountID:              "AccountID",
-		AccessKey:              "AccessKey",
-		SecretAccessKey:        "SecretAccessKey",
-		Route53AccessKey:       "Route53AccessKey",
-		Route53SecretAccessKey: "Route53SecretAccessKey",
-	}
+func Test_kafkaService_ListKafkasMissingCanaryAccount(t *testing.T) {
+	kafkaMissingCanaryAccount := buildKafkaRequest(func(kafkaRequest *dbapi.KafkaRequest) {
+		kafkaRequest.ID = testID
+		kafkaRequest.Status = constants2.KafkaRequestStatusReady.String()
+		kafkaRequest.CanaryServiceAccountClientID = ""
+		kafkaRequest.CanaryServiceAccountClientSecret = ""
+	})
 
-	type args struct {
-		kafkaRequest *dbapi.KafkaRequest
-	}
 	tests := []struct {
-		name    string
-		fields  fields
-		args    args
-		want    *CNameRecordStatus
-		wantErr bool
+		name                        string
+		enableAuthenticationOnKafka bool
+		want                        []*dbapi.KafkaRequest
+		wantErr                     bool
+		setupFn                     func()
 	}{
 		{
-			name: "should get the CNAME record Status",
-			fields: fields{
-				awsConfig: awsConfig,
-				awsClientFactory: aws.NewMockClientFactory(&aws.AWSClientMock{
-					GetChangeFunc: func(changeId string) (*route53.GetChangeOutput, error) {
-						return &route53.GetChangeOutput{
-							ChangeInfo: &route53.ChangeInfo{
-								Id:     &CNAME_Id,
-								Status: &CNAME_Status,
-							},
-						}, nil
-					},
-				}),
+			name:                        "should return an error if the query fails",
+			enableAuthenticationOnKafka: true,
+			want:                        nil,
+			wantErr:                     true,
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().WithExecException().WithQueryException()
 			},
-			args: args{
-				kafkaRequest: &dbapi.KafkaRequest{
-					Region:        "us-east-1",
-					CloudProvider: cloudproviders.AWS.String(),
-				},
+		},
+		{
+			name:                        "should return the prepared kafkas without a canary service account",
+			enableAuthenticationOnKafka: true,
+			want:                        []*dbapi.KafkaRequest{kafkaMissingCanaryAccount},
+			wantErr:                     false,
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().
+					WithQuery(`SELECT * FROM "kafka_requests" WHERE status IN ($1,$2,$3,$4,$5) AND (canary_service_account_client_id = '' OR canary_service_account_client_id IS NULL)`).
+					WithArgs(
+						constants2.KafkaRequestStatusProvisioning.String(),
+						constants2.KafkaRequestStatusReady.String(),
+						constants2.KafkaRequestStatusSuspending.String(),
+						constants2.KafkaRequestStatusSuspended.String(),
+						constants2.KafkaRequestStatusResuming.String(),
+					).
+					WithReply(converters.ConvertKafkaRequest(kafkaMissingCanaryAccount))
+				mocket.Catcher.NewMock().WithExecException().WithQueryException()
 			},
-			want: &CNameRecordStatus{
-				Id:     &CNAME_Id,
-				Status: &CNAME_Status,
+		},
+		{
+			name:                        "should return an empty list when all the kafkas have a canary service account",
+			enableAuthenticationOnKafka: true,
+			want:                        []*dbapi.KafkaRequest{},
+			wantErr:                     false,
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().
+					WithQuery(`SELECT * FROM "kafka_requests" WHERE status IN ($1,$2,$3,$4,$5) AND (canary_service_account_client_id = '' OR canary_service_account_client_id IS NULL)`).
+					WithReply([]map[string]interface{}{})
+				mocket.Catcher.NewMock().WithExecException().WithQueryException()
 			},
-			wantErr: false,
 		},
 		{
-			name: "should return error when it fails to get CNAME status",
-			fields: fields{
-				awsConfig: awsConfig,
-				awsClientFactory: aws.NewMockClientFactory(&aws.AWSClientMock{
-					GetChangeFunc: func(changeId string) (*route53.GetChangeOutput, error) {
-						return nil, errors.GeneralError("Unable to CNAME record status")
-					},
-				}),
+			name:                        "should return an empty list without querying the kafkas when the authentication is disabled",
+			enableAuthenticationOnKafka: false,
+			want:                        []*dbapi.KafkaRequest{},
+			wantErr:                     false,
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().WithExecException().WithQueryException()
 			},
-			args: args{
-				kafkaRequest: &dbapi.KafkaRequest{
-					Region: "us-east-1",
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			tt.setupFn()
+			k := &kafkaService{
+				connectionFactory: db.NewMockConnectionFactory(nil),
+				keycloakService: &sso.KeycloakServiceMock{
+					GetConfigFunc: func() *keycloak.KeycloakConfig {
+						return &keycloak.KeycloakConfig{EnableAuthenticationOnKafka: tt.enableAuthenticationOnKafka}
+					},
 				},
-			},
-			wantErr: true,
+			}
+			got, err := k.ListKafkasMissingCanaryAccount()
+			g.Expect(err != nil).To(gomega.Equal(tt.wantErr))
+			if !tt.wantErr {
+				g.Expect(got).To(gomega.HaveLen(len(tt.want)))
+				for i := range tt.want {
+					g.Expect(got[i].ID).To(gomega.Equal(tt.want[i].ID))
+					g.Expect(got[i].CanaryServiceAccountClientID).To(gomega.BeEmpty())
+				}
+			}
+		})
+	}
+}
+
+func Test_kafkaService_RepairCanaryAccounts(t *testing.T) {
+	kafkaMissingCanaryAccount := buildKafkaRequest(func(kafkaRequest *dbapi.KafkaRequest) {
+		kafkaRequest.ID = testID
+		kafkaRequest.Status = constants2.KafkaRequestStatusReady.String()
+		kafkaRequest.CanaryServiceAccountClientID = ""
+		kafkaRequest.CanaryServiceAccountClientSecret = ""
+	})
+	listQuery := `SELECT * FROM "kafka_requests" WHERE status IN ($1,$2,$3,$4,$5) AND (canary_service_account_client_id = '' OR canary_service_account_client_id IS NULL)`
+
+	tests := []struct {
+		name        string
+		dryRun      bool
+		createErr   bool
+		updateErr   bool
+		want        []string
+		wantErr     bool
+		wantCreated []string
+		wantDeleted []string
+		wantUpdated bool
+	}{
+		{
+			name:   "should return the kafkas that would be repaired without changing them in dry run",
+			dryRun: true,
+			want:   []string{testID},
+		},
+		{
+			name:        "should create and record the canary service account of the kafkas missing one",
+			want:        []string{testID},
+			wantCreated: []string{"canary-" + testID},
+			wantUpdated: true,
+		},
+		{
+			name:        "should return an error when the canary service account cannot be created",
+			createErr:   true,
+			want:        []string{},
+			wantErr:     true,
+			wantCreated: []string{"canary-" + testID},
+		},
+		{
+			name:        "should delete the canary service account when it cannot be recorded",
+			updateErr:   true,
+			want:        []string{},
+			wantErr:     true,
+			wantCreated: []string{"canary-" + testID},
+			wantDeleted: []string{"canary-" + testID},
 		},
 	}
 
@@ -3805,73 +9778,181 @@ func Test_kafkaService_GetCNAMERecordStatus(t *testing.T) {
 		tt := testcase
 		t.Run(tt.name, func(t *testing.T) {
 			g := gomega.NewWithT(t)
+			updated := false
+			mocket.Catcher.Reset().NewMock().
+				WithQuery(listQuery).
+				WithReply(converters.ConvertKafkaRequest(kafkaMissingCanaryAccount))
+			if tt.updateErr {
+				mocket.Catcher.NewMock().WithQuery(`UPDATE "kafka_requests" SET "canary_service_account_client_id"=$1,"canary_service_account_client_secret"=$2`).WithExecException()
+			} else {
+				mocket.Catcher.NewMock().
+					WithQuery(`UPDATE "kafka_requests" SET "canary_service_account_client_id"=$1,"canary_service_account_client_secret"=$2`).
+					WithCallback(func(_ string, args []driver.NamedValue) {
+						g.Expect(args[0].Value).To(gomega.Equal("canary-" + testID))
+						g.Expect(args[1].Value).To(gomega.Equal("secret"))
+						updated = true
+					}).
+					WithRowsNum(1)
+			}
+			mocket.Catcher.NewMock().WithExecException().WithQueryException()
+
+			created := []string{}
+			deleted := []string{}
+			keycloakService := &sso.KeycloakServiceMock{
+				GetConfigFunc: func() *keycloak.KeycloakConfig {
+					return &keycloak.KeycloakConfig{EnableAuthenticationOnKafka: true}
+				},
+				CreateServiceAccountInternalFunc: func(request sso.CompleteServiceAccountRequest) (*api.ServiceAccount, *errors.ServiceError) {
+					created = append(created, request.ClientId)
+					if tt.createErr {
+						return nil, errors.GeneralError("failed to create service account")
+					}
+					return &api.ServiceAccount{ClientID: request.ClientId, ClientSecret: "secret"}, nil
+				},
+				DeleteServiceAccountInternalFunc: func(clientId string) *errors.ServiceError {
+					deleted = append(deleted, clientId)
+					return nil
+				},
+			}
 			k := &kafkaService{
-				awsConfig:        tt.fields.awsConfig,
-				awsClientFactory: tt.fields.awsClientFactory,
+				connectionFactory: db.NewMockConnectionFactory(nil),
+				keycloakService:   keycloakService,
 			}
-			got, err := k.GetCNAMERecordStatus(tt.args.kafkaRequest)
-			g.Expect(got).To(gomega.Equal(tt.want))
+
+			got, err := k.RepairCanaryAccounts(tt.dryRun)
 			g.Expect(err != nil).To(gomega.Equal(tt.wantErr))
+			g.Expect(got).To(gomega.Equal(tt.want))
+			g.Expect(created).To(gomega.ConsistOf(tt.wantCreated))
+			g.Expect(deleted).To(gomega.ConsistOf(tt.wantDeleted))
+			g.Expect(updated).To(gomega.Equal(tt.wantUpdated))
 		})
 	}
 }
 
-func Test_NewKafkaService(t *testing.T) {
-	type args struct {
-		connectionFactory        *db.ConnectionFactory
-		clusterService           ClusterService
-		keycloakService          sso.KafkaKeycloakService
-		kafkaConfig              *config.KafkaConfig
-		dataplaneClusterConfig   *config.DataplaneClusterConfig
-		awsConfig                *config.AWSConfig
-		quotaServiceFactory      QuotaServiceFactory
-		awsClientFactory         aws.ClientFactory
-		authorizationService     authorization.Authorization
-		providerConfig           *config.ProviderConfig
-		clusterPlacementStrategy ClusterPlacementStrategy
+func Test_kafkaService_CleanupOrphanedCanaryAccounts(t *testing.T) {
+	serviceAccounts := []api.ServiceAccount{
+		// the canary service account of a live kafka
+		{ClientID: "canary-live1", Name: "canary-service-account-for-kafka live1"},
+		// the canary service account of a deleted kafka
+		{ClientID: "canary-orphan1", Name: "canary-service-account-for-kafka orphan1"},
+		// a canary service account whose client id has been generated by the SSO
+		{ClientID: "4b7e0e2e-generated", Name: "canary-orphan2"},
+		// a canary service account used by a live kafka with another id, e.g. created with another template
+		{ClientID: "canary-old3", Name: "canary-service-account-for-kafka old3"},
+		// not a canary service account
+		{ClientID: "canary", Name: "canary"},
 	}
+	liveKafkas := []map[string]interface{}{
+		{"id": "live1", "canary_service_account_client_id": "canary-live1"},
+		{"id": "live3", "canary_service_account_client_id": "canary-old3"},
+	}
+
 	tests := []struct {
-		name string
-		args args
-		want *kafkaService
+		name                        string
+		enableAuthenticationOnKafka bool
+		dryRun                      bool
+		listErr                     bool
+		deleteErr                   bool
+		wantOrphaned                []OrphanedCanaryAccount
+		want                        []string
+		wantErr                     bool
+		wantDeleted                 []string
 	}{
 		{
-			name: "should return the kafka service",
-			args: args{
-				connectionFactory:        &db.ConnectionFactory{},
-				clusterService:           &ClusterServiceMock{},
-				keycloakService:          &sso.KeycloakServiceMock{},
-				kafkaConfig:              &config.KafkaConfig{},
-				dataplaneClusterConfig:   &config.DataplaneClusterConfig{},
-				awsConfig:                &config.AWSConfig{},
-				quotaServiceFactory:      &QuotaServiceFactoryMock{},
-				awsClientFactory:         &aws.MockClientFactory{},
-				providerConfig:           &config.ProviderConfig{},
-				clusterPlacementStrategy: &ClusterPlacementStrategyMock{},
-			},
-			want: &kafkaService{
-				connectionFactory:        &db.ConnectionFactory{},
-				clusterService:           &ClusterServiceMock{},
-				keycloakService:          &sso.KeycloakServiceMock{},
-				kafkaConfig:              &config.KafkaConfig{},
-				dataplaneClusterConfig:   &config.DataplaneClusterConfig{},
-				awsConfig:                &config.AWSConfig{},
-				quotaServiceFactory:      &QuotaServiceFactoryMock{},
-				awsClientFactory:         &aws.MockClientFactory{},
-				providerConfig:           &config.ProviderConfig{},
-				clusterPlacementStrategy: &ClusterPlacementStrategyMock{},
-			},
+			name:                        "should return the orphaned canary service accounts without deleting them in dry run",
+			enableAuthenticationOnKafka: true,
+			dryRun:                      true,
+			wantOrphaned: []OrphanedCanaryAccount{
+				{KafkaID: "orphan1", ClientID: "canary-orphan1", Name: "canary-service-account-for-kafka orphan1"},
+				{KafkaID: "orphan2", ClientID: "4b7e0e2e-generated", Name: "canary-orphan2"},
+			},
+			want: []string{"canary-orphan1", "4b7e0e2e-generated"},
+		},
+		{
+			name:                        "should only delete the orphaned canary service accounts",
+			enableAuthenticationOnKafka: true,
+			wantOrphaned: []OrphanedCanaryAccount{
+				{KafkaID: "orphan1", ClientID: "canary-orphan1", Name: "canary-service-account-for-kafka orphan1"},
+				{KafkaID: "orphan2", ClientID: "4b7e0e2e-generated", Name: "canary-orphan2"},
+			},
+			want:        []string{"canary-orphan1", "4b7e0e2e-generated"},
+			wantDeleted: []string{"canary-orphan1", "4b7e0e2e-generated"},
+		},
+		{
+			name:                        "should return an error when the canary service accounts cannot be listed",
+			enableAuthenticationOnKafka: true,
+			listErr:                     true,
+			wantErr:                     true,
+		},
+		{
+			name:                        "should return the service accounts deleted so far when a deletion fails",
+			enableAuthenticationOnKafka: true,
+			deleteErr:                   true,
+			wantOrphaned: []OrphanedCanaryAccount{
+				{KafkaID: "orphan1", ClientID: "canary-orphan1", Name: "canary-service-account-for-kafka orphan1"},
+				{KafkaID: "orphan2", ClientID: "4b7e0e2e-generated", Name: "canary-orphan2"},
+			},
+			want:        []string{"canary-orphan1"},
+			wantErr:     true,
+			wantDeleted: []string{"canary-orphan1", "4b7e0e2e-generated"},
+		},
+		{
+			name:                        "should not return any service account when the authentication is disabled",
+			enableAuthenticationOnKafka: false,
+			wantOrphaned:                []OrphanedCanaryAccount{},
+			want:                        []string{},
 		},
 	}
 
 	for _, testcase := range tests {
-		g := gomega.NewWithT(t)
 		tt := testcase
-		g.Expect(NewKafkaService(tt.args.connectionFactory, tt.args.clusterService, tt.args.keycloakService, tt.args.kafkaConfig, tt.args.dataplaneClusterConfig, tt.args.awsConfig, tt.args.quotaServiceFactory, tt.args.awsClientFactory, tt.args.authorizationService, tt.args.providerConfig, tt.args.clusterPlacementStrategy)).To(gomega.Equal(tt.want))
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			mocket.Catcher.Reset().NewMock().
+				WithQuery(`SELECT "id","canary_service_account_client_id" FROM "kafka_requests" WHERE (id IN ($1,$2,$3,$4) OR canary_service_account_client_id IN ($5,$6,$7,$8)) AND "kafka_requests"."deleted_at" IS NULL`).
+				WithReply(liveKafkas)
+			mocket.Catcher.NewMock().WithExecException().WithQueryException()
+
+			deleted := []string{}
+			keycloakService := &sso.KeycloakServiceMock{
+				GetConfigFunc: func() *keycloak.KeycloakConfig {
+					return &keycloak.KeycloakConfig{EnableAuthenticationOnKafka: tt.enableAuthenticationOnKafka}
+				},
+				ListInternalServiceAccountsFunc: func(prefix string) ([]api.ServiceAccount, *errors.ServiceError) {
+					g.Expect(prefix).To(gomega.Equal("canary-"))
+					if tt.listErr {
+						return nil, errors.GeneralError("failed to list service accounts")
+					}
+					return serviceAccounts, nil
+				},
+				DeleteServiceAccountInternalFunc: func(clientId string) *errors.ServiceError {
+					deleted = append(deleted, clientId)
+					if tt.deleteErr && len(deleted) > 1 {
+						return errors.GeneralError("failed to delete service account")
+					}
+					return nil
+				},
+			}
+			k := &kafkaService{
+				connectionFactory: db.NewMockConnectionFactory(nil),
+				keycloakService:   keycloakService,
+			}
+
+			orphaned, err := k.ListOrphanedCanaryAccounts()
+			g.Expect(err != nil).To(gomega.Equal(tt.listErr))
+			if !tt.listErr {
+				g.Expect(orphaned).To(gomega.Equal(tt.wantOrphaned))
+			}
+
+			got, err := k.CleanupOrphanedCanaryAccounts(tt.dryRun)
+			g.Expect(err != nil).To(gomega.Equal(tt.wantErr))
+			g.Expect(got).To(gomega.Equal(tt.want))
+			g.Expect(deleted).To(gomega.ConsistOf(tt.wantDeleted))
+		})
 	}
 }
 
-func Test_kafkaService_ListKafkasWithRoutesNotCreated(t *testing.T) {
+func Test_kafkaService_RepairMissingNamespaces(t *testing.T) {
 	type fields struct {
 		connectionFactory *db.ConnectionFactory
 	}
@@ -3879,20 +9960,32 @@ func Test_kafkaService_ListKafkasWithRoutesNotCreated(t *testing.T) {
 	tests := []struct {
 		name    string
 		fields  fields
-		want    []*dbapi.KafkaRequest
-		wantErr *errors.ServiceError
+		want    int64
+		wantErr bool
 		setupFn func()
 	}{
 		{
-			name: "should return the kafka by cluster id",
+			name: "should return an error if the update fails",
 			fields: fields{
 				connectionFactory: db.NewMockConnectionFactory(nil),
 			},
-			want: []*dbapi.KafkaRequest{buildKafkaRequest(nil)},
+			want:    0,
+			wantErr: true,
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().WithExecException().WithQueryException()
+			},
+		},
+		{
+			name: "should return the number of kafka requests with a repaired namespace",
+			fields: fields{
+				connectionFactory: db.NewMockConnectionFactory(nil),
+			},
+			want:    1,
+			wantErr: false,
 			setupFn: func() {
 				mocket.Catcher.Reset().NewMock().
-					WithQuery(`SELECT * FROM "kafka_requests"`).
-					WithReply(converters.ConvertKafkaRequest(buildKafkaRequest(nil)))
+					WithQuery(`UPDATE "kafka_requests" SET "namespace"=CONCAT('kafka-', LOWER(id))`).
+					WithRowsNum(1)
 				mocket.Catcher.NewMock().WithExecException().WithQueryException()
 			},
 		},
@@ -3900,13 +9993,102 @@ func Test_kafkaService_ListKafkasWithRoutesNotCreated(t *testing.T) {
 
 	for _, testcase := range tests {
 		tt := testcase
-		tt.setupFn()
+		tt.setupFn()
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			k := &kafkaService{
+				connectionFactory: tt.fields.connectionFactory,
+			}
+			got, err := k.RepairMissingNamespaces()
+			g.Expect(err != nil).To(gomega.Equal(tt.wantErr))
+			g.Expect(got).To(gomega.Equal(tt.want))
+		})
+	}
+}
+
+func Test_kafkaService_RepairMultiAZ(t *testing.T) {
+	type update struct {
+		multiAZ         interface{}
+		instanceType    interface{}
+		mismatchMultiAZ interface{}
+		statuses        []interface{}
+	}
+
+	tests := []struct {
+		name        string
+		rowsNum     int
+		updateErr   bool
+		want        int64
+		wantErr     bool
+		wantUpdates []update
+	}{
+		{
+			name:      "should return an error if the update fails",
+			updateErr: true,
+			want:      0,
+			wantErr:   true,
+		},
+		{
+			name:    "should set the multi AZ mode mandated by each instance type on the mismatched kafka requests",
+			rowsNum: 1,
+			want:    2,
+			wantErr: false,
+			wantUpdates: []update{
+				{
+					multiAZ:         true,
+					instanceType:    types.STANDARD.String(),
+					mismatchMultiAZ: true,
+					statuses:        []interface{}{constants2.KafkaRequestStatusDeleting.String(), constants2.KafkaRequestStatusDeprovision.String()},
+				},
+				{
+					multiAZ:         false,
+					instanceType:    types.DEVELOPER.String(),
+					mismatchMultiAZ: false,
+					statuses:        []interface{}{constants2.KafkaRequestStatusDeleting.String(), constants2.KafkaRequestStatusDeprovision.String()},
+				},
+			},
+		},
+		{
+			name:    "should return zero when all the kafka requests have the expected multi AZ mode",
+			rowsNum: 0,
+			want:    0,
+			wantErr: false,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
 		t.Run(tt.name, func(t *testing.T) {
 			g := gomega.NewWithT(t)
+			var updates []update
+			if tt.updateErr {
+				mocket.Catcher.Reset().NewMock().WithExecException().WithQueryException()
+			} else {
+				// the arguments are the multi AZ mode set, updated_at, the instance type, the mismatched multi AZ mode
+				// and the statuses excluded
+				mocket.Catcher.Reset().NewMock().
+					WithQuery(`UPDATE "kafka_requests" SET "multi_az"=$1,"updated_at"=$2 WHERE instance_type = $3 AND multi_az <> $4 AND status NOT IN ($5,$6)`).
+					WithCallback(func(_ string, args []driver.NamedValue) {
+						updates = append(updates, update{
+							multiAZ:         args[0].Value,
+							instanceType:    args[2].Value,
+							mismatchMultiAZ: args[3].Value,
+							statuses:        []interface{}{args[4].Value, args[5].Value},
+						})
+					}).
+					WithRowsNum(int64(tt.rowsNum))
+				mocket.Catcher.NewMock().WithExecException().WithQueryException()
+			}
 			k := &kafkaService{
-				connectionFactory: tt.fields.connectionFactory,
+				connectionFactory: db.NewMockConnectionFactory(nil),
+			}
+
+			got, err := k.RepairMultiAZ()
+			g.Expect(err != nil).To(gomega.Equal(tt.wantErr))
+			g.Expect(got).To(gomega.Equal(tt.want))
+			if tt.wantUpdates != nil {
+				g.Expect(updates).To(gomega.Equal(tt.wantUpdates))
 			}
-			g.Expect(k.ListKafkasWithRoutesNotCreated()).To(gomega.Equal(tt.want))
 		})
 	}
 }
@@ -4044,3 +10226,544 @@ func Test_kafkaService_getRoute53RegionFromKafkaRequest(t *testing.T) {
 		})
 	}
 }
+
+func Test_buildManagedKafkaCR_CustomAnnotations(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	kafkaRequest := &dbapi.KafkaRequest{
+		Meta:         api.Meta{ID: "kafka-id"},
+		PlacementId:  "placement-id",
+		InstanceType: "developer",
+		SizeId:       "x1",
+	}
+	g.Expect(kafkaRequest.SetAnnotations(map[string]string{
+		"example.com/team":    "streaming",
+		"bf2.org/id":          "overwritten-id",
+		"bf2.org/placementId": "overwritten-placement-id",
+	})).To(gomega.Succeed())
+
+	managedKafkaCR, err := buildManagedKafkaCR(kafkaRequest,
+		&config.KafkaConfig{
+			SupportedInstanceTypes: &kafkaSupportedInstanceTypesConfig,
+		},
+		&sso.KeycloakServiceMock{
+			GetConfigFunc: func() *keycloak.KeycloakConfig {
+				return &keycloak.KeycloakConfig{}
+			},
+			GetRealmConfigFunc: func() *keycloak.KeycloakRealmConfig {
+				return &keycloak.KeycloakRealmConfig{}
+			},
+		})
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(managedKafkaCR.ObjectMeta.Annotations).To(gomega.Equal(map[string]string{
+		"bf2.org/id":          "kafka-id",
+		"bf2.org/placementId": "placement-id",
+		"example.com/team":    "streaming",
+	}))
+}
+
+func Test_buildManagedKafkaCR_MaxConnectionAttemptsPerSecOverride(t *testing.T) {
+	instanceSize, err := kafkaSupportedInstanceTypesConfig.Configuration.GetKafkaInstanceTypeByID("developer")
+	if err != nil {
+		t.Fatal("failed to get the developer instance type")
+	}
+	size, err := instanceSize.GetKafkaInstanceSizeByID("x1")
+	if err != nil {
+		t.Fatal("failed to get the developer x1 size")
+	}
+	ceiling := size.MaxConnectionAttemptsPerSec
+	withinBounds := ceiling - 1
+	exceedingCeiling := ceiling + 100
+
+	tests := []struct {
+		name     string
+		override *int
+		want     int
+	}{
+		{
+			name:     "should use the limit of the size when there is no override",
+			override: nil,
+			want:     ceiling,
+		},
+		{
+			name:     "should use the override when it is within the limit of the size",
+			override: &withinBounds,
+			want:     withinBounds,
+		},
+		{
+			name:     "should cap the override to the limit of the size",
+			override: &exceedingCeiling,
+			want:     ceiling,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			kafkaRequest := buildKafkaRequest(func(kafkaRequest *dbapi.KafkaRequest) {
+				kafkaRequest.InstanceType = "developer"
+				kafkaRequest.SizeId = "x1"
+				kafkaRequest.MaxConnectionAttemptsPerSecOverride = tt.override
+			})
+
+			managedKafkaCR, err := buildManagedKafkaCR(kafkaRequest,
+				&config.KafkaConfig{
+					SupportedInstanceTypes: &kafkaSupportedInstanceTypesConfig,
+				},
+				&sso.KeycloakServiceMock{
+					GetConfigFunc: func() *keycloak.KeycloakConfig {
+						return &keycloak.KeycloakConfig{}
+					},
+					GetRealmConfigFunc: func() *keycloak.KeycloakRealmConfig {
+						return &keycloak.KeycloakRealmConfig{}
+					},
+				})
+			g.Expect(err).To(gomega.BeNil())
+			g.Expect(managedKafkaCR.Spec.Capacity.MaxConnectionAttemptsPerSec).To(gomega.Equal(tt.want))
+		})
+	}
+}
+
+func Test_kafkaService_SetMaxConnectionAttemptsPerSecOverride(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	k := &kafkaService{
+		connectionFactory: db.NewMockConnectionFactory(nil),
+	}
+
+	// a non positive override is rejected before the kafka is looked up
+	override := 0
+	err := k.SetMaxConnectionAttemptsPerSecOverride("kafka-id", &override)
+	g.Expect(err).ToNot(gomega.BeNil())
+	g.Expect(err.Code).To(gomega.Equal(errors.ErrorFieldValidationError))
+}
+
+func Test_kafkaService_ValidateCreateRequest(t *testing.T) {
+	supportsMultiAZ := false
+	singleAZProviderConfig := buildProviderConfiguration(testKafkaRequestRegion, MaxClusterCapacity, MaxClusterCapacity, false)
+	singleAZProviderConfig.ProvidersConfig.SupportedProviders[0].Regions[0].SupportsMultiAZ = &supportsMultiAZ
+
+	standardOnlyProviderConfig := buildProviderConfiguration(testKafkaRequestRegion, MaxClusterCapacity, MaxClusterCapacity, false)
+	delete(standardOnlyProviderConfig.ProvidersConfig.SupportedProviders[0].Regions[0].SupportedInstanceTypes, types.DEVELOPER.String())
+
+	tests := []struct {
+		name           string
+		providerConfig *config.ProviderConfig
+		modifyFn       func(kafkaRequest *dbapi.KafkaRequest)
+		wantErr        bool
+		wantCode       errors.ServiceErrorCode
+	}{
+		{
+			name: "should accept a valid request",
+		},
+		{
+			name: "should reject an empty name",
+			modifyFn: func(kafkaRequest *dbapi.KafkaRequest) {
+				kafkaRequest.Name = ""
+			},
+			wantErr:  true,
+			wantCode: errors.ErrorMalformedKafkaClusterName,
+		},
+		{
+			name: "should reject a name that is too long",
+			modifyFn: func(kafkaRequest *dbapi.KafkaRequest) {
+				kafkaRequest.Name = strings.Repeat("a", MaxKafkaNameLength+1)
+			},
+			wantErr:  true,
+			wantCode: errors.ErrorMalformedKafkaClusterName,
+		},
+		{
+			name: "should reject a name that does not match the name format",
+			modifyFn: func(kafkaRequest *dbapi.KafkaRequest) {
+				kafkaRequest.Name = "Test_Cluster"
+			},
+			wantErr:  true,
+			wantCode: errors.ErrorMalformedKafkaClusterName,
+		},
+		{
+			name: "should reject an unknown instance type",
+			modifyFn: func(kafkaRequest *dbapi.KafkaRequest) {
+				kafkaRequest.InstanceType = "unknown"
+			},
+			wantErr:  true,
+			wantCode: errors.ErrorInstanceTypeNotSupported,
+		},
+		{
+			name: "should reject a size that does not exist for the instance type",
+			modifyFn: func(kafkaRequest *dbapi.KafkaRequest) {
+				kafkaRequest.SizeId = "x9"
+			},
+			wantErr:  true,
+			wantCode: errors.ErrorInstancePlanNotSupported,
+		},
+		{
+			name: "should reject an unsupported cloud provider",
+			modifyFn: func(kafkaRequest *dbapi.KafkaRequest) {
+				kafkaRequest.CloudProvider = "unknown"
+			},
+			wantErr:  true,
+			wantCode: errors.ErrorProviderNotSupported,
+		},
+		{
+			name: "should reject an unsupported region",
+			modifyFn: func(kafkaRequest *dbapi.KafkaRequest) {
+				kafkaRequest.Region = "unknown"
+			},
+			wantErr:  true,
+			wantCode: errors.ErrorRegionNotSupported,
+		},
+		{
+			name:           "should reject an instance type that is not supported in the region",
+			providerConfig: standardOnlyProviderConfig,
+			modifyFn: func(kafkaRequest *dbapi.KafkaRequest) {
+				kafkaRequest.InstanceType = types.DEVELOPER.String()
+			},
+			wantErr:  true,
+			wantCode: errors.ErrorInstanceTypeNotSupported,
+		},
+		{
+			name:           "should reject a multi AZ instance type in a region that does not support multi AZ",
+			providerConfig: singleAZProviderConfig,
+			wantErr:        true,
+			wantCode:       errors.ErrorInstanceTypeNotSupported,
+		},
+		{
+			name:           "should accept a single AZ instance type in a region that does not support multi AZ",
+			providerConfig: singleAZProviderConfig,
+			modifyFn: func(kafkaRequest *dbapi.KafkaRequest) {
+				kafkaRequest.InstanceType = types.DEVELOPER.String()
+				kafkaRequest.MultiAZ = true
+			},
+		},
+		{
+			name: "should reject an invalid billing model",
+			modifyFn: func(kafkaRequest *dbapi.KafkaRequest) {
+				kafkaRequest.BillingModel = "invalid"
+			},
+			wantErr:  true,
+			wantCode: errors.ErrorBillingAccountInvalid,
+		},
+		{
+			name: "should accept a marketplace offered by the cloud provider",
+			modifyFn: func(kafkaRequest *dbapi.KafkaRequest) {
+				kafkaRequest.Marketplace = "aws"
+			},
+		},
+		{
+			name: "should accept a marketplace that is not bound to a cloud provider",
+			modifyFn: func(kafkaRequest *dbapi.KafkaRequest) {
+				kafkaRequest.Marketplace = "rhm"
+			},
+		},
+		{
+			name: "should reject a marketplace not offered by the cloud provider",
+			modifyFn: func(kafkaRequest *dbapi.KafkaRequest) {
+				kafkaRequest.Marketplace = "azure"
+			},
+			wantErr:  true,
+			wantCode: errors.ErrorValidation,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			providerConfig := tt.providerConfig
+			if providerConfig == nil {
+				providerConfig = buildProviderConfiguration(testKafkaRequestRegion, MaxClusterCapacity, MaxClusterCapacity, false)
+			}
+			k := &kafkaService{
+				kafkaConfig:    &defaultKafkaConf,
+				providerConfig: providerConfig,
+			}
+			kafkaRequest := buildKafkaRequest(func(kafkaRequest *dbapi.KafkaRequest) {
+				kafkaRequest.InstanceType = types.STANDARD.String()
+				if tt.modifyFn != nil {
+					tt.modifyFn(kafkaRequest)
+				}
+			})
+
+			err := k.ValidateCreateRequest(kafkaRequest)
+			g.Expect(err != nil).To(gomega.Equal(tt.wantErr))
+			if tt.wantErr {
+				g.Expect(err.Code).To(gomega.Equal(tt.wantCode))
+			}
+		})
+	}
+}
+
+func Test_kafkaService_SetCapacityConsumedOverride(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	k := &kafkaService{
+		connectionFactory: db.NewMockConnectionFactory(nil),
+	}
+
+	// a non positive override is rejected before the kafka is looked up
+	override := 0
+	err := k.SetCapacityConsumedOverride("kafka-id", &override)
+	g.Expect(err).ToNot(gomega.BeNil())
+	g.Expect(err.Code).To(gomega.Equal(errors.ErrorFieldValidationError))
+}
+
+func Test_kafkaService_capacityAvailableForRegionAndInstanceType_CapacityConsumedOverride(t *testing.T) {
+	one := 1
+
+	tests := []struct {
+		name             string
+		existingOverride *int
+		requestOverride  *int
+		want             bool
+	}{
+		{
+			name: "should use the capacity consumed by the sizes when the kafkas have no override",
+			want: false,
+		},
+		{
+			name:             "should use the capacity consumed override of the existing kafkas",
+			existingOverride: &one,
+			want:             true,
+		},
+		{
+			name:            "should use the capacity consumed override of the requested kafka",
+			requestOverride: &one,
+			want:            true,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			existingKafkas := converters.ConvertKafkaRequest(buildKafkaRequest(func(kafkaRequest *dbapi.KafkaRequest) {
+				kafkaRequest.InstanceType = types.DEVELOPER.String()
+				kafkaRequest.SizeId = "x1"
+			}))
+			if tt.existingOverride != nil {
+				existingKafkas[0]["capacity_consumed_override"] = *tt.existingOverride
+			}
+			mocket.Catcher.Reset().NewMock().
+				WithQuery(`SELECT * FROM "kafka_requests" WHERE region = $1 AND cloud_provider = $2 AND instance_type = $3 AND "kafka_requests"."deleted_at" IS NULL`).
+				WithReply(existingKafkas)
+			mocket.Catcher.NewMock().WithExecException().WithQueryException()
+
+			k := &kafkaService{
+				connectionFactory: db.NewMockConnectionFactory(nil),
+				kafkaConfig:       &defaultKafkaConf,
+			}
+			kafkaRequest := buildKafkaRequest(func(kafkaRequest *dbapi.KafkaRequest) {
+				kafkaRequest.InstanceType = types.DEVELOPER.String()
+				kafkaRequest.SizeId = "x1"
+				kafkaRequest.CapacityConsumedOverride = tt.requestOverride
+			})
+
+			// the developer x1 size consumes 2 units of capacity
+			capacity := 3
+			got, err := k.capacityAvailableForRegionAndInstanceType(&capacity, kafkaRequest, false)
+			g.Expect(err).To(gomega.BeNil())
+			g.Expect(got).To(gomega.Equal(tt.want))
+		})
+	}
+}
+
+func Test_kafkaService_GetRegionStatus(t *testing.T) {
+	remainingCapacity := func(c int) *int { return &c }
+
+	type args struct {
+		provider     string
+		region       string
+		instanceType string
+	}
+
+	tests := []struct {
+		name           string
+		providerConfig *config.ProviderConfig
+		args           args
+		want           *RegionStatus
+		wantErrCode    errors.ServiceErrorCode
+		wantErr        bool
+	}{
+		{
+			name:           "should return an error when the provider is not supported",
+			providerConfig: buildProviderConfiguration(testKafkaRequestRegion, 5, 5, false),
+			args:           args{provider: "azure", region: testKafkaRequestRegion, instanceType: types.STANDARD.String()},
+			wantErr:        true,
+			wantErrCode:    errors.ErrorProviderNotSupported,
+		},
+		{
+			name:           "should return an error when the region is not supported",
+			providerConfig: buildProviderConfiguration(testKafkaRequestRegion, 5, 5, false),
+			args:           args{provider: testKafkaRequestProvider, region: "eu-west-1", instanceType: types.STANDARD.String()},
+			wantErr:        true,
+			wantErrCode:    errors.ErrorRegionNotSupported,
+		},
+		{
+			name:           "should be closed as disabled when the limit of the instance type is zero",
+			providerConfig: buildProviderConfiguration(testKafkaRequestRegion, 0, 5, false),
+			args:           args{provider: testKafkaRequestProvider, region: testKafkaRequestRegion, instanceType: types.STANDARD.String()},
+			want:           &RegionStatus{Open: false, Reason: RegionStatusReasonDisabled, RemainingCapacity: remainingCapacity(0)},
+		},
+		{
+			name: "should be closed as disabled when the instance type is not supported in the region",
+			providerConfig: func() *config.ProviderConfig {
+				c := buildProviderConfiguration(testKafkaRequestRegion, 5, 5, false)
+				delete(c.ProvidersConfig.SupportedProviders[0].Regions[0].SupportedInstanceTypes, types.STANDARD.String())
+				return c
+			}(),
+			args: args{provider: testKafkaRequestProvider, region: testKafkaRequestRegion, instanceType: types.STANDARD.String()},
+			want: &RegionStatus{Open: false, Reason: RegionStatusReasonDisabled, RemainingCapacity: remainingCapacity(0)},
+		},
+		{
+			name: "should be closed for maintenance when the region is in maintenance mode",
+			providerConfig: func() *config.ProviderConfig {
+				c := buildProviderConfiguration(testKafkaRequestRegion, 5, 5, false)
+				c.ProvidersConfig.SupportedProviders[0].Regions[0].MaintenanceMode = true
+				return c
+			}(),
+			args: args{provider: testKafkaRequestProvider, region: testKafkaRequestRegion, instanceType: types.STANDARD.String()},
+			want: &RegionStatus{Open: false, Reason: RegionStatusReasonMaintenance, RemainingCapacity: remainingCapacity(3)},
+		},
+		{
+			name:           "should be closed as full when the smallest size does not fit in the remaining capacity",
+			providerConfig: buildProviderConfiguration(testKafkaRequestRegion, 2, 5, false),
+			args:           args{provider: testKafkaRequestProvider, region: testKafkaRequestRegion, instanceType: types.STANDARD.String()},
+			want:           &RegionStatus{Open: false, Reason: RegionStatusReasonFull, RemainingCapacity: remainingCapacity(0)},
+		},
+		{
+			name:           "should be open with the remaining capacity when the region is not full",
+			providerConfig: buildProviderConfiguration(testKafkaRequestRegion, 5, 5, false),
+			args:           args{provider: testKafkaRequestProvider, region: testKafkaRequestRegion, instanceType: types.STANDARD.String()},
+			want:           &RegionStatus{Open: true, RemainingCapacity: remainingCapacity(3)},
+		},
+		{
+			name:           "should be open without remaining capacity when the region has no limit",
+			providerConfig: buildProviderConfiguration(testKafkaRequestRegion, 0, 0, true),
+			args:           args{provider: testKafkaRequestProvider, region: testKafkaRequestRegion, instanceType: types.STANDARD.String()},
+			want:           &RegionStatus{Open: true},
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			// two standard x1 kafkas consuming 1 unit of capacity each
+			standardKafka := buildKafkaRequest(func(kafkaRequest *dbapi.KafkaRequest) {
+				kafkaRequest.InstanceType = types.STANDARD.String()
+			})
+			existingKafkas := append(converters.ConvertKafkaRequest(standardKafka), converters.ConvertKafkaRequest(standardKafka)...)
+			mocket.Catcher.Reset().NewMock().
+				WithQuery(`SELECT * FROM "kafka_requests" WHERE region = $1 AND cloud_provider = $2 AND instance_type = $3 AND "kafka_requests"."deleted_at" IS NULL`).
+				WithReply(existingKafkas)
+			mocket.Catcher.NewMock().WithExecException().WithQueryException()
+
+			k := &kafkaService{
+				connectionFactory: db.NewMockConnectionFactory(nil),
+				kafkaConfig:       &defaultKafkaConf,
+				providerConfig:    tt.providerConfig,
+			}
+
+			got, err := k.GetRegionStatus(tt.args.provider, tt.args.region, tt.args.instanceType)
+			g.Expect(err != nil).To(gomega.Equal(tt.wantErr))
+			if tt.wantErr {
+				g.Expect(err.Code).To(gomega.Equal(tt.wantErrCode))
+				return
+			}
+			g.Expect(got).To(gomega.Equal(tt.want))
+		})
+	}
+}
+
+func Test_kafkaService_GetCapacityReport(t *testing.T) {
+	g := gomega.NewWithT(t)
+	limit := func(l int) *int { return &l }
+
+	providerConfig := &config.ProviderConfig{
+		ProvidersConfig: config.ProviderConfiguration{
+			SupportedProviders: config.ProviderList{
+				{
+					Name: "aws",
+					Regions: config.RegionList{
+						{
+							Name: "us-east-1",
+							SupportedInstanceTypes: config.InstanceTypeMap{
+								"standard":  config.InstanceTypeConfig{Limit: limit(5)},
+								"developer": config.InstanceTypeConfig{Limit: limit(0)},
+							},
+						},
+						{
+							Name: "eu-west-1",
+							SupportedInstanceTypes: config.InstanceTypeMap{
+								"standard":  config.InstanceTypeConfig{Limit: limit(2)},
+								"developer": config.InstanceTypeConfig{},
+							},
+						},
+						{
+							Name:            "eu-central-1",
+							MaintenanceMode: true,
+							SupportedInstanceTypes: config.InstanceTypeMap{
+								"standard": config.InstanceTypeConfig{Limit: limit(5)},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	mocket.Catcher.Reset().NewMock().
+		WithQuery(`SELECT cloud_provider, region, instance_type, size_id, capacity_consumed_override, count(1) as Count FROM "kafka_requests"`).
+		WithReply([]map[string]interface{}{
+			{"cloud_provider": "aws", "region": "us-east-1", "instance_type": "standard", "size_id": "x1", "count": 3},
+			{"cloud_provider": "aws", "region": "eu-west-1", "instance_type": "standard", "size_id": "x1", "count": 2},
+			{"cloud_provider": "aws", "region": "eu-west-1", "instance_type": "developer", "size_id": "x1", "count": 1},
+		})
+	mocket.Catcher.NewMock().WithExecException().WithQueryException()
+
+	k := &kafkaService{
+		connectionFactory: db.NewMockConnectionFactory(nil),
+		kafkaConfig:       &defaultKafkaConf,
+		providerConfig:    providerConfig,
+	}
+
+	got, err := k.GetCapacityReport()
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(got).To(gomega.Equal([]RegionCapacityReport{
+		{
+			CloudProvider: "aws", Region: "us-east-1", InstanceType: "developer", CapacityConsumed: 0, Limit: limit(0),
+			RegionStatus: RegionStatus{Open: false, Reason: RegionStatusReasonDisabled, RemainingCapacity: limit(0)},
+		},
+		{
+			CloudProvider: "aws", Region: "us-east-1", InstanceType: "standard", CapacityConsumed: 3, Limit: limit(5),
+			RegionStatus: RegionStatus{Open: true, RemainingCapacity: limit(2)},
+		},
+		{
+			// the developer x1 size consumes 2 units of capacity
+			CloudProvider: "aws", Region: "eu-west-1", InstanceType: "developer", CapacityConsumed: 2,
+			RegionStatus: RegionStatus{Open: true},
+		},
+		{
+			CloudProvider: "aws", Region: "eu-west-1", InstanceType: "standard", CapacityConsumed: 2, Limit: limit(2),
+			RegionStatus: RegionStatus{Open: false, Reason: RegionStatusReasonFull, RemainingCapacity: limit(0)},
+		},
+		{
+			CloudProvider: "aws", Region: "eu-central-1", InstanceType: "standard", CapacityConsumed: 0, Limit: limit(5),
+			RegionStatus: RegionStatus{Open: false, Reason: RegionStatusReasonMaintenance, RemainingCapacity: limit(5)},
+		},
+	}))
+}
+
+func Test_kafkaService_SetAnnotations(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	k := &kafkaService{
+		connectionFactory: db.NewMockConnectionFactory(nil),
+	}
+
+	// reserved keys are rejected before the kafka is looked up
+	err := k.SetAnnotations("kafka-id", map[string]string{"bf2.org/id": "some-id"})
+	g.Expect(err).ToNot(gomega.BeNil())
+	g.Expect(err.Code).To(gomega.Equal(errors.ErrorFieldValidationError))
+}
@@ -14,6 +14,7 @@ import (
 	apiErrors "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services"
 	"sync"
+	"time"
 )
 
 // Ensure, that KafkaServiceMock does implement KafkaService.
@@ -26,69 +27,264 @@ var _ KafkaService = &KafkaServiceMock{}
 //
 //		// make and configure a mocked KafkaService
 //		mockedKafkaService := &KafkaServiceMock{
+//			AbortPendingQuotaFunc: func(id string) *apiErrors.ServiceError {
+//				panic("mock out the AbortPendingQuota method")
+//			},
 //			AssignBootstrapServerHostFunc: func(kafkaRequest *dbapi.KafkaRequest) error {
 //				panic("mock out the AssignBootstrapServerHost method")
 //			},
 //			AssignInstanceTypeFunc: func(owner string, organisationID string) (types.KafkaInstanceType, *apiErrors.ServiceError) {
 //				panic("mock out the AssignInstanceType method")
 //			},
+//			BackfillQuotaTypeFunc: func() (int64, *apiErrors.ServiceError) {
+//				panic("mock out the BackfillQuotaType method")
+//			},
+//			BumpStrimziVersionFunc: func(ids []string, targetVersion string) (int64, *apiErrors.ServiceError) {
+//				panic("mock out the BumpStrimziVersion method")
+//			},
+//			CancelDeprovisionFunc: func(ctx context.Context, id string) *apiErrors.ServiceError {
+//				panic("mock out the CancelDeprovision method")
+//			},
+//			CancelUpgradeFunc: func(id string) *apiErrors.ServiceError {
+//				panic("mock out the CancelUpgrade method")
+//			},
 //			ChangeKafkaCNAMErecordsFunc: func(kafkaRequest *dbapi.KafkaRequest, action KafkaRoutesAction) (*route53.ChangeResourceRecordSetsOutput, *apiErrors.ServiceError) {
 //				panic("mock out the ChangeKafkaCNAMErecords method")
 //			},
+//			ChangeKafkaCNAMErecordsBatchFunc: func(kafkaRequests []*dbapi.KafkaRequest, action KafkaRoutesAction) map[string]*CNAMEChangeResult {
+//				panic("mock out the ChangeKafkaCNAMErecordsBatch method")
+//			},
+//			CleanupOrphanedCanaryAccountsFunc: func(dryRun bool) ([]string, *apiErrors.ServiceError) {
+//				panic("mock out the CleanupOrphanedCanaryAccounts method")
+//			},
+//			ConfirmQuotaFunc: func(id string) *apiErrors.ServiceError {
+//				panic("mock out the ConfirmQuota method")
+//			},
+//			CountByClusterAndStatusFunc: func(clusterID string) (map[constants2.KafkaStatus]int, error) {
+//				panic("mock out the CountByClusterAndStatus method")
+//			},
+//			CountByMultiAZFunc: func(region string) (map[bool]int, error) {
+//				panic("mock out the CountByMultiAZ method")
+//			},
 //			CountByStatusFunc: func(status []constants2.KafkaStatus) ([]KafkaStatusCount, error) {
 //				panic("mock out the CountByStatus method")
 //			},
 //			DeleteFunc: func(kafkaRequest *dbapi.KafkaRequest) *apiErrors.ServiceError {
 //				panic("mock out the Delete method")
 //			},
+//			DeleteExpiredPendingQuotaKafkasFunc: func() (int64, *apiErrors.ServiceError) {
+//				panic("mock out the DeleteExpiredPendingQuotaKafkas method")
+//			},
 //			DeprovisionExpiredKafkasFunc: func() *apiErrors.ServiceError {
 //				panic("mock out the DeprovisionExpiredKafkas method")
 //			},
 //			DeprovisionKafkaForUsersFunc: func(users []string) *apiErrors.ServiceError {
 //				panic("mock out the DeprovisionKafkaForUsers method")
 //			},
+//			ExplainPlacementFunc: func(criteria *FindClusterCriteria) (*PlacementExplanation, *apiErrors.ServiceError) {
+//				panic("mock out the ExplainPlacement method")
+//			},
+//			ExportCostAllocationFunc: func(from time.Time, to time.Time) ([]CostAllocationRecord, *apiErrors.ServiceError) {
+//				panic("mock out the ExportCostAllocation method")
+//			},
+//			ExportManagedKafkaCRsYAMLFunc: func(clusterID string) ([]byte, *apiErrors.ServiceError) {
+//				panic("mock out the ExportManagedKafkaCRsYAML method")
+//			},
+//			FailStaleProvisioningKafkasFunc: func() ([]string, *apiErrors.ServiceError) {
+//				panic("mock out the FailStaleProvisioningKafkas method")
+//			},
+//			ForceDeleteFunc: func(ctx context.Context, id string) *apiErrors.ServiceError {
+//				panic("mock out the ForceDelete method")
+//			},
 //			GenerateReservedManagedKafkasByClusterIDFunc: func(clusterID string) ([]managedkafka.ManagedKafka, *apiErrors.ServiceError) {
 //				panic("mock out the GenerateReservedManagedKafkasByClusterID method")
 //			},
 //			GetFunc: func(ctx context.Context, id string) (*dbapi.KafkaRequest, *apiErrors.ServiceError) {
 //				panic("mock out the Get method")
 //			},
+//			GetAllowedInstanceTypesFunc: func(ctx context.Context) ([]types.KafkaInstanceType, *apiErrors.ServiceError) {
+//				panic("mock out the GetAllowedInstanceTypes method")
+//			},
 //			GetAvailableSizesInRegionFunc: func(criteria *FindClusterCriteria) ([]string, *apiErrors.ServiceError) {
 //				panic("mock out the GetAvailableSizesInRegion method")
 //			},
+//			GetBillingModelFunc: func(ctx context.Context, id string) (*KafkaBillingModel, *apiErrors.ServiceError) {
+//				panic("mock out the GetBillingModel method")
+//			},
 //			GetByIdFunc: func(id string) (*dbapi.KafkaRequest, *apiErrors.ServiceError) {
 //				panic("mock out the GetById method")
 //			},
+//			GetByIdForContextFunc: func(ctx context.Context, id string) (*dbapi.KafkaRequest, *apiErrors.ServiceError) {
+//				panic("mock out the GetByIdForContext method")
+//			},
+//			GetByIdIncludingDeletedFunc: func(id string) (*dbapi.KafkaRequest, *apiErrors.ServiceError) {
+//				panic("mock out the GetByIdIncludingDeleted method")
+//			},
+//			GetByNameFunc: func(ctx context.Context, name string) (*dbapi.KafkaRequest, *apiErrors.ServiceError) {
+//				panic("mock out the GetByName method")
+//			},
+//			GetByNamespaceFunc: func(clusterID string, namespace string) (*dbapi.KafkaRequest, *apiErrors.ServiceError) {
+//				panic("mock out the GetByNamespace method")
+//			},
 //			GetCNAMERecordStatusFunc: func(kafkaRequest *dbapi.KafkaRequest) (*CNameRecordStatus, error) {
 //				panic("mock out the GetCNAMERecordStatus method")
 //			},
+//			GetCapacityReportFunc: func() ([]RegionCapacityReport, *apiErrors.ServiceError) {
+//				panic("mock out the GetCapacityReport method")
+//			},
+//			GetClusterForKafkaFunc: func(id string) (*api.Cluster, *apiErrors.ServiceError) {
+//				panic("mock out the GetClusterForKafka method")
+//			},
+//			GetDeprovisionReasonFunc: func(id string) (constants2.KafkaDeprovisionReason, *apiErrors.ServiceError) {
+//				panic("mock out the GetDeprovisionReason method")
+//			},
+//			GetFleetSummaryFunc: func() (*FleetSummary, *apiErrors.ServiceError) {
+//				panic("mock out the GetFleetSummary method")
+//			},
 //			GetManagedKafkaByClusterIDFunc: func(clusterID string) ([]managedkafka.ManagedKafka, *apiErrors.ServiceError) {
 //				panic("mock out the GetManagedKafkaByClusterID method")
 //			},
+//			GetManagedKafkaByClusterIDChangedSinceFunc: func(clusterID string, since time.Time) ([]managedkafka.ManagedKafka, *apiErrors.ServiceError) {
+//				panic("mock out the GetManagedKafkaByClusterIDChangedSince method")
+//			},
+//			GetOwnerSummaryFunc: func(ctx context.Context) (*OwnerKafkaSummary, *apiErrors.ServiceError) {
+//				panic("mock out the GetOwnerSummary method")
+//			},
+//			GetProvisioningDurationFunc: func(from time.Time, to time.Time) ([]ProvisioningDurationPercentiles, *apiErrors.ServiceError) {
+//				panic("mock out the GetProvisioningDuration method")
+//			},
+//			GetQuotaCostFunc: func(instanceType types.KafkaInstanceType, sizeId string) (int, *apiErrors.ServiceError) {
+//				panic("mock out the GetQuotaCost method")
+//			},
+//			GetRegionStatusFunc: func(provider string, region string, instanceType string) (*RegionStatus, *apiErrors.ServiceError) {
+//				panic("mock out the GetRegionStatus method")
+//			},
+//			GetStreamingUnitUsageByClusterIDFunc: func(clusterID string) ([]StreamingUnitUsage, *apiErrors.ServiceError) {
+//				panic("mock out the GetStreamingUnitUsageByClusterID method")
+//			},
+//			GetWithFieldsFunc: func(ctx context.Context, id string, columns []string) (*dbapi.KafkaRequest, *apiErrors.ServiceError) {
+//				panic("mock out the GetWithFields method")
+//			},
+//			GetWithRoutesFunc: func(ctx context.Context, id string) (*KafkaWithRoutes, *apiErrors.ServiceError) {
+//				panic("mock out the GetWithRoutes method")
+//			},
 //			HasAvailableCapacityInRegionFunc: func(kafkaRequest *dbapi.KafkaRequest) (bool, *apiErrors.ServiceError) {
 //				panic("mock out the HasAvailableCapacityInRegion method")
 //			},
+//			InvalidateBillingAccountsFunc: func(externalId string) *apiErrors.ServiceError {
+//				panic("mock out the InvalidateBillingAccounts method")
+//			},
 //			ListFunc: func(ctx context.Context, listArgs *services.ListArguments) (dbapi.KafkaList, *api.PagingMeta, *apiErrors.ServiceError) {
 //				panic("mock out the List method")
 //			},
+//			ListByBillingCloudAccountIdFunc: func(accountId string, listArgs *services.ListArguments) (dbapi.KafkaList, *api.PagingMeta, *apiErrors.ServiceError) {
+//				panic("mock out the ListByBillingCloudAccountId method")
+//			},
+//			ListByCreatedRangeFunc: func(from time.Time, to time.Time, listArgs *services.ListArguments) (dbapi.KafkaList, *api.PagingMeta, *apiErrors.ServiceError) {
+//				panic("mock out the ListByCreatedRange method")
+//			},
+//			ListByDesiredStrimziVersionFunc: func(version string, listArgs *services.ListArguments) (dbapi.KafkaList, *api.PagingMeta, *apiErrors.ServiceError) {
+//				panic("mock out the ListByDesiredStrimziVersion method")
+//			},
+//			ListByInstanceTypeFunc: func(instanceType types.KafkaInstanceType, listArgs *services.ListArguments) (dbapi.KafkaList, *api.PagingMeta, *apiErrors.ServiceError) {
+//				panic("mock out the ListByInstanceType method")
+//			},
+//			ListByQuotaTypeFunc: func(quotaType string, listArgs *services.ListArguments) (dbapi.KafkaList, *api.PagingMeta, *apiErrors.ServiceError) {
+//				panic("mock out the ListByQuotaType method")
+//			},
+//			ListByRegionFunc: func(provider string, region string, listArgs *services.ListArguments) (dbapi.KafkaList, *api.PagingMeta, *apiErrors.ServiceError) {
+//				panic("mock out the ListByRegion method")
+//			},
 //			ListByStatusFunc: func(status ...constants2.KafkaStatus) ([]*dbapi.KafkaRequest, *apiErrors.ServiceError) {
 //				panic("mock out the ListByStatus method")
 //			},
 //			ListComponentVersionsFunc: func() ([]KafkaComponentVersions, error) {
 //				panic("mock out the ListComponentVersions method")
 //			},
+//			ListDuplicateBootstrapHostsFunc: func() (map[string][]string, *apiErrors.ServiceError) {
+//				panic("mock out the ListDuplicateBootstrapHosts method")
+//			},
+//			ListKafkasMissingCanaryAccountFunc: func() ([]*dbapi.KafkaRequest, *apiErrors.ServiceError) {
+//				panic("mock out the ListKafkasMissingCanaryAccount method")
+//			},
 //			ListKafkasWithRoutesNotCreatedFunc: func() ([]*dbapi.KafkaRequest, *apiErrors.ServiceError) {
 //				panic("mock out the ListKafkasWithRoutesNotCreated method")
 //			},
+//			ListOrphanedCanaryAccountsFunc: func() ([]OrphanedCanaryAccount, *apiErrors.ServiceError) {
+//				panic("mock out the ListOrphanedCanaryAccounts method")
+//			},
+//			ListReauthDisabledFunc: func(listArgs *services.ListArguments) (dbapi.KafkaList, *api.PagingMeta, *apiErrors.ServiceError) {
+//				panic("mock out the ListReauthDisabled method")
+//			},
+//			ListStuckDeprovisioningFunc: func(olderThan time.Duration) ([]*dbapi.KafkaRequest, *apiErrors.ServiceError) {
+//				panic("mock out the ListStuckDeprovisioning method")
+//			},
+//			ListStuckUpgradesFunc: func(olderThan time.Duration) ([]KafkaComponentVersions, error) {
+//				panic("mock out the ListStuckUpgrades method")
+//			},
+//			ListWithClusterDetailsFunc: func(ctx context.Context, listArgs *services.ListArguments, includeClusterDetails bool) ([]*KafkaWithClusterDetails, *api.PagingMeta, *apiErrors.ServiceError) {
+//				panic("mock out the ListWithClusterDetails method")
+//			},
+//			PauseReconciliationFunc: func(id string) *apiErrors.ServiceError {
+//				panic("mock out the PauseReconciliation method")
+//			},
 //			PrepareKafkaRequestFunc: func(kafkaRequest *dbapi.KafkaRequest) *apiErrors.ServiceError {
 //				panic("mock out the PrepareKafkaRequest method")
 //			},
+//			PromoteExpiredDeprovisionPendingKafkasFunc: func() (int64, *apiErrors.ServiceError) {
+//				panic("mock out the PromoteExpiredDeprovisionPendingKafkas method")
+//			},
+//			RecomputeRegionCapacityUsageFunc: func() *apiErrors.ServiceError {
+//				panic("mock out the RecomputeRegionCapacityUsage method")
+//			},
+//			RecreateRoutesFunc: func(id string) *apiErrors.ServiceError {
+//				panic("mock out the RecreateRoutes method")
+//			},
 //			RegisterKafkaDeprovisionJobFunc: func(ctx context.Context, id string) *apiErrors.ServiceError {
 //				panic("mock out the RegisterKafkaDeprovisionJob method")
 //			},
 //			RegisterKafkaJobFunc: func(kafkaRequest *dbapi.KafkaRequest) *apiErrors.ServiceError {
 //				panic("mock out the RegisterKafkaJob method")
 //			},
+//			RegisterKafkaJobWithDeferredQuotaFunc: func(kafkaRequest *dbapi.KafkaRequest) *apiErrors.ServiceError {
+//				panic("mock out the RegisterKafkaJobWithDeferredQuota method")
+//			},
+//			RepairCanaryAccountsFunc: func(dryRun bool) ([]string, *apiErrors.ServiceError) {
+//				panic("mock out the RepairCanaryAccounts method")
+//			},
+//			RepairMissingNamespacesFunc: func() (int64, *apiErrors.ServiceError) {
+//				panic("mock out the RepairMissingNamespaces method")
+//			},
+//			RepairMultiAZFunc: func() (int64, *apiErrors.ServiceError) {
+//				panic("mock out the RepairMultiAZ method")
+//			},
+//			ResumeReconciliationFunc: func(id string) *apiErrors.ServiceError {
+//				panic("mock out the ResumeReconciliation method")
+//			},
+//			RetryFailedFunc: func(id string) *apiErrors.ServiceError {
+//				panic("mock out the RetryFailed method")
+//			},
+//			SetAnnotationsFunc: func(id string, annotations map[string]string) *apiErrors.ServiceError {
+//				panic("mock out the SetAnnotations method")
+//			},
+//			SetCapacityConsumedOverrideFunc: func(id string, override *int) *apiErrors.ServiceError {
+//				panic("mock out the SetCapacityConsumedOverride method")
+//			},
+//			SetKafkaStorageSizeFunc: func(id string, size string) *apiErrors.ServiceError {
+//				panic("mock out the SetKafkaStorageSize method")
+//			},
+//			SetMaintenanceWindowFunc: func(id string, day string, start string, end string) *apiErrors.ServiceError {
+//				panic("mock out the SetMaintenanceWindow method")
+//			},
+//			SetMaxConnectionAttemptsPerSecOverrideFunc: func(id string, override *int) *apiErrors.ServiceError {
+//				panic("mock out the SetMaxConnectionAttemptsPerSecOverride method")
+//			},
+//			SetReauthenticationBulkFunc: func(ids []string, enabled bool) (int64, *apiErrors.ServiceError) {
+//				panic("mock out the SetReauthenticationBulk method")
+//			},
+//			StreamAllFunc: func(ctx context.Context, batchSize int, fn func(*dbapi.KafkaRequest) error) *apiErrors.ServiceError {
+//				panic("mock out the StreamAll method")
+//			},
 //			UpdateFunc: func(kafkaRequest *dbapi.KafkaRequest) *apiErrors.ServiceError {
 //				panic("mock out the Update method")
 //			},
@@ -101,6 +297,12 @@ var _ KafkaService = &KafkaServiceMock{}
 //			ValidateBillingAccountFunc: func(externalId string, instanceType types.KafkaInstanceType, billingCloudAccountId string, marketplace *string) *apiErrors.ServiceError {
 //				panic("mock out the ValidateBillingAccount method")
 //			},
+//			ValidateCreateRequestFunc: func(kafkaRequest *dbapi.KafkaRequest) *apiErrors.ServiceError {
+//				panic("mock out the ValidateCreateRequest method")
+//			},
+//			ValidateRoutesFunc: func(routes []dbapi.DataPlaneKafkaRoute) *apiErrors.ServiceError {
+//				panic("mock out the ValidateRoutes method")
+//			},
 //			VerifyAndUpdateKafkaAdminFunc: func(ctx context.Context, kafkaRequest *dbapi.KafkaRequest) *apiErrors.ServiceError {
 //				panic("mock out the VerifyAndUpdateKafkaAdmin method")
 //			},
@@ -111,69 +313,264 @@ var _ KafkaService = &KafkaServiceMock{}
 //
 //	}
 type KafkaServiceMock struct {
+	// AbortPendingQuotaFunc mocks the AbortPendingQuota method.
+	AbortPendingQuotaFunc func(id string) *apiErrors.ServiceError
+
 	// AssignBootstrapServerHostFunc mocks the AssignBootstrapServerHost method.
 	AssignBootstrapServerHostFunc func(kafkaRequest *dbapi.KafkaRequest) error
 
 	// AssignInstanceTypeFunc mocks the AssignInstanceType method.
 	AssignInstanceTypeFunc func(owner string, organisationID string) (types.KafkaInstanceType, *apiErrors.ServiceError)
 
+	// BackfillQuotaTypeFunc mocks the BackfillQuotaType method.
+	BackfillQuotaTypeFunc func() (int64, *apiErrors.ServiceError)
+
+	// BumpStrimziVersionFunc mocks the BumpStrimziVersion method.
+	BumpStrimziVersionFunc func(ids []string, targetVersion string) (int64, *apiErrors.ServiceError)
+
+	// CancelDeprovisionFunc mocks the CancelDeprovision method.
+	CancelDeprovisionFunc func(ctx context.Context, id string) *apiErrors.ServiceError
+
+	// CancelUpgradeFunc mocks the CancelUpgrade method.
+	CancelUpgradeFunc func(id string) *apiErrors.ServiceError
+
 	// ChangeKafkaCNAMErecordsFunc mocks the ChangeKafkaCNAMErecords method.
 	ChangeKafkaCNAMErecordsFunc func(kafkaRequest *dbapi.KafkaRequest, action KafkaRoutesAction) (*route53.ChangeResourceRecordSetsOutput, *apiErrors.ServiceError)
 
+	// ChangeKafkaCNAMErecordsBatchFunc mocks the ChangeKafkaCNAMErecordsBatch method.
+	ChangeKafkaCNAMErecordsBatchFunc func(kafkaRequests []*dbapi.KafkaRequest, action KafkaRoutesAction) map[string]*CNAMEChangeResult
+
+	// CleanupOrphanedCanaryAccountsFunc mocks the CleanupOrphanedCanaryAccounts method.
+	CleanupOrphanedCanaryAccountsFunc func(dryRun bool) ([]string, *apiErrors.ServiceError)
+
+	// ConfirmQuotaFunc mocks the ConfirmQuota method.
+	ConfirmQuotaFunc func(id string) *apiErrors.ServiceError
+
+	// CountByClusterAndStatusFunc mocks the CountByClusterAndStatus method.
+	CountByClusterAndStatusFunc func(clusterID string) (map[constants2.KafkaStatus]int, error)
+
+	// CountByMultiAZFunc mocks the CountByMultiAZ method.
+	CountByMultiAZFunc func(region string) (map[bool]int, error)
+
 	// CountByStatusFunc mocks the CountByStatus method.
 	CountByStatusFunc func(status []constants2.KafkaStatus) ([]KafkaStatusCount, error)
 
 	// DeleteFunc mocks the Delete method.
 	DeleteFunc func(kafkaRequest *dbapi.KafkaRequest) *apiErrors.ServiceError
 
+	// DeleteExpiredPendingQuotaKafkasFunc mocks the DeleteExpiredPendingQuotaKafkas method.
+	DeleteExpiredPendingQuotaKafkasFunc func() (int64, *apiErrors.ServiceError)
+
 	// DeprovisionExpiredKafkasFunc mocks the DeprovisionExpiredKafkas method.
 	DeprovisionExpiredKafkasFunc func() *apiErrors.ServiceError
 
 	// DeprovisionKafkaForUsersFunc mocks the DeprovisionKafkaForUsers method.
 	DeprovisionKafkaForUsersFunc func(users []string) *apiErrors.ServiceError
 
+	// ExplainPlacementFunc mocks the ExplainPlacement method.
+	ExplainPlacementFunc func(criteria *FindClusterCriteria) (*PlacementExplanation, *apiErrors.ServiceError)
+
+	// ExportCostAllocationFunc mocks the ExportCostAllocation method.
+	ExportCostAllocationFunc func(from time.Time, to time.Time) ([]CostAllocationRecord, *apiErrors.ServiceError)
+
+	// ExportManagedKafkaCRsYAMLFunc mocks the ExportManagedKafkaCRsYAML method.
+	ExportManagedKafkaCRsYAMLFunc func(clusterID string) ([]byte, *apiErrors.ServiceError)
+
+	// FailStaleProvisioningKafkasFunc mocks the FailStaleProvisioningKafkas method.
+	FailStaleProvisioningKafkasFunc func() ([]string, *apiErrors.ServiceError)
+
+	// ForceDeleteFunc mocks the ForceDelete method.
+	ForceDeleteFunc func(ctx context.Context, id string) *apiErrors.ServiceError
+
 	// GenerateReservedManagedKafkasByClusterIDFunc mocks the GenerateReservedManagedKafkasByClusterID method.
 	GenerateReservedManagedKafkasByClusterIDFunc func(clusterID string) ([]managedkafka.ManagedKafka, *apiErrors.ServiceError)
 
 	// GetFunc mocks the Get method.
 	GetFunc func(ctx context.Context, id string) (*dbapi.KafkaRequest, *apiErrors.ServiceError)
 
+	// GetAllowedInstanceTypesFunc mocks the GetAllowedInstanceTypes method.
+	GetAllowedInstanceTypesFunc func(ctx context.Context) ([]types.KafkaInstanceType, *apiErrors.ServiceError)
+
 	// GetAvailableSizesInRegionFunc mocks the GetAvailableSizesInRegion method.
 	GetAvailableSizesInRegionFunc func(criteria *FindClusterCriteria) ([]string, *apiErrors.ServiceError)
 
+	// GetBillingModelFunc mocks the GetBillingModel method.
+	GetBillingModelFunc func(ctx context.Context, id string) (*KafkaBillingModel, *apiErrors.ServiceError)
+
 	// GetByIdFunc mocks the GetById method.
 	GetByIdFunc func(id string) (*dbapi.KafkaRequest, *apiErrors.ServiceError)
 
+	// GetByIdForContextFunc mocks the GetByIdForContext method.
+	GetByIdForContextFunc func(ctx context.Context, id string) (*dbapi.KafkaRequest, *apiErrors.ServiceError)
+
+	// GetByIdIncludingDeletedFunc mocks the GetByIdIncludingDeleted method.
+	GetByIdIncludingDeletedFunc func(id string) (*dbapi.KafkaRequest, *apiErrors.ServiceError)
+
+	// GetByNameFunc mocks the GetByName method.
+	GetByNameFunc func(ctx context.Context, name string) (*dbapi.KafkaRequest, *apiErrors.ServiceError)
+
+	// GetByNamespaceFunc mocks the GetByNamespace method.
+	GetByNamespaceFunc func(clusterID string, namespace string) (*dbapi.KafkaRequest, *apiErrors.ServiceError)
+
 	// GetCNAMERecordStatusFunc mocks the GetCNAMERecordStatus method.
 	GetCNAMERecordStatusFunc func(kafkaRequest *dbapi.KafkaRequest) (*CNameRecordStatus, error)
 
+	// GetCapacityReportFunc mocks the GetCapacityReport method.
+	GetCapacityReportFunc func() ([]RegionCapacityReport, *apiErrors.ServiceError)
+
+	// GetClusterForKafkaFunc mocks the GetClusterForKafka method.
+	GetClusterForKafkaFunc func(id string) (*api.Cluster, *apiErrors.ServiceError)
+
+	// GetDeprovisionReasonFunc mocks the GetDeprovisionReason method.
+	GetDeprovisionReasonFunc func(id string) (constants2.KafkaDeprovisionReason, *apiErrors.ServiceError)
+
+	// GetFleetSummaryFunc mocks the GetFleetSummary method.
+	GetFleetSummaryFunc func() (*FleetSummary, *apiErrors.ServiceError)
+
 	// GetManagedKafkaByClusterIDFunc mocks the GetManagedKafkaByClusterID method.
 	GetManagedKafkaByClusterIDFunc func(clusterID string) ([]managedkafka.ManagedKafka, *apiErrors.ServiceError)
 
+	// GetManagedKafkaByClusterIDChangedSinceFunc mocks the GetManagedKafkaByClusterIDChangedSince method.
+	GetManagedKafkaByClusterIDChangedSinceFunc func(clusterID string, since time.Time) ([]managedkafka.ManagedKafka, *apiErrors.ServiceError)
+
+	// GetOwnerSummaryFunc mocks the GetOwnerSummary method.
+	GetOwnerSummaryFunc func(ctx context.Context) (*OwnerKafkaSummary, *apiErrors.ServiceError)
+
+	// GetProvisioningDurationFunc mocks the GetProvisioningDuration method.
+	GetProvisioningDurationFunc func(from time.Time, to time.Time) ([]ProvisioningDurationPercentiles, *apiErrors.ServiceError)
+
+	// GetQuotaCostFunc mocks the GetQuotaCost method.
+	GetQuotaCostFunc func(instanceType types.KafkaInstanceType, sizeId string) (int, *apiErrors.ServiceError)
+
+	// GetRegionStatusFunc mocks the GetRegionStatus method.
+	GetRegionStatusFunc func(provider string, region string, instanceType string) (*RegionStatus, *apiErrors.ServiceError)
+
+	// GetStreamingUnitUsageByClusterIDFunc mocks the GetStreamingUnitUsageByClusterID method.
+	GetStreamingUnitUsageByClusterIDFunc func(clusterID string) ([]StreamingUnitUsage, *apiErrors.ServiceError)
+
+	// GetWithFieldsFunc mocks the GetWithFields method.
+	GetWithFieldsFunc func(ctx context.Context, id string, columns []string) (*dbapi.KafkaRequest, *apiErrors.ServiceError)
+
+	// GetWithRoutesFunc mocks the GetWithRoutes method.
+	GetWithRoutesFunc func(ctx context.Context, id string) (*KafkaWithRoutes, *apiErrors.ServiceError)
+
 	// HasAvailableCapacityInRegionFunc mocks the HasAvailableCapacityInRegion method.
 	HasAvailableCapacityInRegionFunc func(kafkaRequest *dbapi.KafkaRequest) (bool, *apiErrors.ServiceError)
 
+	// InvalidateBillingAccountsFunc mocks the InvalidateBillingAccounts method.
+	InvalidateBillingAccountsFunc func(externalId string) *apiErrors.ServiceError
+
 	// ListFunc mocks the List method.
 	ListFunc func(ctx context.Context, listArgs *services.ListArguments) (dbapi.KafkaList, *api.PagingMeta, *apiErrors.ServiceError)
 
+	// ListByBillingCloudAccountIdFunc mocks the ListByBillingCloudAccountId method.
+	ListByBillingCloudAccountIdFunc func(accountId string, listArgs *services.ListArguments) (dbapi.KafkaList, *api.PagingMeta, *apiErrors.ServiceError)
+
+	// ListByCreatedRangeFunc mocks the ListByCreatedRange method.
+	ListByCreatedRangeFunc func(from time.Time, to time.Time, listArgs *services.ListArguments) (dbapi.KafkaList, *api.PagingMeta, *apiErrors.ServiceError)
+
+	// ListByDesiredStrimziVersionFunc mocks the ListByDesiredStrimziVersion method.
+	ListByDesiredStrimziVersionFunc func(version string, listArgs *services.ListArguments) (dbapi.KafkaList, *api.PagingMeta, *apiErrors.ServiceError)
+
+	// ListByInstanceTypeFunc mocks the ListByInstanceType method.
+	ListByInstanceTypeFunc func(instanceType types.KafkaInstanceType, listArgs *services.ListArguments) (dbapi.KafkaList, *api.PagingMeta, *apiErrors.ServiceError)
+
+	// ListByQuotaTypeFunc mocks the ListByQuotaType method.
+	ListByQuotaTypeFunc func(quotaType string, listArgs *services.ListArguments) (dbapi.KafkaList, *api.PagingMeta, *apiErrors.ServiceError)
+
+	// ListByRegionFunc mocks the ListByRegion method.
+	ListByRegionFunc func(provider string, region string, listArgs *services.ListArguments) (dbapi.KafkaList, *api.PagingMeta, *apiErrors.ServiceError)
+
 	// ListByStatusFunc mocks the ListByStatus method.
 	ListByStatusFunc func(status ...constants2.KafkaStatus) ([]*dbapi.KafkaRequest, *apiErrors.ServiceError)
 
 	// ListComponentVersionsFunc mocks the ListComponentVersions method.
 	ListComponentVersionsFunc func() ([]KafkaComponentVersions, error)
 
+	// ListDuplicateBootstrapHostsFunc mocks the ListDuplicateBootstrapHosts method.
+	ListDuplicateBootstrapHostsFunc func() (map[string][]string, *apiErrors.ServiceError)
+
+	// ListKafkasMissingCanaryAccountFunc mocks the ListKafkasMissingCanaryAccount method.
+	ListKafkasMissingCanaryAccountFunc func() ([]*dbapi.KafkaRequest, *apiErrors.ServiceError)
+
 	// ListKafkasWithRoutesNotCreatedFunc mocks the ListKafkasWithRoutesNotCreated method.
 	ListKafkasWithRoutesNotCreatedFunc func() ([]*dbapi.KafkaRequest, *apiErrors.ServiceError)
 
+	// ListOrphanedCanaryAccountsFunc mocks the ListOrphanedCanaryAccounts method.
+	ListOrphanedCanaryAccountsFunc func() ([]OrphanedCanaryAccount, *apiErrors.ServiceError)
+
+	// ListReauthDisabledFunc mocks the ListReauthDisabled method.
+	ListReauthDisabledFunc func(listArgs *services.ListArguments) (dbapi.KafkaList, *api.PagingMeta, *apiErrors.ServiceError)
+
+	// ListStuckDeprovisioningFunc mocks the ListStuckDeprovisioning method.
+	ListStuckDeprovisioningFunc func(olderThan time.Duration) ([]*dbapi.KafkaRequest, *apiErrors.ServiceError)
+
+	// ListStuckUpgradesFunc mocks the ListStuckUpgrades method.
+	ListStuckUpgradesFunc func(olderThan time.Duration) ([]KafkaComponentVersions, error)
+
+	// ListWithClusterDetailsFunc mocks the ListWithClusterDetails method.
+	ListWithClusterDetailsFunc func(ctx context.Context, listArgs *services.ListArguments, includeClusterDetails bool) ([]*KafkaWithClusterDetails, *api.PagingMeta, *apiErrors.ServiceError)
+
+	// PauseReconciliationFunc mocks the PauseReconciliation method.
+	PauseReconciliationFunc func(id string) *apiErrors.ServiceError
+
 	// PrepareKafkaRequestFunc mocks the PrepareKafkaRequest method.
 	PrepareKafkaRequestFunc func(kafkaRequest *dbapi.KafkaRequest) *apiErrors.ServiceError
 
+	// PromoteExpiredDeprovisionPendingKafkasFunc mocks the PromoteExpiredDeprovisionPendingKafkas method.
+	PromoteExpiredDeprovisionPendingKafkasFunc func() (int64, *apiErrors.ServiceError)
+
+	// RecomputeRegionCapacityUsageFunc mocks the RecomputeRegionCapacityUsage method.
+	RecomputeRegionCapacityUsageFunc func() *apiErrors.ServiceError
+
+	// RecreateRoutesFunc mocks the RecreateRoutes method.
+	RecreateRoutesFunc func(id string) *apiErrors.ServiceError
+
 	// RegisterKafkaDeprovisionJobFunc mocks the RegisterKafkaDeprovisionJob method.
 	RegisterKafkaDeprovisionJobFunc func(ctx context.Context, id string) *apiErrors.ServiceError
 
 	// RegisterKafkaJobFunc mocks the RegisterKafkaJob method.
 	RegisterKafkaJobFunc func(kafkaRequest *dbapi.KafkaRequest) *apiErrors.ServiceError
 
+	// RegisterKafkaJobWithDeferredQuotaFunc mocks the RegisterKafkaJobWithDeferredQuota method.
+	RegisterKafkaJobWithDeferredQuotaFunc func(kafkaRequest *dbapi.KafkaRequest) *apiErrors.ServiceError
+
+	// RepairCanaryAccountsFunc mocks the RepairCanaryAccounts method.
+	RepairCanaryAccountsFunc func(dryRun bool) ([]string, *apiErrors.ServiceError)
+
+	// RepairMissingNamespacesFunc mocks the RepairMissingNamespaces method.
+	RepairMissingNamespacesFunc func() (int64, *apiErrors.ServiceError)
+
+	// RepairMultiAZFunc mocks the RepairMultiAZ method.
+	RepairMultiAZFunc func() (int64, *apiErrors.ServiceError)
+
+	// ResumeReconciliationFunc mocks the ResumeReconciliation method.
+	ResumeReconciliationFunc func(id string) *apiErrors.ServiceError
+
+	// RetryFailedFunc mocks the RetryFailed method.
+	RetryFailedFunc func(id string) *apiErrors.ServiceError
+
+	// SetAnnotationsFunc mocks the SetAnnotations method.
+	SetAnnotationsFunc func(id string, annotations map[string]string) *apiErrors.ServiceError
+
+	// SetCapacityConsumedOverrideFunc mocks the SetCapacityConsumedOverride method.
+	SetCapacityConsumedOverrideFunc func(id string, override *int) *apiErrors.ServiceError
+
+	// SetKafkaStorageSizeFunc mocks the SetKafkaStorageSize method.
+	SetKafkaStorageSizeFunc func(id string, size string) *apiErrors.ServiceError
+
+	// SetMaintenanceWindowFunc mocks the SetMaintenanceWindow method.
+	SetMaintenanceWindowFunc func(id string, day string, start string, end string) *apiErrors.ServiceError
+
+	// SetMaxConnectionAttemptsPerSecOverrideFunc mocks the SetMaxConnectionAttemptsPerSecOverride method.
+	SetMaxConnectionAttemptsPerSecOverrideFunc func(id string, override *int) *apiErrors.ServiceError
+
+	// SetReauthenticationBulkFunc mocks the SetReauthenticationBulk method.
+	SetReauthenticationBulkFunc func(ids []string, enabled bool) (int64, *apiErrors.ServiceError)
+
+	// StreamAllFunc mocks the StreamAll method.
+	StreamAllFunc func(ctx context.Context, batchSize int, fn func(*dbapi.KafkaRequest) error) *apiErrors.ServiceError
+
 	// UpdateFunc mocks the Update method.
 	UpdateFunc func(kafkaRequest *dbapi.KafkaRequest) *apiErrors.ServiceError
 
@@ -186,11 +583,22 @@ type KafkaServiceMock struct {
 	// ValidateBillingAccountFunc mocks the ValidateBillingAccount method.
 	ValidateBillingAccountFunc func(externalId string, instanceType types.KafkaInstanceType, billingCloudAccountId string, marketplace *string) *apiErrors.ServiceError
 
+	// ValidateCreateRequestFunc mocks the ValidateCreateRequest method.
+	ValidateCreateRequestFunc func(kafkaRequest *dbapi.KafkaRequest) *apiErrors.ServiceError
+
+	// ValidateRoutesFunc mocks the ValidateRoutes method.
+	ValidateRoutesFunc func(routes []dbapi.DataPlaneKafkaRoute) *apiErrors.ServiceError
+
 	// VerifyAndUpdateKafkaAdminFunc mocks the VerifyAndUpdateKafkaAdmin method.
 	VerifyAndUpdateKafkaAdminFunc func(ctx context.Context, kafkaRequest *dbapi.KafkaRequest) *apiErrors.ServiceError
 
 	// calls tracks calls to the methods.
 	calls struct {
+		// AbortPendingQuota holds details about calls to the AbortPendingQuota method.
+		AbortPendingQuota []struct {
+			// ID is the id argument value.
+			ID string
+		}
 		// AssignBootstrapServerHost holds details about calls to the AssignBootstrapServerHost method.
 		AssignBootstrapServerHost []struct {
 			// KafkaRequest is the kafkaRequest argument value.
@@ -203,6 +611,28 @@ type KafkaServiceMock struct {
 			// OrganisationID is the organisationID argument value.
 			OrganisationID string
 		}
+		// BackfillQuotaType holds details about calls to the BackfillQuotaType method.
+		BackfillQuotaType []struct {
+		}
+		// BumpStrimziVersion holds details about calls to the BumpStrimziVersion method.
+		BumpStrimziVersion []struct {
+			// Ids is the ids argument value.
+			Ids []string
+			// TargetVersion is the targetVersion argument value.
+			TargetVersion string
+		}
+		// CancelDeprovision holds details about calls to the CancelDeprovision method.
+		CancelDeprovision []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID string
+		}
+		// CancelUpgrade holds details about calls to the CancelUpgrade method.
+		CancelUpgrade []struct {
+			// ID is the id argument value.
+			ID string
+		}
 		// ChangeKafkaCNAMErecords holds details about calls to the ChangeKafkaCNAMErecords method.
 		ChangeKafkaCNAMErecords []struct {
 			// KafkaRequest is the kafkaRequest argument value.
@@ -210,6 +640,33 @@ type KafkaServiceMock struct {
 			// Action is the action argument value.
 			Action KafkaRoutesAction
 		}
+		// ChangeKafkaCNAMErecordsBatch holds details about calls to the ChangeKafkaCNAMErecordsBatch method.
+		ChangeKafkaCNAMErecordsBatch []struct {
+			// KafkaRequests is the kafkaRequests argument value.
+			KafkaRequests []*dbapi.KafkaRequest
+			// Action is the action argument value.
+			Action KafkaRoutesAction
+		}
+		// CleanupOrphanedCanaryAccounts holds details about calls to the CleanupOrphanedCanaryAccounts method.
+		CleanupOrphanedCanaryAccounts []struct {
+			// DryRun is the dryRun argument value.
+			DryRun bool
+		}
+		// ConfirmQuota holds details about calls to the ConfirmQuota method.
+		ConfirmQuota []struct {
+			// ID is the id argument value.
+			ID string
+		}
+		// CountByClusterAndStatus holds details about calls to the CountByClusterAndStatus method.
+		CountByClusterAndStatus []struct {
+			// ClusterID is the clusterID argument value.
+			ClusterID string
+		}
+		// CountByMultiAZ holds details about calls to the CountByMultiAZ method.
+		CountByMultiAZ []struct {
+			// Region is the region argument value.
+			Region string
+		}
 		// CountByStatus holds details about calls to the CountByStatus method.
 		CountByStatus []struct {
 			// Status is the status argument value.
@@ -220,6 +677,9 @@ type KafkaServiceMock struct {
 			// KafkaRequest is the kafkaRequest argument value.
 			KafkaRequest *dbapi.KafkaRequest
 		}
+		// DeleteExpiredPendingQuotaKafkas holds details about calls to the DeleteExpiredPendingQuotaKafkas method.
+		DeleteExpiredPendingQuotaKafkas []struct {
+		}
 		// DeprovisionExpiredKafkas holds details about calls to the DeprovisionExpiredKafkas method.
 		DeprovisionExpiredKafkas []struct {
 		}
@@ -228,6 +688,33 @@ type KafkaServiceMock struct {
 			// Users is the users argument value.
 			Users []string
 		}
+		// ExplainPlacement holds details about calls to the ExplainPlacement method.
+		ExplainPlacement []struct {
+			// Criteria is the criteria argument value.
+			Criteria *FindClusterCriteria
+		}
+		// ExportCostAllocation holds details about calls to the ExportCostAllocation method.
+		ExportCostAllocation []struct {
+			// From is the from argument value.
+			From time.Time
+			// To is the to argument value.
+			To time.Time
+		}
+		// ExportManagedKafkaCRsYAML holds details about calls to the ExportManagedKafkaCRsYAML method.
+		ExportManagedKafkaCRsYAML []struct {
+			// ClusterID is the clusterID argument value.
+			ClusterID string
+		}
+		// FailStaleProvisioningKafkas holds details about calls to the FailStaleProvisioningKafkas method.
+		FailStaleProvisioningKafkas []struct {
+		}
+		// ForceDelete holds details about calls to the ForceDelete method.
+		ForceDelete []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID string
+		}
 		// GenerateReservedManagedKafkasByClusterID holds details about calls to the GenerateReservedManagedKafkasByClusterID method.
 		GenerateReservedManagedKafkasByClusterID []struct {
 			// ClusterID is the clusterID argument value.
@@ -240,31 +727,146 @@ type KafkaServiceMock struct {
 			// ID is the id argument value.
 			ID string
 		}
+		// GetAllowedInstanceTypes holds details about calls to the GetAllowedInstanceTypes method.
+		GetAllowedInstanceTypes []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
 		// GetAvailableSizesInRegion holds details about calls to the GetAvailableSizesInRegion method.
 		GetAvailableSizesInRegion []struct {
 			// Criteria is the criteria argument value.
 			Criteria *FindClusterCriteria
 		}
+		// GetBillingModel holds details about calls to the GetBillingModel method.
+		GetBillingModel []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID string
+		}
 		// GetById holds details about calls to the GetById method.
 		GetById []struct {
 			// ID is the id argument value.
 			ID string
 		}
+		// GetByIdForContext holds details about calls to the GetByIdForContext method.
+		GetByIdForContext []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID string
+		}
+		// GetByIdIncludingDeleted holds details about calls to the GetByIdIncludingDeleted method.
+		GetByIdIncludingDeleted []struct {
+			// ID is the id argument value.
+			ID string
+		}
+		// GetByName holds details about calls to the GetByName method.
+		GetByName []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Name is the name argument value.
+			Name string
+		}
+		// GetByNamespace holds details about calls to the GetByNamespace method.
+		GetByNamespace []struct {
+			// ClusterID is the clusterID argument value.
+			ClusterID string
+			// Namespace is the namespace argument value.
+			Namespace string
+		}
 		// GetCNAMERecordStatus holds details about calls to the GetCNAMERecordStatus method.
 		GetCNAMERecordStatus []struct {
 			// KafkaRequest is the kafkaRequest argument value.
 			KafkaRequest *dbapi.KafkaRequest
 		}
+		// GetCapacityReport holds details about calls to the GetCapacityReport method.
+		GetCapacityReport []struct {
+		}
+		// GetClusterForKafka holds details about calls to the GetClusterForKafka method.
+		GetClusterForKafka []struct {
+			// ID is the id argument value.
+			ID string
+		}
+		// GetDeprovisionReason holds details about calls to the GetDeprovisionReason method.
+		GetDeprovisionReason []struct {
+			// ID is the id argument value.
+			ID string
+		}
+		// GetFleetSummary holds details about calls to the GetFleetSummary method.
+		GetFleetSummary []struct {
+		}
 		// GetManagedKafkaByClusterID holds details about calls to the GetManagedKafkaByClusterID method.
 		GetManagedKafkaByClusterID []struct {
 			// ClusterID is the clusterID argument value.
 			ClusterID string
 		}
+		// GetManagedKafkaByClusterIDChangedSince holds details about calls to the GetManagedKafkaByClusterIDChangedSince method.
+		GetManagedKafkaByClusterIDChangedSince []struct {
+			// ClusterID is the clusterID argument value.
+			ClusterID string
+			// Since is the since argument value.
+			Since time.Time
+		}
+		// GetOwnerSummary holds details about calls to the GetOwnerSummary method.
+		GetOwnerSummary []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// GetProvisioningDuration holds details about calls to the GetProvisioningDuration method.
+		GetProvisioningDuration []struct {
+			// From is the from argument value.
+			From time.Time
+			// To is the to argument value.
+			To time.Time
+		}
+		// GetQuotaCost holds details about calls to the GetQuotaCost method.
+		GetQuotaCost []struct {
+			// InstanceType is the instanceType argument value.
+			InstanceType types.KafkaInstanceType
+			// SizeId is the sizeId argument value.
+			SizeId string
+		}
+		// GetRegionStatus holds details about calls to the GetRegionStatus method.
+		GetRegionStatus []struct {
+			// Provider is the provider argument value.
+			Provider string
+			// Region is the region argument value.
+			Region string
+			// InstanceType is the instanceType argument value.
+			InstanceType string
+		}
+		// GetStreamingUnitUsageByClusterID holds details about calls to the GetStreamingUnitUsageByClusterID method.
+		GetStreamingUnitUsageByClusterID []struct {
+			// ClusterID is the clusterID argument value.
+			ClusterID string
+		}
+		// GetWithFields holds details about calls to the GetWithFields method.
+		GetWithFields []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID string
+			// Columns is the columns argument value.
+			Columns []string
+		}
+		// GetWithRoutes holds details about calls to the GetWithRoutes method.
+		GetWithRoutes []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID string
+		}
 		// HasAvailableCapacityInRegion holds details about calls to the HasAvailableCapacityInRegion method.
 		HasAvailableCapacityInRegion []struct {
 			// KafkaRequest is the kafkaRequest argument value.
 			KafkaRequest *dbapi.KafkaRequest
 		}
+		// InvalidateBillingAccounts holds details about calls to the InvalidateBillingAccounts method.
+		InvalidateBillingAccounts []struct {
+			// ExternalId is the externalId argument value.
+			ExternalId string
+		}
 		// List holds details about calls to the List method.
 		List []struct {
 			// Ctx is the ctx argument value.
@@ -272,6 +874,52 @@ type KafkaServiceMock struct {
 			// ListArgs is the listArgs argument value.
 			ListArgs *services.ListArguments
 		}
+		// ListByBillingCloudAccountId holds details about calls to the ListByBillingCloudAccountId method.
+		ListByBillingCloudAccountId []struct {
+			// AccountId is the accountId argument value.
+			AccountId string
+			// ListArgs is the listArgs argument value.
+			ListArgs *services.ListArguments
+		}
+		// ListByCreatedRange holds details about calls to the ListByCreatedRange method.
+		ListByCreatedRange []struct {
+			// From is the from argument value.
+			From time.Time
+			// To is the to argument value.
+			To time.Time
+			// ListArgs is the listArgs argument value.
+			ListArgs *services.ListArguments
+		}
+		// ListByDesiredStrimziVersion holds details about calls to the ListByDesiredStrimziVersion method.
+		ListByDesiredStrimziVersion []struct {
+			// Version is the version argument value.
+			Version string
+			// ListArgs is the listArgs argument value.
+			ListArgs *services.ListArguments
+		}
+		// ListByInstanceType holds details about calls to the ListByInstanceType method.
+		ListByInstanceType []struct {
+			// InstanceType is the instanceType argument value.
+			InstanceType types.KafkaInstanceType
+			// ListArgs is the listArgs argument value.
+			ListArgs *services.ListArguments
+		}
+		// ListByQuotaType holds details about calls to the ListByQuotaType method.
+		ListByQuotaType []struct {
+			// QuotaType is the quotaType argument value.
+			QuotaType string
+			// ListArgs is the listArgs argument value.
+			ListArgs *services.ListArguments
+		}
+		// ListByRegion holds details about calls to the ListByRegion method.
+		ListByRegion []struct {
+			// Provider is the provider argument value.
+			Provider string
+			// Region is the region argument value.
+			Region string
+			// ListArgs is the listArgs argument value.
+			ListArgs *services.ListArguments
+		}
 		// ListByStatus holds details about calls to the ListByStatus method.
 		ListByStatus []struct {
 			// Status is the status argument value.
@@ -280,13 +928,62 @@ type KafkaServiceMock struct {
 		// ListComponentVersions holds details about calls to the ListComponentVersions method.
 		ListComponentVersions []struct {
 		}
+		// ListDuplicateBootstrapHosts holds details about calls to the ListDuplicateBootstrapHosts method.
+		ListDuplicateBootstrapHosts []struct {
+		}
+		// ListKafkasMissingCanaryAccount holds details about calls to the ListKafkasMissingCanaryAccount method.
+		ListKafkasMissingCanaryAccount []struct {
+		}
 		// ListKafkasWithRoutesNotCreated holds details about calls to the ListKafkasWithRoutesNotCreated method.
 		ListKafkasWithRoutesNotCreated []struct {
 		}
-		// PrepareKafkaRequest holds details about calls to the PrepareKafkaRequest method.
-		PrepareKafkaRequest []struct {
-			// KafkaRequest is the kafkaRequest argument value.
-			KafkaRequest *dbapi.KafkaRequest
+		// ListOrphanedCanaryAccounts holds details about calls to the ListOrphanedCanaryAccounts method.
+		ListOrphanedCanaryAccounts []struct {
+		}
+		// ListReauthDisabled holds details about calls to the ListReauthDisabled method.
+		ListReauthDisabled []struct {
+			// ListArgs is the listArgs argument value.
+			ListArgs *services.ListArguments
+		}
+		// ListStuckDeprovisioning holds details about calls to the ListStuckDeprovisioning method.
+		ListStuckDeprovisioning []struct {
+			// OlderThan is the olderThan argument value.
+			OlderThan time.Duration
+		}
+		// ListStuckUpgrades holds details about calls to the ListStuckUpgrades method.
+		ListStuckUpgrades []struct {
+			// OlderThan is the olderThan argument value.
+			OlderThan time.Duration
+		}
+		// ListWithClusterDetails holds details about calls to the ListWithClusterDetails method.
+		ListWithClusterDetails []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ListArgs is the listArgs argument value.
+			ListArgs *services.ListArguments
+			// IncludeClusterDetails is the includeClusterDetails argument value.
+			IncludeClusterDetails bool
+		}
+		// PauseReconciliation holds details about calls to the PauseReconciliation method.
+		PauseReconciliation []struct {
+			// ID is the id argument value.
+			ID string
+		}
+		// PrepareKafkaRequest holds details about calls to the PrepareKafkaRequest method.
+		PrepareKafkaRequest []struct {
+			// KafkaRequest is the kafkaRequest argument value.
+			KafkaRequest *dbapi.KafkaRequest
+		}
+		// PromoteExpiredDeprovisionPendingKafkas holds details about calls to the PromoteExpiredDeprovisionPendingKafkas method.
+		PromoteExpiredDeprovisionPendingKafkas []struct {
+		}
+		// RecomputeRegionCapacityUsage holds details about calls to the RecomputeRegionCapacityUsage method.
+		RecomputeRegionCapacityUsage []struct {
+		}
+		// RecreateRoutes holds details about calls to the RecreateRoutes method.
+		RecreateRoutes []struct {
+			// ID is the id argument value.
+			ID string
 		}
 		// RegisterKafkaDeprovisionJob holds details about calls to the RegisterKafkaDeprovisionJob method.
 		RegisterKafkaDeprovisionJob []struct {
@@ -300,6 +997,87 @@ type KafkaServiceMock struct {
 			// KafkaRequest is the kafkaRequest argument value.
 			KafkaRequest *dbapi.KafkaRequest
 		}
+		// RegisterKafkaJobWithDeferredQuota holds details about calls to the RegisterKafkaJobWithDeferredQuota method.
+		RegisterKafkaJobWithDeferredQuota []struct {
+			// KafkaRequest is the kafkaRequest argument value.
+			KafkaRequest *dbapi.KafkaRequest
+		}
+		// RepairCanaryAccounts holds details about calls to the RepairCanaryAccounts method.
+		RepairCanaryAccounts []struct {
+			// DryRun is the dryRun argument value.
+			DryRun bool
+		}
+		// RepairMissingNamespaces holds details about calls to the RepairMissingNamespaces method.
+		RepairMissingNamespaces []struct {
+		}
+		// RepairMultiAZ holds details about calls to the RepairMultiAZ method.
+		RepairMultiAZ []struct {
+		}
+		// ResumeReconciliation holds details about calls to the ResumeReconciliation method.
+		ResumeReconciliation []struct {
+			// ID is the id argument value.
+			ID string
+		}
+		// RetryFailed holds details about calls to the RetryFailed method.
+		RetryFailed []struct {
+			// ID is the id argument value.
+			ID string
+		}
+		// SetAnnotations holds details about calls to the SetAnnotations method.
+		SetAnnotations []struct {
+			// ID is the id argument value.
+			ID string
+			// Annotations is the annotations argument value.
+			Annotations map[string]string
+		}
+		// SetCapacityConsumedOverride holds details about calls to the SetCapacityConsumedOverride method.
+		SetCapacityConsumedOverride []struct {
+			// ID is the id argument value.
+			ID string
+			// Override is the override argument value.
+			Override *int
+		}
+		// SetKafkaStorageSize holds details about calls to the SetKafkaStorageSize method.
+		SetKafkaStorageSize []struct {
+			// ID is the id argument value.
+			ID string
+			// Size is the size argument value.
+			Size string
+		}
+		// SetMaintenanceWindow holds details about calls to the SetMaintenanceWindow method.
+		SetMaintenanceWindow []struct {
+			// ID is the id argument value.
+			ID string
+			// Day is the day argument value.
+			Day string
+			// Start is the start argument value.
+			Start string
+			// End is the end argument value.
+			End string
+		}
+		// SetMaxConnectionAttemptsPerSecOverride holds details about calls to the SetMaxConnectionAttemptsPerSecOverride method.
+		SetMaxConnectionAttemptsPerSecOverride []struct {
+			// ID is the id argument value.
+			ID string
+			// Override is the override argument value.
+			Override *int
+		}
+		// SetReauthenticationBulk holds details about calls to the SetReauthenticationBulk method.
+		SetReauthenticationBulk []struct {
+			// Ids is the ids argument value.
+			Ids []string
+			// Enabled is the enabled argument value.
+			Enabled bool
+		}
+		// StreamAll holds details about calls to the StreamAll method.
+		StreamAll []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// BatchSize is the batchSize argument value.
+			BatchSize int
+			// Fn is the fn argument value.
+			Fn func(*dbapi.KafkaRequest) error
+		}
 		// Update holds details about calls to the Update method.
 		Update []struct {
 			// KafkaRequest is the kafkaRequest argument value.
@@ -330,6 +1108,16 @@ type KafkaServiceMock struct {
 			// Marketplace is the marketplace argument value.
 			Marketplace *string
 		}
+		// ValidateCreateRequest holds details about calls to the ValidateCreateRequest method.
+		ValidateCreateRequest []struct {
+			// KafkaRequest is the kafkaRequest argument value.
+			KafkaRequest *dbapi.KafkaRequest
+		}
+		// ValidateRoutes holds details about calls to the ValidateRoutes method.
+		ValidateRoutes []struct {
+			// Routes is the routes argument value.
+			Routes []dbapi.DataPlaneKafkaRoute
+		}
 		// VerifyAndUpdateKafkaAdmin holds details about calls to the VerifyAndUpdateKafkaAdmin method.
 		VerifyAndUpdateKafkaAdmin []struct {
 			// Ctx is the ctx argument value.
@@ -338,34 +1126,133 @@ type KafkaServiceMock struct {
 			KafkaRequest *dbapi.KafkaRequest
 		}
 	}
+	lockAbortPendingQuota                        sync.RWMutex
 	lockAssignBootstrapServerHost                sync.RWMutex
 	lockAssignInstanceType                       sync.RWMutex
+	lockBackfillQuotaType                        sync.RWMutex
+	lockBumpStrimziVersion                       sync.RWMutex
+	lockCancelDeprovision                        sync.RWMutex
+	lockCancelUpgrade                            sync.RWMutex
 	lockChangeKafkaCNAMErecords                  sync.RWMutex
+	lockChangeKafkaCNAMErecordsBatch             sync.RWMutex
+	lockCleanupOrphanedCanaryAccounts            sync.RWMutex
+	lockConfirmQuota                             sync.RWMutex
+	lockCountByClusterAndStatus                  sync.RWMutex
+	lockCountByMultiAZ                           sync.RWMutex
 	lockCountByStatus                            sync.RWMutex
 	lockDelete                                   sync.RWMutex
+	lockDeleteExpiredPendingQuotaKafkas          sync.RWMutex
 	lockDeprovisionExpiredKafkas                 sync.RWMutex
 	lockDeprovisionKafkaForUsers                 sync.RWMutex
+	lockExplainPlacement                         sync.RWMutex
+	lockExportCostAllocation                     sync.RWMutex
+	lockExportManagedKafkaCRsYAML                sync.RWMutex
+	lockFailStaleProvisioningKafkas              sync.RWMutex
+	lockForceDelete                              sync.RWMutex
 	lockGenerateReservedManagedKafkasByClusterID sync.RWMutex
 	lockGet                                      sync.RWMutex
+	lockGetAllowedInstanceTypes                  sync.RWMutex
 	lockGetAvailableSizesInRegion                sync.RWMutex
+	lockGetBillingModel                          sync.RWMutex
 	lockGetById                                  sync.RWMutex
+	lockGetByIdForContext                        sync.RWMutex
+	lockGetByIdIncludingDeleted                  sync.RWMutex
+	lockGetByName                                sync.RWMutex
+	lockGetByNamespace                           sync.RWMutex
 	lockGetCNAMERecordStatus                     sync.RWMutex
+	lockGetCapacityReport                        sync.RWMutex
+	lockGetClusterForKafka                       sync.RWMutex
+	lockGetDeprovisionReason                     sync.RWMutex
+	lockGetFleetSummary                          sync.RWMutex
 	lockGetManagedKafkaByClusterID               sync.RWMutex
+	lockGetManagedKafkaByClusterIDChangedSince   sync.RWMutex
+	lockGetOwnerSummary                          sync.RWMutex
+	lockGetProvisioningDuration                  sync.RWMutex
+	lockGetQuotaCost                             sync.RWMutex
+	lockGetRegionStatus                          sync.RWMutex
+	lockGetStreamingUnitUsageByClusterID         sync.RWMutex
+	lockGetWithFields                            sync.RWMutex
+	lockGetWithRoutes                            sync.RWMutex
 	lockHasAvailableCapacityInRegion             sync.RWMutex
+	lockInvalidateBillingAccounts                sync.RWMutex
 	lockList                                     sync.RWMutex
+	lockListByBillingCloudAccountId              sync.RWMutex
+	lockListByCreatedRange                       sync.RWMutex
+	lockListByDesiredStrimziVersion              sync.RWMutex
+	lockListByInstanceType                       sync.RWMutex
+	lockListByQuotaType                          sync.RWMutex
+	lockListByRegion                             sync.RWMutex
 	lockListByStatus                             sync.RWMutex
 	lockListComponentVersions                    sync.RWMutex
+	lockListDuplicateBootstrapHosts              sync.RWMutex
+	lockListKafkasMissingCanaryAccount           sync.RWMutex
 	lockListKafkasWithRoutesNotCreated           sync.RWMutex
+	lockListOrphanedCanaryAccounts               sync.RWMutex
+	lockListReauthDisabled                       sync.RWMutex
+	lockListStuckDeprovisioning                  sync.RWMutex
+	lockListStuckUpgrades                        sync.RWMutex
+	lockListWithClusterDetails                   sync.RWMutex
+	lockPauseReconciliation                      sync.RWMutex
 	lockPrepareKafkaRequest                      sync.RWMutex
+	lockPromoteExpiredDeprovisionPendingKafkas   sync.RWMutex
+	lockRecomputeRegionCapacityUsage             sync.RWMutex
+	lockRecreateRoutes                           sync.RWMutex
 	lockRegisterKafkaDeprovisionJob              sync.RWMutex
 	lockRegisterKafkaJob                         sync.RWMutex
+	lockRegisterKafkaJobWithDeferredQuota        sync.RWMutex
+	lockRepairCanaryAccounts                     sync.RWMutex
+	lockRepairMissingNamespaces                  sync.RWMutex
+	lockRepairMultiAZ                            sync.RWMutex
+	lockResumeReconciliation                     sync.RWMutex
+	lockRetryFailed                              sync.RWMutex
+	lockSetAnnotations                           sync.RWMutex
+	lockSetCapacityConsumedOverride              sync.RWMutex
+	lockSetKafkaStorageSize                      sync.RWMutex
+	lockSetMaintenanceWindow                     sync.RWMutex
+	lockSetMaxConnectionAttemptsPerSecOverride   sync.RWMutex
+	lockSetReauthenticationBulk                  sync.RWMutex
+	lockStreamAll                                sync.RWMutex
 	lockUpdate                                   sync.RWMutex
 	lockUpdateStatus                             sync.RWMutex
 	lockUpdates                                  sync.RWMutex
 	lockValidateBillingAccount                   sync.RWMutex
+	lockValidateCreateRequest                    sync.RWMutex
+	lockValidateRoutes                           sync.RWMutex
 	lockVerifyAndUpdateKafkaAdmin                sync.RWMutex
 }
 
+// AbortPendingQuota calls AbortPendingQuotaFunc.
+func (mock *KafkaServiceMock) AbortPendingQuota(id string) *apiErrors.ServiceError {
+	if mock.AbortPendingQuotaFunc == nil {
+		panic("KafkaServiceMock.AbortPendingQuotaFunc: method is nil but KafkaService.AbortPendingQuota was just called")
+	}
+	callInfo := struct {
+		ID string
+	}{
+		ID: id,
+	}
+	mock.lockAbortPendingQuota.Lock()
+	mock.calls.AbortPendingQuota = append(mock.calls.AbortPendingQuota, callInfo)
+	mock.lockAbortPendingQuota.Unlock()
+	return mock.AbortPendingQuotaFunc(id)
+}
+
+// AbortPendingQuotaCalls gets all the calls that were made to AbortPendingQuota.
+// Check the length with:
+//
+//	len(mockedKafkaService.AbortPendingQuotaCalls())
+func (mock *KafkaServiceMock) AbortPendingQuotaCalls() []struct {
+	ID string
+} {
+	var calls []struct {
+		ID string
+	}
+	mock.lockAbortPendingQuota.RLock()
+	calls = mock.calls.AbortPendingQuota
+	mock.lockAbortPendingQuota.RUnlock()
+	return calls
+}
+
 // AssignBootstrapServerHost calls AssignBootstrapServerHostFunc.
 func (mock *KafkaServiceMock) AssignBootstrapServerHost(kafkaRequest *dbapi.KafkaRequest) error {
 	if mock.AssignBootstrapServerHostFunc == nil {
@@ -434,6 +1321,137 @@ func (mock *KafkaServiceMock) AssignInstanceTypeCalls() []struct {
 	return calls
 }
 
+// BackfillQuotaType calls BackfillQuotaTypeFunc.
+func (mock *KafkaServiceMock) BackfillQuotaType() (int64, *apiErrors.ServiceError) {
+	if mock.BackfillQuotaTypeFunc == nil {
+		panic("KafkaServiceMock.BackfillQuotaTypeFunc: method is nil but KafkaService.BackfillQuotaType was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockBackfillQuotaType.Lock()
+	mock.calls.BackfillQuotaType = append(mock.calls.BackfillQuotaType, callInfo)
+	mock.lockBackfillQuotaType.Unlock()
+	return mock.BackfillQuotaTypeFunc()
+}
+
+// BackfillQuotaTypeCalls gets all the calls that were made to BackfillQuotaType.
+// Check the length with:
+//
+//	len(mockedKafkaService.BackfillQuotaTypeCalls())
+func (mock *KafkaServiceMock) BackfillQuotaTypeCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockBackfillQuotaType.RLock()
+	calls = mock.calls.BackfillQuotaType
+	mock.lockBackfillQuotaType.RUnlock()
+	return calls
+}
+
+// BumpStrimziVersion calls BumpStrimziVersionFunc.
+func (mock *KafkaServiceMock) BumpStrimziVersion(ids []string, targetVersion string) (int64, *apiErrors.ServiceError) {
+	if mock.BumpStrimziVersionFunc == nil {
+		panic("KafkaServiceMock.BumpStrimziVersionFunc: method is nil but KafkaService.BumpStrimziVersion was just called")
+	}
+	callInfo := struct {
+		Ids           []string
+		TargetVersion string
+	}{
+		Ids:           ids,
+		TargetVersion: targetVersion,
+	}
+	mock.lockBumpStrimziVersion.Lock()
+	mock.calls.BumpStrimziVersion = append(mock.calls.BumpStrimziVersion, callInfo)
+	mock.lockBumpStrimziVersion.Unlock()
+	return mock.BumpStrimziVersionFunc(ids, targetVersion)
+}
+
+// BumpStrimziVersionCalls gets all the calls that were made to BumpStrimziVersion.
+// Check the length with:
+//
+//	len(mockedKafkaService.BumpStrimziVersionCalls())
+func (mock *KafkaServiceMock) BumpStrimziVersionCalls() []struct {
+	Ids           []string
+	TargetVersion string
+} {
+	var calls []struct {
+		Ids           []string
+		TargetVersion string
+	}
+	mock.lockBumpStrimziVersion.RLock()
+	calls = mock.calls.BumpStrimziVersion
+	mock.lockBumpStrimziVersion.RUnlock()
+	return calls
+}
+
+// CancelDeprovision calls CancelDeprovisionFunc.
+func (mock *KafkaServiceMock) CancelDeprovision(ctx context.Context, id string) *apiErrors.ServiceError {
+	if mock.CancelDeprovisionFunc == nil {
+		panic("KafkaServiceMock.CancelDeprovisionFunc: method is nil but KafkaService.CancelDeprovision was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  string
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	mock.lockCancelDeprovision.Lock()
+	mock.calls.CancelDeprovision = append(mock.calls.CancelDeprovision, callInfo)
+	mock.lockCancelDeprovision.Unlock()
+	return mock.CancelDeprovisionFunc(ctx, id)
+}
+
+// CancelDeprovisionCalls gets all the calls that were made to CancelDeprovision.
+// Check the length with:
+//
+//	len(mockedKafkaService.CancelDeprovisionCalls())
+func (mock *KafkaServiceMock) CancelDeprovisionCalls() []struct {
+	Ctx context.Context
+	ID  string
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  string
+	}
+	mock.lockCancelDeprovision.RLock()
+	calls = mock.calls.CancelDeprovision
+	mock.lockCancelDeprovision.RUnlock()
+	return calls
+}
+
+// CancelUpgrade calls CancelUpgradeFunc.
+func (mock *KafkaServiceMock) CancelUpgrade(id string) *apiErrors.ServiceError {
+	if mock.CancelUpgradeFunc == nil {
+		panic("KafkaServiceMock.CancelUpgradeFunc: method is nil but KafkaService.CancelUpgrade was just called")
+	}
+	callInfo := struct {
+		ID string
+	}{
+		ID: id,
+	}
+	mock.lockCancelUpgrade.Lock()
+	mock.calls.CancelUpgrade = append(mock.calls.CancelUpgrade, callInfo)
+	mock.lockCancelUpgrade.Unlock()
+	return mock.CancelUpgradeFunc(id)
+}
+
+// CancelUpgradeCalls gets all the calls that were made to CancelUpgrade.
+// Check the length with:
+//
+//	len(mockedKafkaService.CancelUpgradeCalls())
+func (mock *KafkaServiceMock) CancelUpgradeCalls() []struct {
+	ID string
+} {
+	var calls []struct {
+		ID string
+	}
+	mock.lockCancelUpgrade.RLock()
+	calls = mock.calls.CancelUpgrade
+	mock.lockCancelUpgrade.RUnlock()
+	return calls
+}
+
 // ChangeKafkaCNAMErecords calls ChangeKafkaCNAMErecordsFunc.
 func (mock *KafkaServiceMock) ChangeKafkaCNAMErecords(kafkaRequest *dbapi.KafkaRequest, action KafkaRoutesAction) (*route53.ChangeResourceRecordSetsOutput, *apiErrors.ServiceError) {
 	if mock.ChangeKafkaCNAMErecordsFunc == nil {
@@ -470,6 +1488,170 @@ func (mock *KafkaServiceMock) ChangeKafkaCNAMErecordsCalls() []struct {
 	return calls
 }
 
+// ChangeKafkaCNAMErecordsBatch calls ChangeKafkaCNAMErecordsBatchFunc.
+func (mock *KafkaServiceMock) ChangeKafkaCNAMErecordsBatch(kafkaRequests []*dbapi.KafkaRequest, action KafkaRoutesAction) map[string]*CNAMEChangeResult {
+	if mock.ChangeKafkaCNAMErecordsBatchFunc == nil {
+		panic("KafkaServiceMock.ChangeKafkaCNAMErecordsBatchFunc: method is nil but KafkaService.ChangeKafkaCNAMErecordsBatch was just called")
+	}
+	callInfo := struct {
+		KafkaRequests []*dbapi.KafkaRequest
+		Action        KafkaRoutesAction
+	}{
+		KafkaRequests: kafkaRequests,
+		Action:        action,
+	}
+	mock.lockChangeKafkaCNAMErecordsBatch.Lock()
+	mock.calls.ChangeKafkaCNAMErecordsBatch = append(mock.calls.ChangeKafkaCNAMErecordsBatch, callInfo)
+	mock.lockChangeKafkaCNAMErecordsBatch.Unlock()
+	return mock.ChangeKafkaCNAMErecordsBatchFunc(kafkaRequests, action)
+}
+
+// ChangeKafkaCNAMErecordsBatchCalls gets all the calls that were made to ChangeKafkaCNAMErecordsBatch.
+// Check the length with:
+//
+//	len(mockedKafkaService.ChangeKafkaCNAMErecordsBatchCalls())
+func (mock *KafkaServiceMock) ChangeKafkaCNAMErecordsBatchCalls() []struct {
+	KafkaRequests []*dbapi.KafkaRequest
+	Action        KafkaRoutesAction
+} {
+	var calls []struct {
+		KafkaRequests []*dbapi.KafkaRequest
+		Action        KafkaRoutesAction
+	}
+	mock.lockChangeKafkaCNAMErecordsBatch.RLock()
+	calls = mock.calls.ChangeKafkaCNAMErecordsBatch
+	mock.lockChangeKafkaCNAMErecordsBatch.RUnlock()
+	return calls
+}
+
+// CleanupOrphanedCanaryAccounts calls CleanupOrphanedCanaryAccountsFunc.
+func (mock *KafkaServiceMock) CleanupOrphanedCanaryAccounts(dryRun bool) ([]string, *apiErrors.ServiceError) {
+	if mock.CleanupOrphanedCanaryAccountsFunc == nil {
+		panic("KafkaServiceMock.CleanupOrphanedCanaryAccountsFunc: method is nil but KafkaService.CleanupOrphanedCanaryAccounts was just called")
+	}
+	callInfo := struct {
+		DryRun bool
+	}{
+		DryRun: dryRun,
+	}
+	mock.lockCleanupOrphanedCanaryAccounts.Lock()
+	mock.calls.CleanupOrphanedCanaryAccounts = append(mock.calls.CleanupOrphanedCanaryAccounts, callInfo)
+	mock.lockCleanupOrphanedCanaryAccounts.Unlock()
+	return mock.CleanupOrphanedCanaryAccountsFunc(dryRun)
+}
+
+// CleanupOrphanedCanaryAccountsCalls gets all the calls that were made to CleanupOrphanedCanaryAccounts.
+// Check the length with:
+//
+//	len(mockedKafkaService.CleanupOrphanedCanaryAccountsCalls())
+func (mock *KafkaServiceMock) CleanupOrphanedCanaryAccountsCalls() []struct {
+	DryRun bool
+} {
+	var calls []struct {
+		DryRun bool
+	}
+	mock.lockCleanupOrphanedCanaryAccounts.RLock()
+	calls = mock.calls.CleanupOrphanedCanaryAccounts
+	mock.lockCleanupOrphanedCanaryAccounts.RUnlock()
+	return calls
+}
+
+// ConfirmQuota calls ConfirmQuotaFunc.
+func (mock *KafkaServiceMock) ConfirmQuota(id string) *apiErrors.ServiceError {
+	if mock.ConfirmQuotaFunc == nil {
+		panic("KafkaServiceMock.ConfirmQuotaFunc: method is nil but KafkaService.ConfirmQuota was just called")
+	}
+	callInfo := struct {
+		ID string
+	}{
+		ID: id,
+	}
+	mock.lockConfirmQuota.Lock()
+	mock.calls.ConfirmQuota = append(mock.calls.ConfirmQuota, callInfo)
+	mock.lockConfirmQuota.Unlock()
+	return mock.ConfirmQuotaFunc(id)
+}
+
+// ConfirmQuotaCalls gets all the calls that were made to ConfirmQuota.
+// Check the length with:
+//
+//	len(mockedKafkaService.ConfirmQuotaCalls())
+func (mock *KafkaServiceMock) ConfirmQuotaCalls() []struct {
+	ID string
+} {
+	var calls []struct {
+		ID string
+	}
+	mock.lockConfirmQuota.RLock()
+	calls = mock.calls.ConfirmQuota
+	mock.lockConfirmQuota.RUnlock()
+	return calls
+}
+
+// CountByClusterAndStatus calls CountByClusterAndStatusFunc.
+func (mock *KafkaServiceMock) CountByClusterAndStatus(clusterID string) (map[constants2.KafkaStatus]int, error) {
+	if mock.CountByClusterAndStatusFunc == nil {
+		panic("KafkaServiceMock.CountByClusterAndStatusFunc: method is nil but KafkaService.CountByClusterAndStatus was just called")
+	}
+	callInfo := struct {
+		ClusterID string
+	}{
+		ClusterID: clusterID,
+	}
+	mock.lockCountByClusterAndStatus.Lock()
+	mock.calls.CountByClusterAndStatus = append(mock.calls.CountByClusterAndStatus, callInfo)
+	mock.lockCountByClusterAndStatus.Unlock()
+	return mock.CountByClusterAndStatusFunc(clusterID)
+}
+
+// CountByClusterAndStatusCalls gets all the calls that were made to CountByClusterAndStatus.
+// Check the length with:
+//
+//	len(mockedKafkaService.CountByClusterAndStatusCalls())
+func (mock *KafkaServiceMock) CountByClusterAndStatusCalls() []struct {
+	ClusterID string
+} {
+	var calls []struct {
+		ClusterID string
+	}
+	mock.lockCountByClusterAndStatus.RLock()
+	calls = mock.calls.CountByClusterAndStatus
+	mock.lockCountByClusterAndStatus.RUnlock()
+	return calls
+}
+
+// CountByMultiAZ calls CountByMultiAZFunc.
+func (mock *KafkaServiceMock) CountByMultiAZ(region string) (map[bool]int, error) {
+	if mock.CountByMultiAZFunc == nil {
+		panic("KafkaServiceMock.CountByMultiAZFunc: method is nil but KafkaService.CountByMultiAZ was just called")
+	}
+	callInfo := struct {
+		Region string
+	}{
+		Region: region,
+	}
+	mock.lockCountByMultiAZ.Lock()
+	mock.calls.CountByMultiAZ = append(mock.calls.CountByMultiAZ, callInfo)
+	mock.lockCountByMultiAZ.Unlock()
+	return mock.CountByMultiAZFunc(region)
+}
+
+// CountByMultiAZCalls gets all the calls that were made to CountByMultiAZ.
+// Check the length with:
+//
+//	len(mockedKafkaService.CountByMultiAZCalls())
+func (mock *KafkaServiceMock) CountByMultiAZCalls() []struct {
+	Region string
+} {
+	var calls []struct {
+		Region string
+	}
+	mock.lockCountByMultiAZ.RLock()
+	calls = mock.calls.CountByMultiAZ
+	mock.lockCountByMultiAZ.RUnlock()
+	return calls
+}
+
 // CountByStatus calls CountByStatusFunc.
 func (mock *KafkaServiceMock) CountByStatus(status []constants2.KafkaStatus) ([]KafkaStatusCount, error) {
 	if mock.CountByStatusFunc == nil {
@@ -534,6 +1716,33 @@ func (mock *KafkaServiceMock) DeleteCalls() []struct {
 	return calls
 }
 
+// DeleteExpiredPendingQuotaKafkas calls DeleteExpiredPendingQuotaKafkasFunc.
+func (mock *KafkaServiceMock) DeleteExpiredPendingQuotaKafkas() (int64, *apiErrors.ServiceError) {
+	if mock.DeleteExpiredPendingQuotaKafkasFunc == nil {
+		panic("KafkaServiceMock.DeleteExpiredPendingQuotaKafkasFunc: method is nil but KafkaService.DeleteExpiredPendingQuotaKafkas was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockDeleteExpiredPendingQuotaKafkas.Lock()
+	mock.calls.DeleteExpiredPendingQuotaKafkas = append(mock.calls.DeleteExpiredPendingQuotaKafkas, callInfo)
+	mock.lockDeleteExpiredPendingQuotaKafkas.Unlock()
+	return mock.DeleteExpiredPendingQuotaKafkasFunc()
+}
+
+// DeleteExpiredPendingQuotaKafkasCalls gets all the calls that were made to DeleteExpiredPendingQuotaKafkas.
+// Check the length with:
+//
+//	len(mockedKafkaService.DeleteExpiredPendingQuotaKafkasCalls())
+func (mock *KafkaServiceMock) DeleteExpiredPendingQuotaKafkasCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockDeleteExpiredPendingQuotaKafkas.RLock()
+	calls = mock.calls.DeleteExpiredPendingQuotaKafkas
+	mock.lockDeleteExpiredPendingQuotaKafkas.RUnlock()
+	return calls
+}
+
 // DeprovisionExpiredKafkas calls DeprovisionExpiredKafkasFunc.
 func (mock *KafkaServiceMock) DeprovisionExpiredKafkas() *apiErrors.ServiceError {
 	if mock.DeprovisionExpiredKafkasFunc == nil {
@@ -593,35 +1802,198 @@ func (mock *KafkaServiceMock) DeprovisionKafkaForUsersCalls() []struct {
 	return calls
 }
 
-// GenerateReservedManagedKafkasByClusterID calls GenerateReservedManagedKafkasByClusterIDFunc.
-func (mock *KafkaServiceMock) GenerateReservedManagedKafkasByClusterID(clusterID string) ([]managedkafka.ManagedKafka, *apiErrors.ServiceError) {
-	if mock.GenerateReservedManagedKafkasByClusterIDFunc == nil {
-		panic("KafkaServiceMock.GenerateReservedManagedKafkasByClusterIDFunc: method is nil but KafkaService.GenerateReservedManagedKafkasByClusterID was just called")
+// ExplainPlacement calls ExplainPlacementFunc.
+func (mock *KafkaServiceMock) ExplainPlacement(criteria *FindClusterCriteria) (*PlacementExplanation, *apiErrors.ServiceError) {
+	if mock.ExplainPlacementFunc == nil {
+		panic("KafkaServiceMock.ExplainPlacementFunc: method is nil but KafkaService.ExplainPlacement was just called")
 	}
 	callInfo := struct {
-		ClusterID string
+		Criteria *FindClusterCriteria
 	}{
-		ClusterID: clusterID,
+		Criteria: criteria,
 	}
-	mock.lockGenerateReservedManagedKafkasByClusterID.Lock()
-	mock.calls.GenerateReservedManagedKafkasByClusterID = append(mock.calls.GenerateReservedManagedKafkasByClusterID, callInfo)
-	mock.lockGenerateReservedManagedKafkasByClusterID.Unlock()
-	return mock.GenerateReservedManagedKafkasByClusterIDFunc(clusterID)
+	mock.lockExplainPlacement.Lock()
+	mock.calls.ExplainPlacement = append(mock.calls.ExplainPlacement, callInfo)
+	mock.lockExplainPlacement.Unlock()
+	return mock.ExplainPlacementFunc(criteria)
 }
 
-// GenerateReservedManagedKafkasByClusterIDCalls gets all the calls that were made to GenerateReservedManagedKafkasByClusterID.
+// ExplainPlacementCalls gets all the calls that were made to ExplainPlacement.
 // Check the length with:
 //
-//	len(mockedKafkaService.GenerateReservedManagedKafkasByClusterIDCalls())
-func (mock *KafkaServiceMock) GenerateReservedManagedKafkasByClusterIDCalls() []struct {
-	ClusterID string
+//	len(mockedKafkaService.ExplainPlacementCalls())
+func (mock *KafkaServiceMock) ExplainPlacementCalls() []struct {
+	Criteria *FindClusterCriteria
 } {
 	var calls []struct {
-		ClusterID string
+		Criteria *FindClusterCriteria
 	}
-	mock.lockGenerateReservedManagedKafkasByClusterID.RLock()
-	calls = mock.calls.GenerateReservedManagedKafkasByClusterID
-	mock.lockGenerateReservedManagedKafkasByClusterID.RUnlock()
+	mock.lockExplainPlacement.RLock()
+	calls = mock.calls.ExplainPlacement
+	mock.lockExplainPlacement.RUnlock()
+	return calls
+}
+
+// ExportCostAllocation calls ExportCostAllocationFunc.
+func (mock *KafkaServiceMock) ExportCostAllocation(from time.Time, to time.Time) ([]CostAllocationRecord, *apiErrors.ServiceError) {
+	if mock.ExportCostAllocationFunc == nil {
+		panic("KafkaServiceMock.ExportCostAllocationFunc: method is nil but KafkaService.ExportCostAllocation was just called")
+	}
+	callInfo := struct {
+		From time.Time
+		To   time.Time
+	}{
+		From: from,
+		To:   to,
+	}
+	mock.lockExportCostAllocation.Lock()
+	mock.calls.ExportCostAllocation = append(mock.calls.ExportCostAllocation, callInfo)
+	mock.lockExportCostAllocation.Unlock()
+	return mock.ExportCostAllocationFunc(from, to)
+}
+
+// ExportCostAllocationCalls gets all the calls that were made to ExportCostAllocation.
+// Check the length with:
+//
+//	len(mockedKafkaService.ExportCostAllocationCalls())
+func (mock *KafkaServiceMock) ExportCostAllocationCalls() []struct {
+	From time.Time
+	To   time.Time
+} {
+	var calls []struct {
+		From time.Time
+		To   time.Time
+	}
+	mock.lockExportCostAllocation.RLock()
+	calls = mock.calls.ExportCostAllocation
+	mock.lockExportCostAllocation.RUnlock()
+	return calls
+}
+
+// ExportManagedKafkaCRsYAML calls ExportManagedKafkaCRsYAMLFunc.
+func (mock *KafkaServiceMock) ExportManagedKafkaCRsYAML(clusterID string) ([]byte, *apiErrors.ServiceError) {
+	if mock.ExportManagedKafkaCRsYAMLFunc == nil {
+		panic("KafkaServiceMock.ExportManagedKafkaCRsYAMLFunc: method is nil but KafkaService.ExportManagedKafkaCRsYAML was just called")
+	}
+	callInfo := struct {
+		ClusterID string
+	}{
+		ClusterID: clusterID,
+	}
+	mock.lockExportManagedKafkaCRsYAML.Lock()
+	mock.calls.ExportManagedKafkaCRsYAML = append(mock.calls.ExportManagedKafkaCRsYAML, callInfo)
+	mock.lockExportManagedKafkaCRsYAML.Unlock()
+	return mock.ExportManagedKafkaCRsYAMLFunc(clusterID)
+}
+
+// ExportManagedKafkaCRsYAMLCalls gets all the calls that were made to ExportManagedKafkaCRsYAML.
+// Check the length with:
+//
+//	len(mockedKafkaService.ExportManagedKafkaCRsYAMLCalls())
+func (mock *KafkaServiceMock) ExportManagedKafkaCRsYAMLCalls() []struct {
+	ClusterID string
+} {
+	var calls []struct {
+		ClusterID string
+	}
+	mock.lockExportManagedKafkaCRsYAML.RLock()
+	calls = mock.calls.ExportManagedKafkaCRsYAML
+	mock.lockExportManagedKafkaCRsYAML.RUnlock()
+	return calls
+}
+
+// FailStaleProvisioningKafkas calls FailStaleProvisioningKafkasFunc.
+func (mock *KafkaServiceMock) FailStaleProvisioningKafkas() ([]string, *apiErrors.ServiceError) {
+	if mock.FailStaleProvisioningKafkasFunc == nil {
+		panic("KafkaServiceMock.FailStaleProvisioningKafkasFunc: method is nil but KafkaService.FailStaleProvisioningKafkas was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockFailStaleProvisioningKafkas.Lock()
+	mock.calls.FailStaleProvisioningKafkas = append(mock.calls.FailStaleProvisioningKafkas, callInfo)
+	mock.lockFailStaleProvisioningKafkas.Unlock()
+	return mock.FailStaleProvisioningKafkasFunc()
+}
+
+// FailStaleProvisioningKafkasCalls gets all the calls that were made to FailStaleProvisioningKafkas.
+// Check the length with:
+//
+//	len(mockedKafkaService.FailStaleProvisioningKafkasCalls())
+func (mock *KafkaServiceMock) FailStaleProvisioningKafkasCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockFailStaleProvisioningKafkas.RLock()
+	calls = mock.calls.FailStaleProvisioningKafkas
+	mock.lockFailStaleProvisioningKafkas.RUnlock()
+	return calls
+}
+
+// ForceDelete calls ForceDeleteFunc.
+func (mock *KafkaServiceMock) ForceDelete(ctx context.Context, id string) *apiErrors.ServiceError {
+	if mock.ForceDeleteFunc == nil {
+		panic("KafkaServiceMock.ForceDeleteFunc: method is nil but KafkaService.ForceDelete was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  string
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	mock.lockForceDelete.Lock()
+	mock.calls.ForceDelete = append(mock.calls.ForceDelete, callInfo)
+	mock.lockForceDelete.Unlock()
+	return mock.ForceDeleteFunc(ctx, id)
+}
+
+// ForceDeleteCalls gets all the calls that were made to ForceDelete.
+// Check the length with:
+//
+//	len(mockedKafkaService.ForceDeleteCalls())
+func (mock *KafkaServiceMock) ForceDeleteCalls() []struct {
+	Ctx context.Context
+	ID  string
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  string
+	}
+	mock.lockForceDelete.RLock()
+	calls = mock.calls.ForceDelete
+	mock.lockForceDelete.RUnlock()
+	return calls
+}
+
+// GenerateReservedManagedKafkasByClusterID calls GenerateReservedManagedKafkasByClusterIDFunc.
+func (mock *KafkaServiceMock) GenerateReservedManagedKafkasByClusterID(clusterID string) ([]managedkafka.ManagedKafka, *apiErrors.ServiceError) {
+	if mock.GenerateReservedManagedKafkasByClusterIDFunc == nil {
+		panic("KafkaServiceMock.GenerateReservedManagedKafkasByClusterIDFunc: method is nil but KafkaService.GenerateReservedManagedKafkasByClusterID was just called")
+	}
+	callInfo := struct {
+		ClusterID string
+	}{
+		ClusterID: clusterID,
+	}
+	mock.lockGenerateReservedManagedKafkasByClusterID.Lock()
+	mock.calls.GenerateReservedManagedKafkasByClusterID = append(mock.calls.GenerateReservedManagedKafkasByClusterID, callInfo)
+	mock.lockGenerateReservedManagedKafkasByClusterID.Unlock()
+	return mock.GenerateReservedManagedKafkasByClusterIDFunc(clusterID)
+}
+
+// GenerateReservedManagedKafkasByClusterIDCalls gets all the calls that were made to GenerateReservedManagedKafkasByClusterID.
+// Check the length with:
+//
+//	len(mockedKafkaService.GenerateReservedManagedKafkasByClusterIDCalls())
+func (mock *KafkaServiceMock) GenerateReservedManagedKafkasByClusterIDCalls() []struct {
+	ClusterID string
+} {
+	var calls []struct {
+		ClusterID string
+	}
+	mock.lockGenerateReservedManagedKafkasByClusterID.RLock()
+	calls = mock.calls.GenerateReservedManagedKafkasByClusterID
+	mock.lockGenerateReservedManagedKafkasByClusterID.RUnlock()
 	return calls
 }
 
@@ -661,6 +2033,38 @@ func (mock *KafkaServiceMock) GetCalls() []struct {
 	return calls
 }
 
+// GetAllowedInstanceTypes calls GetAllowedInstanceTypesFunc.
+func (mock *KafkaServiceMock) GetAllowedInstanceTypes(ctx context.Context) ([]types.KafkaInstanceType, *apiErrors.ServiceError) {
+	if mock.GetAllowedInstanceTypesFunc == nil {
+		panic("KafkaServiceMock.GetAllowedInstanceTypesFunc: method is nil but KafkaService.GetAllowedInstanceTypes was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockGetAllowedInstanceTypes.Lock()
+	mock.calls.GetAllowedInstanceTypes = append(mock.calls.GetAllowedInstanceTypes, callInfo)
+	mock.lockGetAllowedInstanceTypes.Unlock()
+	return mock.GetAllowedInstanceTypesFunc(ctx)
+}
+
+// GetAllowedInstanceTypesCalls gets all the calls that were made to GetAllowedInstanceTypes.
+// Check the length with:
+//
+//	len(mockedKafkaService.GetAllowedInstanceTypesCalls())
+func (mock *KafkaServiceMock) GetAllowedInstanceTypesCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockGetAllowedInstanceTypes.RLock()
+	calls = mock.calls.GetAllowedInstanceTypes
+	mock.lockGetAllowedInstanceTypes.RUnlock()
+	return calls
+}
+
 // GetAvailableSizesInRegion calls GetAvailableSizesInRegionFunc.
 func (mock *KafkaServiceMock) GetAvailableSizesInRegion(criteria *FindClusterCriteria) ([]string, *apiErrors.ServiceError) {
 	if mock.GetAvailableSizesInRegionFunc == nil {
@@ -685,361 +2089,1980 @@ func (mock *KafkaServiceMock) GetAvailableSizesInRegionCalls() []struct {
 	Criteria *FindClusterCriteria
 } {
 	var calls []struct {
-		Criteria *FindClusterCriteria
+		Criteria *FindClusterCriteria
+	}
+	mock.lockGetAvailableSizesInRegion.RLock()
+	calls = mock.calls.GetAvailableSizesInRegion
+	mock.lockGetAvailableSizesInRegion.RUnlock()
+	return calls
+}
+
+// GetBillingModel calls GetBillingModelFunc.
+func (mock *KafkaServiceMock) GetBillingModel(ctx context.Context, id string) (*KafkaBillingModel, *apiErrors.ServiceError) {
+	if mock.GetBillingModelFunc == nil {
+		panic("KafkaServiceMock.GetBillingModelFunc: method is nil but KafkaService.GetBillingModel was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  string
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	mock.lockGetBillingModel.Lock()
+	mock.calls.GetBillingModel = append(mock.calls.GetBillingModel, callInfo)
+	mock.lockGetBillingModel.Unlock()
+	return mock.GetBillingModelFunc(ctx, id)
+}
+
+// GetBillingModelCalls gets all the calls that were made to GetBillingModel.
+// Check the length with:
+//
+//	len(mockedKafkaService.GetBillingModelCalls())
+func (mock *KafkaServiceMock) GetBillingModelCalls() []struct {
+	Ctx context.Context
+	ID  string
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  string
+	}
+	mock.lockGetBillingModel.RLock()
+	calls = mock.calls.GetBillingModel
+	mock.lockGetBillingModel.RUnlock()
+	return calls
+}
+
+// GetById calls GetByIdFunc.
+func (mock *KafkaServiceMock) GetById(id string) (*dbapi.KafkaRequest, *apiErrors.ServiceError) {
+	if mock.GetByIdFunc == nil {
+		panic("KafkaServiceMock.GetByIdFunc: method is nil but KafkaService.GetById was just called")
+	}
+	callInfo := struct {
+		ID string
+	}{
+		ID: id,
+	}
+	mock.lockGetById.Lock()
+	mock.calls.GetById = append(mock.calls.GetById, callInfo)
+	mock.lockGetById.Unlock()
+	return mock.GetByIdFunc(id)
+}
+
+// GetByIdCalls gets all the calls that were made to GetById.
+// Check the length with:
+//
+//	len(mockedKafkaService.GetByIdCalls())
+func (mock *KafkaServiceMock) GetByIdCalls() []struct {
+	ID string
+} {
+	var calls []struct {
+		ID string
+	}
+	mock.lockGetById.RLock()
+	calls = mock.calls.GetById
+	mock.lockGetById.RUnlock()
+	return calls
+}
+
+// GetByIdForContext calls GetByIdForContextFunc.
+func (mock *KafkaServiceMock) GetByIdForContext(ctx context.Context, id string) (*dbapi.KafkaRequest, *apiErrors.ServiceError) {
+	if mock.GetByIdForContextFunc == nil {
+		panic("KafkaServiceMock.GetByIdForContextFunc: method is nil but KafkaService.GetByIdForContext was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  string
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	mock.lockGetByIdForContext.Lock()
+	mock.calls.GetByIdForContext = append(mock.calls.GetByIdForContext, callInfo)
+	mock.lockGetByIdForContext.Unlock()
+	return mock.GetByIdForContextFunc(ctx, id)
+}
+
+// GetByIdForContextCalls gets all the calls that were made to GetByIdForContext.
+// Check the length with:
+//
+//	len(mockedKafkaService.GetByIdForContextCalls())
+func (mock *KafkaServiceMock) GetByIdForContextCalls() []struct {
+	Ctx context.Context
+	ID  string
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  string
+	}
+	mock.lockGetByIdForContext.RLock()
+	calls = mock.calls.GetByIdForContext
+	mock.lockGetByIdForContext.RUnlock()
+	return calls
+}
+
+// GetByIdIncludingDeleted calls GetByIdIncludingDeletedFunc.
+func (mock *KafkaServiceMock) GetByIdIncludingDeleted(id string) (*dbapi.KafkaRequest, *apiErrors.ServiceError) {
+	if mock.GetByIdIncludingDeletedFunc == nil {
+		panic("KafkaServiceMock.GetByIdIncludingDeletedFunc: method is nil but KafkaService.GetByIdIncludingDeleted was just called")
+	}
+	callInfo := struct {
+		ID string
+	}{
+		ID: id,
+	}
+	mock.lockGetByIdIncludingDeleted.Lock()
+	mock.calls.GetByIdIncludingDeleted = append(mock.calls.GetByIdIncludingDeleted, callInfo)
+	mock.lockGetByIdIncludingDeleted.Unlock()
+	return mock.GetByIdIncludingDeletedFunc(id)
+}
+
+// GetByIdIncludingDeletedCalls gets all the calls that were made to GetByIdIncludingDeleted.
+// Check the length with:
+//
+//	len(mockedKafkaService.GetByIdIncludingDeletedCalls())
+func (mock *KafkaServiceMock) GetByIdIncludingDeletedCalls() []struct {
+	ID string
+} {
+	var calls []struct {
+		ID string
+	}
+	mock.lockGetByIdIncludingDeleted.RLock()
+	calls = mock.calls.GetByIdIncludingDeleted
+	mock.lockGetByIdIncludingDeleted.RUnlock()
+	return calls
+}
+
+// GetByName calls GetByNameFunc.
+func (mock *KafkaServiceMock) GetByName(ctx context.Context, name string) (*dbapi.KafkaRequest, *apiErrors.ServiceError) {
+	if mock.GetByNameFunc == nil {
+		panic("KafkaServiceMock.GetByNameFunc: method is nil but KafkaService.GetByName was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		Name string
+	}{
+		Ctx:  ctx,
+		Name: name,
+	}
+	mock.lockGetByName.Lock()
+	mock.calls.GetByName = append(mock.calls.GetByName, callInfo)
+	mock.lockGetByName.Unlock()
+	return mock.GetByNameFunc(ctx, name)
+}
+
+// GetByNameCalls gets all the calls that were made to GetByName.
+// Check the length with:
+//
+//	len(mockedKafkaService.GetByNameCalls())
+func (mock *KafkaServiceMock) GetByNameCalls() []struct {
+	Ctx  context.Context
+	Name string
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Name string
+	}
+	mock.lockGetByName.RLock()
+	calls = mock.calls.GetByName
+	mock.lockGetByName.RUnlock()
+	return calls
+}
+
+// GetByNamespace calls GetByNamespaceFunc.
+func (mock *KafkaServiceMock) GetByNamespace(clusterID string, namespace string) (*dbapi.KafkaRequest, *apiErrors.ServiceError) {
+	if mock.GetByNamespaceFunc == nil {
+		panic("KafkaServiceMock.GetByNamespaceFunc: method is nil but KafkaService.GetByNamespace was just called")
+	}
+	callInfo := struct {
+		ClusterID string
+		Namespace string
+	}{
+		ClusterID: clusterID,
+		Namespace: namespace,
+	}
+	mock.lockGetByNamespace.Lock()
+	mock.calls.GetByNamespace = append(mock.calls.GetByNamespace, callInfo)
+	mock.lockGetByNamespace.Unlock()
+	return mock.GetByNamespaceFunc(clusterID, namespace)
+}
+
+// GetByNamespaceCalls gets all the calls that were made to GetByNamespace.
+// Check the length with:
+//
+//	len(mockedKafkaService.GetByNamespaceCalls())
+func (mock *KafkaServiceMock) GetByNamespaceCalls() []struct {
+	ClusterID string
+	Namespace string
+} {
+	var calls []struct {
+		ClusterID string
+		Namespace string
+	}
+	mock.lockGetByNamespace.RLock()
+	calls = mock.calls.GetByNamespace
+	mock.lockGetByNamespace.RUnlock()
+	return calls
+}
+
+// GetCNAMERecordStatus calls GetCNAMERecordStatusFunc.
+func (mock *KafkaServiceMock) GetCNAMERecordStatus(kafkaRequest *dbapi.KafkaRequest) (*CNameRecordStatus, error) {
+	if mock.GetCNAMERecordStatusFunc == nil {
+		panic("KafkaServiceMock.GetCNAMERecordStatusFunc: method is nil but KafkaService.GetCNAMERecordStatus was just called")
+	}
+	callInfo := struct {
+		KafkaRequest *dbapi.KafkaRequest
+	}{
+		KafkaRequest: kafkaRequest,
+	}
+	mock.lockGetCNAMERecordStatus.Lock()
+	mock.calls.GetCNAMERecordStatus = append(mock.calls.GetCNAMERecordStatus, callInfo)
+	mock.lockGetCNAMERecordStatus.Unlock()
+	return mock.GetCNAMERecordStatusFunc(kafkaRequest)
+}
+
+// GetCNAMERecordStatusCalls gets all the calls that were made to GetCNAMERecordStatus.
+// Check the length with:
+//
+//	len(mockedKafkaService.GetCNAMERecordStatusCalls())
+func (mock *KafkaServiceMock) GetCNAMERecordStatusCalls() []struct {
+	KafkaRequest *dbapi.KafkaRequest
+} {
+	var calls []struct {
+		KafkaRequest *dbapi.KafkaRequest
+	}
+	mock.lockGetCNAMERecordStatus.RLock()
+	calls = mock.calls.GetCNAMERecordStatus
+	mock.lockGetCNAMERecordStatus.RUnlock()
+	return calls
+}
+
+// GetCapacityReport calls GetCapacityReportFunc.
+func (mock *KafkaServiceMock) GetCapacityReport() ([]RegionCapacityReport, *apiErrors.ServiceError) {
+	if mock.GetCapacityReportFunc == nil {
+		panic("KafkaServiceMock.GetCapacityReportFunc: method is nil but KafkaService.GetCapacityReport was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockGetCapacityReport.Lock()
+	mock.calls.GetCapacityReport = append(mock.calls.GetCapacityReport, callInfo)
+	mock.lockGetCapacityReport.Unlock()
+	return mock.GetCapacityReportFunc()
+}
+
+// GetCapacityReportCalls gets all the calls that were made to GetCapacityReport.
+// Check the length with:
+//
+//	len(mockedKafkaService.GetCapacityReportCalls())
+func (mock *KafkaServiceMock) GetCapacityReportCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockGetCapacityReport.RLock()
+	calls = mock.calls.GetCapacityReport
+	mock.lockGetCapacityReport.RUnlock()
+	return calls
+}
+
+// GetClusterForKafka calls GetClusterForKafkaFunc.
+func (mock *KafkaServiceMock) GetClusterForKafka(id string) (*api.Cluster, *apiErrors.ServiceError) {
+	if mock.GetClusterForKafkaFunc == nil {
+		panic("KafkaServiceMock.GetClusterForKafkaFunc: method is nil but KafkaService.GetClusterForKafka was just called")
+	}
+	callInfo := struct {
+		ID string
+	}{
+		ID: id,
+	}
+	mock.lockGetClusterForKafka.Lock()
+	mock.calls.GetClusterForKafka = append(mock.calls.GetClusterForKafka, callInfo)
+	mock.lockGetClusterForKafka.Unlock()
+	return mock.GetClusterForKafkaFunc(id)
+}
+
+// GetClusterForKafkaCalls gets all the calls that were made to GetClusterForKafka.
+// Check the length with:
+//
+//	len(mockedKafkaService.GetClusterForKafkaCalls())
+func (mock *KafkaServiceMock) GetClusterForKafkaCalls() []struct {
+	ID string
+} {
+	var calls []struct {
+		ID string
+	}
+	mock.lockGetClusterForKafka.RLock()
+	calls = mock.calls.GetClusterForKafka
+	mock.lockGetClusterForKafka.RUnlock()
+	return calls
+}
+
+// GetDeprovisionReason calls GetDeprovisionReasonFunc.
+func (mock *KafkaServiceMock) GetDeprovisionReason(id string) (constants2.KafkaDeprovisionReason, *apiErrors.ServiceError) {
+	if mock.GetDeprovisionReasonFunc == nil {
+		panic("KafkaServiceMock.GetDeprovisionReasonFunc: method is nil but KafkaService.GetDeprovisionReason was just called")
+	}
+	callInfo := struct {
+		ID string
+	}{
+		ID: id,
+	}
+	mock.lockGetDeprovisionReason.Lock()
+	mock.calls.GetDeprovisionReason = append(mock.calls.GetDeprovisionReason, callInfo)
+	mock.lockGetDeprovisionReason.Unlock()
+	return mock.GetDeprovisionReasonFunc(id)
+}
+
+// GetDeprovisionReasonCalls gets all the calls that were made to GetDeprovisionReason.
+// Check the length with:
+//
+//	len(mockedKafkaService.GetDeprovisionReasonCalls())
+func (mock *KafkaServiceMock) GetDeprovisionReasonCalls() []struct {
+	ID string
+} {
+	var calls []struct {
+		ID string
+	}
+	mock.lockGetDeprovisionReason.RLock()
+	calls = mock.calls.GetDeprovisionReason
+	mock.lockGetDeprovisionReason.RUnlock()
+	return calls
+}
+
+// GetFleetSummary calls GetFleetSummaryFunc.
+func (mock *KafkaServiceMock) GetFleetSummary() (*FleetSummary, *apiErrors.ServiceError) {
+	if mock.GetFleetSummaryFunc == nil {
+		panic("KafkaServiceMock.GetFleetSummaryFunc: method is nil but KafkaService.GetFleetSummary was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockGetFleetSummary.Lock()
+	mock.calls.GetFleetSummary = append(mock.calls.GetFleetSummary, callInfo)
+	mock.lockGetFleetSummary.Unlock()
+	return mock.GetFleetSummaryFunc()
+}
+
+// GetFleetSummaryCalls gets all the calls that were made to GetFleetSummary.
+// Check the length with:
+//
+//	len(mockedKafkaService.GetFleetSummaryCalls())
+func (mock *KafkaServiceMock) GetFleetSummaryCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockGetFleetSummary.RLock()
+	calls = mock.calls.GetFleetSummary
+	mock.lockGetFleetSummary.RUnlock()
+	return calls
+}
+
+// GetManagedKafkaByClusterID calls GetManagedKafkaByClusterIDFunc.
+func (mock *KafkaServiceMock) GetManagedKafkaByClusterID(clusterID string) ([]managedkafka.ManagedKafka, *apiErrors.ServiceError) {
+	if mock.GetManagedKafkaByClusterIDFunc == nil {
+		panic("KafkaServiceMock.GetManagedKafkaByClusterIDFunc: method is nil but KafkaService.GetManagedKafkaByClusterID was just called")
+	}
+	callInfo := struct {
+		ClusterID string
+	}{
+		ClusterID: clusterID,
+	}
+	mock.lockGetManagedKafkaByClusterID.Lock()
+	mock.calls.GetManagedKafkaByClusterID = append(mock.calls.GetManagedKafkaByClusterID, callInfo)
+	mock.lockGetManagedKafkaByClusterID.Unlock()
+	return mock.GetManagedKafkaByClusterIDFunc(clusterID)
+}
+
+// GetManagedKafkaByClusterIDCalls gets all the calls that were made to GetManagedKafkaByClusterID.
+// Check the length with:
+//
+//	len(mockedKafkaService.GetManagedKafkaByClusterIDCalls())
+func (mock *KafkaServiceMock) GetManagedKafkaByClusterIDCalls() []struct {
+	ClusterID string
+} {
+	var calls []struct {
+		ClusterID string
+	}
+	mock.lockGetManagedKafkaByClusterID.RLock()
+	calls = mock.calls.GetManagedKafkaByClusterID
+	mock.lockGetManagedKafkaByClusterID.RUnlock()
+	return calls
+}
+
+// GetManagedKafkaByClusterIDChangedSince calls GetManagedKafkaByClusterIDChangedSinceFunc.
+func (mock *KafkaServiceMock) GetManagedKafkaByClusterIDChangedSince(clusterID string, since time.Time) ([]managedkafka.ManagedKafka, *apiErrors.ServiceError) {
+	if mock.GetManagedKafkaByClusterIDChangedSinceFunc == nil {
+		panic("KafkaServiceMock.GetManagedKafkaByClusterIDChangedSinceFunc: method is nil but KafkaService.GetManagedKafkaByClusterIDChangedSince was just called")
+	}
+	callInfo := struct {
+		ClusterID string
+		Since     time.Time
+	}{
+		ClusterID: clusterID,
+		Since:     since,
+	}
+	mock.lockGetManagedKafkaByClusterIDChangedSince.Lock()
+	mock.calls.GetManagedKafkaByClusterIDChangedSince = append(mock.calls.GetManagedKafkaByClusterIDChangedSince, callInfo)
+	mock.lockGetManagedKafkaByClusterIDChangedSince.Unlock()
+	return mock.GetManagedKafkaByClusterIDChangedSinceFunc(clusterID, since)
+}
+
+// GetManagedKafkaByClusterIDChangedSinceCalls gets all the calls that were made to GetManagedKafkaByClusterIDChangedSince.
+// Check the length with:
+//
+//	len(mockedKafkaService.GetManagedKafkaByClusterIDChangedSinceCalls())
+func (mock *KafkaServiceMock) GetManagedKafkaByClusterIDChangedSinceCalls() []struct {
+	ClusterID string
+	Since     time.Time
+} {
+	var calls []struct {
+		ClusterID string
+		Since     time.Time
+	}
+	mock.lockGetManagedKafkaByClusterIDChangedSince.RLock()
+	calls = mock.calls.GetManagedKafkaByClusterIDChangedSince
+	mock.lockGetManagedKafkaByClusterIDChangedSince.RUnlock()
+	return calls
+}
+
+// GetOwnerSummary calls GetOwnerSummaryFunc.
+func (mock *KafkaServiceMock) GetOwnerSummary(ctx context.Context) (*OwnerKafkaSummary, *apiErrors.ServiceError) {
+	if mock.GetOwnerSummaryFunc == nil {
+		panic("KafkaServiceMock.GetOwnerSummaryFunc: method is nil but KafkaService.GetOwnerSummary was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockGetOwnerSummary.Lock()
+	mock.calls.GetOwnerSummary = append(mock.calls.GetOwnerSummary, callInfo)
+	mock.lockGetOwnerSummary.Unlock()
+	return mock.GetOwnerSummaryFunc(ctx)
+}
+
+// GetOwnerSummaryCalls gets all the calls that were made to GetOwnerSummary.
+// Check the length with:
+//
+//	len(mockedKafkaService.GetOwnerSummaryCalls())
+func (mock *KafkaServiceMock) GetOwnerSummaryCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockGetOwnerSummary.RLock()
+	calls = mock.calls.GetOwnerSummary
+	mock.lockGetOwnerSummary.RUnlock()
+	return calls
+}
+
+// GetProvisioningDuration calls GetProvisioningDurationFunc.
+func (mock *KafkaServiceMock) GetProvisioningDuration(from time.Time, to time.Time) ([]ProvisioningDurationPercentiles, *apiErrors.ServiceError) {
+	if mock.GetProvisioningDurationFunc == nil {
+		panic("KafkaServiceMock.GetProvisioningDurationFunc: method is nil but KafkaService.GetProvisioningDuration was just called")
+	}
+	callInfo := struct {
+		From time.Time
+		To   time.Time
+	}{
+		From: from,
+		To:   to,
+	}
+	mock.lockGetProvisioningDuration.Lock()
+	mock.calls.GetProvisioningDuration = append(mock.calls.GetProvisioningDuration, callInfo)
+	mock.lockGetProvisioningDuration.Unlock()
+	return mock.GetProvisioningDurationFunc(from, to)
+}
+
+// GetProvisioningDurationCalls gets all the calls that were made to GetProvisioningDuration.
+// Check the length with:
+//
+//	len(mockedKafkaService.GetProvisioningDurationCalls())
+func (mock *KafkaServiceMock) GetProvisioningDurationCalls() []struct {
+	From time.Time
+	To   time.Time
+} {
+	var calls []struct {
+		From time.Time
+		To   time.Time
+	}
+	mock.lockGetProvisioningDuration.RLock()
+	calls = mock.calls.GetProvisioningDuration
+	mock.lockGetProvisioningDuration.RUnlock()
+	return calls
+}
+
+// GetQuotaCost calls GetQuotaCostFunc.
+func (mock *KafkaServiceMock) GetQuotaCost(instanceType types.KafkaInstanceType, sizeId string) (int, *apiErrors.ServiceError) {
+	if mock.GetQuotaCostFunc == nil {
+		panic("KafkaServiceMock.GetQuotaCostFunc: method is nil but KafkaService.GetQuotaCost was just called")
+	}
+	callInfo := struct {
+		InstanceType types.KafkaInstanceType
+		SizeId       string
+	}{
+		InstanceType: instanceType,
+		SizeId:       sizeId,
+	}
+	mock.lockGetQuotaCost.Lock()
+	mock.calls.GetQuotaCost = append(mock.calls.GetQuotaCost, callInfo)
+	mock.lockGetQuotaCost.Unlock()
+	return mock.GetQuotaCostFunc(instanceType, sizeId)
+}
+
+// GetQuotaCostCalls gets all the calls that were made to GetQuotaCost.
+// Check the length with:
+//
+//	len(mockedKafkaService.GetQuotaCostCalls())
+func (mock *KafkaServiceMock) GetQuotaCostCalls() []struct {
+	InstanceType types.KafkaInstanceType
+	SizeId       string
+} {
+	var calls []struct {
+		InstanceType types.KafkaInstanceType
+		SizeId       string
+	}
+	mock.lockGetQuotaCost.RLock()
+	calls = mock.calls.GetQuotaCost
+	mock.lockGetQuotaCost.RUnlock()
+	return calls
+}
+
+// GetRegionStatus calls GetRegionStatusFunc.
+func (mock *KafkaServiceMock) GetRegionStatus(provider string, region string, instanceType string) (*RegionStatus, *apiErrors.ServiceError) {
+	if mock.GetRegionStatusFunc == nil {
+		panic("KafkaServiceMock.GetRegionStatusFunc: method is nil but KafkaService.GetRegionStatus was just called")
+	}
+	callInfo := struct {
+		Provider     string
+		Region       string
+		InstanceType string
+	}{
+		Provider:     provider,
+		Region:       region,
+		InstanceType: instanceType,
+	}
+	mock.lockGetRegionStatus.Lock()
+	mock.calls.GetRegionStatus = append(mock.calls.GetRegionStatus, callInfo)
+	mock.lockGetRegionStatus.Unlock()
+	return mock.GetRegionStatusFunc(provider, region, instanceType)
+}
+
+// GetRegionStatusCalls gets all the calls that were made to GetRegionStatus.
+// Check the length with:
+//
+//	len(mockedKafkaService.GetRegionStatusCalls())
+func (mock *KafkaServiceMock) GetRegionStatusCalls() []struct {
+	Provider     string
+	Region       string
+	InstanceType string
+} {
+	var calls []struct {
+		Provider     string
+		Region       string
+		InstanceType string
+	}
+	mock.lockGetRegionStatus.RLock()
+	calls = mock.calls.GetRegionStatus
+	mock.lockGetRegionStatus.RUnlock()
+	return calls
+}
+
+// GetStreamingUnitUsageByClusterID calls GetStreamingUnitUsageByClusterIDFunc.
+func (mock *KafkaServiceMock) GetStreamingUnitUsageByClusterID(clusterID string) ([]StreamingUnitUsage, *apiErrors.ServiceError) {
+	if mock.GetStreamingUnitUsageByClusterIDFunc == nil {
+		panic("KafkaServiceMock.GetStreamingUnitUsageByClusterIDFunc: method is nil but KafkaService.GetStreamingUnitUsageByClusterID was just called")
+	}
+	callInfo := struct {
+		ClusterID string
+	}{
+		ClusterID: clusterID,
+	}
+	mock.lockGetStreamingUnitUsageByClusterID.Lock()
+	mock.calls.GetStreamingUnitUsageByClusterID = append(mock.calls.GetStreamingUnitUsageByClusterID, callInfo)
+	mock.lockGetStreamingUnitUsageByClusterID.Unlock()
+	return mock.GetStreamingUnitUsageByClusterIDFunc(clusterID)
+}
+
+// GetStreamingUnitUsageByClusterIDCalls gets all the calls that were made to GetStreamingUnitUsageByClusterID.
+// Check the length with:
+//
+//	len(mockedKafkaService.GetStreamingUnitUsageByClusterIDCalls())
+func (mock *KafkaServiceMock) GetStreamingUnitUsageByClusterIDCalls() []struct {
+	ClusterID string
+} {
+	var calls []struct {
+		ClusterID string
+	}
+	mock.lockGetStreamingUnitUsageByClusterID.RLock()
+	calls = mock.calls.GetStreamingUnitUsageByClusterID
+	mock.lockGetStreamingUnitUsageByClusterID.RUnlock()
+	return calls
+}
+
+// GetWithFields calls GetWithFieldsFunc.
+func (mock *KafkaServiceMock) GetWithFields(ctx context.Context, id string, columns []string) (*dbapi.KafkaRequest, *apiErrors.ServiceError) {
+	if mock.GetWithFieldsFunc == nil {
+		panic("KafkaServiceMock.GetWithFieldsFunc: method is nil but KafkaService.GetWithFields was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		ID      string
+		Columns []string
+	}{
+		Ctx:     ctx,
+		ID:      id,
+		Columns: columns,
+	}
+	mock.lockGetWithFields.Lock()
+	mock.calls.GetWithFields = append(mock.calls.GetWithFields, callInfo)
+	mock.lockGetWithFields.Unlock()
+	return mock.GetWithFieldsFunc(ctx, id, columns)
+}
+
+// GetWithFieldsCalls gets all the calls that were made to GetWithFields.
+// Check the length with:
+//
+//	len(mockedKafkaService.GetWithFieldsCalls())
+func (mock *KafkaServiceMock) GetWithFieldsCalls() []struct {
+	Ctx     context.Context
+	ID      string
+	Columns []string
+} {
+	var calls []struct {
+		Ctx     context.Context
+		ID      string
+		Columns []string
+	}
+	mock.lockGetWithFields.RLock()
+	calls = mock.calls.GetWithFields
+	mock.lockGetWithFields.RUnlock()
+	return calls
+}
+
+// GetWithRoutes calls GetWithRoutesFunc.
+func (mock *KafkaServiceMock) GetWithRoutes(ctx context.Context, id string) (*KafkaWithRoutes, *apiErrors.ServiceError) {
+	if mock.GetWithRoutesFunc == nil {
+		panic("KafkaServiceMock.GetWithRoutesFunc: method is nil but KafkaService.GetWithRoutes was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  string
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	mock.lockGetWithRoutes.Lock()
+	mock.calls.GetWithRoutes = append(mock.calls.GetWithRoutes, callInfo)
+	mock.lockGetWithRoutes.Unlock()
+	return mock.GetWithRoutesFunc(ctx, id)
+}
+
+// GetWithRoutesCalls gets all the calls that were made to GetWithRoutes.
+// Check the length with:
+//
+//	len(mockedKafkaService.GetWithRoutesCalls())
+func (mock *KafkaServiceMock) GetWithRoutesCalls() []struct {
+	Ctx context.Context
+	ID  string
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  string
+	}
+	mock.lockGetWithRoutes.RLock()
+	calls = mock.calls.GetWithRoutes
+	mock.lockGetWithRoutes.RUnlock()
+	return calls
+}
+
+// HasAvailableCapacityInRegion calls HasAvailableCapacityInRegionFunc.
+func (mock *KafkaServiceMock) HasAvailableCapacityInRegion(kafkaRequest *dbapi.KafkaRequest) (bool, *apiErrors.ServiceError) {
+	if mock.HasAvailableCapacityInRegionFunc == nil {
+		panic("KafkaServiceMock.HasAvailableCapacityInRegionFunc: method is nil but KafkaService.HasAvailableCapacityInRegion was just called")
+	}
+	callInfo := struct {
+		KafkaRequest *dbapi.KafkaRequest
+	}{
+		KafkaRequest: kafkaRequest,
+	}
+	mock.lockHasAvailableCapacityInRegion.Lock()
+	mock.calls.HasAvailableCapacityInRegion = append(mock.calls.HasAvailableCapacityInRegion, callInfo)
+	mock.lockHasAvailableCapacityInRegion.Unlock()
+	return mock.HasAvailableCapacityInRegionFunc(kafkaRequest)
+}
+
+// HasAvailableCapacityInRegionCalls gets all the calls that were made to HasAvailableCapacityInRegion.
+// Check the length with:
+//
+//	len(mockedKafkaService.HasAvailableCapacityInRegionCalls())
+func (mock *KafkaServiceMock) HasAvailableCapacityInRegionCalls() []struct {
+	KafkaRequest *dbapi.KafkaRequest
+} {
+	var calls []struct {
+		KafkaRequest *dbapi.KafkaRequest
+	}
+	mock.lockHasAvailableCapacityInRegion.RLock()
+	calls = mock.calls.HasAvailableCapacityInRegion
+	mock.lockHasAvailableCapacityInRegion.RUnlock()
+	return calls
+}
+
+// InvalidateBillingAccounts calls InvalidateBillingAccountsFunc.
+func (mock *KafkaServiceMock) InvalidateBillingAccounts(externalId string) *apiErrors.ServiceError {
+	if mock.InvalidateBillingAccountsFunc == nil {
+		panic("KafkaServiceMock.InvalidateBillingAccountsFunc: method is nil but KafkaService.InvalidateBillingAccounts was just called")
+	}
+	callInfo := struct {
+		ExternalId string
+	}{
+		ExternalId: externalId,
+	}
+	mock.lockInvalidateBillingAccounts.Lock()
+	mock.calls.InvalidateBillingAccounts = append(mock.calls.InvalidateBillingAccounts, callInfo)
+	mock.lockInvalidateBillingAccounts.Unlock()
+	return mock.InvalidateBillingAccountsFunc(externalId)
+}
+
+// InvalidateBillingAccountsCalls gets all the calls that were made to InvalidateBillingAccounts.
+// Check the length with:
+//
+//	len(mockedKafkaService.InvalidateBillingAccountsCalls())
+func (mock *KafkaServiceMock) InvalidateBillingAccountsCalls() []struct {
+	ExternalId string
+} {
+	var calls []struct {
+		ExternalId string
+	}
+	mock.lockInvalidateBillingAccounts.RLock()
+	calls = mock.calls.InvalidateBillingAccounts
+	mock.lockInvalidateBillingAccounts.RUnlock()
+	return calls
+}
+
+// List calls ListFunc.
+func (mock *KafkaServiceMock) List(ctx context.Context, listArgs *services.ListArguments) (dbapi.KafkaList, *api.PagingMeta, *apiErrors.ServiceError) {
+	if mock.ListFunc == nil {
+		panic("KafkaServiceMock.ListFunc: method is nil but KafkaService.List was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		ListArgs *services.ListArguments
+	}{
+		Ctx:      ctx,
+		ListArgs: listArgs,
+	}
+	mock.lockList.Lock()
+	mock.calls.List = append(mock.calls.List, callInfo)
+	mock.lockList.Unlock()
+	return mock.ListFunc(ctx, listArgs)
+}
+
+// ListCalls gets all the calls that were made to List.
+// Check the length with:
+//
+//	len(mockedKafkaService.ListCalls())
+func (mock *KafkaServiceMock) ListCalls() []struct {
+	Ctx      context.Context
+	ListArgs *services.ListArguments
+} {
+	var calls []struct {
+		Ctx      context.Context
+		ListArgs *services.ListArguments
+	}
+	mock.lockList.RLock()
+	calls = mock.calls.List
+	mock.lockList.RUnlock()
+	return calls
+}
+
+// ListByBillingCloudAccountId calls ListByBillingCloudAccountIdFunc.
+func (mock *KafkaServiceMock) ListByBillingCloudAccountId(accountId string, listArgs *services.ListArguments) (dbapi.KafkaList, *api.PagingMeta, *apiErrors.ServiceError) {
+	if mock.ListByBillingCloudAccountIdFunc == nil {
+		panic("KafkaServiceMock.ListByBillingCloudAccountIdFunc: method is nil but KafkaService.ListByBillingCloudAccountId was just called")
+	}
+	callInfo := struct {
+		AccountId string
+		ListArgs  *services.ListArguments
+	}{
+		AccountId: accountId,
+		ListArgs:  listArgs,
+	}
+	mock.lockListByBillingCloudAccountId.Lock()
+	mock.calls.ListByBillingCloudAccountId = append(mock.calls.ListByBillingCloudAccountId, callInfo)
+	mock.lockListByBillingCloudAccountId.Unlock()
+	return mock.ListByBillingCloudAccountIdFunc(accountId, listArgs)
+}
+
+// ListByBillingCloudAccountIdCalls gets all the calls that were made to ListByBillingCloudAccountId.
+// Check the length with:
+//
+//	len(mockedKafkaService.ListByBillingCloudAccountIdCalls())
+func (mock *KafkaServiceMock) ListByBillingCloudAccountIdCalls() []struct {
+	AccountId string
+	ListArgs  *services.ListArguments
+} {
+	var calls []struct {
+		AccountId string
+		ListArgs  *services.ListArguments
+	}
+	mock.lockListByBillingCloudAccountId.RLock()
+	calls = mock.calls.ListByBillingCloudAccountId
+	mock.lockListByBillingCloudAccountId.RUnlock()
+	return calls
+}
+
+// ListByCreatedRange calls ListByCreatedRangeFunc.
+func (mock *KafkaServiceMock) ListByCreatedRange(from time.Time, to time.Time, listArgs *services.ListArguments) (dbapi.KafkaList, *api.PagingMeta, *apiErrors.ServiceError) {
+	if mock.ListByCreatedRangeFunc == nil {
+		panic("KafkaServiceMock.ListByCreatedRangeFunc: method is nil but KafkaService.ListByCreatedRange was just called")
+	}
+	callInfo := struct {
+		From     time.Time
+		To       time.Time
+		ListArgs *services.ListArguments
+	}{
+		From:     from,
+		To:       to,
+		ListArgs: listArgs,
+	}
+	mock.lockListByCreatedRange.Lock()
+	mock.calls.ListByCreatedRange = append(mock.calls.ListByCreatedRange, callInfo)
+	mock.lockListByCreatedRange.Unlock()
+	return mock.ListByCreatedRangeFunc(from, to, listArgs)
+}
+
+// ListByCreatedRangeCalls gets all the calls that were made to ListByCreatedRange.
+// Check the length with:
+//
+//	len(mockedKafkaService.ListByCreatedRangeCalls())
+func (mock *KafkaServiceMock) ListByCreatedRangeCalls() []struct {
+	From     time.Time
+	To       time.Time
+	ListArgs *services.ListArguments
+} {
+	var calls []struct {
+		From     time.Time
+		To       time.Time
+		ListArgs *services.ListArguments
+	}
+	mock.lockListByCreatedRange.RLock()
+	calls = mock.calls.ListByCreatedRange
+	mock.lockListByCreatedRange.RUnlock()
+	return calls
+}
+
+// ListByDesiredStrimziVersion calls ListByDesiredStrimziVersionFunc.
+func (mock *KafkaServiceMock) ListByDesiredStrimziVersion(version string, listArgs *services.ListArguments) (dbapi.KafkaList, *api.PagingMeta, *apiErrors.ServiceError) {
+	if mock.ListByDesiredStrimziVersionFunc == nil {
+		panic("KafkaServiceMock.ListByDesiredStrimziVersionFunc: method is nil but KafkaService.ListByDesiredStrimziVersion was just called")
+	}
+	callInfo := struct {
+		Version  string
+		ListArgs *services.ListArguments
+	}{
+		Version:  version,
+		ListArgs: listArgs,
+	}
+	mock.lockListByDesiredStrimziVersion.Lock()
+	mock.calls.ListByDesiredStrimziVersion = append(mock.calls.ListByDesiredStrimziVersion, callInfo)
+	mock.lockListByDesiredStrimziVersion.Unlock()
+	return mock.ListByDesiredStrimziVersionFunc(version, listArgs)
+}
+
+// ListByDesiredStrimziVersionCalls gets all the calls that were made to ListByDesiredStrimziVersion.
+// Check the length with:
+//
+//	len(mockedKafkaService.ListByDesiredStrimziVersionCalls())
+func (mock *KafkaServiceMock) ListByDesiredStrimziVersionCalls() []struct {
+	Version  string
+	ListArgs *services.ListArguments
+} {
+	var calls []struct {
+		Version  string
+		ListArgs *services.ListArguments
+	}
+	mock.lockListByDesiredStrimziVersion.RLock()
+	calls = mock.calls.ListByDesiredStrimziVersion
+	mock.lockListByDesiredStrimziVersion.RUnlock()
+	return calls
+}
+
+// ListByInstanceType calls ListByInstanceTypeFunc.
+func (mock *KafkaServiceMock) ListByInstanceType(instanceType types.KafkaInstanceType, listArgs *services.ListArguments) (dbapi.KafkaList, *api.PagingMeta, *apiErrors.ServiceError) {
+	if mock.ListByInstanceTypeFunc == nil {
+		panic("KafkaServiceMock.ListByInstanceTypeFunc: method is nil but KafkaService.ListByInstanceType was just called")
+	}
+	callInfo := struct {
+		InstanceType types.KafkaInstanceType
+		ListArgs     *services.ListArguments
+	}{
+		InstanceType: instanceType,
+		ListArgs:     listArgs,
+	}
+	mock.lockListByInstanceType.Lock()
+	mock.calls.ListByInstanceType = append(mock.calls.ListByInstanceType, callInfo)
+	mock.lockListByInstanceType.Unlock()
+	return mock.ListByInstanceTypeFunc(instanceType, listArgs)
+}
+
+// ListByInstanceTypeCalls gets all the calls that were made to ListByInstanceType.
+// Check the length with:
+//
+//	len(mockedKafkaService.ListByInstanceTypeCalls())
+func (mock *KafkaServiceMock) ListByInstanceTypeCalls() []struct {
+	InstanceType types.KafkaInstanceType
+	ListArgs     *services.ListArguments
+} {
+	var calls []struct {
+		InstanceType types.KafkaInstanceType
+		ListArgs     *services.ListArguments
+	}
+	mock.lockListByInstanceType.RLock()
+	calls = mock.calls.ListByInstanceType
+	mock.lockListByInstanceType.RUnlock()
+	return calls
+}
+
+// ListByQuotaType calls ListByQuotaTypeFunc.
+func (mock *KafkaServiceMock) ListByQuotaType(quotaType string, listArgs *services.ListArguments) (dbapi.KafkaList, *api.PagingMeta, *apiErrors.ServiceError) {
+	if mock.ListByQuotaTypeFunc == nil {
+		panic("KafkaServiceMock.ListByQuotaTypeFunc: method is nil but KafkaService.ListByQuotaType was just called")
+	}
+	callInfo := struct {
+		QuotaType string
+		ListArgs  *services.ListArguments
+	}{
+		QuotaType: quotaType,
+		ListArgs:  listArgs,
+	}
+	mock.lockListByQuotaType.Lock()
+	mock.calls.ListByQuotaType = append(mock.calls.ListByQuotaType, callInfo)
+	mock.lockListByQuotaType.Unlock()
+	return mock.ListByQuotaTypeFunc(quotaType, listArgs)
+}
+
+// ListByQuotaTypeCalls gets all the calls that were made to ListByQuotaType.
+// Check the length with:
+//
+//	len(mockedKafkaService.ListByQuotaTypeCalls())
+func (mock *KafkaServiceMock) ListByQuotaTypeCalls() []struct {
+	QuotaType string
+	ListArgs  *services.ListArguments
+} {
+	var calls []struct {
+		QuotaType string
+		ListArgs  *services.ListArguments
+	}
+	mock.lockListByQuotaType.RLock()
+	calls = mock.calls.ListByQuotaType
+	mock.lockListByQuotaType.RUnlock()
+	return calls
+}
+
+// ListByRegion calls ListByRegionFunc.
+func (mock *KafkaServiceMock) ListByRegion(provider string, region string, listArgs *services.ListArguments) (dbapi.KafkaList, *api.PagingMeta, *apiErrors.ServiceError) {
+	if mock.ListByRegionFunc == nil {
+		panic("KafkaServiceMock.ListByRegionFunc: method is nil but KafkaService.ListByRegion was just called")
+	}
+	callInfo := struct {
+		Provider string
+		Region   string
+		ListArgs *services.ListArguments
+	}{
+		Provider: provider,
+		Region:   region,
+		ListArgs: listArgs,
+	}
+	mock.lockListByRegion.Lock()
+	mock.calls.ListByRegion = append(mock.calls.ListByRegion, callInfo)
+	mock.lockListByRegion.Unlock()
+	return mock.ListByRegionFunc(provider, region, listArgs)
+}
+
+// ListByRegionCalls gets all the calls that were made to ListByRegion.
+// Check the length with:
+//
+//	len(mockedKafkaService.ListByRegionCalls())
+func (mock *KafkaServiceMock) ListByRegionCalls() []struct {
+	Provider string
+	Region   string
+	ListArgs *services.ListArguments
+} {
+	var calls []struct {
+		Provider string
+		Region   string
+		ListArgs *services.ListArguments
+	}
+	mock.lockListByRegion.RLock()
+	calls = mock.calls.ListByRegion
+	mock.lockListByRegion.RUnlock()
+	return calls
+}
+
+// ListByStatus calls ListByStatusFunc.
+func (mock *KafkaServiceMock) ListByStatus(status ...constants2.KafkaStatus) ([]*dbapi.KafkaRequest, *apiErrors.ServiceError) {
+	if mock.ListByStatusFunc == nil {
+		panic("KafkaServiceMock.ListByStatusFunc: method is nil but KafkaService.ListByStatus was just called")
+	}
+	callInfo := struct {
+		Status []constants2.KafkaStatus
+	}{
+		Status: status,
+	}
+	mock.lockListByStatus.Lock()
+	mock.calls.ListByStatus = append(mock.calls.ListByStatus, callInfo)
+	mock.lockListByStatus.Unlock()
+	return mock.ListByStatusFunc(status...)
+}
+
+// ListByStatusCalls gets all the calls that were made to ListByStatus.
+// Check the length with:
+//
+//	len(mockedKafkaService.ListByStatusCalls())
+func (mock *KafkaServiceMock) ListByStatusCalls() []struct {
+	Status []constants2.KafkaStatus
+} {
+	var calls []struct {
+		Status []constants2.KafkaStatus
+	}
+	mock.lockListByStatus.RLock()
+	calls = mock.calls.ListByStatus
+	mock.lockListByStatus.RUnlock()
+	return calls
+}
+
+// ListComponentVersions calls ListComponentVersionsFunc.
+func (mock *KafkaServiceMock) ListComponentVersions() ([]KafkaComponentVersions, error) {
+	if mock.ListComponentVersionsFunc == nil {
+		panic("KafkaServiceMock.ListComponentVersionsFunc: method is nil but KafkaService.ListComponentVersions was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockListComponentVersions.Lock()
+	mock.calls.ListComponentVersions = append(mock.calls.ListComponentVersions, callInfo)
+	mock.lockListComponentVersions.Unlock()
+	return mock.ListComponentVersionsFunc()
+}
+
+// ListComponentVersionsCalls gets all the calls that were made to ListComponentVersions.
+// Check the length with:
+//
+//	len(mockedKafkaService.ListComponentVersionsCalls())
+func (mock *KafkaServiceMock) ListComponentVersionsCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockListComponentVersions.RLock()
+	calls = mock.calls.ListComponentVersions
+	mock.lockListComponentVersions.RUnlock()
+	return calls
+}
+
+// ListDuplicateBootstrapHosts calls ListDuplicateBootstrapHostsFunc.
+func (mock *KafkaServiceMock) ListDuplicateBootstrapHosts() (map[string][]string, *apiErrors.ServiceError) {
+	if mock.ListDuplicateBootstrapHostsFunc == nil {
+		panic("KafkaServiceMock.ListDuplicateBootstrapHostsFunc: method is nil but KafkaService.ListDuplicateBootstrapHosts was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockListDuplicateBootstrapHosts.Lock()
+	mock.calls.ListDuplicateBootstrapHosts = append(mock.calls.ListDuplicateBootstrapHosts, callInfo)
+	mock.lockListDuplicateBootstrapHosts.Unlock()
+	return mock.ListDuplicateBootstrapHostsFunc()
+}
+
+// ListDuplicateBootstrapHostsCalls gets all the calls that were made to ListDuplicateBootstrapHosts.
+// Check the length with:
+//
+//	len(mockedKafkaService.ListDuplicateBootstrapHostsCalls())
+func (mock *KafkaServiceMock) ListDuplicateBootstrapHostsCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockListDuplicateBootstrapHosts.RLock()
+	calls = mock.calls.ListDuplicateBootstrapHosts
+	mock.lockListDuplicateBootstrapHosts.RUnlock()
+	return calls
+}
+
+// ListKafkasMissingCanaryAccount calls ListKafkasMissingCanaryAccountFunc.
+func (mock *KafkaServiceMock) ListKafkasMissingCanaryAccount() ([]*dbapi.KafkaRequest, *apiErrors.ServiceError) {
+	if mock.ListKafkasMissingCanaryAccountFunc == nil {
+		panic("KafkaServiceMock.ListKafkasMissingCanaryAccountFunc: method is nil but KafkaService.ListKafkasMissingCanaryAccount was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockListKafkasMissingCanaryAccount.Lock()
+	mock.calls.ListKafkasMissingCanaryAccount = append(mock.calls.ListKafkasMissingCanaryAccount, callInfo)
+	mock.lockListKafkasMissingCanaryAccount.Unlock()
+	return mock.ListKafkasMissingCanaryAccountFunc()
+}
+
+// ListKafkasMissingCanaryAccountCalls gets all the calls that were made to ListKafkasMissingCanaryAccount.
+// Check the length with:
+//
+//	len(mockedKafkaService.ListKafkasMissingCanaryAccountCalls())
+func (mock *KafkaServiceMock) ListKafkasMissingCanaryAccountCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockListKafkasMissingCanaryAccount.RLock()
+	calls = mock.calls.ListKafkasMissingCanaryAccount
+	mock.lockListKafkasMissingCanaryAccount.RUnlock()
+	return calls
+}
+
+// ListKafkasWithRoutesNotCreated calls ListKafkasWithRoutesNotCreatedFunc.
+func (mock *KafkaServiceMock) ListKafkasWithRoutesNotCreated() ([]*dbapi.KafkaRequest, *apiErrors.ServiceError) {
+	if mock.ListKafkasWithRoutesNotCreatedFunc == nil {
+		panic("KafkaServiceMock.ListKafkasWithRoutesNotCreatedFunc: method is nil but KafkaService.ListKafkasWithRoutesNotCreated was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockListKafkasWithRoutesNotCreated.Lock()
+	mock.calls.ListKafkasWithRoutesNotCreated = append(mock.calls.ListKafkasWithRoutesNotCreated, callInfo)
+	mock.lockListKafkasWithRoutesNotCreated.Unlock()
+	return mock.ListKafkasWithRoutesNotCreatedFunc()
+}
+
+// ListKafkasWithRoutesNotCreatedCalls gets all the calls that were made to ListKafkasWithRoutesNotCreated.
+// Check the length with:
+//
+//	len(mockedKafkaService.ListKafkasWithRoutesNotCreatedCalls())
+func (mock *KafkaServiceMock) ListKafkasWithRoutesNotCreatedCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockListKafkasWithRoutesNotCreated.RLock()
+	calls = mock.calls.ListKafkasWithRoutesNotCreated
+	mock.lockListKafkasWithRoutesNotCreated.RUnlock()
+	return calls
+}
+
+// ListOrphanedCanaryAccounts calls ListOrphanedCanaryAccountsFunc.
+func (mock *KafkaServiceMock) ListOrphanedCanaryAccounts() ([]OrphanedCanaryAccount, *apiErrors.ServiceError) {
+	if mock.ListOrphanedCanaryAccountsFunc == nil {
+		panic("KafkaServiceMock.ListOrphanedCanaryAccountsFunc: method is nil but KafkaService.ListOrphanedCanaryAccounts was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockListOrphanedCanaryAccounts.Lock()
+	mock.calls.ListOrphanedCanaryAccounts = append(mock.calls.ListOrphanedCanaryAccounts, callInfo)
+	mock.lockListOrphanedCanaryAccounts.Unlock()
+	return mock.ListOrphanedCanaryAccountsFunc()
+}
+
+// ListOrphanedCanaryAccountsCalls gets all the calls that were made to ListOrphanedCanaryAccounts.
+// Check the length with:
+//
+//	len(mockedKafkaService.ListOrphanedCanaryAccountsCalls())
+func (mock *KafkaServiceMock) ListOrphanedCanaryAccountsCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockListOrphanedCanaryAccounts.RLock()
+	calls = mock.calls.ListOrphanedCanaryAccounts
+	mock.lockListOrphanedCanaryAccounts.RUnlock()
+	return calls
+}
+
+// ListReauthDisabled calls ListReauthDisabledFunc.
+func (mock *KafkaServiceMock) ListReauthDisabled(listArgs *services.ListArguments) (dbapi.KafkaList, *api.PagingMeta, *apiErrors.ServiceError) {
+	if mock.ListReauthDisabledFunc == nil {
+		panic("KafkaServiceMock.ListReauthDisabledFunc: method is nil but KafkaService.ListReauthDisabled was just called")
+	}
+	callInfo := struct {
+		ListArgs *services.ListArguments
+	}{
+		ListArgs: listArgs,
+	}
+	mock.lockListReauthDisabled.Lock()
+	mock.calls.ListReauthDisabled = append(mock.calls.ListReauthDisabled, callInfo)
+	mock.lockListReauthDisabled.Unlock()
+	return mock.ListReauthDisabledFunc(listArgs)
+}
+
+// ListReauthDisabledCalls gets all the calls that were made to ListReauthDisabled.
+// Check the length with:
+//
+//	len(mockedKafkaService.ListReauthDisabledCalls())
+func (mock *KafkaServiceMock) ListReauthDisabledCalls() []struct {
+	ListArgs *services.ListArguments
+} {
+	var calls []struct {
+		ListArgs *services.ListArguments
+	}
+	mock.lockListReauthDisabled.RLock()
+	calls = mock.calls.ListReauthDisabled
+	mock.lockListReauthDisabled.RUnlock()
+	return calls
+}
+
+// ListStuckDeprovisioning calls ListStuckDeprovisioningFunc.
+func (mock *KafkaServiceMock) ListStuckDeprovisioning(olderThan time.Duration) ([]*dbapi.KafkaRequest, *apiErrors.ServiceError) {
+	if mock.ListStuckDeprovisioningFunc == nil {
+		panic("KafkaServiceMock.ListStuckDeprovisioningFunc: method is nil but KafkaService.ListStuckDeprovisioning was just called")
+	}
+	callInfo := struct {
+		OlderThan time.Duration
+	}{
+		OlderThan: olderThan,
+	}
+	mock.lockListStuckDeprovisioning.Lock()
+	mock.calls.ListStuckDeprovisioning = append(mock.calls.ListStuckDeprovisioning, callInfo)
+	mock.lockListStuckDeprovisioning.Unlock()
+	return mock.ListStuckDeprovisioningFunc(olderThan)
+}
+
+// ListStuckDeprovisioningCalls gets all the calls that were made to ListStuckDeprovisioning.
+// Check the length with:
+//
+//	len(mockedKafkaService.ListStuckDeprovisioningCalls())
+func (mock *KafkaServiceMock) ListStuckDeprovisioningCalls() []struct {
+	OlderThan time.Duration
+} {
+	var calls []struct {
+		OlderThan time.Duration
+	}
+	mock.lockListStuckDeprovisioning.RLock()
+	calls = mock.calls.ListStuckDeprovisioning
+	mock.lockListStuckDeprovisioning.RUnlock()
+	return calls
+}
+
+// ListStuckUpgrades calls ListStuckUpgradesFunc.
+func (mock *KafkaServiceMock) ListStuckUpgrades(olderThan time.Duration) ([]KafkaComponentVersions, error) {
+	if mock.ListStuckUpgradesFunc == nil {
+		panic("KafkaServiceMock.ListStuckUpgradesFunc: method is nil but KafkaService.ListStuckUpgrades was just called")
+	}
+	callInfo := struct {
+		OlderThan time.Duration
+	}{
+		OlderThan: olderThan,
+	}
+	mock.lockListStuckUpgrades.Lock()
+	mock.calls.ListStuckUpgrades = append(mock.calls.ListStuckUpgrades, callInfo)
+	mock.lockListStuckUpgrades.Unlock()
+	return mock.ListStuckUpgradesFunc(olderThan)
+}
+
+// ListStuckUpgradesCalls gets all the calls that were made to ListStuckUpgrades.
+// Check the length with:
+//
+//	len(mockedKafkaService.ListStuckUpgradesCalls())
+func (mock *KafkaServiceMock) ListStuckUpgradesCalls() []struct {
+	OlderThan time.Duration
+} {
+	var calls []struct {
+		OlderThan time.Duration
+	}
+	mock.lockListStuckUpgrades.RLock()
+	calls = mock.calls.ListStuckUpgrades
+	mock.lockListStuckUpgrades.RUnlock()
+	return calls
+}
+
+// ListWithClusterDetails calls ListWithClusterDetailsFunc.
+func (mock *KafkaServiceMock) ListWithClusterDetails(ctx context.Context, listArgs *services.ListArguments, includeClusterDetails bool) ([]*KafkaWithClusterDetails, *api.PagingMeta, *apiErrors.ServiceError) {
+	if mock.ListWithClusterDetailsFunc == nil {
+		panic("KafkaServiceMock.ListWithClusterDetailsFunc: method is nil but KafkaService.ListWithClusterDetails was just called")
+	}
+	callInfo := struct {
+		Ctx                   context.Context
+		ListArgs              *services.ListArguments
+		IncludeClusterDetails bool
+	}{
+		Ctx:                   ctx,
+		ListArgs:              listArgs,
+		IncludeClusterDetails: includeClusterDetails,
+	}
+	mock.lockListWithClusterDetails.Lock()
+	mock.calls.ListWithClusterDetails = append(mock.calls.ListWithClusterDetails, callInfo)
+	mock.lockListWithClusterDetails.Unlock()
+	return mock.ListWithClusterDetailsFunc(ctx, listArgs, includeClusterDetails)
+}
+
+// ListWithClusterDetailsCalls gets all the calls that were made to ListWithClusterDetails.
+// Check the length with:
+//
+//	len(mockedKafkaService.ListWithClusterDetailsCalls())
+func (mock *KafkaServiceMock) ListWithClusterDetailsCalls() []struct {
+	Ctx                   context.Context
+	ListArgs              *services.ListArguments
+	IncludeClusterDetails bool
+} {
+	var calls []struct {
+		Ctx                   context.Context
+		ListArgs              *services.ListArguments
+		IncludeClusterDetails bool
+	}
+	mock.lockListWithClusterDetails.RLock()
+	calls = mock.calls.ListWithClusterDetails
+	mock.lockListWithClusterDetails.RUnlock()
+	return calls
+}
+
+// PauseReconciliation calls PauseReconciliationFunc.
+func (mock *KafkaServiceMock) PauseReconciliation(id string) *apiErrors.ServiceError {
+	if mock.PauseReconciliationFunc == nil {
+		panic("KafkaServiceMock.PauseReconciliationFunc: method is nil but KafkaService.PauseReconciliation was just called")
+	}
+	callInfo := struct {
+		ID string
+	}{
+		ID: id,
+	}
+	mock.lockPauseReconciliation.Lock()
+	mock.calls.PauseReconciliation = append(mock.calls.PauseReconciliation, callInfo)
+	mock.lockPauseReconciliation.Unlock()
+	return mock.PauseReconciliationFunc(id)
+}
+
+// PauseReconciliationCalls gets all the calls that were made to PauseReconciliation.
+// Check the length with:
+//
+//	len(mockedKafkaService.PauseReconciliationCalls())
+func (mock *KafkaServiceMock) PauseReconciliationCalls() []struct {
+	ID string
+} {
+	var calls []struct {
+		ID string
+	}
+	mock.lockPauseReconciliation.RLock()
+	calls = mock.calls.PauseReconciliation
+	mock.lockPauseReconciliation.RUnlock()
+	return calls
+}
+
+// PrepareKafkaRequest calls PrepareKafkaRequestFunc.
+func (mock *KafkaServiceMock) PrepareKafkaRequest(kafkaRequest *dbapi.KafkaRequest) *apiErrors.ServiceError {
+	if mock.PrepareKafkaRequestFunc == nil {
+		panic("KafkaServiceMock.PrepareKafkaRequestFunc: method is nil but KafkaService.PrepareKafkaRequest was just called")
+	}
+	callInfo := struct {
+		KafkaRequest *dbapi.KafkaRequest
+	}{
+		KafkaRequest: kafkaRequest,
+	}
+	mock.lockPrepareKafkaRequest.Lock()
+	mock.calls.PrepareKafkaRequest = append(mock.calls.PrepareKafkaRequest, callInfo)
+	mock.lockPrepareKafkaRequest.Unlock()
+	return mock.PrepareKafkaRequestFunc(kafkaRequest)
+}
+
+// PrepareKafkaRequestCalls gets all the calls that were made to PrepareKafkaRequest.
+// Check the length with:
+//
+//	len(mockedKafkaService.PrepareKafkaRequestCalls())
+func (mock *KafkaServiceMock) PrepareKafkaRequestCalls() []struct {
+	KafkaRequest *dbapi.KafkaRequest
+} {
+	var calls []struct {
+		KafkaRequest *dbapi.KafkaRequest
+	}
+	mock.lockPrepareKafkaRequest.RLock()
+	calls = mock.calls.PrepareKafkaRequest
+	mock.lockPrepareKafkaRequest.RUnlock()
+	return calls
+}
+
+// PromoteExpiredDeprovisionPendingKafkas calls PromoteExpiredDeprovisionPendingKafkasFunc.
+func (mock *KafkaServiceMock) PromoteExpiredDeprovisionPendingKafkas() (int64, *apiErrors.ServiceError) {
+	if mock.PromoteExpiredDeprovisionPendingKafkasFunc == nil {
+		panic("KafkaServiceMock.PromoteExpiredDeprovisionPendingKafkasFunc: method is nil but KafkaService.PromoteExpiredDeprovisionPendingKafkas was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockPromoteExpiredDeprovisionPendingKafkas.Lock()
+	mock.calls.PromoteExpiredDeprovisionPendingKafkas = append(mock.calls.PromoteExpiredDeprovisionPendingKafkas, callInfo)
+	mock.lockPromoteExpiredDeprovisionPendingKafkas.Unlock()
+	return mock.PromoteExpiredDeprovisionPendingKafkasFunc()
+}
+
+// PromoteExpiredDeprovisionPendingKafkasCalls gets all the calls that were made to PromoteExpiredDeprovisionPendingKafkas.
+// Check the length with:
+//
+//	len(mockedKafkaService.PromoteExpiredDeprovisionPendingKafkasCalls())
+func (mock *KafkaServiceMock) PromoteExpiredDeprovisionPendingKafkasCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockPromoteExpiredDeprovisionPendingKafkas.RLock()
+	calls = mock.calls.PromoteExpiredDeprovisionPendingKafkas
+	mock.lockPromoteExpiredDeprovisionPendingKafkas.RUnlock()
+	return calls
+}
+
+// RecomputeRegionCapacityUsage calls RecomputeRegionCapacityUsageFunc.
+func (mock *KafkaServiceMock) RecomputeRegionCapacityUsage() *apiErrors.ServiceError {
+	if mock.RecomputeRegionCapacityUsageFunc == nil {
+		panic("KafkaServiceMock.RecomputeRegionCapacityUsageFunc: method is nil but KafkaService.RecomputeRegionCapacityUsage was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockRecomputeRegionCapacityUsage.Lock()
+	mock.calls.RecomputeRegionCapacityUsage = append(mock.calls.RecomputeRegionCapacityUsage, callInfo)
+	mock.lockRecomputeRegionCapacityUsage.Unlock()
+	return mock.RecomputeRegionCapacityUsageFunc()
+}
+
+// RecomputeRegionCapacityUsageCalls gets all the calls that were made to RecomputeRegionCapacityUsage.
+// Check the length with:
+//
+//	len(mockedKafkaService.RecomputeRegionCapacityUsageCalls())
+func (mock *KafkaServiceMock) RecomputeRegionCapacityUsageCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockRecomputeRegionCapacityUsage.RLock()
+	calls = mock.calls.RecomputeRegionCapacityUsage
+	mock.lockRecomputeRegionCapacityUsage.RUnlock()
+	return calls
+}
+
+// RecreateRoutes calls RecreateRoutesFunc.
+func (mock *KafkaServiceMock) RecreateRoutes(id string) *apiErrors.ServiceError {
+	if mock.RecreateRoutesFunc == nil {
+		panic("KafkaServiceMock.RecreateRoutesFunc: method is nil but KafkaService.RecreateRoutes was just called")
+	}
+	callInfo := struct {
+		ID string
+	}{
+		ID: id,
+	}
+	mock.lockRecreateRoutes.Lock()
+	mock.calls.RecreateRoutes = append(mock.calls.RecreateRoutes, callInfo)
+	mock.lockRecreateRoutes.Unlock()
+	return mock.RecreateRoutesFunc(id)
+}
+
+// RecreateRoutesCalls gets all the calls that were made to RecreateRoutes.
+// Check the length with:
+//
+//	len(mockedKafkaService.RecreateRoutesCalls())
+func (mock *KafkaServiceMock) RecreateRoutesCalls() []struct {
+	ID string
+} {
+	var calls []struct {
+		ID string
+	}
+	mock.lockRecreateRoutes.RLock()
+	calls = mock.calls.RecreateRoutes
+	mock.lockRecreateRoutes.RUnlock()
+	return calls
+}
+
+// RegisterKafkaDeprovisionJob calls RegisterKafkaDeprovisionJobFunc.
+func (mock *KafkaServiceMock) RegisterKafkaDeprovisionJob(ctx context.Context, id string) *apiErrors.ServiceError {
+	if mock.RegisterKafkaDeprovisionJobFunc == nil {
+		panic("KafkaServiceMock.RegisterKafkaDeprovisionJobFunc: method is nil but KafkaService.RegisterKafkaDeprovisionJob was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  string
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	mock.lockRegisterKafkaDeprovisionJob.Lock()
+	mock.calls.RegisterKafkaDeprovisionJob = append(mock.calls.RegisterKafkaDeprovisionJob, callInfo)
+	mock.lockRegisterKafkaDeprovisionJob.Unlock()
+	return mock.RegisterKafkaDeprovisionJobFunc(ctx, id)
+}
+
+// RegisterKafkaDeprovisionJobCalls gets all the calls that were made to RegisterKafkaDeprovisionJob.
+// Check the length with:
+//
+//	len(mockedKafkaService.RegisterKafkaDeprovisionJobCalls())
+func (mock *KafkaServiceMock) RegisterKafkaDeprovisionJobCalls() []struct {
+	Ctx context.Context
+	ID  string
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  string
+	}
+	mock.lockRegisterKafkaDeprovisionJob.RLock()
+	calls = mock.calls.RegisterKafkaDeprovisionJob
+	mock.lockRegisterKafkaDeprovisionJob.RUnlock()
+	return calls
+}
+
+// RegisterKafkaJob calls RegisterKafkaJobFunc.
+func (mock *KafkaServiceMock) RegisterKafkaJob(kafkaRequest *dbapi.KafkaRequest) *apiErrors.ServiceError {
+	if mock.RegisterKafkaJobFunc == nil {
+		panic("KafkaServiceMock.RegisterKafkaJobFunc: method is nil but KafkaService.RegisterKafkaJob was just called")
+	}
+	callInfo := struct {
+		KafkaRequest *dbapi.KafkaRequest
+	}{
+		KafkaRequest: kafkaRequest,
+	}
+	mock.lockRegisterKafkaJob.Lock()
+	mock.calls.RegisterKafkaJob = append(mock.calls.RegisterKafkaJob, callInfo)
+	mock.lockRegisterKafkaJob.Unlock()
+	return mock.RegisterKafkaJobFunc(kafkaRequest)
+}
+
+// RegisterKafkaJobCalls gets all the calls that were made to RegisterKafkaJob.
+// Check the length with:
+//
+//	len(mockedKafkaService.RegisterKafkaJobCalls())
+func (mock *KafkaServiceMock) RegisterKafkaJobCalls() []struct {
+	KafkaRequest *dbapi.KafkaRequest
+} {
+	var calls []struct {
+		KafkaRequest *dbapi.KafkaRequest
+	}
+	mock.lockRegisterKafkaJob.RLock()
+	calls = mock.calls.RegisterKafkaJob
+	mock.lockRegisterKafkaJob.RUnlock()
+	return calls
+}
+
+// RegisterKafkaJobWithDeferredQuota calls RegisterKafkaJobWithDeferredQuotaFunc.
+func (mock *KafkaServiceMock) RegisterKafkaJobWithDeferredQuota(kafkaRequest *dbapi.KafkaRequest) *apiErrors.ServiceError {
+	if mock.RegisterKafkaJobWithDeferredQuotaFunc == nil {
+		panic("KafkaServiceMock.RegisterKafkaJobWithDeferredQuotaFunc: method is nil but KafkaService.RegisterKafkaJobWithDeferredQuota was just called")
+	}
+	callInfo := struct {
+		KafkaRequest *dbapi.KafkaRequest
+	}{
+		KafkaRequest: kafkaRequest,
+	}
+	mock.lockRegisterKafkaJobWithDeferredQuota.Lock()
+	mock.calls.RegisterKafkaJobWithDeferredQuota = append(mock.calls.RegisterKafkaJobWithDeferredQuota, callInfo)
+	mock.lockRegisterKafkaJobWithDeferredQuota.Unlock()
+	return mock.RegisterKafkaJobWithDeferredQuotaFunc(kafkaRequest)
+}
+
+// RegisterKafkaJobWithDeferredQuotaCalls gets all the calls that were made to RegisterKafkaJobWithDeferredQuota.
+// Check the length with:
+//
+//	len(mockedKafkaService.RegisterKafkaJobWithDeferredQuotaCalls())
+func (mock *KafkaServiceMock) RegisterKafkaJobWithDeferredQuotaCalls() []struct {
+	KafkaRequest *dbapi.KafkaRequest
+} {
+	var calls []struct {
+		KafkaRequest *dbapi.KafkaRequest
+	}
+	mock.lockRegisterKafkaJobWithDeferredQuota.RLock()
+	calls = mock.calls.RegisterKafkaJobWithDeferredQuota
+	mock.lockRegisterKafkaJobWithDeferredQuota.RUnlock()
+	return calls
+}
+
+// RepairCanaryAccounts calls RepairCanaryAccountsFunc.
+func (mock *KafkaServiceMock) RepairCanaryAccounts(dryRun bool) ([]string, *apiErrors.ServiceError) {
+	if mock.RepairCanaryAccountsFunc == nil {
+		panic("KafkaServiceMock.RepairCanaryAccountsFunc: method is nil but KafkaService.RepairCanaryAccounts was just called")
+	}
+	callInfo := struct {
+		DryRun bool
+	}{
+		DryRun: dryRun,
+	}
+	mock.lockRepairCanaryAccounts.Lock()
+	mock.calls.RepairCanaryAccounts = append(mock.calls.RepairCanaryAccounts, callInfo)
+	mock.lockRepairCanaryAccounts.Unlock()
+	return mock.RepairCanaryAccountsFunc(dryRun)
+}
+
+// RepairCanaryAccountsCalls gets all the calls that were made to RepairCanaryAccounts.
+// Check the length with:
+//
+//	len(mockedKafkaService.RepairCanaryAccountsCalls())
+func (mock *KafkaServiceMock) RepairCanaryAccountsCalls() []struct {
+	DryRun bool
+} {
+	var calls []struct {
+		DryRun bool
 	}
-	mock.lockGetAvailableSizesInRegion.RLock()
-	calls = mock.calls.GetAvailableSizesInRegion
-	mock.lockGetAvailableSizesInRegion.RUnlock()
+	mock.lockRepairCanaryAccounts.RLock()
+	calls = mock.calls.RepairCanaryAccounts
+	mock.lockRepairCanaryAccounts.RUnlock()
 	return calls
 }
 
-// GetById calls GetByIdFunc.
-func (mock *KafkaServiceMock) GetById(id string) (*dbapi.KafkaRequest, *apiErrors.ServiceError) {
-	if mock.GetByIdFunc == nil {
-		panic("KafkaServiceMock.GetByIdFunc: method is nil but KafkaService.GetById was just called")
+// RepairMissingNamespaces calls RepairMissingNamespacesFunc.
+func (mock *KafkaServiceMock) RepairMissingNamespaces() (int64, *apiErrors.ServiceError) {
+	if mock.RepairMissingNamespacesFunc == nil {
+		panic("KafkaServiceMock.RepairMissingNamespacesFunc: method is nil but KafkaService.RepairMissingNamespaces was just called")
 	}
 	callInfo := struct {
-		ID string
-	}{
-		ID: id,
-	}
-	mock.lockGetById.Lock()
-	mock.calls.GetById = append(mock.calls.GetById, callInfo)
-	mock.lockGetById.Unlock()
-	return mock.GetByIdFunc(id)
+	}{}
+	mock.lockRepairMissingNamespaces.Lock()
+	mock.calls.RepairMissingNamespaces = append(mock.calls.RepairMissingNamespaces, callInfo)
+	mock.lockRepairMissingNamespaces.Unlock()
+	return mock.RepairMissingNamespacesFunc()
 }
 
-// GetByIdCalls gets all the calls that were made to GetById.
+// RepairMissingNamespacesCalls gets all the calls that were made to RepairMissingNamespaces.
 // Check the length with:
 //
-//	len(mockedKafkaService.GetByIdCalls())
-func (mock *KafkaServiceMock) GetByIdCalls() []struct {
-	ID string
+//	len(mockedKafkaService.RepairMissingNamespacesCalls())
+func (mock *KafkaServiceMock) RepairMissingNamespacesCalls() []struct {
 } {
 	var calls []struct {
-		ID string
 	}
-	mock.lockGetById.RLock()
-	calls = mock.calls.GetById
-	mock.lockGetById.RUnlock()
+	mock.lockRepairMissingNamespaces.RLock()
+	calls = mock.calls.RepairMissingNamespaces
+	mock.lockRepairMissingNamespaces.RUnlock()
 	return calls
 }
 
-// GetCNAMERecordStatus calls GetCNAMERecordStatusFunc.
-func (mock *KafkaServiceMock) GetCNAMERecordStatus(kafkaRequest *dbapi.KafkaRequest) (*CNameRecordStatus, error) {
-	if mock.GetCNAMERecordStatusFunc == nil {
-		panic("KafkaServiceMock.GetCNAMERecordStatusFunc: method is nil but KafkaService.GetCNAMERecordStatus was just called")
+// RepairMultiAZ calls RepairMultiAZFunc.
+func (mock *KafkaServiceMock) RepairMultiAZ() (int64, *apiErrors.ServiceError) {
+	if mock.RepairMultiAZFunc == nil {
+		panic("KafkaServiceMock.RepairMultiAZFunc: method is nil but KafkaService.RepairMultiAZ was just called")
 	}
 	callInfo := struct {
-		KafkaRequest *dbapi.KafkaRequest
-	}{
-		KafkaRequest: kafkaRequest,
-	}
-	mock.lockGetCNAMERecordStatus.Lock()
-	mock.calls.GetCNAMERecordStatus = append(mock.calls.GetCNAMERecordStatus, callInfo)
-	mock.lockGetCNAMERecordStatus.Unlock()
-	return mock.GetCNAMERecordStatusFunc(kafkaRequest)
+	}{}
+	mock.lockRepairMultiAZ.Lock()
+	mock.calls.RepairMultiAZ = append(mock.calls.RepairMultiAZ, callInfo)
+	mock.lockRepairMultiAZ.Unlock()
+	return mock.RepairMultiAZFunc()
 }
 
-// GetCNAMERecordStatusCalls gets all the calls that were made to GetCNAMERecordStatus.
+// RepairMultiAZCalls gets all the calls that were made to RepairMultiAZ.
 // Check the length with:
 //
-//	len(mockedKafkaService.GetCNAMERecordStatusCalls())
-func (mock *KafkaServiceMock) GetCNAMERecordStatusCalls() []struct {
-	KafkaRequest *dbapi.KafkaRequest
+//	len(mockedKafkaService.RepairMultiAZCalls())
+func (mock *KafkaServiceMock) RepairMultiAZCalls() []struct {
 } {
 	var calls []struct {
-		KafkaRequest *dbapi.KafkaRequest
 	}
-	mock.lockGetCNAMERecordStatus.RLock()
-	calls = mock.calls.GetCNAMERecordStatus
-	mock.lockGetCNAMERecordStatus.RUnlock()
+	mock.lockRepairMultiAZ.RLock()
+	calls = mock.calls.RepairMultiAZ
+	mock.lockRepairMultiAZ.RUnlock()
 	return calls
 }
 
-// GetManagedKafkaByClusterID calls GetManagedKafkaByClusterIDFunc.
-func (mock *KafkaServiceMock) GetManagedKafkaByClusterID(clusterID string) ([]managedkafka.ManagedKafka, *apiErrors.ServiceError) {
-	if mock.GetManagedKafkaByClusterIDFunc == nil {
-		panic("KafkaServiceMock.GetManagedKafkaByClusterIDFunc: method is nil but KafkaService.GetManagedKafkaByClusterID was just called")
+// ResumeReconciliation calls ResumeReconciliationFunc.
+func (mock *KafkaServiceMock) ResumeReconciliation(id string) *apiErrors.ServiceError {
+	if mock.ResumeReconciliationFunc == nil {
+		panic("KafkaServiceMock.ResumeReconciliationFunc: method is nil but KafkaService.ResumeReconciliation was just called")
 	}
 	callInfo := struct {
-		ClusterID string
+		ID string
 	}{
-		ClusterID: clusterID,
+		ID: id,
 	}
-	mock.lockGetManagedKafkaByClusterID.Lock()
-	mock.calls.GetManagedKafkaByClusterID = append(mock.calls.GetManagedKafkaByClusterID, callInfo)
-	mock.lockGetManagedKafkaByClusterID.Unlock()
-	return mock.GetManagedKafkaByClusterIDFunc(clusterID)
+	mock.lockResumeReconciliation.Lock()
+	mock.calls.ResumeReconciliation = append(mock.calls.ResumeReconciliation, callInfo)
+	mock.lockResumeReconciliation.Unlock()
+	return mock.ResumeReconciliationFunc(id)
 }
 
-// GetManagedKafkaByClusterIDCalls gets all the calls that were made to GetManagedKafkaByClusterID.
+// ResumeReconciliationCalls gets all the calls that were made to ResumeReconciliation.
 // Check the length with:
 //
-//	len(mockedKafkaService.GetManagedKafkaByClusterIDCalls())
-func (mock *KafkaServiceMock) GetManagedKafkaByClusterIDCalls() []struct {
-	ClusterID string
+//	len(mockedKafkaService.ResumeReconciliationCalls())
+func (mock *KafkaServiceMock) ResumeReconciliationCalls() []struct {
+	ID string
 } {
 	var calls []struct {
-		ClusterID string
+		ID string
 	}
-	mock.lockGetManagedKafkaByClusterID.RLock()
-	calls = mock.calls.GetManagedKafkaByClusterID
-	mock.lockGetManagedKafkaByClusterID.RUnlock()
+	mock.lockResumeReconciliation.RLock()
+	calls = mock.calls.ResumeReconciliation
+	mock.lockResumeReconciliation.RUnlock()
 	return calls
 }
 
-// HasAvailableCapacityInRegion calls HasAvailableCapacityInRegionFunc.
-func (mock *KafkaServiceMock) HasAvailableCapacityInRegion(kafkaRequest *dbapi.KafkaRequest) (bool, *apiErrors.ServiceError) {
-	if mock.HasAvailableCapacityInRegionFunc == nil {
-		panic("KafkaServiceMock.HasAvailableCapacityInRegionFunc: method is nil but KafkaService.HasAvailableCapacityInRegion was just called")
+// RetryFailed calls RetryFailedFunc.
+func (mock *KafkaServiceMock) RetryFailed(id string) *apiErrors.ServiceError {
+	if mock.RetryFailedFunc == nil {
+		panic("KafkaServiceMock.RetryFailedFunc: method is nil but KafkaService.RetryFailed was just called")
 	}
 	callInfo := struct {
-		KafkaRequest *dbapi.KafkaRequest
+		ID string
 	}{
-		KafkaRequest: kafkaRequest,
+		ID: id,
 	}
-	mock.lockHasAvailableCapacityInRegion.Lock()
-	mock.calls.HasAvailableCapacityInRegion = append(mock.calls.HasAvailableCapacityInRegion, callInfo)
-	mock.lockHasAvailableCapacityInRegion.Unlock()
-	return mock.HasAvailableCapacityInRegionFunc(kafkaRequest)
+	mock.lockRetryFailed.Lock()
+	mock.calls.RetryFailed = append(mock.calls.RetryFailed, callInfo)
+	mock.lockRetryFailed.Unlock()
+	return mock.RetryFailedFunc(id)
 }
 
-// HasAvailableCapacityInRegionCalls gets all the calls that were made to HasAvailableCapacityInRegion.
+// RetryFailedCalls gets all the calls that were made to RetryFailed.
 // Check the length with:
 //
-//	len(mockedKafkaService.HasAvailableCapacityInRegionCalls())
-func (mock *KafkaServiceMock) HasAvailableCapacityInRegionCalls() []struct {
-	KafkaRequest *dbapi.KafkaRequest
+//	len(mockedKafkaService.RetryFailedCalls())
+func (mock *KafkaServiceMock) RetryFailedCalls() []struct {
+	ID string
 } {
 	var calls []struct {
-		KafkaRequest *dbapi.KafkaRequest
+		ID string
 	}
-	mock.lockHasAvailableCapacityInRegion.RLock()
-	calls = mock.calls.HasAvailableCapacityInRegion
-	mock.lockHasAvailableCapacityInRegion.RUnlock()
+	mock.lockRetryFailed.RLock()
+	calls = mock.calls.RetryFailed
+	mock.lockRetryFailed.RUnlock()
 	return calls
 }
 
-// List calls ListFunc.
-func (mock *KafkaServiceMock) List(ctx context.Context, listArgs *services.ListArguments) (dbapi.KafkaList, *api.PagingMeta, *apiErrors.ServiceError) {
-	if mock.ListFunc == nil {
-		panic("KafkaServiceMock.ListFunc: method is nil but KafkaService.List was just called")
+// SetAnnotations calls SetAnnotationsFunc.
+func (mock *KafkaServiceMock) SetAnnotations(id string, annotations map[string]string) *apiErrors.ServiceError {
+	if mock.SetAnnotationsFunc == nil {
+		panic("KafkaServiceMock.SetAnnotationsFunc: method is nil but KafkaService.SetAnnotations was just called")
 	}
 	callInfo := struct {
-		Ctx      context.Context
-		ListArgs *services.ListArguments
+		ID          string
+		Annotations map[string]string
 	}{
-		Ctx:      ctx,
-		ListArgs: listArgs,
+		ID:          id,
+		Annotations: annotations,
 	}
-	mock.lockList.Lock()
-	mock.calls.List = append(mock.calls.List, callInfo)
-	mock.lockList.Unlock()
-	return mock.ListFunc(ctx, listArgs)
+	mock.lockSetAnnotations.Lock()
+	mock.calls.SetAnnotations = append(mock.calls.SetAnnotations, callInfo)
+	mock.lockSetAnnotations.Unlock()
+	return mock.SetAnnotationsFunc(id, annotations)
 }
 
-// ListCalls gets all the calls that were made to List.
+// SetAnnotationsCalls gets all the calls that were made to SetAnnotations.
 // Check the length with:
 //
-//	len(mockedKafkaService.ListCalls())
-func (mock *KafkaServiceMock) ListCalls() []struct {
-	Ctx      context.Context
-	ListArgs *services.ListArguments
+//	len(mockedKafkaService.SetAnnotationsCalls())
+func (mock *KafkaServiceMock) SetAnnotationsCalls() []struct {
+	ID          string
+	Annotations map[string]string
 } {
 	var calls []struct {
-		Ctx      context.Context
-		ListArgs *services.ListArguments
+		ID          string
+		Annotations map[string]string
 	}
-	mock.lockList.RLock()
-	calls = mock.calls.List
-	mock.lockList.RUnlock()
+	mock.lockSetAnnotations.RLock()
+	calls = mock.calls.SetAnnotations
+	mock.lockSetAnnotations.RUnlock()
 	return calls
 }
 
-// ListByStatus calls ListByStatusFunc.
-func (mock *KafkaServiceMock) ListByStatus(status ...constants2.KafkaStatus) ([]*dbapi.KafkaRequest, *apiErrors.ServiceError) {
-	if mock.ListByStatusFunc == nil {
-		panic("KafkaServiceMock.ListByStatusFunc: method is nil but KafkaService.ListByStatus was just called")
+// SetCapacityConsumedOverride calls SetCapacityConsumedOverrideFunc.
+func (mock *KafkaServiceMock) SetCapacityConsumedOverride(id string, override *int) *apiErrors.ServiceError {
+	if mock.SetCapacityConsumedOverrideFunc == nil {
+		panic("KafkaServiceMock.SetCapacityConsumedOverrideFunc: method is nil but KafkaService.SetCapacityConsumedOverride was just called")
 	}
 	callInfo := struct {
-		Status []constants2.KafkaStatus
+		ID       string
+		Override *int
 	}{
-		Status: status,
+		ID:       id,
+		Override: override,
 	}
-	mock.lockListByStatus.Lock()
-	mock.calls.ListByStatus = append(mock.calls.ListByStatus, callInfo)
-	mock.lockListByStatus.Unlock()
-	return mock.ListByStatusFunc(status...)
+	mock.lockSetCapacityConsumedOverride.Lock()
+	mock.calls.SetCapacityConsumedOverride = append(mock.calls.SetCapacityConsumedOverride, callInfo)
+	mock.lockSetCapacityConsumedOverride.Unlock()
+	return mock.SetCapacityConsumedOverrideFunc(id, override)
 }
 
-// ListByStatusCalls gets all the calls that were made to ListByStatus.
+// SetCapacityConsumedOverrideCalls gets all the calls that were made to SetCapacityConsumedOverride.
 // Check the length with:
 //
-//	len(mockedKafkaService.ListByStatusCalls())
-func (mock *KafkaServiceMock) ListByStatusCalls() []struct {
-	Status []constants2.KafkaStatus
+//	len(mockedKafkaService.SetCapacityConsumedOverrideCalls())
+func (mock *KafkaServiceMock) SetCapacityConsumedOverrideCalls() []struct {
+	ID       string
+	Override *int
 } {
 	var calls []struct {
-		Status []constants2.KafkaStatus
+		ID       string
+		Override *int
 	}
-	mock.lockListByStatus.RLock()
-	calls = mock.calls.ListByStatus
-	mock.lockListByStatus.RUnlock()
+	mock.lockSetCapacityConsumedOverride.RLock()
+	calls = mock.calls.SetCapacityConsumedOverride
+	mock.lockSetCapacityConsumedOverride.RUnlock()
 	return calls
 }
 
-// ListComponentVersions calls ListComponentVersionsFunc.
-func (mock *KafkaServiceMock) ListComponentVersions() ([]KafkaComponentVersions, error) {
-	if mock.ListComponentVersionsFunc == nil {
-		panic("KafkaServiceMock.ListComponentVersionsFunc: method is nil but KafkaService.ListComponentVersions was just called")
+// SetKafkaStorageSize calls SetKafkaStorageSizeFunc.
+func (mock *KafkaServiceMock) SetKafkaStorageSize(id string, size string) *apiErrors.ServiceError {
+	if mock.SetKafkaStorageSizeFunc == nil {
+		panic("KafkaServiceMock.SetKafkaStorageSizeFunc: method is nil but KafkaService.SetKafkaStorageSize was just called")
 	}
 	callInfo := struct {
-	}{}
-	mock.lockListComponentVersions.Lock()
-	mock.calls.ListComponentVersions = append(mock.calls.ListComponentVersions, callInfo)
-	mock.lockListComponentVersions.Unlock()
-	return mock.ListComponentVersionsFunc()
+		ID   string
+		Size string
+	}{
+		ID:   id,
+		Size: size,
+	}
+	mock.lockSetKafkaStorageSize.Lock()
+	mock.calls.SetKafkaStorageSize = append(mock.calls.SetKafkaStorageSize, callInfo)
+	mock.lockSetKafkaStorageSize.Unlock()
+	return mock.SetKafkaStorageSizeFunc(id, size)
 }
 
-// ListComponentVersionsCalls gets all the calls that were made to ListComponentVersions.
+// SetKafkaStorageSizeCalls gets all the calls that were made to SetKafkaStorageSize.
 // Check the length with:
 //
-//	len(mockedKafkaService.ListComponentVersionsCalls())
-func (mock *KafkaServiceMock) ListComponentVersionsCalls() []struct {
+//	len(mockedKafkaService.SetKafkaStorageSizeCalls())
+func (mock *KafkaServiceMock) SetKafkaStorageSizeCalls() []struct {
+	ID   string
+	Size string
 } {
 	var calls []struct {
+		ID   string
+		Size string
 	}
-	mock.lockListComponentVersions.RLock()
-	calls = mock.calls.ListComponentVersions
-	mock.lockListComponentVersions.RUnlock()
+	mock.lockSetKafkaStorageSize.RLock()
+	calls = mock.calls.SetKafkaStorageSize
+	mock.lockSetKafkaStorageSize.RUnlock()
 	return calls
 }
 
-// ListKafkasWithRoutesNotCreated calls ListKafkasWithRoutesNotCreatedFunc.
-func (mock *KafkaServiceMock) ListKafkasWithRoutesNotCreated() ([]*dbapi.KafkaRequest, *apiErrors.ServiceError) {
-	if mock.ListKafkasWithRoutesNotCreatedFunc == nil {
-		panic("KafkaServiceMock.ListKafkasWithRoutesNotCreatedFunc: method is nil but KafkaService.ListKafkasWithRoutesNotCreated was just called")
+// SetMaintenanceWindow calls SetMaintenanceWindowFunc.
+func (mock *KafkaServiceMock) SetMaintenanceWindow(id string, day string, start string, end string) *apiErrors.ServiceError {
+	if mock.SetMaintenanceWindowFunc == nil {
+		panic("KafkaServiceMock.SetMaintenanceWindowFunc: method is nil but KafkaService.SetMaintenanceWindow was just called")
 	}
 	callInfo := struct {
-	}{}
-	mock.lockListKafkasWithRoutesNotCreated.Lock()
-	mock.calls.ListKafkasWithRoutesNotCreated = append(mock.calls.ListKafkasWithRoutesNotCreated, callInfo)
-	mock.lockListKafkasWithRoutesNotCreated.Unlock()
-	return mock.ListKafkasWithRoutesNotCreatedFunc()
+		ID    string
+		Day   string
+		Start string
+		End   string
+	}{
+		ID:    id,
+		Day:   day,
+		Start: start,
+		End:   end,
+	}
+	mock.lockSetMaintenanceWindow.Lock()
+	mock.calls.SetMaintenanceWindow = append(mock.calls.SetMaintenanceWindow, callInfo)
+	mock.lockSetMaintenanceWindow.Unlock()
+	return mock.SetMaintenanceWindowFunc(id, day, start, end)
 }
 
-// ListKafkasWithRoutesNotCreatedCalls gets all the calls that were made to ListKafkasWithRoutesNotCreated.
+// SetMaintenanceWindowCalls gets all the calls that were made to SetMaintenanceWindow.
 // Check the length with:
 //
-//	len(mockedKafkaService.ListKafkasWithRoutesNotCreatedCalls())
-func (mock *KafkaServiceMock) ListKafkasWithRoutesNotCreatedCalls() []struct {
+//	len(mockedKafkaService.SetMaintenanceWindowCalls())
+func (mock *KafkaServiceMock) SetMaintenanceWindowCalls() []struct {
+	ID    string
+	Day   string
+	Start string
+	End   string
 } {
 	var calls []struct {
+		ID    string
+		Day   string
+		Start string
+		End   string
 	}
-	mock.lockListKafkasWithRoutesNotCreated.RLock()
-	calls = mock.calls.ListKafkasWithRoutesNotCreated
-	mock.lockListKafkasWithRoutesNotCreated.RUnlock()
+	mock.lockSetMaintenanceWindow.RLock()
+	calls = mock.calls.SetMaintenanceWindow
+	mock.lockSetMaintenanceWindow.RUnlock()
 	return calls
 }
 
-// PrepareKafkaRequest calls PrepareKafkaRequestFunc.
-func (mock *KafkaServiceMock) PrepareKafkaRequest(kafkaRequest *dbapi.KafkaRequest) *apiErrors.ServiceError {
-	if mock.PrepareKafkaRequestFunc == nil {
-		panic("KafkaServiceMock.PrepareKafkaRequestFunc: method is nil but KafkaService.PrepareKafkaRequest was just called")
+// SetMaxConnectionAttemptsPerSecOverride calls SetMaxConnectionAttemptsPerSecOverrideFunc.
+func (mock *KafkaServiceMock) SetMaxConnectionAttemptsPerSecOverride(id string, override *int) *apiErrors.ServiceError {
+	if mock.SetMaxConnectionAttemptsPerSecOverrideFunc == nil {
+		panic("KafkaServiceMock.SetMaxConnectionAttemptsPerSecOverrideFunc: method is nil but KafkaService.SetMaxConnectionAttemptsPerSecOverride was just called")
 	}
 	callInfo := struct {
-		KafkaRequest *dbapi.KafkaRequest
+		ID       string
+		Override *int
 	}{
-		KafkaRequest: kafkaRequest,
+		ID:       id,
+		Override: override,
 	}
-	mock.lockPrepareKafkaRequest.Lock()
-	mock.calls.PrepareKafkaRequest = append(mock.calls.PrepareKafkaRequest, callInfo)
-	mock.lockPrepareKafkaRequest.Unlock()
-	return mock.PrepareKafkaRequestFunc(kafkaRequest)
+	mock.lockSetMaxConnectionAttemptsPerSecOverride.Lock()
+	mock.calls.SetMaxConnectionAttemptsPerSecOverride = append(mock.calls.SetMaxConnectionAttemptsPerSecOverride, callInfo)
+	mock.lockSetMaxConnectionAttemptsPerSecOverride.Unlock()
+	return mock.SetMaxConnectionAttemptsPerSecOverrideFunc(id, override)
 }
 
-// PrepareKafkaRequestCalls gets all the calls that were made to PrepareKafkaRequest.
+// SetMaxConnectionAttemptsPerSecOverrideCalls gets all the calls that were made to SetMaxConnectionAttemptsPerSecOverride.
 // Check the length with:
 //
-//	len(mockedKafkaService.PrepareKafkaRequestCalls())
-func (mock *KafkaServiceMock) PrepareKafkaRequestCalls() []struct {
-	KafkaRequest *dbapi.KafkaRequest
+//	len(mockedKafkaService.SetMaxConnectionAttemptsPerSecOverrideCalls())
+func (mock *KafkaServiceMock) SetMaxConnectionAttemptsPerSecOverrideCalls() []struct {
+	ID       string
+	Override *int
 } {
 	var calls []struct {
-		KafkaRequest *dbapi.KafkaRequest
+		ID       string
+		Override *int
 	}
-	mock.lockPrepareKafkaRequest.RLock()
-	calls = mock.calls.PrepareKafkaRequest
-	mock.lockPrepareKafkaRequest.RUnlock()
+	mock.lockSetMaxConnectionAttemptsPerSecOverride.RLock()
+	calls = mock.calls.SetMaxConnectionAttemptsPerSecOverride
+	mock.lockSetMaxConnectionAttemptsPerSecOverride.RUnlock()
 	return calls
 }
 
-// RegisterKafkaDeprovisionJob calls RegisterKafkaDeprovisionJobFunc.
-func (mock *KafkaServiceMock) RegisterKafkaDeprovisionJob(ctx context.Context, id string) *apiErrors.ServiceError {
-	if mock.RegisterKafkaDeprovisionJobFunc == nil {
-		panic("KafkaServiceMock.RegisterKafkaDeprovisionJobFunc: method is nil but KafkaService.RegisterKafkaDeprovisionJob was just called")
+// SetReauthenticationBulk calls SetReauthenticationBulkFunc.
+func (mock *KafkaServiceMock) SetReauthenticationBulk(ids []string, enabled bool) (int64, *apiErrors.ServiceError) {
+	if mock.SetReauthenticationBulkFunc == nil {
+		panic("KafkaServiceMock.SetReauthenticationBulkFunc: method is nil but KafkaService.SetReauthenticationBulk was just called")
 	}
 	callInfo := struct {
-		Ctx context.Context
-		ID  string
+		Ids     []string
+		Enabled bool
 	}{
-		Ctx: ctx,
-		ID:  id,
+		Ids:     ids,
+		Enabled: enabled,
 	}
-	mock.lockRegisterKafkaDeprovisionJob.Lock()
-	mock.calls.RegisterKafkaDeprovisionJob = append(mock.calls.RegisterKafkaDeprovisionJob, callInfo)
-	mock.lockRegisterKafkaDeprovisionJob.Unlock()
-	return mock.RegisterKafkaDeprovisionJobFunc(ctx, id)
+	mock.lockSetReauthenticationBulk.Lock()
+	mock.calls.SetReauthenticationBulk = append(mock.calls.SetReauthenticationBulk, callInfo)
+	mock.lockSetReauthenticationBulk.Unlock()
+	return mock.SetReauthenticationBulkFunc(ids, enabled)
 }
 
-// RegisterKafkaDeprovisionJobCalls gets all the calls that were made to RegisterKafkaDeprovisionJob.
+// SetReauthenticationBulkCalls gets all the calls that were made to SetReauthenticationBulk.
 // Check the length with:
 //
-//	len(mockedKafkaService.RegisterKafkaDeprovisionJobCalls())
-func (mock *KafkaServiceMock) RegisterKafkaDeprovisionJobCalls() []struct {
-	Ctx context.Context
-	ID  string
+//	len(mockedKafkaService.SetReauthenticationBulkCalls())
+func (mock *KafkaServiceMock) SetReauthenticationBulkCalls() []struct {
+	Ids     []string
+	Enabled bool
 } {
 	var calls []struct {
-		Ctx context.Context
-		ID  string
+		Ids     []string
+		Enabled bool
 	}
-	mock.lockRegisterKafkaDeprovisionJob.RLock()
-	calls = mock.calls.RegisterKafkaDeprovisionJob
-	mock.lockRegisterKafkaDeprovisionJob.RUnlock()
+	mock.lockSetReauthenticationBulk.RLock()
+	calls = mock.calls.SetReauthenticationBulk
+	mock.lockSetReauthenticationBulk.RUnlock()
 	return calls
 }
 
-// RegisterKafkaJob calls RegisterKafkaJobFunc.
-func (mock *KafkaServiceMock) RegisterKafkaJob(kafkaRequest *dbapi.KafkaRequest) *apiErrors.ServiceError {
-	if mock.RegisterKafkaJobFunc == nil {
-		panic("KafkaServiceMock.RegisterKafkaJobFunc: method is nil but KafkaService.RegisterKafkaJob was just called")
+// StreamAll calls StreamAllFunc.
+func (mock *KafkaServiceMock) StreamAll(ctx context.Context, batchSize int, fn func(*dbapi.KafkaRequest) error) *apiErrors.ServiceError {
+	if mock.StreamAllFunc == nil {
+		panic("KafkaServiceMock.StreamAllFunc: method is nil but KafkaService.StreamAll was just called")
 	}
 	callInfo := struct {
-		KafkaRequest *dbapi.KafkaRequest
+		Ctx       context.Context
+		BatchSize int
+		Fn        func(*dbapi.KafkaRequest) error
 	}{
-		KafkaRequest: kafkaRequest,
+		Ctx:       ctx,
+		BatchSize: batchSize,
+		Fn:        fn,
 	}
-	mock.lockRegisterKafkaJob.Lock()
-	mock.calls.RegisterKafkaJob = append(mock.calls.RegisterKafkaJob, callInfo)
-	mock.lockRegisterKafkaJob.Unlock()
-	return mock.RegisterKafkaJobFunc(kafkaRequest)
+	mock.lockStreamAll.Lock()
+	mock.calls.StreamAll = append(mock.calls.StreamAll, callInfo)
+	mock.lockStreamAll.Unlock()
+	return mock.StreamAllFunc(ctx, batchSize, fn)
 }
 
-// RegisterKafkaJobCalls gets all the calls that were made to RegisterKafkaJob.
+// StreamAllCalls gets all the calls that were made to StreamAll.
 // Check the length with:
 //
-//	len(mockedKafkaService.RegisterKafkaJobCalls())
-func (mock *KafkaServiceMock) RegisterKafkaJobCalls() []struct {
-	KafkaRequest *dbapi.KafkaRequest
+//	len(mockedKafkaService.StreamAllCalls())
+func (mock *KafkaServiceMock) StreamAllCalls() []struct {
+	Ctx       context.Context
+	BatchSize int
+	Fn        func(*dbapi.KafkaRequest) error
 } {
 	var calls []struct {
-		KafkaRequest *dbapi.KafkaRequest
+		Ctx       context.Context
+		BatchSize int
+		Fn        func(*dbapi.KafkaRequest) error
 	}
-	mock.lockRegisterKafkaJob.RLock()
-	calls = mock.calls.RegisterKafkaJob
-	mock.lockRegisterKafkaJob.RUnlock()
+	mock.lockStreamAll.RLock()
+	calls = mock.calls.StreamAll
+	mock.lockStreamAll.RUnlock()
 	return calls
 }
 
@@ -1191,6 +4214,70 @@ func (mock *KafkaServiceMock) ValidateBillingAccountCalls() []struct {
 	return calls
 }
 
+// ValidateCreateRequest calls ValidateCreateRequestFunc.
+func (mock *KafkaServiceMock) ValidateCreateRequest(kafkaRequest *dbapi.KafkaRequest) *apiErrors.ServiceError {
+	if mock.ValidateCreateRequestFunc == nil {
+		panic("KafkaServiceMock.ValidateCreateRequestFunc: method is nil but KafkaService.ValidateCreateRequest was just called")
+	}
+	callInfo := struct {
+		KafkaRequest *dbapi.KafkaRequest
+	}{
+		KafkaRequest: kafkaRequest,
+	}
+	mock.lockValidateCreateRequest.Lock()
+	mock.calls.ValidateCreateRequest = append(mock.calls.ValidateCreateRequest, callInfo)
+	mock.lockValidateCreateRequest.Unlock()
+	return mock.ValidateCreateRequestFunc(kafkaRequest)
+}
+
+// ValidateCreateRequestCalls gets all the calls that were made to ValidateCreateRequest.
+// Check the length with:
+//
+//	len(mockedKafkaService.ValidateCreateRequestCalls())
+func (mock *KafkaServiceMock) ValidateCreateRequestCalls() []struct {
+	KafkaRequest *dbapi.KafkaRequest
+} {
+	var calls []struct {
+		KafkaRequest *dbapi.KafkaRequest
+	}
+	mock.lockValidateCreateRequest.RLock()
+	calls = mock.calls.ValidateCreateRequest
+	mock.lockValidateCreateRequest.RUnlock()
+	return calls
+}
+
+// ValidateRoutes calls ValidateRoutesFunc.
+func (mock *KafkaServiceMock) ValidateRoutes(routes []dbapi.DataPlaneKafkaRoute) *apiErrors.ServiceError {
+	if mock.ValidateRoutesFunc == nil {
+		panic("KafkaServiceMock.ValidateRoutesFunc: method is nil but KafkaService.ValidateRoutes was just called")
+	}
+	callInfo := struct {
+		Routes []dbapi.DataPlaneKafkaRoute
+	}{
+		Routes: routes,
+	}
+	mock.lockValidateRoutes.Lock()
+	mock.calls.ValidateRoutes = append(mock.calls.ValidateRoutes, callInfo)
+	mock.lockValidateRoutes.Unlock()
+	return mock.ValidateRoutesFunc(routes)
+}
+
+// ValidateRoutesCalls gets all the calls that were made to ValidateRoutes.
+// Check the length with:
+//
+//	len(mockedKafkaService.ValidateRoutesCalls())
+func (mock *KafkaServiceMock) ValidateRoutesCalls() []struct {
+	Routes []dbapi.DataPlaneKafkaRoute
+} {
+	var calls []struct {
+		Routes []dbapi.DataPlaneKafkaRoute
+	}
+	mock.lockValidateRoutes.RLock()
+	calls = mock.calls.ValidateRoutes
+	mock.lockValidateRoutes.RUnlock()
+	return calls
+}
+
 // VerifyAndUpdateKafkaAdmin calls VerifyAndUpdateKafkaAdminFunc.
 func (mock *KafkaServiceMock) VerifyAndUpdateKafkaAdmin(ctx context.Context, kafkaRequest *dbapi.KafkaRequest) *apiErrors.ServiceError {
 	if mock.VerifyAndUpdateKafkaAdminFunc == nil {
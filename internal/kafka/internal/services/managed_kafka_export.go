@@ -0,0 +1,35 @@
+package services
+
+import (
+	"bytes"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	k8sYaml "sigs.k8s.io/yaml"
+)
+
+// managedKafkaYAMLSeparator precedes each ManagedKafka CR in the multi-document YAML
+const managedKafkaYAMLSeparator = "---\n"
+
+func (k *kafkaService) ExportManagedKafkaCRsYAML(clusterID string) ([]byte, *errors.ServiceError) {
+	if clusterID == "" {
+		return nil, errors.Validation("cluster id is undefined")
+	}
+
+	managedKafkas, svcErr := k.GetManagedKafkaByClusterID(clusterID)
+	if svcErr != nil {
+		return nil, svcErr
+	}
+
+	// the CRs are marshalled through their json tags, as they are when sent to the agent
+	var out bytes.Buffer
+	for _, managedKafka := range managedKafkas {
+		doc, err := k8sYaml.Marshal(managedKafka)
+		if err != nil {
+			return nil, errors.NewWithCause(errors.ErrorGeneral, err, "failed to marshal ManagedKafka CR %q of cluster %q", managedKafka.Name, clusterID)
+		}
+		out.WriteString(managedKafkaYAMLSeparator)
+		out.Write(doc)
+	}
+
+	return append([]byte{}, out.Bytes()...), nil
+}
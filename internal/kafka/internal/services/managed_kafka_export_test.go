@@ -0,0 +1,117 @@
+package services
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/config"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/converters"
+	managedkafka "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api/managedkafkas.managedkafka.bf2.org/v1"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/client/keycloak"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/sso"
+	"github.com/onsi/gomega"
+
+	mocket "github.com/selvatico/go-mocket"
+	k8sYaml "sigs.k8s.io/yaml"
+)
+
+func Test_kafkaService_ExportManagedKafkaCRsYAML(t *testing.T) {
+	keycloakService := &sso.KeycloakServiceMock{
+		GetConfigFunc: func() *keycloak.KeycloakConfig {
+			return &keycloak.KeycloakConfig{
+				EnableAuthenticationOnKafka: true,
+			}
+		},
+		GetRealmConfigFunc: func() *keycloak.KeycloakRealmConfig {
+			return &keycloak.KeycloakRealmConfig{}
+		},
+	}
+	kafkaConfig := &config.KafkaConfig{
+		EnableKafkaExternalCertificate: true,
+		SupportedInstanceTypes:         &kafkaSupportedInstanceTypesConfig,
+	}
+	seededKafkas := dbapi.KafkaList{
+		buildKafkaRequest(func(kafkaRequest *dbapi.KafkaRequest) {
+			kafkaRequest.ID = "first-kafka-id"
+			kafkaRequest.Name = "first-kafka"
+		}),
+		buildKafkaRequest(func(kafkaRequest *dbapi.KafkaRequest) {
+			kafkaRequest.ID = "second-kafka-id"
+			kafkaRequest.Name = "second-kafka"
+			kafkaRequest.InstanceType = "developer"
+		}),
+	}
+
+	tests := []struct {
+		name      string
+		clusterID string
+		reply     []map[string]interface{}
+		wantNames []string
+		wantErr   bool
+	}{
+		{
+			name:      "should export a YAML document for each kafka of the cluster",
+			clusterID: testClusterID,
+			reply:     converters.ConvertKafkaRequestList(seededKafkas),
+			wantNames: []string{"first-kafka", "second-kafka"},
+		},
+		{
+			name:      "should return an empty document when there is no kafka on the cluster",
+			clusterID: testClusterID,
+			reply:     []map[string]interface{}{},
+		},
+		{
+			name:    "should return an error when the cluster id is empty",
+			wantErr: true,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			mocket.Catcher.Reset()
+			mocket.Catcher.NewMock().
+				WithQuery(`SELECT * FROM "kafka_requests" WHERE cluster_id = $1`).
+				WithReply(tt.reply)
+			mocket.Catcher.NewMock().WithExecException().WithQueryException()
+
+			k := &kafkaService{
+				connectionFactory: db.NewMockConnectionFactory(nil),
+				keycloakService:   keycloakService,
+				kafkaConfig:       kafkaConfig,
+			}
+			got, err := k.ExportManagedKafkaCRsYAML(tt.clusterID)
+			if tt.wantErr {
+				g.Expect(err).ToNot(gomega.BeNil())
+				return
+			}
+			g.Expect(err).To(gomega.BeNil())
+			if len(tt.wantNames) == 0 {
+				g.Expect(got).To(gomega.BeEmpty())
+				return
+			}
+
+			want, err := k.GetManagedKafkaByClusterID(tt.clusterID)
+			g.Expect(err).To(gomega.BeNil())
+
+			docs := strings.Split(strings.TrimPrefix(string(got), managedKafkaYAMLSeparator), managedKafkaYAMLSeparator)
+			g.Expect(docs).To(gomega.HaveLen(len(tt.wantNames)))
+			for i, doc := range docs {
+				var managedKafka managedkafka.ManagedKafka
+				g.Expect(k8sYaml.UnmarshalStrict([]byte(doc), &managedKafka)).To(gomega.Succeed())
+				g.Expect(managedKafka.Name).To(gomega.Equal(tt.wantNames[i]))
+
+				// the unmarshalled CR is marshalled back to the same document
+				roundTrip, marshalErr := k8sYaml.Marshal(managedKafka)
+				g.Expect(marshalErr).ToNot(gomega.HaveOccurred())
+				g.Expect(string(roundTrip)).To(gomega.Equal(doc))
+				expected, marshalErr := k8sYaml.Marshal(want[i])
+				g.Expect(marshalErr).ToNot(gomega.HaveOccurred())
+				g.Expect(string(roundTrip)).To(gomega.Equal(string(expected)))
+			}
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared/utils/arrays"
+)
+
+// OwnerKafkaSummary summarizes the kafkas the user has access to
+type OwnerKafkaSummary struct {
+	Total         int
+	CountByStatus map[string]int
+	// StreamingUnits is the number of streaming units consumed by the kafkas that are not being deleted
+	StreamingUnits int
+	// NearestExpiry is when the first kafka with a lifespan (e.g. a developer instance) that is not being deleted
+	// expires, it is nil when there is no such kafka
+	NearestExpiry        *time.Time
+	NearestExpiryKafkaId string
+}
+
+func (k *kafkaService) GetOwnerSummary(ctx context.Context) (*OwnerKafkaSummary, *errors.ServiceError) {
+	dbConn, _, svcErr := k.filterByCaller(ctx, k.connectionFactory.New())
+	if svcErr != nil {
+		return nil, svcErr
+	}
+
+	var kafkas []*dbapi.KafkaRequest
+	if err := dbConn.
+		Model(&dbapi.KafkaRequest{}).
+		Select("id", "status", "instance_type", "size_id", "organisation_id", "created_at").
+		Find(&kafkas).Error; err != nil {
+		return nil, errors.NewWithCause(errors.ErrorGeneral, err, "failed to find the kafkas of the user")
+	}
+
+	summary := &OwnerKafkaSummary{
+		Total:         len(kafkas),
+		CountByStatus: map[string]int{},
+	}
+	for _, kafka := range kafkas {
+		summary.CountByStatus[kafka.Status]++
+		if arrays.Contains(kafkaDeletionStatuses, kafka.Status) {
+			continue
+		}
+
+		instanceSize, err := k.kafkaConfig.GetKafkaInstanceSize(kafka.InstanceType, kafka.SizeId)
+		if err != nil {
+			return nil, errors.NewWithCause(errors.ErrorInstancePlanNotSupported, err, "failed to get the size of kafka %q", kafka.ID)
+		}
+		summary.StreamingUnits += instanceSize.QuotaConsumed
+
+		// the organisation of the kafka may have its own lifespan
+		if lifespanSeconds := k.kafkaConfig.KafkaLifespan.GetLifespanSeconds(kafka.OrganisationId, instanceSize.LifespanSeconds); lifespanSeconds != nil {
+			expiresAt := kafka.GetExpirationTime(*lifespanSeconds)
+			if summary.NearestExpiry == nil || expiresAt.Before(*summary.NearestExpiry) {
+				summary.NearestExpiry = expiresAt
+				summary.NearestExpiryKafkaId = kafka.ID
+			}
+		}
+	}
+
+	return summary, nil
+}
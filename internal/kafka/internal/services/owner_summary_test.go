@@ -0,0 +1,127 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	constants2 "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/constants"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/kafkas/types"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/auth"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/onsi/gomega"
+
+	mocket "github.com/selvatico/go-mocket"
+)
+
+func Test_kafkaService_GetOwnerSummary(t *testing.T) {
+	authHelper, err := auth.NewAuthHelper(JwtKeyFile, JwtCAFile, "")
+	if err != nil {
+		t.Fatalf("failed to create auth helper: %s", err.Error())
+	}
+	account, err := authHelper.NewAccount(testUser, "", "", "")
+	if err != nil {
+		t.Fatal("failed to build a new account")
+	}
+	jwt, err := authHelper.CreateJWTWithClaims(account, nil)
+	if err != nil {
+		t.Fatalf("failed to create jwt: %s", err.Error())
+	}
+	authenticatedCtx := auth.SetTokenInContext(context.TODO(), jwt)
+
+	now := time.Now()
+	developerLifespan := time.Duration(*kafkaSupportedInstanceTypesConfig.Configuration.SupportedKafkaInstanceTypes[1].Sizes[0].LifespanSeconds) * time.Second
+	expiringDeveloperCreatedAt := now.Add(-developerLifespan + time.Hour)
+	expiringDeveloperExpiresAt := expiringDeveloperCreatedAt.Add(developerLifespan)
+	kafkaRow := func(id string, instanceType types.KafkaInstanceType, status constants2.KafkaStatus, createdAt time.Time) map[string]interface{} {
+		return map[string]interface{}{
+			"id":            id,
+			"status":        status.String(),
+			"instance_type": instanceType.String(),
+			"size_id":       "x1",
+			"created_at":    createdAt,
+		}
+	}
+
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		reply    []map[string]interface{}
+		queryErr bool
+		want     *OwnerKafkaSummary
+		wantErr  bool
+	}{
+		{
+			name: "should summarize the kafkas of the user with mixed statuses including an expiring developer instance",
+			ctx:  authenticatedCtx,
+			reply: []map[string]interface{}{
+				kafkaRow("standard-ready", types.STANDARD, constants2.KafkaRequestStatusReady, now.Add(-240*time.Hour)),
+				kafkaRow("standard-failed", types.STANDARD, constants2.KafkaRequestStatusFailed, now.Add(-time.Hour)),
+				kafkaRow("standard-deprovision", types.STANDARD, constants2.KafkaRequestStatusDeprovision, now.Add(-time.Hour)),
+				kafkaRow("developer-expiring", types.DEVELOPER, constants2.KafkaRequestStatusReady, expiringDeveloperCreatedAt),
+				kafkaRow("developer-accepted", types.DEVELOPER, constants2.KafkaRequestStatusAccepted, now),
+				// a kafka being deleted does not expire anymore nor consume streaming units
+				kafkaRow("developer-deleting", types.DEVELOPER, constants2.KafkaRequestStatusDeleting, now.Add(-developerLifespan)),
+			},
+			want: &OwnerKafkaSummary{
+				Total: 6,
+				CountByStatus: map[string]int{
+					constants2.KafkaRequestStatusReady.String():       2,
+					constants2.KafkaRequestStatusFailed.String():      1,
+					constants2.KafkaRequestStatusDeprovision.String(): 1,
+					constants2.KafkaRequestStatusAccepted.String():    1,
+					constants2.KafkaRequestStatusDeleting.String():    1,
+				},
+				StreamingUnits:       6,
+				NearestExpiry:        &expiringDeveloperExpiresAt,
+				NearestExpiryKafkaId: "developer-expiring",
+			},
+		},
+		{
+			name:  "should return an empty summary when the user has no kafka",
+			ctx:   authenticatedCtx,
+			reply: []map[string]interface{}{},
+			want: &OwnerKafkaSummary{
+				CountByStatus: map[string]int{},
+			},
+		},
+		{
+			name:    "should return an error when the user is not authenticated",
+			ctx:     context.TODO(),
+			wantErr: true,
+		},
+		{
+			name:     "should return an error when the kafkas cannot be found",
+			ctx:      authenticatedCtx,
+			queryErr: true,
+			wantErr:  true,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			mocket.Catcher.Reset()
+			if !tt.queryErr {
+				mocket.Catcher.NewMock().
+					WithQuery(`FROM "kafka_requests" WHERE owner = $1`).
+					WithArgs(testUser).
+					WithReply(tt.reply)
+			}
+			mocket.Catcher.NewMock().WithExecException().WithQueryException()
+
+			k := &kafkaService{
+				connectionFactory: db.NewMockConnectionFactory(nil),
+				kafkaConfig:       &defaultKafkaConf,
+			}
+			got, err := k.GetOwnerSummary(tt.ctx)
+			if tt.wantErr {
+				g.Expect(err).ToNot(gomega.BeNil())
+				return
+			}
+			g.Expect(err).To(gomega.BeNil())
+			g.Expect(got).To(gomega.Equal(tt.want))
+		})
+	}
+}
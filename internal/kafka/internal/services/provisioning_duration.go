@@ -0,0 +1,92 @@
+package services
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+)
+
+// ProvisioningDurationPercentiles are the percentiles of the time taken by the kafkas of an instance type in a region
+// to become ready after being created
+type ProvisioningDurationPercentiles struct {
+	InstanceType  string
+	CloudProvider string
+	Region        string
+	// Count is the number of kafkas the percentiles are computed from
+	Count int
+	P50   time.Duration
+	P95   time.Duration
+	P99   time.Duration
+}
+
+type kafkaProvisioningTimes struct {
+	InstanceType  string
+	CloudProvider string
+	Region        string
+	CreatedAt     time.Time
+	ProvisionedAt time.Time
+}
+
+func (k *kafkaService) GetProvisioningDuration(from time.Time, to time.Time) ([]ProvisioningDurationPercentiles, *errors.ServiceError) {
+	if from.After(to) {
+		return nil, errors.Validation("the start of the range '%s' must not be after its end '%s'", from.Format(time.RFC3339), to.Format(time.RFC3339))
+	}
+
+	// the kafkas deleted since they have been provisioned are included
+	var times []kafkaProvisioningTimes
+	if err := k.connectionFactory.New().
+		Unscoped().
+		Model(&dbapi.KafkaRequest{}).
+		Select("instance_type", "cloud_provider", "region", "created_at", "provisioned_at").
+		Where("provisioned_at BETWEEN ? AND ?", from, to).
+		Scan(&times).Error; err != nil {
+		return nil, errors.NewWithCause(errors.ErrorGeneral, err, "failed to find the provisioning times of kafkas")
+	}
+
+	durations := map[regionInstanceType][]time.Duration{}
+	for _, t := range times {
+		key := regionInstanceType{cloudProvider: t.CloudProvider, region: t.Region, instanceType: t.InstanceType}
+		durations[key] = append(durations[key], t.ProvisionedAt.Sub(t.CreatedAt))
+	}
+
+	percentiles := make([]ProvisioningDurationPercentiles, 0, len(durations))
+	for key, d := range durations {
+		sort.Slice(d, func(i, j int) bool { return d[i] < d[j] })
+		percentiles = append(percentiles, ProvisioningDurationPercentiles{
+			InstanceType:  key.instanceType,
+			CloudProvider: key.cloudProvider,
+			Region:        key.region,
+			Count:         len(d),
+			P50:           durationPercentile(d, 50),
+			P95:           durationPercentile(d, 95),
+			P99:           durationPercentile(d, 99),
+		})
+	}
+	sort.Slice(percentiles, func(i, j int) bool {
+		if percentiles[i].InstanceType != percentiles[j].InstanceType {
+			return percentiles[i].InstanceType < percentiles[j].InstanceType
+		}
+		if percentiles[i].CloudProvider != percentiles[j].CloudProvider {
+			return percentiles[i].CloudProvider < percentiles[j].CloudProvider
+		}
+		return percentiles[i].Region < percentiles[j].Region
+	})
+
+	return percentiles, nil
+}
+
+// durationPercentile returns the given percentile of the sorted durations using the nearest-rank method, i.e. the
+// smallest duration that is greater than or equal to the given percentage of the durations
+func durationPercentile(sorted []time.Duration, percentile float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := int(math.Ceil(percentile / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
@@ -0,0 +1,106 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/kafkas/types"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/onsi/gomega"
+
+	mocket "github.com/selvatico/go-mocket"
+)
+
+func Test_kafkaService_GetProvisioningDuration(t *testing.T) {
+	to := time.Now()
+	from := to.Add(-24 * time.Hour)
+	provisioningTimes := func(instanceType types.KafkaInstanceType, durations ...time.Duration) []map[string]interface{} {
+		var rows []map[string]interface{}
+		for _, d := range durations {
+			rows = append(rows, map[string]interface{}{
+				"instance_type":  instanceType.String(),
+				"cloud_provider": testKafkaRequestProvider,
+				"region":         testKafkaRequestRegion,
+				"created_at":     to.Add(-d),
+				"provisioned_at": to,
+			})
+		}
+		return rows
+	}
+	var standardDurations []time.Duration
+	for i := 100; i >= 1; i-- {
+		standardDurations = append(standardDurations, time.Duration(i)*time.Minute)
+	}
+
+	tests := []struct {
+		name    string
+		from    time.Time
+		to      time.Time
+		reply   []map[string]interface{}
+		want    []ProvisioningDurationPercentiles
+		wantErr bool
+	}{
+		{
+			name:  "should compute the percentiles of the provisioning durations of each instance type and region",
+			from:  from,
+			to:    to,
+			reply: append(provisioningTimes(types.STANDARD, standardDurations...), provisioningTimes(types.DEVELOPER, 30*time.Minute, 10*time.Minute, 20*time.Minute)...),
+			want: []ProvisioningDurationPercentiles{
+				{
+					InstanceType:  types.DEVELOPER.String(),
+					CloudProvider: testKafkaRequestProvider,
+					Region:        testKafkaRequestRegion,
+					Count:         3,
+					P50:           20 * time.Minute,
+					P95:           30 * time.Minute,
+					P99:           30 * time.Minute,
+				},
+				{
+					InstanceType:  types.STANDARD.String(),
+					CloudProvider: testKafkaRequestProvider,
+					Region:        testKafkaRequestRegion,
+					Count:         100,
+					P50:           50 * time.Minute,
+					P95:           95 * time.Minute,
+					P99:           99 * time.Minute,
+				},
+			},
+		},
+		{
+			name:  "should return no percentiles when no kafka has been provisioned in the window",
+			from:  from,
+			to:    to,
+			reply: []map[string]interface{}{},
+			want:  []ProvisioningDurationPercentiles{},
+		},
+		{
+			name:    "should return an error when the window starts after it ends",
+			from:    to,
+			to:      from,
+			wantErr: true,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			mocket.Catcher.Reset()
+			mocket.Catcher.NewMock().
+				WithQuery(`FROM "kafka_requests" WHERE provisioned_at BETWEEN $1 AND $2`).
+				WithReply(tt.reply)
+			mocket.Catcher.NewMock().WithExecException().WithQueryException()
+
+			k := &kafkaService{
+				connectionFactory: db.NewMockConnectionFactory(nil),
+			}
+			got, err := k.GetProvisioningDuration(tt.from, tt.to)
+			if tt.wantErr {
+				g.Expect(err).ToNot(gomega.BeNil())
+				return
+			}
+			g.Expect(err).To(gomega.BeNil())
+			g.Expect(got).To(gomega.Equal(tt.want))
+		})
+	}
+}
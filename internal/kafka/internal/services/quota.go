@@ -16,4 +16,6 @@ type QuotaService interface {
 	DeleteQuota(subscriptionId string) *errors.ServiceError
 	// ValidateBillingAccount validates if a billing account is contained in the quota cost response
 	ValidateBillingAccount(organisationId string, instanceType types.KafkaInstanceType, billingCloudAccountId string, marketplace *string) *errors.ServiceError
+	// InvalidateBillingAccounts discards the cached billing accounts of an organisation, e.g. after a new billing account is added
+	InvalidateBillingAccounts(organisationId string)
 }
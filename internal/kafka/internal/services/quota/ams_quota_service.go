@@ -2,6 +2,8 @@ package quota
 
 import (
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/dbapi"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/cloudproviders"
@@ -11,11 +13,24 @@ import (
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared/utils/arrays"
 	amsv1 "github.com/openshift-online/ocm-sdk-go/accountsmgmt/v1"
+	"github.com/patrickmn/go-cache"
 )
 
 type amsQuotaService struct {
 	amsClient   ocm.AMSClient
 	kafkaConfig *config.KafkaConfig
+	// billingAccountsCache holds the billing accounts enumerated for an organisation and quota type.
+	// Caching is disabled when nil
+	billingAccountsCache *cache.Cache
+}
+
+const (
+	billingAccountsCacheTTL             = 1 * time.Minute
+	billingAccountsCacheCleanupInterval = 5 * time.Minute
+)
+
+func newBillingAccountsCache() *cache.Cache {
+	return cache.New(billingAccountsCacheTTL, billingAccountsCacheCleanupInterval)
 }
 
 const (
@@ -121,32 +136,74 @@ func (q amsQuotaService) billingModelMatches(computedBillingModel string, reques
 	return false, ""
 }
 
-func (q amsQuotaService) ValidateBillingAccount(organisationId string, instanceType types.KafkaInstanceType, billingCloudAccountId string, marketplace *string) *errors.ServiceError {
+func billingAccountsCacheKey(organisationId string, quotaType ocm.KafkaQuotaType) string {
+	return fmt.Sprintf("%s/%s/%s", organisationId, quotaType.GetResourceName(), quotaType.GetProduct())
+}
+
+// listBillingAccounts returns the cloud accounts of all the quota costs of the given quota type for the
+// organisation with the given external id. The result is cached for a short time
+func (q amsQuotaService) listBillingAccounts(organisationId string, quotaType ocm.KafkaQuotaType) ([]*amsv1.CloudAccount, *errors.ServiceError) {
+	key := billingAccountsCacheKey(organisationId, quotaType)
+	if q.billingAccountsCache != nil {
+		if cached, found := q.billingAccountsCache.Get(key); found {
+			return cached.([]*amsv1.CloudAccount), nil
+		}
+	}
+
 	orgId, err := q.amsClient.GetOrganisationIdFromExternalId(organisationId)
 	if err != nil {
-		return errors.NewWithCause(errors.ErrorGeneral, err, fmt.Sprintf("Error checking quota: failed to get organization with external id %v", organisationId))
+		return nil, errors.NewWithCause(errors.ErrorGeneral, err, fmt.Sprintf("Error checking quota: failed to get organization with external id %v", organisationId))
 	}
 
-	quotaType := instanceType.GetQuotaType()
 	quotaCosts, err := q.amsClient.GetQuotaCostsForProduct(orgId, quotaType.GetResourceName(), quotaType.GetProduct())
 	if err != nil {
-		return errors.NewWithCause(errors.ErrorGeneral, err, fmt.Sprintf("Error checking quota: failed to get assigned quota of type %v for organization with id %v", instanceType.GetQuotaType(), orgId))
+		return nil, errors.NewWithCause(errors.ErrorGeneral, err, fmt.Sprintf("Error checking quota: failed to get assigned quota of type %v for organization with id %v", quotaType, orgId))
+	}
+
+	var billingAccounts []*amsv1.CloudAccount
+	for _, quotaCost := range quotaCosts {
+		billingAccounts = append(billingAccounts, quotaCost.CloudAccounts()...)
+	}
+
+	if q.billingAccountsCache != nil {
+		q.billingAccountsCache.Set(key, billingAccounts, cache.DefaultExpiration)
+	}
+
+	return billingAccounts, nil
+}
+
+// InvalidateBillingAccounts removes the cached billing accounts of the organisation with the given external id
+func (q amsQuotaService) InvalidateBillingAccounts(organisationId string) {
+	if q.billingAccountsCache == nil {
+		return
+	}
+
+	prefix := organisationId + "/"
+	for key := range q.billingAccountsCache.Items() {
+		if strings.HasPrefix(key, prefix) {
+			q.billingAccountsCache.Delete(key)
+		}
+	}
+}
+
+func (q amsQuotaService) ValidateBillingAccount(organisationId string, instanceType types.KafkaInstanceType, billingCloudAccountId string, marketplace *string) *errors.ServiceError {
+	cloudAccounts, serviceErr := q.listBillingAccounts(organisationId, instanceType.GetQuotaType())
+	if serviceErr != nil {
+		return serviceErr
 	}
 
 	var matchingBillingAccounts = 0
 	var billingAccounts []amsv1.CloudAccount
 
-	for _, quotaCost := range quotaCosts {
-		for _, cloudAccount := range quotaCost.CloudAccounts() {
-			billingAccounts = append(billingAccounts, *cloudAccount)
-			if cloudAccount.CloudAccountID() == billingCloudAccountId {
-				if marketplace != nil && *marketplace != cloudAccount.CloudProviderID() {
-					continue
-				}
-
-				// matching billing account found
-				matchingBillingAccounts++
+	for _, cloudAccount := range cloudAccounts {
+		billingAccounts = append(billingAccounts, *cloudAccount)
+		if cloudAccount.CloudAccountID() == billingCloudAccountId {
+			if marketplace != nil && *marketplace != cloudAccount.CloudProviderID() {
+				continue
 			}
+
+			// matching billing account found
+			matchingBillingAccounts++
 		}
 	}
 
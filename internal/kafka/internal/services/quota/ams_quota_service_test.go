@@ -2,6 +2,8 @@ package quota
 
 import (
 	"fmt"
+	"sync"
+	"sync/atomic"
 	"testing"
 
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/dbapi"
@@ -700,6 +702,51 @@ func Test_AMSValidateBillingAccount(t *testing.T) {
 	}
 }
 
+func Test_AMSValidateBillingAccount_CachesBillingAccounts(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	var enumerations int32
+	ocmClient := &ocm.ClientMock{
+		GetOrganisationIdFromExternalIdFunc: ocmClientMockWithCloudAccounts.GetOrganisationIdFromExternalIdFunc,
+		GetQuotaCostsForProductFunc: func(organizationID, resourceName, product string) ([]*v1.QuotaCost, error) {
+			atomic.AddInt32(&enumerations, 1)
+			return ocmClientMockWithCloudAccounts.GetQuotaCostsForProductFunc(organizationID, resourceName, product)
+		},
+	}
+	factory := NewDefaultQuotaServiceFactory(ocmClient, nil, nil, &defaultKafkaConf)
+	quotaService, _ := factory.GetQuotaService(api.AMSQuotaType)
+	marketplace := "aws"
+
+	// the first validation enumerates the billing accounts of the organisation
+	g.Expect(quotaService.ValidateBillingAccount("test", types.STANDARD, "1234567890", &marketplace)).To(gomega.BeNil())
+	g.Expect(atomic.LoadInt32(&enumerations)).To(gomega.Equal(int32(1)))
+
+	// repeated and concurrent validations within the TTL are served from the cache
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			g.Expect(quotaService.ValidateBillingAccount("test", types.STANDARD, "1234567890", &marketplace)).To(gomega.BeNil())
+		}()
+	}
+	wg.Wait()
+	g.Expect(quotaService.ValidateBillingAccount("test", types.STANDARD, "1234567890", &marketplace)).To(gomega.BeNil())
+	g.Expect(atomic.LoadInt32(&enumerations)).To(gomega.Equal(int32(1)))
+
+	// the billing accounts of other organisations are cached separately
+	g.Expect(quotaService.ValidateBillingAccount("other", types.STANDARD, "1234567890", &marketplace)).To(gomega.BeNil())
+	g.Expect(atomic.LoadInt32(&enumerations)).To(gomega.Equal(int32(2)))
+
+	// invalidation forces the billing accounts of the organisation, and only those, to be enumerated again
+	quotaService.InvalidateBillingAccounts("test")
+	g.Expect(quotaService.ValidateBillingAccount("test", types.STANDARD, "1234567890", &marketplace)).To(gomega.BeNil())
+	g.Expect(atomic.LoadInt32(&enumerations)).To(gomega.Equal(int32(3)))
+	g.Expect(quotaService.ValidateBillingAccount("test", types.STANDARD, "1234567890", &marketplace)).To(gomega.BeNil())
+	g.Expect(quotaService.ValidateBillingAccount("other", types.STANDARD, "1234567890", &marketplace)).To(gomega.BeNil())
+	g.Expect(atomic.LoadInt32(&enumerations)).To(gomega.Equal(int32(3)))
+}
+
 func Test_AMSCheckQuota(t *testing.T) {
 	type fields struct {
 		ocmClient   ocm.Client
@@ -22,7 +22,7 @@ func NewDefaultQuotaServiceFactory(
 	kafkaConfig *config.KafkaConfig,
 ) services.QuotaServiceFactory {
 	quotaServiceContainer := map[api.QuotaType]services.QuotaService{
-		api.AMSQuotaType:                 &amsQuotaService{amsClient: amsClient, kafkaConfig: kafkaConfig},
+		api.AMSQuotaType:                 &amsQuotaService{amsClient: amsClient, kafkaConfig: kafkaConfig, billingAccountsCache: newBillingAccountsCache()},
 		api.QuotaManagementListQuotaType: &QuotaManagementListService{connectionFactory: connectionFactory, quotaManagementList: quotaManagementListConfig, kafkaConfig: kafkaConfig},
 	}
 	return &DefaultQuotaServiceFactory{quotaServiceContainer: quotaServiceContainer}
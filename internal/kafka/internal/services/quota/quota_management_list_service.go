@@ -5,6 +5,7 @@ import (
 
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/quota_management"
 
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/constants"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/dbapi"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/config"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/kafkas/types"
@@ -23,6 +24,9 @@ func (q QuotaManagementListService) ValidateBillingAccount(organisationId string
 	return nil
 }
 
+// billing accounts are not used by the quota list, there is nothing to invalidate
+func (q QuotaManagementListService) InvalidateBillingAccounts(organisationId string) {}
+
 func (q QuotaManagementListService) CheckIfQuotaIsDefinedForInstanceType(username string, organisationId string, instanceType types.KafkaInstanceType) (bool, *errors.ServiceError) {
 	orgId := organisationId
 	org, orgFound := q.quotaManagementList.QuotaList.Organisations.GetById(orgId)
@@ -87,8 +91,12 @@ func (q QuotaManagementListService) ReserveQuota(kafka *dbapi.KafkaRequest, inst
 		return "", errors.GeneralError(errMessage)
 	}
 
-	for _, kafka := range kafkas {
-		kafkaInstanceSize, e := q.kafkaConfig.GetKafkaInstanceSize(kafka.InstanceType, kafka.SizeId)
+	for _, k := range kafkas {
+		// a kafka whose quota reservation has been deferred is already persisted, it must not be counted twice
+		if k.ID == kafka.ID && kafka.Status == constants.KafkaRequestStatusPendingQuota.String() {
+			continue
+		}
+		kafkaInstanceSize, e := q.kafkaConfig.GetKafkaInstanceSize(k.InstanceType, k.SizeId)
 		if e != nil {
 			return "", errors.NewWithCause(errors.ErrorGeneral, e, errMessage)
 		}
@@ -26,6 +26,9 @@ var _ QuotaService = &QuotaServiceMock{}
 //			DeleteQuotaFunc: func(subscriptionId string) *apiErrors.ServiceError {
 //				panic("mock out the DeleteQuota method")
 //			},
+//			InvalidateBillingAccountsFunc: func(organisationId string) {
+//				panic("mock out the InvalidateBillingAccounts method")
+//			},
 //			ReserveQuotaFunc: func(kafka *dbapi.KafkaRequest, instanceType types.KafkaInstanceType) (string, *apiErrors.ServiceError) {
 //				panic("mock out the ReserveQuota method")
 //			},
@@ -45,6 +48,9 @@ type QuotaServiceMock struct {
 	// DeleteQuotaFunc mocks the DeleteQuota method.
 	DeleteQuotaFunc func(subscriptionId string) *apiErrors.ServiceError
 
+	// InvalidateBillingAccountsFunc mocks the InvalidateBillingAccounts method.
+	InvalidateBillingAccountsFunc func(organisationId string)
+
 	// ReserveQuotaFunc mocks the ReserveQuota method.
 	ReserveQuotaFunc func(kafka *dbapi.KafkaRequest, instanceType types.KafkaInstanceType) (string, *apiErrors.ServiceError)
 
@@ -67,6 +73,11 @@ type QuotaServiceMock struct {
 			// SubscriptionId is the subscriptionId argument value.
 			SubscriptionId string
 		}
+		// InvalidateBillingAccounts holds details about calls to the InvalidateBillingAccounts method.
+		InvalidateBillingAccounts []struct {
+			// OrganisationId is the organisationId argument value.
+			OrganisationId string
+		}
 		// ReserveQuota holds details about calls to the ReserveQuota method.
 		ReserveQuota []struct {
 			// Kafka is the kafka argument value.
@@ -88,6 +99,7 @@ type QuotaServiceMock struct {
 	}
 	lockCheckIfQuotaIsDefinedForInstanceType sync.RWMutex
 	lockDeleteQuota                          sync.RWMutex
+	lockInvalidateBillingAccounts            sync.RWMutex
 	lockReserveQuota                         sync.RWMutex
 	lockValidateBillingAccount               sync.RWMutex
 }
@@ -164,6 +176,38 @@ func (mock *QuotaServiceMock) DeleteQuotaCalls() []struct {
 	return calls
 }
 
+// InvalidateBillingAccounts calls InvalidateBillingAccountsFunc.
+func (mock *QuotaServiceMock) InvalidateBillingAccounts(organisationId string) {
+	if mock.InvalidateBillingAccountsFunc == nil {
+		panic("QuotaServiceMock.InvalidateBillingAccountsFunc: method is nil but QuotaService.InvalidateBillingAccounts was just called")
+	}
+	callInfo := struct {
+		OrganisationId string
+	}{
+		OrganisationId: organisationId,
+	}
+	mock.lockInvalidateBillingAccounts.Lock()
+	mock.calls.InvalidateBillingAccounts = append(mock.calls.InvalidateBillingAccounts, callInfo)
+	mock.lockInvalidateBillingAccounts.Unlock()
+	mock.InvalidateBillingAccountsFunc(organisationId)
+}
+
+// InvalidateBillingAccountsCalls gets all the calls that were made to InvalidateBillingAccounts.
+// Check the length with:
+//
+//	len(mockedQuotaService.InvalidateBillingAccountsCalls())
+func (mock *QuotaServiceMock) InvalidateBillingAccountsCalls() []struct {
+	OrganisationId string
+} {
+	var calls []struct {
+		OrganisationId string
+	}
+	mock.lockInvalidateBillingAccounts.RLock()
+	calls = mock.calls.InvalidateBillingAccounts
+	mock.lockInvalidateBillingAccounts.RUnlock()
+	return calls
+}
+
 // ReserveQuota calls ReserveQuotaFunc.
 func (mock *QuotaServiceMock) ReserveQuota(kafka *dbapi.KafkaRequest, instanceType types.KafkaInstanceType) (string, *apiErrors.ServiceError) {
 	if mock.ReserveQuotaFunc == nil {
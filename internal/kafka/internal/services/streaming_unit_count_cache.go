@@ -0,0 +1,62 @@
+package services
+
+import (
+	"sync"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/config"
+)
+
+// StreamingUnitCountCache holds the last computed streaming unit counts per cluster and instance type.
+// It is shared by the cluster service, which fills it, and the kafka service, which invalidates it
+// whenever a kafka is created or deleted. A nil cache or a zero TTL disables caching.
+type StreamingUnitCountCache struct {
+	ttl time.Duration
+	now func() time.Time
+
+	mu        sync.Mutex
+	counts    KafkaStreamingUnitCountPerClusterList
+	expiresAt time.Time
+}
+
+func NewStreamingUnitCountCache(kafkaConfig *config.KafkaConfig) *StreamingUnitCountCache {
+	return &StreamingUnitCountCache{
+		ttl: kafkaConfig.StreamingUnitCountCacheTTL,
+		now: time.Now,
+	}
+}
+
+// getOrCompute returns the cached counts if they have not expired yet, otherwise the counts are
+// computed with the given function and cached. The cache is bypassed, but refreshed, if forceRefresh is true
+func (c *StreamingUnitCountCache) getOrCompute(forceRefresh bool, compute func() (KafkaStreamingUnitCountPerClusterList, error)) (KafkaStreamingUnitCountPerClusterList, error) {
+	if c == nil || c.ttl <= 0 {
+		return compute()
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !forceRefresh && c.counts != nil && c.now().Before(c.expiresAt) {
+		return c.counts, nil
+	}
+
+	counts, err := compute()
+	if err != nil {
+		return nil, err
+	}
+	c.counts = counts
+	c.expiresAt = c.now().Add(c.ttl)
+
+	return counts, nil
+}
+
+// Invalidate discards the cached counts so that the next call computes them again
+func (c *StreamingUnitCountCache) Invalidate() {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.counts = nil
+}
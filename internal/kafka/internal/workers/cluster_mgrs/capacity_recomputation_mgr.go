@@ -0,0 +1,70 @@
+package cluster_mgrs
+
+import (
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/config"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/services"
+	fleeterrors "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/workers"
+	"github.com/golang/glog"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+)
+
+const (
+	capacityRecomputationWorkerType = "capacity_recomputation"
+)
+
+// CapacityRecomputationManager represents a worker that periodically materializes the streaming unit counts of the
+// data plane clusters and the capacity consumed in each region, which are read by the capacity metrics and the region
+// capacity checks instead of aggregating all the kafkas on each call.
+type CapacityRecomputationManager struct {
+	workers.BaseWorker
+	clusterService services.ClusterService
+	kafkaService   services.KafkaService
+	kafkaConfig    *config.KafkaConfig
+}
+
+// NewCapacityRecomputationManager creates a new worker that materializes the capacity of the clusters and regions.
+func NewCapacityRecomputationManager(reconciler workers.Reconciler,
+	clusterService services.ClusterService,
+	kafkaService services.KafkaService,
+	kafkaConfig *config.KafkaConfig) *CapacityRecomputationManager {
+	return &CapacityRecomputationManager{
+		BaseWorker: workers.BaseWorker{
+			Id:         uuid.New().String(),
+			WorkerType: capacityRecomputationWorkerType,
+			Reconciler: reconciler,
+		},
+		clusterService: clusterService,
+		kafkaService:   kafkaService,
+		kafkaConfig:    kafkaConfig,
+	}
+}
+
+// Start initializes the worker to materialize the capacity of the clusters and regions.
+func (m *CapacityRecomputationManager) Start() {
+	m.StartWorker(m)
+}
+
+// Stop causes the process for materializing the capacity of the clusters and regions to stop.
+func (m *CapacityRecomputationManager) Stop() {
+	m.StopWorker(m)
+}
+
+func (m *CapacityRecomputationManager) Reconcile() []error {
+	if m.kafkaConfig.CapacityRecomputationMaxAge <= 0 {
+		return nil
+	}
+
+	glog.Infoln("recomputing capacity")
+
+	var errList fleeterrors.ErrorList
+	if err := m.clusterService.RecomputeStreamingUnitCounts(); err != nil {
+		errList.AddErrors(errors.Wrap(err, "failed to recompute the streaming unit counts of the clusters"))
+	}
+	if err := m.kafkaService.RecomputeRegionCapacityUsage(); err != nil {
+		errList.AddErrors(errors.Wrap(err, "failed to recompute the capacity consumed in each region"))
+	}
+
+	return errList.ToErrorSlice()
+}
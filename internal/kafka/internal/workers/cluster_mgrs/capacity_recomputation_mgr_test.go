@@ -0,0 +1,72 @@
+package cluster_mgrs
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/config"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/services"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/workers"
+	"github.com/onsi/gomega"
+
+	apiErrors "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+)
+
+func TestCapacityRecomputationManager_Reconcile(t *testing.T) {
+	tests := []struct {
+		name              string
+		maxAge            time.Duration
+		clusterServiceErr error
+		kafkaServiceErr   *apiErrors.ServiceError
+		wantRecomputed    bool
+		wantErrorsCount   int
+	}{
+		{
+			name:           "should recompute the capacity of the clusters and regions",
+			maxAge:         time.Minute,
+			wantRecomputed: true,
+		},
+		{
+			name:           "should not recompute the capacity when the capacity recomputation is disabled",
+			wantRecomputed: false,
+		},
+		{
+			name:              "should return the errors of the recomputations",
+			maxAge:            time.Minute,
+			clusterServiceErr: errors.New("failed to recompute the streaming unit counts"),
+			kafkaServiceErr:   apiErrors.GeneralError("failed to recompute the capacity consumed in each region"),
+			wantRecomputed:    true,
+			wantErrorsCount:   2,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			clusterService := &services.ClusterServiceMock{
+				RecomputeStreamingUnitCountsFunc: func() error {
+					return tt.clusterServiceErr
+				},
+			}
+			kafkaService := &services.KafkaServiceMock{
+				RecomputeRegionCapacityUsageFunc: func() *apiErrors.ServiceError {
+					return tt.kafkaServiceErr
+				},
+			}
+			m := NewCapacityRecomputationManager(workers.Reconciler{}, clusterService, kafkaService, &config.KafkaConfig{CapacityRecomputationMaxAge: tt.maxAge})
+
+			g.Expect(m.Reconcile()).To(gomega.HaveLen(tt.wantErrorsCount))
+			g.Expect(clusterService.RecomputeStreamingUnitCountsCalls()).To(gomega.HaveLen(boolToCount(tt.wantRecomputed)))
+			g.Expect(kafkaService.RecomputeRegionCapacityUsageCalls()).To(gomega.HaveLen(boolToCount(tt.wantRecomputed)))
+		})
+	}
+}
+
+func boolToCount(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -115,6 +115,8 @@ func (k *AcceptedKafkaManager) reconcileAcceptedKafka(kafka *dbapi.KafkaRequest)
 
 	glog.Infof("Kafka instance with id %s is assigned to cluster with id %s", kafka.ID, kafka.ClusterID)
 	kafka.Status = constants2.KafkaRequestStatusPreparing.String()
+	statusUpdatedAt := time.Now()
+	kafka.StatusUpdatedAt = &statusUpdatedAt
 	if err2 := k.kafkaService.Update(kafka); err2 != nil {
 		return errors.Wrapf(err2, "failed to update kafka %s with cluster details", kafka.ID)
 	}
@@ -122,9 +124,10 @@ func (k *AcceptedKafkaManager) reconcileAcceptedKafka(kafka *dbapi.KafkaRequest)
 }
 
 func (k *AcceptedKafkaManager) markTheUnassignedKafkaAsFailedOrAllowRetryClusterPlacementReconciliation(kafka *dbapi.KafkaRequest) error {
-	durationSinceCreation := time.Since(kafka.CreatedAt)
+	// the duration is counted from when the kafka has been accepted so that a retried failed kafka gets a full retry period
+	durationSinceAccepted := time.Since(kafka.GetStatusUpdatedAt())
 	logger.Logger.Warningf("No available cluster found for Kafka %s instance of size %s in region %s and cloud provider %s", kafka.InstanceType, kafka.SizeId, kafka.Region, kafka.CloudProvider)
-	if durationSinceCreation < constants2.AcceptedKafkaMaxRetryDurationWhileWaitingForClusterAssignment {
+	if durationSinceAccepted < constants2.AcceptedKafkaMaxRetryDurationWhileWaitingForClusterAssignment {
 		return nil
 	}
 	kafka.Status = constants2.KafkaRequestStatusFailed.String()
@@ -132,17 +135,17 @@ func (k *AcceptedKafkaManager) markTheUnassignedKafkaAsFailedOrAllowRetryCluster
 	if err2 := k.kafkaService.Update(kafka); err2 != nil {
 		return errors.Wrapf(err2, "failed to update failed kafka %s", kafka.ID)
 	}
-	metrics.UpdateKafkaRequestsStatusSinceCreatedMetric(constants2.KafkaRequestStatusFailed, kafka.ID, kafka.ClusterID, durationSinceCreation)
+	metrics.UpdateKafkaRequestsStatusSinceCreatedMetric(constants2.KafkaRequestStatusFailed, kafka.ID, kafka.ClusterID, time.Since(kafka.CreatedAt))
 	metrics.IncreaseKafkaTotalOperationsCountMetric(constants2.KafkaOperationCreate)
 	return nil
 }
 
 func (k *AcceptedKafkaManager) markTheAssignedKafkaAsFailedOrAllowRetryStrimziVersionPickingReconciliation(kafka *dbapi.KafkaRequest) error {
-	durationSinceCreation := time.Since(kafka.CreatedAt)
+	durationSinceAccepted := time.Since(kafka.GetStatusUpdatedAt())
 	// Strimzi version may not be available at the start (i.e. during upgrade of Strimzi operator).
 	// We need to allow the reconciler to retry getting and setting of the desired strimzi version for a Kafka request
 	// until the max retry duration is reached before updating its status to 'failed'.
-	if durationSinceCreation < constants2.AcceptedKafkaMaxRetryDurationWhileWaitingForStrimziVersion {
+	if durationSinceAccepted < constants2.AcceptedKafkaMaxRetryDurationWhileWaitingForStrimziVersion {
 		glog.V(10).Infof("No available and ready strimzi version found for Kafka '%s' in Cluster ID '%s'", kafka.ID, kafka.ClusterID)
 		return nil
 	}
@@ -151,7 +154,7 @@ func (k *AcceptedKafkaManager) markTheAssignedKafkaAsFailedOrAllowRetryStrimziVe
 	if err := k.kafkaService.Update(kafka); err != nil {
 		return errors.Wrapf(err, "failed to update failed kafka %s", kafka.ID)
 	}
-	metrics.UpdateKafkaRequestsStatusSinceCreatedMetric(constants2.KafkaRequestStatusFailed, kafka.ID, kafka.ClusterID, durationSinceCreation)
+	metrics.UpdateKafkaRequestsStatusSinceCreatedMetric(constants2.KafkaRequestStatusFailed, kafka.ID, kafka.ClusterID, time.Since(kafka.CreatedAt))
 	metrics.IncreaseKafkaTotalOperationsCountMetric(constants2.KafkaOperationCreate)
 	return nil
 }
@@ -415,6 +415,33 @@ func TestAcceptedKafkaManager_reconcileAcceptedKafka(t *testing.T) {
 			wantClusterID: "",
 			wantStatus:    constants2.KafkaRequestStatusFailed.String(),
 		},
+		{
+			name: "should keep a retried Kafka in accepted status while waiting for cluster assignment since its retry",
+			fields: fields{
+				clusterService: &services.ClusterServiceMock{
+					FindClusterByIDFunc: nil, // make it as nil as it never be called
+				},
+				clusterPlacementStrategy: &services.ClusterPlacementStrategyMock{
+					FindClusterFunc: func(kafka *dbapi.KafkaRequest) (*api.Cluster, error) {
+						return nil, nil
+					},
+				},
+				kafkaService: &services.KafkaServiceMock{
+					UpdateFunc: nil, // make it as nil as it never be called
+				},
+			},
+			args: args{
+				kafka: mockKafkas.BuildKafkaRequest(
+					mockKafkas.WithCreatedAt(time.Now().Add(-2*time.Hour)), // the kafka has been created more than an hour ago
+					mockKafkas.WithStatusUpdatedAt(time.Now()),             // but it has just been moved back to accepted
+					mockKafkas.With(mockKafkas.CLUSTER_ID, ""),
+					mockKafkas.With(mockKafkas.STATUS, constants2.KafkaRequestStatusAccepted.String()),
+				),
+			},
+			wantErr:       false,
+			wantClusterID: "",
+			wantStatus:    constants2.KafkaRequestStatusAccepted.String(),
+		},
 		{
 			name: "should return an error when marking the Kafka as failed returns an error",
 			fields: fields{
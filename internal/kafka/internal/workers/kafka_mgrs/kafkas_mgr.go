@@ -23,6 +23,7 @@ var kafkaMetricsStatuses = []constants2.KafkaStatus{
 	constants2.KafkaRequestStatusPreparing,
 	constants2.KafkaRequestStatusProvisioning,
 	constants2.KafkaRequestStatusReady,
+	constants2.KafkaRequestStatusDeprovisionPending,
 	constants2.KafkaRequestStatusDeprovision,
 	constants2.KafkaRequestStatusDeleting,
 	constants2.KafkaRequestStatusFailed,
@@ -82,6 +83,12 @@ func (k *KafkaManager) Reconcile() []error {
 		encounteredErrors = append(encounteredErrors, capacityError)
 	}
 
+	if k.dataplaneClusterConfig.IsDataPlaneAutoScalingEnabled() {
+		if usageError := k.setClusterStreamingUnitUsageMetrics(); usageError != nil {
+			encounteredErrors = append(encounteredErrors, usageError)
+		}
+	}
+
 	// delete kafkas of denied owners
 	accessControlListConfig := k.accessControlListConfig
 	if accessControlListConfig.EnableDenyList {
@@ -104,6 +111,18 @@ func (k *KafkaManager) Reconcile() []error {
 		}
 	}
 
+	// cleaning up kafkas whose quota has never been confirmed
+	if _, pendingQuotaError := k.kafkaService.DeleteExpiredPendingQuotaKafkas(); pendingQuotaError != nil {
+		wrappedError := errors.Wrap(pendingQuotaError, "failed to delete expired Kafka instances pending quota")
+		encounteredErrors = append(encounteredErrors, wrappedError)
+	}
+
+	// deprovisioning kafkas whose deletion has not been cancelled within the grace period
+	if _, pendingDeletionError := k.kafkaService.PromoteExpiredDeprovisionPendingKafkas(); pendingDeletionError != nil {
+		wrappedError := errors.Wrap(pendingDeletionError, "failed to deprovision Kafka instances pending deletion")
+		encounteredErrors = append(encounteredErrors, wrappedError)
+	}
+
 	return encounteredErrors
 }
 
@@ -129,7 +148,7 @@ func (k *KafkaManager) setKafkaStatusCountMetric() []error {
 }
 
 func (k *KafkaManager) setClusterStatusCapacityMetrics() error {
-	usedStreamingUnitsCountByRegion, err := k.clusterService.FindStreamingUnitCountByClusterAndInstanceType()
+	usedStreamingUnitsCountByRegion, err := k.clusterService.FindCachedStreamingUnitCountByClusterAndInstanceType(false)
 	if err != nil {
 		return errors.Wrap(err, "failed to count Kafkas by region")
 	}
@@ -173,6 +192,38 @@ func (k *KafkaManager) setClusterStatusCapacityMetrics() error {
 
 // calculateAvailableAndMaxCapacityForDynamicScaling takes in used capacity and compute available and max capacity by taking into consideration region limits and dynamic capacity info
 // i.e MaxUnits value. Once the computation is completed, MaxUnits will indicate the maximum capacity which takes in region limits. And Count will indicate available capacity
+// setClusterStreamingUnitUsageMetrics reports, for each ready cluster, the streaming units held by the reserved kafkas
+// alongside the ones consumed by the real kafkas
+func (k *KafkaManager) setClusterStreamingUnitUsageMetrics() error {
+	streamingUnitCounts, err := k.clusterService.FindCachedStreamingUnitCountByClusterAndInstanceType(false)
+	if err != nil {
+		return errors.Wrap(err, "failed to count the streaming units per cluster")
+	}
+
+	reportedClusters := map[string]struct{}{}
+	for _, streamingUnitCount := range streamingUnitCounts {
+		if streamingUnitCount.Status != api.ClusterReady.String() {
+			continue
+		}
+		if _, reported := reportedClusters[streamingUnitCount.ClusterId]; reported {
+			continue
+		}
+		reportedClusters[streamingUnitCount.ClusterId] = struct{}{}
+
+		usage, svcErr := k.kafkaService.GetStreamingUnitUsageByClusterID(streamingUnitCount.ClusterId)
+		if svcErr != nil {
+			return errors.Wrapf(svcErr, "failed to get the streaming unit usage of cluster %s", streamingUnitCount.ClusterId)
+		}
+
+		for _, u := range usage {
+			metrics.UpdateClusterStatusCapacityReservedCount(u.CloudProvider, u.Region, u.InstanceType, u.ClusterID, float64(u.Reserved))
+			metrics.UpdateClusterStatusCapacityUsedCount(u.CloudProvider, u.Region, u.InstanceType, u.ClusterID, float64(u.Used))
+		}
+	}
+
+	return nil
+}
+
 func (k *KafkaManager) calculateAvailableAndMaxCapacityForDynamicScaling(streamingUnitsByRegion services.KafkaStreamingUnitCountPerCluster) (services.KafkaStreamingUnitCountPerCluster, error) {
 	limit, err := k.getRegionInstanceTypeLimit(streamingUnitsByRegion.Region, streamingUnitsByRegion.CloudProvider, streamingUnitsByRegion.InstanceType)
 	if err != nil {
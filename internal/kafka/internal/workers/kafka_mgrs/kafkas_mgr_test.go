@@ -1,13 +1,18 @@
 package kafka_mgrs
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/constants"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/config"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/services"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/acl"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/metrics"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/workers"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 
 	"github.com/onsi/gomega"
 
@@ -40,9 +45,15 @@ func TestKafkaManager_Reconcile(t *testing.T) {
 					DeprovisionExpiredKafkasFunc: func() *errors.ServiceError {
 						return nil
 					},
+					DeleteExpiredPendingQuotaKafkasFunc: func() (int64, *errors.ServiceError) {
+						return 0, nil
+					},
+					PromoteExpiredDeprovisionPendingKafkasFunc: func() (int64, *errors.ServiceError) {
+						return 0, nil
+					},
 				},
 				clusterService: &services.ClusterServiceMock{
-					FindStreamingUnitCountByClusterAndInstanceTypeFunc: func() (services.KafkaStreamingUnitCountPerClusterList, error) {
+					FindCachedStreamingUnitCountByClusterAndInstanceTypeFunc: func(forceRefresh bool) (services.KafkaStreamingUnitCountPerClusterList, error) {
 						return services.KafkaStreamingUnitCountPerClusterList{}, nil
 					},
 				},
@@ -62,9 +73,15 @@ func TestKafkaManager_Reconcile(t *testing.T) {
 					DeprovisionExpiredKafkasFunc: func() *errors.ServiceError {
 						return nil
 					},
+					DeleteExpiredPendingQuotaKafkasFunc: func() (int64, *errors.ServiceError) {
+						return 0, nil
+					},
+					PromoteExpiredDeprovisionPendingKafkasFunc: func() (int64, *errors.ServiceError) {
+						return 0, nil
+					},
 				},
 				clusterService: &services.ClusterServiceMock{
-					FindStreamingUnitCountByClusterAndInstanceTypeFunc: func() (services.KafkaStreamingUnitCountPerClusterList, error) {
+					FindCachedStreamingUnitCountByClusterAndInstanceTypeFunc: func(forceRefresh bool) (services.KafkaStreamingUnitCountPerClusterList, error) {
 						return nil, errors.GeneralError("failed to get kafka streaming unit count kafkas per cluster and instance type")
 					},
 				},
@@ -84,9 +101,15 @@ func TestKafkaManager_Reconcile(t *testing.T) {
 					DeprovisionExpiredKafkasFunc: func() *errors.ServiceError {
 						return errors.GeneralError("failed to deprovision expired kafkas")
 					},
+					DeleteExpiredPendingQuotaKafkasFunc: func() (int64, *errors.ServiceError) {
+						return 0, nil
+					},
+					PromoteExpiredDeprovisionPendingKafkasFunc: func() (int64, *errors.ServiceError) {
+						return 0, nil
+					},
 				},
 				clusterService: &services.ClusterServiceMock{
-					FindStreamingUnitCountByClusterAndInstanceTypeFunc: func() (services.KafkaStreamingUnitCountPerClusterList, error) {
+					FindCachedStreamingUnitCountByClusterAndInstanceTypeFunc: func(forceRefresh bool) (services.KafkaStreamingUnitCountPerClusterList, error) {
 						return services.KafkaStreamingUnitCountPerClusterList{}, nil
 					},
 				},
@@ -98,6 +121,62 @@ func TestKafkaManager_Reconcile(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "should return an error if DeleteExpiredPendingQuotaKafkas returns an error",
+			fields: fields{
+				kafkaService: &services.KafkaServiceMock{
+					CountByStatusFunc: func(status []constants.KafkaStatus) ([]services.KafkaStatusCount, error) {
+						return []services.KafkaStatusCount{}, nil
+					},
+					DeprovisionExpiredKafkasFunc: func() *errors.ServiceError {
+						return nil
+					},
+					DeleteExpiredPendingQuotaKafkasFunc: func() (int64, *errors.ServiceError) {
+						return 0, errors.GeneralError("failed to delete expired kafkas pending quota")
+					},
+					PromoteExpiredDeprovisionPendingKafkasFunc: func() (int64, *errors.ServiceError) {
+						return 0, nil
+					},
+				},
+				clusterService: &services.ClusterServiceMock{
+					FindCachedStreamingUnitCountByClusterAndInstanceTypeFunc: func(forceRefresh bool) (services.KafkaStreamingUnitCountPerClusterList, error) {
+						return services.KafkaStreamingUnitCountPerClusterList{}, nil
+					},
+				},
+				dataplaneClusterConfig:  *config.NewDataplaneClusterConfig(),
+				accessControlListConfig: acl.NewAccessControlListConfig(),
+				kafkaConfig:             *config.NewKafkaConfig(),
+			},
+			wantErr: true,
+		},
+		{
+			name: "should return an error if PromoteExpiredDeprovisionPendingKafkas returns an error",
+			fields: fields{
+				kafkaService: &services.KafkaServiceMock{
+					CountByStatusFunc: func(status []constants.KafkaStatus) ([]services.KafkaStatusCount, error) {
+						return []services.KafkaStatusCount{}, nil
+					},
+					DeprovisionExpiredKafkasFunc: func() *errors.ServiceError {
+						return nil
+					},
+					DeleteExpiredPendingQuotaKafkasFunc: func() (int64, *errors.ServiceError) {
+						return 0, nil
+					},
+					PromoteExpiredDeprovisionPendingKafkasFunc: func() (int64, *errors.ServiceError) {
+						return 0, errors.GeneralError("failed to deprovision kafkas pending deletion")
+					},
+				},
+				clusterService: &services.ClusterServiceMock{
+					FindCachedStreamingUnitCountByClusterAndInstanceTypeFunc: func(forceRefresh bool) (services.KafkaStreamingUnitCountPerClusterList, error) {
+						return services.KafkaStreamingUnitCountPerClusterList{}, nil
+					},
+				},
+				dataplaneClusterConfig:  *config.NewDataplaneClusterConfig(),
+				accessControlListConfig: acl.NewAccessControlListConfig(),
+				kafkaConfig:             *config.NewKafkaConfig(),
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, testcase := range tests {
@@ -252,7 +331,7 @@ func TestKafkaManager_setClusterStatusCapacityMetrics(t *testing.T) {
 			name: "should return an error if CountStreamingUnitByRegionAndInstanceType fails",
 			fields: fields{
 				clusterService: &services.ClusterServiceMock{
-					FindStreamingUnitCountByClusterAndInstanceTypeFunc: func() (services.KafkaStreamingUnitCountPerClusterList, error) {
+					FindCachedStreamingUnitCountByClusterAndInstanceTypeFunc: func(forceRefresh bool) (services.KafkaStreamingUnitCountPerClusterList, error) {
 						return nil, errors.GeneralError("failed to get kafka streaming unit count per cluster and instance type")
 					},
 				},
@@ -263,7 +342,7 @@ func TestKafkaManager_setClusterStatusCapacityMetrics(t *testing.T) {
 			name: "should return an error if calculateCapacityByRegionAndInstanceTypeForManualClusters fails",
 			fields: fields{
 				clusterService: &services.ClusterServiceMock{
-					FindStreamingUnitCountByClusterAndInstanceTypeFunc: func() (services.KafkaStreamingUnitCountPerClusterList, error) {
+					FindCachedStreamingUnitCountByClusterAndInstanceTypeFunc: func(forceRefresh bool) (services.KafkaStreamingUnitCountPerClusterList, error) {
 						return services.KafkaStreamingUnitCountPerClusterList{
 							{
 								Region:        "us-east-1",
@@ -308,7 +387,7 @@ func TestKafkaManager_setClusterStatusCapacityMetrics(t *testing.T) {
 			name: "should return an error if calculateAvailableAndMaxCapacityForDynamicScaling fails",
 			fields: fields{
 				clusterService: &services.ClusterServiceMock{
-					FindStreamingUnitCountByClusterAndInstanceTypeFunc: func() (services.KafkaStreamingUnitCountPerClusterList, error) {
+					FindCachedStreamingUnitCountByClusterAndInstanceTypeFunc: func(forceRefresh bool) (services.KafkaStreamingUnitCountPerClusterList, error) {
 						return services.KafkaStreamingUnitCountPerClusterList{
 							{
 								Region:        "us-east-1",
@@ -348,7 +427,7 @@ func TestKafkaManager_setClusterStatusCapacityMetrics(t *testing.T) {
 			name: "should successfully assign metrics for manual clusters",
 			fields: fields{
 				clusterService: &services.ClusterServiceMock{
-					FindStreamingUnitCountByClusterAndInstanceTypeFunc: func() (services.KafkaStreamingUnitCountPerClusterList, error) {
+					FindCachedStreamingUnitCountByClusterAndInstanceTypeFunc: func(forceRefresh bool) (services.KafkaStreamingUnitCountPerClusterList, error) {
 						return services.KafkaStreamingUnitCountPerClusterList{
 							{
 								Region:        "us-east-1",
@@ -393,7 +472,7 @@ func TestKafkaManager_setClusterStatusCapacityMetrics(t *testing.T) {
 			name: "should successfully assign metrics for autoscaling mode",
 			fields: fields{
 				clusterService: &services.ClusterServiceMock{
-					FindStreamingUnitCountByClusterAndInstanceTypeFunc: func() (services.KafkaStreamingUnitCountPerClusterList, error) {
+					FindCachedStreamingUnitCountByClusterAndInstanceTypeFunc: func(forceRefresh bool) (services.KafkaStreamingUnitCountPerClusterList, error) {
 						return services.KafkaStreamingUnitCountPerClusterList{
 							{
 								Region:        "us-east-1",
@@ -883,3 +962,74 @@ func TestKafkaManager_calculateAvailableAndMaxCapacityForDynamicScaling(t *testi
 		})
 	}
 }
+
+func TestKafkaManager_setClusterStreamingUnitUsageMetrics(t *testing.T) {
+	streamingUnitCounts := services.KafkaStreamingUnitCountPerClusterList{
+		{ClusterId: "ready-cluster", CloudProvider: "aws", Region: "us-east-1", InstanceType: "standard", Count: 3, Status: api.ClusterReady.String()},
+		{ClusterId: "ready-cluster", CloudProvider: "aws", Region: "us-east-1", InstanceType: "developer", Count: 1, Status: api.ClusterReady.String()},
+		{ClusterId: "provisioning-cluster", CloudProvider: "aws", Region: "us-east-1", InstanceType: "standard", Count: 0, Status: api.ClusterProvisioning.String()},
+	}
+
+	tests := []struct {
+		name         string
+		kafkaService services.KafkaService
+		want         string
+		wantErr      bool
+	}{
+		{
+			name: "should report the reserved and the used streaming units of the ready clusters",
+			kafkaService: &services.KafkaServiceMock{
+				GetStreamingUnitUsageByClusterIDFunc: func(clusterID string) ([]services.StreamingUnitUsage, *errors.ServiceError) {
+					if clusterID != "ready-cluster" {
+						return nil, errors.GeneralError("unexpected cluster %s", clusterID)
+					}
+					return []services.StreamingUnitUsage{
+						{ClusterID: clusterID, CloudProvider: "aws", Region: "us-east-1", InstanceType: "standard", Reserved: 2, Used: 3},
+						{ClusterID: clusterID, CloudProvider: "aws", Region: "us-east-1", InstanceType: "developer", Reserved: 1, Used: 1},
+					}, nil
+				},
+			},
+			want: `# HELP kas_fleet_manager_cluster_status_capacity_reserved number of Streaming Units held by reserved instances per region and kafka instance type
+# TYPE kas_fleet_manager_cluster_status_capacity_reserved gauge
+kas_fleet_manager_cluster_status_capacity_reserved{cloud_provider="aws",cluster_id="ready-cluster",instance_type="developer",region="us-east-1"} 1
+kas_fleet_manager_cluster_status_capacity_reserved{cloud_provider="aws",cluster_id="ready-cluster",instance_type="standard",region="us-east-1"} 2
+# HELP kas_fleet_manager_cluster_status_capacity_used number of Streaming Units consumed by existing instances per region and kafka instance type
+# TYPE kas_fleet_manager_cluster_status_capacity_used gauge
+kas_fleet_manager_cluster_status_capacity_used{cloud_provider="aws",cluster_id="ready-cluster",instance_type="developer",region="us-east-1"} 1
+kas_fleet_manager_cluster_status_capacity_used{cloud_provider="aws",cluster_id="ready-cluster",instance_type="standard",region="us-east-1"} 3
+`,
+		},
+		{
+			name: "should return an error if the streaming unit usage of a cluster cannot be retrieved",
+			kafkaService: &services.KafkaServiceMock{
+				GetStreamingUnitUsageByClusterIDFunc: func(clusterID string) ([]services.StreamingUnitUsage, *errors.ServiceError) {
+					return nil, errors.GeneralError("failed to generate reserved kafkas")
+				},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			metrics.Reset()
+			k := &KafkaManager{
+				kafkaService: tt.kafkaService,
+				clusterService: &services.ClusterServiceMock{
+					FindCachedStreamingUnitCountByClusterAndInstanceTypeFunc: func(forceRefresh bool) (services.KafkaStreamingUnitCountPerClusterList, error) {
+						return streamingUnitCounts, nil
+					},
+				},
+			}
+			err := k.setClusterStreamingUnitUsageMetrics()
+			g.Expect(err != nil).To(gomega.Equal(tt.wantErr))
+			if !tt.wantErr {
+				reservedMetric := metrics.KasFleetManager + "_" + metrics.ClusterStatusCapacityReserved
+				usedMetric := metrics.KasFleetManager + "_" + metrics.ClusterStatusCapacityUsed
+				g.Expect(testutil.GatherAndCompare(prometheus.DefaultGatherer, strings.NewReader(tt.want), reservedMetric, usedMetric)).To(gomega.Succeed())
+			}
+		})
+	}
+}
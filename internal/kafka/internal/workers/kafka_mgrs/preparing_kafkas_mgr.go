@@ -81,9 +81,9 @@ func (k *PreparingKafkaManager) reconcilePreparingKafka(kafka *dbapi.KafkaReques
 func (k *PreparingKafkaManager) handleKafkaRequestCreationError(kafkaRequest *dbapi.KafkaRequest, err *serviceErr.ServiceError) error {
 	if err.IsServerErrorClass() {
 		// retry the kafka creation request only if the failure is caused by server errors
-		// and the time elapsed since its db record was created is still within the threshold.
-		durationSinceCreation := time.Since(kafkaRequest.CreatedAt)
-		if durationSinceCreation > constants2.KafkaMaxDurationWithProvisioningErrs {
+		// and the time elapsed since it has been moved to the preparing status is still within the threshold.
+		durationSincePreparing := time.Since(kafkaRequest.GetStatusUpdatedAt())
+		if durationSincePreparing > constants2.KafkaMaxDurationWithProvisioningErrs {
 			metrics.IncreaseKafkaTotalOperationsCountMetric(constants2.KafkaOperationCreate)
 			kafkaRequest.Status = string(constants2.KafkaRequestStatusFailed)
 			kafkaRequest.FailedReason = err.Reason
@@ -49,6 +49,15 @@ func (k *ProvisioningKafkaManager) Reconcile() []error {
 	glog.Infoln("reconciling kafkas")
 	var encounteredErrors []error
 
+	// kafkas taking longer to be provisioned than the provisioning timeout of their instance type are failed
+	failedKafkas, serviceErr := k.kafkaService.FailStaleProvisioningKafkas()
+	if serviceErr != nil {
+		encounteredErrors = append(encounteredErrors, errors.Wrap(serviceErr, "failed to fail stale provisioning kafkas"))
+	}
+	if len(failedKafkas) > 0 {
+		glog.Infof("failed stale provisioning kafkas count = %d", len(failedKafkas))
+	}
+
 	// handle provisioning kafkas state.
 	// Kafkas in a "provisioning" state means that it is ready to be sent to the KAS Fleetshard Operator for Kafka creation in the data plane cluster.
 	// The update of the Kafka request status from 'provisioning' to another state will be handled by the KAS Fleetshard Operator.
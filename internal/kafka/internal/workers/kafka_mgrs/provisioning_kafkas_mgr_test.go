@@ -30,6 +30,9 @@ func Test_ProvisioningKafkaManager_Reconcile(t *testing.T) {
 			name: "Should throw an error if listing kafkas fails",
 			fields: fields{
 				kafkaService: &services.KafkaServiceMock{
+					FailStaleProvisioningKafkasFunc: func() ([]string, *svcErrors.ServiceError) {
+						return []string{}, nil
+					},
 					ListByStatusFunc: func(status ...constants2.KafkaStatus) ([]*dbapi.KafkaRequest, *svcErrors.ServiceError) {
 						return nil, svcErrors.GeneralError("failed to list kafka requests")
 					},
@@ -37,6 +40,20 @@ func Test_ProvisioningKafkaManager_Reconcile(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "Should throw an error if failing the stale provisioning kafkas fails",
+			fields: fields{
+				kafkaService: &services.KafkaServiceMock{
+					FailStaleProvisioningKafkasFunc: func() ([]string, *svcErrors.ServiceError) {
+						return nil, svcErrors.GeneralError("failed to fail stale provisioning kafkas")
+					},
+					ListByStatusFunc: func(status ...constants2.KafkaStatus) ([]*dbapi.KafkaRequest, *svcErrors.ServiceError) {
+						return []*dbapi.KafkaRequest{}, nil
+					},
+				},
+			},
+			wantErr: true,
+		},
 		{
 			name: "Should throw an error when updating metrics for reassigning kafka returns an error",
 			fields: fields{
@@ -49,6 +66,9 @@ func Test_ProvisioningKafkaManager_Reconcile(t *testing.T) {
 					},
 				},
 				kafkaService: &services.KafkaServiceMock{
+					FailStaleProvisioningKafkasFunc: func() ([]string, *svcErrors.ServiceError) {
+						return []string{}, nil
+					},
 					ListByStatusFunc: func(status ...constants2.KafkaStatus) ([]*dbapi.KafkaRequest, *svcErrors.ServiceError) {
 						return []*dbapi.KafkaRequest{
 							mockKafkas.BuildKafkaRequest(func(kafkaRequest *dbapi.KafkaRequest) {
@@ -71,6 +91,9 @@ func Test_ProvisioningKafkaManager_Reconcile(t *testing.T) {
 			name: "Should not throw an error if listing kafkas returns an empty list",
 			fields: fields{
 				kafkaService: &services.KafkaServiceMock{
+					FailStaleProvisioningKafkasFunc: func() ([]string, *svcErrors.ServiceError) {
+						return []string{}, nil
+					},
 					ListByStatusFunc: func(status ...constants2.KafkaStatus) ([]*dbapi.KafkaRequest, *svcErrors.ServiceError) {
 						return []*dbapi.KafkaRequest{}, nil
 					},
@@ -90,6 +113,9 @@ func Test_ProvisioningKafkaManager_Reconcile(t *testing.T) {
 					},
 				},
 				kafkaService: &services.KafkaServiceMock{
+					FailStaleProvisioningKafkasFunc: func() ([]string, *svcErrors.ServiceError) {
+						return []string{}, nil
+					},
 					ListByStatusFunc: func(status ...constants2.KafkaStatus) ([]*dbapi.KafkaRequest, *svcErrors.ServiceError) {
 						return []*dbapi.KafkaRequest{
 							mockKafkas.BuildKafkaRequest(func(kafkaRequest *dbapi.KafkaRequest) {
@@ -2,7 +2,6 @@ package kafka_mgrs
 
 import (
 	"fmt"
-	"strings"
 
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/sso"
 
@@ -77,13 +76,13 @@ func (k *ReadyKafkaManager) Reconcile() []error {
 // This is only meant to be a temporary code, in the future it can be replaced with the service account rotation logic.
 func (k *ReadyKafkaManager) reconcileCanaryServiceAccount(kafkaRequest *dbapi.KafkaRequest) error {
 	if kafkaRequest.CanaryServiceAccountClientID == "" && kafkaRequest.CanaryServiceAccountClientSecret == "" {
-		clientId := strings.ToLower(fmt.Sprintf("%s-%s", services.CanaryServiceAccountPrefix, kafkaRequest.ID))
+		clientId := k.keycloakConfig.CanaryServiceAccountClientID(kafkaRequest.ID)
 		serviceAccountRequest := sso.CompleteServiceAccountRequest{
 			Owner:          kafkaRequest.Owner,
 			OwnerAccountId: kafkaRequest.OwnerAccountId,
 			ClientId:       clientId,
 			OrgId:          kafkaRequest.OrganisationId,
-			Name:           fmt.Sprintf("canary-service-account-for-kafka %s", kafkaRequest.ID),
+			Name:           k.keycloakConfig.CanaryServiceAccountName(kafkaRequest.ID),
 			Description:    fmt.Sprintf("canary service account for kafka %s", kafkaRequest.ID),
 		}
 
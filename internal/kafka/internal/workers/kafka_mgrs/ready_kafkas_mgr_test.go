@@ -206,6 +206,7 @@ func TestReadyKafkaManager_reconcileCanaryServiceAccount(t *testing.T) {
 			k := &ReadyKafkaManager{
 				kafkaService:    tt.fields.kafkaService,
 				keycloakService: tt.fields.keycloakService,
+				keycloakConfig:  keycloak.NewKeycloakConfig(),
 			}
 
 			g.Expect(k.reconcileCanaryServiceAccount(tt.args.kafka) != nil).To(gomega.Equal(tt.wantErr))
@@ -58,6 +58,10 @@ func ConfigProviders() di.Option {
 
 func ServiceProviders() di.Option {
 	return di.Options(
+		di.Provide(services.NewStreamingUnitCountCache),
+		di.Provide(services.NewClusterDNSCache),
+		di.Provide(services.NewKafkaLifecycleEventSink),
+		di.Provide(services.NewKafkaNamespaceAllocator),
 		di.Provide(services.NewClusterService),
 		di.Provide(services.NewKafkaService, di.As(new(services.KafkaService))),
 		di.Provide(services.NewCloudProvidersService),
@@ -76,6 +80,7 @@ func ServiceProviders() di.Option {
 		di.Provide(cluster_mgrs.NewCleanupClustersManager, di.As(new(workers.Worker))),
 		di.Provide(cluster_mgrs.NewDeprovisioningClustersManager, di.As(new(workers.Worker))),
 		di.Provide(cluster_mgrs.NewDynamicScaleDownManager, di.As(new(workers.Worker))),
+		di.Provide(cluster_mgrs.NewCapacityRecomputationManager, di.As(new(workers.Worker))),
 		di.Provide(kafka_mgrs.NewKafkaManager, di.As(new(workers.Worker))),
 		di.Provide(kafka_mgrs.NewAcceptedKafkaManager, di.As(new(workers.Worker))),
 		di.Provide(kafka_mgrs.NewPreparingKafkaManager, di.As(new(workers.Worker))),
@@ -120,6 +120,12 @@ func WithReauthenticationEnabled(enabled bool) KafkaRequestBuildOption {
 	}
 }
 
+func WithReauthenticationUnspecified(unspecified bool) KafkaRequestBuildOption {
+	return func(request *dbapi.KafkaRequest) {
+		request.ReauthenticationUnspecified = unspecified
+	}
+}
+
 func WithDeleted(deleted bool) KafkaRequestBuildOption {
 	return func(request *dbapi.KafkaRequest) {
 		request.Meta.DeletedAt.Valid = deleted
@@ -138,6 +144,12 @@ func WithCreatedAt(createdAt time.Time) KafkaRequestBuildOption {
 	}
 }
 
+func WithStatusUpdatedAt(statusUpdatedAt time.Time) KafkaRequestBuildOption {
+	return func(request *dbapi.KafkaRequest) {
+		request.StatusUpdatedAt = &statusUpdatedAt
+	}
+}
+
 func WithPredefinedTestValues() KafkaRequestBuildOption {
 	return func(request *dbapi.KafkaRequest) {
 		request.Meta = api.Meta{
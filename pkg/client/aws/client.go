@@ -26,6 +26,7 @@ type AWSClient interface {
 	// route53
 	ListHostedZonesByNameInput(dnsName string) (*route53.ListHostedZonesByNameOutput, error)
 	ChangeResourceRecordSets(dnsName string, recordChangeBatch *route53.ChangeBatch) (*route53.ChangeResourceRecordSetsOutput, error)
+	ChangeResourceRecordSetsInHostedZone(hostedZoneId string, recordChangeBatch *route53.ChangeBatch) (*route53.ChangeResourceRecordSetsOutput, error)
 	GetChange(changeId string) (*route53.GetChangeOutput, error)
 }
 
@@ -125,10 +126,12 @@ func (client *awsCl) ChangeResourceRecordSets(dnsName string, recordChangeBatch
 		return nil, fmt.Errorf("No Hosted Zones found")
 	}
 
-	hostedZoneId := zones.HostedZones[0].Id
+	return client.ChangeResourceRecordSetsInHostedZone(*zones.HostedZones[0].Id, recordChangeBatch)
+}
 
+func (client *awsCl) ChangeResourceRecordSetsInHostedZone(hostedZoneId string, recordChangeBatch *route53.ChangeBatch) (*route53.ChangeResourceRecordSetsOutput, error) {
 	recordChanges := &route53.ChangeResourceRecordSetsInput{
-		HostedZoneId: hostedZoneId,
+		HostedZoneId: &hostedZoneId,
 		ChangeBatch:  recordChangeBatch,
 	}
 
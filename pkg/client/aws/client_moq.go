@@ -21,6 +21,9 @@ var _ AWSClient = &AWSClientMock{}
 //			ChangeResourceRecordSetsFunc: func(dnsName string, recordChangeBatch *route53.ChangeBatch) (*route53.ChangeResourceRecordSetsOutput, error) {
 //				panic("mock out the ChangeResourceRecordSets method")
 //			},
+//			ChangeResourceRecordSetsInHostedZoneFunc: func(hostedZoneId string, recordChangeBatch *route53.ChangeBatch) (*route53.ChangeResourceRecordSetsOutput, error) {
+//				panic("mock out the ChangeResourceRecordSetsInHostedZone method")
+//			},
 //			GetChangeFunc: func(changeId string) (*route53.GetChangeOutput, error) {
 //				panic("mock out the GetChange method")
 //			},
@@ -37,6 +40,9 @@ type AWSClientMock struct {
 	// ChangeResourceRecordSetsFunc mocks the ChangeResourceRecordSets method.
 	ChangeResourceRecordSetsFunc func(dnsName string, recordChangeBatch *route53.ChangeBatch) (*route53.ChangeResourceRecordSetsOutput, error)
 
+	// ChangeResourceRecordSetsInHostedZoneFunc mocks the ChangeResourceRecordSetsInHostedZone method.
+	ChangeResourceRecordSetsInHostedZoneFunc func(hostedZoneId string, recordChangeBatch *route53.ChangeBatch) (*route53.ChangeResourceRecordSetsOutput, error)
+
 	// GetChangeFunc mocks the GetChange method.
 	GetChangeFunc func(changeId string) (*route53.GetChangeOutput, error)
 
@@ -52,6 +58,13 @@ type AWSClientMock struct {
 			// RecordChangeBatch is the recordChangeBatch argument value.
 			RecordChangeBatch *route53.ChangeBatch
 		}
+		// ChangeResourceRecordSetsInHostedZone holds details about calls to the ChangeResourceRecordSetsInHostedZone method.
+		ChangeResourceRecordSetsInHostedZone []struct {
+			// HostedZoneId is the hostedZoneId argument value.
+			HostedZoneId string
+			// RecordChangeBatch is the recordChangeBatch argument value.
+			RecordChangeBatch *route53.ChangeBatch
+		}
 		// GetChange holds details about calls to the GetChange method.
 		GetChange []struct {
 			// ChangeId is the changeId argument value.
@@ -63,9 +76,10 @@ type AWSClientMock struct {
 			DnsName string
 		}
 	}
-	lockChangeResourceRecordSets   sync.RWMutex
-	lockGetChange                  sync.RWMutex
-	lockListHostedZonesByNameInput sync.RWMutex
+	lockChangeResourceRecordSets             sync.RWMutex
+	lockChangeResourceRecordSetsInHostedZone sync.RWMutex
+	lockGetChange                            sync.RWMutex
+	lockListHostedZonesByNameInput           sync.RWMutex
 }
 
 // ChangeResourceRecordSets calls ChangeResourceRecordSetsFunc.
@@ -104,6 +118,42 @@ func (mock *AWSClientMock) ChangeResourceRecordSetsCalls() []struct {
 	return calls
 }
 
+// ChangeResourceRecordSetsInHostedZone calls ChangeResourceRecordSetsInHostedZoneFunc.
+func (mock *AWSClientMock) ChangeResourceRecordSetsInHostedZone(hostedZoneId string, recordChangeBatch *route53.ChangeBatch) (*route53.ChangeResourceRecordSetsOutput, error) {
+	if mock.ChangeResourceRecordSetsInHostedZoneFunc == nil {
+		panic("AWSClientMock.ChangeResourceRecordSetsInHostedZoneFunc: method is nil but AWSClient.ChangeResourceRecordSetsInHostedZone was just called")
+	}
+	callInfo := struct {
+		HostedZoneId      string
+		RecordChangeBatch *route53.ChangeBatch
+	}{
+		HostedZoneId:      hostedZoneId,
+		RecordChangeBatch: recordChangeBatch,
+	}
+	mock.lockChangeResourceRecordSetsInHostedZone.Lock()
+	mock.calls.ChangeResourceRecordSetsInHostedZone = append(mock.calls.ChangeResourceRecordSetsInHostedZone, callInfo)
+	mock.lockChangeResourceRecordSetsInHostedZone.Unlock()
+	return mock.ChangeResourceRecordSetsInHostedZoneFunc(hostedZoneId, recordChangeBatch)
+}
+
+// ChangeResourceRecordSetsInHostedZoneCalls gets all the calls that were made to ChangeResourceRecordSetsInHostedZone.
+// Check the length with:
+//
+//	len(mockedAWSClient.ChangeResourceRecordSetsInHostedZoneCalls())
+func (mock *AWSClientMock) ChangeResourceRecordSetsInHostedZoneCalls() []struct {
+	HostedZoneId      string
+	RecordChangeBatch *route53.ChangeBatch
+} {
+	var calls []struct {
+		HostedZoneId      string
+		RecordChangeBatch *route53.ChangeBatch
+	}
+	mock.lockChangeResourceRecordSetsInHostedZone.RLock()
+	calls = mock.calls.ChangeResourceRecordSetsInHostedZone
+	mock.lockChangeResourceRecordSetsInHostedZone.RUnlock()
+	return calls
+}
+
 // GetChange calls GetChangeFunc.
 func (mock *AWSClientMock) GetChange(changeId string) (*route53.GetChangeOutput, error) {
 	if mock.GetChangeFunc == nil {
@@ -3,6 +3,8 @@ package keycloak
 import (
 	"fmt"
 	"os"
+	"regexp"
+	"strings"
 
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/environments"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared"
@@ -17,8 +19,20 @@ const (
 	INTERNAL_SSO_REALM            string = "internal_sso"
 	SSO_SPEICAL_MGMT_ORG_ID_STAGE string = "13640203"
 	//AUTH_SSO SSOProvider ="auth_sso"
+
+	// CanaryServiceAccountKafkaIDPlaceholder is replaced by the kafka id in the canary service account templates
+	CanaryServiceAccountKafkaIDPlaceholder      = "{id}"
+	DefaultCanaryServiceAccountClientIDTemplate = "canary-" + CanaryServiceAccountKafkaIDPlaceholder
+	DefaultCanaryServiceAccountNameTemplate     = "canary-service-account-for-kafka " + CanaryServiceAccountKafkaIDPlaceholder
+
+	// canaryServiceAccountClientIDMaxLength is the maximum length of the client id of a canary service account
+	canaryServiceAccountClientIDMaxLength = 255
+	// sampleKafkaID has the length and characters of the kafka ids, it is used to validate the canary templates
+	sampleKafkaID = "c8a2bqqfhm0bosna5ng0"
 )
 
+var canaryServiceAccountClientIDRegexp = regexp.MustCompile(`^[a-z0-9]([a-z0-9._-]*[a-z0-9])?$`)
+
 type KeycloakConfig struct {
 	EnableAuthenticationOnKafka                bool                 `json:"enable_auth"`
 	BaseURL                                    string               `json:"base_url"`
@@ -40,6 +54,10 @@ type KeycloakConfig struct {
 	SSOSpecialManagementOrgID                  string               `json:"-"`
 	ServiceAccounttLimitCheckSkipOrgIdListFile string               `json:"-"`
 	ServiceAccounttLimitCheckSkipOrgIdList     []string             `json:"-"`
+	// CanaryServiceAccountClientIDTemplate and CanaryServiceAccountNameTemplate are the client id and name of the
+	// canary service account of a kafka, with CanaryServiceAccountKafkaIDPlaceholder replaced by the kafka id
+	CanaryServiceAccountClientIDTemplate string `json:"canary_service_account_client_id_template"`
+	CanaryServiceAccountNameTemplate     string `json:"canary_service_account_name_template"`
 }
 
 type KeycloakRealmConfig struct {
@@ -115,6 +133,8 @@ func NewKeycloakConfig() *KeycloakConfig {
 		SelectSSOProvider:                          MAS_SSO,
 		SSOSpecialManagementOrgID:                  SSO_SPEICAL_MGMT_ORG_ID_STAGE,
 		ServiceAccounttLimitCheckSkipOrgIdListFile: "config/service-account-limits-check-skip-org-id-list.yaml",
+		CanaryServiceAccountClientIDTemplate:       DefaultCanaryServiceAccountClientIDTemplate,
+		CanaryServiceAccountNameTemplate:           DefaultCanaryServiceAccountNameTemplate,
 	}
 	return kc
 }
@@ -145,13 +165,81 @@ func (kc *KeycloakConfig) AddFlags(fs *pflag.FlagSet) {
 	fs.StringVar(&kc.AdminAPISSORealm.BaseURL, "admin-api-sso-base-url", kc.AdminAPISSORealm.BaseURL, "Base url of admin api sso realm, 'https://auth.redhat.com' by default")
 	fs.StringVar(&kc.AdminAPISSORealm.APIEndpointURI, "admin-api-sso-endpoint-uri", kc.AdminAPISSORealm.APIEndpointURI, "API Endpoint URI of admin api sso realm, '/auth/realms/EmployeeIDP' by default")
 	fs.StringVar(&kc.AdminAPISSORealm.Realm, "admin-api-sso-realm", kc.AdminAPISSORealm.Realm, "Admin api sso realm, 'EmployeeIDP' by default")
+	fs.StringVar(&kc.CanaryServiceAccountClientIDTemplate, "canary-service-account-client-id-template", kc.CanaryServiceAccountClientIDTemplate, "Client id of the canary service account of a kafka, '{id}' is replaced by the kafka id. The client id is lower cased")
+	fs.StringVar(&kc.CanaryServiceAccountNameTemplate, "canary-service-account-name-template", kc.CanaryServiceAccountNameTemplate, "Name of the canary service account of a kafka, '{id}' is replaced by the kafka id")
+}
+
+// CanaryServiceAccountClientID returns the client id of the canary service account of the given kafka
+func (kc *KeycloakConfig) CanaryServiceAccountClientID(kafkaID string) string {
+	template := kc.CanaryServiceAccountClientIDTemplate
+	if template == "" {
+		template = DefaultCanaryServiceAccountClientIDTemplate
+	}
+	return strings.ToLower(strings.ReplaceAll(template, CanaryServiceAccountKafkaIDPlaceholder, kafkaID))
+}
+
+// CanaryServiceAccountClientIDPrefix returns the prefix shared by the client ids of all the canary service accounts
+func (kc *KeycloakConfig) CanaryServiceAccountClientIDPrefix() string {
+	template := kc.CanaryServiceAccountClientIDTemplate
+	if template == "" {
+		template = DefaultCanaryServiceAccountClientIDTemplate
+	}
+	return strings.ToLower(strings.SplitN(template, CanaryServiceAccountKafkaIDPlaceholder, 2)[0])
+}
+
+// CanaryServiceAccountKafkaID returns the id of the kafka whose canary service account has the given client id, it
+// returns false if the client id is not the one of a canary service account
+func (kc *KeycloakConfig) CanaryServiceAccountKafkaID(clientID string) (string, bool) {
+	template := kc.CanaryServiceAccountClientIDTemplate
+	if template == "" {
+		template = DefaultCanaryServiceAccountClientIDTemplate
+	}
+	parts := strings.SplitN(strings.ToLower(template), CanaryServiceAccountKafkaIDPlaceholder, 2)
+	if len(parts) != 2 || !strings.HasPrefix(clientID, parts[0]) || !strings.HasSuffix(clientID, parts[1]) ||
+		len(clientID) <= len(parts[0])+len(parts[1]) {
+		return "", false
+	}
+
+	kafkaID := clientID[len(parts[0]) : len(clientID)-len(parts[1])]
+	// the templates with several placeholders are only matched by the client ids of the canary service accounts
+	if kc.CanaryServiceAccountClientID(kafkaID) != clientID {
+		return "", false
+	}
+	return kafkaID, true
+}
+
+// CanaryServiceAccountName returns the name of the canary service account of the given kafka
+func (kc *KeycloakConfig) CanaryServiceAccountName(kafkaID string) string {
+	template := kc.CanaryServiceAccountNameTemplate
+	if template == "" {
+		template = DefaultCanaryServiceAccountNameTemplate
+	}
+	return strings.ReplaceAll(template, CanaryServiceAccountKafkaIDPlaceholder, kafkaID)
+}
+
+// validateCanaryServiceAccountTemplates checks that the templates produce a distinct and legal client id and name
+// for each kafka
+func (kc *KeycloakConfig) validateCanaryServiceAccountTemplates() error {
+	if !strings.Contains(kc.CanaryServiceAccountClientIDTemplate, CanaryServiceAccountKafkaIDPlaceholder) {
+		return fmt.Errorf("canary service account client id template %q must contain %s", kc.CanaryServiceAccountClientIDTemplate, CanaryServiceAccountKafkaIDPlaceholder)
+	}
+	if !strings.Contains(kc.CanaryServiceAccountNameTemplate, CanaryServiceAccountKafkaIDPlaceholder) {
+		return fmt.Errorf("canary service account name template %q must contain %s", kc.CanaryServiceAccountNameTemplate, CanaryServiceAccountKafkaIDPlaceholder)
+	}
+
+	clientID := kc.CanaryServiceAccountClientID(sampleKafkaID)
+	if len(clientID) > canaryServiceAccountClientIDMaxLength || !canaryServiceAccountClientIDRegexp.MatchString(clientID) {
+		return fmt.Errorf("canary service account client id template %q produces the invalid client id %q: it must be at most %d lower case alphanumeric characters, '-', '.' or '_' and start and end with an alphanumeric character",
+			kc.CanaryServiceAccountClientIDTemplate, clientID, canaryServiceAccountClientIDMaxLength)
+	}
+	return nil
 }
 
 func (kc *KeycloakConfig) Validate(env *environments.Env) error {
 	if kc.SelectSSOProvider != REDHAT_SSO && kc.SelectSSOProvider != MAS_SSO {
 		return fmt.Errorf("Invalid sso provider selected must be `mas_sso` or `redhat_sso`")
 	}
-	return nil
+	return kc.validateCanaryServiceAccountTemplates()
 }
 
 func (kc *KeycloakConfig) ReadFiles() error {
@@ -0,0 +1,170 @@
+package keycloak
+
+import (
+	"testing"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/environments"
+	"github.com/onsi/gomega"
+)
+
+func TestKeycloakConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name     string
+		modifyFn func(config *KeycloakConfig)
+		wantErr  bool
+	}{
+		{
+			name:    "should return no error with the default KeycloakConfig",
+			wantErr: false,
+		},
+		{
+			name: "should return an error with an invalid sso provider",
+			modifyFn: func(config *KeycloakConfig) {
+				config.SelectSSOProvider = "invalid"
+			},
+			wantErr: true,
+		},
+		{
+			name: "should return no error with valid canary service account templates",
+			modifyFn: func(config *KeycloakConfig) {
+				config.CanaryServiceAccountClientIDTemplate = "Fleet-A.canary_{id}"
+				config.CanaryServiceAccountNameTemplate = "fleet-a canary {id}"
+			},
+			wantErr: false,
+		},
+		{
+			name: "should return an error when the canary client id template does not contain the kafka id",
+			modifyFn: func(config *KeycloakConfig) {
+				config.CanaryServiceAccountClientIDTemplate = "canary"
+			},
+			wantErr: true,
+		},
+		{
+			name: "should return an error when the canary name template does not contain the kafka id",
+			modifyFn: func(config *KeycloakConfig) {
+				config.CanaryServiceAccountNameTemplate = "canary"
+			},
+			wantErr: true,
+		},
+		{
+			name: "should return an error when the canary client id template produces illegal characters",
+			modifyFn: func(config *KeycloakConfig) {
+				config.CanaryServiceAccountClientIDTemplate = "canary/{id}"
+			},
+			wantErr: true,
+		},
+		{
+			name: "should return an error when the canary client id template produces a client id ending with a separator",
+			modifyFn: func(config *KeycloakConfig) {
+				config.CanaryServiceAccountClientIDTemplate = "{id}-"
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			config := NewKeycloakConfig()
+			if tt.modifyFn != nil {
+				tt.modifyFn(config)
+			}
+			g.Expect(config.Validate(&environments.Env{}) != nil).To(gomega.Equal(tt.wantErr))
+		})
+	}
+}
+
+func TestKeycloakConfig_CanaryServiceAccount(t *testing.T) {
+	tests := []struct {
+		name             string
+		clientIDTemplate string
+		nameTemplate     string
+		wantClientID     string
+		wantName         string
+	}{
+		{
+			name:         "should use the default templates when none is set",
+			wantClientID: "canary-c8a2bqqfhm0bosna5ng0",
+			wantName:     "canary-service-account-for-kafka c8a2bqqfhm0bosna5ng0",
+		},
+		{
+			name:             "should replace the kafka id in the templates and lower case the client id",
+			clientIDTemplate: "Fleet-A-{id}-canary",
+			nameTemplate:     "Fleet A canary for {id}",
+			wantClientID:     "fleet-a-c8a2bqqfhm0bosna5ng0-canary",
+			wantName:         "Fleet A canary for c8a2bqqfhm0bosna5ng0",
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			config := &KeycloakConfig{
+				CanaryServiceAccountClientIDTemplate: tt.clientIDTemplate,
+				CanaryServiceAccountNameTemplate:     tt.nameTemplate,
+			}
+			g.Expect(config.CanaryServiceAccountClientID("c8a2bqqfhm0bosna5ng0")).To(gomega.Equal(tt.wantClientID))
+			g.Expect(config.CanaryServiceAccountName("c8a2bqqfhm0bosna5ng0")).To(gomega.Equal(tt.wantName))
+		})
+	}
+}
+
+func TestKeycloakConfig_CanaryServiceAccountKafkaID(t *testing.T) {
+	tests := []struct {
+		name             string
+		clientIDTemplate string
+		clientID         string
+		wantPrefix       string
+		wantKafkaID      string
+		wantOk           bool
+	}{
+		{
+			name:        "should return the kafka id of a canary client id with the default template",
+			clientID:    "canary-c8a2bqqfhm0bosna5ng0",
+			wantPrefix:  "canary-",
+			wantKafkaID: "c8a2bqqfhm0bosna5ng0",
+			wantOk:      true,
+		},
+		{
+			name:       "should not match a client id that is not a canary one",
+			clientID:   "srvc-acct-c8a2bqqfhm0bosna5ng0",
+			wantPrefix: "canary-",
+			wantOk:     false,
+		},
+		{
+			name:       "should not match the prefix of the canary client ids",
+			clientID:   "canary-",
+			wantPrefix: "canary-",
+			wantOk:     false,
+		},
+		{
+			name:             "should return the kafka id of a canary client id with a configured template",
+			clientIDTemplate: "Fleet-A-{id}-canary",
+			clientID:         "fleet-a-c8a2bqqfhm0bosna5ng0-canary",
+			wantPrefix:       "fleet-a-",
+			wantKafkaID:      "c8a2bqqfhm0bosna5ng0",
+			wantOk:           true,
+		},
+		{
+			name:             "should not match a client id missing the suffix of the template",
+			clientIDTemplate: "fleet-a-{id}-canary",
+			clientID:         "fleet-a-c8a2bqqfhm0bosna5ng0",
+			wantPrefix:       "fleet-a-",
+			wantOk:           false,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			config := &KeycloakConfig{CanaryServiceAccountClientIDTemplate: tt.clientIDTemplate}
+			g.Expect(config.CanaryServiceAccountClientIDPrefix()).To(gomega.Equal(tt.wantPrefix))
+			kafkaID, ok := config.CanaryServiceAccountKafkaID(tt.clientID)
+			g.Expect(ok).To(gomega.Equal(tt.wantOk))
+			g.Expect(kafkaID).To(gomega.Equal(tt.wantKafkaID))
+		})
+	}
+}
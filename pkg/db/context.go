@@ -8,6 +8,7 @@ import (
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/constants"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/logger"
+	"gorm.io/gorm"
 )
 
 // NewContext returns a new context with transaction stored in it.
@@ -91,6 +92,18 @@ func FromContext(ctx context.Context) (*sql.Tx, error) {
 	return transaction.tx, nil
 }
 
+// NewFromContext returns a new database connection running its statements in the transaction stored in the context,
+// so that they are committed or rolled back with the other statements of the transaction when it is resolved
+func (c *ConnectionFactory) NewFromContext(ctx context.Context) (*gorm.DB, error) {
+	tx, err := FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	dbConn := c.New().Session(&gorm.Session{Context: ctx})
+	dbConn.Statement.ConnPool = tx
+	return dbConn, nil
+}
+
 // MarkForRollback flags the transaction stored in the context for rollback and logs whatever error caused the rollback
 func MarkForRollback(ctx context.Context, err error) {
 	ulog := logger.NewUHCLogger(ctx)
@@ -284,6 +284,38 @@ func Test_FromContext(t *testing.T) {
 	}
 }
 
+func Test_NewFromContext(t *testing.T) {
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		wantErr bool
+	}{
+		{
+			name:    "should fail if could not retrieve transaction from context",
+			ctx:     c,
+			wantErr: true,
+		},
+		{
+			name: "should return a connection running its statements in the transaction",
+			ctx:  c2,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			t.Parallel()
+			dbConn, err := mockConn.NewFromContext(tt.ctx)
+			g.Expect(err != nil).To(gomega.Equal(tt.wantErr))
+			if tt.wantErr {
+				return
+			}
+			g.Expect(dbConn.Statement.ConnPool).To(gomega.BeIdenticalTo(tx.tx))
+		})
+	}
+}
+
 func Test_MarkForRollback(t *testing.T) {
 	txF := txFactory{
 		resolved:          true,
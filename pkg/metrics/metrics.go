@@ -30,6 +30,12 @@ const (
 	KafkaOperationsSuccessCount = "kafka_operations_success_count"
 	// KafkaOperationsTotalCount - name of the metric for all Kafka-related operations
 	KafkaOperationsTotalCount = "kafka_operations_total_count"
+	// KafkaOperationsFailureCount - name of the metric for Kafka-related failed operations
+	KafkaOperationsFailureCount = "kafka_operations_failure_count"
+
+	// KafkaQuotaReservationFailuresCount - name of the metric for failed Kafka quota reservations
+	KafkaQuotaReservationFailuresCount = "kafka_quota_reservation_failures_count"
+	labelReason                        = "reason"
 
 	// KafkaRequestsStatus - kafka requests status metric
 	KafkaRequestsStatusSinceCreated = "kafka_requests_status_since_created_in_seconds"
@@ -73,6 +79,9 @@ const (
 	// ClusterStatusCapacityAvailable - metric name for the number of available instances
 	ClusterStatusCapacityAvailable = "cluster_status_capacity_available"
 
+	// ClusterStatusCapacityReserved - metric name for the number of streaming units held by reserved instances
+	ClusterStatusCapacityReserved = "cluster_status_capacity_reserved"
+
 	// ClusterProviderResourceQuotaConsumedProviderResourceQuotaConsumed - metric name for how much quota, given to a user by a cluster provider, is currently used.
 	ClusterProviderResourceQuotaConsumed = "cluster_provider_resource_quota_consumed"
 
@@ -133,6 +142,11 @@ var KafkaOperationsCountMetricsLabels = []string{
 	labelOperation,
 }
 
+// KafkaQuotaReservationFailuresCountMetricsLabels - is the slice of labels to add to the Kafka quota reservation failures count metric
+var KafkaQuotaReservationFailuresCountMetricsLabels = []string{
+	labelReason,
+}
+
 var KafkaPerClusterCountMetricsLabels = []string{
 	LabelClusterID,
 	LabelClusterExternalID,
@@ -264,6 +278,17 @@ func UpdateClusterStatusCapacityAvailableCount(provider string, region, instance
 	clusterStatusCapacityAvailableMetric.With(labels).Set(count)
 }
 
+// UpdateClusterStatusCapacityReservedCount - sets reserved capacity per region and instance type
+func UpdateClusterStatusCapacityReservedCount(provider string, region, instanceType, clusterId string, count float64) {
+	labels := prometheus.Labels{
+		LabelRegion:        region,
+		LabelInstanceType:  instanceType,
+		LabelClusterID:     clusterId,
+		LabelCloudProvider: provider,
+	}
+	clusterStatusCapacityReservedMetric.With(labels).Set(count)
+}
+
 // create a new counterVec for total cluster operation counts
 var clusterOperationsTotalCountMetric = prometheus.NewCounterVec(
 	prometheus.CounterOpts{
@@ -304,6 +329,16 @@ var clusterStatusCapacityAvailableMetric = prometheus.NewGaugeVec(
 	clusterStatusCapacityLabels,
 )
 
+// create a new gauge vec for the number of streaming units held by reserved kafka instances grouped by region and instance type
+var clusterStatusCapacityReservedMetric = prometheus.NewGaugeVec(
+	prometheus.GaugeOpts{
+		Subsystem: KasFleetManager,
+		Name:      ClusterStatusCapacityReserved,
+		Help:      "number of Streaming Units held by reserved instances per region and kafka instance type",
+	},
+	clusterStatusCapacityLabels,
+)
+
 // IncreaseClusterTotalOperationsCountMetric - increase counter for clusterOperationsTotalCountMetric
 func IncreaseClusterTotalOperationsCountMetric(operation constants2.ClusterOperation) {
 	labels := prometheus.Labels{
@@ -528,6 +563,42 @@ func IncreaseKafkaTotalOperationsCountMetric(operation constants2.KafkaOperation
 	kafkaOperationsTotalCountMetric.With(labels).Inc()
 }
 
+// create a new counterVec for failed Kafka operations counts
+var kafkaOperationsFailureCountMetric = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Subsystem: KasFleetManager,
+		Name:      KafkaOperationsFailureCount,
+		Help:      "number of failed kafka operations",
+	},
+	KafkaOperationsCountMetricsLabels,
+)
+
+// IncreaseKafkaFailureOperationsCountMetric - increase counter for the kafkaOperationsFailureCountMetric
+func IncreaseKafkaFailureOperationsCountMetric(operation constants2.KafkaOperation) {
+	labels := prometheus.Labels{
+		labelOperation: operation.String(),
+	}
+	kafkaOperationsFailureCountMetric.With(labels).Inc()
+}
+
+// KafkaQuotaReservationFailuresCountMetric - counter of the failed Kafka quota reservations by reason
+var KafkaQuotaReservationFailuresCountMetric = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Subsystem: KasFleetManager,
+		Name:      KafkaQuotaReservationFailuresCount,
+		Help:      "number of failed kafka quota reservations by reason",
+	},
+	KafkaQuotaReservationFailuresCountMetricsLabels,
+)
+
+// IncreaseKafkaQuotaReservationFailuresCountMetric - increase counter for the KafkaQuotaReservationFailuresCountMetric
+func IncreaseKafkaQuotaReservationFailuresCountMetric(reason string) {
+	labels := prometheus.Labels{
+		labelReason: reason,
+	}
+	KafkaQuotaReservationFailuresCountMetric.With(labels).Inc()
+}
+
 // #### Metrics for Kafkas - End ####
 
 // #### Metrics for Reconcilers - Start ####
@@ -793,6 +864,7 @@ func init() {
 	prometheus.MustRegister(clusterStatusCapacityMaxMetric)
 	prometheus.MustRegister(clusterStatusCapacityUsedMetric)
 	prometheus.MustRegister(clusterStatusCapacityAvailableMetric)
+	prometheus.MustRegister(clusterStatusCapacityReservedMetric)
 	prometheus.MustRegister(clusterProviderResourceQuotaConsumedMetric)
 	prometheus.MustRegister(prewarmingStatusInfoCountMetric)
 	prometheus.MustRegister(clusterProviderResourceQuotaMaxAllowedMetric)
@@ -801,8 +873,10 @@ func init() {
 	prometheus.MustRegister(requestKafkaCreationDurationMetric)
 	prometheus.MustRegister(kafkaOperationsSuccessCountMetric)
 	prometheus.MustRegister(kafkaOperationsTotalCountMetric)
+	prometheus.MustRegister(kafkaOperationsFailureCountMetric)
 	prometheus.MustRegister(kafkaStatusSinceCreatedMetric)
 	prometheus.MustRegister(KafkaStatusCountMetric)
+	prometheus.MustRegister(KafkaQuotaReservationFailuresCountMetric)
 
 	// metrics for reconcilers
 	prometheus.MustRegister(reconcilerDurationMetric)
@@ -828,6 +902,7 @@ func ResetMetricsForKafkaManagers() {
 	clusterStatusCapacityUsedMetric.Reset()
 	clusterStatusCapacityAvailableMetric.Reset()
 	clusterStatusCapacityMaxMetric.Reset()
+	clusterStatusCapacityReservedMetric.Reset()
 }
 
 // ResetMetricsForClusterManagers will reset the metrics for the ClusterManager background reconciler
@@ -868,14 +943,17 @@ func Reset() {
 	prewarmingStatusInfoCountMetric.Reset()
 	clusterStatusCapacityUsedMetric.Reset()
 	clusterStatusCapacityAvailableMetric.Reset()
+	clusterStatusCapacityReservedMetric.Reset()
 	clusterProviderResourceQuotaConsumedMetric.Reset()
 	clusterProviderResourceQuotaMaxAllowedMetric.Reset()
 
 	requestKafkaCreationDurationMetric.Reset()
 	kafkaOperationsSuccessCountMetric.Reset()
 	kafkaOperationsTotalCountMetric.Reset()
+	kafkaOperationsFailureCountMetric.Reset()
 	kafkaStatusSinceCreatedMetric.Reset()
 	KafkaStatusCountMetric.Reset()
+	KafkaQuotaReservationFailuresCountMetric.Reset()
 
 	reconcilerDurationMetric.Reset()
 	reconcilerSuccessCountMetric.Reset()
@@ -60,6 +60,9 @@ var _ keycloakServiceInternal = &keycloakServiceInternalMock{}
 //			IsKafkaClientExistFunc: func(accessToken string, clientId string) *errors.ServiceError {
 //				panic("mock out the IsKafkaClientExist method")
 //			},
+//			ListInternalServiceAccountsFunc: func(accessToken string, prefix string) ([]api.ServiceAccount, *errors.ServiceError) {
+//				panic("mock out the ListInternalServiceAccounts method")
+//			},
 //			ListServiceAccFunc: func(accessToken string, ctx context.Context, first int, max int) ([]api.ServiceAccount, *errors.ServiceError) {
 //				panic("mock out the ListServiceAcc method")
 //			},
@@ -121,6 +124,9 @@ type keycloakServiceInternalMock struct {
 	// IsKafkaClientExistFunc mocks the IsKafkaClientExist method.
 	IsKafkaClientExistFunc func(accessToken string, clientId string) *errors.ServiceError
 
+	// ListInternalServiceAccountsFunc mocks the ListInternalServiceAccounts method.
+	ListInternalServiceAccountsFunc func(accessToken string, prefix string) ([]api.ServiceAccount, *errors.ServiceError)
+
 	// ListServiceAccFunc mocks the ListServiceAcc method.
 	ListServiceAccFunc func(accessToken string, ctx context.Context, first int, max int) ([]api.ServiceAccount, *errors.ServiceError)
 
@@ -229,6 +235,13 @@ type keycloakServiceInternalMock struct {
 			// ClientId is the clientId argument value.
 			ClientId string
 		}
+		// ListInternalServiceAccounts holds details about calls to the ListInternalServiceAccounts method.
+		ListInternalServiceAccounts []struct {
+			// AccessToken is the accessToken argument value.
+			AccessToken string
+			// Prefix is the prefix argument value.
+			Prefix string
+		}
 		// ListServiceAcc holds details about calls to the ListServiceAcc method.
 		ListServiceAcc []struct {
 			// AccessToken is the accessToken argument value.
@@ -286,6 +299,7 @@ type keycloakServiceInternalMock struct {
 	lockGetServiceAccountByClientId                         sync.RWMutex
 	lockGetServiceAccountById                               sync.RWMutex
 	lockIsKafkaClientExist                                  sync.RWMutex
+	lockListInternalServiceAccounts                         sync.RWMutex
 	lockListServiceAcc                                      sync.RWMutex
 	lockRegisterClientInSSO                                 sync.RWMutex
 	lockRegisterConnectorFleetshardOperatorServiceAccount   sync.RWMutex
@@ -759,6 +773,42 @@ func (mock *keycloakServiceInternalMock) IsKafkaClientExistCalls() []struct {
 	return calls
 }
 
+// ListInternalServiceAccounts calls ListInternalServiceAccountsFunc.
+func (mock *keycloakServiceInternalMock) ListInternalServiceAccounts(accessToken string, prefix string) ([]api.ServiceAccount, *errors.ServiceError) {
+	if mock.ListInternalServiceAccountsFunc == nil {
+		panic("keycloakServiceInternalMock.ListInternalServiceAccountsFunc: method is nil but keycloakServiceInternal.ListInternalServiceAccounts was just called")
+	}
+	callInfo := struct {
+		AccessToken string
+		Prefix      string
+	}{
+		AccessToken: accessToken,
+		Prefix:      prefix,
+	}
+	mock.lockListInternalServiceAccounts.Lock()
+	mock.calls.ListInternalServiceAccounts = append(mock.calls.ListInternalServiceAccounts, callInfo)
+	mock.lockListInternalServiceAccounts.Unlock()
+	return mock.ListInternalServiceAccountsFunc(accessToken, prefix)
+}
+
+// ListInternalServiceAccountsCalls gets all the calls that were made to ListInternalServiceAccounts.
+// Check the length with:
+//
+//	len(mockedkeycloakServiceInternal.ListInternalServiceAccountsCalls())
+func (mock *keycloakServiceInternalMock) ListInternalServiceAccountsCalls() []struct {
+	AccessToken string
+	Prefix      string
+} {
+	var calls []struct {
+		AccessToken string
+		Prefix      string
+	}
+	mock.lockListInternalServiceAccounts.RLock()
+	calls = mock.calls.ListInternalServiceAccounts
+	mock.lockListInternalServiceAccounts.RUnlock()
+	return calls
+}
+
 // ListServiceAcc calls ListServiceAccFunc.
 func (mock *keycloakServiceInternalMock) ListServiceAcc(accessToken string, ctx context.Context, first int, max int) ([]api.ServiceAccount, *errors.ServiceError) {
 	if mock.ListServiceAccFunc == nil {
@@ -36,6 +36,9 @@ type KeycloakService interface {
 	GetKafkaClientSecret(clientId string) (string, *errors.ServiceError)
 	CreateServiceAccountInternal(request CompleteServiceAccountRequest) (*api.ServiceAccount, *errors.ServiceError)
 	DeleteServiceAccountInternal(clientId string) *errors.ServiceError
+	// ListInternalServiceAccounts returns all the service accounts, regardless of their owner, whose client id or name
+	// starts with the given prefix, e.g. the canary service accounts of the kafkas
+	ListInternalServiceAccounts(prefix string) ([]api.ServiceAccount, *errors.ServiceError)
 }
 
 //go:generate moq -out osd_keycloak_service_moq.go . OSDKeycloakService
@@ -65,6 +68,7 @@ type keycloakServiceInternal interface {
 	GetKafkaClientSecret(accessToken string, clientId string) (string, *errors.ServiceError)
 	CreateServiceAccountInternal(accessToken string, request CompleteServiceAccountRequest) (*api.ServiceAccount, *errors.ServiceError)
 	DeleteServiceAccountInternal(accessToken string, clientId string) *errors.ServiceError
+	ListInternalServiceAccounts(accessToken string, prefix string) ([]api.ServiceAccount, *errors.ServiceError)
 }
 
 func NewKeycloakServiceBuilder() KeycloakServiceBuilderSelector {
@@ -287,6 +287,34 @@ func (kc *masService) DeleteServiceAccountInternal(accessToken string, serviceAc
 	return nil
 }
 
+func (kc *masService) ListInternalServiceAccounts(accessToken string, prefix string) ([]api.ServiceAccount, *errors.ServiceError) {
+	max := kc.kcClient.GetConfig().MaxLimitForGetClients
+	if max <= 0 {
+		max = 100
+	}
+
+	serviceAccounts := []api.ServiceAccount{}
+	for first := 0; ; first += max {
+		clients, err := kc.kcClient.GetClients(accessToken, first, max, "")
+		if err != nil {
+			return nil, errors.NewWithCause(errors.ErrorGeneral, err, "failed to collect internal service accounts")
+		}
+		for _, client := range clients {
+			if !strings.HasPrefix(shared.SafeString(client.ClientID), prefix) {
+				continue
+			}
+			serviceAccounts = append(serviceAccounts, api.ServiceAccount{
+				ID:       shared.SafeString(client.ID),
+				ClientID: shared.SafeString(client.ClientID),
+				Name:     shared.SafeString(client.Name),
+			})
+		}
+		if len(clients) < max {
+			return serviceAccounts, nil
+		}
+	}
+}
+
 func (kc *masService) ResetServiceAccountCredentials(accessToken string, ctx context.Context, id string) (*api.ServiceAccount, *errors.ServiceError) {
 	claims, err := auth.GetClaimsFromContext(ctx)
 	if err != nil { //4xx
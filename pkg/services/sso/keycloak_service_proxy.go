@@ -166,6 +166,14 @@ func (r *keycloakServiceProxy) DeleteServiceAccountInternal(clientId string) *er
 	}
 }
 
+func (r *keycloakServiceProxy) ListInternalServiceAccounts(prefix string) ([]api.ServiceAccount, *errors.ServiceError) {
+	if token, err := r.retrieveToken(); err != nil {
+		return nil, err
+	} else {
+		return r.service.ListInternalServiceAccounts(token, prefix)
+	}
+}
+
 // Utility functions
 
 func (r *keycloakServiceProxy) retrieveToken() (string, *errors.ServiceError) {
@@ -57,6 +57,9 @@ var _ KeycloakService = &KeycloakServiceMock{}
 //			IsKafkaClientExistFunc: func(clientId string) *errors.ServiceError {
 //				panic("mock out the IsKafkaClientExist method")
 //			},
+//			ListInternalServiceAccountsFunc: func(prefix string) ([]api.ServiceAccount, *errors.ServiceError) {
+//				panic("mock out the ListInternalServiceAccounts method")
+//			},
 //			ListServiceAccFunc: func(ctx context.Context, first int, max int) ([]api.ServiceAccount, *errors.ServiceError) {
 //				panic("mock out the ListServiceAcc method")
 //			},
@@ -112,6 +115,9 @@ type KeycloakServiceMock struct {
 	// IsKafkaClientExistFunc mocks the IsKafkaClientExist method.
 	IsKafkaClientExistFunc func(clientId string) *errors.ServiceError
 
+	// ListInternalServiceAccountsFunc mocks the ListInternalServiceAccounts method.
+	ListInternalServiceAccountsFunc func(prefix string) ([]api.ServiceAccount, *errors.ServiceError)
+
 	// ListServiceAccFunc mocks the ListServiceAcc method.
 	ListServiceAccFunc func(ctx context.Context, first int, max int) ([]api.ServiceAccount, *errors.ServiceError)
 
@@ -190,6 +196,11 @@ type KeycloakServiceMock struct {
 			// ClientId is the clientId argument value.
 			ClientId string
 		}
+		// ListInternalServiceAccounts holds details about calls to the ListInternalServiceAccounts method.
+		ListInternalServiceAccounts []struct {
+			// Prefix is the prefix argument value.
+			Prefix string
+		}
 		// ListServiceAcc holds details about calls to the ListServiceAcc method.
 		ListServiceAcc []struct {
 			// Ctx is the ctx argument value.
@@ -229,6 +240,7 @@ type KeycloakServiceMock struct {
 	lockGetServiceAccountByClientId                         sync.RWMutex
 	lockGetServiceAccountById                               sync.RWMutex
 	lockIsKafkaClientExist                                  sync.RWMutex
+	lockListInternalServiceAccounts                         sync.RWMutex
 	lockListServiceAcc                                      sync.RWMutex
 	lockRegisterConnectorFleetshardOperatorServiceAccount   sync.RWMutex
 	lockRegisterKasFleetshardOperatorServiceAccount         sync.RWMutex
@@ -625,6 +637,38 @@ func (mock *KeycloakServiceMock) IsKafkaClientExistCalls() []struct {
 	return calls
 }
 
+// ListInternalServiceAccounts calls ListInternalServiceAccountsFunc.
+func (mock *KeycloakServiceMock) ListInternalServiceAccounts(prefix string) ([]api.ServiceAccount, *errors.ServiceError) {
+	if mock.ListInternalServiceAccountsFunc == nil {
+		panic("KeycloakServiceMock.ListInternalServiceAccountsFunc: method is nil but KeycloakService.ListInternalServiceAccounts was just called")
+	}
+	callInfo := struct {
+		Prefix string
+	}{
+		Prefix: prefix,
+	}
+	mock.lockListInternalServiceAccounts.Lock()
+	mock.calls.ListInternalServiceAccounts = append(mock.calls.ListInternalServiceAccounts, callInfo)
+	mock.lockListInternalServiceAccounts.Unlock()
+	return mock.ListInternalServiceAccountsFunc(prefix)
+}
+
+// ListInternalServiceAccountsCalls gets all the calls that were made to ListInternalServiceAccounts.
+// Check the length with:
+//
+//	len(mockedKeycloakService.ListInternalServiceAccountsCalls())
+func (mock *KeycloakServiceMock) ListInternalServiceAccountsCalls() []struct {
+	Prefix string
+} {
+	var calls []struct {
+		Prefix string
+	}
+	mock.lockListInternalServiceAccounts.RLock()
+	calls = mock.calls.ListInternalServiceAccounts
+	mock.lockListInternalServiceAccounts.RUnlock()
+	return calls
+}
+
 // ListServiceAcc calls ListServiceAccFunc.
 func (mock *KeycloakServiceMock) ListServiceAcc(ctx context.Context, first int, max int) ([]api.ServiceAccount, *errors.ServiceError) {
 	if mock.ListServiceAccFunc == nil {
@@ -60,6 +60,9 @@ var _ OSDKeycloakService = &OSDKeycloakServiceMock{}
 //			IsKafkaClientExistFunc: func(clientId string) *errors.ServiceError {
 //				panic("mock out the IsKafkaClientExist method")
 //			},
+//			ListInternalServiceAccountsFunc: func(prefix string) ([]api.ServiceAccount, *errors.ServiceError) {
+//				panic("mock out the ListInternalServiceAccounts method")
+//			},
 //			ListServiceAccFunc: func(ctx context.Context, first int, max int) ([]api.ServiceAccount, *errors.ServiceError) {
 //				panic("mock out the ListServiceAcc method")
 //			},
@@ -121,6 +124,9 @@ type OSDKeycloakServiceMock struct {
 	// IsKafkaClientExistFunc mocks the IsKafkaClientExist method.
 	IsKafkaClientExistFunc func(clientId string) *errors.ServiceError
 
+	// ListInternalServiceAccountsFunc mocks the ListInternalServiceAccounts method.
+	ListInternalServiceAccountsFunc func(prefix string) ([]api.ServiceAccount, *errors.ServiceError)
+
 	// ListServiceAccFunc mocks the ListServiceAcc method.
 	ListServiceAccFunc func(ctx context.Context, first int, max int) ([]api.ServiceAccount, *errors.ServiceError)
 
@@ -207,6 +213,11 @@ type OSDKeycloakServiceMock struct {
 			// ClientId is the clientId argument value.
 			ClientId string
 		}
+		// ListInternalServiceAccounts holds details about calls to the ListInternalServiceAccounts method.
+		ListInternalServiceAccounts []struct {
+			// Prefix is the prefix argument value.
+			Prefix string
+		}
 		// ListServiceAcc holds details about calls to the ListServiceAcc method.
 		ListServiceAcc []struct {
 			// Ctx is the ctx argument value.
@@ -254,6 +265,7 @@ type OSDKeycloakServiceMock struct {
 	lockGetServiceAccountByClientId                         sync.RWMutex
 	lockGetServiceAccountById                               sync.RWMutex
 	lockIsKafkaClientExist                                  sync.RWMutex
+	lockListInternalServiceAccounts                         sync.RWMutex
 	lockListServiceAcc                                      sync.RWMutex
 	lockRegisterClientInSSO                                 sync.RWMutex
 	lockRegisterConnectorFleetshardOperatorServiceAccount   sync.RWMutex
@@ -683,6 +695,38 @@ func (mock *OSDKeycloakServiceMock) IsKafkaClientExistCalls() []struct {
 	return calls
 }
 
+// ListInternalServiceAccounts calls ListInternalServiceAccountsFunc.
+func (mock *OSDKeycloakServiceMock) ListInternalServiceAccounts(prefix string) ([]api.ServiceAccount, *errors.ServiceError) {
+	if mock.ListInternalServiceAccountsFunc == nil {
+		panic("OSDKeycloakServiceMock.ListInternalServiceAccountsFunc: method is nil but OSDKeycloakService.ListInternalServiceAccounts was just called")
+	}
+	callInfo := struct {
+		Prefix string
+	}{
+		Prefix: prefix,
+	}
+	mock.lockListInternalServiceAccounts.Lock()
+	mock.calls.ListInternalServiceAccounts = append(mock.calls.ListInternalServiceAccounts, callInfo)
+	mock.lockListInternalServiceAccounts.Unlock()
+	return mock.ListInternalServiceAccountsFunc(prefix)
+}
+
+// ListInternalServiceAccountsCalls gets all the calls that were made to ListInternalServiceAccounts.
+// Check the length with:
+//
+//	len(mockedOSDKeycloakService.ListInternalServiceAccountsCalls())
+func (mock *OSDKeycloakServiceMock) ListInternalServiceAccountsCalls() []struct {
+	Prefix string
+} {
+	var calls []struct {
+		Prefix string
+	}
+	mock.lockListInternalServiceAccounts.RLock()
+	calls = mock.calls.ListInternalServiceAccounts
+	mock.lockListInternalServiceAccounts.RUnlock()
+	return calls
+}
+
 // ListServiceAcc calls ListServiceAccFunc.
 func (mock *OSDKeycloakServiceMock) ListServiceAcc(ctx context.Context, first int, max int) ([]api.ServiceAccount, *errors.ServiceError) {
 	if mock.ListServiceAccFunc == nil {
@@ -315,6 +315,26 @@ func (r *redhatssoService) DeleteServiceAccountInternal(accessToken string, clie
 	return r.DeleteServiceAccount(accessToken, context.Background(), clientId)
 }
 
+func (r *redhatssoService) ListInternalServiceAccounts(accessToken string, prefix string) ([]api.ServiceAccount, *errors.ServiceError) {
+	// the client ids are generated by the SSO, the internal service accounts are named after the client id requested
+	serviceAccounts := []api.ServiceAccount{}
+	max := 100
+	for first := 0; ; first += max {
+		accounts, err := r.client.GetServiceAccounts(accessToken, first, max)
+		if err != nil {
+			return nil, errors.NewWithCause(errors.ErrorGeneral, err, "failed to collect internal service accounts")
+		}
+		for i := range accounts {
+			if strings.HasPrefix(shared.SafeString(accounts[i].ClientId), prefix) || strings.HasPrefix(shared.SafeString(accounts[i].Name), prefix) {
+				serviceAccounts = append(serviceAccounts, *convertServiceAccountDataToAPIServiceAccount(&accounts[i]))
+			}
+		}
+		if len(accounts) < max {
+			return serviceAccounts, nil
+		}
+	}
+}
+
 // // utility functions
 func convertServiceAccountDataToAPIServiceAccount(data *serviceaccountsclient.ServiceAccountData) *api.ServiceAccount {
 	return &api.ServiceAccount{